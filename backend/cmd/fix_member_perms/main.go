@@ -28,49 +28,34 @@ func main() {
 	err = db.Transaction(func(tx *gorm.DB) error {
 		// 1. Find all default "Member" roles
 		var memberRoles []model.Role
-		if err := tx.Where("name = ? AND is_default = ?", "Member", true).Find(&memberRoles).Error; err != nil {
+		if err := tx.Where("name = ? AND is_default = ?", model.RoleNameMember, true).Find(&memberRoles).Error; err != nil {
 			return err
 		}
 
 		log.Printf("Found %d default 'Member' roles to update.\n", len(memberRoles))
 
 		for _, role := range memberRoles {
-			// 2. Ensure CONNECT_MEDIA exists
-			var count int64
-			if err := tx.Model(&model.RolePermission{}).
-				Where("role_id = ? AND permission_code = ?", role.ID, "CONNECT_MEDIA").
-				Count(&count).Error; err != nil {
-				return err
-			}
-
-			if count == 0 {
-				log.Printf("Adding CONNECT_MEDIA to role ID %d (Workspace %d)\n", role.ID, role.WorkspaceID)
-				if err := tx.Create(&model.RolePermission{
-					RoleID:         role.ID,
-					PermissionCode: "CONNECT_MEDIA",
-				}).Error; err != nil {
+			// 2. Ensure the canonical member permissions exist (same set new workspaces get)
+			for _, code := range model.MemberRolePermissions {
+				var count int64
+				if err := tx.Model(&model.RolePermission{}).
+					Where("role_id = ? AND permission_code = ?", role.ID, code).
+					Count(&count).Error; err != nil {
 					return err
 				}
-			}
 
-			// 3. Ensure SEND_MESSAGES exists
-			if err := tx.Model(&model.RolePermission{}).
-				Where("role_id = ? AND permission_code = ?", role.ID, "SEND_MESSAGES").
-				Count(&count).Error; err != nil {
-				return err
-			}
-
-			if count == 0 {
-				log.Printf("Adding SEND_MESSAGES to role ID %d (Workspace %d)\n", role.ID, role.WorkspaceID)
-				if err := tx.Create(&model.RolePermission{
-					RoleID:         role.ID,
-					PermissionCode: "SEND_MESSAGES",
-				}).Error; err != nil {
-					return err
+				if count == 0 {
+					log.Printf("Adding %s to role ID %d (Workspace %d)\n", code, role.ID, role.WorkspaceID)
+					if err := tx.Create(&model.RolePermission{
+						RoleID:         role.ID,
+						PermissionCode: code,
+					}).Error; err != nil {
+						return err
+					}
 				}
 			}
 
-			// 4. Remove CONNECT_VOICE if exists
+			// 3. Remove CONNECT_VOICE if exists
 			if err := tx.Where("role_id = ? AND permission_code = ?", role.ID, "CONNECT_VOICE").
 				Delete(&model.RolePermission{}).Error; err != nil {
 				return err
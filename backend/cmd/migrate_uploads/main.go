@@ -0,0 +1,323 @@
+package main
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io/fs"
+	"log"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"realtime-backend/internal/config"
+	"realtime-backend/internal/database"
+	"realtime-backend/internal/model"
+	"realtime-backend/internal/storage"
+)
+
+// Reconciliation report statuses
+const (
+	statusMigrated     = "migrated"
+	statusWouldMigrate = "would_migrate" // -dry-run
+	statusOrphaned     = "orphaned"      // local file no WorkspaceFile row points at
+	statusMissing      = "missing"       // row points at /uploads but the file is gone
+	statusFailed       = "failed"
+)
+
+// reportRow is one line of the reconciliation report
+type reportRow struct {
+	Status      string
+	LocalPath   string
+	FileID      int64
+	WorkspaceID int64
+	S3Key       string
+	FileURL     string
+	Detail      string
+}
+
+type migrator struct {
+	db       *gorm.DB
+	s3       *storage.S3Registry
+	dir      string
+	dryRun   bool
+	deleteOK bool
+
+	regions map[int64]string // workspace ID → data region
+	report  []reportRow
+}
+
+func main() {
+	dir := flag.String("dir", "./uploads", "legacy uploads directory (served at /uploads)")
+	reportPath := flag.String("report", "migrate_uploads_report.csv", "reconciliation report output (CSV)")
+	dryRun := flag.Bool("dry-run", false, "report what would be migrated without uploading or updating rows")
+	deleteLocal := flag.Bool("delete-local", false, "delete local files once they are uploaded and their rows are updated")
+	flag.Parse()
+
+	cfg := config.Load()
+
+	db, err := database.ConnectDB()
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+
+	m := &migrator{
+		db:       db,
+		dir:      *dir,
+		dryRun:   *dryRun,
+		deleteOK: *deleteLocal && !*dryRun,
+		regions:  make(map[int64]string),
+	}
+	if !m.dryRun {
+		registry, err := storage.NewS3Registry(&cfg.S3)
+		if err != nil {
+			log.Fatalf("Failed to initialize S3: %v", err)
+		}
+		m.s3 = registry
+	}
+
+	if err := m.run(); err != nil {
+		log.Fatalf("Migration failed: %v", err)
+	}
+
+	if err := writeReport(*reportPath, m.report); err != nil {
+		log.Fatalf("Failed to write report: %v", err)
+	}
+
+	counts := make(map[string]int)
+	for _, r := range m.report {
+		counts[r.Status]++
+	}
+	log.Printf("Done. migrated=%d would_migrate=%d orphaned=%d missing=%d failed=%d (report: %s)",
+		counts[statusMigrated], counts[statusWouldMigrate], counts[statusOrphaned],
+		counts[statusMissing], counts[statusFailed], *reportPath)
+	if counts[statusFailed] > 0 {
+		os.Exit(1)
+	}
+}
+
+func (m *migrator) run() error {
+	// 1. WorkspaceFile rows still pointing at the legacy static path
+	var files []model.WorkspaceFile
+	if err := m.db.
+		Where("type = ? AND (s3_key IS NULL OR s3_key = '') AND file_url LIKE ?", "FILE", "%/uploads/%").
+		Find(&files).Error; err != nil {
+		return fmt.Errorf("failed to load workspace files: %w", err)
+	}
+
+	rowsByPath := make(map[string][]model.WorkspaceFile)
+	for _, f := range files {
+		rel, ok := uploadsRelativePath(*f.FileURL)
+		if !ok {
+			continue
+		}
+		rowsByPath[rel] = append(rowsByPath[rel], f)
+	}
+	log.Printf("Found %d workspace files referencing /uploads", len(files))
+
+	// 2. Walk the directory and migrate every file a row points at
+	seen := make(map[string]bool)
+	err := filepath.WalkDir(m.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(m.dir, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		seen[rel] = true
+
+		rows := rowsByPath[rel]
+		if len(rows) == 0 {
+			m.report = append(m.report, reportRow{Status: statusOrphaned, LocalPath: rel})
+			return nil
+		}
+
+		allMigrated := true
+		for i := range rows {
+			if !m.migrateFile(path, rel, &rows[i]) {
+				allMigrated = false
+			}
+		}
+		if allMigrated && m.deleteOK {
+			if err := os.Remove(path); err != nil {
+				log.Printf("Failed to delete local file %s: %v", rel, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to walk %s: %w", m.dir, err)
+	}
+
+	// 3. Rows whose local file no longer exists
+	paths := make([]string, 0, len(rowsByPath))
+	for rel := range rowsByPath {
+		paths = append(paths, rel)
+	}
+	sort.Strings(paths)
+	for _, rel := range paths {
+		if seen[rel] {
+			continue
+		}
+		for _, f := range rowsByPath[rel] {
+			m.report = append(m.report, reportRow{
+				Status:      statusMissing,
+				LocalPath:   rel,
+				FileID:      f.ID,
+				WorkspaceID: f.WorkspaceID,
+				FileURL:     *f.FileURL,
+			})
+		}
+	}
+	return nil
+}
+
+// migrateFile uploads one local file for a row and points the row at the new object
+func (m *migrator) migrateFile(path, rel string, f *model.WorkspaceFile) bool {
+	row := reportRow{LocalPath: rel, FileID: f.ID, WorkspaceID: f.WorkspaceID}
+	fail := func(detail string) bool {
+		row.Status = statusFailed
+		row.Detail = detail
+		m.report = append(m.report, row)
+		log.Printf("❌ %s (file %d): %s", rel, f.ID, detail)
+		return false
+	}
+
+	name := f.Name
+	if name == "" {
+		name = filepath.Base(rel)
+	}
+	key := storage.WorkspaceObjectKey(f.WorkspaceID, name)
+	row.S3Key = key
+
+	if m.dryRun {
+		row.Status = statusWouldMigrate
+		m.report = append(m.report, row)
+		return true
+	}
+
+	s3Service, err := m.s3.ForRegion(m.workspaceRegion(f.WorkspaceID))
+	if err != nil {
+		return fail(err.Error())
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return fail(err.Error())
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return fail(err.Error())
+	}
+
+	contentType := detectContentType(file, f)
+	if err := s3Service.PutObject(key, contentType, file, info.Size()); err != nil {
+		return fail(err.Error())
+	}
+
+	fileURL := s3Service.GetPublicURL(key)
+	updates := map[string]interface{}{
+		"file_url": fileURL,
+		"s3_key":   key,
+	}
+	if f.FileSize == nil {
+		updates["file_size"] = info.Size()
+	}
+	if f.MimeType == nil || *f.MimeType == "" {
+		updates["mime_type"] = contentType
+	}
+	if err := m.db.Model(&model.WorkspaceFile{}).Where("id = ?", f.ID).Updates(updates).Error; err != nil {
+		// The object is uploaded but unreferenced; the report keeps its key for cleanup
+		return fail("uploaded but failed to update row: " + err.Error())
+	}
+
+	row.Status = statusMigrated
+	row.FileURL = fileURL
+	m.report = append(m.report, row)
+	log.Printf("✅ %s → %s (file %d)", rel, key, f.ID)
+	return true
+}
+
+// workspaceRegion returns the workspace's data region ("" = default region)
+func (m *migrator) workspaceRegion(workspaceID int64) string {
+	if region, ok := m.regions[workspaceID]; ok {
+		return region
+	}
+	var region string
+	m.db.Model(&model.Workspace{}).
+		Where("id = ?", workspaceID).
+		Select("COALESCE(data_region, '')").
+		Scan(&region)
+	m.regions[workspaceID] = region
+	return region
+}
+
+// uploadsRelativePath extracts the path below /uploads/ from a stored file URL,
+// which may be relative ("/uploads/a.png") or absolute ("http://host/uploads/a.png")
+func uploadsRelativePath(fileURL string) (string, bool) {
+	path := fileURL
+	if u, err := url.Parse(fileURL); err == nil {
+		path = u.Path
+	}
+	idx := strings.Index(path, "/uploads/")
+	if idx < 0 {
+		return "", false
+	}
+	rel := strings.TrimPrefix(path[idx+len("/uploads/"):], "/")
+	if rel == "" || strings.Contains(rel, "..") {
+		return "", false
+	}
+	return rel, true
+}
+
+// detectContentType prefers the stored MIME type, then the extension, then content sniffing
+func detectContentType(file *os.File, f *model.WorkspaceFile) string {
+	if f.MimeType != nil && *f.MimeType != "" {
+		return *f.MimeType
+	}
+	if byExt := mime.TypeByExtension(filepath.Ext(file.Name())); byExt != "" {
+		return byExt
+	}
+
+	buf := make([]byte, 512)
+	n, _ := file.Read(buf)
+	if _, err := file.Seek(0, 0); err != nil {
+		return "application/octet-stream"
+	}
+	return http.DetectContentType(buf[:n])
+}
+
+func writeReport(path string, rows []reportRow) error {
+	out, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	w := csv.NewWriter(out)
+	w.Write([]string{"status", "local_path", "file_id", "workspace_id", "s3_key", "file_url", "detail"})
+	for _, r := range rows {
+		fileID, workspaceID := "", ""
+		if r.FileID != 0 {
+			fileID = fmt.Sprint(r.FileID)
+			workspaceID = fmt.Sprint(r.WorkspaceID)
+		}
+		w.Write([]string{r.Status, r.LocalPath, fileID, workspaceID, r.S3Key, r.FileURL, r.Detail})
+	}
+	w.Flush()
+	return w.Error()
+}
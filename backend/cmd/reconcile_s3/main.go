@@ -0,0 +1,450 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"realtime-backend/internal/config"
+	"realtime-backend/internal/database"
+	"realtime-backend/internal/model"
+	"realtime-backend/internal/storage"
+)
+
+// Reconciliation report statuses
+const (
+	statusOrphaned       = "orphaned"        // object no row points at
+	statusDeleted        = "deleted"         // orphan removed with -delete
+	statusMissing        = "missing"         // row points at an object that is gone
+	statusStaleMultipart = "stale_multipart" // multipart upload never completed or aborted
+	statusAborted        = "aborted"         // stale multipart upload aborted with -delete
+	statusFailed         = "failed"
+)
+
+// recheckBatch bounds the IN (...) list when re-checking orphans before deletion
+const recheckBatch = 500
+
+// reportRow is one line of the reconciliation report
+type reportRow struct {
+	Status       string
+	Region       string
+	S3Key        string
+	Size         int64
+	LastModified time.Time
+	WorkspaceID  int64
+	FileID       int64
+	Detail       string
+}
+
+// objectRef is a database row that points at an S3 object
+type objectRef struct {
+	WorkspaceID int64
+	FileID      int64 // 0 for ZIP jobs
+	ZipJobID    int64
+}
+
+type reconciler struct {
+	db       *gorm.DB
+	s3       *storage.S3Registry
+	cutoff   time.Time
+	deleteOK bool
+
+	regions map[int64]string // workspace ID → data region ("" = default)
+	report  []reportRow
+}
+
+func main() {
+	workspaceID := flag.Int64("workspace", 0, "only reconcile this workspace (0 = every workspace in every region)")
+	grace := flag.Duration("grace", 48*time.Hour, "ignore objects and multipart uploads newer than this (uploads still in flight)")
+	deleteOrphans := flag.Bool("delete", false, "delete orphaned objects and abort stale multipart uploads")
+	reportPath := flag.String("report", "reconcile_s3_report.csv", "reconciliation report output (CSV)")
+	flag.Parse()
+
+	cfg := config.Load()
+
+	db, err := database.ConnectDB()
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+
+	registry, err := storage.NewS3Registry(&cfg.S3)
+	if err != nil {
+		log.Fatalf("Failed to initialize S3: %v", err)
+	}
+
+	r := &reconciler{
+		db:       db,
+		s3:       registry,
+		cutoff:   time.Now().Add(-*grace),
+		deleteOK: *deleteOrphans,
+	}
+	if err := r.loadRegions(); err != nil {
+		log.Fatalf("Failed to load workspace regions: %v", err)
+	}
+
+	ctx := context.Background()
+	if *workspaceID > 0 {
+		region, ok := r.regions[*workspaceID]
+		if !ok {
+			log.Fatalf("Workspace %d not found", *workspaceID)
+		}
+		if region == "" {
+			region = registry.DefaultRegion()
+		}
+		err = r.reconcile(ctx, region, storage.WorkspacePrefix(*workspaceID))
+	} else {
+		for _, region := range registry.Regions() {
+			if err = r.reconcile(ctx, region, "workspaces/"); err != nil {
+				break
+			}
+		}
+	}
+	if err != nil {
+		log.Fatalf("Reconciliation failed: %v", err)
+	}
+
+	if err := writeReport(*reportPath, r.report); err != nil {
+		log.Fatalf("Failed to write report: %v", err)
+	}
+
+	counts := make(map[string]int)
+	var orphanBytes int64
+	for _, row := range r.report {
+		counts[row.Status]++
+		if row.Status == statusOrphaned || row.Status == statusDeleted {
+			orphanBytes += row.Size
+		}
+	}
+	log.Printf("Done. orphaned=%d deleted=%d missing=%d stale_multipart=%d aborted=%d failed=%d orphan_bytes=%d (report: %s)",
+		counts[statusOrphaned], counts[statusDeleted], counts[statusMissing],
+		counts[statusStaleMultipart], counts[statusAborted], counts[statusFailed], orphanBytes, *reportPath)
+	if counts[statusFailed] > 0 {
+		os.Exit(1)
+	}
+}
+
+// loadRegions caches every workspace's data region so missing objects are only
+// reported against the bucket the workspace actually stores into
+func (r *reconciler) loadRegions() error {
+	var rows []struct {
+		ID     int64
+		Region string
+	}
+	err := r.db.Model(&model.Workspace{}).
+		Select("id, COALESCE(data_region, '') AS region").
+		Scan(&rows).Error
+	if err != nil {
+		return err
+	}
+	r.regions = make(map[int64]string, len(rows))
+	for _, row := range rows {
+		r.regions[row.ID] = row.Region
+	}
+	return nil
+}
+
+// reconcile compares one bucket prefix against the database.
+// References are loaded before listing so an object uploaded mid-run can only
+// look orphaned (and is then protected by the grace period and the re-check),
+// never the other way around.
+func (r *reconciler) reconcile(ctx context.Context, region, prefix string) error {
+	svc, err := r.s3.ForRegion(region)
+	if err != nil {
+		return err
+	}
+	log.Printf("Reconciling s3://%s/%s (%s)", svc.Bucket(), prefix, region)
+
+	refs, err := r.loadRefs(prefix)
+	if err != nil {
+		return fmt.Errorf("failed to load object references: %w", err)
+	}
+
+	objects, err := svc.ListObjects(ctx, prefix)
+	if err != nil {
+		return err
+	}
+	thumbnails, err := svc.ListObjects(ctx, "thumbnails/"+prefix)
+	if err != nil {
+		return err
+	}
+	log.Printf("Found %d objects, %d thumbnails, %d referenced keys", len(objects), len(thumbnails), len(refs))
+
+	// 1. Objects (and thumbnails of objects) nothing points at
+	listed := make(map[string]bool, len(objects))
+	var orphans []reportRow
+	for _, obj := range objects {
+		listed[obj.Key] = true
+		if len(refs[obj.Key]) > 0 || obj.LastModified.After(r.cutoff) {
+			continue
+		}
+		orphans = append(orphans, r.objectRow(region, obj, ""))
+	}
+	for _, obj := range thumbnails {
+		source, ok := storage.ThumbnailSourceKey(obj.Key)
+		if !ok || len(refs[source]) > 0 || obj.LastModified.After(r.cutoff) {
+			continue
+		}
+		orphans = append(orphans, r.objectRow(region, obj, "thumbnail"))
+	}
+	r.removeOrphans(svc, region, orphans)
+
+	// 2. Rows whose object no longer exists in the bucket they belong to
+	keys := make([]string, 0, len(refs))
+	for key := range refs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if listed[key] {
+			continue
+		}
+		for _, ref := range refs[key] {
+			if !r.storesIn(ref.WorkspaceID, region) {
+				continue
+			}
+			row := reportRow{Status: statusMissing, Region: region, S3Key: key, WorkspaceID: ref.WorkspaceID, FileID: ref.FileID}
+			if ref.ZipJobID != 0 {
+				row.Detail = fmt.Sprintf("zip job %d", ref.ZipJobID)
+			}
+			r.report = append(r.report, row)
+		}
+	}
+
+	// 3. Multipart uploads that were started but never completed or aborted
+	uploads, err := svc.ListMultipartUploads(ctx, prefix)
+	if err != nil {
+		return err
+	}
+	for _, u := range uploads {
+		if u.Initiated.After(r.cutoff) {
+			continue
+		}
+		row := reportRow{
+			Status:       statusStaleMultipart,
+			Region:       region,
+			S3Key:        u.Key,
+			LastModified: u.Initiated,
+			WorkspaceID:  workspaceFromKey(u.Key),
+			Detail:       "upload " + u.UploadID,
+		}
+		if r.deleteOK {
+			if err := svc.AbortMultipartUpload(ctx, u.Key, u.UploadID); err != nil {
+				row.Status = statusFailed
+				row.Detail = err.Error()
+			} else {
+				row.Status = statusAborted
+			}
+		}
+		r.report = append(r.report, row)
+	}
+	return nil
+}
+
+// loadRefs returns every key under prefix that a workspace file (including
+// trashed ones), a workspace icon or a ZIP job points at. Keys are matched by
+// prefix rather than workspace so a row linking to another workspace's object
+// still counts.
+func (r *reconciler) loadRefs(prefix string) (map[string][]objectRef, error) {
+	refs := make(map[string][]objectRef)
+	pattern := prefix + "%"
+
+	var files []model.WorkspaceFile
+	err := r.db.Unscoped().
+		Select("id", "workspace_id", "s3_key").
+		Where("s3_key LIKE ?", pattern).
+		Find(&files).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range files {
+		refs[*f.S3Key] = append(refs[*f.S3Key], objectRef{WorkspaceID: f.WorkspaceID, FileID: f.ID})
+	}
+
+	var workspaces []model.Workspace
+	err = r.db.Select("id", "icon_key").
+		Where("icon_key LIKE ?", pattern).
+		Find(&workspaces).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, w := range workspaces {
+		refs[*w.IconKey] = append(refs[*w.IconKey], objectRef{WorkspaceID: w.ID})
+	}
+
+	var jobs []model.FileZipJob
+	err = r.db.Select("id", "workspace_id", "s3_key").
+		Where("s3_key LIKE ?", pattern).
+		Find(&jobs).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, j := range jobs {
+		refs[*j.S3Key] = append(refs[*j.S3Key], objectRef{WorkspaceID: j.WorkspaceID, ZipJobID: j.ID})
+	}
+	return refs, nil
+}
+
+// removeOrphans reports orphans and, with -delete, removes the ones that are
+// still unreferenced right before deletion
+func (r *reconciler) removeOrphans(svc *storage.S3Service, region string, orphans []reportRow) {
+	if !r.deleteOK || len(orphans) == 0 {
+		r.report = append(r.report, orphans...)
+		return
+	}
+
+	sources := make([]string, len(orphans))
+	for i, o := range orphans {
+		sources[i] = o.S3Key
+		if o.Detail == "thumbnail" {
+			sources[i], _ = storage.ThumbnailSourceKey(o.S3Key)
+		}
+	}
+	referenced, err := r.referencedKeys(sources)
+	if err != nil {
+		for i := range orphans {
+			orphans[i].Status = statusFailed
+			orphans[i].Detail = "re-check failed: " + err.Error()
+		}
+		r.report = append(r.report, orphans...)
+		return
+	}
+
+	var keys []string
+	var deletable []int
+	for i := range orphans {
+		if referenced[sources[i]] {
+			continue // picked up by a new row since the listing
+		}
+		keys = append(keys, orphans[i].S3Key)
+		deletable = append(deletable, i)
+	}
+
+	err = svc.DeleteFiles(keys)
+	for _, i := range deletable {
+		if err != nil {
+			orphans[i].Status = statusFailed
+			orphans[i].Detail = err.Error()
+		} else {
+			orphans[i].Status = statusDeleted
+		}
+		r.report = append(r.report, orphans[i])
+	}
+	if err == nil {
+		log.Printf("🗑️ Deleted %d orphaned objects from %s", len(keys), region)
+	}
+}
+
+// referencedKeys re-queries which of the given keys are referenced now
+func (r *reconciler) referencedKeys(keys []string) (map[string]bool, error) {
+	referenced := make(map[string]bool)
+	for start := 0; start < len(keys); start += recheckBatch {
+		batch := keys[start:min(start+recheckBatch, len(keys))]
+
+		var found []string
+		err := r.db.Unscoped().Model(&model.WorkspaceFile{}).
+			Where("s3_key IN ?", batch).
+			Pluck("s3_key", &found).Error
+		if err != nil {
+			return nil, err
+		}
+		var zips []string
+		err = r.db.Model(&model.FileZipJob{}).
+			Where("s3_key IN ?", batch).
+			Pluck("s3_key", &zips).Error
+		if err != nil {
+			return nil, err
+		}
+		var icons []string
+		err = r.db.Model(&model.Workspace{}).
+			Where("icon_key IN ?", batch).
+			Pluck("icon_key", &icons).Error
+		if err != nil {
+			return nil, err
+		}
+		found = append(found, zips...)
+		for _, key := range append(found, icons...) {
+			referenced[key] = true
+		}
+	}
+	return referenced, nil
+}
+
+// storesIn reports whether the workspace keeps its files in region
+func (r *reconciler) storesIn(workspaceID int64, region string) bool {
+	wsRegion, ok := r.regions[workspaceID]
+	if !ok {
+		return false
+	}
+	if wsRegion == "" {
+		wsRegion = r.s3.DefaultRegion()
+	}
+	return wsRegion == region
+}
+
+func (r *reconciler) objectRow(region string, obj storage.ObjectInfo, detail string) reportRow {
+	key := obj.Key
+	if source, ok := storage.ThumbnailSourceKey(key); ok {
+		key = source
+	}
+	return reportRow{
+		Status:       statusOrphaned,
+		Region:       region,
+		S3Key:        obj.Key,
+		Size:         obj.Size,
+		LastModified: obj.LastModified,
+		WorkspaceID:  workspaceFromKey(key),
+		Detail:       detail,
+	}
+}
+
+// workspaceFromKey parses the workspace ID out of workspaces/{id}/... (0 if it does not match)
+func workspaceFromKey(key string) int64 {
+	rest, ok := strings.CutPrefix(key, "workspaces/")
+	if !ok {
+		return 0
+	}
+	idPart, _, _ := strings.Cut(rest, "/")
+	id, err := strconv.ParseInt(idPart, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return id
+}
+
+func writeReport(path string, rows []reportRow) error {
+	out, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	w := csv.NewWriter(out)
+	w.Write([]string{"status", "region", "s3_key", "size", "last_modified", "workspace_id", "file_id", "detail"})
+	for _, r := range rows {
+		size, modified, workspaceID, fileID := "", "", "", ""
+		if r.Size > 0 {
+			size = fmt.Sprint(r.Size)
+		}
+		if !r.LastModified.IsZero() {
+			modified = r.LastModified.Format(time.RFC3339)
+		}
+		if r.WorkspaceID != 0 {
+			workspaceID = fmt.Sprint(r.WorkspaceID)
+		}
+		if r.FileID != 0 {
+			fileID = fmt.Sprint(r.FileID)
+		}
+		w.Write([]string{r.Status, r.Region, r.S3Key, size, modified, workspaceID, fileID, r.Detail})
+	}
+	w.Flush()
+	return w.Error()
+}
@@ -5,6 +5,7 @@ import (
 
 	"realtime-backend/internal/config"
 	"realtime-backend/internal/database"
+	"realtime-backend/internal/errorreport"
 	"realtime-backend/internal/server"
 )
 
@@ -12,6 +13,11 @@ func main() {
 	// 설정 로드
 	cfg := config.Load()
 
+	// 오류 리포팅 초기화 (실패해도 서버는 계속 동작)
+	if err := errorreport.Init(cfg.Sentry); err != nil {
+		log.Printf("⚠️ Error reporting initialization failed: %v", err)
+	}
+
 	// 데이터베이스 연결
 	db, err := database.ConnectDB()
 	if err != nil {
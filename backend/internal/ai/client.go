@@ -11,6 +11,7 @@ import (
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/keepalive"
 
+	"realtime-backend/internal/metrics"
 	"realtime-backend/pb"
 )
 
@@ -28,6 +29,10 @@ const (
 	MaxSendMsgSize   = 4 * 1024 * 1024 // 4MB
 )
 
+// droppedTotal 수신 채널이 가득 차 버려진 gRPC 응답 수
+var droppedTotal = metrics.NewCounterVec("eum_ai_client_dropped_total",
+	"gRPC AI responses dropped because the receive channel was full", "channel")
+
 // GrpcClient Python AI 서버와 통신하는 gRPC 클라이언트
 type GrpcClient struct {
 	conn   *grpc.ClientConn
@@ -58,6 +63,9 @@ type AudioMessage struct {
 	SampleRate           uint32
 	DurationMs           uint32
 	SpeakerParticipantID string
+	Streamed             bool   // 스트리밍 TTS 청크 (false면 AudioData가 전체 오디오)
+	Sequence             uint32 // 청크 순번 (0부터)
+	Last                 bool   // 스트림의 마지막 청크
 }
 
 // AudioChunkWithSpeaker 스피커 정보가 포함된 오디오 청크
@@ -359,6 +367,7 @@ func (c *GrpcClient) StartChatStream(ctx context.Context, sessionID, roomID stri
 				select {
 				case transcriptChan <- msg:
 				default:
+					droppedTotal.Inc("transcript")
 					log.Printf("⚠️ [%s] Transcript channel full, dropping", sessionID)
 				}
 
@@ -397,6 +406,7 @@ func (c *GrpcClient) StartChatStream(ctx context.Context, sessionID, roomID stri
 				select {
 				case audioChan <- msg:
 				default:
+					droppedTotal.Inc("audio")
 					log.Printf("⚠️ [%s] Audio channel full, dropping TTS audio", sessionID)
 				}
 
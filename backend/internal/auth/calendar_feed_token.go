@@ -0,0 +1,25 @@
+package auth
+
+import (
+	"strings"
+)
+
+// CalendarFeedTokenPrefix 캘린더 구독 토큰 접두사
+// 구독 URL(?token=)로 공유되는 토큰이라 JWT가 아닌 임의 문자열을 쓰고 DB에는 해시만 저장
+// - 어떤 서명 키로도 검증되지 않으므로 피드 외의 인증(액세스/리프레시 토큰)에는 쓸 수 없음
+const CalendarFeedTokenPrefix = "eum_cal_"
+
+// NewCalendarFeedToken 캘린더 구독 토큰과 저장용 해시 생성 (재발급하면 해시가 바뀌어 이전 URL은 무효)
+func NewCalendarFeedToken() (token, hash string, err error) {
+	raw, _, err := NewOpaqueToken()
+	if err != nil {
+		return "", "", err
+	}
+	token = CalendarFeedTokenPrefix + raw
+	return token, HashOpaqueToken(token), nil
+}
+
+// IsCalendarFeedToken 캘린더 구독 토큰 형식인지
+func IsCalendarFeedToken(token string) bool {
+	return strings.HasPrefix(token, CalendarFeedTokenPrefix)
+}
@@ -0,0 +1,117 @@
+package auth
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ErrImpersonationReadOnly 읽기 전용 대리 접속으로 쓰기가 가능한 연결 시도
+var ErrImpersonationReadOnly = errors.New("impersonation session is read-only")
+
+// ImpersonationClaim 관리자 대리 접속 정보 (토큰에 포함)
+type ImpersonationClaim struct {
+	SessionID int64 `json:"sid"`
+	AdminID   int64 `json:"admin_id"`
+	ReadOnly  bool  `json:"read_only"`
+}
+
+// ImpersonationStore 대리 접속 세션 저장소 (세션 유효성 확인 및 감사 기록)
+type ImpersonationStore interface {
+	IsActive(sessionID int64) bool
+	RecordAccess(claim *ImpersonationClaim, targetUserID int64, method, path string, status int, blocked bool)
+}
+
+// SetImpersonationStore 대리 접속 세션 저장소 설정 (없으면 대리 접속 토큰은 모두 거부)
+func (m *JWTManager) SetImpersonationStore(store ImpersonationStore) {
+	m.impersonationStore = store
+}
+
+// GenerateImpersonationToken 대리 접속용 액세스 토큰 생성 (리프레시 토큰 없음, 만료 시 세션 종료)
+func (m *JWTManager) GenerateImpersonationToken(userID int64, email, nickname string, imp ImpersonationClaim, expiresAt time.Time) (string, error) {
+	claims := &Claims{
+		UserID:        userID,
+		Email:         email,
+		Nickname:      nickname,
+		Impersonation: &imp,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			NotBefore: jwt.NewNumericDate(time.Now()),
+			Issuer:    "eum-api",
+			Subject:   strconv.FormatInt(userID, 10),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(m.secretKey)
+}
+
+// IsImpersonating 대리 접속 토큰 여부
+func (c *Claims) IsImpersonating() bool {
+	return c != nil && c.Impersonation != nil
+}
+
+// RejectImpersonation 대리 접속으로는 호출할 수 없는 라우트 (세션/토큰/2단계 인증 등 계정 보안)
+// 쓰기 허용 대리 접속이라도 대상 사용자의 자격 증명을 만들거나 바꾸면 세션 종료 후에도 남고 감사 기록과도 끊기므로 차단
+func RejectImpersonation() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if claims, err := GetClaimsFromContext(c); err == nil && claims.IsImpersonating() {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": "this endpoint is not available while impersonating",
+				"code":  "IMPERSONATION_NOT_ALLOWED",
+			})
+		}
+		return c.Next()
+	}
+}
+
+// guardImpersonation 대리 접속 요청 처리: 배너 헤더 설정, 읽기 전용 차단, 감사 기록
+func (m *JWTManager) guardImpersonation(c *fiber.Ctx, claims *Claims) error {
+	imp := claims.Impersonation
+
+	c.Set("X-Impersonation", "true")
+	c.Set("X-Impersonated-By", strconv.FormatInt(imp.AdminID, 10))
+	c.Set("X-Impersonation-Read-Only", strconv.FormatBool(imp.ReadOnly))
+	if claims.ExpiresAt != nil {
+		c.Set("X-Impersonation-Expires-At", claims.ExpiresAt.Format(time.RFC3339))
+	}
+
+	method := c.Method()
+	path := c.Path()
+
+	if imp.ReadOnly && !isSafeMethod(method) {
+		m.impersonationStore.RecordAccess(imp, claims.UserID, method, path, fiber.StatusForbidden, true)
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "impersonation session is read-only",
+			"code":  "IMPERSONATION_READ_ONLY",
+		})
+	}
+
+	err := c.Next()
+	m.impersonationStore.RecordAccess(imp, claims.UserID, method, path, c.Response().StatusCode(), false)
+	return err
+}
+
+// GuardWebSocketImpersonation 대리 접속 WebSocket 연결 감사 기록
+// WebSocket은 채팅/그리기/오디오를 보낼 수 있으므로 읽기 전용 대리 접속은 연결 자체를 거부
+func (m *JWTManager) GuardWebSocketImpersonation(c *fiber.Ctx, claims *Claims) error {
+	if !claims.IsImpersonating() {
+		return nil
+	}
+	imp := claims.Impersonation
+
+	if imp.ReadOnly {
+		m.impersonationStore.RecordAccess(imp, claims.UserID, "WS", c.Path(), fiber.StatusForbidden, true)
+		return ErrImpersonationReadOnly
+	}
+	m.impersonationStore.RecordAccess(imp, claims.UserID, "WS", c.Path(), fiber.StatusSwitchingProtocols, false)
+	return nil
+}
+
+func isSafeMethod(method string) bool {
+	return method == fiber.MethodGet || method == fiber.MethodHead || method == fiber.MethodOptions
+}
@@ -0,0 +1,128 @@
+package auth
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+// 방 입장 토큰 역할
+const (
+	JoinRoleSpeaker  = "speaker"
+	JoinRoleListener = "listener"
+)
+
+// joinTokenAudience 입장 토큰 전용 audience (액세스 토큰과 혼용 방지)
+const joinTokenAudience = "eum-room-join"
+
+var ErrJoinTokenReused = errors.New("join token has already been used")
+
+// JoinClaims 음성 Room 입장 토큰 클레임 (사용자, Room, 역할, 언어 바인딩)
+type JoinClaims struct {
+	UserID   int64  `json:"user_id"`
+	Nickname string `json:"nickname"`
+	RoomID   string `json:"room_id"`
+	Role     string `json:"role"` // speaker, listener
+	Lang     string `json:"lang"` // speaker: 발화 언어, listener: 번역 대상 언어
+	jwt.RegisteredClaims
+}
+
+// JoinTokenStore 사용된 입장 토큰 기록 (재사용 방지)
+type JoinTokenStore interface {
+	// Consume 토큰 ID를 사용 처리. 이미 사용된 토큰이면 false
+	Consume(tokenID string, expiresAt time.Time) (bool, error)
+}
+
+// GenerateJoinToken 일회용 Room 입장 토큰 생성 (토큰, 만료 시각 반환)
+func (m *JWTManager) GenerateJoinToken(userID int64, nickname, roomID, role, lang string, ttl time.Duration) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(ttl)
+	claims := &JoinClaims{
+		UserID:   userID,
+		Nickname: nickname,
+		RoomID:   roomID,
+		Role:     role,
+		Lang:     lang,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			Issuer:    "eum-api",
+			Subject:   strconv.FormatInt(userID, 10),
+			Audience:  jwt.ClaimStrings{joinTokenAudience},
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	signed, err := token.SignedString(m.secretKey)
+	return signed, expiresAt, err
+}
+
+// ValidateJoinToken 입장 토큰 서명/만료 검증 후 사용 처리 (한 번만 성공)
+func (m *JWTManager) ValidateJoinToken(tokenString string, store JoinTokenStore) (*JoinClaims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &JoinClaims{}, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, ErrInvalidToken
+		}
+		return m.secretKey, nil
+	}, jwt.WithAudience(joinTokenAudience))
+
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, ErrExpiredToken
+		}
+		return nil, ErrInvalidToken
+	}
+
+	claims, ok := token.Claims.(*JoinClaims)
+	if !ok || !token.Valid || claims.ID == "" || claims.RoomID == "" {
+		return nil, ErrInvalidToken
+	}
+	if claims.Role != JoinRoleSpeaker && claims.Role != JoinRoleListener {
+		return nil, ErrInvalidToken
+	}
+
+	first, err := store.Consume(claims.ID, claims.ExpiresAt.Time)
+	if err != nil {
+		return nil, err
+	}
+	if !first {
+		return nil, ErrJoinTokenReused
+	}
+	return claims, nil
+}
+
+// MemoryJoinTokenStore 단일 인스턴스용 입장 토큰 저장소 (Redis가 없을 때 사용)
+type MemoryJoinTokenStore struct {
+	mu   sync.Mutex
+	used map[string]time.Time // tokenID -> 만료 시각
+}
+
+// NewMemoryJoinTokenStore MemoryJoinTokenStore 생성
+func NewMemoryJoinTokenStore() *MemoryJoinTokenStore {
+	return &MemoryJoinTokenStore{used: make(map[string]time.Time)}
+}
+
+// Consume 토큰 ID 사용 처리 (만료된 기록은 함께 정리)
+func (s *MemoryJoinTokenStore) Consume(tokenID string, expiresAt time.Time) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for id, exp := range s.used {
+		if now.After(exp) {
+			delete(s.used, id)
+		}
+	}
+
+	if _, exists := s.used[tokenID]; exists {
+		return false, nil
+	}
+	s.used[tokenID] = expiresAt
+	return true, nil
+}
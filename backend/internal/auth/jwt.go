@@ -6,26 +6,34 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
 var (
 	ErrInvalidToken = errors.New("invalid token")
 	ErrExpiredToken = errors.New("token has expired")
+	ErrRevokedToken = errors.New("token has been revoked")
 )
 
 // Claims JWT 클레임
 type Claims struct {
-	UserID   int64  `json:"user_id"`
-	Email    string `json:"email"`
-	Nickname string `json:"nickname"`
+	UserID        int64               `json:"user_id"`
+	Email         string              `json:"email"`
+	Nickname      string              `json:"nickname"`
+	Impersonation *ImpersonationClaim `json:"imp,omitempty"` // 관리자 대리 접속 토큰인 경우
+	SessionID     int64               `json:"sid,omitempty"` // 로그인 세션 (세션이 취소되면 거부)
+	PersonalToken *PersonalTokenClaim `json:"-"`             // 개인 액세스 토큰으로 인증한 경우 (JWT에는 없음)
 	jwt.RegisteredClaims
 }
 
 // JWTManager JWT 토큰 관리자
 type JWTManager struct {
-	secretKey     []byte
-	accessExpiry  time.Duration
-	refreshExpiry time.Duration
+	secretKey          []byte
+	accessExpiry       time.Duration
+	refreshExpiry      time.Duration
+	impersonationStore ImpersonationStore
+	sessionStore       SessionStore
+	personalTokenStore PersonalTokenStore
 }
 
 // NewJWTManager JWTManager 생성
@@ -37,12 +45,13 @@ func NewJWTManager(secretKey string, accessExpiry, refreshExpiry time.Duration)
 	}
 }
 
-// GenerateAccessToken 액세스 토큰 생성
-func (m *JWTManager) GenerateAccessToken(userID int64, email, nickname string) (string, error) {
+// GenerateAccessToken 로그인 세션의 액세스 토큰 생성
+func (m *JWTManager) GenerateAccessToken(userID int64, email, nickname string, sessionID int64) (string, error) {
 	claims := &Claims{
-		UserID:   userID,
-		Email:    email,
-		Nickname: nickname,
+		UserID:    userID,
+		Email:     email,
+		Nickname:  nickname,
+		SessionID: sessionID,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(m.accessExpiry)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
@@ -56,18 +65,22 @@ func (m *JWTManager) GenerateAccessToken(userID int64, email, nickname string) (
 	return token.SignedString(m.secretKey)
 }
 
-// GenerateRefreshToken 리프레시 토큰 생성
-func (m *JWTManager) GenerateRefreshToken(userID int64) (string, error) {
-	claims := &jwt.RegisteredClaims{
-		ExpiresAt: jwt.NewNumericDate(time.Now().Add(m.refreshExpiry)),
-		IssuedAt:  jwt.NewNumericDate(time.Now()),
-		NotBefore: jwt.NewNumericDate(time.Now()),
-		Issuer:    "eum-api",
-		Subject:   strconv.FormatInt(userID, 10),
+// GenerateRefreshToken 로그인 세션의 리프레시 토큰 생성 (갱신할 때마다 새 토큰으로 교체)
+func (m *JWTManager) GenerateRefreshToken(userID, sessionID int64) (string, error) {
+	claims := &RefreshClaims{
+		SessionID: sessionID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(), // 같은 초에 교체해도 토큰이 달라지도록
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(m.refreshExpiry)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			NotBefore: jwt.NewNumericDate(time.Now()),
+			Issuer:    "eum-api",
+			Subject:   strconv.FormatInt(userID, 10),
+		},
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(m.secretKey)
+	return token.SignedString(m.refreshKey())
 }
 
 // ValidateAccessToken 액세스 토큰 검증
@@ -91,34 +104,75 @@ func (m *JWTManager) ValidateAccessToken(tokenString string) (*Claims, error) {
 		return nil, ErrInvalidToken
 	}
 
+	// 입장 토큰, WebSocket 티켓처럼 용도가 정해진 토큰은 액세스 토큰으로 쓰지 못함
+	if len(claims.Audience) > 0 {
+		return nil, ErrInvalidToken
+	}
+
+	if err := m.checkSessionClaims(claims); err != nil {
+		return nil, err
+	}
 	return claims, nil
 }
 
-// ValidateRefreshToken 리프레시 토큰 검증 후 UserID 반환
-func (m *JWTManager) ValidateRefreshToken(tokenString string) (int64, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &jwt.RegisteredClaims{}, func(token *jwt.Token) (interface{}, error) {
+// checkSessionClaims 대리 접속/로그인 세션이 아직 유효한지 확인 (액세스 토큰, WebSocket 티켓 공용)
+func (m *JWTManager) checkSessionClaims(claims *Claims) error {
+	// 대리 접속 토큰은 세션이 종료되면 만료 전이라도 거부
+	if claims.Impersonation != nil {
+		if m.impersonationStore == nil || !m.impersonationStore.IsActive(claims.Impersonation.SessionID) {
+			return ErrInvalidToken
+		}
+	}
+
+	// 로그아웃/취소된 로그인 세션의 토큰 거부 (세션 도입 전 토큰은 sid가 없어 만료까지 허용)
+	if claims.SessionID != 0 && !m.sessionActive(claims.SessionID) {
+		return ErrRevokedToken
+	}
+	return nil
+}
+
+// ValidateRefreshToken 리프레시 토큰 서명 검증 후 UserID, 세션 ID 반환 (세션 상태는 호출하는 쪽에서 확인)
+// legacy 는 키 분리 전 공용 키로 서명된 토큰 - 세션에 저장된 현재 토큰과 같을 때만 인정해야 함
+func (m *JWTManager) ValidateRefreshToken(tokenString string) (userID, sessionID int64, legacy bool, err error) {
+	claims, err := parseRefreshToken(tokenString, m.refreshKey())
+	if errors.Is(err, ErrInvalidToken) {
+		claims, err = parseRefreshToken(tokenString, m.secretKey)
+		legacy = true
+	}
+	if err != nil {
+		return 0, 0, false, err
+	}
+
+	// 입장 토큰, 캘린더 구독 토큰, WebSocket 티켓(aud)과 대리 접속 토큰(imp), 세션 없는 토큰은 리프레시 불가
+	if len(claims.Audience) > 0 || len(claims.Impersonation) > 0 || claims.SessionID == 0 {
+		return 0, 0, false, ErrInvalidToken
+	}
+
+	userID, err = strconv.ParseInt(claims.Subject, 10, 64)
+	if err != nil {
+		return 0, 0, false, ErrInvalidToken
+	}
+
+	return userID, claims.SessionID, legacy, nil
+}
+
+func parseRefreshToken(tokenString string, key []byte) (*RefreshClaims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &RefreshClaims{}, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, ErrInvalidToken
 		}
-		return m.secretKey, nil
+		return key, nil
 	})
-
 	if err != nil {
 		if errors.Is(err, jwt.ErrTokenExpired) {
-			return 0, ErrExpiredToken
+			return nil, ErrExpiredToken
 		}
-		return 0, ErrInvalidToken
+		return nil, ErrInvalidToken
 	}
 
-	claims, ok := token.Claims.(*jwt.RegisteredClaims)
+	claims, ok := token.Claims.(*RefreshClaims)
 	if !ok || !token.Valid {
-		return 0, ErrInvalidToken
-	}
-
-	userID, err := strconv.ParseInt(claims.Subject, 10, 64)
-	if err != nil {
-		return 0, ErrInvalidToken
+		return nil, ErrInvalidToken
 	}
-
-	return userID, nil
+	return claims, nil
 }
@@ -59,8 +59,14 @@ func AuthMiddleware(jwtManager *JWTManager) fiber.Handler {
 			token = parts[1]
 		}
 
-		// 토큰 검증
-		claims, err := jwtManager.ValidateAccessToken(token)
+		// 토큰 검증 (개인 액세스 토큰은 Authorization 헤더로만)
+		var claims *Claims
+		var err error
+		if IsPersonalToken(token) {
+			claims, err = jwtManager.ValidatePersonalToken(token)
+		} else {
+			claims, err = jwtManager.ValidateAccessToken(token)
+		}
 		if err != nil {
 			if err == ErrExpiredToken {
 				return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
@@ -68,6 +74,12 @@ func AuthMiddleware(jwtManager *JWTManager) fiber.Handler {
 					"code":  "TOKEN_EXPIRED",
 				})
 			}
+			if err == ErrRevokedToken {
+				return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+					"error": "session has been revoked",
+					"code":  "SESSION_REVOKED",
+				})
+			}
 			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
 				"error": "invalid token",
 			})
@@ -79,6 +91,13 @@ func AuthMiddleware(jwtManager *JWTManager) fiber.Handler {
 		c.Locals("nickname", claims.Nickname)
 		c.Locals("claims", claims)
 
+		if claims.IsImpersonating() {
+			return jwtManager.guardImpersonation(c, claims)
+		}
+		if claims.PersonalToken != nil {
+			return guardPersonalToken(c, claims)
+		}
+
 		return c.Next()
 	}
 }
@@ -97,12 +116,25 @@ func OptionalAuthMiddleware(jwtManager *JWTManager) fiber.Handler {
 				authHeader = strings.TrimPrefix(authHeader, "Bearer ")
 			}
 
-			claims, err := jwtManager.ValidateAccessToken(authHeader)
+			var claims *Claims
+			var err error
+			if IsPersonalToken(authHeader) {
+				claims, err = jwtManager.ValidatePersonalToken(authHeader)
+			} else {
+				claims, err = jwtManager.ValidateAccessToken(authHeader)
+			}
 			if err == nil {
 				c.Locals("userID", claims.UserID)
 				c.Locals("email", claims.Email)
 				c.Locals("nickname", claims.Nickname)
 				c.Locals("claims", claims)
+
+				if claims.IsImpersonating() {
+					return jwtManager.guardImpersonation(c, claims)
+				}
+				if claims.PersonalToken != nil {
+					return guardPersonalToken(c, claims)
+				}
 			}
 		}
 
@@ -2,38 +2,140 @@ package auth
 
 import "gorm.io/gorm"
 
+// guestChannelPermissions 게스트 역할이 회의별 오버라이드로 받을 수 있는 권한
+// 워크스페이스 단위 권한(CheckPermission)은 게스트에게 항상 거부
+var guestChannelPermissions = map[string]bool{"CONNECT_MEDIA": true, "DRAW_WHITEBOARD": true}
+
 // CheckPermission 권한 확인
 func CheckPermission(db *gorm.DB, workspaceID, userID int64, permissionCode string) (bool, error) {
+	grants, err := loadMemberGrants(db, workspaceID, userID)
+	if err != nil {
+		return false, err
+	}
+	return grants.superUser || (!grants.guest && grants.codes[permissionCode]), nil
+}
+
+// IsWorkspaceGuest 게스트 역할 멤버인지 확인 (채팅/파일 접근 차단용)
+func IsWorkspaceGuest(db *gorm.DB, workspaceID, userID int64) (bool, error) {
+	grants, err := loadMemberGrants(db, workspaceID, userID)
+	if err != nil {
+		return false, err
+	}
+	return grants.guest, nil
+}
+
+// CheckChannelPermission 채팅방/회의 단위 권한 확인
+// 워크스페이스 역할 권한 위에 역할 오버라이드, 그 위에 사용자 오버라이드를 적용 (DENY/ALLOW)
+// 소유자와 ADMIN은 오버라이드의 영향을 받지 않음
+func CheckChannelPermission(db *gorm.DB, workspaceID, channelID, userID int64, permissionCode string) (bool, error) {
+	grants, err := loadMemberGrants(db, workspaceID, userID)
+	if err != nil {
+		return false, err
+	}
+	if grants.superUser {
+		return true, nil
+	}
+	if grants.guest && !guestChannelPermissions[permissionCode] {
+		return false, nil
+	}
+
+	overrides, err := loadChannelOverrides(db, workspaceID, channelID)
+	if err != nil {
+		return false, err
+	}
+
+	granted := !grants.guest && grants.codes[permissionCode]
+	roleEffect, userEffect := "", ""
+	for _, o := range overrides {
+		if o.PermissionCode != permissionCode {
+			continue
+		}
+		switch {
+		case o.TargetType == "USER" && o.TargetID == userID:
+			userEffect = o.Effect
+		case o.TargetType == "ROLE" && grants.roleID != nil && o.TargetID == *grants.roleID:
+			roleEffect = o.Effect
+		}
+	}
+	for _, effect := range []string{roleEffect, userEffect} {
+		switch effect {
+		case "ALLOW":
+			granted = true
+		case "DENY":
+			granted = false
+		}
+	}
+	return granted, nil
+}
+
+// loadMemberGrants 소유자 여부, 역할, 역할 권한 조회 (캐시 우선)
+func loadMemberGrants(db *gorm.DB, workspaceID, userID int64) (memberGrants, error) {
+	key := memberKey{workspaceID, userID}
+	grants, generation, ok := grantsCache.get(key)
+	if ok {
+		return grants, nil
+	}
+
 	// 1. 소유자(Owner) 확인 - 소유자는 모든 권한을 가짐 (Super User)
 	var ownerID int64
 	if err := db.Table("workspaces").Where("id = ?", workspaceID).Select("owner_id").Scan(&ownerID).Error; err != nil {
-		return false, err
+		return memberGrants{}, err
 	}
 	if ownerID == userID {
-		return true, nil
+		grants = memberGrants{superUser: true}
+		grantsCache.set(key, grants, generation)
+		return grants, nil
 	}
 
-	// 2. ADMIN 권한 확인 (Super User)
-	var adminCount int64
-	err := db.Table("role_permissions").
-		Joins("JOIN workspace_members ON workspace_members.role_id = role_permissions.role_id").
-		Where("workspace_members.workspace_id = ? AND workspace_members.user_id = ? AND role_permissions.permission_code = 'ADMIN'", workspaceID, userID).
-		Count(&adminCount).Error
+	// 2. 멤버 역할과 역할 권한 (ADMIN이면 Super User, 게스트 역할이면 회의 오버라이드만 적용)
+	var roles []struct {
+		RoleID  int64
+		IsGuest bool
+	}
+	if err := db.Table("workspace_members").
+		Select("workspace_members.role_id, roles.is_guest").
+		Joins("JOIN roles ON roles.id = workspace_members.role_id").
+		Where("workspace_members.workspace_id = ? AND workspace_members.user_id = ?", workspaceID, userID).
+		Limit(1).
+		Scan(&roles).Error; err != nil {
+		return memberGrants{}, err
+	}
+	grants = memberGrants{codes: map[string]bool{}}
+	if len(roles) > 0 {
+		grants.roleID = &roles[0].RoleID
+		grants.guest = roles[0].IsGuest
 
-	if err == nil && adminCount > 0 {
-		return true, nil
+		var codes []string
+		if err := db.Table("role_permissions").
+			Where("role_id = ?", roles[0].RoleID).
+			Pluck("permission_code", &codes).Error; err != nil {
+			return memberGrants{}, err
+		}
+		for _, code := range codes {
+			grants.codes[code] = true
+		}
+		grants.superUser = !grants.guest && grants.codes["ADMIN"]
 	}
 
-	// 3. 역할 기반 권한 확인
-	var count int64
-	err = db.Table("role_permissions").
-		Joins("JOIN workspace_members ON workspace_members.role_id = role_permissions.role_id").
-		Where("workspace_members.workspace_id = ? AND workspace_members.user_id = ? AND role_permissions.permission_code = ?", workspaceID, userID, permissionCode).
-		Count(&count).Error
+	grantsCache.set(key, grants, generation)
+	return grants, nil
+}
 
-	if err != nil {
-		return false, err
+// loadChannelOverrides 채팅방/회의의 전체 권한 오버라이드 조회 (캐시 우선)
+func loadChannelOverrides(db *gorm.DB, workspaceID, channelID int64) ([]channelOverride, error) {
+	key := channelKey{workspaceID, channelID}
+	overrides, generation, ok := overrideCache.get(key)
+	if ok {
+		return overrides, nil
+	}
+
+	if err := db.Table("channel_permission_overrides").
+		Select("target_type, target_id, permission_code, effect").
+		Where("meeting_id = ?", channelID).
+		Scan(&overrides).Error; err != nil {
+		return nil, err
 	}
 
-	return count > 0, nil
+	overrideCache.set(key, overrides, generation)
+	return overrides, nil
 }
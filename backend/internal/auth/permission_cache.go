@@ -0,0 +1,142 @@
+package auth
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// 권한 캐시 설정
+// 다른 인스턴스에서 변경된 권한은 TTL이 지나야 반영됨 (같은 인스턴스 변경은 즉시 무효화)
+const (
+	permissionCacheTTL  = 30 * time.Second
+	permissionCacheSize = 10000
+)
+
+// memberGrants (워크스페이스, 사용자) 기준 권한 스냅샷
+type memberGrants struct {
+	superUser bool // 소유자 또는 ADMIN
+	guest     bool // 게스트 역할 (회의 오버라이드로 허용된 접속만 가능)
+	roleID    *int64
+	codes     map[string]bool
+}
+
+// channelOverride 채팅방/회의 권한 오버라이드 한 건
+type channelOverride struct {
+	TargetType     string
+	TargetID       int64
+	PermissionCode string
+	Effect         string
+}
+
+type memberKey struct{ workspaceID, userID int64 }
+
+type channelKey struct{ workspaceID, channelID int64 }
+
+var (
+	grantsCache   = newTTLCache[memberKey, memberGrants](permissionCacheTTL, permissionCacheSize)
+	overrideCache = newTTLCache[channelKey, []channelOverride](permissionCacheTTL, permissionCacheSize)
+)
+
+// InvalidateMemberPermissions 멤버 역할 변경, 가입, 탈퇴 후 호출
+func InvalidateMemberPermissions(workspaceID, userID int64) {
+	grantsCache.delete(memberKey{workspaceID, userID})
+}
+
+// InvalidateWorkspacePermissions 역할 권한 변경/삭제, 워크스페이스 삭제 후 호출 (해당 워크스페이스 전체)
+func InvalidateWorkspacePermissions(workspaceID int64) {
+	grantsCache.deleteFunc(func(k memberKey) bool { return k.workspaceID == workspaceID })
+	overrideCache.deleteFunc(func(k channelKey) bool { return k.workspaceID == workspaceID })
+}
+
+// InvalidateChannelPermissions 채널 오버라이드 변경, 채널 삭제 후 호출
+func InvalidateChannelPermissions(workspaceID, channelID int64) {
+	overrideCache.delete(channelKey{workspaceID, channelID})
+}
+
+// ttlCache 만료 시간이 있는 LRU 캐시
+// 조회 도중 무효화가 일어나면 그 조회 결과는 저장하지 않음 (generation 비교)
+type ttlCache[K comparable, V any] struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	size       int
+	generation uint64
+	order      *list.List // 앞쪽이 최근 사용
+	items      map[K]*list.Element
+}
+
+type ttlEntry[K comparable, V any] struct {
+	key       K
+	value     V
+	expiresAt time.Time
+}
+
+func newTTLCache[K comparable, V any](ttl time.Duration, size int) *ttlCache[K, V] {
+	return &ttlCache[K, V]{
+		ttl:   ttl,
+		size:  size,
+		order: list.New(),
+		items: make(map[K]*list.Element),
+	}
+}
+
+// get 캐시 조회, 없으면 현재 generation을 함께 반환 (set에 전달)
+func (c *ttlCache[K, V]) get(key K) (V, uint64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok {
+		entry := el.Value.(*ttlEntry[K, V])
+		if time.Now().Before(entry.expiresAt) {
+			c.order.MoveToFront(el)
+			return entry.value, c.generation, true
+		}
+		c.order.Remove(el)
+		delete(c.items, key)
+	}
+	var zero V
+	return zero, c.generation, false
+}
+
+// set 조회 결과 저장 (get 이후 무효화가 있었으면 무시)
+func (c *ttlCache[K, V]) set(key K, value V, generation uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if generation != c.generation {
+		return
+	}
+	if el, ok := c.items[key]; ok {
+		c.order.Remove(el)
+	}
+	c.items[key] = c.order.PushFront(&ttlEntry[K, V]{key: key, value: value, expiresAt: time.Now().Add(c.ttl)})
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*ttlEntry[K, V]).key)
+	}
+}
+
+func (c *ttlCache[K, V]) delete(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	if el, ok := c.items[key]; ok {
+		c.order.Remove(el)
+		delete(c.items, key)
+	}
+}
+
+func (c *ttlCache[K, V]) deleteFunc(match func(K) bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	for key, el := range c.items {
+		if match(key) {
+			c.order.Remove(el)
+			delete(c.items, key)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package auth
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// PersonalTokenPrefix 개인 액세스 토큰 접두사 (JWT와 구분, 유출 스캐너가 찾기 쉽도록)
+const PersonalTokenPrefix = "eum_pat_"
+
+// 개인 액세스 토큰 권한 범위
+const (
+	ScopeRead  = "read"  // GET/HEAD 요청만
+	ScopeWrite = "write" // 모든 요청
+)
+
+// ValidScope 지원하는 권한 범위인지
+func ValidScope(scope string) bool {
+	return scope == ScopeRead || scope == ScopeWrite
+}
+
+// PersonalTokenClaim 개인 액세스 토큰으로 인증한 요청의 토큰 정보
+type PersonalTokenClaim struct {
+	TokenID int64    `json:"token_id"`
+	Scopes  []string `json:"scopes"`
+}
+
+// HasScope 권한 범위 보유 여부 (write는 read 포함)
+func (p *PersonalTokenClaim) HasScope(scope string) bool {
+	return slices.Contains(p.Scopes, scope) || (scope == ScopeRead && slices.Contains(p.Scopes, ScopeWrite))
+}
+
+// PersonalToken 저장소에서 찾은 유효한 토큰
+type PersonalToken struct {
+	ID       int64
+	UserID   int64
+	Email    string
+	Nickname string
+	Scopes   []string
+}
+
+// PersonalTokenStore 개인 액세스 토큰 저장소 (해시로 조회, 취소/만료된 토큰은 nil)
+type PersonalTokenStore interface {
+	LookupPersonalToken(hash string) (*PersonalToken, error)
+}
+
+// 토큰 조회 캐시 (다른 인스턴스에서 취소된 토큰은 TTL이 지나야 거부됨)
+const (
+	personalTokenCacheTTL  = 30 * time.Second
+	personalTokenCacheSize = 10000
+)
+
+var personalTokenCache = newTTLCache[string, *PersonalToken](personalTokenCacheTTL, personalTokenCacheSize)
+
+// InvalidatePersonalToken 토큰 취소 후 호출 (이 인스턴스에서는 즉시 거부)
+func InvalidatePersonalToken(hash string) {
+	personalTokenCache.delete(hash)
+}
+
+// SetPersonalTokenStore 개인 액세스 토큰 저장소 설정 (없으면 개인 액세스 토큰은 모두 거부)
+func (m *JWTManager) SetPersonalTokenStore(store PersonalTokenStore) {
+	m.personalTokenStore = store
+}
+
+// IsPersonalToken 개인 액세스 토큰 형식인지
+func IsPersonalToken(token string) bool {
+	return strings.HasPrefix(token, PersonalTokenPrefix)
+}
+
+// ValidatePersonalToken 개인 액세스 토큰 확인 후 요청 클레임 반환
+func (m *JWTManager) ValidatePersonalToken(token string) (*Claims, error) {
+	if m.personalTokenStore == nil {
+		return nil, ErrInvalidToken
+	}
+	hash := HashOpaqueToken(token)
+	pat, generation, ok := personalTokenCache.get(hash)
+	if !ok {
+		var err error
+		pat, err = m.personalTokenStore.LookupPersonalToken(hash)
+		if err != nil {
+			return nil, ErrInvalidToken
+		}
+		personalTokenCache.set(hash, pat, generation)
+	}
+	if pat == nil {
+		return nil, ErrInvalidToken
+	}
+
+	return &Claims{
+		UserID:        pat.UserID,
+		Email:         pat.Email,
+		Nickname:      pat.Nickname,
+		PersonalToken: &PersonalTokenClaim{TokenID: pat.ID, Scopes: pat.Scopes},
+	}, nil
+}
+
+// guardPersonalToken 권한 범위 밖의 요청 차단 (read 토큰은 조회만)
+func guardPersonalToken(c *fiber.Ctx, claims *Claims) error {
+	if !isSafeMethod(c.Method()) && !claims.PersonalToken.HasScope(ScopeWrite) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "token does not have the write scope",
+			"code":  "INSUFFICIENT_SCOPE",
+		})
+	}
+	return c.Next()
+}
+
+// RejectPersonalToken 개인 액세스 토큰으로는 호출할 수 없는 라우트 (토큰/세션 관리 등 계정 보안)
+func RejectPersonalToken() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if claims, err := GetClaimsFromContext(c); err == nil && claims.PersonalToken != nil {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": "this endpoint requires a browser session",
+				"code":  "PERSONAL_TOKEN_NOT_ALLOWED",
+			})
+		}
+		return c.Next()
+	}
+}
@@ -0,0 +1,67 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/json"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// RefreshClaims 리프레시 토큰 클레임 (로그인 세션에 묶임)
+type RefreshClaims struct {
+	SessionID     int64           `json:"sid,omitempty"`
+	Impersonation json.RawMessage `json:"imp,omitempty"` // 리프레시 토큰에는 없음 - 있으면 다른 용도의 토큰이므로 거부
+	jwt.RegisteredClaims
+}
+
+// refreshKey 리프레시 토큰 서명 키 - 액세스 토큰, 대리 접속 토큰, 캘린더 구독 토큰, WebSocket 티켓과
+// 키를 분리해 다른 토큰을 리프레시 토큰으로 제출해도 서명 검증에서 거부됨
+func (m *JWTManager) refreshKey() []byte {
+	mac := hmac.New(sha256.New, m.secretKey)
+	mac.Write([]byte("refresh-token"))
+	return mac.Sum(nil)
+}
+
+// SessionStore 로그인 세션 저장소 (액세스 토큰 검증 시 취소 여부 확인)
+type SessionStore interface {
+	IsSessionActive(sessionID int64) bool
+}
+
+// 세션 상태 캐시 (다른 인스턴스에서 취소된 세션은 TTL이 지나야 거부됨)
+const (
+	sessionCacheTTL  = 30 * time.Second
+	sessionCacheSize = 50000
+)
+
+var sessionCache = newTTLCache[int64, bool](sessionCacheTTL, sessionCacheSize)
+
+// InvalidateSession 세션 취소 후 호출 (이 인스턴스에서는 즉시 거부)
+func InvalidateSession(sessionID int64) {
+	sessionCache.delete(sessionID)
+}
+
+// sessionActive 캐시를 거쳐 세션 유효성 확인
+func (m *JWTManager) sessionActive(sessionID int64) bool {
+	if m.sessionStore == nil {
+		return true
+	}
+	active, generation, ok := sessionCache.get(sessionID)
+	if ok {
+		return active
+	}
+	active = m.sessionStore.IsSessionActive(sessionID)
+	sessionCache.set(sessionID, active, generation)
+	return active
+}
+
+// SetSessionStore 로그인 세션 저장소 설정 (없으면 세션 취소를 확인하지 않음)
+func (m *JWTManager) SetSessionStore(store SessionStore) {
+	m.sessionStore = store
+}
+
+// RefreshExpiry 리프레시 토큰(로그인 세션) 유효 기간
+func (m *JWTManager) RefreshExpiry() time.Duration {
+	return m.refreshExpiry
+}
@@ -0,0 +1,169 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// TOTP 파라미터 (RFC 6238 기본값 - 대부분의 인증 앱이 이 값만 지원)
+const (
+	totpPeriod     = 30
+	totpDigits     = 6
+	totpSkewSteps  = 1 // 시계 오차 허용 (앞뒤 한 구간)
+	totpSecretSize = 20
+	totpIssuer     = "EUM"
+
+	backupCodeCount = 10
+
+	// TwoFactorTokenExpiry 비밀번호/Google 확인 후 두 번째 인증까지 허용하는 시간
+	TwoFactorTokenExpiry = 5 * time.Minute
+)
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateTOTPSecret 인증 앱에 등록할 비밀 키 생성 (base32)
+func GenerateTOTPSecret() (string, error) {
+	buf := make([]byte, totpSecretSize)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return totpEncoding.EncodeToString(buf), nil
+}
+
+// TOTPProvisioningURI 인증 앱 QR 코드용 otpauth:// URI
+func TOTPProvisioningURI(secret, account string) string {
+	label := url.PathEscape(totpIssuer + ":" + account)
+	q := url.Values{}
+	q.Set("secret", secret)
+	q.Set("issuer", totpIssuer)
+	q.Set("algorithm", "SHA1")
+	q.Set("digits", strconv.Itoa(totpDigits))
+	q.Set("period", strconv.Itoa(totpPeriod))
+	return "otpauth://totp/" + label + "?" + q.Encode()
+}
+
+// ValidateTOTP 코드가 맞으면 해당 시간 구간 번호 반환
+// 같은 구간 코드를 다시 쓰지 못하도록 lastStep 이하 구간은 거부
+func ValidateTOTP(secret, code string, lastStep int64, now time.Time) (int64, bool) {
+	code = strings.TrimSpace(code)
+	if len(code) != totpDigits {
+		return 0, false
+	}
+	key, err := totpEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return 0, false
+	}
+
+	current := now.Unix() / totpPeriod
+	for step := current - totpSkewSteps; step <= current+totpSkewSteps; step++ {
+		if step <= lastStep {
+			continue
+		}
+		if subtle.ConstantTimeCompare([]byte(totpCode(key, step)), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// totpCode 시간 구간의 HOTP 코드 (RFC 4226)
+func totpCode(key []byte, step int64) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(step))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%0*d", totpDigits, value%1000000)
+}
+
+// GenerateBackupCodes 일회용 백업 코드 생성 (보여 줄 코드, 저장할 해시)
+func GenerateBackupCodes() (codes, hashes []string, err error) {
+	codes = make([]string, backupCodeCount)
+	hashes = make([]string, backupCodeCount)
+	for i := range codes {
+		buf := make([]byte, 5)
+		if _, err := rand.Read(buf); err != nil {
+			return nil, nil, err
+		}
+		raw := strings.ToLower(totpEncoding.EncodeToString(buf)) // 8자
+		codes[i] = raw[:4] + "-" + raw[4:]
+		hashes[i] = HashBackupCode(codes[i])
+	}
+	return codes, hashes, nil
+}
+
+// HashBackupCode 백업 코드 조회용 해시 (대소문자/하이픈/공백 무시)
+func HashBackupCode(code string) string {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(strings.TrimSpace(code)))
+	return HashOpaqueToken(normalized)
+}
+
+// TwoFactorClaims 2단계 인증 대기 토큰 클레임 (로그인 세션 토큰이 아님)
+type TwoFactorClaims struct {
+	jwt.RegisteredClaims
+}
+
+// twoFactorKey 대기 토큰 서명 키 - 액세스/리프레시 토큰과 키를 분리해 서로 바꿔 쓸 수 없게 함
+func (m *JWTManager) twoFactorKey() []byte {
+	mac := hmac.New(sha256.New, m.secretKey)
+	mac.Write([]byte("two-factor-pending"))
+	return mac.Sum(nil)
+}
+
+// GenerateTwoFactorToken 첫 번째 인증을 통과한 사용자의 2단계 인증 대기 토큰 생성
+func (m *JWTManager) GenerateTwoFactorToken(userID int64) (string, error) {
+	now := time.Now()
+	claims := &TwoFactorClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(now.Add(TwoFactorTokenExpiry)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			Issuer:    "eum-api",
+			Subject:   strconv.FormatInt(userID, 10),
+		},
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(m.twoFactorKey())
+}
+
+// ValidateTwoFactorToken 2단계 인증 대기 토큰 검증 후 UserID 반환
+func (m *JWTManager) ValidateTwoFactorToken(tokenString string) (int64, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &TwoFactorClaims{}, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, ErrInvalidToken
+		}
+		return m.twoFactorKey(), nil
+	})
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return 0, ErrExpiredToken
+		}
+		return 0, ErrInvalidToken
+	}
+
+	claims, ok := token.Claims.(*TwoFactorClaims)
+	if !ok || !token.Valid {
+		return 0, ErrInvalidToken
+	}
+	userID, err := strconv.ParseInt(claims.Subject, 10, 64)
+	if err != nil {
+		return 0, ErrInvalidToken
+	}
+	return userID, nil
+}
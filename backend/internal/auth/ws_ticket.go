@@ -0,0 +1,116 @@
+package auth
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+// wsTicketAudience WebSocket 티켓 전용 audience (액세스 토큰과 혼용 방지)
+const wsTicketAudience = "eum-ws-ticket"
+
+// WSTicketExpiry 티켓 발급 후 WebSocket 연결까지 허용하는 시간
+const WSTicketExpiry = 30 * time.Second
+
+var (
+	ErrMissingToken      = errors.New("missing access token")
+	ErrInsufficientScope = errors.New("personal access token lacks required scope")
+)
+
+// GenerateWSTicket 로그인 사용자의 일회용 WebSocket 티켓 생성 (?ticket= 으로 한 번만 사용)
+// 쿠키를 보낼 수 없거나 URL에 액세스 토큰을 넣고 싶지 않은 클라이언트용
+func (m *JWTManager) GenerateWSTicket(claims *Claims) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(WSTicketExpiry)
+	ticket := &Claims{
+		UserID:        claims.UserID,
+		Email:         claims.Email,
+		Nickname:      claims.Nickname,
+		Impersonation: claims.Impersonation,
+		SessionID:     claims.SessionID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			Issuer:    "eum-api",
+			Subject:   strconv.FormatInt(claims.UserID, 10),
+			Audience:  jwt.ClaimStrings{wsTicketAudience},
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, ticket)
+	signed, err := token.SignedString(m.secretKey)
+	return signed, expiresAt, err
+}
+
+// ValidateWSTicket 티켓 서명/만료/세션 검증 후 사용 처리 (한 번만 성공)
+// 사용 기록은 입장 토큰 저장소를 함께 씀 (토큰 ID가 UUID라 겹치지 않음)
+func (m *JWTManager) ValidateWSTicket(tokenString string, store JoinTokenStore) (*Claims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, ErrInvalidToken
+		}
+		return m.secretKey, nil
+	}, jwt.WithAudience(wsTicketAudience))
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, ErrExpiredToken
+		}
+		return nil, ErrInvalidToken
+	}
+
+	claims, ok := token.Claims.(*Claims)
+	if !ok || !token.Valid || claims.ID == "" {
+		return nil, ErrInvalidToken
+	}
+	if err := m.checkSessionClaims(claims); err != nil {
+		return nil, err
+	}
+
+	first, err := store.Consume(claims.ID, claims.ExpiresAt.Time)
+	if err != nil {
+		return nil, err
+	}
+	if !first {
+		return nil, ErrJoinTokenReused
+	}
+	return claims, nil
+}
+
+// AuthenticateWebSocket WebSocket 업그레이드 요청의 사용자 확인
+// ?ticket= 일회용 티켓 → Authorization: Bearer (액세스 토큰 또는 write 범위 개인 액세스 토큰) → access_token 쿠키 순
+func (m *JWTManager) AuthenticateWebSocket(c *fiber.Ctx, store JoinTokenStore) (*Claims, error) {
+	if ticket := c.Query("ticket"); ticket != "" {
+		return m.ValidateWSTicket(ticket, store)
+	}
+
+	if header := c.Get(fiber.HeaderAuthorization); header != "" {
+		scheme, token, ok := strings.Cut(header, " ")
+		if !ok || !strings.EqualFold(scheme, "bearer") || token == "" {
+			return nil, ErrInvalidToken
+		}
+		if IsPersonalToken(token) {
+			claims, err := m.ValidatePersonalToken(token)
+			if err != nil {
+				return nil, err
+			}
+			// WebSocket은 메시지를 보낼 수 있으므로 write 범위 필요
+			if !claims.PersonalToken.HasScope(ScopeWrite) {
+				return nil, ErrInsufficientScope
+			}
+			return claims, nil
+		}
+		return m.ValidateAccessToken(token)
+	}
+
+	if token := c.Cookies("access_token"); token != "" {
+		return m.ValidateAccessToken(token)
+	}
+	return nil, ErrMissingToken
+}
@@ -0,0 +1,167 @@
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	appconfig "realtime-backend/internal/config"
+)
+
+// Batch transcription job states
+const (
+	BatchJobQueued     = "QUEUED"
+	BatchJobInProgress = "IN_PROGRESS"
+	BatchJobCompleted  = "COMPLETED"
+	BatchJobFailed     = "FAILED"
+)
+
+// batchMaxSpeakers is the speaker label limit for uploaded recordings
+const batchMaxSpeakers = 10
+
+// BatchTranscribeClient runs Amazon Transcribe batch jobs on S3 objects.
+// It speaks the service's JSON API directly; only four operations are needed,
+// which does not justify another generated service module.
+type BatchTranscribeClient struct {
+	api *jsonAPIClient
+}
+
+// BatchTranscriptionJob is the state of a batch job
+type BatchTranscriptionJob struct {
+	Status        string
+	LanguageCode  string // Transcribe locale, identified when the job auto-detects the language
+	TranscriptURI string // Presigned URL of the transcript JSON (COMPLETED only)
+	FailureReason string
+}
+
+// BatchSegment is one speaker turn of a batch transcript
+type BatchSegment struct {
+	SpeakerLabel string // "spk_0", empty when speakers were not identified
+	Text         string
+	StartTime    float64 // seconds from the start of the media
+	EndTime      float64
+}
+
+// NewBatchTranscribeClient creates a batch Transcribe client in region (the bucket's region)
+func NewBatchTranscribeClient(ctx context.Context, cfg *appconfig.Config, region string) (*BatchTranscribeClient, error) {
+	api, err := newJSONAPIClient(ctx, cfg, region, "transcribe", "Transcribe")
+	if err != nil {
+		return nil, err
+	}
+	return &BatchTranscribeClient{api: api}, nil
+}
+
+// StartJob starts transcribing mediaURI ("s3://bucket/key"). An empty languageCode lets
+// Transcribe identify the language among languageOptions.
+func (c *BatchTranscribeClient) StartJob(ctx context.Context, jobName, mediaURI, mediaFormat, languageCode string, languageOptions []string) error {
+	input := map[string]interface{}{
+		"TranscriptionJobName": jobName,
+		"Media":                map[string]string{"MediaFileUri": mediaURI},
+		"Settings": map[string]interface{}{
+			"ShowSpeakerLabels": true,
+			"MaxSpeakerLabels":  batchMaxSpeakers,
+		},
+	}
+	if mediaFormat != "" {
+		input["MediaFormat"] = mediaFormat
+	}
+	if languageCode != "" {
+		input["LanguageCode"] = languageCode
+	} else {
+		input["IdentifyLanguage"] = true
+		if len(languageOptions) >= 2 {
+			input["LanguageOptions"] = languageOptions
+		}
+	}
+	return c.api.call(ctx, "StartTranscriptionJob", input, nil)
+}
+
+// GetJob returns the job's current state
+func (c *BatchTranscribeClient) GetJob(ctx context.Context, jobName string) (*BatchTranscriptionJob, error) {
+	var output struct {
+		TranscriptionJob struct {
+			TranscriptionJobStatus string
+			LanguageCode           string
+			FailureReason          string
+			Transcript             struct {
+				TranscriptFileUri string
+			}
+		}
+	}
+	if err := c.api.call(ctx, "GetTranscriptionJob", map[string]string{"TranscriptionJobName": jobName}, &output); err != nil {
+		return nil, err
+	}
+
+	job := output.TranscriptionJob
+	return &BatchTranscriptionJob{
+		Status:        job.TranscriptionJobStatus,
+		LanguageCode:  job.LanguageCode,
+		TranscriptURI: job.Transcript.TranscriptFileUri,
+		FailureReason: job.FailureReason,
+	}, nil
+}
+
+// DeleteJob removes a finished job (and its service-managed transcript)
+func (c *BatchTranscribeClient) DeleteJob(ctx context.Context, jobName string) error {
+	return c.api.call(ctx, "DeleteTranscriptionJob", map[string]string{"TranscriptionJobName": jobName}, nil)
+}
+
+// FetchTranscript downloads a completed job's transcript as speaker turns
+func (c *BatchTranscribeClient) FetchTranscript(ctx context.Context, transcriptURI string) ([]BatchSegment, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, transcriptURI, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.api.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("transcript download failed: %s", resp.Status)
+	}
+
+	var transcript struct {
+		Results struct {
+			Transcripts []struct {
+				Transcript string `json:"transcript"`
+			} `json:"transcripts"`
+			AudioSegments []struct {
+				Transcript   string `json:"transcript"`
+				SpeakerLabel string `json:"speaker_label"`
+				StartTime    string `json:"start_time"`
+				EndTime      string `json:"end_time"`
+			} `json:"audio_segments"`
+		} `json:"results"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&transcript); err != nil {
+		return nil, fmt.Errorf("invalid transcript: %w", err)
+	}
+
+	segments := make([]BatchSegment, 0, len(transcript.Results.AudioSegments))
+	for _, s := range transcript.Results.AudioSegments {
+		if s.Transcript == "" {
+			continue
+		}
+		start, _ := strconv.ParseFloat(s.StartTime, 64)
+		end, _ := strconv.ParseFloat(s.EndTime, 64)
+		segments = append(segments, BatchSegment{
+			SpeakerLabel: s.SpeakerLabel,
+			Text:         s.Transcript,
+			StartTime:    start,
+			EndTime:      end,
+		})
+	}
+
+	// Older transcripts have no segments, only the full text
+	if len(segments) == 0 {
+		for _, t := range transcript.Results.Transcripts {
+			if t.Transcript != "" {
+				segments = append(segments, BatchSegment{Text: t.Transcript})
+			}
+		}
+	}
+	return segments, nil
+}
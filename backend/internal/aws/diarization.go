@@ -0,0 +1,103 @@
+package aws
+
+import (
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/transcribestreaming/types"
+)
+
+// MixedSpeakerID marks audio that carries a whole room mix on one stream instead of a single speaker.
+// Streams for this ID are started with Transcribe speaker diarization and their results are
+// attributed to participants by the pipeline's SpeakerResolver.
+const MixedSpeakerID = "mixed"
+
+// audioClockWindow is how far back audio offsets can be mapped to wall-clock time
+const audioClockWindow = 2 * time.Minute
+
+// SpeakerResolver maps a diarized speaker label ("spk_0") spoken between start and end
+// to a participant ID. Returning "" keeps the stream's own speaker ID.
+type SpeakerResolver func(label string, start, end time.Time) string
+
+// StreamOptions configures a Transcribe stream
+type StreamOptions struct {
+	ShowSpeakerLabels bool // Enable speaker diarization (mixed room feeds)
+}
+
+// audioCheckpoint records when a given offset of the audio timeline was sent
+type audioCheckpoint struct {
+	offset time.Duration
+	at     time.Time
+}
+
+// audioClock maps Transcribe's audio-time offsets to wall-clock time.
+// Audio may be VAD-gated, so the audio timeline runs slower than the wall clock.
+type audioClock struct {
+	mu          sync.Mutex
+	bytesPerSec int64
+	sent        int64 // Total bytes sent to Transcribe
+	checkpoints []audioCheckpoint
+}
+
+// record notes that n more bytes were sent now
+func (c *audioClock) record(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	c.checkpoints = append(c.checkpoints, audioCheckpoint{offset: c.offsetLocked(), at: now})
+	c.sent += int64(n)
+
+	// Drop checkpoints older than the window (keep at least one)
+	drop := 0
+	for drop < len(c.checkpoints)-1 && now.Sub(c.checkpoints[drop].at) > audioClockWindow {
+		drop++
+	}
+	c.checkpoints = c.checkpoints[drop:]
+}
+
+func (c *audioClock) offsetLocked() time.Duration {
+	if c.bytesPerSec <= 0 {
+		return 0
+	}
+	return time.Duration(c.sent) * time.Second / time.Duration(c.bytesPerSec)
+}
+
+// wallTime converts an audio offset in seconds (as reported by Transcribe) to wall-clock time
+func (c *audioClock) wallTime(seconds float64) time.Time {
+	offset := time.Duration(seconds * float64(time.Second))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.checkpoints) == 0 {
+		return time.Now()
+	}
+	// Latest checkpoint at or before the offset
+	cp := c.checkpoints[0]
+	for _, candidate := range c.checkpoints {
+		if candidate.offset > offset {
+			break
+		}
+		cp = candidate
+	}
+	return cp.at.Add(offset - cp.offset)
+}
+
+// dominantSpeakerLabel returns the diarized label that covers most of the result's speech
+func dominantSpeakerLabel(items []types.Item) string {
+	spoken := make(map[string]float64)
+	best := ""
+	for _, item := range items {
+		label := aws.ToString(item.Speaker)
+		if label == "" {
+			continue
+		}
+		spoken[label] += item.EndTime - item.StartTime
+		if best == "" || spoken[label] > spoken[best] {
+			best = label
+		}
+	}
+	return best
+}
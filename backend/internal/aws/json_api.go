@@ -0,0 +1,109 @@
+package aws
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+
+	appconfig "realtime-backend/internal/config"
+)
+
+// jsonAPIClient calls an AWS JSON 1.1 service with SigV4-signed requests.
+// Used for the few batch operations (Transcribe jobs, Textract) that have no
+// generated service module in this build.
+type jsonAPIClient struct {
+	httpClient   *http.Client
+	creds        aws.CredentialsProvider
+	signer       *v4.Signer
+	service      string // SigV4 signing name ("transcribe")
+	targetPrefix string // X-Amz-Target prefix ("Transcribe")
+	region       string
+	endpoint     string
+}
+
+func newJSONAPIClient(ctx context.Context, cfg *appconfig.Config, region, service, targetPrefix string) (*jsonAPIClient, error) {
+	if region == "" {
+		region = cfg.S3.Region
+	}
+
+	awsCfg, err := config.LoadDefaultConfig(ctx,
+		config.WithRegion(region),
+		config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(
+			cfg.S3.AccessKeyID,
+			cfg.S3.SecretAccessKey,
+			"",
+		)),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &jsonAPIClient{
+		httpClient:   &http.Client{Timeout: 30 * time.Second},
+		creds:        awsCfg.Credentials,
+		signer:       v4.NewSigner(),
+		service:      service,
+		targetPrefix: targetPrefix,
+		region:       region,
+		endpoint:     fmt.Sprintf("https://%s.%s.amazonaws.com/", service, region),
+	}, nil
+}
+
+// call invokes one operation and decodes the response into output (if non-nil)
+func (c *jsonAPIClient) call(ctx context.Context, operation string, input, output interface{}) error {
+	body, err := json.Marshal(input)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", c.targetPrefix+"."+operation)
+
+	creds, err := c.creds.Retrieve(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve credentials: %w", err)
+	}
+	hash := sha256.Sum256(body)
+	if err := c.signer.SignHTTP(ctx, creds, req, hex.EncodeToString(hash[:]), c.service, c.region, time.Now()); err != nil {
+		return fmt.Errorf("failed to sign request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 8<<20))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		var apiErr struct {
+			Type    string `json:"__type"`
+			Message string `json:"message"`
+		}
+		json.Unmarshal(respBody, &apiErr)
+		return fmt.Errorf("%s %s failed (%d %s): %s", c.service, operation, resp.StatusCode, apiErr.Type, apiErr.Message)
+	}
+
+	if output == nil {
+		return nil
+	}
+	return json.Unmarshal(respBody, output)
+}
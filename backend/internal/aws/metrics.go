@@ -0,0 +1,30 @@
+package aws
+
+import (
+	"realtime-backend/internal/metrics"
+)
+
+// Pipeline stage labels for stageDuration / stageErrors
+const (
+	stageProcessAudio    = "process_audio"
+	stageTranscribeStart = "transcribe_start"
+	stageTranslate       = "translate"
+	stagePolly           = "polly"
+	stagePollyFirstChunk = "polly_first_chunk" // time until the first streamed TTS chunk
+)
+
+var (
+	stageDuration = metrics.NewHistogramVec("eum_pipeline_stage_duration_seconds",
+		"Latency of AWS translation pipeline stages", metrics.DefaultLatencyBuckets, "stage")
+	stageErrors = metrics.NewCounterVec("eum_pipeline_stage_errors_total",
+		"Failed AWS translation pipeline stage calls", "stage")
+	droppedTotal = metrics.NewCounterVec("eum_pipeline_dropped_total",
+		"Pipeline messages dropped because the output channel was full", "channel")
+	activeStreams = metrics.NewGaugeVec("eum_transcribe_streams_active",
+		"Transcribe streams currently open on this instance")
+)
+
+// dropped records a message dropped on a full pipeline channel ("transcript", "audio", "error")
+func dropped(channel string) {
+	droppedTotal.Inc(channel)
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"sync"
 	"time"
+	"unicode/utf8"
 
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
@@ -13,12 +14,17 @@ import (
 
 	"realtime-backend/internal/ai"
 	appconfig "realtime-backend/internal/config"
+	"realtime-backend/internal/errorreport"
+	"realtime-backend/internal/lifecycle"
 	"realtime-backend/pb"
 )
 
 // Stream timeout configuration
 const (
 	StreamIdleTimeout = 30 * time.Minute // Close stream after 30 minutes of inactivity
+
+	pipelineWorkerShutdownGrace = 10 * time.Second       // Goroutines still running this long after Close count as leaked
+	audioChunkSendTimeout       = 500 * time.Millisecond // How long a streamed TTS chunk waits for room in AudioChan
 )
 
 // Pipeline orchestrates STT -> Translate -> TTS flow using AWS services
@@ -27,11 +33,14 @@ type Pipeline struct {
 	translate  *TranslateClient
 	polly      *PollyClient
 	cache      *PipelineCache
+	redactor   *Redactor // Workspace profanity/PII redaction (nil = off)
 
 	// Per-speaker streams with last activity tracking
 	speakerStreams   map[string]*TranscribeStream
 	streamLastActive map[string]time.Time
+	streamHolds      map[string]time.Time // Streams kept open without audio until then (speaker reconnecting)
 	streamsMu        sync.RWMutex
+	idleTimeout      time.Duration // StreamIdleTimeout, or VADStreamIdleTimeout when audio is VAD-gated
 
 	// Output channels (compatible with ai.ChatStream)
 	TranscriptChan chan *ai.TranscriptMessage
@@ -42,21 +51,55 @@ type Pipeline struct {
 	targetLanguages []string
 	targetLangsMu   sync.RWMutex
 
+	// Room translation settings (TTS, partial throttling, confidence, allowed targets)
+	settings   TranslationSettings
+	settingsMu sync.RWMutex
+
+	// Attributes diarized results of MixedSpeakerID streams to participants (nil = keep labels)
+	resolveSpeaker SpeakerResolver
+
+	// Billable usage accounting and spend caps (nil = unmetered)
+	usage            UsageMeter
+	audioBytesPerSec int64
+
 	ctx    context.Context
 	cancel context.CancelFunc
+
+	// Long-lived pipeline goroutines; leaks after Close surface in /metrics
+	workers *lifecycle.Group
 }
 
 // PipelineConfig configuration for pipeline
 type PipelineConfig struct {
 	TargetLanguages []string
 	SampleRate      int32
+	Region          string               // AWS region for Transcribe/Translate/Polly (empty = cfg.S3.Region)
+	Terminologies   map[string]string    // Custom terminologies by "source:target" pair (workspace glossary)
+	Redaction       *RedactionConfig     // Profanity/PII redaction before Translate (workspace setting)
+	Settings        *TranslationSettings // Room translation settings (nil = defaults)
+
+	// VADAggressiveness tells the pipeline that callers drop silent frames (0 = off, 1-3).
+	// Gated streams only receive audio while someone speaks, so they are closed after a short silence.
+	VADAggressiveness int
+
+	// SpeakerResolver attributes diarized results of MixedSpeakerID streams to participants
+	SpeakerResolver SpeakerResolver
+
+	// Usage meters Transcribe/Translate/Polly spend (e.g. per workspace) and enforces caps
+	Usage UsageMeter
 }
 
 // NewPipeline creates a new AWS AI pipeline
 func NewPipeline(ctx context.Context, cfg *appconfig.Config, pipelineCfg *PipelineConfig) (*Pipeline, error) {
+	// Workspaces with a pinned data region get clients in that region
+	region := cfg.S3.Region
+	if pipelineCfg != nil && pipelineCfg.Region != "" {
+		region = pipelineCfg.Region
+	}
+
 	// Load AWS config using S3 credentials
 	awsCfg, err := config.LoadDefaultConfig(ctx,
-		config.WithRegion(cfg.S3.Region),
+		config.WithRegion(region),
 		config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(
 			cfg.S3.AccessKeyID,
 			cfg.S3.SecretAccessKey,
@@ -79,8 +122,13 @@ func NewPipeline(ctx context.Context, cfg *appconfig.Config, pipelineCfg *Pipeli
 		targetLangs = pipelineCfg.TargetLanguages
 	}
 
-	log.Printf("[AWS Pipeline] Initializing with region=%s, sampleRate=%d, targetLangs=%v",
-		cfg.S3.Region, sampleRate, targetLangs)
+	idleTimeout := StreamIdleTimeout
+	if pipelineCfg != nil && NormalizeVADAggressiveness(pipelineCfg.VADAggressiveness) != VADOff {
+		idleTimeout = VADStreamIdleTimeout
+	}
+
+	log.Printf("[AWS Pipeline] Initializing with region=%s, sampleRate=%d, targetLangs=%v, streamIdleTimeout=%s",
+		region, sampleRate, targetLangs, idleTimeout)
 
 	pipeline := &Pipeline{
 		transcribe:       NewTranscribeClient(awsCfg, sampleRate),
@@ -89,23 +137,58 @@ func NewPipeline(ctx context.Context, cfg *appconfig.Config, pipelineCfg *Pipeli
 		cache:            NewPipelineCache(DefaultCacheConfig()),
 		speakerStreams:   make(map[string]*TranscribeStream),
 		streamLastActive: make(map[string]time.Time),
+		streamHolds:      make(map[string]time.Time),
+		idleTimeout:      idleTimeout,
 		TranscriptChan:   make(chan *ai.TranscriptMessage, 50),
 		AudioChan:        make(chan *ai.AudioMessage, 100),
 		ErrChan:          make(chan error, 10),
 		targetLanguages:  targetLangs,
+		settings:         DefaultTranslationSettings(),
+		audioBytesPerSec: int64(sampleRate) * 2, // 16-bit mono PCM
 		ctx:              pCtx,
 		cancel:           cancel,
+		workers:          lifecycle.NewGroup("aws_pipeline", strings.Join(targetLangs, ",")),
+	}
+
+	if pipelineCfg != nil && len(pipelineCfg.Terminologies) > 0 {
+		pipeline.translate.SetTerminologies(pipelineCfg.Terminologies)
+		log.Printf("[AWS Pipeline] Using custom terminologies: %v", pipelineCfg.Terminologies)
+	}
+
+	if pipelineCfg != nil && pipelineCfg.Redaction != nil {
+		pipeline.redactor = NewRedactor(pipelineCfg.Redaction)
+		if pipeline.redactor != nil && len(pipelineCfg.Redaction.VocabularyFilters) > 0 {
+			pipeline.transcribe.SetVocabularyFilters(pipelineCfg.Redaction.VocabularyFilters, pipelineCfg.Redaction.Mode)
+		}
+		log.Printf("[AWS Pipeline] Redaction mode: %s (pii: %v, deny-list: %d)",
+			pipelineCfg.Redaction.Mode, pipelineCfg.Redaction.PII, len(pipelineCfg.Redaction.DenyList))
+	}
+
+	if pipelineCfg != nil && pipelineCfg.Settings != nil {
+		pipeline.ApplySettings(*pipelineCfg.Settings)
+	}
+
+	if pipelineCfg != nil {
+		pipeline.resolveSpeaker = pipelineCfg.SpeakerResolver
+		pipeline.usage = pipelineCfg.Usage
 	}
 
 	// Start stream timeout checker
-	go pipeline.streamTimeoutChecker()
+	pipeline.workers.Go("stream_timeout_checker", pipeline.streamTimeoutChecker)
 
 	return pipeline, nil
 }
 
 // streamTimeoutChecker periodically checks and closes idle streams
 func (p *Pipeline) streamTimeoutChecker() {
-	ticker := time.NewTicker(1 * time.Minute)
+	defer errorreport.Recover(errorreport.Context{Component: "aws.pipeline.timeout_checker"})
+
+	// VAD-gated streams idle out within seconds, so check more often than once a minute
+	interval := 1 * time.Minute
+	if p.idleTimeout/2 < interval {
+		interval = p.idleTimeout / 2
+	}
+	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
 
 	for {
@@ -125,11 +208,19 @@ func (p *Pipeline) closeIdleStreams() {
 
 	now := time.Now()
 	for key, lastActive := range p.streamLastActive {
-		if now.Sub(lastActive) > StreamIdleTimeout {
+		if hold, held := p.streamHolds[key]; held {
+			if now.Before(hold) {
+				continue
+			}
+			delete(p.streamHolds, key)
+		}
+		if now.Sub(lastActive) > p.idleTimeout {
 			if stream, exists := p.speakerStreams[key]; exists {
 				stream.Close()
 				delete(p.speakerStreams, key)
 				delete(p.streamLastActive, key)
+				delete(p.streamHolds, key)
+				activeStreams.Dec()
 				log.Printf("[AWS Pipeline] Closed idle stream: %s (inactive for %v)", key, now.Sub(lastActive))
 			}
 		}
@@ -141,6 +232,12 @@ func (p *Pipeline) ProcessAudio(speakerID, sourceLang, speakerName string, audio
 	// Debug log disabled to reduce noise
 	// log.Printf("[AWS Pipeline] ProcessAudio called: speaker=%s, lang=%s, audioSize=%d bytes",
 	// 	speakerID, sourceLang, len(audioData))
+	defer stageDuration.ObserveDuration(time.Now(), stageProcessAudio)
+
+	// Transcription cap reached: audio is dropped until the cap resets or is raised
+	if !p.usageAllowed(UsageTranscribeSeconds) {
+		return nil
+	}
 
 	stream, err := p.getOrCreateStream(speakerID, sourceLang)
 	if err != nil {
@@ -155,9 +252,13 @@ func (p *Pipeline) ProcessAudio(speakerID, sourceLang, speakerName string, audio
 	p.streamsMu.Unlock()
 
 	if err := stream.SendAudio(audioData); err != nil {
+		stageErrors.Inc(stageProcessAudio)
 		log.Printf("[AWS Pipeline] ERROR sending audio: %v", err)
 		return err
 	}
+	if p.audioBytesPerSec > 0 {
+		p.recordUsage(UsageTranscribeSeconds, float64(len(audioData))/float64(p.audioBytesPerSec))
+	}
 
 	return nil
 }
@@ -175,8 +276,12 @@ func (p *Pipeline) getOrCreateStream(speakerID, sourceLang string) (*TranscribeS
 		if stream.IsClosed() {
 			// Stream is dead, remove it and create new one
 			p.streamsMu.Lock()
-			delete(p.speakerStreams, key)
-			delete(p.streamLastActive, key)
+			if _, ok := p.speakerStreams[key]; ok {
+				delete(p.speakerStreams, key)
+				delete(p.streamLastActive, key)
+				delete(p.streamHolds, key)
+				activeStreams.Dec()
+			}
 			p.streamsMu.Unlock()
 			log.Printf("[AWS Pipeline] Removed dead stream for speaker %s, will recreate", speakerID)
 		} else {
@@ -193,18 +298,23 @@ func (p *Pipeline) getOrCreateStream(speakerID, sourceLang string) (*TranscribeS
 	}
 
 	// Create new stream
-	stream, err := p.transcribe.StartStream(p.ctx, speakerID, sourceLang)
+	startedAt := time.Now()
+	opts := StreamOptions{ShowSpeakerLabels: speakerID == MixedSpeakerID}
+	stream, err := p.transcribe.StartStream(p.ctx, speakerID, sourceLang, opts)
+	stageDuration.ObserveDuration(startedAt, stageTranscribeStart)
 	if err != nil {
+		stageErrors.Inc(stageTranscribeStart)
 		log.Printf("[AWS Pipeline] Failed to create Transcribe stream for speaker %s: %v", speakerID, err)
 		return nil, err
 	}
 
 	p.speakerStreams[key] = stream
+	activeStreams.Inc()
 
 	// Start processing transcripts from this stream
-	go p.processTranscripts(stream, sourceLang)
+	p.workers.Go("transcript_processor", func() { p.processTranscripts(stream, sourceLang) })
 
-	log.Printf("[AWS Pipeline] Created Transcribe stream for speaker %s (lang: %s)", speakerID, sourceLang)
+	log.Printf("[AWS Pipeline] Created Transcribe stream for speaker %s (lang: %s, diarization: %v)", speakerID, sourceLang, opts.ShowSpeakerLabels)
 
 	return stream, nil
 }
@@ -212,15 +322,43 @@ func (p *Pipeline) getOrCreateStream(speakerID, sourceLang string) (*TranscribeS
 // processTranscripts handles transcripts from a speaker stream
 func (p *Pipeline) processTranscripts(stream *TranscribeStream, sourceLang string) {
 	log.Printf("[AWS Pipeline] 🔄 processTranscripts started for stream (sourceLang: %s)", sourceLang)
+	defer errorreport.Recover(errorreport.Context{
+		Component: "aws.pipeline.transcripts",
+		Tags:      map[string]string{"speaker_id": stream.speakerID, "source_lang": sourceLang},
+	})
 
 	// Track last partial text for delta TTS (only send new portion)
 	var lastPartialText string
 	var lastTTSSentText string
+	var lastPartialSentAt time.Time
 
 	for result := range stream.TranscriptChan {
+		// Redact before anything reaches Translate/Polly or listeners
+		text, ok := p.redactor.Apply(result.Text)
+		if !ok {
+			log.Printf("[AWS Pipeline] 🚫 Dropped transcript with filtered content (isFinal: %v)", result.IsFinal)
+			continue
+		}
+		result.Text = text
+
+		// Mixed room feed: attribute the diarized label to a participant
+		if result.SpeakerLabel != "" {
+			result.SpeakerID = p.attributeSpeaker(result)
+		}
+
 		log.Printf("[AWS Pipeline] 📨 Received transcript: '%s' (isFinal: %v, confidence: %.2f, lang: %s)",
 			result.Text, result.IsFinal, result.Confidence, sourceLang)
 
+		settings := p.Settings()
+
+		// Throttle partials per speaker stream; finals always go through
+		if !result.IsFinal && settings.PartialInterval > 0 {
+			if time.Since(lastPartialSentAt) < settings.PartialInterval {
+				continue
+			}
+			lastPartialSentAt = time.Now()
+		}
+
 		// For Korean→Japanese: translate and TTS partials immediately for real-time experience
 		if sourceLang == "ko" && !result.IsFinal {
 			text := strings.TrimSpace(result.Text)
@@ -228,18 +366,8 @@ func (p *Pipeline) processTranscripts(stream *TranscribeStream, sourceLang strin
 
 			// Only process if text is long enough and different from last
 			if len([]rune(text)) >= 3 && text != lastPartialText {
-				// Check if Japanese is in target languages
-				p.targetLangsMu.RLock()
-				hasJapaneseTarget := false
-				for _, lang := range p.targetLanguages {
-					if lang == "ja" {
-						hasJapaneseTarget = true
-						break
-					}
-				}
-				p.targetLangsMu.RUnlock()
-
-				if hasJapaneseTarget {
+				// Chunk TTS only makes sense while TTS is on and Japanese is an allowed target
+				if settings.TTSEnabled && p.hasTargetLanguage("ja") {
 					// Calculate delta (new portion only)
 					deltaText := text
 					if strings.HasPrefix(text, lastTTSSentText) && len(text) > len(lastTTSSentText) {
@@ -260,14 +388,14 @@ func (p *Pipeline) processTranscripts(stream *TranscribeStream, sourceLang strin
 
 			// Only send regular partial if we didn't already send a translated partial
 			if !sentTranslatedPartial {
-				p.sendPartialTranscript(result)
+				p.sendPartialTranscript(result, settings.MinConfidence)
 			}
 			continue
 		}
 
 		// For other languages: send partial without translation
 		if !result.IsFinal {
-			p.sendPartialTranscript(result)
+			p.sendPartialTranscript(result, settings.MinConfidence)
 			continue
 		}
 
@@ -281,18 +409,7 @@ func (p *Pipeline) processTranscripts(stream *TranscribeStream, sourceLang strin
 
 		// Process final result: Translate + TTS (skip TTS if we already sent partials for KO→JA)
 		if sourceLang == "ko" && sentPartialTTS {
-			// Check if Japanese is in targets
-			p.targetLangsMu.RLock()
-			hasJapaneseTarget := false
-			for _, lang := range p.targetLanguages {
-				if lang == "ja" {
-					hasJapaneseTarget = true
-					break
-				}
-			}
-			p.targetLangsMu.RUnlock()
-
-			if hasJapaneseTarget {
+			if p.hasTargetLanguage("ja") {
 				// Skip TTS for Japanese since we already sent chunk TTS
 				go p.processFinalTranscriptNoTTS(result, sourceLang, "ja")
 				continue
@@ -318,7 +435,7 @@ func (p *Pipeline) processPartialWithTranslationAndTTS(result *TranscriptResult,
 	log.Printf("[AWS Pipeline] 🇯🇵 Processing delta chunk: '%s'", deltaText)
 
 	// Translate the delta text
-	trans, err := p.translate.Translate(ctx, deltaText, sourceLang, targetLang)
+	trans, err := p.translateText(ctx, deltaText, sourceLang, targetLang)
 	if err != nil {
 		log.Printf("[AWS Pipeline] Partial translation error: %v", err)
 		return
@@ -354,40 +471,18 @@ func (p *Pipeline) processPartialWithTranslationAndTTS(result *TranscriptResult,
 	case p.TranscriptChan <- transcriptMsg:
 		log.Printf("[AWS Pipeline] 🇯🇵 KO→JA chunk: '%s' → '%s'", deltaText, trans.TranslatedText)
 	default:
+		dropped("transcript")
 		log.Printf("[AWS Pipeline] Transcript channel full (KO→JA partial)")
 	}
 
-	// Generate TTS immediately for the delta translation
-	audio, err := p.polly.Synthesize(ctx, trans.TranslatedText, targetLang)
-	if err != nil {
-		log.Printf("[AWS Pipeline] Partial TTS error: %v", err)
-		return
-	}
-
-	if len(audio.AudioData) == 0 {
-		return
-	}
-
-	// Send TTS audio
-	audioMsg := &ai.AudioMessage{
-		TranscriptID:         transcriptMsg.ID,
-		TargetLanguage:       targetLang,
-		AudioData:            audio.AudioData,
-		Format:               audio.Format,
-		SampleRate:           uint32(audio.SampleRate),
-		SpeakerParticipantID: result.SpeakerID,
-	}
-
-	select {
-	case p.AudioChan <- audioMsg:
-		log.Printf("[AWS Pipeline] 🔊 KO→JA chunk TTS: '%s' (%d bytes)", trans.TranslatedText, len(audio.AudioData))
-	default:
-		log.Printf("[AWS Pipeline] Audio channel full (KO→JA partial)")
+	// Stream TTS immediately for the delta translation
+	if n := p.streamTTS(ctx, transcriptMsg.ID, result.SpeakerID, targetLang, trans.TranslatedText); n > 0 {
+		log.Printf("[AWS Pipeline] 🔊 KO→JA chunk TTS: '%s' (%d bytes)", trans.TranslatedText, n)
 	}
 }
 
 // sendPartialTranscript sends a partial transcript without translation
-func (p *Pipeline) sendPartialTranscript(result *TranscriptResult) {
+func (p *Pipeline) sendPartialTranscript(result *TranscriptResult, minConfidence float32) {
 	// Apply lighter noise filtering for partials (allow lower confidence for real-time feedback)
 	text := strings.TrimSpace(result.Text)
 	runes := []rune(text)
@@ -398,7 +493,7 @@ func (p *Pipeline) sendPartialTranscript(result *TranscriptResult) {
 	}
 
 	// Skip very low confidence partials
-	if result.Confidence > 0 && result.Confidence < 0.4 {
+	if result.Confidence > 0 && result.Confidence < minConfidence*partialConfidenceRatio {
 		return
 	}
 
@@ -433,6 +528,7 @@ func (p *Pipeline) sendPartialTranscript(result *TranscriptResult) {
 	select {
 	case p.TranscriptChan <- msg:
 	default:
+		dropped("transcript")
 		log.Printf("[AWS Pipeline] Transcript channel full (partial)")
 	}
 }
@@ -469,7 +565,7 @@ var noisePatterns = map[string][]string{
 }
 
 // isNoiseText checks if text is likely noise/hallucination
-func isNoiseText(text string, sourceLang string, confidence float32) bool {
+func isNoiseText(text string, sourceLang string, confidence, minConfidence float32) bool {
 	text = strings.TrimSpace(text)
 	runes := []rune(text)
 
@@ -479,7 +575,7 @@ func isNoiseText(text string, sourceLang string, confidence float32) bool {
 	}
 
 	// Low confidence
-	if confidence > 0 && confidence < MinConfidenceThreshold {
+	if confidence > 0 && confidence < minConfidence {
 		return true
 	}
 
@@ -539,15 +635,13 @@ func (p *Pipeline) processFinalTranscript(result *TranscriptResult, sourceLang s
 	ctx, cancel := context.WithTimeout(p.ctx, 15*time.Second)
 	defer cancel()
 
-	// Get target languages
-	p.targetLangsMu.RLock()
-	targetLangs := make([]string, len(p.targetLanguages))
-	copy(targetLangs, p.targetLanguages)
-	p.targetLangsMu.RUnlock()
+	// Get target languages (restricted to the room's allowed list)
+	targetLangs := p.targetLanguagesSnapshot()
+	settings := p.Settings()
 
 	// Enhanced noise filtering
 	text := strings.TrimSpace(result.Text)
-	if isNoiseText(text, sourceLang, result.Confidence) {
+	if isNoiseText(text, sourceLang, result.Confidence, settings.MinConfidence) {
 		// Only log if it's not a super short text to reduce log spam
 		if len([]rune(text)) >= 2 {
 			log.Printf("[AWS Pipeline] Filtering noise: '%s' (confidence: %.2f)", text, result.Confidence)
@@ -581,7 +675,7 @@ func (p *Pipeline) processFinalTranscript(result *TranscriptResult, sourceLang s
 			}
 
 			// Call Translate API
-			trans, err := p.translate.Translate(ctx, result.Text, sourceLang, tgtLang)
+			trans, err := p.translateText(ctx, result.Text, sourceLang, tgtLang)
 			if err != nil {
 				log.Printf("[AWS Pipeline] Translation error for %s: %v", tgtLang, err)
 				return
@@ -627,9 +721,14 @@ func (p *Pipeline) processFinalTranscript(result *TranscriptResult, sourceLang s
 	case p.TranscriptChan <- transcriptMsg:
 		log.Printf("[AWS Pipeline] Sent transcript with %d translations", len(transcriptMsg.Translations))
 	default:
+		dropped("transcript")
 		log.Printf("[AWS Pipeline] Transcript channel full")
 	}
 
+	if !settings.TTSEnabled {
+		return
+	}
+
 	// Generate TTS for each target language (parallel, with caching)
 	log.Printf("[AWS Pipeline] 🔊 Generating TTS for %d translations", len(translations))
 
@@ -655,49 +754,8 @@ func (p *Pipeline) processFinalTranscript(result *TranscriptResult, sourceLang s
 
 			log.Printf("[AWS Pipeline] 🎙️ Generating TTS for '%s' in %s", text, targetLang)
 
-			var audioData []byte
-			var format string = "mp3"
-			var sampleRate int32 = 24000
-
-			// Check TTS cache first
-			if cached, ok := p.cache.GetTTS(text, targetLang); ok {
-				audioData = cached
-				log.Printf("[AWS Pipeline] 📦 TTS cache hit for %s", targetLang)
-			} else {
-				// Call Polly API
-				audio, err := p.polly.Synthesize(ctx, text, targetLang)
-				if err != nil {
-					log.Printf("[AWS Pipeline] ❌ TTS error for %s: %v", targetLang, err)
-					return
-				}
-
-				if len(audio.AudioData) == 0 {
-					log.Printf("[AWS Pipeline] ⚠️ Empty audio data from Polly for %s", targetLang)
-					return
-				}
-
-				// Store in cache
-				p.cache.SetTTS(text, targetLang, audio.AudioData)
-
-				audioData = audio.AudioData
-				format = audio.Format
-				sampleRate = audio.SampleRate
-			}
-
-			audioMsg := &ai.AudioMessage{
-				TranscriptID:         transcriptMsg.ID,
-				TargetLanguage:       targetLang,
-				AudioData:            audioData,
-				Format:               format,
-				SampleRate:           uint32(sampleRate),
-				SpeakerParticipantID: result.SpeakerID,
-			}
-
-			select {
-			case p.AudioChan <- audioMsg:
-				log.Printf("[AWS Pipeline] ✅ Sent TTS audio for %s (%d bytes)", targetLang, len(audioData))
-			default:
-				log.Printf("[AWS Pipeline] ⚠️ Audio channel full for %s", targetLang)
+			if n := p.streamTTS(ctx, transcriptMsg.ID, result.SpeakerID, targetLang, text); n > 0 {
+				log.Printf("[AWS Pipeline] ✅ Sent TTS audio for %s (%d bytes)", targetLang, n)
 			}
 		}(lang, trans.TranslatedText)
 	}
@@ -711,15 +769,13 @@ func (p *Pipeline) processFinalTranscriptNoTTS(result *TranscriptResult, sourceL
 	ctx, cancel := context.WithTimeout(p.ctx, 15*time.Second)
 	defer cancel()
 
-	// Get target languages
-	p.targetLangsMu.RLock()
-	targetLangs := make([]string, len(p.targetLanguages))
-	copy(targetLangs, p.targetLanguages)
-	p.targetLangsMu.RUnlock()
+	// Get target languages (restricted to the room's allowed list)
+	targetLangs := p.targetLanguagesSnapshot()
+	settings := p.Settings()
 
 	// Enhanced noise filtering
 	text := strings.TrimSpace(result.Text)
-	if isNoiseText(text, sourceLang, result.Confidence) {
+	if isNoiseText(text, sourceLang, result.Confidence, settings.MinConfidence) {
 		if len([]rune(text)) >= 2 {
 			log.Printf("[AWS Pipeline] Filtering noise (NoTTS): '%s' (confidence: %.2f)", text, result.Confidence)
 		}
@@ -751,7 +807,7 @@ func (p *Pipeline) processFinalTranscriptNoTTS(result *TranscriptResult, sourceL
 			}
 
 			// Call Translate API
-			trans, err := p.translate.Translate(ctx, result.Text, sourceLang, tgtLang)
+			trans, err := p.translateText(ctx, result.Text, sourceLang, tgtLang)
 			if err != nil {
 				log.Printf("[AWS Pipeline] Translation error for %s: %v", tgtLang, err)
 				return
@@ -797,9 +853,14 @@ func (p *Pipeline) processFinalTranscriptNoTTS(result *TranscriptResult, sourceL
 	case p.TranscriptChan <- transcriptMsg:
 		log.Printf("[AWS Pipeline] Sent transcript (NoTTS for %s) with %d translations", skipTTSLang, len(transcriptMsg.Translations))
 	default:
+		dropped("transcript")
 		log.Printf("[AWS Pipeline] Transcript channel full")
 	}
 
+	if !settings.TTSEnabled {
+		return
+	}
+
 	// Generate TTS for each target language EXCEPT skipTTSLang
 	var wg sync.WaitGroup
 	for lang, trans := range translations {
@@ -822,51 +883,93 @@ func (p *Pipeline) processFinalTranscriptNoTTS(result *TranscriptResult, sourceL
 
 			log.Printf("[AWS Pipeline] 🎙️ Generating TTS for '%s' in %s", text, targetLang)
 
-			var audioData []byte
-			var format string = "mp3"
-			var sampleRate int32 = 24000
-
-			// Check TTS cache first
-			if cached, ok := p.cache.GetTTS(text, targetLang); ok {
-				audioData = cached
-			} else {
-				// Call Polly API
-				audio, err := p.polly.Synthesize(ctx, text, targetLang)
-				if err != nil {
-					log.Printf("[AWS Pipeline] ❌ TTS error for %s: %v", targetLang, err)
-					return
-				}
+			if n := p.streamTTS(ctx, transcriptMsg.ID, result.SpeakerID, targetLang, text); n > 0 {
+				log.Printf("[AWS Pipeline] ✅ Sent TTS audio for %s (%d bytes)", targetLang, n)
+			}
+		}(lang, trans.TranslatedText)
+	}
+	wg.Wait()
+}
 
-				if len(audio.AudioData) == 0 {
-					return
-				}
+// translateText calls Translate unless the translation cap is reached, and meters the characters
+// actually sent (same-language and empty texts never reach the API)
+func (p *Pipeline) translateText(ctx context.Context, text, sourceLang, targetLang string) (*TranslationResult, error) {
+	if !p.usageAllowed(UsageTranslateChars) {
+		return nil, errUsageCapReached
+	}
+	trans, err := p.translate.Translate(ctx, text, sourceLang, targetLang)
+	if err == nil && text != "" && trans.SourceLanguage != trans.TargetLanguage {
+		p.recordUsage(UsageTranslateChars, float64(utf8.RuneCountInString(text)))
+	}
+	return trans, err
+}
 
-				// Store in cache
-				p.cache.SetTTS(text, targetLang, audio.AudioData)
+// streamTTS synthesizes text and sends it to AudioChan as sequenced chunks while Polly is still
+// producing it. A cache hit goes out as a single last chunk. Returns the number of bytes sent.
+func (p *Pipeline) streamTTS(ctx context.Context, transcriptID, speakerID, targetLang, text string) int {
+	if cached, ok := p.cache.GetTTS(text, targetLang); ok {
+		log.Printf("[AWS Pipeline] 📦 TTS cache hit for %s", targetLang)
+		p.sendAudioChunk(transcriptID, speakerID, targetLang, cached, 0, true)
+		return len(cached)
+	}
 
-				audioData = audio.AudioData
-				format = audio.Format
-				sampleRate = audio.SampleRate
-			}
+	if !p.usageAllowed(UsageTTSChars) {
+		log.Printf("[AWS Pipeline] ⏭️ Skipping TTS for %s: usage cap reached", targetLang)
+		return 0
+	}
 
-			audioMsg := &ai.AudioMessage{
-				TranscriptID:         transcriptMsg.ID,
-				TargetLanguage:       targetLang,
-				AudioData:            audioData,
-				Format:               format,
-				SampleRate:           uint32(sampleRate),
-				SpeakerParticipantID: result.SpeakerID,
-			}
+	var seq uint32
+	audioData, err := p.polly.SynthesizeStream(ctx, text, targetLang, func(chunk []byte, last bool) {
+		p.sendAudioChunk(transcriptID, speakerID, targetLang, chunk, seq, last)
+		seq++
+	})
+	if err != nil {
+		log.Printf("[AWS Pipeline] ❌ TTS error for %s: %v", targetLang, err)
+		// Close a stream that already started so listeners do not wait for the rest
+		if seq > 0 {
+			p.sendAudioChunk(transcriptID, speakerID, targetLang, nil, seq, true)
+		}
+		return 0
+	}
+	if len(audioData) == 0 {
+		log.Printf("[AWS Pipeline] ⚠️ Empty audio data from Polly for %s", targetLang)
+		return 0
+	}
 
-			select {
-			case p.AudioChan <- audioMsg:
-				log.Printf("[AWS Pipeline] ✅ Sent TTS audio for %s (%d bytes)", targetLang, len(audioData))
-			default:
-				log.Printf("[AWS Pipeline] ⚠️ Audio channel full for %s", targetLang)
-			}
-		}(lang, trans.TranslatedText)
+	p.recordUsage(UsageTTSChars, float64(utf8.RuneCountInString(text)))
+	p.cache.SetTTS(text, targetLang, audioData)
+	return len(audioData)
+}
+
+// sendAudioChunk sends one streamed TTS chunk. A dropped chunk corrupts the whole stream, so this
+// waits briefly for room in AudioChan instead of dropping right away.
+func (p *Pipeline) sendAudioChunk(transcriptID, speakerID, targetLang string, chunk []byte, seq uint32, last bool) {
+	audioMsg := &ai.AudioMessage{
+		TranscriptID:         transcriptID,
+		TargetLanguage:       targetLang,
+		AudioData:            chunk,
+		Format:               "mp3",
+		SampleRate:           24000,
+		SpeakerParticipantID: speakerID,
+		Streamed:             true,
+		Sequence:             seq,
+		Last:                 last,
+	}
+
+	select {
+	case p.AudioChan <- audioMsg:
+		return
+	default:
+	}
+
+	timer := time.NewTimer(audioChunkSendTimeout)
+	defer timer.Stop()
+	select {
+	case p.AudioChan <- audioMsg:
+	case <-timer.C:
+		dropped("audio")
+		log.Printf("[AWS Pipeline] ⚠️ Audio channel full for %s (chunk %d)", targetLang, seq)
 	}
-	wg.Wait()
 }
 
 // sendError sends an error to the error channel
@@ -874,6 +977,7 @@ func (p *Pipeline) sendError(err error) {
 	select {
 	case p.ErrChan <- err:
 	default:
+		dropped("error")
 	}
 }
 
@@ -885,6 +989,27 @@ func (p *Pipeline) UpdateTargetLanguages(langs []string) {
 	log.Printf("[AWS Pipeline] Updated target languages: %v", langs)
 }
 
+// hasTargetLanguage reports whether lang is a current, allowed target language
+func (p *Pipeline) hasTargetLanguage(lang string) bool {
+	for _, target := range p.targetLanguagesSnapshot() {
+		if target == lang {
+			return true
+		}
+	}
+	return false
+}
+
+// attributeSpeaker returns the participant ID for a diarized result.
+// Unresolved labels stay distinguishable as "mixed:spk_N".
+func (p *Pipeline) attributeSpeaker(result *TranscriptResult) string {
+	if p.resolveSpeaker != nil {
+		if participantID := p.resolveSpeaker(result.SpeakerLabel, result.StartTime, result.EndTime); participantID != "" {
+			return participantID
+		}
+	}
+	return MixedSpeakerID + ":" + result.SpeakerLabel
+}
+
 // RemoveSpeakerStream removes a speaker's transcription stream
 func (p *Pipeline) RemoveSpeakerStream(speakerID, sourceLang string) {
 	key := speakerID + ":" + sourceLang
@@ -895,10 +1020,40 @@ func (p *Pipeline) RemoveSpeakerStream(speakerID, sourceLang string) {
 	if stream, exists := p.speakerStreams[key]; exists {
 		stream.Close()
 		delete(p.speakerStreams, key)
+		delete(p.streamLastActive, key)
+		delete(p.streamHolds, key)
+		activeStreams.Dec()
 		log.Printf("[AWS Pipeline] Removed stream for speaker %s", speakerID)
 	}
 }
 
+// HoldSpeakerStream keeps a speaker's stream open without audio until the given time, so a
+// speaker whose connection dropped can resume on the same stream. The stream's keep-alive
+// silence stops Transcribe from timing out meanwhile. A zero time releases the hold.
+func (p *Pipeline) HoldSpeakerStream(speakerID, sourceLang string, until time.Time) {
+	key := speakerID + ":" + sourceLang
+
+	p.streamsMu.Lock()
+	defer p.streamsMu.Unlock()
+
+	if until.IsZero() {
+		delete(p.streamHolds, key)
+		return
+	}
+	if _, exists := p.speakerStreams[key]; exists {
+		p.streamHolds[key] = until
+	}
+}
+
+// HasSpeakerStream reports whether the speaker has an open Transcribe stream
+func (p *Pipeline) HasSpeakerStream(speakerID, sourceLang string) bool {
+	p.streamsMu.RLock()
+	defer p.streamsMu.RUnlock()
+
+	stream, exists := p.speakerStreams[speakerID+":"+sourceLang]
+	return exists && !stream.IsClosed()
+}
+
 // Close shuts down the pipeline
 func (p *Pipeline) Close() error {
 	p.cancel()
@@ -907,6 +1062,7 @@ func (p *Pipeline) Close() error {
 	for key, stream := range p.speakerStreams {
 		stream.Close()
 		delete(p.speakerStreams, key)
+		activeStreams.Dec()
 	}
 	p.streamsMu.Unlock()
 
@@ -920,5 +1076,7 @@ func (p *Pipeline) Close() error {
 	close(p.ErrChan)
 
 	log.Printf("[AWS Pipeline] Pipeline closed")
+
+	go p.workers.Close(pipelineWorkerShutdownGrace)
 	return nil
 }
@@ -0,0 +1,36 @@
+package aws
+
+import (
+	"context"
+	"testing"
+
+	"go.uber.org/goleak"
+
+	appconfig "realtime-backend/internal/config"
+)
+
+// TestPipelineCloseStopsWorkers builds a pipeline with static credentials (no AWS calls are made
+// until audio arrives) and checks that Close stops the timeout checker and the cache cleanup loop.
+func TestPipelineCloseStopsWorkers(t *testing.T) {
+	defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+
+	cfg := &appconfig.Config{S3: appconfig.S3Config{
+		Region:          "us-east-1",
+		AccessKeyID:     "test",
+		SecretAccessKey: "test",
+	}}
+	p, err := NewPipeline(context.Background(), cfg, &PipelineConfig{
+		TargetLanguages:   []string{"en", "ko"},
+		VADAggressiveness: 2,
+	})
+	if err != nil {
+		t.Fatalf("NewPipeline: %v", err)
+	}
+	if got := p.workers.Running()["stream_timeout_checker"]; got != 1 {
+		t.Fatalf("stream_timeout_checker running = %d, want 1", got)
+	}
+
+	if err := p.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+}
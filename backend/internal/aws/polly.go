@@ -4,10 +4,13 @@ import (
 	"context"
 	"io"
 	"log"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/polly"
 	"github.com/aws/aws-sdk-go-v2/service/polly/types"
+
+	lang "realtime-backend/internal/language"
 )
 
 // PollyClient wraps Amazon Polly TTS
@@ -30,19 +33,15 @@ type AudioResult struct {
 	Language   string
 }
 
-// 언어별 기본 Neural 음성 설정
-var defaultVoices = map[string]pollyVoiceConfig{
-	"ko": {VoiceID: types.VoiceIdSeoyeon, Engine: types.EngineNeural},
-	"en": {VoiceID: types.VoiceIdJoanna, Engine: types.EngineNeural},
-	"ja": {VoiceID: types.VoiceIdMizuki, Engine: types.EngineStandard}, // Mizuki는 Standard만 지원
-	"zh": {VoiceID: types.VoiceIdZhiyu, Engine: types.EngineNeural},
-}
-
 // NewPollyClient creates a new Polly TTS client
+// Default voices come from the language registry (languages without a Polly voice have no TTS)
 func NewPollyClient(cfg aws.Config) *PollyClient {
 	voices := make(map[string]pollyVoiceConfig)
-	for k, v := range defaultVoices {
-		voices[k] = v
+	for _, l := range lang.All() {
+		if !l.TTS {
+			continue
+		}
+		voices[l.Code] = pollyVoiceConfig{VoiceID: types.VoiceId(l.PollyVoice), Engine: types.Engine(l.PollyEngine)}
 	}
 
 	return &PollyClient{
@@ -51,40 +50,59 @@ func NewPollyClient(cfg aws.Config) *PollyClient {
 	}
 }
 
-// Synthesize generates speech from text
-func (c *PollyClient) Synthesize(ctx context.Context, text, language string) (*AudioResult, error) {
-	if text == "" {
-		return &AudioResult{
-			AudioData:  []byte{},
-			Format:     "mp3",
-			SampleRate: 24000,
-			Language:   language,
-		}, nil
-	}
+// TTSChunkSize is the size of the MP3 chunks SynthesizeStream hands out
+const TTSChunkSize = 4 * 1024
 
-	voiceCfg, ok := c.voices[language]
+// hasVoice reports whether text should be synthesized at all: empty text and registry
+// languages without a Polly voice (subtitles only) produce no audio
+func hasVoice(text, language string) bool {
+	return text != "" && !(lang.IsSupported(language) && !lang.SupportsTTS(language))
+}
+
+// speechInput builds the SynthesizeSpeech request for a language, falling back to English
+func (c *PollyClient) speechInput(text, language string) *polly.SynthesizeSpeechInput {
+	voiceCfg, ok := c.voices[lang.NormalizeOr(language, language)]
 	if !ok {
 		voiceCfg = c.voices["en"] // 기본값: 영어
 		log.Printf("[Polly] Unknown language '%s', defaulting to English", language)
 	}
 
-	input := &polly.SynthesizeSpeechInput{
+	return &polly.SynthesizeSpeechInput{
 		Text:         aws.String(text),
 		VoiceId:      voiceCfg.VoiceID,
 		Engine:       voiceCfg.Engine,
 		OutputFormat: types.OutputFormatMp3,
 		SampleRate:   aws.String("24000"),
 	}
+}
 
+// Synthesize generates speech from text
+func (c *PollyClient) Synthesize(ctx context.Context, text, language string) (*AudioResult, error) {
+	if !hasVoice(text, language) {
+		return &AudioResult{
+			AudioData:  []byte{},
+			Format:     "mp3",
+			SampleRate: 24000,
+			Language:   language,
+		}, nil
+	}
+
+	input := c.speechInput(text, language)
+
+	startedAt := time.Now()
 	output, err := c.client.SynthesizeSpeech(ctx, input)
 	if err != nil {
+		stageDuration.ObserveDuration(startedAt, stagePolly)
+		stageErrors.Inc(stagePolly)
 		log.Printf("[Polly] Error synthesizing speech for language %s: %v", language, err)
 		return nil, err
 	}
 	defer output.AudioStream.Close()
 
 	audioData, err := io.ReadAll(output.AudioStream)
+	stageDuration.ObserveDuration(startedAt, stagePolly)
 	if err != nil {
+		stageErrors.Inc(stagePolly)
 		log.Printf("[Polly] Error reading audio stream: %v", err)
 		return nil, err
 	}
@@ -98,3 +116,54 @@ func (c *PollyClient) Synthesize(ctx context.Context, text, language string) (*A
 		Language:   language,
 	}, nil
 }
+
+// SynthesizeStream generates speech from text and hands the MP3 to emit in TTSChunkSize chunks
+// as Polly produces it, so playback can start before synthesis finishes. The chunk flagged last
+// ends the stream. Returns the whole audio (for caching); empty when the language has no voice.
+func (c *PollyClient) SynthesizeStream(ctx context.Context, text, language string, emit func(chunk []byte, last bool)) ([]byte, error) {
+	if !hasVoice(text, language) {
+		return []byte{}, nil
+	}
+
+	startedAt := time.Now()
+	output, err := c.client.SynthesizeSpeech(ctx, c.speechInput(text, language))
+	if err != nil {
+		stageDuration.ObserveDuration(startedAt, stagePolly)
+		stageErrors.Inc(stagePolly)
+		log.Printf("[Polly] Error synthesizing speech for language %s: %v", language, err)
+		return nil, err
+	}
+	defer output.AudioStream.Close()
+
+	// Hold one chunk back so the final one can be flagged as last
+	var audioData, pending []byte
+	buf := make([]byte, TTSChunkSize)
+	for {
+		n, err := io.ReadFull(output.AudioStream, buf)
+		if n > 0 {
+			if pending == nil {
+				stageDuration.ObserveDuration(startedAt, stagePollyFirstChunk)
+			} else {
+				emit(pending, false)
+			}
+			pending = append([]byte(nil), buf[:n]...)
+			audioData = append(audioData, pending...)
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			stageDuration.ObserveDuration(startedAt, stagePolly)
+			stageErrors.Inc(stagePolly)
+			log.Printf("[Polly] Error reading audio stream: %v", err)
+			return audioData, err
+		}
+	}
+	stageDuration.ObserveDuration(startedAt, stagePolly)
+
+	if pending != nil {
+		emit(pending, true)
+	}
+	log.Printf("[Polly] Streamed %d bytes of audio for language %s", len(audioData), language)
+	return audioData, nil
+}
@@ -0,0 +1,104 @@
+package aws
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/transcribestreaming/types"
+)
+
+// RedactionMode decides what happens to an utterance containing filtered content
+type RedactionMode string
+
+const (
+	RedactionOff  RedactionMode = "OFF"
+	RedactionMask RedactionMode = "MASK" // replace matches with "***"
+	RedactionDrop RedactionMode = "DROP" // drop the whole utterance
+)
+
+// RedactionConfig is the workspace redaction setting applied between Transcribe and Translate
+type RedactionConfig struct {
+	Mode              RedactionMode
+	DenyList          []string          // Workspace deny-list (case-insensitive, substring match)
+	PII               bool              // Redact e-mail addresses, phone/card/resident registration numbers
+	VocabularyFilters map[string]string // Transcribe vocabulary filter name by source language
+}
+
+// piiPatterns matches personal data commonly spoken in meetings
+var piiPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`),                   // e-mail
+	regexp.MustCompile(`\b\d{6}\s?-\s?[1-4]\d{6}\b`),                                       // KR resident registration number
+	regexp.MustCompile(`\b(?:\d{4}[\s-]?){3}\d{1,4}\b`),                                    // card number
+	regexp.MustCompile(`(?:\+?\d{1,3}[\s-]?)?\(?0?1[016789]\)?[\s-]?\d{3,4}[\s-]?\d{4}\b`), // mobile phone
+}
+
+// Redactor masks or drops filtered words and PII in transcripts
+type Redactor struct {
+	mode     RedactionMode
+	denyList *regexp.Regexp
+	pii      bool
+}
+
+// NewRedactor builds a redactor from a workspace setting (nil when redaction is off)
+func NewRedactor(cfg *RedactionConfig) *Redactor {
+	if cfg == nil || (cfg.Mode != RedactionMask && cfg.Mode != RedactionDrop) {
+		return nil
+	}
+
+	r := &Redactor{mode: cfg.Mode, pii: cfg.PII}
+
+	words := make([]string, 0, len(cfg.DenyList))
+	for _, w := range cfg.DenyList {
+		if w = strings.TrimSpace(w); w != "" {
+			words = append(words, regexp.QuoteMeta(w))
+		}
+	}
+	if len(words) > 0 {
+		// Longest first so overlapping entries mask the whole phrase
+		sort.Slice(words, func(i, j int) bool { return len(words[i]) > len(words[j]) })
+		r.denyList = regexp.MustCompile(`(?i)` + strings.Join(words, "|"))
+	}
+
+	if r.denyList == nil && !r.pii {
+		return nil
+	}
+	return r
+}
+
+// Apply redacts text. ok is false when the utterance must be dropped.
+func (r *Redactor) Apply(text string) (redacted string, ok bool) {
+	if r == nil || text == "" {
+		return text, true
+	}
+
+	matched := false
+	replace := func(re *regexp.Regexp) {
+		text = re.ReplaceAllStringFunc(text, func(string) string {
+			matched = true
+			return "***"
+		})
+	}
+
+	if r.denyList != nil {
+		replace(r.denyList)
+	}
+	if r.pii {
+		for _, re := range piiPatterns {
+			replace(re)
+		}
+	}
+
+	if matched && r.mode == RedactionDrop {
+		return "", false
+	}
+	return text, true
+}
+
+// vocabularyFilterMethod maps the workspace mode to the Transcribe vocabulary filter method
+func vocabularyFilterMethod(mode RedactionMode) types.VocabularyFilterMethod {
+	if mode == RedactionDrop {
+		return types.VocabularyFilterMethodRemove
+	}
+	return types.VocabularyFilterMethodMask
+}
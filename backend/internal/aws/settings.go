@@ -0,0 +1,84 @@
+package aws
+
+import (
+	"log"
+	"time"
+)
+
+// TranslationSettings are per-room knobs applied live to a running pipeline
+type TranslationSettings struct {
+	TTSEnabled      bool          // Synthesize translated speech (false = text only)
+	PartialInterval time.Duration // Minimum gap between partial transcripts per speaker (0 = no throttling)
+	MinConfidence   float32       // Finals below this confidence are treated as noise
+	AllowedTargets  []string      // Target languages the room may translate into (empty = all)
+}
+
+// partialConfidenceRatio keeps partial filtering lighter than finals (0.4 at the default 0.5)
+const partialConfidenceRatio = 0.8
+
+// DefaultTranslationSettings returns the settings used when a room has none stored
+func DefaultTranslationSettings() TranslationSettings {
+	return TranslationSettings{
+		TTSEnabled:    true,
+		MinConfidence: MinConfidenceThreshold,
+	}
+}
+
+// AllowsTarget reports whether the settings permit translating into lang
+func (s TranslationSettings) AllowsTarget(lang string) bool {
+	if len(s.AllowedTargets) == 0 {
+		return true
+	}
+	for _, allowed := range s.AllowedTargets {
+		if allowed == lang {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterTargets drops target languages the settings do not allow
+func (s TranslationSettings) FilterTargets(langs []string) []string {
+	if len(s.AllowedTargets) == 0 {
+		return langs
+	}
+	filtered := make([]string, 0, len(langs))
+	for _, lang := range langs {
+		if s.AllowsTarget(lang) {
+			filtered = append(filtered, lang)
+		}
+	}
+	return filtered
+}
+
+// ApplySettings replaces the pipeline's translation settings; takes effect on the next transcript
+func (p *Pipeline) ApplySettings(s TranslationSettings) {
+	if s.MinConfidence < 0 {
+		s.MinConfidence = 0
+	}
+	s.AllowedTargets = append([]string(nil), s.AllowedTargets...)
+
+	p.settingsMu.Lock()
+	p.settings = s
+	p.settingsMu.Unlock()
+
+	log.Printf("[AWS Pipeline] Applied settings: tts=%v, partialInterval=%s, minConfidence=%.2f, allowedTargets=%v",
+		s.TTSEnabled, s.PartialInterval, s.MinConfidence, s.AllowedTargets)
+}
+
+// Settings returns the current translation settings
+func (p *Pipeline) Settings() TranslationSettings {
+	p.settingsMu.RLock()
+	defer p.settingsMu.RUnlock()
+	return p.settings
+}
+
+// targetLanguagesSnapshot returns the room's target languages narrowed by the allowed list
+func (p *Pipeline) targetLanguagesSnapshot() []string {
+	p.targetLangsMu.RLock()
+	targetLangs := make([]string, len(p.targetLanguages))
+	copy(targetLangs, p.targetLanguages)
+	p.targetLangsMu.RUnlock()
+
+	return p.Settings().FilterTargets(targetLangs)
+}
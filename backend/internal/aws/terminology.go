@@ -0,0 +1,122 @@
+package aws
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/translate"
+	"github.com/aws/aws-sdk-go-v2/service/translate/types"
+
+	appconfig "realtime-backend/internal/config"
+)
+
+// TerminologyEntry is a single glossary term and its preferred translation
+type TerminologyEntry struct {
+	Source string
+	Target string
+}
+
+// TerminologyName returns the Amazon Translate custom terminology name for a workspace language pair
+func TerminologyName(workspaceID int64, sourceLang, targetLang string) string {
+	return fmt.Sprintf("eum-ws%d-%s-%s", workspaceID, normalizeLanguageCode(sourceLang), normalizeLanguageCode(targetLang))
+}
+
+// NewRegionalTranslateClient creates a Translate client outside of a pipeline (e.g. for glossary sync)
+func NewRegionalTranslateClient(ctx context.Context, cfg *appconfig.Config, region string) (*TranslateClient, error) {
+	if region == "" {
+		region = cfg.S3.Region
+	}
+
+	awsCfg, err := config.LoadDefaultConfig(ctx,
+		config.WithRegion(region),
+		config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(
+			cfg.S3.AccessKeyID,
+			cfg.S3.SecretAccessKey,
+			"",
+		)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return NewTranslateClient(awsCfg), nil
+}
+
+// SetTerminologies sets the custom terminologies to apply, keyed by "source:target" language pair
+func (c *TranslateClient) SetTerminologies(names map[string]string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.terminologies = names
+}
+
+// terminologyFor returns the terminology name for a normalized language pair ("" if none)
+func (c *TranslateClient) terminologyFor(srcCode, tgtCode string) string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.terminologies[srcCode+":"+tgtCode]
+}
+
+// ImportTerminology uploads (overwrites) a unidirectional custom terminology.
+// An empty entry list deletes the terminology instead.
+func (c *TranslateClient) ImportTerminology(ctx context.Context, name, sourceLang, targetLang string, entries []TerminologyEntry) error {
+	if len(entries) == 0 {
+		return c.DeleteTerminology(ctx, name)
+	}
+
+	srcCode := normalizeLanguageCode(sourceLang)
+	tgtCode := normalizeLanguageCode(targetLang)
+	if srcCode == "" || tgtCode == "" {
+		return fmt.Errorf("unsupported language pair %s -> %s", sourceLang, targetLang)
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write([]string{srcCode, tgtCode}); err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if err := w.Write([]string{e.Source, e.Target}); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return err
+	}
+
+	_, err := c.client.ImportTerminology(ctx, &translate.ImportTerminologyInput{
+		Name:          aws.String(name),
+		MergeStrategy: types.MergeStrategyOverwrite,
+		TerminologyData: &types.TerminologyData{
+			File:           buf.Bytes(),
+			Format:         types.TerminologyDataFormatCsv,
+			Directionality: types.DirectionalityUni,
+		},
+	})
+	if err != nil {
+		log.Printf("[Translate] ❌ Failed to import terminology %s: %v", name, err)
+		return err
+	}
+
+	log.Printf("[Translate] ✅ Imported terminology %s (%d terms, %s→%s)", name, len(entries), srcCode, tgtCode)
+	return nil
+}
+
+// DeleteTerminology removes a custom terminology (missing terminologies are ignored)
+func (c *TranslateClient) DeleteTerminology(ctx context.Context, name string) error {
+	_, err := c.client.DeleteTerminology(ctx, &translate.DeleteTerminologyInput{
+		Name: aws.String(name),
+	})
+	var notFound *types.ResourceNotFoundException
+	if err != nil && !errors.As(err, &notFound) {
+		log.Printf("[Translate] ❌ Failed to delete terminology %s: %v", name, err)
+		return err
+	}
+	return nil
+}
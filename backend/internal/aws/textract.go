@@ -0,0 +1,124 @@
+package aws
+
+import (
+	"context"
+	"strings"
+
+	appconfig "realtime-backend/internal/config"
+)
+
+// Textract text detection job states
+const (
+	TextractInProgress     = "IN_PROGRESS"
+	TextractSucceeded      = "SUCCEEDED"
+	TextractFailed         = "FAILED"
+	TextractPartialSuccess = "PARTIAL_SUCCESS"
+)
+
+// textractPageSize is the maximum number of blocks per GetDocumentTextDetection call
+const textractPageSize = 1000
+
+// TextractClient runs asynchronous Amazon Textract text detection on S3 objects
+// (PDF, TIFF, JPEG, PNG). Async jobs are used even for images so multi-page
+// PDFs and single images go through the same path.
+type TextractClient struct {
+	api *jsonAPIClient
+}
+
+// TextDetectionResult is the state of a text detection job
+type TextDetectionResult struct {
+	Status        string
+	StatusMessage string
+	Pages         int
+	Text          string // Detected lines, pages separated by a blank line (SUCCEEDED/PARTIAL_SUCCESS only)
+}
+
+// NewTextractClient creates a Textract client in region (the bucket's region)
+func NewTextractClient(ctx context.Context, cfg *appconfig.Config, region string) (*TextractClient, error) {
+	api, err := newJSONAPIClient(ctx, cfg, region, "textract", "Textract")
+	if err != nil {
+		return nil, err
+	}
+	return &TextractClient{api: api}, nil
+}
+
+// StartTextDetection starts detecting text in s3://bucket/key and returns the job ID
+func (c *TextractClient) StartTextDetection(ctx context.Context, bucket, key string) (string, error) {
+	input := map[string]interface{}{
+		"DocumentLocation": map[string]interface{}{
+			"S3Object": map[string]string{"Bucket": bucket, "Name": key},
+		},
+	}
+	var output struct {
+		JobId string
+	}
+	if err := c.api.call(ctx, "StartDocumentTextDetection", input, &output); err != nil {
+		return "", err
+	}
+	return output.JobId, nil
+}
+
+// GetTextDetection returns the job's state, and once finished, all detected text
+func (c *TextractClient) GetTextDetection(ctx context.Context, jobID string) (*TextDetectionResult, error) {
+	result := &TextDetectionResult{}
+	var text strings.Builder
+	page := 0
+	nextToken := ""
+
+	for {
+		input := map[string]interface{}{
+			"JobId":      jobID,
+			"MaxResults": textractPageSize,
+		}
+		if nextToken != "" {
+			input["NextToken"] = nextToken
+		}
+
+		var output struct {
+			JobStatus        string
+			StatusMessage    string
+			NextToken        string
+			DocumentMetadata struct {
+				Pages int
+			}
+			Blocks []struct {
+				BlockType string
+				Text      string
+				Page      int
+			}
+		}
+		if err := c.api.call(ctx, "GetDocumentTextDetection", input, &output); err != nil {
+			return nil, err
+		}
+
+		result.Status = output.JobStatus
+		result.StatusMessage = output.StatusMessage
+		result.Pages = output.DocumentMetadata.Pages
+		if output.JobStatus != TextractSucceeded && output.JobStatus != TextractPartialSuccess {
+			return result, nil
+		}
+
+		for _, block := range output.Blocks {
+			if block.BlockType != "LINE" || block.Text == "" {
+				continue
+			}
+			if text.Len() > 0 {
+				if block.Page != page {
+					text.WriteString("\n\n")
+				} else {
+					text.WriteString("\n")
+				}
+			}
+			page = block.Page
+			text.WriteString(block.Text)
+		}
+
+		if output.NextToken == "" {
+			break
+		}
+		nextToken = output.NextToken
+	}
+
+	result.Text = text.String()
+	return result, nil
+}
@@ -9,6 +9,9 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/transcribestreaming"
 	"github.com/aws/aws-sdk-go-v2/service/transcribestreaming/types"
+
+	"realtime-backend/internal/errorreport"
+	"realtime-backend/internal/language"
 )
 
 // Keep-alive configuration
@@ -21,6 +24,10 @@ const (
 type TranscribeClient struct {
 	client     *transcribestreaming.Client
 	sampleRate int32
+
+	// Vocabulary filters (profanity) by source language, see redaction.go
+	vocabularyFilters map[string]string
+	filterMethod      types.VocabularyFilterMethod
 }
 
 // TranscribeStream represents an active transcription stream for a speaker
@@ -42,6 +49,10 @@ type TranscribeStream struct {
 	lastAudioTime time.Time
 	keepAliveMu   sync.Mutex
 
+	// Speaker diarization (mixed room feeds only)
+	diarize bool
+	clock   audioClock
+
 	mu       sync.Mutex
 	isClosed bool
 }
@@ -55,14 +66,11 @@ type TranscriptResult struct {
 	IsFinal     bool
 	Confidence  float32
 	TimestampMs uint64
-}
 
-// Transcribe 언어 코드 매핑
-var transcribeLanguageCodes = map[string]types.LanguageCode{
-	"ko": types.LanguageCodeKoKr,
-	"en": types.LanguageCodeEnUs,
-	"ja": types.LanguageCodeJaJp,
-	"zh": types.LanguageCodeZhCn,
+	// Set on diarized streams only
+	SpeakerLabel string    // Transcribe speaker label ("spk_0") that spoke most of the result
+	StartTime    time.Time // Wall-clock time the result's audio started
+	EndTime      time.Time
 }
 
 // NewTranscribeClient creates a new Transcribe Streaming client
@@ -74,10 +82,11 @@ func NewTranscribeClient(cfg aws.Config, sampleRate int32) *TranscribeClient {
 }
 
 // StartStream initiates a new transcription stream for a speaker
-func (c *TranscribeClient) StartStream(ctx context.Context, speakerID, sourceLang string) (*TranscribeStream, error) {
-	langCode, ok := transcribeLanguageCodes[sourceLang]
-	if !ok {
-		langCode = types.LanguageCodeEnUs
+func (c *TranscribeClient) StartStream(ctx context.Context, speakerID, sourceLang string, opts StreamOptions) (*TranscribeStream, error) {
+	langCode := types.LanguageCodeEnUs
+	if l, ok := language.Get(sourceLang); ok && l.STT {
+		langCode = types.LanguageCode(l.TranscribeCode)
+	} else {
 		log.Printf("[Transcribe] Unknown language '%s', defaulting to en-US", sourceLang)
 	}
 
@@ -93,15 +102,26 @@ func (c *TranscribeClient) StartStream(ctx context.Context, speakerID, sourceLan
 		TranscriptChan: make(chan *TranscriptResult, 50),
 		audioIn:        make(chan []byte, 100),
 		lastAudioTime:  time.Now(),
+		diarize:        opts.ShowSpeakerLabels,
+		clock:          audioClock{bytesPerSec: int64(c.sampleRate) * 2},
 		isClosed:       false,
 	}
 
-	// Start the transcription stream
-	resp, err := c.client.StartStreamTranscription(streamCtx, &transcribestreaming.StartStreamTranscriptionInput{
+	input := &transcribestreaming.StartStreamTranscriptionInput{
 		LanguageCode:         langCode,
 		MediaEncoding:        types.MediaEncodingPcm,
 		MediaSampleRateHertz: aws.Int32(c.sampleRate),
-	})
+	}
+	if filter := c.vocabularyFilters[sourceLang]; filter != "" {
+		input.VocabularyFilterName = aws.String(filter)
+		input.VocabularyFilterMethod = c.filterMethod
+	}
+	if opts.ShowSpeakerLabels {
+		input.ShowSpeakerLabel = true
+	}
+
+	// Start the transcription stream
+	resp, err := c.client.StartStreamTranscription(streamCtx, input)
 	if err != nil {
 		log.Printf("[Transcribe] ERROR StartStreamTranscription failed: %v", err)
 		cancel()
@@ -120,6 +140,12 @@ func (c *TranscribeClient) StartStream(ctx context.Context, speakerID, sourceLan
 	return ts, nil
 }
 
+// SetVocabularyFilters applies Transcribe vocabulary filters to streams started afterwards
+func (c *TranscribeClient) SetVocabularyFilters(filters map[string]string, mode RedactionMode) {
+	c.vocabularyFilters = filters
+	c.filterMethod = vocabularyFilterMethod(mode)
+}
+
 // MaxAudioChunkSize is the recommended audio chunk size for AWS Transcribe
 const MaxAudioChunkSize = 3200
 
@@ -195,6 +221,7 @@ func (ts *TranscribeStream) keepAliveLoop() {
 
 // sendAudioLoop sends audio chunks to Transcribe
 func (ts *TranscribeStream) sendAudioLoop() {
+	defer errorreport.Recover(ts.reportContext("aws.transcribe.send"))
 	defer func() {
 		ts.mu.Lock()
 		ts.isClosed = true
@@ -234,6 +261,7 @@ func (ts *TranscribeStream) sendAudioLoop() {
 				log.Printf("[Transcribe] Send error for %s: %v", ts.speakerID, err)
 				return
 			}
+			ts.clock.record(len(audioData))
 		}
 	}
 }
@@ -241,6 +269,7 @@ func (ts *TranscribeStream) sendAudioLoop() {
 // receiveLoop receives transcript results from Transcribe
 func (ts *TranscribeStream) receiveLoop() {
 	log.Printf("[Transcribe] 🎧 receiveLoop started for speaker %s", ts.speakerID)
+	defer errorreport.Recover(ts.reportContext("aws.transcribe.receive"))
 
 	defer func() {
 		ts.mu.Lock()
@@ -305,8 +334,7 @@ func (ts *TranscribeStream) handleTranscriptEvent(event types.TranscriptEvent) {
 			log.Printf("[Transcribe] ✅ Final from %s: '%s' (confidence: %.2f)", ts.speakerID, transcript, confidence)
 		}
 
-		select {
-		case ts.TranscriptChan <- &TranscriptResult{
+		transcriptResult := &TranscriptResult{
 			SpeakerID:   ts.speakerID,
 			Text:        transcript,
 			Language:    ts.sourceLang,
@@ -314,13 +342,29 @@ func (ts *TranscribeStream) handleTranscriptEvent(event types.TranscriptEvent) {
 			IsFinal:     !isPartial,
 			Confidence:  confidence,
 			TimestampMs: uint64(time.Now().UnixMilli()),
-		}:
+		}
+		if ts.diarize {
+			transcriptResult.SpeakerLabel = dominantSpeakerLabel(alt.Items)
+			transcriptResult.StartTime = ts.clock.wallTime(result.StartTime)
+			transcriptResult.EndTime = ts.clock.wallTime(result.EndTime)
+		}
+
+		select {
+		case ts.TranscriptChan <- transcriptResult:
 		default:
 			log.Printf("[Transcribe] ⚠️ Channel full, dropping transcript: '%s'", transcript)
 		}
 	}
 }
 
+// reportContext identifies this stream in error reports
+func (ts *TranscribeStream) reportContext(component string) errorreport.Context {
+	return errorreport.Context{
+		Component: component,
+		Tags:      map[string]string{"speaker_id": ts.speakerID, "source_lang": ts.sourceLang},
+	}
+}
+
 // IsClosed returns whether the stream has been closed
 func (ts *TranscribeStream) IsClosed() bool {
 	ts.mu.Lock()
@@ -3,16 +3,20 @@ package aws
 import (
 	"context"
 	"log"
-	"strings"
 	"sync"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/translate"
+
+	"realtime-backend/internal/language"
 )
 
 // TranslateClient wraps Amazon Translate
 type TranslateClient struct {
-	client *translate.Client
+	client        *translate.Client
+	mu            sync.RWMutex
+	terminologies map[string]string // "source:target" -> custom terminology name (see terminology.go)
 }
 
 // TranslationResult holds translated text
@@ -23,46 +27,10 @@ type TranslationResult struct {
 	TranslatedText string
 }
 
-// Translate 언어 코드 매핑 (Amazon Translate는 ISO 639-1 사용)
-// AWS Translate supports these primary codes
-var translateLanguageCodes = map[string]string{
-	"ko": "ko",
-	"en": "en",
-	"ja": "ja",
-	"zh": "zh",
-	// Aliases for common variations
-	"ko-KR": "ko",
-	"en-US": "en",
-	"en-GB": "en",
-	"ja-JP": "ja",
-	"zh-CN": "zh",
-	"zh-TW": "zh",
-}
-
-// supportedTargetLanguages is a set of valid target languages
-var supportedTargetLanguages = map[string]bool{
-	"ko": true,
-	"en": true,
-	"ja": true,
-	"zh": true,
-}
-
-// normalizeLanguageCode normalizes a language code to a supported format
+// normalizeLanguageCode maps an app or locale code to its Amazon Translate code ("" if unsupported)
 func normalizeLanguageCode(lang string) string {
-	// First check if it's already in the map
-	if code, ok := translateLanguageCodes[lang]; ok {
-		return code
-	}
-	// Try lowercase
-	if code, ok := translateLanguageCodes[strings.ToLower(lang)]; ok {
-		return code
-	}
-	// Try just the first 2 characters
-	if len(lang) >= 2 {
-		short := strings.ToLower(lang[:2])
-		if code, ok := translateLanguageCodes[short]; ok {
-			return code
-		}
+	if l, ok := language.Get(lang); ok && l.Translate {
+		return l.TranslateCode
 	}
 	return ""
 }
@@ -90,12 +58,6 @@ func (c *TranslateClient) Translate(ctx context.Context, text, sourceLang, targe
 		tgtCode = "en"
 	}
 
-	// Validate target is a supported language (prevent German, Spanish, etc.)
-	if !supportedTargetLanguages[tgtCode] {
-		log.Printf("[Translate] ⚠️ Unsupported target language '%s' (normalized from '%s'), defaulting to 'en'", tgtCode, targetLang)
-		tgtCode = "en"
-	}
-
 	// Skip if same language
 	if srcCode == tgtCode {
 		return &TranslationResult{
@@ -122,10 +84,18 @@ func (c *TranslateClient) Translate(ctx context.Context, text, sourceLang, targe
 		TargetLanguageCode: aws.String(tgtCode),
 	}
 
+	// Apply workspace glossary so product names and jargon keep their preferred translation
+	if name := c.terminologyFor(srcCode, tgtCode); name != "" {
+		input.TerminologyNames = []string{name}
+	}
+
 	log.Printf("[Translate] Translating: '%s' from %s to %s", text, srcCode, tgtCode)
 
+	startedAt := time.Now()
 	output, err := c.client.TranslateText(ctx, input)
+	stageDuration.ObserveDuration(startedAt, stageTranslate)
 	if err != nil {
+		stageErrors.Inc(stageTranslate)
 		log.Printf("[Translate] ❌ Error translating from %s to %s: %v", srcCode, tgtCode, err)
 		return nil, err
 	}
@@ -0,0 +1,34 @@
+package aws
+
+import "errors"
+
+// errUsageCapReached is returned instead of calling an AWS API whose usage cap is reached
+var errUsageCapReached = errors.New("usage cap reached")
+
+// UsageKind identifies a billable AWS AI resource
+type UsageKind string
+
+const (
+	UsageTranscribeSeconds UsageKind = "transcribe_seconds" // audio streamed to Transcribe
+	UsageTranslateChars    UsageKind = "translate_chars"    // characters sent to Translate
+	UsageTTSChars          UsageKind = "tts_chars"          // characters synthesized by Polly
+)
+
+// UsageMeter receives the pipeline's billable usage and can stop further spend, e.g. when the
+// room's workspace reached its monthly cap. Calls come from many goroutines.
+type UsageMeter interface {
+	RecordUsage(kind UsageKind, amount float64)
+	UsageAllowed(kind UsageKind) bool
+}
+
+// recordUsage reports usage to the meter (if any)
+func (p *Pipeline) recordUsage(kind UsageKind, amount float64) {
+	if p.usage != nil && amount > 0 {
+		p.usage.RecordUsage(kind, amount)
+	}
+}
+
+// usageAllowed reports whether the meter still allows spending on kind
+func (p *Pipeline) usageAllowed(kind UsageKind) bool {
+	return p.usage == nil || p.usage.UsageAllowed(kind)
+}
@@ -0,0 +1,145 @@
+package aws
+
+import (
+	"encoding/binary"
+	"math"
+	"time"
+)
+
+// VAD aggressiveness levels (PipelineConfig.VADAggressiveness)
+const (
+	VADOff        = 0
+	VADLenient    = 1
+	VADNormal     = 2
+	VADAggressive = 3
+)
+
+// VADStreamIdleTimeout closes a speaker's Transcribe stream after this much gated silence.
+// Transcribe itself fails streams that receive no audio for 15s, so close cleanly before that.
+const VADStreamIdleTimeout = 10 * time.Second
+
+// vadProfile holds the thresholds for one aggressiveness level
+type vadProfile struct {
+	minRMS     float64       // Absolute energy floor for speech (16-bit PCM RMS)
+	noiseRatio float64       // Speech must be this many times louder than the noise floor
+	hangover   time.Duration // Keep forwarding this long after the last voiced frame
+}
+
+var vadProfiles = map[int]vadProfile{
+	VADLenient:    {minRMS: 150, noiseRatio: 2.0, hangover: 800 * time.Millisecond},
+	VADNormal:     {minRMS: 300, noiseRatio: 3.0, hangover: 500 * time.Millisecond},
+	VADAggressive: {minRMS: 500, noiseRatio: 4.0, hangover: 300 * time.Millisecond},
+}
+
+const (
+	vadPreroll         = 300 * time.Millisecond // Silent audio replayed at speech onset so first syllables are kept
+	vadNoiseAdaptation = 0.05                   // Noise floor EMA weight per silent frame
+	vadInitialNoise    = 100.0
+)
+
+// VAD is an energy-based voice activity gate for one speaker's 16-bit mono PCM audio.
+// Not safe for concurrent use; the room's audio processor owns one per speaker.
+type VAD struct {
+	profile    vadProfile
+	sampleRate int32
+
+	noiseFloor  float64
+	speaking    bool
+	lastVoiced  time.Duration // Audio clock position of the last voiced frame
+	clock       time.Duration // Total audio duration processed
+	preroll     [][]byte
+	prerollSize time.Duration
+}
+
+// NormalizeVADAggressiveness clamps a configured level to 0 (off) ~ 3
+func NormalizeVADAggressiveness(level int) int {
+	if level < VADOff {
+		return VADOff
+	}
+	if level > VADAggressive {
+		return VADAggressive
+	}
+	return level
+}
+
+// NewVAD creates a gate for the given aggressiveness (nil when VAD is off)
+func NewVAD(aggressiveness int, sampleRate int32) *VAD {
+	profile, ok := vadProfiles[NormalizeVADAggressiveness(aggressiveness)]
+	if !ok {
+		return nil
+	}
+	if sampleRate <= 0 {
+		sampleRate = 16000
+	}
+	return &VAD{profile: profile, sampleRate: sampleRate, noiseFloor: vadInitialNoise}
+}
+
+// Process returns the frames to forward for this input frame: nothing while silent,
+// the buffered pre-roll plus the frame at speech onset, and the frame itself while speaking.
+// A nil VAD forwards everything.
+func (v *VAD) Process(frame []byte) [][]byte {
+	if v == nil {
+		return [][]byte{frame}
+	}
+
+	duration := v.frameDuration(frame)
+	v.clock += duration
+	rms := frameRMS(frame)
+
+	threshold := math.Max(v.profile.minRMS, v.noiseFloor*v.profile.noiseRatio)
+	voiced := rms >= threshold
+	if voiced {
+		v.lastVoiced = v.clock
+	} else {
+		v.noiseFloor += (rms - v.noiseFloor) * vadNoiseAdaptation
+	}
+
+	if v.speaking {
+		if !voiced && v.clock-v.lastVoiced > v.profile.hangover {
+			v.speaking = false
+			v.bufferPreroll(frame, duration)
+			return nil
+		}
+		return [][]byte{frame}
+	}
+
+	if !voiced {
+		v.bufferPreroll(frame, duration)
+		return nil
+	}
+
+	v.speaking = true
+	frames := append(v.preroll, frame)
+	v.preroll = nil
+	v.prerollSize = 0
+	return frames
+}
+
+// bufferPreroll keeps the most recent silent audio for replay at speech onset
+func (v *VAD) bufferPreroll(frame []byte, duration time.Duration) {
+	v.preroll = append(v.preroll, append([]byte(nil), frame...))
+	v.prerollSize += duration
+	for len(v.preroll) > 1 && v.prerollSize > vadPreroll {
+		v.prerollSize -= v.frameDuration(v.preroll[0])
+		v.preroll = v.preroll[1:]
+	}
+}
+
+func (v *VAD) frameDuration(frame []byte) time.Duration {
+	samples := len(frame) / 2
+	return time.Duration(samples) * time.Second / time.Duration(v.sampleRate)
+}
+
+// frameRMS returns the RMS energy of 16-bit little-endian PCM samples
+func frameRMS(frame []byte) float64 {
+	samples := len(frame) / 2
+	if samples == 0 {
+		return 0
+	}
+	var sum float64
+	for i := 0; i+1 < len(frame); i += 2 {
+		s := float64(int16(binary.LittleEndian.Uint16(frame[i:])))
+		sum += s * s
+	}
+	return math.Sqrt(sum / float64(samples))
+}
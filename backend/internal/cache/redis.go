@@ -166,6 +166,11 @@ func (r *RedisClient) Set(ctx context.Context, key string, value interface{}, ex
 	return r.client.Set(ctx, key, value, expiration).Err()
 }
 
+// SetNX sets a key only if it does not exist yet (returns false if it already existed)
+func (r *RedisClient) SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error) {
+	return r.client.SetNX(ctx, key, value, expiration).Result()
+}
+
 // Get gets a value by key
 func (r *RedisClient) Get(ctx context.Context, key string) (string, error) {
 	return r.client.Get(ctx, key).Result()
@@ -181,6 +186,35 @@ func (r *RedisClient) HIncrBy(ctx context.Context, key, field string, incr int64
 	return r.client.HIncrBy(ctx, key, field, incr).Result()
 }
 
+// hIncrByIfExistsScript increments a hash field only while the hash exists, so a counter
+// whose cache entry expired is not recreated holding just the increment
+var hIncrByIfExistsScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 1 then
+	return redis.call('HINCRBY', KEYS[1], ARGV[1], ARGV[2])
+end
+return false
+`)
+
+// HIncrByIfExists increments a hash field if the hash exists (ok is false if it did not)
+func (r *RedisClient) HIncrByIfExists(ctx context.Context, key, field string, incr int64) (int64, bool, error) {
+	n, err := hIncrByIfExistsScript.Run(ctx, r.client, []string{key}, field, incr).Int64()
+	if err == redis.Nil {
+		return 0, false, nil
+	}
+	return n, err == nil, err
+}
+
+// HSetWithExpiry replaces a hash with the given fields and sets its expiration
+func (r *RedisClient) HSetWithExpiry(ctx context.Context, key string, values map[string]interface{}, expiration time.Duration) error {
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, key)
+		pipe.HSet(ctx, key, values)
+		pipe.Expire(ctx, key, expiration)
+		return nil
+	})
+	return err
+}
+
 // SAdd adds one or more members to a set
 func (r *RedisClient) SAdd(ctx context.Context, key string, members ...interface{}) error {
 	return r.client.SAdd(ctx, key, members...).Err()
@@ -195,3 +229,23 @@ func (r *RedisClient) SIsMember(ctx context.Context, key string, member interfac
 func (r *RedisClient) SRem(ctx context.Context, key string, members ...interface{}) error {
 	return r.client.SRem(ctx, key, members...).Err()
 }
+
+// SCard returns the number of members in a set
+func (r *RedisClient) SCard(ctx context.Context, key string) (int64, error) {
+	return r.client.SCard(ctx, key).Result()
+}
+
+// Publish publishes a message to a pub/sub channel
+func (r *RedisClient) Publish(ctx context.Context, channel string, payload []byte) error {
+	return r.client.Publish(ctx, channel, payload).Err()
+}
+
+// Subscribe subscribes to the given pub/sub channels
+func (r *RedisClient) Subscribe(ctx context.Context, channels ...string) *redis.PubSub {
+	return r.client.Subscribe(ctx, channels...)
+}
+
+// PSubscribe subscribes to all channels matching the given patterns
+func (r *RedisClient) PSubscribe(ctx context.Context, patterns ...string) *redis.PubSub {
+	return r.client.PSubscribe(ctx, patterns...)
+}
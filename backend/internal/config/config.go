@@ -1,6 +1,7 @@
 package config
 
 import (
+	"fmt"
 	"log"
 	"os"
 	"strconv"
@@ -21,6 +22,46 @@ type Config struct {
 	S3        S3Config
 	LiveKit   LiveKitConfig
 	Redis     RedisConfig
+	Presence  PresenceConfig
+	Probe     ProbeConfig
+	Sentry    SentryConfig
+	Push      PushConfig
+	Mail      MailConfig
+}
+
+// MailConfig 이메일 알림 설정 (SMTP_HOST가 비어 있으면 메일 비활성화, SES는 SMTP 엔드포인트로 연결)
+type MailConfig struct {
+	SMTPHost     string
+	SMTPPort     int // 465면 TLS로 바로 연결, 그 외에는 STARTTLS
+	SMTPUsername string
+	SMTPPassword string
+	From         string        // 보내는 주소 ("EUM <no-reply@example.com>")
+	DigestHour   int           // 일일 요약 기본 발송 시각 (0-23, 사용자 타임존 기준)
+	DigestTZ     string        // 사용자가 타임존을 정하지 않았을 때 기준 타임존
+	SendTimeout  time.Duration // 메일 한 통 전송 최대 시간
+}
+
+// PushConfig 모바일/데스크톱 푸시 알림 설정 (둘 다 비어 있으면 푸시 비활성화)
+type PushConfig struct {
+	FCMProjectID       string
+	FCMCredentialsFile string // 서비스 계정 JSON 경로
+	VAPIDPrivateKey    string // Web Push VAPID 개인키 (base64url, 공개키는 여기서 계산)
+	VAPIDSubject       string // mailto: 또는 https: 연락처
+	MaxAttempts        int    // 일시적 오류(429, 5xx) 재시도 포함 최대 전송 횟수
+}
+
+// SentryConfig 오류 리포팅 설정 (Sentry 호환 서버)
+type SentryConfig struct {
+	DSN         string // 비어 있으면 로그만 남김
+	Environment string
+	Release     string // 비어 있으면 빌드 정보의 VCS 리비전 사용
+}
+
+// ProbeConfig 외부 업타임 모니터용 합성 프로브 설정
+type ProbeConfig struct {
+	Token   string        // X-Probe-Token 헤더 값 (비어 있으면 프로브 비활성화)
+	BaseURL string        // 자기 자신에게 접속할 WebSocket 주소 (기본: ws://127.0.0.1{PORT})
+	Timeout time.Duration // 프로브 1회 최대 소요 시간
 }
 
 // RedisConfig ElastiCache/Valkey 설정
@@ -31,6 +72,13 @@ type RedisConfig struct {
 	DB       int
 }
 
+// PresenceConfig 접속 상태 자동 전환 (클라이언트 heartbeat는 30초마다)
+type PresenceConfig struct {
+	IdleAfter     time.Duration // heartbeat가 이만큼 없으면 ONLINE → IDLE
+	OfflineAfter  time.Duration // heartbeat가 이만큼 없으면 OFFLINE (연결이 남아 있어도)
+	SweepInterval time.Duration // 만료 검사 주기
+}
+
 // S3Config AWS S3 설정
 type S3Config struct {
 	Region          string
@@ -38,6 +86,16 @@ type S3Config struct {
 	AccessKeyID     string
 	SecretAccessKey string
 	PresignExpiry   time.Duration
+	RegionBuckets   map[string]string // 데이터 레지던시용 리전별 버킷 (region → bucket)
+	ZipMaxSize      int64             // 폴더 ZIP 다운로드 최대 원본 크기 (bytes)
+	ZipStreamLimit  int64             // 이 크기를 넘는 폴더는 백그라운드 작업으로 압축 후 알림
+	ZipConcurrency  int               // ZIP 생성 시 S3 동시 다운로드 수
+	UploadMaxSize   int64             // 멀티파트 업로드 최대 파일 크기 (bytes)
+	TrashRetention  time.Duration     // 휴지통 보관 기간 (지나면 영구 삭제)
+	WorkspaceQuota  int64             // 워크스페이스 기본 저장 용량 (bytes, 0 = 제한 없음)
+	CDNBaseURL      string            // 기본 버킷 앞단 CDN 주소 (예: https://cdn.example.com, 비어 있으면 S3 URL)
+	AvatarMaxSize   int64             // 프로필 이미지 원본 최대 크기 (bytes)
+	Endpoint        string            // S3 호환 스토리지 주소 (예: MinIO, 비어 있으면 AWS 기본 엔드포인트)
 }
 
 // LiveKitConfig LiveKit 설정
@@ -49,11 +107,15 @@ type LiveKitConfig struct {
 
 // AuthConfig 인증 설정
 type AuthConfig struct {
-	JWTSecret          string
-	AccessTokenExpiry  time.Duration
-	RefreshTokenExpiry time.Duration
-	GoogleClientID     string
-	SecureCookie       bool
+	JWTSecret                string
+	AccessTokenExpiry        time.Duration
+	RefreshTokenExpiry       time.Duration
+	GoogleClientID           string
+	SecureCookie             bool
+	ImpersonationMaxDuration time.Duration // 관리자 대리 접속 세션 최대 유지 시간
+	JoinTokenExpiry          time.Duration // 음성 Room 일회용 입장 토큰 유효 시간
+	RequireJoinToken         bool          // true면 /ws/audio, /ws/room 연결에 입장 토큰 필수
+	RequireRoomIdentity      bool          // true면 /ws/room 연결 사용자/권한/발화자 ID 검증
 }
 
 // AIConfig AI 서버 설정
@@ -61,14 +123,28 @@ type AIConfig struct {
 	ServerAddr string
 	Enabled    bool
 	UseAWS     bool // true: AWS 직접 사용, false: Python gRPC 서버 사용
+
+	// 언어별 Transcribe 어휘 필터 이름 (비속어 가림, 워크스페이스 설정이 켜진 경우에만 적용)
+	TranscribeVocabularyFilters map[string]string
+
+	// AWS ↔ gRPC 자동 전환 (회로 차단기)
+	FallbackEnabled          bool
+	FallbackFailureThreshold int           // 연속 실패 횟수 기준
+	FallbackCooldown         time.Duration // 차단 후 재시도까지 대기 시간
+
+	// 발화 구간 검출 (무음 프레임은 Transcribe로 보내지 않음)
+	VADAggressiveness int // 0 = 끔, 1 (관대) ~ 3 (엄격)
 }
 
 // ServerConfig HTTP 서버 설정
 type ServerConfig struct {
+	ID           string // 인스턴스 식별자 (presence, 진단에 표시, 기본: 호스트 이름-PID)
 	Port         string
 	ReadTimeout  time.Duration
 	WriteTimeout time.Duration
 	IdleTimeout  time.Duration
+	PublicWebURL string        // 프론트엔드 주소 (일정/알림의 회의 참여 링크, 비어 있으면 상대 경로)
+	InviteTTL    time.Duration // 워크스페이스 초대 유효 기간 (지나면 초대 만료, 0 = 만료 없음)
 }
 
 // WebSocketConfig WebSocket 관련 설정
@@ -77,6 +153,13 @@ type WebSocketConfig struct {
 	WriteBufferSize  int
 	HandshakeTimeout time.Duration
 	WriteTimeout     time.Duration
+
+	MaxRoomListeners            int           // /ws/room Room당 최대 리스너 수 (0 = 무제한)
+	ChatMessagesPerSecond       int           // 채팅 WebSocket 연결당 초당 메시지 수 (0 = 무제한)
+	RoomResumeWindow            time.Duration // /ws/room 끊긴 연결이 같은 Transcribe 스트림으로 재접속할 수 있는 시간 (0 = 끔)
+	WhiteboardMessagesPerSecond int           // 화이트보드 WebSocket 연결당 초당 메시지 수 (0 = 무제한)
+	WhiteboardFlushInterval     time.Duration // 화이트보드 획을 모아서 DB에 저장하는 주기
+	WhiteboardTrashRetention    time.Duration // 체크포인트 복원으로 지워진 화이트보드 내용 보관 기간
 }
 
 // AudioConfig 오디오 처리 설정
@@ -108,16 +191,26 @@ func Load() *Config {
 
 	return &Config{
 		Server: ServerConfig{
+			ID:           getEnv("SERVER_ID", defaultServerID()),
 			Port:         getEnv("PORT", ":8080"),
 			ReadTimeout:  getDuration("READ_TIMEOUT", 10*time.Second),
 			WriteTimeout: getDuration("WRITE_TIMEOUT", 10*time.Second),
 			IdleTimeout:  getDuration("IDLE_TIMEOUT", 120*time.Second),
+			PublicWebURL: strings.TrimRight(getEnv("PUBLIC_WEB_URL", ""), "/"),
+			InviteTTL:    getDuration("WORKSPACE_INVITE_TTL", 7*24*time.Hour),
 		},
 		WebSocket: WebSocketConfig{
 			ReadBufferSize:   getInt("WS_READ_BUFFER_SIZE", 16*1024),
 			WriteBufferSize:  getInt("WS_WRITE_BUFFER_SIZE", 16*1024),
 			HandshakeTimeout: getDuration("WS_HANDSHAKE_TIMEOUT", 10*time.Second),
 			WriteTimeout:     getDuration("WS_WRITE_TIMEOUT", 5*time.Second),
+
+			MaxRoomListeners:            getInt("WS_MAX_ROOM_LISTENERS", 0),
+			ChatMessagesPerSecond:       getInt("WS_CHAT_MESSAGES_PER_SECOND", 10),
+			RoomResumeWindow:            getDuration("WS_ROOM_RESUME_WINDOW", 20*time.Second),
+			WhiteboardMessagesPerSecond: getInt("WS_WHITEBOARD_MESSAGES_PER_SECOND", 30),
+			WhiteboardFlushInterval:     getDuration("WS_WHITEBOARD_FLUSH_INTERVAL", time.Second),
+			WhiteboardTrashRetention:    getDuration("WHITEBOARD_TRASH_RETENTION", 7*24*time.Hour),
 		},
 		Audio: AudioConfig{
 			ChannelBufferSize: getInt("AUDIO_CHANNEL_BUFFER_SIZE", 100),
@@ -133,13 +226,25 @@ func Load() *Config {
 			ServerAddr: getEnv("AI_SERVER_ADDR", "localhost:50051"),
 			Enabled:    getBool("AI_ENABLED", false),
 			UseAWS:     getBool("AI_USE_AWS", false),
+
+			TranscribeVocabularyFilters: getMap("AWS_TRANSCRIBE_VOCABULARY_FILTERS"),
+
+			FallbackEnabled:          getBool("AI_FALLBACK_ENABLED", false),
+			FallbackFailureThreshold: getInt("AI_FALLBACK_FAILURE_THRESHOLD", 5),
+			FallbackCooldown:         getDuration("AI_FALLBACK_COOLDOWN", 30*time.Second),
+
+			VADAggressiveness: getInt("AI_VAD_AGGRESSIVENESS", 2),
 		},
 		Auth: AuthConfig{
-			JWTSecret:          jwtSecret,
-			AccessTokenExpiry:  getDuration("ACCESS_TOKEN_EXPIRY", 1*time.Hour),
-			RefreshTokenExpiry: getDuration("REFRESH_TOKEN_EXPIRY", 7*24*time.Hour),
-			GoogleClientID:     getEnv("GOOGLE_CLIENT_ID", ""),
-			SecureCookie:       getBool("SECURE_COOKIE", false),
+			JWTSecret:                jwtSecret,
+			AccessTokenExpiry:        getDuration("ACCESS_TOKEN_EXPIRY", 1*time.Hour),
+			RefreshTokenExpiry:       getDuration("REFRESH_TOKEN_EXPIRY", 7*24*time.Hour),
+			GoogleClientID:           getEnv("GOOGLE_CLIENT_ID", ""),
+			SecureCookie:             getBool("SECURE_COOKIE", false),
+			ImpersonationMaxDuration: getDuration("IMPERSONATION_MAX_DURATION", 30*time.Minute),
+			JoinTokenExpiry:          getDuration("ROOM_JOIN_TOKEN_EXPIRY", time.Minute),
+			RequireJoinToken:         getBool("ROOM_JOIN_TOKEN_REQUIRED", false),
+			RequireRoomIdentity:      getBool("ROOM_IDENTITY_REQUIRED", true),
 		},
 		S3: S3Config{
 			Region:          getEnv("AWS_REGION", "ap-northeast-2"),
@@ -147,6 +252,16 @@ func Load() *Config {
 			AccessKeyID:     getEnv("AWS_ACCESS_KEY_ID", ""),
 			SecretAccessKey: getEnv("AWS_SECRET_ACCESS_KEY", ""),
 			PresignExpiry:   getDuration("S3_PRESIGN_EXPIRY", 15*time.Minute),
+			RegionBuckets:   getMap("AWS_S3_REGION_BUCKETS"),
+			ZipMaxSize:      int64(getInt("S3_ZIP_MAX_BYTES", 2<<30)),            // 2GB
+			ZipStreamLimit:  int64(getInt("S3_ZIP_STREAM_LIMIT_BYTES", 200<<20)), // 200MB
+			ZipConcurrency:  getInt("S3_ZIP_CONCURRENCY", 4),
+			UploadMaxSize:   int64(getInt("S3_UPLOAD_MAX_BYTES", 50<<30)), // 50GB
+			TrashRetention:  getDuration("FILE_TRASH_RETENTION", 30*24*time.Hour),
+			WorkspaceQuota:  int64(getInt("S3_WORKSPACE_QUOTA_BYTES", 0)),
+			CDNBaseURL:      strings.TrimSuffix(getEnv("S3_CDN_BASE_URL", ""), "/"),
+			AvatarMaxSize:   int64(getInt("S3_AVATAR_MAX_BYTES", 5<<20)), // 5MB
+			Endpoint:        strings.TrimSuffix(getEnv("AWS_S3_ENDPOINT", ""), "/"),
 		},
 		LiveKit: LiveKitConfig{
 			Host:      getEnv("LIVEKIT_HOST", "ws://localhost:7880"),
@@ -159,7 +274,48 @@ func Load() *Config {
 			Enabled:  getBool("REDIS_ENABLED", false),
 			DB:       getInt("REDIS_DB", 0),
 		},
+		Presence: PresenceConfig{
+			IdleAfter:     getDuration("PRESENCE_IDLE_AFTER", 90*time.Second),
+			OfflineAfter:  getDuration("PRESENCE_OFFLINE_AFTER", 5*time.Minute),
+			SweepInterval: getDuration("PRESENCE_SWEEP_INTERVAL", 15*time.Second),
+		},
+		Probe: ProbeConfig{
+			Token:   getEnv("PROBE_TOKEN", ""),
+			BaseURL: getEnv("PROBE_BASE_URL", ""),
+			Timeout: getDuration("PROBE_TIMEOUT", 10*time.Second),
+		},
+		Sentry: SentryConfig{
+			DSN:         getEnv("SENTRY_DSN", ""),
+			Environment: getEnv("SENTRY_ENVIRONMENT", "development"),
+			Release:     getEnv("SENTRY_RELEASE", ""),
+		},
+		Push: PushConfig{
+			FCMProjectID:       getEnv("FCM_PROJECT_ID", ""),
+			FCMCredentialsFile: getEnv("FCM_CREDENTIALS_FILE", ""),
+			VAPIDPrivateKey:    getEnv("VAPID_PRIVATE_KEY", ""),
+			VAPIDSubject:       getEnv("VAPID_SUBJECT", ""),
+			MaxAttempts:        getInt("PUSH_MAX_ATTEMPTS", 3),
+		},
+		Mail: MailConfig{
+			SMTPHost:     getEnv("SMTP_HOST", ""),
+			SMTPPort:     getInt("SMTP_PORT", 587),
+			SMTPUsername: getEnv("SMTP_USERNAME", ""),
+			SMTPPassword: getEnv("SMTP_PASSWORD", ""),
+			From:         getEnv("MAIL_FROM", ""),
+			DigestHour:   getInt("MAIL_DIGEST_HOUR", 9),
+			DigestTZ:     getEnv("MAIL_DIGEST_TIMEZONE", "Asia/Seoul"),
+			SendTimeout:  getDuration("MAIL_SEND_TIMEOUT", 15*time.Second),
+		},
+	}
+}
+
+// defaultServerID 호스트 이름과 PID로 만든 인스턴스 식별자 (같은 호스트에서 여러 프로세스를 띄워도 구분)
+func defaultServerID() string {
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		host = "server"
 	}
+	return fmt.Sprintf("%s-%d", host, os.Getpid())
 }
 
 // getRequiredEnv 필수 환경 변수 조회 (없으면 Fatal)
@@ -212,3 +368,20 @@ func getDuration(key string, defaultValue time.Duration) time.Duration {
 	}
 	return defaultValue
 }
+
+// getMap "key=value,key=value" 형식 환경 변수 조회
+func getMap(key string) map[string]string {
+	result := make(map[string]string)
+	value := os.Getenv(key)
+	if value == "" {
+		return result
+	}
+	for _, pair := range strings.Split(value, ",") {
+		k, v, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok || k == "" || v == "" {
+			continue
+		}
+		result[strings.TrimSpace(k)] = strings.TrimSpace(v)
+	}
+	return result
+}
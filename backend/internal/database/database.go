@@ -102,6 +102,52 @@ func ConnectDB() (*gorm.DB, error) {
 		&model.Notification{},
 		&model.WhiteboardStroke{},
 		&model.WhiteboardSnapshot{},
+		&model.WhiteboardCheckpoint{},
+		&model.Poll{},
+		&model.PollVote{},
+		&model.RecordingConsent{},
+		&model.ImpersonationSession{},
+		&model.ImpersonationAuditLog{},
+		&model.GlossaryTerm{},
+		&model.GlossaryTerminology{},
+		&model.RedactionSetting{},
+		&model.RoomTranslationSetting{},
+		&model.WorkspaceLanguageSetting{},
+		&model.FileZipJob{},
+		&model.FileAccess{},
+		&model.FileStar{},
+		&model.FolderPermission{},
+		&model.AIUsageDaily{},
+		&model.WorkspaceAIUsageLimit{},
+		&model.FolderWatch{},
+		&model.FileTranscriptionJob{},
+		&model.FileText{},
+		&model.MeetingMinutes{},
+		&model.StatusIncident{},
+		&model.WorkspaceStorageQuota{},
+		&model.FileShareLink{},
+		&model.FileActivity{},
+		&model.FileActivitySetting{},
+		&model.WorkspaceInviteLink{},
+		&model.AuditEvent{},
+		&model.WorkspaceDailyStats{},
+		&model.WorkspaceJoinRequest{},
+		&model.ChannelPermissionOverride{},
+		&model.CalendarEventException{},
+		&model.EventReminderPreference{},
+		&model.EventReminderDelivery{},
+		&model.CalendarFeedToken{},
+		&model.EventComment{},
+		&model.EventAttachment{},
+		&model.PushDevice{},
+		&model.EmailPreference{},
+		&model.NotificationSettings{},
+		&model.AccountToken{},
+		&model.AuthSession{},
+		&model.PersonalAccessToken{},
+		&model.UserTwoFactor{},
+		&model.TwoFactorBackupCode{},
+		&model.AdminAuditLog{},
 	); err != nil {
 		log.Printf("⚠️ AutoMigrate warning: %v", err)
 	}
@@ -126,6 +172,15 @@ func ConnectDB() (*gorm.DB, error) {
 	ALTER TABLE users ADD COLUMN IF NOT EXISTS custom_status_text varchar(100);
 	ALTER TABLE users ADD COLUMN IF NOT EXISTS custom_status_emoji varchar(10);
 	ALTER TABLE users ADD COLUMN IF NOT EXISTS custom_status_expires_at timestamptz;
+
+	-- Manual migration for recording consent
+	ALTER TABLE meetings ADD COLUMN IF NOT EXISTS recording_enabled boolean DEFAULT false;
+
+	-- Manual migration for workspace data residency
+	ALTER TABLE workspaces ADD COLUMN IF NOT EXISTS data_region varchar(30);
+
+	-- Manual migration for platform admin
+	ALTER TABLE users ADD COLUMN IF NOT EXISTS is_platform_admin boolean DEFAULT false;
 	CREATE TABLE IF NOT EXISTS whiteboard_snapshots (
 		id bigserial PRIMARY KEY,
 		meeting_id bigint NOT NULL,
@@ -134,12 +189,63 @@ func ConnectDB() (*gorm.DB, error) {
 		end_id bigint,
 		created_at timestamptz DEFAULT now()
 	);
-	CREATE INDEX IF NOT EXISTS idx_whiteboard_snapshots_meeting ON whiteboard_snapshots (meeting_id);`
+	CREATE INDEX IF NOT EXISTS idx_whiteboard_snapshots_meeting ON whiteboard_snapshots (meeting_id);
+
+	-- Manual migration for named whiteboards (workspace boards have no meeting)
+	ALTER TABLE whiteboard_strokes ALTER COLUMN meeting_id DROP NOT NULL;
+	ALTER TABLE whiteboard_strokes ADD COLUMN IF NOT EXISTS whiteboard_id bigint;
+	CREATE INDEX IF NOT EXISTS idx_whiteboard_strokes_whiteboard_id ON whiteboard_strokes (whiteboard_id);
+	ALTER TABLE whiteboard_snapshots ALTER COLUMN meeting_id DROP NOT NULL;
+	ALTER TABLE whiteboard_snapshots ADD COLUMN IF NOT EXISTS whiteboard_id bigint;
+	CREATE INDEX IF NOT EXISTS idx_whiteboard_snapshots_whiteboard_id ON whiteboard_snapshots (whiteboard_id);
+
+	-- Manual migration for whiteboard objects (shapes, text, images)
+	ALTER TABLE whiteboard_strokes ADD COLUMN IF NOT EXISTS object_type varchar(20) NOT NULL DEFAULT 'stroke';
+	ALTER TABLE whiteboard_strokes ADD COLUMN IF NOT EXISTS object_id varchar(64);
+	CREATE INDEX IF NOT EXISTS idx_whiteboard_strokes_object_id ON whiteboard_strokes (object_id);
+
+	-- Manual migration for whiteboard view-only mode
+	ALTER TABLE meetings ADD COLUMN IF NOT EXISTS whiteboard_view_only boolean NOT NULL DEFAULT false;
+	ALTER TABLE whiteboards ADD COLUMN IF NOT EXISTS view_only boolean NOT NULL DEFAULT false;
+
+	-- Manual migration for opaque calendar feed tokens (JWT feed tokens are revoked; users re-issue the URL)
+	DELETE FROM calendar_feed_tokens WHERE token_hash IS NULL;
+	ALTER TABLE calendar_feed_tokens DROP COLUMN IF EXISTS token_id;`
 
 	if err := db.Exec(sql).Error; err != nil {
 		log.Printf("⚠️ Manual Table Creation Warning: %v", err)
 	}
 
+	// DRAW_WHITEBOARD 도입 전 역할은 메시지를 보낼 수 있으면 그리기도 허용 (한 번만 실행, 이후 관리자가 뺀 권한은 유지)
+	permissionSQL := `
+	INSERT INTO role_permissions (role_id, permission_code)
+	SELECT role_id, 'DRAW_WHITEBOARD' FROM role_permissions
+	WHERE permission_code = 'SEND_MESSAGES'
+	  AND NOT EXISTS (SELECT 1 FROM role_permissions WHERE permission_code = 'DRAW_WHITEBOARD')
+	ON CONFLICT DO NOTHING;`
+
+	if err := db.Exec(permissionSQL).Error; err != nil {
+		log.Printf("⚠️ Whiteboard Permission Backfill Warning: %v", err)
+	}
+
+	// 파일 이름 부분 검색(ILIKE '%q%')용 trigram 인덱스
+	searchSQL := `
+	CREATE EXTENSION IF NOT EXISTS pg_trgm;
+	CREATE INDEX IF NOT EXISTS idx_workspace_files_name_trgm ON workspace_files USING gin (name gin_trgm_ops);`
+
+	if err := db.Exec(searchSQL).Error; err != nil {
+		log.Printf("⚠️ File Search Index Warning: %v", err)
+	}
+
+	// 파일 활동 기록은 감사용이라 수정/삭제 불가
+	auditSQL := `
+	CREATE OR REPLACE RULE file_activities_no_update AS ON UPDATE TO file_activities DO INSTEAD NOTHING;
+	CREATE OR REPLACE RULE file_activities_no_delete AS ON DELETE TO file_activities DO INSTEAD NOTHING;`
+
+	if err := db.Exec(auditSQL).Error; err != nil {
+		log.Printf("⚠️ File Activity Rule Warning: %v", err)
+	}
+
 	return db, nil
 }
 
@@ -0,0 +1,375 @@
+package errorreport
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"realtime-backend/internal/config"
+)
+
+const (
+	modulePrefix = "realtime-backend/"
+	selfPrefix   = "realtime-backend/internal/errorreport."
+	queueSize    = 100
+)
+
+// Context 오류 이벤트에 붙는 사용자/워크스페이스 정보
+type Context struct {
+	UserID      int64
+	WorkspaceID int64
+	Component   string            // 발생 위치 (예: "ws.chat", "room.broadcaster")
+	Tags        map[string]string // 추가 태그 (room_id 등)
+}
+
+// Reporter Sentry store API로 이벤트를 비동기 전송
+type Reporter struct {
+	endpoint    string
+	authHeader  string
+	release     string
+	environment string
+	serverName  string
+	client      *http.Client
+	events      chan *event
+	pending     sync.WaitGroup
+}
+
+var (
+	mu       sync.RWMutex
+	reporter *Reporter
+)
+
+// Init 전역 리포터 초기화 (DSN이 없으면 로그만 남기고 전송하지 않음)
+func Init(cfg config.SentryConfig) error {
+	if cfg.DSN == "" {
+		log.Println("ℹ️ Error reporting not configured (SENTRY_DSN is empty)")
+		return nil
+	}
+
+	endpoint, key, err := parseDSN(cfg.DSN)
+	if err != nil {
+		return err
+	}
+
+	release := cfg.Release
+	if release == "" {
+		release = buildRevision()
+	}
+	serverName, _ := os.Hostname()
+
+	r := &Reporter{
+		endpoint:    endpoint,
+		authHeader:  fmt.Sprintf("Sentry sentry_version=7, sentry_client=eum-backend/1.0, sentry_key=%s", key),
+		release:     release,
+		environment: cfg.Environment,
+		serverName:  serverName,
+		client:      &http.Client{Timeout: 5 * time.Second},
+		events:      make(chan *event, queueSize),
+	}
+	go r.run()
+
+	mu.Lock()
+	reporter = r
+	mu.Unlock()
+
+	log.Printf("✅ Error reporting enabled (release: %s, environment: %s)", release, cfg.Environment)
+	return nil
+}
+
+// Flush 대기 중인 이벤트 전송을 최대 timeout 동안 기다림 (종료 직전 호출)
+func Flush(timeout time.Duration) {
+	r := current()
+	if r == nil {
+		return
+	}
+
+	done := make(chan struct{})
+	go func() {
+		r.pending.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(timeout):
+		log.Printf("⚠️ Error reporting flush timed out after %v", timeout)
+	}
+}
+
+// CaptureError 처리되지 않은 오류 보고
+func CaptureError(err error, ctx Context) {
+	if err == nil {
+		return
+	}
+	log.Printf("❗ [%s] %v", componentName(ctx), err)
+
+	r := current()
+	if r == nil {
+		return
+	}
+
+	frames := callerFrames(3, false)
+	e := r.newEvent("error", ctx)
+	e.Exception = &exceptionList{Values: []exception{{
+		Type:       fmt.Sprintf("%T", err),
+		Value:      err.Error(),
+		Mechanism:  &mechanism{Type: "generic", Handled: true},
+		Stacktrace: &stacktrace{Frames: frames},
+	}}}
+	r.enqueue(e)
+}
+
+// CapturePanic recover()로 잡은 패닉 보고 (deferred 함수 안에서 호출해야 패닉 스택이 남음)
+// 같은 위치의 같은 패닉은 하나의 이슈로 묶이도록 fingerprint 지정
+func CapturePanic(recovered any, ctx Context) {
+	log.Printf("🔥 [%s] panic: %v\n%s", componentName(ctx), recovered, debug.Stack())
+
+	r := current()
+	if r == nil {
+		return
+	}
+
+	frames := callerFrames(3, true)
+	panicType := fmt.Sprintf("%T", recovered)
+
+	e := r.newEvent("fatal", ctx)
+	e.Exception = &exceptionList{Values: []exception{{
+		Type:       "panic: " + panicType,
+		Value:      fmt.Sprint(recovered),
+		Mechanism:  &mechanism{Type: "panic", Handled: false},
+		Stacktrace: &stacktrace{Frames: frames},
+	}}}
+	e.Fingerprint = []string{"panic", componentName(ctx), panicType, panicOrigin(frames)}
+	r.enqueue(e)
+}
+
+// Recover 고루틴 패닉 격리용 (defer errorreport.Recover(ctx))
+// 패닉을 삼키고 보고하므로 해당 고루틴만 종료되고 프로세스는 계속 동작
+func Recover(ctx Context) {
+	if recovered := recover(); recovered != nil {
+		CapturePanic(recovered, ctx)
+	}
+}
+
+func current() *Reporter {
+	mu.RLock()
+	defer mu.RUnlock()
+	return reporter
+}
+
+func componentName(ctx Context) string {
+	if ctx.Component == "" {
+		return "unknown"
+	}
+	return ctx.Component
+}
+
+func (r *Reporter) newEvent(level string, ctx Context) *event {
+	e := &event{
+		EventID:     newEventID(),
+		Timestamp:   time.Now().UTC().Format(time.RFC3339),
+		Level:       level,
+		Platform:    "go",
+		Release:     r.release,
+		Environment: r.environment,
+		ServerName:  r.serverName,
+		Tags:        map[string]string{"component": componentName(ctx)},
+	}
+	for k, v := range ctx.Tags {
+		e.Tags[k] = v
+	}
+	if ctx.WorkspaceID != 0 {
+		e.Tags["workspace_id"] = strconv.FormatInt(ctx.WorkspaceID, 10)
+	}
+	if ctx.UserID != 0 {
+		e.User = &user{ID: strconv.FormatInt(ctx.UserID, 10)}
+	}
+	return e
+}
+
+// enqueue 전송 큐에 추가 (가득 차면 버림 - 패닉 폭주가 서버를 막지 않도록)
+func (r *Reporter) enqueue(e *event) {
+	r.pending.Add(1)
+	select {
+	case r.events <- e:
+	default:
+		r.pending.Done()
+		log.Printf("⚠️ Error reporting queue full, dropping event %s", e.EventID)
+	}
+}
+
+func (r *Reporter) run() {
+	for e := range r.events {
+		r.send(e)
+		r.pending.Done()
+	}
+}
+
+func (r *Reporter) send(e *event) {
+	body, err := json.Marshal(e)
+	if err != nil {
+		log.Printf("⚠️ Failed to encode error event: %v", err)
+		return
+	}
+
+	req, err := http.NewRequest(http.MethodPost, r.endpoint, bytes.NewReader(body))
+	if err != nil {
+		log.Printf("⚠️ Failed to build error event request: %v", err)
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Sentry-Auth", r.authHeader)
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		log.Printf("⚠️ Failed to send error event %s: %v", e.EventID, err)
+		return
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		log.Printf("⚠️ Error event %s rejected: %s", e.EventID, resp.Status)
+	}
+}
+
+// parseDSN https://<key>@<host>/<project> → store API 주소, 공개 키
+func parseDSN(dsn string) (string, string, error) {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid SENTRY_DSN: %w", err)
+	}
+	if u.User == nil || u.User.Username() == "" {
+		return "", "", fmt.Errorf("invalid SENTRY_DSN: missing public key")
+	}
+
+	path := strings.TrimSuffix(u.Path, "/")
+	idx := strings.LastIndex(path, "/")
+	if idx < 0 || path[idx+1:] == "" {
+		return "", "", fmt.Errorf("invalid SENTRY_DSN: missing project id")
+	}
+
+	endpoint := fmt.Sprintf("%s://%s%s/api/%s/store/", u.Scheme, u.Host, path[:idx], path[idx+1:])
+	return endpoint, u.User.Username(), nil
+}
+
+// buildRevision 빌드에 포함된 VCS 리비전 (릴리스 태그 기본값)
+func buildRevision() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+	for _, s := range info.Settings {
+		if s.Key == "vcs.revision" {
+			if len(s.Value) > 12 {
+				return s.Value[:12]
+			}
+			return s.Value
+		}
+	}
+	return ""
+}
+
+func newEventID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// callerFrames 현재 고루틴 스택 (Sentry 규격: 오래된 호출이 먼저)
+// fromPanic이면 recover 처리부는 빼고 panic 지점부터 기록
+func callerFrames(skip int, fromPanic bool) []frame {
+	pcs := make([]uintptr, 64)
+	n := runtime.Callers(skip, pcs)
+	iter := runtime.CallersFrames(pcs[:n])
+
+	var frames []frame
+	for {
+		f, more := iter.Next()
+		if fromPanic && f.Function == "runtime.gopanic" {
+			frames = frames[:0]
+		} else if !strings.HasPrefix(f.Function, selfPrefix) {
+			frames = append(frames, frame{
+				Function: f.Function,
+				Filename: f.File,
+				AbsPath:  f.File,
+				Lineno:   f.Line,
+				InApp:    strings.HasPrefix(f.Function, modulePrefix),
+			})
+		}
+		if !more {
+			break
+		}
+	}
+
+	for i, j := 0, len(frames)-1; i < j; i, j = i+1, j-1 {
+		frames[i], frames[j] = frames[j], frames[i]
+	}
+	return frames
+}
+
+// panicOrigin 패닉이 발생한 가장 안쪽의 앱 코드 위치
+func panicOrigin(frames []frame) string {
+	for i := len(frames) - 1; i >= 0; i-- {
+		if frames[i].InApp {
+			return frames[i].Function
+		}
+	}
+	return ""
+}
+
+type event struct {
+	EventID     string            `json:"event_id"`
+	Timestamp   string            `json:"timestamp"`
+	Level       string            `json:"level"`
+	Platform    string            `json:"platform"`
+	Release     string            `json:"release,omitempty"`
+	Environment string            `json:"environment,omitempty"`
+	ServerName  string            `json:"server_name,omitempty"`
+	Exception   *exceptionList    `json:"exception,omitempty"`
+	User        *user             `json:"user,omitempty"`
+	Tags        map[string]string `json:"tags,omitempty"`
+	Fingerprint []string          `json:"fingerprint,omitempty"`
+}
+
+type exceptionList struct {
+	Values []exception `json:"values"`
+}
+
+type exception struct {
+	Type       string      `json:"type"`
+	Value      string      `json:"value"`
+	Mechanism  *mechanism  `json:"mechanism,omitempty"`
+	Stacktrace *stacktrace `json:"stacktrace,omitempty"`
+}
+
+type mechanism struct {
+	Type    string `json:"type"`
+	Handled bool   `json:"handled"`
+}
+
+type stacktrace struct {
+	Frames []frame `json:"frames"`
+}
+
+type frame struct {
+	Function string `json:"function"`
+	Filename string `json:"filename"`
+	AbsPath  string `json:"abs_path"`
+	Lineno   int    `json:"lineno"`
+	InApp    bool   `json:"in_app"`
+}
+
+type user struct {
+	ID string `json:"id"`
+}
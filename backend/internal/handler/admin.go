@@ -0,0 +1,640 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+
+	"realtime-backend/internal/auth"
+	"realtime-backend/internal/model"
+)
+
+const (
+	adminListDefaultLimit = 50
+	adminListMaxLimit     = 200
+	suspendReasonMaxLen   = 500
+)
+
+// AdminHandler 플랫폼 관리자 API (사용자/워크스페이스 관리, 시스템 사용량)
+type AdminHandler struct {
+	db *gorm.DB
+}
+
+// NewAdminHandler AdminHandler 생성
+func NewAdminHandler(db *gorm.DB) *AdminHandler {
+	return &AdminHandler{db: db}
+}
+
+// AdminUserResponse 관리자용 사용자 정보
+type AdminUserResponse struct {
+	ID              int64   `json:"id"`
+	Email           string  `json:"email"`
+	Nickname        string  `json:"nickname"`
+	ProfileImg      *string `json:"profile_img,omitempty"`
+	Provider        *string `json:"provider,omitempty"`
+	EmailVerified   bool    `json:"email_verified"`
+	IsPlatformAdmin bool    `json:"is_platform_admin"`
+	SuspendedAt     *string `json:"suspended_at,omitempty"`
+	SuspendedReason *string `json:"suspended_reason,omitempty"`
+	CreatedAt       string  `json:"created_at"`
+}
+
+// AdminUserDetailResponse 관리자용 사용자 상세 (소속 워크스페이스, 로그인 현황)
+type AdminUserDetailResponse struct {
+	AdminUserResponse
+	TwoFactorEnabled bool                     `json:"two_factor_enabled"`
+	ActiveSessions   int64                    `json:"active_sessions"`
+	PersonalTokens   int64                    `json:"personal_tokens"`
+	LastSeenAt       *string                  `json:"last_seen_at,omitempty"`
+	Workspaces       []AdminUserWorkspaceItem `json:"workspaces"`
+}
+
+// AdminUserWorkspaceItem 사용자가 속한 워크스페이스
+type AdminUserWorkspaceItem struct {
+	ID      int64  `json:"id"`
+	Name    string `json:"name"`
+	IsOwner bool   `json:"is_owner"`
+	Status  string `json:"status"`
+}
+
+// AdminWorkspaceResponse 관리자용 워크스페이스 정보
+type AdminWorkspaceResponse struct {
+	ID               int64         `json:"id"`
+	Name             string        `json:"name"`
+	OwnerID          int64         `json:"owner_id"`
+	Owner            *UserResponse `json:"owner,omitempty"`
+	DataRegion       *string       `json:"data_region,omitempty"`
+	Discoverable     bool          `json:"discoverable"`
+	RequireTwoFactor bool          `json:"require_two_factor"`
+	MemberCount      int64         `json:"member_count"`
+	CreatedAt        string        `json:"created_at"`
+}
+
+// AdminWorkspaceDetailResponse 관리자용 워크스페이스 상세 (저장 용량, 회의, 이번 달 AI 사용량)
+type AdminWorkspaceDetailResponse struct {
+	AdminWorkspaceResponse
+	FileCount        int64           `json:"file_count"`
+	StorageBytes     int64           `json:"storage_bytes"`
+	MeetingCount     int64           `json:"meeting_count"`
+	ActiveMeetings   int64           `json:"active_meetings"`
+	AIUsageThisMonth AdminAIUsageSum `json:"ai_usage_this_month"`
+}
+
+// AdminAIUsageSum AI 사용량 합계
+type AdminAIUsageSum struct {
+	TranscribeSeconds float64 `json:"transcribe_seconds"`
+	TranslateChars    int64   `json:"translate_chars"`
+	TTSChars          int64   `json:"tts_chars"`
+}
+
+// AdminAuditLogResponse 관리자 작업 기록 응답
+type AdminAuditLogResponse struct {
+	ID         int64           `json:"id"`
+	AdminID    int64           `json:"admin_id"`
+	Admin      *UserResponse   `json:"admin,omitempty"`
+	Action     string          `json:"action"`
+	TargetType string          `json:"target_type"`
+	TargetID   int64           `json:"target_id"`
+	Metadata   json.RawMessage `json:"metadata"`
+	IPAddress  string          `json:"ip_address"`
+	CreatedAt  string          `json:"created_at"`
+}
+
+// SuspendUserRequest 계정 정지 요청
+type SuspendUserRequest struct {
+	Reason string `json:"reason"`
+}
+
+// RequirePlatformAdmin 플랫폼 관리자 전용 라우트 미들웨어 (대리 접속/개인 액세스 토큰 불가)
+// 확인한 관리자는 c.Locals("platform_admin")에 저장
+func RequirePlatformAdmin(db *gorm.DB) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		admin, ok := requirePlatformAdmin(c, db)
+		if !ok {
+			return nil
+		}
+		c.Locals("platform_admin", admin)
+		return c.Next()
+	}
+}
+
+// currentAdmin RequirePlatformAdmin 미들웨어가 확인한 관리자
+func currentAdmin(c *fiber.Ctx) *model.User {
+	admin, _ := c.Locals("platform_admin").(*model.User)
+	return admin
+}
+
+// recordAdminAction 관리자 작업 기록 (실패해도 요청은 계속 처리)
+func recordAdminAction(db *gorm.DB, c *fiber.Ctx, adminID int64, action model.AdminAction, targetType string, targetID int64, metadata map[string]interface{}) {
+	entry := model.AdminAuditLog{
+		AdminID:    adminID,
+		Action:     action.String(),
+		TargetType: targetType,
+		TargetID:   targetID,
+		Metadata:   "{}",
+		IPAddress:  c.IP(),
+	}
+	if len(metadata) > 0 {
+		if data, err := json.Marshal(metadata); err == nil {
+			entry.Metadata = string(data)
+		}
+	}
+	if err := db.Create(&entry).Error; err != nil {
+		log.Printf("⚠️ 관리자 작업 기록 실패: admin=%d, action=%s, target=%s:%d, err=%v", adminID, action, targetType, targetID, err)
+	}
+}
+
+// adminPage limit/offset 쿼리 파라미터
+func adminPage(c *fiber.Ctx) (int, int) {
+	limit := c.QueryInt("limit", adminListDefaultLimit)
+	if limit <= 0 || limit > adminListMaxLimit {
+		limit = adminListDefaultLimit
+	}
+	offset := c.QueryInt("offset", 0)
+	if offset < 0 {
+		offset = 0
+	}
+	return limit, offset
+}
+
+// ListUsers 사용자 목록 (q: 이메일/닉네임 검색, status: active | suspended | admin)
+func (h *AdminHandler) ListUsers(c *fiber.Ctx) error {
+	limit, offset := adminPage(c)
+
+	query := h.db.Model(&model.User{})
+	if q := strings.TrimSpace(c.Query("q")); q != "" {
+		pattern := "%" + q + "%"
+		query = query.Where("email ILIKE ? OR nickname ILIKE ?", pattern, pattern)
+	}
+	switch c.Query("status") {
+	case "":
+	case "active":
+		query = query.Where("suspended_at IS NULL")
+	case "suspended":
+		query = query.Where("suspended_at IS NOT NULL")
+	case "admin":
+		query = query.Where("is_platform_admin = ?", true)
+	default:
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "status must be active, suspended or admin"})
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to count users"})
+	}
+
+	var users []model.User
+	if err := query.Order("id DESC").Limit(limit).Offset(offset).Find(&users).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get users"})
+	}
+
+	responses := make([]AdminUserResponse, len(users))
+	for i := range users {
+		responses[i] = toAdminUserResponse(&users[i])
+	}
+	return c.JSON(fiber.Map{
+		"users":    responses,
+		"total":    total,
+		"has_more": int64(offset+len(users)) < total,
+	})
+}
+
+// GetUser 사용자 상세 (조회도 관리자 작업으로 기록)
+func (h *AdminHandler) GetUser(c *fiber.Ctx) error {
+	admin := currentAdmin(c)
+	userID, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid user id"})
+	}
+
+	var user model.User
+	if err := h.db.First(&user, userID).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "user not found"})
+	}
+
+	resp := AdminUserDetailResponse{
+		AdminUserResponse: toAdminUserResponse(&user),
+		Workspaces:        []AdminUserWorkspaceItem{},
+	}
+	resp.TwoFactorEnabled, _ = twoFactorEnabled(h.db, user.ID)
+
+	now := time.Now()
+	h.db.Model(&model.AuthSession{}).
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", user.ID, now).
+		Count(&resp.ActiveSessions)
+	h.db.Model(&model.PersonalAccessToken{}).
+		Where("user_id = ? AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > ?)", user.ID, now).
+		Count(&resp.PersonalTokens)
+
+	var lastSession model.AuthSession
+	if err := h.db.Where("user_id = ?", user.ID).Order("last_seen_at DESC").First(&lastSession).Error; err == nil {
+		lastSeen := lastSession.LastSeenAt.Format(time.RFC3339)
+		resp.LastSeenAt = &lastSeen
+	}
+
+	var members []model.WorkspaceMember
+	h.db.Preload("Workspace").Where("user_id = ?", user.ID).Find(&members)
+	for _, m := range members {
+		resp.Workspaces = append(resp.Workspaces, AdminUserWorkspaceItem{
+			ID:      m.WorkspaceID,
+			Name:    m.Workspace.Name,
+			IsOwner: m.Workspace.OwnerID == user.ID,
+			Status:  m.Status,
+		})
+	}
+
+	recordAdminAction(h.db, c, admin.ID, model.AdminUserViewed, model.AdminTargetUser, user.ID, nil)
+	return c.JSON(resp)
+}
+
+// SuspendUser 계정 정지 - 모든 로그인 세션을 취소하고 로그인/개인 액세스 토큰 사용을 막음
+func (h *AdminHandler) SuspendUser(c *fiber.Ctx) error {
+	admin := currentAdmin(c)
+	userID, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid user id"})
+	}
+
+	var req SuspendUserRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid request body"})
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "reason is required"})
+	}
+	if len([]rune(reason)) > suspendReasonMaxLen {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "reason is too long"})
+	}
+
+	var user model.User
+	if err := h.db.First(&user, userID).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "user not found"})
+	}
+	if user.ID == admin.ID {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "cannot suspend yourself"})
+	}
+	if user.IsPlatformAdmin {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "cannot suspend another platform admin"})
+	}
+	if user.SuspendedAt != nil {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "user is already suspended"})
+	}
+
+	now := time.Now()
+	if err := h.db.Model(&user).Updates(map[string]interface{}{
+		"suspended_at":     now,
+		"suspended_reason": reason,
+	}).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to suspend user"})
+	}
+	if err := h.logoutEverywhere(user.ID); err != nil {
+		log.Printf("⚠️ 정지 계정 세션 취소 실패: user=%d, err=%v", user.ID, err)
+	}
+
+	recordAdminAction(h.db, c, admin.ID, model.AdminUserSuspended, model.AdminTargetUser, user.ID, map[string]interface{}{
+		"reason": reason,
+	})
+	log.Printf("🚫 계정 정지: user=%d admin=%d", user.ID, admin.ID)
+
+	user.SuspendedAt = &now
+	user.SuspendedReason = &reason
+	return c.JSON(toAdminUserResponse(&user))
+}
+
+// UnsuspendUser 계정 정지 해제 (다시 로그인해야 함)
+func (h *AdminHandler) UnsuspendUser(c *fiber.Ctx) error {
+	admin := currentAdmin(c)
+	userID, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid user id"})
+	}
+
+	var user model.User
+	if err := h.db.First(&user, userID).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "user not found"})
+	}
+	if user.SuspendedAt == nil {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "user is not suspended"})
+	}
+
+	if err := h.db.Model(&user).Updates(map[string]interface{}{
+		"suspended_at":     nil,
+		"suspended_reason": nil,
+	}).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to unsuspend user"})
+	}
+	h.invalidatePersonalTokens(user.ID)
+
+	recordAdminAction(h.db, c, admin.ID, model.AdminUserUnsuspended, model.AdminTargetUser, user.ID, nil)
+
+	user.SuspendedAt = nil
+	user.SuspendedReason = nil
+	return c.JSON(toAdminUserResponse(&user))
+}
+
+// ForceLogoutUser 사용자의 모든 로그인 세션 강제 로그아웃
+func (h *AdminHandler) ForceLogoutUser(c *fiber.Ctx) error {
+	admin := currentAdmin(c)
+	userID, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid user id"})
+	}
+
+	var user model.User
+	if err := h.db.Select("id").First(&user, userID).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "user not found"})
+	}
+
+	var active int64
+	h.db.Model(&model.AuthSession{}).Where("user_id = ? AND revoked_at IS NULL", user.ID).Count(&active)
+	if err := h.logoutEverywhere(user.ID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to revoke sessions"})
+	}
+
+	recordAdminAction(h.db, c, admin.ID, model.AdminUserLoggedOut, model.AdminTargetUser, user.ID, map[string]interface{}{
+		"sessions_revoked": active,
+	})
+	return c.JSON(fiber.Map{
+		"message":          "all sessions revoked",
+		"sessions_revoked": active,
+	})
+}
+
+// logoutEverywhere 로그인 세션을 모두 취소하고 개인 액세스 토큰 캐시 무효화
+func (h *AdminHandler) logoutEverywhere(userID int64) error {
+	h.invalidatePersonalTokens(userID)
+	return revokeSessions(h.db, model.SessionRevokedByAdmin, "user_id = ?", userID)
+}
+
+// invalidatePersonalTokens 이 인스턴스에 캐시된 사용자의 개인 액세스 토큰 조회 결과 삭제
+func (h *AdminHandler) invalidatePersonalTokens(userID int64) {
+	var hashes []string
+	h.db.Model(&model.PersonalAccessToken{}).Where("user_id = ?", userID).Pluck("token_hash", &hashes)
+	for _, hash := range hashes {
+		auth.InvalidatePersonalToken(hash)
+	}
+}
+
+// ListWorkspaces 워크스페이스 목록 (q: 이름 검색)
+func (h *AdminHandler) ListWorkspaces(c *fiber.Ctx) error {
+	limit, offset := adminPage(c)
+
+	query := h.db.Model(&model.Workspace{})
+	if q := strings.TrimSpace(c.Query("q")); q != "" {
+		query = query.Where("name ILIKE ?", "%"+q+"%")
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to count workspaces"})
+	}
+
+	var workspaces []model.Workspace
+	if err := query.Preload("Owner").Order("id DESC").Limit(limit).Offset(offset).Find(&workspaces).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get workspaces"})
+	}
+
+	ids := make([]int64, len(workspaces))
+	for i, ws := range workspaces {
+		ids[i] = ws.ID
+	}
+	memberCounts := h.memberCounts(ids)
+
+	responses := make([]AdminWorkspaceResponse, len(workspaces))
+	for i := range workspaces {
+		responses[i] = toAdminWorkspaceResponse(&workspaces[i], memberCounts[workspaces[i].ID])
+	}
+	return c.JSON(fiber.Map{
+		"workspaces": responses,
+		"total":      total,
+		"has_more":   int64(offset+len(workspaces)) < total,
+	})
+}
+
+// GetWorkspace 워크스페이스 상세 (조회도 관리자 작업으로 기록)
+func (h *AdminHandler) GetWorkspace(c *fiber.Ctx) error {
+	admin := currentAdmin(c)
+	workspaceID, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid workspace id"})
+	}
+
+	var workspace model.Workspace
+	if err := h.db.Preload("Owner").First(&workspace, workspaceID).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "workspace not found"})
+	}
+
+	resp := AdminWorkspaceDetailResponse{
+		AdminWorkspaceResponse: toAdminWorkspaceResponse(&workspace, h.memberCounts([]int64{workspace.ID})[workspace.ID]),
+	}
+
+	var files struct {
+		Count int64
+		Bytes int64
+	}
+	h.db.Model(&model.WorkspaceFile{}).
+		Select("COUNT(*) AS count, COALESCE(SUM(file_size), 0) AS bytes").
+		Where("workspace_id = ? AND type = ?", workspace.ID, "FILE").
+		Scan(&files)
+	resp.FileCount, resp.StorageBytes = files.Count, files.Bytes
+
+	h.db.Model(&model.Meeting{}).Where("workspace_id = ?", workspace.ID).Count(&resp.MeetingCount)
+	h.db.Model(&model.Meeting{}).Where("workspace_id = ? AND status = ?", workspace.ID, "IN_PROGRESS").Count(&resp.ActiveMeetings)
+	resp.AIUsageThisMonth = h.aiUsageSince(monthStartUTC(), "workspace_id = ?", workspace.ID)
+
+	recordAdminAction(h.db, c, admin.ID, model.AdminWorkspaceViewed, model.AdminTargetWorkspace, workspace.ID, nil)
+	return c.JSON(resp)
+}
+
+// GetSystemUsage 시스템 전체 사용량 (사용자, 워크스페이스, 세션, 회의, 저장 용량, 이번 달 AI 사용량)
+func (h *AdminHandler) GetSystemUsage(c *fiber.Ctx) error {
+	now := time.Now()
+	weekAgo := now.AddDate(0, 0, -7)
+
+	var users struct {
+		Total     int64 `json:"total"`
+		NewInWeek int64 `json:"new_last_7_days"`
+		Suspended int64 `json:"suspended"`
+		Admins    int64 `json:"platform_admins"`
+	}
+	h.db.Model(&model.User{}).Count(&users.Total)
+	h.db.Model(&model.User{}).Where("created_at >= ?", weekAgo).Count(&users.NewInWeek)
+	h.db.Model(&model.User{}).Where("suspended_at IS NOT NULL").Count(&users.Suspended)
+	h.db.Model(&model.User{}).Where("is_platform_admin = ?", true).Count(&users.Admins)
+
+	var workspaces struct {
+		Total     int64 `json:"total"`
+		NewInWeek int64 `json:"new_last_7_days"`
+	}
+	h.db.Model(&model.Workspace{}).Count(&workspaces.Total)
+	h.db.Model(&model.Workspace{}).Where("created_at >= ?", weekAgo).Count(&workspaces.NewInWeek)
+
+	var activeSessions, activeUsers, activeMeetings int64
+	h.db.Model(&model.AuthSession{}).Where("revoked_at IS NULL AND expires_at > ?", now).Count(&activeSessions)
+	h.db.Model(&model.AuthSession{}).Where("last_seen_at >= ?", now.Add(-24*time.Hour)).Distinct("user_id").Count(&activeUsers)
+	h.db.Model(&model.Meeting{}).Where("status = ?", "IN_PROGRESS").Count(&activeMeetings)
+
+	var files struct {
+		Count int64 `json:"files"`
+		Bytes int64 `json:"bytes"`
+	}
+	h.db.Model(&model.WorkspaceFile{}).
+		Select("COUNT(*) AS count, COALESCE(SUM(file_size), 0) AS bytes").
+		Where("type = ?", "FILE").
+		Scan(&files)
+
+	return c.JSON(fiber.Map{
+		"users":      users,
+		"workspaces": workspaces,
+		"sessions": fiber.Map{
+			"active":         activeSessions,
+			"users_last_24h": activeUsers,
+		},
+		"meetings": fiber.Map{
+			"in_progress": activeMeetings,
+		},
+		"storage":             files,
+		"ai_usage_this_month": h.aiUsageSince(monthStartUTC(), "1 = 1"),
+		"generated_at":        now.Format(time.RFC3339),
+	})
+}
+
+// GetAdminAuditLogs 관리자 작업 기록 (최신순, admin_id, action, target_type, target_id로 필터)
+func (h *AdminHandler) GetAdminAuditLogs(c *fiber.Ctx) error {
+	limit, offset := adminPage(c)
+
+	query := h.db.Model(&model.AdminAuditLog{})
+	if adminID := c.QueryInt("admin_id", 0); adminID > 0 {
+		query = query.Where("admin_id = ?", adminID)
+	}
+	if action := c.Query("action"); action != "" {
+		query = query.Where("action = ?", action)
+	}
+	if targetType := c.Query("target_type"); targetType != "" {
+		query = query.Where("target_type = ?", targetType)
+	}
+	if targetID := c.QueryInt("target_id", 0); targetID > 0 {
+		query = query.Where("target_id = ?", targetID)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to count audit logs"})
+	}
+
+	var logs []model.AdminAuditLog
+	if err := query.Preload("Admin").Order("created_at DESC, id DESC").Limit(limit).Offset(offset).Find(&logs).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get audit logs"})
+	}
+
+	responses := make([]AdminAuditLogResponse, len(logs))
+	for i, l := range logs {
+		responses[i] = AdminAuditLogResponse{
+			ID:         l.ID,
+			AdminID:    l.AdminID,
+			Action:     l.Action,
+			TargetType: l.TargetType,
+			TargetID:   l.TargetID,
+			Metadata:   json.RawMessage(l.Metadata),
+			IPAddress:  l.IPAddress,
+			CreatedAt:  l.CreatedAt.Format(time.RFC3339),
+		}
+		if l.Admin != nil {
+			responses[i].Admin = &UserResponse{
+				ID:         l.Admin.ID,
+				Email:      l.Admin.Email,
+				Nickname:   l.Admin.Nickname,
+				ProfileImg: l.Admin.ProfileImg,
+			}
+		}
+	}
+	return c.JSON(fiber.Map{
+		"logs":     responses,
+		"total":    total,
+		"has_more": int64(offset+len(logs)) < total,
+	})
+}
+
+// memberCounts 워크스페이스별 활성 멤버 수
+func (h *AdminHandler) memberCounts(workspaceIDs []int64) map[int64]int64 {
+	counts := make(map[int64]int64, len(workspaceIDs))
+	if len(workspaceIDs) == 0 {
+		return counts
+	}
+	var rows []struct {
+		WorkspaceID int64
+		Count       int64
+	}
+	h.db.Model(&model.WorkspaceMember{}).
+		Select("workspace_id, COUNT(*) AS count").
+		Where("workspace_id IN ? AND status = ?", workspaceIDs, model.MemberStatusActive.String()).
+		Group("workspace_id").
+		Scan(&rows)
+	for _, r := range rows {
+		counts[r.WorkspaceID] = r.Count
+	}
+	return counts
+}
+
+// aiUsageSince 기간 내 AI 사용량 합계
+func (h *AdminHandler) aiUsageSince(since time.Time, query string, args ...interface{}) AdminAIUsageSum {
+	var sum AdminAIUsageSum
+	h.db.Model(&model.AIUsageDaily{}).
+		Select("COALESCE(SUM(transcribe_seconds), 0) AS transcribe_seconds, COALESCE(SUM(translate_chars), 0) AS translate_chars, COALESCE(SUM(tts_chars), 0) AS tts_chars").
+		Where("usage_date >= ?", since).
+		Where(query, args...).
+		Scan(&sum)
+	return sum
+}
+
+// monthStartUTC 이번 달 1일 (AI 사용량은 UTC 날짜 기준)
+func monthStartUTC() time.Time {
+	now := time.Now().UTC()
+	return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+func toAdminUserResponse(u *model.User) AdminUserResponse {
+	resp := AdminUserResponse{
+		ID:              u.ID,
+		Email:           u.Email,
+		Nickname:        u.Nickname,
+		ProfileImg:      u.ProfileImg,
+		Provider:        u.Provider,
+		EmailVerified:   u.EmailVerifiedAt != nil || u.PasswordHash == nil, // Google 계정은 인증된 것으로 취급
+		IsPlatformAdmin: u.IsPlatformAdmin,
+		SuspendedReason: u.SuspendedReason,
+		CreatedAt:       u.CreatedAt.Format(time.RFC3339),
+	}
+	if u.SuspendedAt != nil {
+		suspendedAt := u.SuspendedAt.Format(time.RFC3339)
+		resp.SuspendedAt = &suspendedAt
+	}
+	return resp
+}
+
+func toAdminWorkspaceResponse(ws *model.Workspace, memberCount int64) AdminWorkspaceResponse {
+	resp := AdminWorkspaceResponse{
+		ID:               ws.ID,
+		Name:             ws.Name,
+		OwnerID:          ws.OwnerID,
+		DataRegion:       ws.DataRegion,
+		Discoverable:     ws.Discoverable,
+		RequireTwoFactor: ws.RequireTwoFactor,
+		MemberCount:      memberCount,
+		CreatedAt:        ws.CreatedAt.Format(time.RFC3339),
+	}
+	if ws.Owner.ID != 0 {
+		resp.Owner = &UserResponse{
+			ID:         ws.Owner.ID,
+			Email:      ws.Owner.Email,
+			Nickname:   ws.Owner.Nickname,
+			ProfileImg: ws.Owner.ProfileImg,
+		}
+	}
+	return resp
+}
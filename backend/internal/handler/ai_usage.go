@@ -0,0 +1,273 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"realtime-backend/internal/auth"
+	"realtime-backend/internal/model"
+)
+
+// aiUsageMaxRangeDays 사용량 조회 최대 기간
+const aiUsageMaxRangeDays = 366
+
+// AIUsageHandler 워크스페이스 AI 사용량(Transcribe/Translate/Polly) 조회 및 월간 한도 관리
+type AIUsageHandler struct {
+	db      *gorm.DB
+	roomHub *RoomHub
+}
+
+// NewAIUsageHandler AIUsageHandler 생성
+func NewAIUsageHandler(db *gorm.DB, roomHub *RoomHub) *AIUsageHandler {
+	return &AIUsageHandler{db: db, roomHub: roomHub}
+}
+
+// AIUsageTotals 사용량 합계
+type AIUsageTotals struct {
+	TranscribeSeconds float64 `json:"transcribe_seconds"`
+	TranslateChars    int64   `json:"translate_chars"`
+	TTSChars          int64   `json:"tts_chars"`
+}
+
+// AIUsageDayResponse 일별 사용량
+type AIUsageDayResponse struct {
+	Date string `json:"date"`
+	AIUsageTotals
+}
+
+// AIUsageRoomResponse Room별 사용량
+type AIUsageRoomResponse struct {
+	RoomID string `json:"room_id"`
+	AIUsageTotals
+}
+
+// AIUsageLimitsResponse 월간 한도 (0 = 제한 없음)
+type AIUsageLimitsResponse struct {
+	MonthlyTranscribeSeconds int64   `json:"monthly_transcribe_seconds"`
+	MonthlyTranslateChars    int64   `json:"monthly_translate_chars"`
+	MonthlyTTSChars          int64   `json:"monthly_tts_chars"`
+	UpdatedBy                *int64  `json:"updated_by,omitempty"`
+	UpdatedAt                *string `json:"updated_at,omitempty"`
+}
+
+// AIUsageResponse 워크스페이스 AI 사용량 응답
+type AIUsageResponse struct {
+	WorkspaceID int64                 `json:"workspace_id"`
+	From        string                `json:"from"`
+	To          string                `json:"to"`
+	Totals      AIUsageTotals         `json:"totals"`
+	Daily       []AIUsageDayResponse  `json:"daily"`
+	Rooms       []AIUsageRoomResponse `json:"rooms"`
+	MonthToDate AIUsageTotals         `json:"month_to_date"` // 이번 달(UTC) 사용량 - 한도와 비교
+	Limits      AIUsageLimitsResponse `json:"limits"`
+}
+
+// UpdateAIUsageLimitsRequest 월간 한도 변경 요청 (보낸 항목만 변경, 0 = 제한 없음)
+type UpdateAIUsageLimitsRequest struct {
+	MonthlyTranscribeSeconds *int64 `json:"monthly_transcribe_seconds"`
+	MonthlyTranslateChars    *int64 `json:"monthly_translate_chars"`
+	MonthlyTTSChars          *int64 `json:"monthly_tts_chars"`
+}
+
+// GetAIUsage 워크스페이스 AI 사용량 조회 (ADMIN)
+// ?from=YYYY-MM-DD&to=YYYY-MM-DD (기본값: 이번 달 1일 ~ 오늘, UTC)
+func (h *AIUsageHandler) GetAIUsage(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	workspaceID, err := c.ParamsInt("workspaceId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid workspace id",
+		})
+	}
+
+	if !h.requireAdmin(c, int64(workspaceID), claims.UserID) {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	from, to := monthStart, now.Truncate(24*time.Hour)
+	if v := c.Query("from"); v != "" {
+		if from, err = time.Parse(aiUsageDateLayout, v); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "invalid from date (YYYY-MM-DD)",
+			})
+		}
+	}
+	if v := c.Query("to"); v != "" {
+		if to, err = time.Parse(aiUsageDateLayout, v); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "invalid to date (YYYY-MM-DD)",
+			})
+		}
+	}
+	if to.Before(from) || to.Sub(from) > aiUsageMaxRangeDays*24*time.Hour {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid date range",
+		})
+	}
+
+	// 아직 저장되지 않은 사용량까지 반영
+	if h.roomHub != nil && h.roomHub.usage != nil {
+		h.roomHub.usage.Flush()
+	}
+
+	totalsSelect := "COALESCE(SUM(transcribe_seconds), 0) AS transcribe_seconds, " +
+		"COALESCE(SUM(translate_chars), 0) AS translate_chars, COALESCE(SUM(tts_chars), 0) AS tts_chars"
+	rangeQuery := func() *gorm.DB {
+		return h.db.Model(&model.AIUsageDaily{}).
+			Where("workspace_id = ? AND usage_date BETWEEN ? AND ?", workspaceID, from, to)
+	}
+
+	var daily []struct {
+		UsageDate time.Time
+		AIUsageTotals
+	}
+	if err := rangeQuery().
+		Select("usage_date, " + totalsSelect).
+		Group("usage_date").
+		Order("usage_date ASC").
+		Scan(&daily).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get ai usage",
+		})
+	}
+
+	var rooms []AIUsageRoomResponse
+	if err := rangeQuery().
+		Select("room_id, " + totalsSelect).
+		Group("room_id").
+		Order("transcribe_seconds DESC").
+		Scan(&rooms).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get ai usage",
+		})
+	}
+
+	var monthToDate AIUsageTotals
+	h.db.Model(&model.AIUsageDaily{}).
+		Where("workspace_id = ? AND usage_date >= ?", workspaceID, monthStart).
+		Select(totalsSelect).
+		Scan(&monthToDate)
+
+	resp := AIUsageResponse{
+		WorkspaceID: int64(workspaceID),
+		From:        from.Format(aiUsageDateLayout),
+		To:          to.Format(aiUsageDateLayout),
+		Daily:       make([]AIUsageDayResponse, 0, len(daily)),
+		Rooms:       rooms,
+		MonthToDate: monthToDate,
+		Limits:      h.loadLimits(int64(workspaceID)),
+	}
+	if resp.Rooms == nil {
+		resp.Rooms = []AIUsageRoomResponse{}
+	}
+	for _, d := range daily {
+		resp.Daily = append(resp.Daily, AIUsageDayResponse{
+			Date:          d.UsageDate.Format(aiUsageDateLayout),
+			AIUsageTotals: d.AIUsageTotals,
+		})
+		resp.Totals.TranscribeSeconds += d.TranscribeSeconds
+		resp.Totals.TranslateChars += d.TranslateChars
+		resp.Totals.TTSChars += d.TTSChars
+	}
+
+	return c.JSON(resp)
+}
+
+// UpdateAIUsageLimits 워크스페이스 월간 AI 사용량 한도 변경 (ADMIN)
+// 한도를 넘으면 진행 중인 Room도 해당 기능이 중단됨 (다른 인스턴스는 최대 1분 뒤 반영)
+func (h *AIUsageHandler) UpdateAIUsageLimits(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	workspaceID, err := c.ParamsInt("workspaceId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid workspace id",
+		})
+	}
+
+	if !h.requireAdmin(c, int64(workspaceID), claims.UserID) {
+		return nil
+	}
+
+	var req UpdateAIUsageLimitsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+	for _, v := range []*int64{req.MonthlyTranscribeSeconds, req.MonthlyTranslateChars, req.MonthlyTTSChars} {
+		if v != nil && *v < 0 {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "limits must be zero (unlimited) or positive",
+			})
+		}
+	}
+
+	var limit model.WorkspaceAIUsageLimit
+	h.db.Where("workspace_id = ?", workspaceID).Limit(1).Find(&limit)
+	limit.WorkspaceID = int64(workspaceID)
+	if req.MonthlyTranscribeSeconds != nil {
+		limit.MonthlyTranscribeSeconds = *req.MonthlyTranscribeSeconds
+	}
+	if req.MonthlyTranslateChars != nil {
+		limit.MonthlyTranslateChars = *req.MonthlyTranslateChars
+	}
+	if req.MonthlyTTSChars != nil {
+		limit.MonthlyTTSChars = *req.MonthlyTTSChars
+	}
+	limit.UpdatedBy = &claims.UserID
+
+	if err := h.db.Clauses(clause.OnConflict{UpdateAll: true}).Create(&limit).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to update ai usage limits",
+		})
+	}
+
+	if h.roomHub != nil && h.roomHub.usage != nil {
+		h.roomHub.usage.Invalidate(int64(workspaceID))
+	}
+
+	return c.JSON(toAIUsageLimitsResponse(&limit))
+}
+
+// requireAdmin ADMIN 권한 확인 (실패 시 에러 응답을 기록하고 false 반환)
+func (h *AIUsageHandler) requireAdmin(c *fiber.Ctx, workspaceID, userID int64) bool {
+	hasPermission, err := auth.CheckPermission(h.db, workspaceID, userID, "ADMIN")
+	if err != nil {
+		c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to check permission",
+		})
+		return false
+	}
+	if !hasPermission {
+		c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "you do not have permission to manage ai usage",
+		})
+		return false
+	}
+	return true
+}
+
+func (h *AIUsageHandler) loadLimits(workspaceID int64) AIUsageLimitsResponse {
+	var limit model.WorkspaceAIUsageLimit
+	h.db.Where("workspace_id = ?", workspaceID).Limit(1).Find(&limit)
+	return toAIUsageLimitsResponse(&limit)
+}
+
+func toAIUsageLimitsResponse(limit *model.WorkspaceAIUsageLimit) AIUsageLimitsResponse {
+	resp := AIUsageLimitsResponse{
+		MonthlyTranscribeSeconds: limit.MonthlyTranscribeSeconds,
+		MonthlyTranslateChars:    limit.MonthlyTranslateChars,
+		MonthlyTTSChars:          limit.MonthlyTTSChars,
+		UpdatedBy:                limit.UpdatedBy,
+	}
+	if !limit.UpdatedAt.IsZero() {
+		updatedAt := limit.UpdatedAt.Format("2006-01-02T15:04:05Z07:00")
+		resp.UpdatedAt = &updatedAt
+	}
+	return resp
+}
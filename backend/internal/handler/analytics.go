@@ -0,0 +1,348 @@
+package handler
+
+import (
+	"log"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"realtime-backend/internal/auth"
+	"realtime-backend/internal/errorreport"
+	"realtime-backend/internal/model"
+)
+
+const (
+	analyticsRollupInterval     = 15 * time.Minute
+	analyticsRollupLookbackDays = 2 // 늦게 끝난 회의/사용량 반영을 위해 어제까지 다시 집계
+	analyticsDefaultRangeDays   = 30
+	analyticsMaxRangeDays       = 366
+)
+
+// AnalyticsHandler 워크스페이스 대시보드 통계 (일별 롤업 + 조회)
+type AnalyticsHandler struct {
+	db   *gorm.DB
+	done chan struct{}
+}
+
+// NewAnalyticsHandler AnalyticsHandler 생성
+func NewAnalyticsHandler(db *gorm.DB) *AnalyticsHandler {
+	return &AnalyticsHandler{db: db}
+}
+
+// AnalyticsDayResponse 일별 통계
+type AnalyticsDayResponse struct {
+	Date              string  `json:"date"`
+	MessageCount      int64   `json:"message_count"`
+	MeetingCount      int64   `json:"meeting_count"`
+	MeetingMinutes    float64 `json:"meeting_minutes"`
+	ActiveMembers     int64   `json:"active_members"`
+	StorageAddedBytes int64   `json:"storage_added_bytes"`
+	StorageUsedBytes  *int64  `json:"storage_used_bytes,omitempty"`
+	TranscribeSeconds float64 `json:"transcribe_seconds"`
+	TranslateChars    int64   `json:"translate_chars"`
+	TTSChars          int64   `json:"tts_chars"`
+}
+
+// AnalyticsTotals 기간 합계 (활성 멤버는 합산할 수 없어 일 최대/평균으로 표시)
+type AnalyticsTotals struct {
+	MessageCount         int64   `json:"message_count"`
+	MeetingCount         int64   `json:"meeting_count"`
+	MeetingMinutes       float64 `json:"meeting_minutes"`
+	PeakActiveMembers    int64   `json:"peak_active_members"`
+	AverageActiveMembers float64 `json:"average_active_members"`
+	StorageAddedBytes    int64   `json:"storage_added_bytes"`
+	StorageUsedBytes     *int64  `json:"storage_used_bytes,omitempty"` // 기간 중 마지막으로 기록된 사용량
+	TranscribeSeconds    float64 `json:"transcribe_seconds"`
+	TranslateChars       int64   `json:"translate_chars"`
+	TTSChars             int64   `json:"tts_chars"`
+}
+
+// AnalyticsResponse 워크스페이스 통계 응답
+type AnalyticsResponse struct {
+	WorkspaceID int64                  `json:"workspace_id"`
+	From        string                 `json:"from"`
+	To          string                 `json:"to"`
+	Totals      AnalyticsTotals        `json:"totals"`
+	Daily       []AnalyticsDayResponse `json:"daily"`
+	RolledUpAt  *string                `json:"rolled_up_at,omitempty"` // 가장 최근 집계 시각
+}
+
+// GetWorkspaceAnalytics 워크스페이스 대시보드 통계 (소유자 전용)
+// ?from=YYYY-MM-DD&to=YYYY-MM-DD (기본값: 최근 30일, UTC)
+func (h *AnalyticsHandler) GetWorkspaceAnalytics(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	workspaceID, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid workspace id",
+		})
+	}
+
+	var workspace model.Workspace
+	if err := h.db.Select("id", "owner_id").First(&workspace, workspaceID).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "workspace not found",
+		})
+	}
+	if workspace.OwnerID != claims.UserID {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "only the workspace owner can view analytics",
+		})
+	}
+
+	to := time.Now().UTC().Truncate(24 * time.Hour)
+	from := to.AddDate(0, 0, -(analyticsDefaultRangeDays - 1))
+	if v := c.Query("from"); v != "" {
+		if from, err = time.Parse(aiUsageDateLayout, v); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "invalid from date (YYYY-MM-DD)",
+			})
+		}
+	}
+	if v := c.Query("to"); v != "" {
+		if to, err = time.Parse(aiUsageDateLayout, v); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "invalid to date (YYYY-MM-DD)",
+			})
+		}
+	}
+	if to.Before(from) || to.Sub(from) > analyticsMaxRangeDays*24*time.Hour {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid date range",
+		})
+	}
+
+	var stats []model.WorkspaceDailyStats
+	if err := h.db.Where("workspace_id = ? AND stat_date BETWEEN ? AND ?", workspaceID, from, to).
+		Order("stat_date ASC").
+		Find(&stats).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get analytics",
+		})
+	}
+
+	resp := AnalyticsResponse{
+		WorkspaceID: int64(workspaceID),
+		From:        from.Format(aiUsageDateLayout),
+		To:          to.Format(aiUsageDateLayout),
+		Daily:       make([]AnalyticsDayResponse, 0, len(stats)),
+	}
+	var activeSum int64
+	var rolledUpAt time.Time
+	for _, s := range stats {
+		resp.Daily = append(resp.Daily, AnalyticsDayResponse{
+			Date:              s.StatDate.Format(aiUsageDateLayout),
+			MessageCount:      s.MessageCount,
+			MeetingCount:      s.MeetingCount,
+			MeetingMinutes:    s.MeetingMinutes,
+			ActiveMembers:     s.ActiveMembers,
+			StorageAddedBytes: s.StorageAddedBytes,
+			StorageUsedBytes:  s.StorageUsedBytes,
+			TranscribeSeconds: s.TranscribeSeconds,
+			TranslateChars:    s.TranslateChars,
+			TTSChars:          s.TTSChars,
+		})
+
+		t := &resp.Totals
+		t.MessageCount += s.MessageCount
+		t.MeetingCount += s.MeetingCount
+		t.MeetingMinutes += s.MeetingMinutes
+		t.PeakActiveMembers = max(t.PeakActiveMembers, s.ActiveMembers)
+		t.StorageAddedBytes += s.StorageAddedBytes
+		if s.StorageUsedBytes != nil {
+			t.StorageUsedBytes = s.StorageUsedBytes
+		}
+		t.TranscribeSeconds += s.TranscribeSeconds
+		t.TranslateChars += s.TranslateChars
+		t.TTSChars += s.TTSChars
+		activeSum += s.ActiveMembers
+		if s.RolledUpAt.After(rolledUpAt) {
+			rolledUpAt = s.RolledUpAt
+		}
+	}
+	if len(stats) > 0 {
+		resp.Totals.AverageActiveMembers = float64(activeSum) / float64(len(stats))
+		formatted := rolledUpAt.Format("2006-01-02T15:04:05Z07:00")
+		resp.RolledUpAt = &formatted
+	}
+
+	return c.JSON(resp)
+}
+
+// StartRollups 일별 통계 롤업 시작 (시작 직후 한 번, 이후 주기적으로)
+func (h *AnalyticsHandler) StartRollups() {
+	if h.done == nil {
+		h.done = make(chan struct{})
+		go h.runRollups(h.done)
+	}
+}
+
+// Close 롤업 중단
+func (h *AnalyticsHandler) Close() {
+	if h.done != nil {
+		close(h.done)
+		h.done = nil
+	}
+}
+
+func (h *AnalyticsHandler) runRollups(done <-chan struct{}) {
+	defer errorreport.Recover(errorreport.Context{Component: "analytics.rollup"})
+
+	ticker := time.NewTicker(analyticsRollupInterval)
+	defer ticker.Stop()
+
+	for {
+		today := time.Now().UTC().Truncate(24 * time.Hour)
+		for i := analyticsRollupLookbackDays - 1; i >= 0; i-- {
+			if err := h.rollupDay(today.AddDate(0, 0, -i), i == 0); err != nil {
+				log.Printf("⚠️ 통계 롤업 실패 (%s): %v", today.AddDate(0, 0, -i).Format(aiUsageDateLayout), err)
+			}
+		}
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// rollupDay 하루치 통계를 워크스페이스별로 다시 계산해 저장
+// 저장 용량 사용량은 현재 값밖에 알 수 없으므로 오늘 롤업에서만 기록
+func (h *AnalyticsHandler) rollupDay(day time.Time, isToday bool) error {
+	start, end := day, day.AddDate(0, 0, 1)
+	now := time.Now()
+	stats := make(map[int64]*model.WorkspaceDailyStats)
+	statFor := func(workspaceID int64) *model.WorkspaceDailyStats {
+		s, ok := stats[workspaceID]
+		if !ok {
+			s = &model.WorkspaceDailyStats{WorkspaceID: workspaceID, StatDate: day, RolledUpAt: now}
+			stats[workspaceID] = s
+		}
+		return s
+	}
+
+	var messages []struct {
+		WorkspaceID int64
+		Count       int64
+	}
+	if err := h.db.Model(&model.ChatLog{}).
+		Select("meetings.workspace_id, COUNT(*) AS count").
+		Joins("JOIN meetings ON meetings.id = chat_logs.meeting_id").
+		Where("meetings.workspace_id IS NOT NULL AND chat_logs.type <> ?", "SYSTEM").
+		Where("chat_logs.created_at >= ? AND chat_logs.created_at < ?", start, end).
+		Group("meetings.workspace_id").
+		Scan(&messages).Error; err != nil {
+		return err
+	}
+	for _, m := range messages {
+		statFor(m.WorkspaceID).MessageCount = m.Count
+	}
+
+	var meetings []struct {
+		WorkspaceID int64
+		Count       int64
+		Minutes     float64
+	}
+	if err := h.db.Model(&model.Meeting{}).
+		Select("workspace_id, COUNT(*) AS count, COALESCE(SUM(EXTRACT(EPOCH FROM ended_at - started_at)), 0) / 60 AS minutes").
+		Where("workspace_id IS NOT NULL AND type NOT IN ?", []string{model.MeetingTypeChatRoom.String(), model.MeetingTypeDM.String()}).
+		Where("started_at IS NOT NULL AND ended_at >= ? AND ended_at < ?", start, end).
+		Group("workspace_id").
+		Scan(&meetings).Error; err != nil {
+		return err
+	}
+	for _, m := range meetings {
+		s := statFor(m.WorkspaceID)
+		s.MeetingCount = m.Count
+		s.MeetingMinutes = m.Minutes
+	}
+
+	var active []struct {
+		WorkspaceID int64
+		Count       int64
+	}
+	if err := h.db.Raw(`
+		SELECT workspace_id, COUNT(DISTINCT user_id) AS count FROM (
+			SELECT m.workspace_id, cl.sender_id AS user_id
+			FROM chat_logs cl JOIN meetings m ON m.id = cl.meeting_id
+			WHERE m.workspace_id IS NOT NULL AND cl.sender_id IS NOT NULL
+				AND cl.created_at >= ? AND cl.created_at < ?
+			UNION
+			SELECT m.workspace_id, p.user_id
+			FROM participants p JOIN meetings m ON m.id = p.meeting_id
+			WHERE m.workspace_id IS NOT NULL AND p.user_id IS NOT NULL
+				AND p.joined_at >= ? AND p.joined_at < ?
+		) activity
+		GROUP BY workspace_id`, start, end, start, end).
+		Scan(&active).Error; err != nil {
+		return err
+	}
+	for _, a := range active {
+		statFor(a.WorkspaceID).ActiveMembers = a.Count
+	}
+
+	// 같은 내용으로 연결한 파일도 업로드 한 건으로 셈 (휴지통으로 옮긴 파일 포함)
+	var storageAdded []struct {
+		WorkspaceID int64
+		Bytes       int64
+	}
+	if err := h.db.Unscoped().Model(&model.WorkspaceFile{}).
+		Select("workspace_id, COALESCE(SUM(file_size), 0) AS bytes").
+		Where("type = ? AND created_at >= ? AND created_at < ?", "FILE", start, end).
+		Group("workspace_id").
+		Scan(&storageAdded).Error; err != nil {
+		return err
+	}
+	for _, s := range storageAdded {
+		statFor(s.WorkspaceID).StorageAddedBytes = s.Bytes
+	}
+
+	var usage []struct {
+		WorkspaceID int64
+		AIUsageTotals
+	}
+	if err := h.db.Model(&model.AIUsageDaily{}).
+		Select("workspace_id, COALESCE(SUM(transcribe_seconds), 0) AS transcribe_seconds, "+
+			"COALESCE(SUM(translate_chars), 0) AS translate_chars, COALESCE(SUM(tts_chars), 0) AS tts_chars").
+		Where("workspace_id <> 0 AND usage_date = ?", day).
+		Group("workspace_id").
+		Scan(&usage).Error; err != nil {
+		return err
+	}
+	for _, u := range usage {
+		s := statFor(u.WorkspaceID)
+		s.TranscribeSeconds = u.TranscribeSeconds
+		s.TranslateChars = u.TranslateChars
+		s.TTSChars = u.TTSChars
+	}
+
+	updateColumns := []string{
+		"message_count", "meeting_count", "meeting_minutes", "active_members", "storage_added_bytes",
+		"transcribe_seconds", "translate_chars", "tts_chars", "rolled_up_at",
+	}
+	if isToday {
+		var quotas []model.WorkspaceStorageQuota
+		if err := h.db.Select("workspace_id", "used_bytes").Find(&quotas).Error; err != nil {
+			return err
+		}
+		for _, q := range quotas {
+			used := q.UsedBytes
+			statFor(q.WorkspaceID).StorageUsedBytes = &used
+		}
+		updateColumns = append(updateColumns, "storage_used_bytes")
+	}
+
+	if len(stats) == 0 {
+		return nil
+	}
+	rows := make([]model.WorkspaceDailyStats, 0, len(stats))
+	for _, s := range stats {
+		rows = append(rows, *s)
+	}
+	return h.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "workspace_id"}, {Name: "stat_date"}},
+		DoUpdates: clause.AssignmentColumns(updateColumns),
+	}).CreateInBatches(rows, 500).Error
+}
@@ -4,16 +4,19 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/gofiber/contrib/websocket"
+	"github.com/gofiber/fiber/v2"
 	"gorm.io/gorm"
 
 	"realtime-backend/internal/ai"
 	"realtime-backend/internal/cache"
 	"realtime-backend/internal/auth"
 	"realtime-backend/internal/config"
+	"realtime-backend/internal/errorreport"
 	"realtime-backend/internal/model"
 	"realtime-backend/internal/session"
 )
@@ -23,6 +26,7 @@ type AudioHandler struct {
     cfg         *config.Config
     db          *gorm.DB
     aiClient    *ai.GrpcClient
+    fallbackAI  *ai.GrpcClient // AWS 모드에서 Room 예비 백엔드로만 사용
     roomHub     *RoomHub
     redisClient *cache.RedisClient
 }
@@ -47,7 +51,20 @@ func NewAudioHandler(cfg *config.Config, db *gorm.DB) *AudioHandler {
 		if cfg.AI.UseAWS {
 			// AWS 직접 사용 모드
 			log.Println("☁️ AWS AI services mode enabled (Transcribe/Translate/Polly)")
-			handler.roomHub = NewRoomHub(nil, cfg, true, handler.redisClient)
+
+			// 전환이 켜져 있으면 Python gRPC 서버를 예비 백엔드로 연결
+			var fallbackClient *ai.GrpcClient
+			if cfg.AI.FallbackEnabled {
+				client, err := ai.NewGrpcClient(cfg.AI.ServerAddr)
+				if err != nil {
+					log.Printf("⚠️ Failed to connect to fallback AI server: %v (gRPC fallback disabled)", err)
+				} else {
+					handler.fallbackAI = client
+					fallbackClient = client
+					log.Printf("🤖 Fallback AI server at %s", cfg.AI.ServerAddr)
+				}
+			}
+			handler.roomHub = NewRoomHub(fallbackClient, cfg, true, handler.redisClient)
 		} else {
 			// Python gRPC 서버 모드
 			client, err := ai.NewGrpcClient(cfg.AI.ServerAddr)
@@ -72,11 +89,19 @@ func NewAudioHandler(cfg *config.Config, db *gorm.DB) *AudioHandler {
 
 // Close 핸들러 리소스 정리
 func (h *AudioHandler) Close() error {
+	if h.roomHub != nil {
+		h.roomHub.Close()
+	}
 	if h.aiClient != nil {
 		if err := h.aiClient.Close(); err != nil {
 			log.Printf("⚠️ Error closing AI client: %v", err)
 		}
 	}
+	if h.fallbackAI != nil {
+		if err := h.fallbackAI.Close(); err != nil {
+			log.Printf("⚠️ Error closing fallback AI client: %v", err)
+		}
+	}
 	if h.redisClient != nil {
 		if err := h.redisClient.Close(); err != nil {
 			log.Printf("⚠️ Error closing Redis client: %v", err)
@@ -90,6 +115,11 @@ func (h *AudioHandler) GetRoomHub() *RoomHub {
 	return h.roomHub
 }
 
+// GetRedisClient returns the shared Redis client (nil when Redis is disabled)
+func (h *AudioHandler) GetRedisClient() *cache.RedisClient {
+	return h.redisClient
+}
+
 // RoomTranscriptResponse is the response for room transcripts
 type RoomTranscriptResponse struct {
 	RoomID      string    `json:"roomId"`
@@ -108,7 +138,7 @@ func (h *AudioHandler) HandleWebSocket(c *websocket.Conn) {
 	// 패닉 복구 - 서버 크래시 방지
 	defer func() {
 		if r := recover(); r != nil {
-			log.Printf("오디오 WebSocket 패닉 복구: %v", r)
+			errorreport.CapturePanic(r, wsReportContext(c, "ws.audio"))
 		}
 	}()
 
@@ -147,11 +177,13 @@ func (h *AudioHandler) HandleWebSocket(c *websocket.Conn) {
 			if err != nil {
 				log.Printf("❌ [%s] Permission check failed: %v", sess.ID, err)
 				h.sendErrorResponse(c, sess.ID, "PERMISSION_ERROR", "Internal server error")
+				closeWS(c, WSCloseInternalError, "permission check failed")
 				return
 			}
 			if !hasPermission {
 				log.Printf("❌ [%s] Permission denied: CONNECT_MEDIA", sess.ID)
 				h.sendErrorResponse(c, sess.ID, "PERMISSION_DENIED", "You do not have permission to connect to media")
+				closeWS(c, WSCloseForbidden, "permission denied")
 				return
 			}
 		}
@@ -187,6 +219,7 @@ func (h *AudioHandler) HandleWebSocket(c *websocket.Conn) {
 	if err := h.performHandshake(c, sess); err != nil {
 		log.Printf("❌ [%s] Handshake failed: %v", sess.ID, err)
 		h.sendErrorResponse(c, sess.ID, "HANDSHAKE_FAILED", err.Error())
+		closeWS(c, WSCloseInvalidRequest, "handshake failed")
 		return
 	}
 
@@ -359,6 +392,7 @@ func (h *AudioHandler) receiveLoop(c *websocket.Conn, sess *session.Session) {
 		select {
 		case sess.AudioPackets <- packet:
 		default:
+			roomDroppedTotal.Inc("session_audio_in")
 			log.Printf("⚠️ [%s] Audio buffer full, dropping packet #%d", sess.ID, seqNum)
 		}
 	}
@@ -458,6 +492,7 @@ func (h *AudioHandler) aiUnifiedWorker(sess *session.Session) {
 				select {
 				case chatStream.SendChan <- audioChunk:
 				default:
+					roomDroppedTotal.Inc("session_grpc_send")
 					log.Printf("⚠️ [%s] gRPC send buffer full, dropping packet #%d", sess.ID, packet.SeqNum)
 				}
 			}
@@ -513,6 +548,7 @@ func (h *AudioHandler) aiUnifiedWorker(sess *session.Session) {
 					log.Printf("📝 [%s] Transcript sent: %s", sess.ID, transcript.OriginalText)
 				}
 			default:
+				roomDroppedTotal.Inc("session_transcript")
 				log.Printf("⚠️ [%s] Transcript buffer full, dropping message", sess.ID)
 			}
 
@@ -531,6 +567,7 @@ func (h *AudioHandler) aiUnifiedWorker(sess *session.Session) {
 			case sess.EchoPackets <- audioMsg.AudioData:
 				log.Printf("🔊 [%s] TTS audio sent to WebSocket", sess.ID)
 			default:
+				roomDroppedTotal.Inc("session_echo")
 				log.Printf("⚠️ [%s] Echo buffer full, dropping AI audio response", sess.ID)
 			}
 
@@ -652,6 +689,7 @@ func (h *AudioHandler) processingWorkerEcho(sess *session.Session) {
 			select {
 			case sess.EchoPackets <- packet.Data:
 			default:
+				roomDroppedTotal.Inc("session_echo")
 				log.Printf("⚠️ [%s] Echo buffer full, dropping packet #%d", sess.ID, packet.SeqNum)
 			}
 		}
@@ -707,7 +745,7 @@ func (h *AudioHandler) sendErrorResponse(c *websocket.Conn, sessionID, code, mes
 func (h *AudioHandler) HandleRoomWebSocket(c *websocket.Conn) {
 	defer func() {
 		if r := recover(); r != nil {
-			log.Printf("Room WebSocket 패닉 복구: %v", r)
+			errorreport.CapturePanic(r, wsReportContext(c, "ws.room"))
 		}
 	}()
 
@@ -719,6 +757,7 @@ func (h *AudioHandler) HandleRoomWebSocket(c *websocket.Conn) {
 	if roomID == "" || listenerID == "" {
 		log.Printf("❌ Room WebSocket: missing roomId or listenerId")
 		h.sendRoomError(c, "INVALID_PARAMS", "roomId and listenerId are required")
+		closeWS(c, WSCloseInvalidRequest, "roomId and listenerId are required")
 		return
 	}
 
@@ -727,29 +766,81 @@ func (h *AudioHandler) HandleRoomWebSocket(c *websocket.Conn) {
 	}
 
 	log.Printf("🏠 [Room %s] New listener connected: %s (target: %s)", roomID, listenerID, targetLang)
+	if identity, ok := c.Locals("roomIdentity").(*roomIdentity); ok {
+		log.Printf("🔐 [Room %s] Listener %s verified as user %d (%s)", roomID, listenerID, identity.UserID, identity.Nickname)
+	}
 
 	// Room 가져오기 또는 생성
 	room := h.roomHub.GetOrCreateRoom(roomID)
 
-	// 리스너 등록
-	room.AddListener(listenerID, targetLang, c)
+	// 최대 인원 확인 (재접속한 리스너는 허용)
+	if max := h.cfg.WebSocket.MaxRoomListeners; max > 0 && !room.HasListenerCapacity(listenerID, max) {
+		log.Printf("🚫 [Room %s] Room full, rejecting listener %s", roomID, listenerID)
+		h.sendRoomError(c, "ROOM_FULL", "room is full")
+		closeWS(c, WSCloseRoomFull, "room is full")
+		return
+	}
+
+	// 워크스페이스 허용 번역 언어 확인 (지정하지 않았으면 기본 언어로 대체)
+	policy := room.languagePolicy()
+	if !policy.allows(targetLang) {
+		if explicit, _ := c.Locals("targetLangExplicit").(bool); explicit {
+			log.Printf("🚫 [Room %s] Target language %s not allowed for listener %s", roomID, targetLang, listenerID)
+			h.sendTargetLangRejected(c, targetLang, policy)
+			closeWS(c, WSCloseForbidden, "target language not allowed")
+			return
+		}
+		targetLang = policy.defaultTargets()[0]
+	}
 
-	// Ready 응답 전송
-	readyResponse := fmt.Sprintf(`{"status":"ready","roomId":"%s","listenerId":"%s","targetLang":"%s"}`,
-		roomID, listenerID, targetLang)
+	// 리스너 등록 (audioStream=1 / protocol=2면 TTS를 청크 단위 프레임으로 수신)
+	protocol := listenerProtocolRaw
+	if v, _ := c.Locals("listenerProtocol").(string); v == "2" {
+		protocol = listenerProtocolFramed
+	} else if streamAudio, _ := c.Locals("audioStream").(bool); streamAudio {
+		protocol = listenerProtocolTTS
+	}
+
+	// 재접속 세션 (같은 인스턴스에서 resumeToken으로 재접속하면 기존 Transcribe 스트림 이어서 사용)
+	resumeToken, _ := c.Locals("resumeToken").(string)
+	session, resumed := room.openResumeSession(resumeToken, listenerID)
+
+	// Ready 응답 전송 (resumeToken은 재접속 시 ?resumeToken=으로 전달)
+	// 등록 이후에는 리스너 전송 큐만 연결에 쓰므로 등록 전에 직접 전송
+	readyResponse := fmt.Sprintf(`{"status":"ready","roomId":"%s","listenerId":"%s","targetLang":"%s","resumeToken":"%s","resumed":%t}`,
+		roomID, listenerID, targetLang, session.token, resumed)
 	if err := c.WriteMessage(websocket.TextMessage, []byte(readyResponse)); err != nil {
 		log.Printf("❌ [Room %s] Failed to send ready response: %v", roomID, err)
-		room.RemoveListener(listenerID)
+		room.suspendResumeSession(session, h.cfg.WebSocket.RoomResumeWindow)
 		return
 	}
+	listener := room.AddListener(listenerID, targetLang, protocol, c)
+	if resumed {
+		room.replayResumedAudio(session)
+	}
+	identity, _ := c.Locals("roomIdentity").(*roomIdentity)
+	h.roomHub.markInCall(roomID, identity)
 
-	// 연결 종료 시 정리
+	// 연결 종료 시 정리 (발화자 스트림은 재접속 대기 시간 동안 유지)
 	defer func() {
-		room.RemoveListener(listenerID)
+		h.roomHub.clearInCall(roomID, identity)
+		room.RemoveListener(listener)
+		room.suspendResumeSession(session, h.cfg.WebSocket.RoomResumeWindow)
 		log.Printf("🔌 [Room %s] Listener disconnected: %s", roomID, listenerID)
 		c.Close()
 	}()
 
+	// 검증에 실패한 발화자 ID (연결당 한 번만 에러 전송)
+	rejectedSpeakers := make(map[string]bool)
+	acceptSpeaker := func(speakerID string) (*speakerIdentity, bool) {
+		identity, ok := room.verifySpeaker(speakerID)
+		if !ok && !rejectedSpeakers[speakerID] {
+			rejectedSpeakers[speakerID] = true
+			listener.sendText(roomErrorMessage("SPEAKER_REJECTED", "speaker is not a member of this room"))
+		}
+		return identity, ok
+	}
+
 	// 오디오 수신 루프 (리스너가 캡처한 원격 참가자 오디오)
 	for {
 		messageType, msg, err := c.ReadMessage()
@@ -772,32 +863,62 @@ func (h *AudioHandler) HandleRoomWebSocket(c *websocket.Conn) {
 			// Debug log disabled to reduce noise
 			// log.Printf("🎵 [Room %s] Received audio: %d bytes from listener %s", roomID, len(msg), listenerID)
 
-			speakerID := string(msg[:36])
+			speakerID := strings.TrimSpace(string(msg[:36]))
 			sourceLang := string(msg[36:38])
 			audioData := msg[38:]
 
-			// Speaker 정보 업데이트 (있으면)
-			room.AddOrUpdateSpeaker(speakerID, sourceLang, "", "")
+			// 한 연결로 Room 전체 믹스를 보내는 경우 (화자 분리는 파이프라인에서 처리)
+			if speakerID == mixedSpeakerID {
+				room.AddOrUpdateSpeaker(speakerID, sourceLang, "", "")
+				room.noteSpeakerAudio(session, speakerID, sourceLang, audioData)
+				room.SendAudio(speakerID, sourceLang, audioData)
+				continue
+			}
+
+			// 발화자 ID 검증 (워크스페이스 멤버가 아니면 오디오 폐기)
+			identity, ok := acceptSpeaker(speakerID)
+			if !ok {
+				continue
+			}
+
+			// Speaker 정보 업데이트 (검증된 경우 DB의 닉네임/프로필 사용)
+			if identity != nil {
+				room.AddOrUpdateSpeaker(speakerID, sourceLang, identity.nickname, identity.profileImg)
+			} else {
+				room.AddOrUpdateSpeaker(speakerID, sourceLang, "", "")
+			}
 
 			// Room에 오디오 전송
+			room.noteSpeakerAudio(session, speakerID, sourceLang, audioData)
 			room.SendAudio(speakerID, sourceLang, audioData)
 		}
 
 		// 텍스트 메시지 = 제어 메시지
 		if messageType == websocket.TextMessage {
 			var controlMsg struct {
-				Type       string `json:"type"`
-				SpeakerID  string `json:"speakerId"`
-				SourceLang string `json:"sourceLang"`
-				TargetLang string `json:"targetLang"`
-				Nickname   string `json:"nickname"`
-				ProfileImg string `json:"profileImg"`
+				Type       string   `json:"type"`
+				SpeakerID  string   `json:"speakerId"`
+				SourceLang string   `json:"sourceLang"`
+				TargetLang string   `json:"targetLang"`
+				Nickname   string   `json:"nickname"`
+				ProfileImg string   `json:"profileImg"`
+				SpeakerIDs []string `json:"speakerIds"` // active_speakers (LiveKit 활성 화자, 큰 소리 순)
 			}
 			if err := json.Unmarshal(msg, &controlMsg); err == nil {
 				switch controlMsg.Type {
 				case "speaker_info":
+					speakerID := strings.TrimSpace(controlMsg.SpeakerID)
+					identity, ok := acceptSpeaker(speakerID)
+					if !ok {
+						continue
+					}
+					// 검증된 발화자는 클라이언트가 보낸 값 대신 DB의 닉네임/프로필 사용
+					if identity != nil {
+						controlMsg.Nickname = identity.nickname
+						controlMsg.ProfileImg = identity.profileImg
+					}
 					room.AddOrUpdateSpeaker(
-						controlMsg.SpeakerID,
+						speakerID,
 						controlMsg.SourceLang,
 						controlMsg.Nickname,
 						controlMsg.ProfileImg,
@@ -805,14 +926,30 @@ func (h *AudioHandler) HandleRoomWebSocket(c *websocket.Conn) {
 					log.Printf("📢 [Room %s] Speaker info updated: %s (%s)",
 						roomID, controlMsg.Nickname, controlMsg.SourceLang)
 
+				case "active_speakers":
+					// LiveKit 활성 화자 힌트 (믹스 피드의 화자 라벨을 참가자에 매핑하는 데 사용)
+					speakers := make([]string, 0, len(controlMsg.SpeakerIDs))
+					for _, id := range controlMsg.SpeakerIDs {
+						id = strings.TrimSpace(id)
+						if _, ok := acceptSpeaker(id); ok {
+							speakers = append(speakers, id)
+						}
+					}
+					room.recordActiveSpeakers(speakers)
+
 				case "speaker_leave":
 					// 스피커가 방을 나갔을 때 Transcribe 스트림 종료
 					room.RemoveSpeaker(controlMsg.SpeakerID)
+					room.forgetSpeakerResume(session, controlMsg.SpeakerID)
 					log.Printf("👋 [Room %s] Speaker left: %s", roomID, controlMsg.SpeakerID)
 
 				case "update_target_language":
 					// 리스너의 타겟 언어 업데이트
 					if controlMsg.TargetLang != "" {
+						if policy := room.languagePolicy(); !policy.allows(controlMsg.TargetLang) {
+							listener.sendText(targetLangRejectedMessage(controlMsg.TargetLang, policy))
+							continue
+						}
 						room.UpdateListenerTargetLang(listenerID, controlMsg.TargetLang)
 						log.Printf("🌐 [Room %s] Listener %s updated target language to: %s",
 							roomID, listenerID, controlMsg.TargetLang)
@@ -823,8 +960,29 @@ func (h *AudioHandler) HandleRoomWebSocket(c *websocket.Conn) {
 	}
 }
 
+// sendTargetLangRejected 허용되지 않은 번역 언어 에러 전송 (선택 가능한 언어 목록 포함)
+func (h *AudioHandler) sendTargetLangRejected(c *websocket.Conn, targetLang string, policy workspaceLanguagePolicy) {
+	_ = c.WriteMessage(websocket.TextMessage, targetLangRejectedMessage(targetLang, policy))
+}
+
+// targetLangRejectedMessage 허용되지 않은 번역 언어 에러 메시지
+func targetLangRejectedMessage(targetLang string, policy workspaceLanguagePolicy) []byte {
+	response, _ := json.Marshal(fiber.Map{
+		"status":             "error",
+		"code":               "TARGET_LANGUAGE_NOT_ALLOWED",
+		"message":            "target language " + targetLang + " is not allowed in this workspace",
+		"allowedTargetLangs": policy.allowed,
+		"defaultTargetLangs": policy.defaultTargets(),
+	})
+	return response
+}
+
 // sendRoomError Room WebSocket 에러 응답 전송
 func (h *AudioHandler) sendRoomError(c *websocket.Conn, code, message string) {
-	response := fmt.Sprintf(`{"status":"error","code":"%s","message":"%s"}`, code, message)
-	_ = c.WriteMessage(websocket.TextMessage, []byte(response))
+	_ = c.WriteMessage(websocket.TextMessage, roomErrorMessage(code, message))
+}
+
+// roomErrorMessage Room WebSocket 에러 메시지 (리스너 등록 후에는 전송 큐로 보냄)
+func roomErrorMessage(code, message string) []byte {
+	return []byte(fmt.Sprintf(`{"status":"error","code":"%s","message":"%s"}`, code, message))
 }
@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+
+	"realtime-backend/internal/auth"
+	"realtime-backend/internal/model"
+)
+
+const (
+	auditEventDefaultLimit = 50
+	auditEventMaxLimit     = 200
+)
+
+// AuditEventResponse 감사 기록 응답
+type AuditEventResponse struct {
+	ID         int64           `json:"id"`
+	ActorID    *int64          `json:"actor_id,omitempty"`
+	Actor      *UserResponse   `json:"actor,omitempty"`
+	Action     string          `json:"action"`
+	TargetType string          `json:"target_type"`
+	TargetID   *int64          `json:"target_id,omitempty"`
+	Metadata   json.RawMessage `json:"metadata"`
+	CreatedAt  string          `json:"created_at"`
+}
+
+// GetAuditEvents 워크스페이스 감사 기록 (소유자 전용, 최신순)
+// action, actor_id, target_type, target_id, permission, from, to로 필터
+// permission은 그 권한이 새로 부여된 역할 변경/멤버 역할 변경만 (예: 누가 MANAGE_ROLES를 줬는지)
+func (h *WorkspaceHandler) GetAuditEvents(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	workspaceID, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid workspace id",
+		})
+	}
+
+	var workspace model.Workspace
+	if err := h.db.Select("id", "owner_id").First(&workspace, workspaceID).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "workspace not found",
+		})
+	}
+	if workspace.OwnerID != claims.UserID {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "only the workspace owner can view the audit log",
+		})
+	}
+
+	limit := c.QueryInt("limit", auditEventDefaultLimit)
+	if limit <= 0 || limit > auditEventMaxLimit {
+		limit = auditEventDefaultLimit
+	}
+	offset := c.QueryInt("offset", 0)
+	if offset < 0 {
+		offset = 0
+	}
+
+	query := h.db.Model(&model.AuditEvent{}).Where("workspace_id = ?", workspaceID)
+	if action := c.Query("action"); action != "" {
+		query = query.Where("action = ?", action)
+	}
+	if actorID := c.QueryInt("actor_id", 0); actorID > 0 {
+		query = query.Where("actor_id = ?", actorID)
+	}
+	if targetType := c.Query("target_type"); targetType != "" {
+		query = query.Where("target_type = ?", targetType)
+	}
+	if targetID := c.QueryInt("target_id", 0); targetID > 0 {
+		query = query.Where("target_id = ?", targetID)
+	}
+	if permission := c.Query("permission"); permission != "" {
+		filter, _ := json.Marshal(map[string][]string{"permissions_added": {permission}})
+		query = query.Where("metadata @> ?::jsonb", string(filter))
+	}
+	from, err := parseSearchDate(c.Query("from"), false)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "from must be a date (YYYY-MM-DD) or RFC3339 time",
+		})
+	}
+	to, err := parseSearchDate(c.Query("to"), true)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "to must be a date (YYYY-MM-DD) or RFC3339 time",
+		})
+	}
+	if from != nil {
+		query = query.Where("created_at >= ?", *from)
+	}
+	if to != nil {
+		query = query.Where("created_at < ?", *to)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to count audit events",
+		})
+	}
+
+	var events []model.AuditEvent
+	err = query.Preload("Actor").
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&events).Error
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get audit events",
+		})
+	}
+
+	responses := make([]AuditEventResponse, len(events))
+	for i := range events {
+		responses[i] = toAuditEventResponse(&events[i])
+	}
+
+	return c.JSON(fiber.Map{
+		"events":   responses,
+		"total":    total,
+		"has_more": int64(offset+len(events)) < total,
+	})
+}
+
+// recordAuditEvent 관리 작업 기록 (기록 실패는 로그만 남기고 원래 작업은 그대로 성공)
+// targetID가 0이면 대상 ID 없이 기록
+func recordAuditEvent(db *gorm.DB, workspaceID int64, actorID *int64, action model.AuditAction, targetType string, targetID int64, metadata map[string]interface{}) {
+	event := model.AuditEvent{
+		WorkspaceID: workspaceID,
+		ActorID:     actorID,
+		Action:      action.String(),
+		TargetType:  targetType,
+		Metadata:    "{}",
+	}
+	if targetID != 0 {
+		event.TargetID = &targetID
+	}
+	if len(metadata) > 0 {
+		if data, err := json.Marshal(metadata); err == nil {
+			event.Metadata = string(data)
+		}
+	}
+	if err := db.Create(&event).Error; err != nil {
+		log.Printf("⚠️ 감사 기록 실패: workspace=%d, action=%s, err=%v", workspaceID, action, err)
+	}
+}
+
+func toAuditEventResponse(event *model.AuditEvent) AuditEventResponse {
+	resp := AuditEventResponse{
+		ID:         event.ID,
+		ActorID:    event.ActorID,
+		Action:     event.Action,
+		TargetType: event.TargetType,
+		TargetID:   event.TargetID,
+		Metadata:   json.RawMessage(event.Metadata),
+		CreatedAt:  event.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	if event.Actor != nil && event.Actor.ID != 0 {
+		resp.Actor = &UserResponse{
+			ID:         event.Actor.ID,
+			Email:      event.Actor.Email,
+			Nickname:   event.Actor.Nickname,
+			ProfileImg: event.Actor.ProfileImg,
+		}
+	}
+	return resp
+}
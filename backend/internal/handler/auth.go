@@ -81,6 +81,12 @@ type UserResponse struct {
 	Provider   *string `json:"provider,omitempty"`
 }
 
+// MeResponse 내 정보 응답 (관리자 대리 접속 중이면 배너 표시용 정보 포함)
+type MeResponse struct {
+	UserResponse
+	Impersonation *auth.ImpersonationClaim `json:"impersonation,omitempty"`
+}
+
 // GoogleLogin Google OAuth 로그인
 func (h *AuthHandler) GoogleLogin(c *fiber.Ctx) error {
 	var req GoogleLoginRequest
@@ -157,6 +163,13 @@ func (h *AuthHandler) GoogleLogin(c *fiber.Ctx) error {
 			updates := map[string]interface{}{
 				"profile_img": googleUser.Picture,
 			}
+			// 이메일 인증을 마치지 않은 계정은 Google이 주소 소유를 확인해 준 지금의 사용자 것으로 봄
+			// 남의 이메일로 먼저 가입해 둔 사람이 로그인하지 못하도록 그 비밀번호와 세션은 폐기 (비밀번호 재설정으로 다시 설정)
+			unverified := user.EmailVerifiedAt == nil
+			if unverified {
+				updates["email_verified_at"] = time.Now()
+				updates["password_hash"] = nil
+			}
 			// Provider가 없거나 다르면 업데이트
 			if user.Provider == nil || *user.Provider != "google" {
 				updates["provider"] = provider
@@ -165,6 +178,11 @@ func (h *AuthHandler) GoogleLogin(c *fiber.Ctx) error {
 			if err := tx.Model(&user).Updates(updates).Error; err != nil {
 				return err
 			}
+			if unverified {
+				if err := revokeSessions(tx, model.SessionRevokedAccountLinked, "user_id = ?", user.ID); err != nil {
+					return err
+				}
+			}
 		}
 		return nil
 	})
@@ -175,56 +193,131 @@ func (h *AuthHandler) GoogleLogin(c *fiber.Ctx) error {
 		})
 	}
 
-	// JWT 토큰 생성
-	accessToken, err := h.jwtManager.GenerateAccessToken(user.ID, user.Email, user.Nickname)
+	return h.issueSession(c, &user)
+}
+
+// issueSession 첫 번째 인증을 통과한 사용자 로그인 (Google, 이메일 로그인 공용)
+// 2단계 인증을 켠 사용자는 세션 대신 대기 토큰을 받고 /auth/2fa/verify 로 로그인을 마침
+func (h *AuthHandler) issueSession(c *fiber.Ctx, user *model.User) error {
+	if user.SuspendedAt != nil {
+		return rejectSuspended(c)
+	}
+	enabled, err := twoFactorEnabled(h.db, user.ID)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "failed to generate token",
+			"error": "failed to check two-factor authentication",
 		})
 	}
+	if enabled {
+		return h.requireSecondFactor(c, user)
+	}
+	return h.startSession(c, user)
+}
 
-	refreshToken, err := h.jwtManager.GenerateRefreshToken(user.ID)
+// rejectSuspended 정지된 계정 로그인 거부 응답
+func rejectSuspended(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+		"error": "account suspended",
+		"code":  "ACCOUNT_SUSPENDED",
+	})
+}
+
+// startSession 로그인 세션을 만들고 액세스/리프레시 토큰을 쿠키로 설정한 뒤 로그인 응답 반환
+func (h *AuthHandler) startSession(c *fiber.Ctx, user *model.User) error {
+	session, err := h.createSession(c, user.ID)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "failed to generate refresh token",
+			"error": "failed to create session",
 		})
 	}
+	if err := h.setSessionCookies(c, user, session.ID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to generate token",
+		})
+	}
+
+	return c.JSON(AuthResponse{
+		User: UserResponse{
+			ID:         user.ID,
+			Email:      user.Email,
+			Nickname:   user.Nickname,
+			ProfileImg: user.ProfileImg,
+			Provider:   user.Provider,
+		},
+		ExpiresIn: 900, // 15분
+	})
+}
 
-	// HTTP-Only 쿠키로 액세스 토큰 설정 (XSS 방지)
+// setSessionCookies 세션의 새 액세스/리프레시 토큰을 쿠키로 설정하고 리프레시 토큰 해시를 세션에 저장
+func (h *AuthHandler) setSessionCookies(c *fiber.Ctx, user *model.User, sessionID int64) error {
+	accessToken, refreshToken, err := h.sessionTokens(user, sessionID)
+	if err != nil {
+		return err
+	}
+	if err := h.db.Model(&model.AuthSession{}).Where("id = ?", sessionID).
+		Update("refresh_token_hash", auth.HashOpaqueToken(refreshToken)).Error; err != nil {
+		return err
+	}
+	h.setAccessCookie(c, accessToken)
+	h.setRefreshCookie(c, refreshToken)
+	return nil
+}
+
+// sessionTokens 세션의 새 액세스/리프레시 토큰 생성
+func (h *AuthHandler) sessionTokens(user *model.User, sessionID int64) (string, string, error) {
+	accessToken, err := h.jwtManager.GenerateAccessToken(user.ID, user.Email, user.Nickname, sessionID)
+	if err != nil {
+		return "", "", err
+	}
+	refreshToken, err := h.jwtManager.GenerateRefreshToken(user.ID, sessionID)
+	if err != nil {
+		return "", "", err
+	}
+	return accessToken, refreshToken, nil
+}
+
+// setRefreshCookie HTTP-Only 쿠키로 리프레시 토큰 설정
+func (h *AuthHandler) setRefreshCookie(c *fiber.Ctx, refreshToken string) {
 	c.Cookie(&fiber.Cookie{
-		Name:     "access_token",
-		Value:    accessToken,
+		Name:     "refresh_token",
+		Value:    refreshToken,
 		Path:     "/",
-		MaxAge:   15 * 60, // 15분 (보안 강화)
+		MaxAge:   7 * 24 * 60 * 60, // 7일
 		Secure:   h.secureCookie,
 		HTTPOnly: true,
 		SameSite: "Lax",
 	})
+}
 
-	// HTTP-Only 쿠키로 리프레시 토큰 설정
+// setAccessCookie HTTP-Only 쿠키로 액세스 토큰 설정 (XSS 방지)
+func (h *AuthHandler) setAccessCookie(c *fiber.Ctx, accessToken string) {
 	c.Cookie(&fiber.Cookie{
-		Name:     "refresh_token",
-		Value:    refreshToken,
+		Name:     "access_token",
+		Value:    accessToken,
 		Path:     "/",
-		MaxAge:   7 * 24 * 60 * 60, // 7일
+		MaxAge:   15 * 60, // 15분 (보안 강화)
 		Secure:   h.secureCookie,
 		HTTPOnly: true,
 		SameSite: "Lax",
 	})
+}
 
-	return c.JSON(AuthResponse{
-		User: UserResponse{
-			ID:         user.ID,
-			Email:      user.Email,
-			Nickname:   user.Nickname,
-			ProfileImg: user.ProfileImg,
-			Provider:   user.Provider,
-		},
-		ExpiresIn: 900, // 15분
-	})
+// clearSessionCookies 액세스/리프레시 토큰 쿠키 삭제
+func (h *AuthHandler) clearSessionCookies(c *fiber.Ctx) {
+	for _, name := range []string{"access_token", "refresh_token"} {
+		c.Cookie(&fiber.Cookie{
+			Name:     name,
+			Value:    "",
+			Path:     "/",
+			MaxAge:   -1,
+			Secure:   h.secureCookie,
+			HTTPOnly: true,
+		})
+	}
 }
 
-// RefreshToken 토큰 갱신
+// RefreshToken 토큰 갱신 (리프레시 토큰도 새로 교체)
+// 이미 교체된 토큰이 다시 오면 탈취된 것으로 보고 세션 전체를 취소
 func (h *AuthHandler) RefreshToken(c *fiber.Ctx) error {
 	refreshToken := c.Cookies("refresh_token")
 	if refreshToken == "" {
@@ -234,17 +327,12 @@ func (h *AuthHandler) RefreshToken(c *fiber.Ctx) error {
 	}
 
 	// 리프레시 토큰 검증
-	userID, err := h.jwtManager.ValidateRefreshToken(refreshToken)
+	userID, sessionID, legacy, err := h.jwtManager.ValidateRefreshToken(refreshToken)
+	if err == nil && legacy && !h.isCurrentRefreshToken(userID, sessionID, refreshToken) {
+		err = auth.ErrInvalidToken
+	}
 	if err != nil {
-		// 쿠키 삭제
-		c.Cookie(&fiber.Cookie{
-			Name:     "refresh_token",
-			Value:    "",
-			Path:     "/",
-			MaxAge:   -1,
-			Secure:   h.secureCookie,
-			HTTPOnly: true,
-		})
+		h.clearSessionCookies(c)
 		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
 			"error": "invalid or expired refresh token",
 		})
@@ -257,52 +345,41 @@ func (h *AuthHandler) RefreshToken(c *fiber.Ctx) error {
 			"error": "user not found",
 		})
 	}
+	if user.SuspendedAt != nil {
+		h.clearSessionCookies(c)
+		return rejectSuspended(c)
+	}
 
-	// 새 액세스 토큰 발급
-	accessToken, err := h.jwtManager.GenerateAccessToken(user.ID, user.Email, user.Nickname)
+	revoked, err := h.rotateSession(c, &user, sessionID, refreshToken)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "failed to generate token",
+			"error": "failed to refresh session",
+		})
+	}
+	if revoked {
+		h.clearSessionCookies(c)
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "session has been revoked",
+			"code":  "SESSION_REVOKED",
 		})
 	}
-
-	// HTTP-Only 쿠키로 액세스 토큰 설정
-	c.Cookie(&fiber.Cookie{
-		Name:     "access_token",
-		Value:    accessToken,
-		Path:     "/",
-		MaxAge:   15 * 60, // 15분
-		Secure:   h.secureCookie,
-		HTTPOnly: true,
-		SameSite: "Lax",
-	})
 
 	return c.JSON(fiber.Map{
 		"expires_in": 900,
 	})
 }
 
-// Logout 로그아웃
+// Logout 로그아웃 (현재 세션 취소)
 func (h *AuthHandler) Logout(c *fiber.Ctx) error {
-	// 액세스 토큰 쿠키 삭제
-	c.Cookie(&fiber.Cookie{
-		Name:     "access_token",
-		Value:    "",
-		Path:     "/",
-		MaxAge:   -1,
-		Secure:   h.secureCookie,
-		HTTPOnly: true,
-	})
+	if claims, err := auth.GetClaimsFromContext(c); err == nil && claims.SessionID != 0 {
+		if err := revokeSessions(h.db, model.SessionRevokedLogout, "id = ? AND user_id = ?", claims.SessionID, claims.UserID); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "failed to revoke session",
+			})
+		}
+	}
 
-	// 리프레시 토큰 쿠키 삭제
-	c.Cookie(&fiber.Cookie{
-		Name:     "refresh_token",
-		Value:    "",
-		Path:     "/",
-		MaxAge:   -1,
-		Secure:   h.secureCookie,
-		HTTPOnly: true,
-	})
+	h.clearSessionCookies(c)
 
 	return c.JSON(fiber.Map{
 		"message": "logged out successfully",
@@ -325,11 +402,14 @@ func (h *AuthHandler) GetMe(c *fiber.Ctx) error {
 		})
 	}
 
-	return c.JSON(UserResponse{
-		ID:         user.ID,
-		Email:      user.Email,
-		Nickname:   user.Nickname,
-		ProfileImg: user.ProfileImg,
-		Provider:   user.Provider,
+	return c.JSON(MeResponse{
+		UserResponse: UserResponse{
+			ID:         user.ID,
+			Email:      user.Email,
+			Nickname:   user.Nickname,
+			ProfileImg: user.ProfileImg,
+			Provider:   user.Provider,
+		},
+		Impersonation: claims.Impersonation,
 	})
 }
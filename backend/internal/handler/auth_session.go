@@ -0,0 +1,204 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+
+	"realtime-backend/internal/auth"
+	"realtime-backend/internal/model"
+)
+
+// refreshReuseGrace 직전 리프레시 토큰을 이 시간 안에 다시 쓰면 동시 갱신(여러 탭)으로 보고 허용
+const refreshReuseGrace = 30 * time.Second
+
+// SessionResponse 로그인 세션 목록 항목
+type SessionResponse struct {
+	ID         int64  `json:"id"`
+	Device     string `json:"device"`
+	IPAddress  string `json:"ip_address"`
+	CreatedAt  string `json:"created_at"`
+	LastSeenAt string `json:"last_seen_at"`
+	ExpiresAt  string `json:"expires_at"`
+	Current    bool   `json:"current"` // 이 요청을 보낸 세션
+}
+
+// createSession 로그인 세션 생성 (리프레시 토큰 해시는 토큰 발급 후 저장)
+func (h *AuthHandler) createSession(c *fiber.Ctx, userID int64) (*model.AuthSession, error) {
+	ua := c.Get(fiber.HeaderUserAgent)
+	if len(ua) > 255 {
+		ua = ua[:255]
+	}
+	now := time.Now()
+	session := model.AuthSession{
+		UserID:     userID,
+		Device:     ua,
+		IPAddress:  c.IP(),
+		LastSeenAt: now,
+		ExpiresAt:  now.Add(h.jwtManager.RefreshExpiry()),
+	}
+	if err := h.db.Create(&session).Error; err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// isCurrentRefreshToken 키 분리 전에 발급된 리프레시 토큰 확인: 세션에 저장된 현재 토큰과 정확히 같을 때만 인정
+// (공용 키로 서명된 다른 토큰으로 재사용 감지를 일으켜 세션을 취소시키지 못하도록 불일치는 그냥 거부)
+func (h *AuthHandler) isCurrentRefreshToken(userID, sessionID int64, refreshToken string) bool {
+	var count int64
+	h.db.Model(&model.AuthSession{}).
+		Where("id = ? AND user_id = ? AND refresh_token_hash = ? AND revoked_at IS NULL", sessionID, userID, auth.HashOpaqueToken(refreshToken)).
+		Count(&count)
+	return count > 0
+}
+
+// rotateSession 리프레시 토큰 교체 후 쿠키 설정, 세션이 취소됐거나 재사용이 감지되면 revoked
+// 현재 토큰 해시를 조건으로 교체하므로 동시에 온 요청 중 하나만 새 리프레시 토큰을 받음
+func (h *AuthHandler) rotateSession(c *fiber.Ctx, user *model.User, sessionID int64, refreshToken string) (revoked bool, err error) {
+	var session model.AuthSession
+	if err := h.db.First(&session, "id = ? AND user_id = ?", sessionID, user.ID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return true, nil
+		}
+		return false, err
+	}
+	if !session.IsActive() {
+		return true, nil
+	}
+
+	now := time.Now()
+	hash := auth.HashOpaqueToken(refreshToken)
+	accessToken, newRefreshToken, err := h.sessionTokens(user, sessionID)
+	if err != nil {
+		return false, err
+	}
+
+	if session.RefreshTokenHash != hash {
+		if session.PreviousTokenHash != nil && *session.PreviousTokenHash == hash &&
+			session.RotatedAt != nil && now.Sub(*session.RotatedAt) < refreshReuseGrace {
+			// 다른 탭이 방금 교체함 - 새 리프레시 토큰은 그쪽 응답이 쿠키로 설정하므로 액세스 토큰만
+			h.setAccessCookie(c, accessToken)
+			return false, nil
+		}
+		log.Printf("🚨 리프레시 토큰 재사용 감지, 세션 취소: session=%d user=%d ip=%s", session.ID, user.ID, c.IP())
+		if err := revokeSessions(h.db, model.SessionRevokedRefreshReuse, "id = ?", session.ID); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+
+	result := h.db.Model(&model.AuthSession{}).
+		Where("id = ? AND refresh_token_hash = ? AND revoked_at IS NULL", session.ID, hash).
+		Updates(map[string]interface{}{
+			"refresh_token_hash":  auth.HashOpaqueToken(newRefreshToken),
+			"previous_token_hash": hash,
+			"rotated_at":          now,
+			"last_seen_at":        now,
+			"ip_address":          c.IP(),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	h.setAccessCookie(c, accessToken)
+	if result.RowsAffected > 0 {
+		h.setRefreshCookie(c, newRefreshToken)
+	}
+	return false, nil
+}
+
+// revokeSessions 조건에 맞는 활성 세션 취소 (이 인스턴스의 세션 캐시도 무효화)
+func revokeSessions(db *gorm.DB, reason string, query string, args ...interface{}) error {
+	var ids []int64
+	if err := db.Model(&model.AuthSession{}).
+		Where("revoked_at IS NULL").
+		Where(query, args...).
+		Pluck("id", &ids).Error; err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	if err := db.Model(&model.AuthSession{}).
+		Where("id IN ? AND revoked_at IS NULL", ids).
+		Updates(map[string]interface{}{"revoked_at": time.Now(), "revoked_reason": reason}).Error; err != nil {
+		return err
+	}
+	for _, id := range ids {
+		auth.InvalidateSession(id)
+	}
+	return nil
+}
+
+// ListSessions 내 로그인 세션 목록 (최근 사용 순)
+func (h *AuthHandler) ListSessions(c *fiber.Ctx) error {
+	claims, err := auth.GetClaimsFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "authentication required"})
+	}
+
+	var sessions []model.AuthSession
+	if err := h.db.Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", claims.UserID, time.Now()).
+		Order("last_seen_at DESC").
+		Find(&sessions).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to load sessions"})
+	}
+
+	resp := make([]SessionResponse, len(sessions))
+	for i, s := range sessions {
+		resp[i] = SessionResponse{
+			ID:         s.ID,
+			Device:     s.Device,
+			IPAddress:  s.IPAddress,
+			CreatedAt:  s.CreatedAt.Format(time.RFC3339),
+			LastSeenAt: s.LastSeenAt.Format(time.RFC3339),
+			ExpiresAt:  s.ExpiresAt.Format(time.RFC3339),
+			Current:    s.ID == claims.SessionID,
+		}
+	}
+	return c.JSON(fiber.Map{"sessions": resp})
+}
+
+// RevokeSession 세션 하나 로그아웃 (다른 기기)
+func (h *AuthHandler) RevokeSession(c *fiber.Ctx) error {
+	claims, err := auth.GetClaimsFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "authentication required"})
+	}
+	sessionID, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid session id"})
+	}
+
+	var count int64
+	h.db.Model(&model.AuthSession{}).Where("id = ? AND user_id = ? AND revoked_at IS NULL", sessionID, claims.UserID).Count(&count)
+	if count == 0 {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "session not found"})
+	}
+	if err := revokeSessions(h.db, model.SessionRevokedByUser, "id = ? AND user_id = ?", sessionID, claims.UserID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to revoke session"})
+	}
+	if sessionID == claims.SessionID {
+		h.clearSessionCookies(c)
+	}
+
+	return c.JSON(fiber.Map{"message": "session revoked"})
+}
+
+// RevokeOtherSessions 현재 세션을 제외한 모든 세션 로그아웃
+func (h *AuthHandler) RevokeOtherSessions(c *fiber.Ctx) error {
+	claims, err := auth.GetClaimsFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "authentication required"})
+	}
+
+	if err := revokeSessions(h.db, model.SessionRevokedByUser, "user_id = ? AND id <> ?", claims.UserID, claims.SessionID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to revoke sessions"})
+	}
+
+	return c.JSON(fiber.Map{"message": "other sessions revoked"})
+}
@@ -1,6 +1,9 @@
 package handler
 
 import (
+	"fmt"
+	"log"
+	"reflect"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
@@ -8,16 +11,20 @@ import (
 
 	"realtime-backend/internal/auth"
 	"realtime-backend/internal/model"
+	"realtime-backend/internal/recurrence"
 )
 
 // CalendarHandler 캘린더 핸들러
 type CalendarHandler struct {
-	db *gorm.DB
+	db         *gorm.DB
+	jwtManager *auth.JWTManager // 캘린더 구독 토큰 발급/검증
+	webURL     string           // 회의 참여 링크의 프론트엔드 주소
+	done       chan struct{}    // 알림 스케줄러 중단
 }
 
 // NewCalendarHandler CalendarHandler 생성
-func NewCalendarHandler(db *gorm.DB) *CalendarHandler {
-	return &CalendarHandler{db: db}
+func NewCalendarHandler(db *gorm.DB, jwtManager *auth.JWTManager, webURL string) *CalendarHandler {
+	return &CalendarHandler{db: db, jwtManager: jwtManager, webURL: webURL}
 }
 
 // CalendarEventResponse 캘린더 이벤트 응답
@@ -30,30 +37,53 @@ type CalendarEventResponse struct {
 	StartAt         string             `json:"start_at"`
 	EndAt           string             `json:"end_at"`
 	IsAllDay        bool               `json:"is_all_day"`
+	TimeZone        string             `json:"time_zone"`
+	StartDate       string             `json:"start_date,omitempty"` // 종일 일정의 날짜 (YYYY-MM-DD)
+	EndDate         string             `json:"end_date,omitempty"`
 	LinkedMeetingID *int64             `json:"linked_meeting_id,omitempty"`
 	Color           *string            `json:"color,omitempty"`
 	CreatedAt       string             `json:"created_at"`
 	Creator         *UserResponse      `json:"creator,omitempty"`
 	Attendees       []AttendeeResponse `json:"attendees,omitempty"`
+
+	Attachments []EventAttachmentResponse `json:"attachments"` // 준비 자료 (파일, 안건 링크)
+
+	RecurrenceRule  *string `json:"recurrence_rule,omitempty"`
+	OccurrenceStart string  `json:"occurrence_start,omitempty"` // 반복 일정 회차의 원래 시작 시각 (회차 수정/취소 시 사용)
+	IsException     bool    `json:"is_exception,omitempty"`     // 이 회차만 따로 수정됨
+
+	Reminders []int  `json:"reminders"`          // 기본 알림 (시작 몇 분 전)
+	JoinURL   string `json:"join_url,omitempty"` // 연결된 회의 참여 링크
 }
 
 // AttendeeResponse 참석자 응답
 type AttendeeResponse struct {
-	UserID    int64         `json:"user_id"`
-	Status    string        `json:"status"`
-	CreatedAt string        `json:"created_at"`
-	User      *UserResponse `json:"user,omitempty"`
+	UserID      int64         `json:"user_id"`
+	Status      string        `json:"status"`
+	IsOrganizer bool          `json:"is_organizer"` // 공동 주최자
+	CreatedAt   string        `json:"created_at"`
+	User        *UserResponse `json:"user,omitempty"`
 }
 
 // CreateEventRequest 이벤트 생성 요청
 type CreateEventRequest struct {
-	Title       string   `json:"title"`
-	Description *string  `json:"description,omitempty"`
-	StartAt     string   `json:"start_at"`
-	EndAt       string   `json:"end_at"`
-	IsAllDay    bool     `json:"is_all_day"`
-	Color       *string  `json:"color,omitempty"`
-	AttendeeIDs []int64  `json:"attendee_ids,omitempty"`
+	Title       string  `json:"title"`
+	Description *string `json:"description,omitempty"`
+	StartAt     string  `json:"start_at"`
+	EndAt       string  `json:"end_at"`
+	IsAllDay    bool    `json:"is_all_day"`
+	TimeZone    string  `json:"time_zone,omitempty"` // IANA 타임존 (없으면 ?tz= / X-Timezone, 그것도 없으면 Asia/Seoul)
+	Color       *string `json:"color,omitempty"`
+	AttendeeIDs []int64 `json:"attendee_ids,omitempty"`
+
+	OrganizerIDs []int64 `json:"organizer_ids,omitempty"` // 공동 주최자 (참석자로도 추가됨), 수정 시 []이면 모두 해제
+
+	Repeat         string  `json:"repeat,omitempty"`          // DAILY, WEEKLY, MONTHLY, YEARLY (recurrence_rule이 없을 때)
+	RecurrenceRule *string `json:"recurrence_rule,omitempty"` // RRULE (예: FREQ=WEEKLY;BYDAY=MO,WE;COUNT=10), 수정 시 ""이면 반복 해제
+
+	Reminders []int `json:"reminders,omitempty"` // 시작 몇 분 전에 알릴지 (예: [10, 60]), 수정 시 []이면 알림 없음
+
+	CreateMeeting bool `json:"create_meeting,omitempty"` // 화상 회의를 만들어 일정에 연결 (연결된 회의가 없을 때만)
 }
 
 // GetWorkspaceEvents 워크스페이스 이벤트 목록
@@ -77,23 +107,37 @@ func (h *CalendarHandler) GetWorkspaceEvents(c *fiber.Ctx) error {
 	startDate := c.Query("start_date")
 	endDate := c.Query("end_date")
 
-	query := h.db.Where("workspace_id = ?", workspaceID)
+	// 날짜 범위와 응답 시각은 요청자 타임존 기준 (없으면 범위는 UTC, 시각은 일정 타임존)
+	loc := requestLocation(c)
+	rangeLoc := loc
+	if rangeLoc == nil {
+		rangeLoc = time.UTC
+	}
 
+	// 반복 일정은 기간이 없으면 기본 범위만 펼침
+	now := time.Now()
+	from, to := now.Add(-calendarExpandDefaultPast), now.Add(calendarExpandDefaultFuture)
+	hasFrom, hasTo := false, false
 	if startDate != "" {
-		if t, err := time.Parse("2006-01-02", startDate); err == nil {
-			query = query.Where("end_at >= ?", t)
+		if t, err := time.ParseInLocation("2006-01-02", startDate, rangeLoc); err == nil {
+			from, hasFrom = t, true
 		}
 	}
 	if endDate != "" {
-		if t, err := time.Parse("2006-01-02", endDate); err == nil {
-			query = query.Where("start_at <= ?", t.Add(24*time.Hour))
+		if t, err := time.ParseInLocation("2006-01-02", endDate, rangeLoc); err == nil {
+			to, hasTo = t.Add(24*time.Hour), true
 		}
 	}
 
+	query := h.db.Where("workspace_id = ?", workspaceID).
+		Where(eventRangeQuery(h.db, from, to, hasFrom, hasTo))
+
 	var events []model.CalendarEvent
 	err = query.
 		Preload("Creator").
 		Preload("Attendees.User").
+		Preload("Attachments.File").
+		Preload("LinkedMeeting").
 		Order("start_at ASC").
 		Find(&events).Error
 
@@ -103,10 +147,7 @@ func (h *CalendarHandler) GetWorkspaceEvents(c *fiber.Ctx) error {
 		})
 	}
 
-	responses := make([]CalendarEventResponse, len(events))
-	for i, e := range events {
-		responses[i] = h.toEventResponse(&e)
-	}
+	responses := h.expandEvents(events, from, to, loc)
 
 	return c.JSON(fiber.Map{
 		"events": responses,
@@ -144,15 +185,15 @@ func (h *CalendarHandler) CreateEvent(c *fiber.Ctx) error {
 		})
 	}
 
-	// 시간 파싱
-	startAt, err := time.Parse(time.RFC3339, req.StartAt)
+	// 시간 파싱 (종일 일정은 날짜만 사용)
+	startAt, err := parseEventTime(req.StartAt, req.IsAllDay)
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 			"error": "invalid start_at format",
 		})
 	}
 
-	endAt, err := time.Parse(time.RFC3339, req.EndAt)
+	endAt, err := parseEventTime(req.EndAt, req.IsAllDay)
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 			"error": "invalid end_at format",
@@ -165,6 +206,33 @@ func (h *CalendarHandler) CreateEvent(c *fiber.Ctx) error {
 		})
 	}
 
+	timeZone := defaultEventTimeZone
+	if req.TimeZone != "" {
+		loc, err := resolveTimeZone(req.TimeZone)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		timeZone = loc.String()
+	} else if loc := requestLocation(c); loc != nil {
+		timeZone = loc.String()
+	}
+
+	rule, err := resolveRecurrenceRule(req.Repeat, req.RecurrenceRule)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid recurrence: " + err.Error(),
+		})
+	}
+
+	reminders, err := normalizeReminders(req.Reminders)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
 	req.Title = sanitizeString(req.Title)
 	if len(req.Title) > 255 {
 		req.Title = req.Title[:255]
@@ -179,7 +247,18 @@ func (h *CalendarHandler) CreateEvent(c *fiber.Ctx) error {
 		StartAt:     startAt,
 		EndAt:       endAt,
 		IsAllDay:    req.IsAllDay,
+		TimeZone:    timeZone,
 		Color:       req.Color,
+
+		ReminderMinutes: reminders,
+	}
+	applyRecurrence(&event, rule)
+
+	organizerIDs := h.validOrganizerIDs(&event, req.OrganizerIDs)
+	if len(organizerIDs) > maxEventOrganizers {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "too many organizers",
+		})
 	}
 
 	err = h.db.Transaction(func(tx *gorm.DB) error {
@@ -203,7 +282,15 @@ func (h *CalendarHandler) CreateEvent(c *fiber.Ctx) error {
 				return err
 			}
 		}
+		if len(organizerIDs) > 0 {
+			if err := h.setEventOrganizersWithTx(tx, &event, organizerIDs); err != nil {
+				return err
+			}
+		}
 
+		if req.CreateMeeting {
+			return createEventMeetingWithTx(tx, &event)
+		}
 		return nil
 	})
 
@@ -214,9 +301,9 @@ func (h *CalendarHandler) CreateEvent(c *fiber.Ctx) error {
 	}
 
 	// 전체 정보 로드
-	h.db.Preload("Creator").Preload("Attendees.User").First(&event, event.ID)
+	h.db.Preload("Creator").Preload("Attendees.User").Preload("Attachments.File").Preload("LinkedMeeting").First(&event, event.ID)
 
-	return c.Status(fiber.StatusCreated).JSON(h.toEventResponse(&event))
+	return c.Status(fiber.StatusCreated).JSON(h.toEventResponse(&event, requestLocation(c)))
 }
 
 // UpdateEvent 이벤트 수정
@@ -243,11 +330,10 @@ func (h *CalendarHandler) UpdateEvent(c *fiber.Ctx) error {
 		})
 	}
 
-	// 생성자만 수정 가능
-	if event.CreatorID == nil || *event.CreatorID != claims.UserID {
-		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-			"error": "only creator can update the event",
-		})
+	// 생성자, 공동 주최자, MANAGE_EVENTS 권한자만 수정 가능
+	access, ok := h.requireEventEditor(c, &event, claims.UserID)
+	if !ok {
+		return nil
 	}
 
 	var req CreateEventRequest
@@ -257,33 +343,108 @@ func (h *CalendarHandler) UpdateEvent(c *fiber.Ctx) error {
 		})
 	}
 
+	var organizerIDs []int64
+	if req.OrganizerIDs != nil {
+		if !access.canAssignOrganizers() {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": "only the event creator can change organizers",
+			})
+		}
+		organizerIDs = h.validOrganizerIDs(&event, req.OrganizerIDs)
+		if len(organizerIDs) > maxEventOrganizers {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "too many organizers",
+			})
+		}
+	}
+
 	if req.Title != "" {
 		event.Title = sanitizeString(req.Title)
 	}
 	if req.Description != nil {
 		event.Description = req.Description
 	}
+	previousStart, previousRule := event.StartAt, event.RecurrenceRule
+	if req.TimeZone != "" {
+		loc, err := resolveTimeZone(req.TimeZone)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		event.TimeZone = loc.String()
+	}
+	// 시간 일정 → 종일 일정으로 바꾸면서 시각을 보내지 않은 경우 일정 타임존의 날짜로
+	if req.IsAllDay && !event.IsAllDay {
+		event.StartAt = floatingDate(event.StartAt.In(eventLocation(&event)))
+		event.EndAt = floatingDate(event.EndAt.In(eventLocation(&event)))
+	}
 	if req.StartAt != "" {
-		if t, err := time.Parse(time.RFC3339, req.StartAt); err == nil {
+		if t, err := parseEventTime(req.StartAt, req.IsAllDay); err == nil {
 			event.StartAt = t
 		}
 	}
 	if req.EndAt != "" {
-		if t, err := time.Parse(time.RFC3339, req.EndAt); err == nil {
+		if t, err := parseEventTime(req.EndAt, req.IsAllDay); err == nil {
 			event.EndAt = t
 		}
 	}
 	event.IsAllDay = req.IsAllDay
 	event.Color = req.Color
+	if req.Reminders != nil {
+		reminders, err := normalizeReminders(req.Reminders)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		event.ReminderMinutes = reminders
+	}
 
-	if err := h.db.Save(&event).Error; err != nil {
+	// 반복 규칙 변경 (요청에 없으면 기존 규칙 유지, 시작 시각이 바뀌면 마지막 회차 다시 계산)
+	if req.RecurrenceRule != nil || req.Repeat != "" {
+		rule, err := resolveRecurrenceRule(req.Repeat, req.RecurrenceRule)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "invalid recurrence: " + err.Error(),
+			})
+		}
+		applyRecurrence(&event, rule)
+	} else if event.RecurrenceRule != nil {
+		if rule, err := recurrence.Parse(*event.RecurrenceRule); err == nil {
+			applyRecurrence(&event, rule)
+		}
+	}
+
+	err = h.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&event).Error; err != nil {
+			return err
+		}
+		// 회차 기준이 바뀌면 회차별 수정/취소는 더 이상 맞지 않으므로 삭제
+		if !event.StartAt.Equal(previousStart) || !reflect.DeepEqual(event.RecurrenceRule, previousRule) {
+			if err := tx.Where("event_id = ?", event.ID).Delete(&model.CalendarEventException{}).Error; err != nil {
+				return err
+			}
+		}
+		if req.OrganizerIDs != nil {
+			if err := h.setEventOrganizersWithTx(tx, &event, organizerIDs); err != nil {
+				return err
+			}
+		}
+		if event.LinkedMeetingID == nil && req.CreateMeeting {
+			return createEventMeetingWithTx(tx, &event)
+		}
+		return syncEventMeetingWithTx(tx, &event)
+	})
+	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "failed to update event",
 		})
 	}
-	h.db.Preload("Creator").Preload("Attendees.User").First(&event, event.ID)
+	h.auditManagedEvent(access, &event, claims.UserID, model.AuditEventUpdated, nil)
+	h.db.Preload("Creator").Preload("Attendees.User").Preload("Attachments.File").Preload("LinkedMeeting").First(&event, event.ID)
 
-	return c.JSON(h.toEventResponse(&event))
+	return c.JSON(h.toEventResponse(&event, requestLocation(c)))
 }
 
 // DeleteEvent 이벤트 삭제
@@ -310,16 +471,23 @@ func (h *CalendarHandler) DeleteEvent(c *fiber.Ctx) error {
 		})
 	}
 
-	// 생성자만 삭제 가능
-	if event.CreatorID == nil || *event.CreatorID != claims.UserID {
-		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-			"error": "only creator can delete the event",
-		})
+	access, ok := h.requireEventEditor(c, &event, claims.UserID)
+	if !ok {
+		return nil
 	}
 
-	// 참석자 먼저 삭제
+	// 참석자, 회차별 수정 기록, 알림 설정, 댓글, 첨부 먼저 삭제
 	h.db.Where("event_id = ?", eventID).Delete(&model.EventAttendee{})
+	h.db.Where("event_id = ?", eventID).Delete(&model.CalendarEventException{})
+	h.db.Where("event_id = ?", eventID).Delete(&model.EventReminderPreference{})
+	h.db.Where("event_id = ?", eventID).Delete(&model.EventReminderDelivery{})
+	h.db.Where("event_id = ?", eventID).Delete(&model.EventComment{})
+	h.db.Where("event_id = ?", eventID).Delete(&model.EventAttachment{})
 	h.db.Delete(&event)
+	if event.LinkedMeetingID != nil {
+		deleteEventMeetingWithTx(h.db, *event.LinkedMeetingID)
+	}
+	h.auditManagedEvent(access, &event, claims.UserID, model.AuditEventDeleted, nil)
 
 	return c.JSON(fiber.Map{
 		"message": "event deleted",
@@ -372,12 +540,16 @@ func (h *CalendarHandler) UpdateAttendeeStatus(c *fiber.Ctx) error {
 		})
 	}
 
+	previousStatus := attendee.Status
 	attendee.Status = req.Status
 	if err := h.db.Save(&attendee).Error; err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "failed to update attendee status",
 		})
 	}
+	if previousStatus != req.Status {
+		h.notifyAttendeeStatus(int64(workspaceID), int64(eventID), claims, req.Status)
+	}
 
 	return c.JSON(fiber.Map{
 		"message": "status updated",
@@ -385,6 +557,33 @@ func (h *CalendarHandler) UpdateAttendeeStatus(c *fiber.Ctx) error {
 	})
 }
 
+// notifyAttendeeStatus 참석 응답 변경을 일정 생성자에게 알림
+func (h *CalendarHandler) notifyAttendeeStatus(workspaceID, eventID int64, claims *auth.Claims, status string) {
+	var event model.CalendarEvent
+	if err := h.db.Select("id", "title", "creator_id").
+		Where("id = ? AND workspace_id = ?", eventID, workspaceID).
+		First(&event).Error; err != nil {
+		return
+	}
+	if event.CreatorID == nil || *event.CreatorID == claims.UserID {
+		return
+	}
+
+	var content string
+	switch status {
+	case "ACCEPTED":
+		content = fmt.Sprintf("%s님이 '%s' 일정에 참석합니다.", claims.Nickname, event.Title)
+	case "DECLINED":
+		content = fmt.Sprintf("%s님이 '%s' 일정에 불참합니다.", claims.Nickname, event.Title)
+	default:
+		content = fmt.Sprintf("%s님이 '%s' 일정의 참석 여부를 미정으로 바꿨습니다.", claims.Nickname, event.Title)
+	}
+	relatedType := "CALENDAR_EVENT"
+	if err := CreateNotification(h.db, *event.CreatorID, &claims.UserID, model.NotificationTypeEventRSVP.String(), content, &relatedType, &event.ID); err != nil {
+		log.Printf("⚠️ 참석 응답 알림 생성 실패: event=%d, err=%v", event.ID, err)
+	}
+}
+
 // 헬퍼 함수
 func (h *CalendarHandler) isWorkspaceMember(workspaceID, userID int64) bool {
 	var count int64
@@ -394,22 +593,38 @@ func (h *CalendarHandler) isWorkspaceMember(workspaceID, userID int64) bool {
 	return count > 0
 }
 
-func (h *CalendarHandler) toEventResponse(e *model.CalendarEvent) CalendarEventResponse {
+// toEventResponse 이벤트 응답 (loc: 요청자 타임존, nil이면 일정 타임존)
+func (h *CalendarHandler) toEventResponse(e *model.CalendarEvent, loc *time.Location) CalendarEventResponse {
+	if loc == nil {
+		loc = eventLocation(e)
+	}
 	resp := CalendarEventResponse{
 		ID:          e.ID,
 		WorkspaceID: e.WorkspaceID,
 		CreatorID:   e.CreatorID,
 		Title:       e.Title,
 		Description: e.Description,
-		StartAt:     e.StartAt.Format(time.RFC3339),
-		EndAt:       e.EndAt.Format(time.RFC3339),
+		StartAt:     formatEventTime(e.StartAt, e.IsAllDay, loc),
+		EndAt:       formatEventTime(e.EndAt, e.IsAllDay, loc),
 		IsAllDay:    e.IsAllDay,
+		TimeZone:    e.TimeZone,
 		Color:       e.Color,
 		CreatedAt:   e.CreatedAt.Format(time.RFC3339),
+
+		RecurrenceRule: e.RecurrenceRule,
+		Reminders:      parseReminderMinutes(e.ReminderMinutes),
+		Attachments:    toEventAttachmentResponses(e.Attachments),
+	}
+	if e.IsAllDay {
+		resp.StartDate = e.StartAt.UTC().Format("2006-01-02")
+		resp.EndDate = e.EndAt.UTC().Format("2006-01-02")
 	}
 
 	if e.LinkedMeetingID != nil {
 		resp.LinkedMeetingID = e.LinkedMeetingID
+		if e.LinkedMeeting != nil && e.LinkedMeeting.ID != 0 {
+			resp.JoinURL = h.meetingJoinURL(e.WorkspaceID, e.LinkedMeeting.Code)
+		}
 	}
 
 	if e.Creator != nil && e.Creator.ID != 0 {
@@ -425,9 +640,10 @@ func (h *CalendarHandler) toEventResponse(e *model.CalendarEvent) CalendarEventR
 		resp.Attendees = make([]AttendeeResponse, len(e.Attendees))
 		for i, a := range e.Attendees {
 			resp.Attendees[i] = AttendeeResponse{
-				UserID:    a.UserID,
-				Status:    a.Status,
-				CreatedAt: a.CreatedAt.Format(time.RFC3339),
+				UserID:      a.UserID,
+				Status:      a.Status,
+				IsOrganizer: a.IsOrganizer,
+				CreatedAt:   a.CreatedAt.Format(time.RFC3339),
 			}
 			if a.User.ID != 0 {
 				resp.Attendees[i].User = &UserResponse{
@@ -0,0 +1,204 @@
+package handler
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"realtime-backend/internal/auth"
+	"realtime-backend/internal/model"
+)
+
+// maxEventAttachments 일정 하나에 첨부할 수 있는 자료 수
+const maxEventAttachments = 20
+
+// EventAttachmentResponse 일정 첨부 자료 응답
+type EventAttachmentResponse struct {
+	ID        int64   `json:"id"`
+	Type      string  `json:"type"` // FILE, LINK
+	Title     string  `json:"title"`
+	FileID    *int64  `json:"file_id,omitempty"`
+	MimeType  *string `json:"mime_type,omitempty"`
+	FileSize  *int64  `json:"file_size,omitempty"`
+	URL       *string `json:"url,omitempty"` // LINK만 (파일은 /files/:fileId/download로 받음)
+	AddedBy   *int64  `json:"added_by,omitempty"`
+	CreatedAt string  `json:"created_at"`
+}
+
+// AddEventAttachmentRequest 첨부 요청 (file_id 또는 url 중 하나)
+type AddEventAttachmentRequest struct {
+	FileID *int64  `json:"file_id,omitempty"`
+	URL    *string `json:"url,omitempty"`
+	Title  string  `json:"title,omitempty"` // 링크 제목 (없으면 URL), 파일은 파일 이름 사용
+}
+
+// AddEventAttachment 일정에 워크스페이스 파일이나 안건 문서 링크 첨부 (일정 주최자만)
+func (h *CalendarHandler) AddEventAttachment(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	event, ok := h.requireMemberEvent(c, claims.UserID)
+	if !ok {
+		return nil
+	}
+	if _, ok := h.requireEventEditor(c, event, claims.UserID); !ok {
+		return nil
+	}
+
+	var req AddEventAttachmentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+	if (req.FileID == nil) == (req.URL == nil) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "either file_id or url is required",
+		})
+	}
+
+	var count int64
+	h.db.Model(&model.EventAttachment{}).Where("event_id = ?", event.ID).Count(&count)
+	if count >= maxEventAttachments {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "too many attachments",
+		})
+	}
+
+	attachment := model.EventAttachment{
+		EventID: event.ID,
+		AddedBy: &claims.UserID,
+	}
+	if req.FileID != nil {
+		var file model.WorkspaceFile
+		if err := h.db.Where("id = ? AND workspace_id = ? AND type = ?", *req.FileID, event.WorkspaceID, "FILE").
+			First(&file).Error; err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "file not found",
+			})
+		}
+		// 첨부하는 사람이 볼 수 없는 제한 폴더의 파일은 첨부 불가
+		access, err := queryFolderAccess(h.db, event.WorkspaceID, claims.UserID)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "failed to check folder permissions",
+			})
+		}
+		if !access.fileAllowed(&file) {
+			return folderForbidden(c)
+		}
+
+		var exists int64
+		h.db.Model(&model.EventAttachment{}).Where("event_id = ? AND file_id = ?", event.ID, file.ID).Count(&exists)
+		if exists > 0 {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": "file is already attached",
+			})
+		}
+
+		attachment.Type = model.EventAttachmentFile
+		attachment.FileID = &file.ID
+		attachment.Title = file.Name
+		attachment.File = &file
+	} else {
+		link := strings.TrimSpace(*req.URL)
+		if err := validateAttachmentURL(link); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		title := sanitizeString(strings.TrimSpace(req.Title))
+		if title == "" {
+			title = link
+		}
+		if len(title) > 255 {
+			title = title[:255]
+		}
+		attachment.Type = model.EventAttachmentLink
+		attachment.URL = &link
+		attachment.Title = title
+	}
+
+	if err := h.db.Omit("File").Create(&attachment).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to add attachment",
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(toEventAttachmentResponse(&attachment))
+}
+
+// DeleteEventAttachment 첨부 자료 제거 (일정 주최자 또는 첨부한 사람)
+func (h *CalendarHandler) DeleteEventAttachment(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	event, ok := h.requireMemberEvent(c, claims.UserID)
+	if !ok {
+		return nil
+	}
+
+	var attachment model.EventAttachment
+	if err := h.db.Where("id = ? AND event_id = ?", c.Params("attachmentId"), event.ID).First(&attachment).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "attachment not found",
+		})
+	}
+	if attachment.AddedBy == nil || *attachment.AddedBy != claims.UserID {
+		if _, ok := h.requireEventEditor(c, event, claims.UserID); !ok {
+			return nil
+		}
+	}
+
+	if err := h.db.Delete(&attachment).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to delete attachment",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "attachment deleted",
+	})
+}
+
+// validateAttachmentURL 첨부 링크는 http(s) 주소만 허용
+func validateAttachmentURL(raw string) error {
+	if len(raw) > 2048 {
+		return fmt.Errorf("url is too long")
+	}
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return fmt.Errorf("url must be an http(s) URL")
+	}
+	return nil
+}
+
+// toEventAttachmentResponses 일정 응답용 첨부 목록 (휴지통으로 옮긴 파일은 제외)
+func toEventAttachmentResponses(attachments []model.EventAttachment) []EventAttachmentResponse {
+	out := make([]EventAttachmentResponse, 0, len(attachments))
+	for i := range attachments {
+		a := &attachments[i]
+		if a.Type == model.EventAttachmentFile && (a.File == nil || a.File.ID == 0) {
+			continue
+		}
+		out = append(out, toEventAttachmentResponse(a))
+	}
+	return out
+}
+
+func toEventAttachmentResponse(a *model.EventAttachment) EventAttachmentResponse {
+	resp := EventAttachmentResponse{
+		ID:        a.ID,
+		Type:      a.Type,
+		Title:     a.Title,
+		FileID:    a.FileID,
+		URL:       a.URL,
+		AddedBy:   a.AddedBy,
+		CreatedAt: a.CreatedAt.Format(time.RFC3339),
+	}
+	if a.File != nil && a.File.ID != 0 {
+		resp.Title = a.File.Name // 첨부 후 이름이 바뀐 경우 현재 이름
+		resp.MimeType = a.File.MimeType
+		resp.FileSize = a.File.FileSize
+	}
+	return resp
+}
@@ -0,0 +1,242 @@
+package handler
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"realtime-backend/internal/auth"
+	"realtime-backend/internal/model"
+)
+
+const eventCommentMaxLength = 2000
+
+// EventCommentResponse 일정 댓글 응답
+type EventCommentResponse struct {
+	ID        int64         `json:"id"`
+	EventID   int64         `json:"event_id"`
+	UserID    int64         `json:"user_id"`
+	Content   string        `json:"content"`
+	CreatedAt string        `json:"created_at"`
+	UpdatedAt string        `json:"updated_at"`
+	Edited    bool          `json:"edited"`
+	User      *UserResponse `json:"user,omitempty"`
+}
+
+// GetEventComments 일정 댓글 목록 (오래된 순, limit/offset)
+func (h *CalendarHandler) GetEventComments(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	event, ok := h.requireMemberEvent(c, claims.UserID)
+	if !ok {
+		return nil
+	}
+
+	limit := c.QueryInt("limit", 50)
+	if limit < 1 || limit > 200 {
+		limit = 50
+	}
+	offset := c.QueryInt("offset", 0)
+
+	var comments []model.EventComment
+	if err := h.db.Where("event_id = ?", event.ID).
+		Preload("User").
+		Order("created_at ASC, id ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&comments).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get comments",
+		})
+	}
+
+	var total int64
+	h.db.Model(&model.EventComment{}).Where("event_id = ?", event.ID).Count(&total)
+
+	responses := make([]EventCommentResponse, len(comments))
+	for i := range comments {
+		responses[i] = toEventCommentResponse(&comments[i])
+	}
+	return c.JSON(fiber.Map{
+		"comments": responses,
+		"total":    total,
+	})
+}
+
+// CreateEventComment 일정 댓글 작성 (생성자와 거절하지 않은 참석자에게 알림)
+func (h *CalendarHandler) CreateEventComment(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	event, ok := h.requireMemberEvent(c, claims.UserID)
+	if !ok {
+		return nil
+	}
+
+	content, ok := parseEventCommentContent(c)
+	if !ok {
+		return nil
+	}
+
+	comment := model.EventComment{
+		EventID: event.ID,
+		UserID:  claims.UserID,
+		Content: content,
+	}
+	if err := h.db.Create(&comment).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to create comment",
+		})
+	}
+	h.db.Preload("User").First(&comment, comment.ID)
+
+	h.db.Preload("Attendees", "status <> ?", "DECLINED").First(event, event.ID)
+	var receivers []int64
+	for _, userID := range reminderRecipients(event) {
+		if userID != claims.UserID {
+			receivers = append(receivers, userID)
+		}
+	}
+	if len(receivers) > 0 {
+		relatedType := "CALENDAR_EVENT"
+		notice := fmt.Sprintf("%s님이 '%s' 일정에 댓글을 남겼습니다.", comment.User.Nickname, event.Title)
+		if err := CreateNotifications(h.db, receivers, &claims.UserID, model.NotificationTypeEventComment.String(), notice, &relatedType, &event.ID); err != nil {
+			log.Printf("⚠️ 일정 댓글 알림 생성 실패: event=%d, err=%v", event.ID, err)
+		}
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(toEventCommentResponse(&comment))
+}
+
+// UpdateEventComment 일정 댓글 수정 (작성자만)
+func (h *CalendarHandler) UpdateEventComment(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	event, ok := h.requireMemberEvent(c, claims.UserID)
+	if !ok {
+		return nil
+	}
+
+	var comment model.EventComment
+	if err := h.db.Where("id = ? AND event_id = ?", c.Params("commentId"), event.ID).First(&comment).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "comment not found",
+		})
+	}
+	if comment.UserID != claims.UserID {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "only the author can edit this comment",
+		})
+	}
+
+	content, ok := parseEventCommentContent(c)
+	if !ok {
+		return nil
+	}
+	comment.Content = content
+	if err := h.db.Save(&comment).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to update comment",
+		})
+	}
+	h.db.Preload("User").First(&comment, comment.ID)
+
+	return c.JSON(toEventCommentResponse(&comment))
+}
+
+// DeleteEventComment 일정 댓글 삭제 (작성자 또는 일정 주최자)
+func (h *CalendarHandler) DeleteEventComment(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	event, ok := h.requireMemberEvent(c, claims.UserID)
+	if !ok {
+		return nil
+	}
+
+	var comment model.EventComment
+	if err := h.db.Where("id = ? AND event_id = ?", c.Params("commentId"), event.ID).First(&comment).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "comment not found",
+		})
+	}
+	if comment.UserID != claims.UserID {
+		if _, ok := h.requireEventEditor(c, event, claims.UserID); !ok {
+			return nil
+		}
+	}
+
+	if err := h.db.Delete(&comment).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to delete comment",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "comment deleted",
+	})
+}
+
+// requireMemberEvent 워크스페이스 멤버인지 확인하고 일정 조회 (실패 시 응답 작성 후 false)
+func (h *CalendarHandler) requireMemberEvent(c *fiber.Ctx, userID int64) (*model.CalendarEvent, bool) {
+	workspaceID, err := c.ParamsInt("workspaceId")
+	if err != nil {
+		c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid workspace id"})
+		return nil, false
+	}
+	eventID, err := c.ParamsInt("eventId")
+	if err != nil {
+		c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid event id"})
+		return nil, false
+	}
+	if !h.isWorkspaceMember(int64(workspaceID), userID) {
+		c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "you are not a member of this workspace"})
+		return nil, false
+	}
+
+	var event model.CalendarEvent
+	if err := h.db.Where("id = ? AND workspace_id = ?", eventID, workspaceID).First(&event).Error; err != nil {
+		c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "event not found"})
+		return nil, false
+	}
+	return &event, true
+}
+
+// parseEventCommentContent 댓글 본문 파싱/검증 (실패 시 응답 작성 후 false)
+func parseEventCommentContent(c *fiber.Ctx) (string, bool) {
+	var req struct {
+		Content string `json:"content"`
+	}
+	if err := c.BodyParser(&req); err != nil {
+		c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid request body"})
+		return "", false
+	}
+	content := strings.TrimSpace(sanitizeString(req.Content))
+	if content == "" {
+		c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "content is required"})
+		return "", false
+	}
+	if len([]rune(content)) > eventCommentMaxLength {
+		c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("content too long (max %d characters)", eventCommentMaxLength)})
+		return "", false
+	}
+	return content, true
+}
+
+func toEventCommentResponse(comment *model.EventComment) EventCommentResponse {
+	resp := EventCommentResponse{
+		ID:        comment.ID,
+		EventID:   comment.EventID,
+		UserID:    comment.UserID,
+		Content:   comment.Content,
+		CreatedAt: comment.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: comment.UpdatedAt.Format(time.RFC3339),
+		Edited:    comment.UpdatedAt.Sub(comment.CreatedAt) > time.Second,
+	}
+	if comment.User.ID != 0 {
+		resp.User = &UserResponse{
+			ID:         comment.User.ID,
+			Email:      comment.User.Email,
+			Nickname:   comment.User.Nickname,
+			ProfileImg: comment.User.ProfileImg,
+		}
+	}
+	return resp
+}
@@ -0,0 +1,440 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"realtime-backend/internal/auth"
+	"realtime-backend/internal/ical"
+	"realtime-backend/internal/model"
+	"realtime-backend/internal/recurrence"
+)
+
+const (
+	// icsFeedPastDays 피드에 포함하는 지난 일정 범위
+	icsFeedPastDays = 90
+
+	icsImportMaxBytes  = 1 << 20
+	icsImportMaxEvents = 500
+)
+
+var errImportNotCreator = errors.New("event with this UID was created by another member")
+
+// ICSImportFailure 가져오지 못한 일정
+type ICSImportFailure struct {
+	UID    string `json:"uid"`
+	Reason string `json:"reason"`
+}
+
+// FeedAuth 캘린더 피드 인증 (?token= 구독 토큰이 있으면 그 토큰으로, 없으면 일반 로그인 인증)
+func (h *CalendarHandler) FeedAuth(c *fiber.Ctx) error {
+	token := c.Query("token")
+	if token == "" {
+		return auth.AuthMiddleware(h.jwtManager)(c)
+	}
+
+	workspaceID, err := c.ParamsInt("workspaceId")
+	if err != nil || !auth.IsCalendarFeedToken(token) {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "invalid token",
+		})
+	}
+
+	// 재발급/취소된 토큰은 해시가 없어 거부
+	var feed model.CalendarFeedToken
+	if err := h.db.Where("workspace_id = ? AND token_hash = ?", workspaceID, auth.HashOpaqueToken(token)).
+		First(&feed).Error; err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "invalid token",
+		})
+	}
+
+	c.Locals("userID", feed.UserID)
+	c.Locals("claims", &auth.Claims{UserID: feed.UserID})
+	return c.Next()
+}
+
+// ExportICS 워크스페이스 캘린더 ICS (구독 피드 겸 내보내기)
+// 반복 일정은 RRULE로, 회차별 취소는 EXDATE, 회차별 수정은 RECURRENCE-ID 일정으로 내보냄
+func (h *CalendarHandler) ExportICS(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	workspaceID, err := c.ParamsInt("workspaceId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid workspace id",
+		})
+	}
+
+	// 탈퇴/추방된 멤버의 구독 URL은 더 이상 동작하지 않음
+	if !h.isWorkspaceMember(int64(workspaceID), claims.UserID) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "you are not a member of this workspace",
+		})
+	}
+
+	var workspace model.Workspace
+	if err := h.db.Select("id", "name").First(&workspace, workspaceID).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "workspace not found",
+		})
+	}
+
+	now := time.Now()
+	from, to := now.AddDate(0, 0, -icsFeedPastDays), now.AddDate(100, 0, 0)
+	var events []model.CalendarEvent
+	if err := h.db.Where("workspace_id = ?", workspaceID).
+		Where(eventRangeQuery(h.db, from, to, true, false)).
+		Order("start_at ASC").
+		Find(&events).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get events",
+		})
+	}
+
+	var seriesIDs []int64
+	for _, e := range events {
+		if e.RecurrenceRule != nil {
+			seriesIDs = append(seriesIDs, e.ID)
+		}
+	}
+	exceptions := make(map[int64][]model.CalendarEventException)
+	if len(seriesIDs) > 0 {
+		var rows []model.CalendarEventException
+		h.db.Where("event_id IN ?", seriesIDs).Order("occurrence_start ASC").Find(&rows)
+		for _, row := range rows {
+			exceptions[row.EventID] = append(exceptions[row.EventID], row)
+		}
+	}
+
+	icsEvents := make([]ical.Event, 0, len(events))
+	for i := range events {
+		icsEvents = append(icsEvents, toICSEvents(&events[i], exceptions[events[i].ID])...)
+	}
+
+	var buf bytes.Buffer
+	if err := ical.Encode(&buf, workspace.Name, icsEvents); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to export events",
+		})
+	}
+
+	c.Set(fiber.HeaderContentType, "text/calendar; charset=utf-8")
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`inline; filename="workspace-%d.ics"`, workspace.ID))
+	c.Set(fiber.HeaderCacheControl, "private, max-age=300")
+	return c.Send(buf.Bytes())
+}
+
+// toICSEvents 일정 하나를 VEVENT로 (반복 일정의 수정된 회차는 별도 VEVENT)
+func toICSEvents(e *model.CalendarEvent, exceptions []model.CalendarEventException) []ical.Event {
+	uid := calendarEventUID(e)
+	master := ical.Event{
+		UID:     uid,
+		Summary: e.Title,
+		Start:   e.StartAt,
+		End:     e.EndAt,
+		AllDay:  e.IsAllDay,
+		Created: e.CreatedAt,
+	}
+	if e.IsAllDay {
+		master.Start, master.End = e.StartAt.UTC(), e.EndAt.UTC()
+	} else {
+		master.TZID = eventLocation(e).String()
+	}
+	if e.Description != nil {
+		master.Description = *e.Description
+	}
+	if e.RecurrenceRule == nil {
+		return []ical.Event{master}
+	}
+
+	master.RRule = *e.RecurrenceRule
+	out := []ical.Event{master}
+	for _, ex := range exceptions {
+		if ex.IsCancelled {
+			master.ExDates = append(master.ExDates, ex.OccurrenceStart)
+			continue
+		}
+		o := newOccurrence(e, ex.OccurrenceStart, &ex)
+		override := master
+		override.RRule, override.ExDates = "", nil
+		override.Start, override.End = o.start, o.end
+		override.RecurrenceID = &ex.OccurrenceStart
+		if ex.Title != nil {
+			override.Summary = *ex.Title
+		}
+		if ex.Description != nil {
+			override.Description = *ex.Description
+		}
+		out = append(out, override)
+	}
+	out[0] = master
+	return out
+}
+
+// calendarEventUID 가져온 일정은 원래 UID 유지 (다시 가져오거나 다른 캘린더와 중복되지 않도록)
+func calendarEventUID(e *model.CalendarEvent) string {
+	if e.ExternalUID != nil {
+		return *e.ExternalUID
+	}
+	return fmt.Sprintf("event-%d@eum", e.ID)
+}
+
+// CreateFeedToken 캘린더 구독 토큰 발급 (이미 있으면 재발급, 이전 URL은 무효)
+func (h *CalendarHandler) CreateFeedToken(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	workspaceID, err := c.ParamsInt("workspaceId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid workspace id",
+		})
+	}
+	if !h.isWorkspaceMember(int64(workspaceID), claims.UserID) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "you are not a member of this workspace",
+		})
+	}
+
+	token, hash, err := auth.NewCalendarFeedToken()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to create feed token",
+		})
+	}
+
+	feed := model.CalendarFeedToken{
+		WorkspaceID: int64(workspaceID),
+		UserID:      claims.UserID,
+		TokenHash:   hash,
+	}
+	err = h.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "workspace_id"}, {Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"token_hash", "updated_at"}),
+	}).Create(&feed).Error
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to create feed token",
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"token": token,
+		"url":   fmt.Sprintf("%s/api/workspaces/%d/events.ics?token=%s", c.BaseURL(), feed.WorkspaceID, token),
+	})
+}
+
+// RevokeFeedToken 캘린더 구독 토큰 취소
+func (h *CalendarHandler) RevokeFeedToken(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	workspaceID, err := c.ParamsInt("workspaceId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid workspace id",
+		})
+	}
+
+	if err := h.db.Where("workspace_id = ? AND user_id = ?", workspaceID, claims.UserID).
+		Delete(&model.CalendarFeedToken{}).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to revoke feed token",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "feed token revoked",
+	})
+}
+
+// ImportICS ICS 파일의 일정 가져오기 (multipart "file" 또는 text/calendar 본문)
+// 같은 UID를 다시 가져오면 내가 가져온 일정은 갱신, 다른 멤버가 가져온 일정은 건너뜀
+func (h *CalendarHandler) ImportICS(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	workspaceID, err := c.ParamsInt("workspaceId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid workspace id",
+		})
+	}
+	if !h.isWorkspaceMember(int64(workspaceID), claims.UserID) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "you are not a member of this workspace",
+		})
+	}
+
+	data := c.Body()
+	if fileHeader, err := c.FormFile("file"); err == nil {
+		src, err := fileHeader.Open()
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "failed to read file",
+			})
+		}
+		defer src.Close()
+		if data, err = io.ReadAll(io.LimitReader(src, icsImportMaxBytes+1)); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "failed to read file",
+			})
+		}
+	}
+	if len(data) == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "ics file is required",
+		})
+	}
+	if len(data) > icsImportMaxBytes {
+		return c.Status(fiber.StatusRequestEntityTooLarge).JSON(fiber.Map{
+			"error": "ics file too large (max 1MB)",
+		})
+	}
+
+	parsed, err := ical.Parse(bytes.NewReader(data))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid ics file: " + err.Error(),
+		})
+	}
+
+	// 회차 수정(RECURRENCE-ID)은 원본 일정과 함께 처리
+	var masters []ical.Event
+	overrides := make(map[string][]ical.Event)
+	for _, e := range parsed {
+		if e.RecurrenceID != nil {
+			overrides[e.UID] = append(overrides[e.UID], e)
+		} else {
+			masters = append(masters, e)
+		}
+	}
+	if len(masters) > icsImportMaxEvents {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": fmt.Sprintf("too many events (max %d)", icsImportMaxEvents),
+		})
+	}
+
+	imported, updated := 0, 0
+	failed := []ICSImportFailure{}
+	for _, e := range masters {
+		if e.Cancelled {
+			continue
+		}
+		created, err := h.importICSEvent(int64(workspaceID), claims.UserID, e, overrides[e.UID])
+		if err != nil {
+			failed = append(failed, ICSImportFailure{UID: e.UID, Reason: err.Error()})
+			continue
+		}
+		if created {
+			imported++
+		} else {
+			updated++
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"imported": imported,
+		"updated":  updated,
+		"failed":   failed,
+	})
+}
+
+// importICSEvent 일정 하나 생성 또는 갱신 (반복 일정의 EXDATE/회차 수정은 회차별 수정 기록으로)
+func (h *CalendarHandler) importICSEvent(workspaceID, userID int64, e ical.Event, overrides []ical.Event) (bool, error) {
+	if e.Start.IsZero() {
+		return false, errors.New("missing DTSTART")
+	}
+	if e.End.Before(e.Start) {
+		return false, errors.New("DTEND is before DTSTART")
+	}
+	var rule *recurrence.Rule
+	if e.RRule != "" {
+		var err error
+		if rule, err = recurrence.Parse(e.RRule); err != nil {
+			return false, fmt.Errorf("unsupported RRULE: %w", err)
+		}
+	}
+
+	title := sanitizeString(e.Summary)
+	if title == "" {
+		title = "(제목 없음)"
+	}
+	if len(title) > 255 {
+		title = title[:255]
+	}
+
+	created := false
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		var event model.CalendarEvent
+		var uid *string
+		if e.UID != "" {
+			uid = &e.UID
+			err := tx.Where("workspace_id = ? AND external_uid = ?", workspaceID, e.UID).First(&event).Error
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+		}
+		if event.ID != 0 && (event.CreatorID == nil || *event.CreatorID != userID) {
+			return errImportNotCreator
+		}
+
+		created = event.ID == 0
+		event.WorkspaceID = workspaceID
+		event.CreatorID = &userID
+		event.ExternalUID = uid
+		event.Title = title
+		event.Description = nil
+		if e.Description != "" {
+			event.Description = &e.Description
+		}
+		event.StartAt, event.EndAt, event.IsAllDay = e.Start, e.End, e.AllDay
+		if e.AllDay {
+			// DTEND는 다음 날 0시(제외)이므로 마지막 날짜로
+			event.StartAt = floatingDate(e.Start)
+			event.EndAt = floatingDate(e.End)
+			if event.EndAt.After(event.StartAt) {
+				event.EndAt = event.EndAt.AddDate(0, 0, -1)
+			}
+		}
+		switch {
+		case e.TZID != "":
+			event.TimeZone = e.TZID
+		case event.TimeZone == "":
+			event.TimeZone = defaultEventTimeZone
+		}
+		if event.ReminderMinutes == "" {
+			event.ReminderMinutes = "[]"
+		}
+		applyRecurrence(&event, rule)
+		if err := tx.Save(&event).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("event_id = ?", event.ID).Delete(&model.CalendarEventException{}).Error; err != nil {
+			return err
+		}
+		if rule == nil {
+			return nil
+		}
+		var rows []model.CalendarEventException
+		for _, ex := range e.ExDates {
+			rows = append(rows, model.CalendarEventException{EventID: event.ID, OccurrenceStart: ex, IsCancelled: true, UpdatedBy: &userID})
+		}
+		for _, o := range overrides {
+			row := model.CalendarEventException{EventID: event.ID, OccurrenceStart: *o.RecurrenceID, IsCancelled: o.Cancelled, UpdatedBy: &userID}
+			if !o.Cancelled {
+				overrideTitle := sanitizeString(o.Summary)
+				row.Title, row.Description = &overrideTitle, &o.Description
+				row.StartAt, row.EndAt = &o.Start, &o.End
+			}
+			rows = append(rows, row)
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&rows).Error
+	})
+	return created, err
+}
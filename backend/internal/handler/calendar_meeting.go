@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"fmt"
+	"net/url"
+
+	"gorm.io/gorm"
+
+	"realtime-backend/internal/model"
+)
+
+// createEventMeetingWithTx 일정용 화상 회의 생성 후 일정에 연결 (생성자가 호스트)
+func createEventMeetingWithTx(tx *gorm.DB, event *model.CalendarEvent) error {
+	code, err := generateSecureMeetingCode()
+	if err != nil {
+		return err
+	}
+
+	title := event.Title
+	if len(title) > 200 {
+		title = title[:200]
+	}
+	start, end := event.StartAt, event.EndAt
+	meeting := model.Meeting{
+		WorkspaceID:      &event.WorkspaceID,
+		HostID:           *event.CreatorID,
+		Title:            title,
+		Code:             code,
+		Type:             "VIDEO",
+		Status:           "SCHEDULED",
+		ScheduledStartAt: &start,
+		ScheduledEndAt:   &end,
+	}
+	if err := tx.Create(&meeting).Error; err != nil {
+		return err
+	}
+	if err := tx.Create(&model.Participant{
+		MeetingID: meeting.ID,
+		UserID:    event.CreatorID,
+		Role:      "HOST",
+	}).Error; err != nil {
+		return err
+	}
+
+	event.LinkedMeetingID = &meeting.ID
+	return tx.Model(event).Update("linked_meeting_id", meeting.ID).Error
+}
+
+// syncEventMeetingWithTx 일정 제목/시간 변경을 연결된 회의에 반영 (아직 시작 전인 회의만)
+func syncEventMeetingWithTx(tx *gorm.DB, event *model.CalendarEvent) error {
+	if event.LinkedMeetingID == nil {
+		return nil
+	}
+	title := event.Title
+	if len(title) > 200 {
+		title = title[:200]
+	}
+	return tx.Model(&model.Meeting{}).
+		Where("id = ? AND status = ?", *event.LinkedMeetingID, "SCHEDULED").
+		Updates(map[string]interface{}{
+			"title":              title,
+			"scheduled_start_at": event.StartAt,
+			"scheduled_end_at":   event.EndAt,
+		}).Error
+}
+
+// deleteEventMeetingWithTx 일정 삭제 시 한 번도 열리지 않은 연결 회의도 삭제
+func deleteEventMeetingWithTx(tx *gorm.DB, meetingID int64) error {
+	var meeting model.Meeting
+	if err := tx.Select("id", "status").First(&meeting, meetingID).Error; err != nil {
+		return nil
+	}
+	if meeting.Status != "SCHEDULED" {
+		return nil
+	}
+	if err := tx.Where("meeting_id = ?", meetingID).Delete(&model.Participant{}).Error; err != nil {
+		return err
+	}
+	return tx.Delete(&meeting).Error
+}
+
+// meetingJoinURL 회의 참여 링크 (PUBLIC_WEB_URL이 없으면 상대 경로)
+func (h *CalendarHandler) meetingJoinURL(workspaceID int64, meetingCode string) string {
+	return fmt.Sprintf("%s/workspace/%d?meeting=%s", h.webURL, workspaceID, url.QueryEscape(meetingCode))
+}
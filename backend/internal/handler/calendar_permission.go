@@ -0,0 +1,171 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+
+	"realtime-backend/internal/auth"
+	"realtime-backend/internal/model"
+)
+
+// maxEventOrganizers 일정 하나에 지정할 수 있는 공동 주최자 수
+const maxEventOrganizers = 20
+
+// eventAccess 일정 수정 권한 종류
+type eventAccess int
+
+const (
+	eventAccessNone        eventAccess = iota
+	eventAccessCoOrganizer             // 공동 주최자 (일정 수정/삭제만)
+	eventAccessCreator                 // 생성자
+	eventAccessManager                 // 다른 멤버의 일정을 MANAGE_EVENTS 권한으로 관리 (감사 기록 남김)
+)
+
+// canAssignOrganizers 공동 주최자를 지정할 수 있는지 (생성자 또는 MANAGE_EVENTS)
+func (a eventAccess) canAssignOrganizers() bool {
+	return a == eventAccessCreator || a == eventAccessManager
+}
+
+// eventAccessFor 사용자가 일정을 어떤 자격으로 수정할 수 있는지
+func (h *CalendarHandler) eventAccessFor(event *model.CalendarEvent, userID int64) (eventAccess, error) {
+	if event.CreatorID != nil && *event.CreatorID == userID {
+		return eventAccessCreator, nil
+	}
+
+	var count int64
+	if err := h.db.Model(&model.EventAttendee{}).
+		Where("event_id = ? AND user_id = ? AND is_organizer = ?", event.ID, userID, true).
+		Count(&count).Error; err != nil {
+		return eventAccessNone, err
+	}
+	if count > 0 && h.isWorkspaceMember(event.WorkspaceID, userID) {
+		return eventAccessCoOrganizer, nil
+	}
+
+	allowed, err := auth.CheckPermission(h.db, event.WorkspaceID, userID, model.PermissionManageEvents)
+	if err != nil {
+		return eventAccessNone, err
+	}
+	if allowed {
+		return eventAccessManager, nil
+	}
+	return eventAccessNone, nil
+}
+
+// requireEventEditor 일정 수정 권한 확인 (실패 시 응답 작성 후 false)
+func (h *CalendarHandler) requireEventEditor(c *fiber.Ctx, event *model.CalendarEvent, userID int64) (eventAccess, bool) {
+	access, err := h.eventAccessFor(event, userID)
+	if err != nil {
+		c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to check permission"})
+		return eventAccessNone, false
+	}
+	if access == eventAccessNone {
+		c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "only the event organizers can modify the event"})
+		return eventAccessNone, false
+	}
+	return access, true
+}
+
+// auditManagedEvent 관리자가 다른 멤버의 일정을 고친 경우에만 감사 기록
+func (h *CalendarHandler) auditManagedEvent(access eventAccess, event *model.CalendarEvent, actorID int64, action model.AuditAction, metadata map[string]interface{}) {
+	if access != eventAccessManager {
+		return
+	}
+	if metadata == nil {
+		metadata = map[string]interface{}{}
+	}
+	metadata["title"] = event.Title
+	metadata["creator_id"] = event.CreatorID
+	recordAuditEvent(h.db, event.WorkspaceID, &actorID, action, model.AuditTargetEvent, event.ID, metadata)
+}
+
+// setEventOrganizersWithTx 공동 주최자 목록 교체
+// 참석자가 아니면 참석자로 추가하고, 목록에서 빠진 공동 주최자는 일반 참석자로 남김
+func (h *CalendarHandler) setEventOrganizersWithTx(tx *gorm.DB, event *model.CalendarEvent, userIDs []int64) error {
+	if err := tx.Model(&model.EventAttendee{}).
+		Where("event_id = ? AND is_organizer = ?", event.ID, true).
+		Update("is_organizer", false).Error; err != nil {
+		return err
+	}
+
+	for _, userID := range userIDs {
+		var attendee model.EventAttendee
+		err := tx.Where("event_id = ? AND user_id = ?", event.ID, userID).
+			Attrs(model.EventAttendee{Status: "PENDING"}).
+			FirstOrCreate(&attendee, model.EventAttendee{EventID: event.ID, UserID: userID}).Error
+		if err != nil {
+			return err
+		}
+		if err := tx.Model(&attendee).Update("is_organizer", true).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validOrganizerIDs 공동 주최자로 지정할 수 있는 사용자만 (활성 멤버, 생성자 제외, 중복 제거)
+func (h *CalendarHandler) validOrganizerIDs(event *model.CalendarEvent, userIDs []int64) []int64 {
+	valid := make([]int64, 0, len(userIDs))
+	seen := make(map[int64]bool, len(userIDs))
+	for _, userID := range userIDs {
+		if seen[userID] || (event.CreatorID != nil && *event.CreatorID == userID) {
+			continue
+		}
+		seen[userID] = true
+		if h.isWorkspaceMember(event.WorkspaceID, userID) {
+			valid = append(valid, userID)
+		}
+	}
+	return valid
+}
+
+// UpdateEventOrganizers 공동 주최자 지정 (생성자 또는 MANAGE_EVENTS)
+// PUT /:workspaceId/events/:eventId/organizers {"user_ids": [..]} - 목록 전체 교체, []이면 모두 해제
+func (h *CalendarHandler) UpdateEventOrganizers(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	event, ok := h.requireMemberEvent(c, claims.UserID)
+	if !ok {
+		return nil
+	}
+
+	var req struct {
+		UserIDs []int64 `json:"user_ids"`
+	}
+	if err := c.BodyParser(&req); err != nil || req.UserIDs == nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "user_ids is required",
+		})
+	}
+
+	access, ok := h.requireEventEditor(c, event, claims.UserID)
+	if !ok {
+		return nil
+	}
+	if !access.canAssignOrganizers() {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "only the event creator can change organizers",
+		})
+	}
+
+	organizerIDs := h.validOrganizerIDs(event, req.UserIDs)
+	if len(organizerIDs) > maxEventOrganizers {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "too many organizers",
+		})
+	}
+
+	if err := h.db.Transaction(func(tx *gorm.DB) error {
+		return h.setEventOrganizersWithTx(tx, event, organizerIDs)
+	}); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to update organizers",
+		})
+	}
+	h.auditManagedEvent(access, event, claims.UserID, model.AuditEventUpdated, map[string]interface{}{
+		"organizer_ids": organizerIDs,
+	})
+
+	h.db.Preload("Creator").Preload("Attendees.User").Preload("Attachments.File").Preload("LinkedMeeting").First(event, event.ID)
+
+	return c.JSON(h.toEventResponse(event, requestLocation(c)))
+}
@@ -0,0 +1,217 @@
+package handler
+
+import (
+	"slices"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"realtime-backend/internal/auth"
+	"realtime-backend/internal/model"
+)
+
+const (
+	personalAgendaDefaultDays = 14
+	personalAgendaMaxDays     = 92
+)
+
+// AgendaItem 개인 일정 항목 (워크스페이스 일정 또는 내가 호스트인 회의)
+type AgendaItem struct {
+	Kind          string                 `json:"kind"` // EVENT, MEETING
+	WorkspaceID   int64                  `json:"workspace_id"`
+	WorkspaceName string                 `json:"workspace_name"`
+	StartAt       string                 `json:"start_at"`
+	EndAt         string                 `json:"end_at"`
+	Event         *CalendarEventResponse `json:"event,omitempty"`
+	Meeting       *AgendaMeeting         `json:"meeting,omitempty"`
+
+	start time.Time
+}
+
+// AgendaMeeting 개인 일정에 표시하는 회의 정보
+type AgendaMeeting struct {
+	ID      int64  `json:"id"`
+	Title   string `json:"title"`
+	Code    string `json:"code"`
+	Type    string `json:"type"`
+	Status  string `json:"status"`
+	JoinURL string `json:"join_url"`
+}
+
+// GetMyEvents 내가 속한 모든 워크스페이스의 일정 + 내가 호스트인 회의 (?from=&to=, ?mine=true면 내가 만들었거나 참석하는 일정만)
+func (h *CalendarHandler) GetMyEvents(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	loc := requestLocation(c)
+	rangeLoc := loc
+	if rangeLoc == nil {
+		rangeLoc = time.UTC
+	}
+
+	now := time.Now()
+	from, to := now, now.AddDate(0, 0, personalAgendaDefaultDays)
+	if v := c.Query("from"); v != "" {
+		t, err := parseAgendaBound(v, rangeLoc, false)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "invalid from (RFC3339 or YYYY-MM-DD)",
+			})
+		}
+		from = t
+		if c.Query("to") == "" {
+			to = from.AddDate(0, 0, personalAgendaDefaultDays)
+		}
+	}
+	if v := c.Query("to"); v != "" {
+		t, err := parseAgendaBound(v, rangeLoc, true)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "invalid to (RFC3339 or YYYY-MM-DD)",
+			})
+		}
+		to = t
+	}
+	if to.Before(from) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "to must be after from",
+		})
+	}
+	if to.Sub(from) > personalAgendaMaxDays*24*time.Hour {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "range too large (max 92 days)",
+		})
+	}
+
+	// 내가 속한 워크스페이스 (소유 + 활성 멤버)
+	var workspaces []model.Workspace
+	if err := h.db.Select("id", "name").
+		Where("owner_id = ? OR id IN (?)", claims.UserID,
+			h.db.Model(&model.WorkspaceMember{}).Select("workspace_id").
+				Where("user_id = ? AND status = ?", claims.UserID, model.MemberStatusActive.String())).
+		Find(&workspaces).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get workspaces",
+		})
+	}
+	names := make(map[int64]string, len(workspaces))
+	workspaceIDs := make([]int64, len(workspaces))
+	for i, w := range workspaces {
+		names[w.ID] = w.Name
+		workspaceIDs[i] = w.ID
+	}
+
+	items := []AgendaItem{}
+	linkedMeetings := make(map[int64]bool)
+	if len(workspaceIDs) > 0 {
+		query := h.db.Where("workspace_id IN ?", workspaceIDs).
+			Where(eventRangeQuery(h.db, from, to, true, true))
+		if c.QueryBool("mine") {
+			query = query.Where("creator_id = ? OR id IN (?)", claims.UserID,
+				h.db.Model(&model.EventAttendee{}).Select("event_id").
+					Where("user_id = ? AND status <> ?", claims.UserID, "DECLINED"))
+		}
+
+		var events []model.CalendarEvent
+		if err := query.
+			Preload("Creator").
+			Preload("Attendees.User").
+			Preload("Attachments.File").
+			Preload("LinkedMeeting").
+			Find(&events).Error; err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "failed to get events",
+			})
+		}
+
+		for _, o := range expandOccurrences(h.db, events, from, to) {
+			var resp CalendarEventResponse
+			if o.occurrence == nil {
+				resp = h.toEventResponse(o.event, loc)
+			} else {
+				resp = h.toOccurrenceResponse(o, loc)
+			}
+			if o.event.LinkedMeetingID != nil {
+				linkedMeetings[*o.event.LinkedMeetingID] = true
+			}
+			items = append(items, AgendaItem{
+				Kind:          "EVENT",
+				WorkspaceID:   o.event.WorkspaceID,
+				WorkspaceName: names[o.event.WorkspaceID],
+				StartAt:       resp.StartAt,
+				EndAt:         resp.EndAt,
+				Event:         &resp,
+				start:         eventInstant(o.event, o.start),
+			})
+		}
+	}
+
+	// 내가 호스트인 회의 (예정 시각 또는 시작 시각이 범위 안, 일정에 연결된 회의는 일정으로만 표시)
+	var meetings []model.Meeting
+	if err := h.db.Where("host_id = ? AND workspace_id IS NOT NULL AND type NOT IN ?", claims.UserID,
+		[]string{model.MeetingTypeChatRoom.String(), model.MeetingTypeDM.String()}).
+		Where("COALESCE(scheduled_start_at, started_at) BETWEEN ? AND ?", from, to).
+		Find(&meetings).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get meetings",
+		})
+	}
+	for _, m := range meetings {
+		if linkedMeetings[m.ID] {
+			continue
+		}
+		start := m.StartedAt
+		if m.ScheduledStartAt != nil {
+			start = m.ScheduledStartAt
+		}
+		end := *start
+		if m.ScheduledEndAt != nil {
+			end = *m.ScheduledEndAt
+		} else if m.EndedAt != nil {
+			end = *m.EndedAt
+		}
+		displayLoc := loc
+		if displayLoc == nil {
+			displayLoc = time.Local
+		}
+
+		items = append(items, AgendaItem{
+			Kind:          "MEETING",
+			WorkspaceID:   *m.WorkspaceID,
+			WorkspaceName: names[*m.WorkspaceID],
+			StartAt:       start.In(displayLoc).Format(time.RFC3339),
+			EndAt:         end.In(displayLoc).Format(time.RFC3339),
+			Meeting: &AgendaMeeting{
+				ID:      m.ID,
+				Title:   m.Title,
+				Code:    m.Code,
+				Type:    m.Type,
+				Status:  m.Status,
+				JoinURL: h.meetingJoinURL(*m.WorkspaceID, m.Code),
+			},
+			start: *start,
+		})
+	}
+
+	slices.SortStableFunc(items, func(a, b AgendaItem) int { return a.start.Compare(b.start) })
+
+	return c.JSON(fiber.Map{
+		"from":  from.Format(time.RFC3339),
+		"to":    to.Format(time.RFC3339),
+		"items": items,
+		"total": len(items),
+	})
+}
+
+// parseAgendaBound 기간 경계 파싱 (날짜만 있으면 loc 기준, to는 그날 끝까지 포함)
+func parseAgendaBound(value string, loc *time.Location, isEnd bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	t, err := time.ParseInLocation("2006-01-02", value, loc)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if isEnd {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, nil
+}
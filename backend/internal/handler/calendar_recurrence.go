@@ -0,0 +1,367 @@
+package handler
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"realtime-backend/internal/auth"
+	"realtime-backend/internal/model"
+	"realtime-backend/internal/recurrence"
+)
+
+const (
+	// 기간을 지정하지 않고 조회하면 반복 일정은 이 범위만 펼침
+	calendarExpandDefaultPast   = 31 * 24 * time.Hour
+	calendarExpandDefaultFuture = 92 * 24 * time.Hour
+
+	// maxOccurrencesPerEvent 반복 일정 하나에서 한 번에 펼치는 최대 회차 수
+	maxOccurrencesPerEvent = 500
+)
+
+// repeatPresets 간단 반복 옵션 → RRULE
+var repeatPresets = map[string]string{
+	"DAILY":   "FREQ=DAILY",
+	"WEEKLY":  "FREQ=WEEKLY",
+	"MONTHLY": "FREQ=MONTHLY",
+	"YEARLY":  "FREQ=YEARLY",
+}
+
+// OccurrenceRequest 반복 일정 회차 수정 요청 (occurrence_start는 규칙상 원래 시작 시각)
+type OccurrenceRequest struct {
+	OccurrenceStart string  `json:"occurrence_start"`
+	Title           *string `json:"title,omitempty"`
+	Description     *string `json:"description,omitempty"`
+	StartAt         *string `json:"start_at,omitempty"`
+	EndAt           *string `json:"end_at,omitempty"`
+}
+
+// resolveRecurrenceRule 요청의 recurrence_rule(RRULE) 또는 repeat(DAILY 등)을 정규화된 규칙으로
+// 둘 다 없으면 nil (단일 일정)
+func resolveRecurrenceRule(repeat string, rule *string) (*recurrence.Rule, error) {
+	raw := ""
+	if rule != nil {
+		raw = strings.TrimSpace(*rule)
+	}
+	if raw == "" && repeat != "" {
+		preset, ok := repeatPresets[strings.ToUpper(repeat)]
+		if !ok {
+			return nil, errors.New("repeat must be DAILY, WEEKLY, MONTHLY or YEARLY")
+		}
+		raw = preset
+	}
+	if raw == "" {
+		return nil, nil
+	}
+	return recurrence.Parse(raw)
+}
+
+// applyRecurrence 이벤트에 반복 규칙 저장 (nil이면 단일 일정으로)
+func applyRecurrence(event *model.CalendarEvent, rule *recurrence.Rule) {
+	if rule == nil {
+		event.RecurrenceRule = nil
+		event.RecurrenceUntil = nil
+		return
+	}
+	event.RecurrenceRule = valPtr(rule.String())
+	event.RecurrenceUntil = rule.LastOccurrence(seriesStart(event))
+}
+
+// eventRangeQuery [from, to]와 겹치는 일정 조건
+// 단일 일정은 기간이 주어진 쪽만 필터 (기존 동작 유지), 반복 일정은 시리즈 기간으로 필터
+func eventRangeQuery(db *gorm.DB, from, to time.Time, hasFrom, hasTo bool) *gorm.DB {
+	single := db.Where("recurrence_rule IS NULL")
+	if hasFrom {
+		single = single.Where("end_at >= ?", from)
+	}
+	if hasTo {
+		single = single.Where("start_at <= ?", to)
+	}
+	series := db.Where("recurrence_rule IS NOT NULL AND start_at <= ? AND (recurrence_until IS NULL OR recurrence_until + (end_at - start_at) >= ?)", to, from)
+	return db.Where(single).Or(series)
+}
+
+// eventOccurrence 조회 범위 안의 일정 한 건 (단일 일정 또는 반복 일정의 한 회차)
+type eventOccurrence struct {
+	event      *model.CalendarEvent
+	occurrence *time.Time // 반복 일정 회차의 원래 시작 시각 (단일 일정은 nil)
+	start, end time.Time  // 회차별 수정이 반영된 시각
+	exception  *model.CalendarEventException
+}
+
+// newOccurrence 반복 일정의 한 회차 (회차별 수정이 있으면 반영)
+func newOccurrence(e *model.CalendarEvent, occurrence time.Time, ex *model.CalendarEventException) eventOccurrence {
+	o := eventOccurrence{event: e, occurrence: &occurrence, start: occurrence, exception: ex}
+	duration := e.EndAt.Sub(e.StartAt)
+	if ex != nil && ex.StartAt != nil {
+		o.start = *ex.StartAt
+	}
+	o.end = o.start.Add(duration)
+	if ex != nil && ex.EndAt != nil {
+		o.end = *ex.EndAt
+	}
+	return o
+}
+
+// expandOccurrences 반복 일정을 [from, to]와 겹치는 회차로 펼치고 회차별 수정/취소를 반영 (시작 시각순)
+func expandOccurrences(db *gorm.DB, events []model.CalendarEvent, from, to time.Time) []eventOccurrence {
+	var seriesIDs []int64
+	for _, e := range events {
+		if e.RecurrenceRule != nil {
+			seriesIDs = append(seriesIDs, e.ID)
+		}
+	}
+	exceptions := make(map[int64]map[int64]model.CalendarEventException)
+	if len(seriesIDs) > 0 {
+		var rows []model.CalendarEventException
+		db.Where("event_id IN ?", seriesIDs).Find(&rows)
+		for _, row := range rows {
+			if exceptions[row.EventID] == nil {
+				exceptions[row.EventID] = make(map[int64]model.CalendarEventException)
+			}
+			exceptions[row.EventID][row.OccurrenceStart.Unix()] = row
+		}
+	}
+
+	var occurrences []eventOccurrence
+	for i := range events {
+		e := &events[i]
+		var rule *recurrence.Rule
+		if e.RecurrenceRule != nil {
+			rule, _ = recurrence.Parse(*e.RecurrenceRule)
+		}
+		if rule == nil {
+			occurrences = append(occurrences, eventOccurrence{event: e, start: e.StartAt, end: e.EndAt})
+			continue
+		}
+
+		duration := e.EndAt.Sub(e.StartAt)
+		seen := make(map[int64]bool)
+		for _, occurrence := range rule.Between(seriesStart(e), from.Add(-duration), to, maxOccurrencesPerEvent) {
+			seen[occurrence.Unix()] = true
+			ex, hasException := exceptions[e.ID][occurrence.Unix()]
+			if hasException && ex.IsCancelled {
+				continue
+			}
+			occurrences = append(occurrences, newOccurrence(e, occurrence, exceptionPtr(ex, hasException)))
+		}
+
+		// 범위 밖 회차를 범위 안으로 옮긴 경우
+		for key, ex := range exceptions[e.ID] {
+			if seen[key] || ex.IsCancelled || ex.StartAt == nil {
+				continue
+			}
+			o := newOccurrence(e, ex.OccurrenceStart, &ex)
+			if o.start.After(to) || o.end.Before(from) {
+				continue
+			}
+			occurrences = append(occurrences, o)
+		}
+	}
+
+	slices.SortStableFunc(occurrences, func(a, b eventOccurrence) int { return a.start.Compare(b.start) })
+	return occurrences
+}
+
+// expandEvents 조회 범위의 일정 응답 (반복 일정은 회차별로)
+func (h *CalendarHandler) expandEvents(events []model.CalendarEvent, from, to time.Time, loc *time.Location) []CalendarEventResponse {
+	occurrences := expandOccurrences(h.db, events, from, to)
+	responses := make([]CalendarEventResponse, len(occurrences))
+	for i, o := range occurrences {
+		if o.occurrence == nil {
+			responses[i] = h.toEventResponse(o.event, loc)
+		} else {
+			responses[i] = h.toOccurrenceResponse(o, loc)
+		}
+	}
+	return responses
+}
+
+func exceptionPtr(ex model.CalendarEventException, ok bool) *model.CalendarEventException {
+	if !ok {
+		return nil
+	}
+	return &ex
+}
+
+// toOccurrenceResponse 반복 일정의 한 회차 응답 (ID는 시리즈 ID, occurrence_start로 회차 구분)
+func (h *CalendarHandler) toOccurrenceResponse(o eventOccurrence, loc *time.Location) CalendarEventResponse {
+	resp := h.toEventResponse(o.event, loc)
+	if loc == nil {
+		loc = eventLocation(o.event)
+	}
+	if ex := o.exception; ex != nil {
+		resp.IsException = true
+		if ex.Title != nil {
+			resp.Title = *ex.Title
+		}
+		if ex.Description != nil {
+			resp.Description = ex.Description
+		}
+	}
+	resp.StartAt = formatEventTime(o.start, o.event.IsAllDay, loc)
+	resp.EndAt = formatEventTime(o.end, o.event.IsAllDay, loc)
+	if o.event.IsAllDay {
+		resp.StartDate = o.start.UTC().Format("2006-01-02")
+		resp.EndDate = o.end.UTC().Format("2006-01-02")
+	}
+	// 회차 식별용이므로 타임존 변환 없이 원래 시각 그대로
+	resp.OccurrenceStart = o.occurrence.Format(time.RFC3339)
+	return resp
+}
+
+// UpdateEventOccurrence 반복 일정의 한 회차만 수정 (생성자만)
+func (h *CalendarHandler) UpdateEventOccurrence(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+
+	var req OccurrenceRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+
+	event, occurrence, ok := h.requireSeriesOccurrence(c, claims.UserID, req.OccurrenceStart)
+	if !ok {
+		return nil
+	}
+
+	// 이미 수정한 회차면 요청에 없는 필드는 이전 수정 내용 유지
+	var ex model.CalendarEventException
+	h.db.Where("event_id = ? AND occurrence_start = ?", event.ID, occurrence).First(&ex)
+	ex.EventID = event.ID
+	ex.OccurrenceStart = occurrence
+	ex.IsCancelled = false
+	ex.UpdatedBy = &claims.UserID
+	if req.Title != nil {
+		title := sanitizeString(*req.Title)
+		if title == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "title cannot be empty",
+			})
+		}
+		if len(title) > 255 {
+			title = title[:255]
+		}
+		ex.Title = &title
+	}
+	if req.Description != nil {
+		ex.Description = req.Description
+	}
+	if req.StartAt != nil {
+		t, err := parseEventTime(*req.StartAt, event.IsAllDay)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "invalid start_at format",
+			})
+		}
+		ex.StartAt = &t
+	}
+	if req.EndAt != nil {
+		t, err := parseEventTime(*req.EndAt, event.IsAllDay)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "invalid end_at format",
+			})
+		}
+		ex.EndAt = &t
+	}
+
+	start := occurrence
+	if ex.StartAt != nil {
+		start = *ex.StartAt
+	}
+	end := start.Add(event.EndAt.Sub(event.StartAt))
+	if ex.EndAt != nil {
+		end = *ex.EndAt
+	}
+	if end.Before(start) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "end_at must be after start_at",
+		})
+	}
+
+	if err := h.db.Save(&ex).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to update occurrence",
+		})
+	}
+
+	h.db.Preload("Creator").Preload("Attendees.User").Preload("Attachments.File").Preload("LinkedMeeting").First(event, event.ID)
+	return c.JSON(h.toOccurrenceResponse(newOccurrence(event, occurrence, &ex), requestLocation(c)))
+}
+
+// CancelEventOccurrence 반복 일정의 한 회차만 취소 (?occurrence_start=, 생성자만)
+func (h *CalendarHandler) CancelEventOccurrence(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+
+	event, occurrence, ok := h.requireSeriesOccurrence(c, claims.UserID, c.Query("occurrence_start"))
+	if !ok {
+		return nil
+	}
+
+	err := h.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "event_id"}, {Name: "occurrence_start"}},
+		DoUpdates: clause.AssignmentColumns([]string{"is_cancelled", "updated_by", "updated_at"}),
+	}).Create(&model.CalendarEventException{
+		EventID:         event.ID,
+		OccurrenceStart: occurrence,
+		IsCancelled:     true,
+		UpdatedBy:       &claims.UserID,
+	}).Error
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to cancel occurrence",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message":          "occurrence cancelled",
+		"event_id":         event.ID,
+		"occurrence_start": occurrence.Format(time.RFC3339),
+	})
+}
+
+// requireSeriesOccurrence 반복 일정 조회 + 수정 권한 확인 + 회차 시각 검증 (실패하면 응답을 쓰고 false)
+func (h *CalendarHandler) requireSeriesOccurrence(c *fiber.Ctx, userID int64, occurrenceStart string) (*model.CalendarEvent, time.Time, bool) {
+	workspaceID, err := c.ParamsInt("workspaceId")
+	if err != nil {
+		c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid workspace id"})
+		return nil, time.Time{}, false
+	}
+	eventID, err := c.ParamsInt("eventId")
+	if err != nil {
+		c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid event id"})
+		return nil, time.Time{}, false
+	}
+	occurrence, err := time.Parse(time.RFC3339, occurrenceStart)
+	if err != nil {
+		c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "occurrence_start must be an RFC3339 time"})
+		return nil, time.Time{}, false
+	}
+
+	var event model.CalendarEvent
+	if err := h.db.Where("id = ? AND workspace_id = ?", eventID, workspaceID).First(&event).Error; err != nil {
+		c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "event not found"})
+		return nil, time.Time{}, false
+	}
+	if _, ok := h.requireEventEditor(c, &event, userID); !ok {
+		return nil, time.Time{}, false
+	}
+	if event.RecurrenceRule == nil {
+		c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "event is not recurring"})
+		return nil, time.Time{}, false
+	}
+
+	rule, err := recurrence.Parse(*event.RecurrenceRule)
+	if err != nil || !rule.Includes(seriesStart(&event), occurrence) {
+		c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "occurrence_start is not an occurrence of this event"})
+		return nil, time.Time{}, false
+	}
+	return &event, occurrence, true
+}
@@ -0,0 +1,293 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm/clause"
+
+	"realtime-backend/internal/auth"
+	"realtime-backend/internal/errorreport"
+	"realtime-backend/internal/model"
+)
+
+const (
+	calendarReminderInterval = 30 * time.Second
+
+	// reminderFireGrace 서버가 멈춰 있어 놓친 알림은 이 시간 안의 것만 늦게라도 발송
+	reminderFireGrace = 10 * time.Minute
+
+	maxRemindersPerEvent = 5
+	maxReminderMinutes   = 7 * 24 * 60 // 최대 1주 전
+)
+
+// parseReminderMinutes 저장된 알림 시각(JSON 배열) 파싱
+func parseReminderMinutes(raw string) []int {
+	minutes := []int{}
+	if raw != "" {
+		if err := json.Unmarshal([]byte(raw), &minutes); err != nil {
+			return []int{}
+		}
+	}
+	return minutes
+}
+
+// normalizeReminders 요청한 알림 시각 검증 후 저장할 JSON (중복 제거, 정렬)
+func normalizeReminders(minutes []int) (string, error) {
+	if len(minutes) > maxRemindersPerEvent {
+		return "", fmt.Errorf("at most %d reminders are allowed", maxRemindersPerEvent)
+	}
+	out := append([]int{}, minutes...)
+	for _, m := range out {
+		if m < 0 || m > maxReminderMinutes {
+			return "", fmt.Errorf("reminder must be between 0 and %d minutes", maxReminderMinutes)
+		}
+	}
+	slices.Sort(out)
+	out = slices.Compact(out)
+	data, err := json.Marshal(out)
+	return string(data), err
+}
+
+// UpdateMyReminders 내 일정 알림 설정 (생성자/참석자만, reminders가 null이면 일정 기본 알림 사용)
+func (h *CalendarHandler) UpdateMyReminders(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	workspaceID, err := c.ParamsInt("workspaceId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid workspace id",
+		})
+	}
+	eventID, err := c.ParamsInt("eventId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid event id",
+		})
+	}
+
+	var req struct {
+		Reminders *[]int `json:"reminders"`
+	}
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+
+	var event model.CalendarEvent
+	if err := h.db.Where("id = ? AND workspace_id = ?", eventID, workspaceID).First(&event).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "event not found",
+		})
+	}
+	if !h.isEventParticipant(&event, claims.UserID) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "you are not an attendee of this event",
+		})
+	}
+
+	if req.Reminders == nil {
+		if err := h.db.Where("event_id = ? AND user_id = ?", event.ID, claims.UserID).Delete(&model.EventReminderPreference{}).Error; err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "failed to update reminders",
+			})
+		}
+		return c.JSON(fiber.Map{
+			"reminders": parseReminderMinutes(event.ReminderMinutes),
+			"custom":    false,
+		})
+	}
+
+	minutes, err := normalizeReminders(*req.Reminders)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	err = h.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "event_id"}, {Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"minutes_before", "updated_at"}),
+	}).Create(&model.EventReminderPreference{
+		EventID:       event.ID,
+		UserID:        claims.UserID,
+		MinutesBefore: minutes,
+	}).Error
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to update reminders",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"reminders": parseReminderMinutes(minutes),
+		"custom":    true,
+	})
+}
+
+// isEventParticipant 일정 생성자이거나 참석자인지
+func (h *CalendarHandler) isEventParticipant(event *model.CalendarEvent, userID int64) bool {
+	if event.CreatorID != nil && *event.CreatorID == userID {
+		return true
+	}
+	var count int64
+	h.db.Model(&model.EventAttendee{}).Where("event_id = ? AND user_id = ?", event.ID, userID).Count(&count)
+	return count > 0
+}
+
+// StartReminders 일정 알림 스케줄러 시작
+func (h *CalendarHandler) StartReminders() {
+	if h.done == nil {
+		h.done = make(chan struct{})
+		go h.runReminders(h.done)
+	}
+}
+
+// Close 알림 스케줄러 중단
+func (h *CalendarHandler) Close() {
+	if h.done != nil {
+		close(h.done)
+		h.done = nil
+	}
+}
+
+func (h *CalendarHandler) runReminders(done <-chan struct{}) {
+	defer errorreport.Recover(errorreport.Context{Component: "calendar.reminders"})
+
+	ticker := time.NewTicker(calendarReminderInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := h.sendDueReminders(time.Now()); err != nil {
+			log.Printf("⚠️ 일정 알림 발송 실패: %v", err)
+		}
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// sendDueReminders 알림 시각(시작 - N분)이 지난 회차의 알림 발송
+// 생성자와 거절하지 않은 참석자에게, 참석자별 설정이 있으면 그 설정으로
+func (h *CalendarHandler) sendDueReminders(now time.Time) error {
+	// 알림 시각이 (now - grace, now]이면 시작 시각은 (now - grace, now + 최대 알림 시간] 안에 있음
+	from, to := now.Add(-reminderFireGrace), now.Add(maxReminderMinutes*time.Minute)
+
+	var events []model.CalendarEvent
+	err := h.db.Preload("Attendees", "status <> ?", "DECLINED").
+		Preload("LinkedMeeting").
+		Where(eventRangeQuery(h.db, from, to, true, true)).
+		Where("(reminder_minutes <> '[]'::jsonb OR EXISTS (SELECT 1 FROM event_reminder_preferences p WHERE p.event_id = calendar_events.id))").
+		Find(&events).Error
+	if err != nil {
+		return err
+	}
+	if len(events) == 0 {
+		return nil
+	}
+
+	eventIDs := make([]int64, len(events))
+	for i, e := range events {
+		eventIDs[i] = e.ID
+	}
+	var prefRows []model.EventReminderPreference
+	if err := h.db.Where("event_id IN ?", eventIDs).Find(&prefRows).Error; err != nil {
+		return err
+	}
+	prefs := make(map[int64]map[int64][]int)
+	for _, p := range prefRows {
+		if prefs[p.EventID] == nil {
+			prefs[p.EventID] = make(map[int64][]int)
+		}
+		prefs[p.EventID][p.UserID] = parseReminderMinutes(p.MinutesBefore)
+	}
+
+	for _, o := range expandOccurrences(h.db, events, from, to) {
+		defaults := parseReminderMinutes(o.event.ReminderMinutes)
+		due := make(map[int][]int64) // 분 → 받을 사용자
+		for _, userID := range reminderRecipients(o.event) {
+			minutes := defaults
+			if pref, ok := prefs[o.event.ID][userID]; ok {
+				minutes = pref
+			}
+			for _, m := range minutes {
+				fireAt := eventInstant(o.event, o.start).Add(-time.Duration(m) * time.Minute)
+				if fireAt.After(now) || !fireAt.After(from) {
+					continue
+				}
+				due[m] = append(due[m], userID)
+			}
+		}
+		for m, userIDs := range due {
+			h.deliverReminder(o, m, userIDs)
+		}
+	}
+
+	// 지난 발송 기록 정리
+	h.db.Where("start_at < ?", now.Add(-24*time.Hour)).Delete(&model.EventReminderDelivery{})
+	return nil
+}
+
+// deliverReminder 발송 기록을 먼저 남기고 새로 기록된 사용자에게만 알림
+func (h *CalendarHandler) deliverReminder(o eventOccurrence, minutes int, userIDs []int64) {
+	var receivers []int64
+	for _, userID := range userIDs {
+		result := h.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&model.EventReminderDelivery{
+			EventID:       o.event.ID,
+			UserID:        userID,
+			StartAt:       o.start,
+			MinutesBefore: minutes,
+		})
+		if result.Error == nil && result.RowsAffected == 1 {
+			receivers = append(receivers, userID)
+		}
+	}
+	if len(receivers) == 0 {
+		return
+	}
+
+	title := o.event.Title
+	if o.exception != nil && o.exception.Title != nil {
+		title = *o.exception.Title
+	}
+	relatedType := "CALENDAR_EVENT"
+	content := fmt.Sprintf("'%s' 일정이 %s 시작합니다.", title, reminderLeadText(minutes))
+	if o.event.LinkedMeeting != nil && o.event.LinkedMeeting.ID != 0 {
+		content += " 회의 참여: " + h.meetingJoinURL(o.event.WorkspaceID, o.event.LinkedMeeting.Code)
+	}
+	if err := createActionNotifications(h.db, receivers, nil, model.NotificationTypeEventReminder.String(), content, &relatedType, &o.event.ID, eventNotificationData(o)); err != nil {
+		log.Printf("⚠️ 일정 알림 생성 실패: event=%d, err=%v", o.event.ID, err)
+	}
+}
+
+// reminderRecipients 생성자 + 거절하지 않은 참석자 (Attendees는 DECLINED 제외하고 로드된 상태)
+func reminderRecipients(e *model.CalendarEvent) []int64 {
+	var userIDs []int64
+	if e.CreatorID != nil {
+		userIDs = append(userIDs, *e.CreatorID)
+	}
+	for _, a := range e.Attendees {
+		if !slices.Contains(userIDs, a.UserID) {
+			userIDs = append(userIDs, a.UserID)
+		}
+	}
+	return userIDs
+}
+
+func reminderLeadText(minutes int) string {
+	switch {
+	case minutes == 0:
+		return "지금"
+	case minutes%(24*60) == 0:
+		return fmt.Sprintf("%d일 후에", minutes/(24*60))
+	case minutes%60 == 0:
+		return fmt.Sprintf("%d시간 후에", minutes/60)
+	default:
+		return fmt.Sprintf("%d분 후에", minutes)
+	}
+}
@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"realtime-backend/internal/model"
+)
+
+// defaultEventTimeZone 요청에 타임존이 없을 때 일정 타임존 (서버 배포 TZ와 같음)
+const defaultEventTimeZone = "Asia/Seoul"
+
+// resolveTimeZone IANA 타임존 이름 검증 ("UTC", "Asia/Seoul" 등)
+func resolveTimeZone(name string) (*time.Location, error) {
+	if name == "" || name == "Local" {
+		return nil, errors.New("time zone is required")
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, errors.New("unknown time zone: " + name)
+	}
+	return loc, nil
+}
+
+// requestLocation 요청자 타임존 (?tz= 또는 X-Timezone 헤더, 없거나 잘못되면 nil)
+func requestLocation(c *fiber.Ctx) *time.Location {
+	name := c.Query("tz")
+	if name == "" {
+		name = c.Get("X-Timezone")
+	}
+	loc, err := resolveTimeZone(name)
+	if err != nil {
+		return nil
+	}
+	return loc
+}
+
+// eventLocation 일정 타임존 (저장된 값이 잘못됐으면 UTC)
+func eventLocation(e *model.CalendarEvent) *time.Location {
+	loc, err := resolveTimeZone(e.TimeZone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// seriesStart 반복 계산 기준 시각
+// 시간 일정은 일정 타임존 기준 (서머타임이 바뀌어도 현지 시각 유지), 종일 일정은 UTC 0시 날짜 기준
+func seriesStart(e *model.CalendarEvent) time.Time {
+	if e.IsAllDay {
+		return e.StartAt.UTC()
+	}
+	return e.StartAt.In(eventLocation(e))
+}
+
+// parseEventTime RFC3339 시각 파싱 (종일 일정은 YYYY-MM-DD도 허용하고 적힌 날짜만 사용)
+func parseEventTime(value string, allDay bool) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil && allDay {
+		t, err = time.Parse("2006-01-02", value)
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+	if allDay {
+		return floatingDate(t), nil
+	}
+	return t, nil
+}
+
+// floatingDate 종일 일정 저장 형식 (요청에 적힌 날짜의 UTC 0시, 보는 사람 타임존과 무관하게 같은 날짜)
+func floatingDate(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// formatEventTime 응답용 시각 (종일 일정은 날짜가 밀리지 않도록 loc 기준 같은 날짜 0시)
+func formatEventTime(t time.Time, allDay bool, loc *time.Location) string {
+	if allDay {
+		y, m, d := t.UTC().Date()
+		return time.Date(y, m, d, 0, 0, 0, 0, loc).Format(time.RFC3339)
+	}
+	return t.In(loc).Format(time.RFC3339)
+}
+
+// eventInstant 실제 시작 시각 (종일 일정은 일정 타임존의 그날 0시, 알림 발송용)
+func eventInstant(e *model.CalendarEvent, t time.Time) time.Time {
+	if !e.IsAllDay {
+		return t
+	}
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, eventLocation(e))
+}
@@ -0,0 +1,181 @@
+package handler
+
+import (
+	"slices"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+
+	"realtime-backend/internal/auth"
+	"realtime-backend/internal/model"
+)
+
+// ChannelOverrideRequest 채널 권한 오버라이드 항목
+type ChannelOverrideRequest struct {
+	TargetType     string `json:"target_type"` // ROLE, USER
+	TargetID       int64  `json:"target_id"`
+	PermissionCode string `json:"permission_code"`
+	Effect         string `json:"effect"` // ALLOW, DENY
+}
+
+// ChannelOverrideResponse 채널 권한 오버라이드 응답
+type ChannelOverrideResponse struct {
+	ID             int64  `json:"id"`
+	TargetType     string `json:"target_type"`
+	TargetID       int64  `json:"target_id"`
+	PermissionCode string `json:"permission_code"`
+	Effect         string `json:"effect"`
+}
+
+// GetChannelOverrides 채팅방/회의 권한 오버라이드 목록 (MANAGE_ROLES)
+func (h *RoleHandler) GetChannelOverrides(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	channel, ok := h.requireChannelManager(c, claims.UserID)
+	if !ok {
+		return nil
+	}
+
+	var overrides []model.ChannelPermissionOverride
+	if err := h.db.Where("meeting_id = ?", channel.ID).
+		Order("target_type ASC, target_id ASC, permission_code ASC").
+		Find(&overrides).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get permission overrides"})
+	}
+
+	return c.JSON(fiber.Map{
+		"channel_id":       channel.ID,
+		"overrides":        toChannelOverrideResponses(overrides),
+		"permission_codes": model.ChannelPermissionCodes,
+	})
+}
+
+// UpdateChannelOverrides 채팅방/회의 권한 오버라이드 전체 교체 (MANAGE_ROLES)
+func (h *RoleHandler) UpdateChannelOverrides(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	channel, ok := h.requireChannelManager(c, claims.UserID)
+	if !ok {
+		return nil
+	}
+
+	var req struct {
+		Overrides []ChannelOverrideRequest `json:"overrides"`
+	}
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid request body"})
+	}
+
+	workspaceID := *channel.WorkspaceID
+	overrides := make([]model.ChannelPermissionOverride, 0, len(req.Overrides))
+	index := make(map[ChannelOverrideRequest]int)
+	for _, o := range req.Overrides {
+		if !slices.Contains(model.ChannelPermissionCodes, o.PermissionCode) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "permission cannot be overridden per channel: " + o.PermissionCode})
+		}
+		if o.Effect != model.OverrideEffectAllow && o.Effect != model.OverrideEffectDeny {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "effect must be ALLOW or DENY"})
+		}
+
+		var count int64
+		switch o.TargetType {
+		case model.OverrideTargetRole:
+			h.db.Model(&model.Role{}).Where("id = ? AND workspace_id = ?", o.TargetID, workspaceID).Count(&count)
+		case model.OverrideTargetUser:
+			h.db.Model(&model.WorkspaceMember{}).Where("workspace_id = ? AND user_id = ?", workspaceID, o.TargetID).Count(&count)
+		default:
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "target_type must be ROLE or USER"})
+		}
+		if count == 0 {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "override target not found in this workspace"})
+		}
+
+		// 같은 대상/권한이 여러 번 오면 마지막 값만 사용
+		key := ChannelOverrideRequest{TargetType: o.TargetType, TargetID: o.TargetID, PermissionCode: o.PermissionCode}
+		if i, ok := index[key]; ok {
+			overrides[i].Effect = o.Effect
+			continue
+		}
+		index[key] = len(overrides)
+		overrides = append(overrides, model.ChannelPermissionOverride{
+			WorkspaceID:    workspaceID,
+			MeetingID:      channel.ID,
+			TargetType:     o.TargetType,
+			TargetID:       o.TargetID,
+			PermissionCode: o.PermissionCode,
+			Effect:         o.Effect,
+			UpdatedBy:      &claims.UserID,
+		})
+	}
+
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("meeting_id = ?", channel.ID).Delete(&model.ChannelPermissionOverride{}).Error; err != nil {
+			return err
+		}
+		if len(overrides) == 0 {
+			return nil
+		}
+		return tx.Create(&overrides).Error
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to update permission overrides"})
+	}
+	auth.InvalidateChannelPermissions(workspaceID, channel.ID)
+
+	recordAuditEvent(h.db, workspaceID, &claims.UserID, model.AuditChannelOverridesUpdated, model.AuditTargetRoom, channel.ID, map[string]interface{}{
+		"override_count": len(overrides),
+	})
+
+	return c.JSON(fiber.Map{
+		"channel_id": channel.ID,
+		"overrides":  toChannelOverrideResponses(overrides),
+	})
+}
+
+// requireChannelManager 채팅방/회의 조회 + MANAGE_ROLES 권한 확인 (실패하면 응답을 쓰고 false)
+// chatrooms/:roomId, meetings/:meetingId 두 경로에서 함께 사용
+func (h *RoleHandler) requireChannelManager(c *fiber.Ctx, userID int64) (*model.Meeting, bool) {
+	workspaceID, err := c.ParamsInt("workspaceId")
+	if err != nil {
+		c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid workspace id"})
+		return nil, false
+	}
+	channelID, err := c.ParamsInt("roomId")
+	if err != nil {
+		channelID, err = c.ParamsInt("meetingId")
+	}
+	if err != nil {
+		c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid channel id"})
+		return nil, false
+	}
+
+	hasPermission, err := auth.CheckPermission(h.db, int64(workspaceID), userID, "MANAGE_ROLES")
+	if err != nil {
+		c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to check permission"})
+		return nil, false
+	}
+	if !hasPermission {
+		c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "you do not have permission to manage roles"})
+		return nil, false
+	}
+
+	var channel model.Meeting
+	if err := h.db.Where("id = ? AND workspace_id = ? AND type <> ?", channelID, workspaceID, model.MeetingTypeDM.String()).
+		First(&channel).Error; err != nil {
+		c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "channel not found"})
+		return nil, false
+	}
+	return &channel, true
+}
+
+func toChannelOverrideResponses(overrides []model.ChannelPermissionOverride) []ChannelOverrideResponse {
+	responses := make([]ChannelOverrideResponse, len(overrides))
+	for i, o := range overrides {
+		responses[i] = ChannelOverrideResponse{
+			ID:             o.ID,
+			TargetType:     o.TargetType,
+			TargetID:       o.TargetID,
+			PermissionCode: o.PermissionCode,
+			Effect:         o.Effect,
+		}
+	}
+	return responses
+}
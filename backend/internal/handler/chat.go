@@ -194,6 +194,7 @@ func (h *ChatHandler) SendMessage(c *fiber.Ctx) error {
 
 	// Sender 정보 로드
 	h.db.Preload("Sender").First(&chatLog, chatLog.ID)
+	notifyRoleMentions(h.db, int64(workspaceID), meeting.ID, claims.UserID, claims.Nickname, req.Message)
 
 	return c.Status(fiber.StatusCreated).JSON(h.toChatLogResponse(&chatLog))
 }
@@ -469,8 +470,8 @@ func (h *ChatHandler) SendChatRoomMessage(c *fiber.Ctx) error {
 		})
 	}
 
-	// 권한 확인
-	hasPermission, err := auth.CheckPermission(h.db, int64(workspaceID), claims.UserID, "SEND_MESSAGES")
+	// 권한 확인 (채팅방 오버라이드 적용)
+	hasPermission, err := auth.CheckChannelPermission(h.db, int64(workspaceID), int64(roomID), claims.UserID, "SEND_MESSAGES")
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to check permission"})
 	}
@@ -526,6 +527,8 @@ func (h *ChatHandler) SendChatRoomMessage(c *fiber.Ctx) error {
 
 	// Sender 정보 로드
 	h.db.Preload("Sender").First(&chatLog, chatLog.ID)
+	notifyRoleMentions(h.db, int64(workspaceID), room.ID, claims.UserID, claims.Nickname, req.Message)
+	go pushDirectMessage(h.db, room.ID, claims.UserID, claims.Nickname, req.Message)
 
 	return c.Status(fiber.StatusCreated).JSON(h.toChatLogResponse(&chatLog))
 }
@@ -647,12 +650,23 @@ func (h *ChatHandler) DeleteChatRoom(c *fiber.Ctx) error {
 		})
 	}
 
+	// 채팅방 권한 오버라이드 삭제
+	if err := h.db.Where("meeting_id = ?", room.ID).Delete(&model.ChannelPermissionOverride{}).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to delete chat room",
+		})
+	}
+
 	// 채팅방 삭제
 	if err := h.db.Delete(&room).Error; err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "failed to delete chat room",
 		})
 	}
+	auth.InvalidateChannelPermissions(int64(workspaceID), room.ID)
+	recordAuditEvent(h.db, int64(workspaceID), &claims.UserID, model.AuditRoomDeleted, model.AuditTargetRoom, room.ID, map[string]interface{}{
+		"title": room.Title,
+	})
 
 	return c.JSON(fiber.Map{
 		"message": "chat room deleted successfully",
@@ -9,6 +9,8 @@ import (
 	"github.com/gofiber/contrib/websocket"
 	"gorm.io/gorm"
 
+	"realtime-backend/internal/auth"
+	"realtime-backend/internal/errorreport"
 	"realtime-backend/internal/model"
 )
 
@@ -17,6 +19,8 @@ type ChatWSHandler struct {
 	db    *gorm.DB
 	rooms map[int64]*ChatRoom // roomId -> ChatRoom
 	mu    sync.RWMutex
+
+	messagesPerSecond int // 연결당 초당 메시지 수 제한 (0 = 무제한)
 }
 
 // ChatRoom 채팅방
@@ -28,10 +32,10 @@ type ChatRoom struct {
 // ChatClient 채팅 클라이언트
 type ChatClient struct {
 	UserID      int64
+	WorkspaceID int64
 	Nickname    string
 	Conn        *websocket.Conn
-	Permissions []string
-	IsOwner     bool
+	CanSend     bool // 연결 시점의 SEND_MESSAGES 권한 (채팅방 오버라이드 반영)
 }
 
 // WSMessage WebSocket 메시지
@@ -63,6 +67,11 @@ func NewChatWSHandler(db *gorm.DB) *ChatWSHandler {
 	}
 }
 
+// SetMessageRateLimit 연결당 초당 메시지 수 제한 설정 (초과 시 4008로 종료)
+func (h *ChatWSHandler) SetMessageRateLimit(perSecond int) {
+	h.messagesPerSecond = perSecond
+}
+
 // getOrCreateRoom 채팅방 조회 또는 생성
 func (h *ChatWSHandler) getOrCreateRoom(roomID int64) *ChatRoom {
 	h.mu.Lock()
@@ -85,7 +94,7 @@ func (h *ChatWSHandler) HandleWebSocket(c *websocket.Conn) {
 	// 패닉 복구 - 서버 크래시 방지
 	defer func() {
 		if r := recover(); r != nil {
-			log.Printf("채팅 WebSocket 패닉 복구: %v", r)
+			errorreport.CapturePanic(r, wsReportContext(c, "ws.chat"))
 		}
 	}()
 
@@ -101,31 +110,24 @@ func (h *ChatWSHandler) HandleWebSocket(c *websocket.Conn) {
 
 	if !ok1 || !ok2 || !ok3 || !ok4 {
 		c.WriteMessage(websocket.TextMessage, []byte(`{"type":"error","message":"invalid session"}`))
-		c.Close()
+		closeWS(c, WSCloseInternalError, "invalid session")
 		return
 	}
 
-	// 권한 확인
-	var permissions []string
-	h.db.Table("role_permissions").
-		Joins("JOIN workspace_members ON workspace_members.role_id = role_permissions.role_id").
-		Where("workspace_members.workspace_id = ? AND workspace_members.user_id = ?", workspaceID, userID).
-		Pluck("permission_code", &permissions)
-
-	var isOwner bool
-	var workspace model.Workspace
-	if err := h.db.First(&workspace, workspaceID).Error; err == nil && workspace.OwnerID == userID {
-		isOwner = true
+	// 권한 확인 (소유자/ADMIN 포함, 채팅방 오버라이드 적용)
+	canSend, err := auth.CheckChannelPermission(h.db, workspaceID, roomID, userID, model.PermissionSendMessages)
+	if err != nil {
+		log.Printf("채팅 권한 확인 실패: room=%d, user=%d, err=%v", roomID, userID, err)
 	}
 
 	room := h.getOrCreateRoom(roomID)
 
 	client := &ChatClient{
 		UserID:      userID,
+		WorkspaceID: workspaceID,
 		Nickname:    nickname,
 		Conn:        c,
-		Permissions: permissions,
-		IsOwner:     isOwner,
+		CanSend:     canSend,
 	}
 
 	// 클라이언트 등록
@@ -144,6 +146,8 @@ func (h *ChatWSHandler) HandleWebSocket(c *websocket.Conn) {
 		log.Printf("채팅 클라이언트 연결 해제: room=%d, user=%d", roomID, userID)
 	}()
 
+	limiter := newWSRateLimiter(h.messagesPerSecond)
+
 	// 메시지 수신 루프
 	for {
 		_, msgBytes, err := c.ReadMessage()
@@ -151,6 +155,12 @@ func (h *ChatWSHandler) HandleWebSocket(c *websocket.Conn) {
 			break
 		}
 
+		if !limiter.allow() {
+			log.Printf("채팅 메시지 속도 초과로 연결 종료: room=%d, user=%d", roomID, userID)
+			closeWS(c, WSCloseRateLimited, "too many messages")
+			break
+		}
+
 		var msg WSMessage
 		if err := json.Unmarshal(msgBytes, &msg); err != nil {
 			continue
@@ -159,17 +169,7 @@ func (h *ChatWSHandler) HandleWebSocket(c *websocket.Conn) {
 		switch msg.Type {
 		case "message":
 			// 권한 체크
-			canSend := client.IsOwner
-			if !canSend {
-				for _, p := range client.Permissions {
-					if p == "SEND_MESSAGES" {
-						canSend = true
-						break
-					}
-				}
-			}
-
-			if canSend {
+			if client.CanSend {
 				h.handleMessage(room, client, roomID, msg.Payload)
 			} else {
 				c.WriteMessage(websocket.TextMessage, []byte(`{"type":"error","message":"no permission to send messages"}`))
@@ -225,6 +225,8 @@ func (h *ChatWSHandler) handleMessage(room *ChatRoom, client *ChatClient, roomID
 	}
 
 	h.broadcast(room, broadcastMsg)
+	notifyRoleMentions(h.db, client.WorkspaceID, roomID, client.UserID, client.Nickname, message)
+	go pushDirectMessage(h.db, roomID, client.UserID, client.Nickname, message)
 }
 
 // broadcastTyping 타이핑 상태 브로드캐스트
@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"realtime-backend/internal/presence"
+)
+
+// DiagnosticsHandler 운영 진단용 엔드포인트 (X-Probe-Token 필요)
+type DiagnosticsHandler struct {
+	roomHub  *RoomHub
+	serverID string
+	presence *presence.Manager
+}
+
+// NewDiagnosticsHandler DiagnosticsHandler 생성
+func NewDiagnosticsHandler(roomHub *RoomHub, serverID string, pm *presence.Manager) *DiagnosticsHandler {
+	return &DiagnosticsHandler{roomHub: roomHub, serverID: serverID, presence: pm}
+}
+
+// AIBackendsResponse Room별 AI 백엔드/회로 차단기 상태 응답
+type AIBackendsResponse struct {
+	ServerID        string              `json:"server_id"` // 응답한 인스턴스 (로드밸런서 뒤에서 어느 인스턴스 상태인지)
+	FallbackEnabled bool                `json:"fallback_enabled"`
+	Preferred       string              `json:"preferred"`
+	Rooms           []RoomBackendStatus `json:"rooms"`
+	CheckedAt       string              `json:"checked_at"`
+}
+
+// AIBackends 이 인스턴스의 Room별 AI 백엔드 상태 조회 (?room_id=로 필터)
+func (h *DiagnosticsHandler) AIBackends(c *fiber.Ctx) error {
+	if h.roomHub == nil {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+			"error": "room hub is not available",
+		})
+	}
+
+	rooms := h.roomHub.BackendStatuses()
+	if roomID := c.Query("room_id"); roomID != "" {
+		filtered := make([]RoomBackendStatus, 0, 1)
+		for _, room := range rooms {
+			if room.RoomID == roomID {
+				filtered = append(filtered, room)
+			}
+		}
+		rooms = filtered
+	}
+
+	return c.JSON(AIBackendsResponse{
+		ServerID:        h.serverID,
+		FallbackEnabled: h.roomHub.fallbackEnabled(),
+		Preferred:       string(h.roomHub.preferredBackend()),
+		Rooms:           rooms,
+		CheckedAt:       time.Now().Format("2006-01-02T15:04:05Z07:00"),
+	})
+}
+
+// PresenceDiagnosticsResponse 인스턴스별 presence 세션 현황 응답
+type PresenceDiagnosticsResponse struct {
+	ServerID  string                  `json:"server_id"`
+	Servers   []presence.ServerStatus `json:"servers"` // alive=false면 다음 검사 때 세션이 정리됨
+	CheckedAt string                  `json:"checked_at"`
+}
+
+// Presence 인스턴스별 presence 세션 현황 (종료된 인스턴스가 남긴 세션 확인용)
+func (h *DiagnosticsHandler) Presence(c *fiber.Ctx) error {
+	if h.presence == nil {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+			"error": "presence is not available",
+		})
+	}
+
+	servers, err := h.presence.Servers()
+	if err != nil {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+			"error": "failed to read presence servers",
+		})
+	}
+
+	return c.JSON(PresenceDiagnosticsResponse{
+		ServerID:  h.serverID,
+		Servers:   servers,
+		CheckedAt: time.Now().Format("2006-01-02T15:04:05Z07:00"),
+	})
+}
@@ -0,0 +1,492 @@
+package handler
+
+import (
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"realtime-backend/internal/auth"
+	"realtime-backend/internal/errorreport"
+	"realtime-backend/internal/model"
+)
+
+const (
+	fileAccessFlushInterval = 30 * time.Second
+	fileAccessMaxPending    = 1000 // 이 이상 쌓이면 주기를 기다리지 않고 바로 저장
+	recentFilesDefaultLimit = 20
+	recentFilesMaxLimit     = 100
+)
+
+// fileAccessKey 사용자-파일 쌍
+type fileAccessKey struct {
+	userID int64
+	fileID int64
+}
+
+// pendingFileAccess 아직 저장되지 않은 열람 기록
+type pendingFileAccess struct {
+	workspaceID int64
+	count       int64
+	lastAt      time.Time
+}
+
+// fileAccessTracker 파일 열람 기록을 메모리에 모았다가 주기적으로 한 번에 upsert
+// 다운로드마다 DB 쓰기가 발생하지 않도록 같은 사용자-파일 쌍은 횟수만 합산
+type fileAccessTracker struct {
+	db      *gorm.DB
+	mu      sync.Mutex
+	pending map[fileAccessKey]*pendingFileAccess
+	flushCh chan struct{}
+	done    chan struct{}
+	stopped chan struct{}
+	once    sync.Once
+}
+
+func newFileAccessTracker(db *gorm.DB) *fileAccessTracker {
+	t := &fileAccessTracker{
+		db:      db,
+		pending: make(map[fileAccessKey]*pendingFileAccess),
+		flushCh: make(chan struct{}, 1),
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	go t.run()
+	return t
+}
+
+// Record 열람 기록 추가 (DB 쓰기 없음)
+func (t *fileAccessTracker) Record(userID, workspaceID, fileID int64) {
+	t.mu.Lock()
+	key := fileAccessKey{userID: userID, fileID: fileID}
+	p, ok := t.pending[key]
+	if !ok {
+		p = &pendingFileAccess{workspaceID: workspaceID}
+		t.pending[key] = p
+	}
+	p.count++
+	p.lastAt = time.Now()
+	full := len(t.pending) >= fileAccessMaxPending
+	t.mu.Unlock()
+
+	if full {
+		select {
+		case t.flushCh <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// pendingFor 아직 저장되지 않은 사용자의 워크스페이스 열람 기록 (최근 파일 조회에 합산)
+func (t *fileAccessTracker) pendingFor(userID, workspaceID int64) map[int64]pendingFileAccess {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	result := make(map[int64]pendingFileAccess)
+	for key, p := range t.pending {
+		if key.userID == userID && p.workspaceID == workspaceID {
+			result[key.fileID] = *p
+		}
+	}
+	return result
+}
+
+// Close 남은 기록을 저장하고 종료
+func (t *fileAccessTracker) Close() {
+	t.once.Do(func() {
+		close(t.done)
+		<-t.stopped
+	})
+}
+
+func (t *fileAccessTracker) run() {
+	defer close(t.stopped)
+	defer errorreport.Recover(errorreport.Context{Component: "storage.file_access_tracker"})
+
+	ticker := time.NewTicker(fileAccessFlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-t.done:
+			t.flush()
+			return
+		case <-ticker.C:
+			t.flush()
+		case <-t.flushCh:
+			t.flush()
+		}
+	}
+}
+
+func (t *fileAccessTracker) flush() {
+	t.mu.Lock()
+	if len(t.pending) == 0 {
+		t.mu.Unlock()
+		return
+	}
+	pending := t.pending
+	t.pending = make(map[fileAccessKey]*pendingFileAccess)
+	t.mu.Unlock()
+
+	rows := make([]model.FileAccess, 0, len(pending))
+	for key, p := range pending {
+		rows = append(rows, model.FileAccess{
+			UserID:         key.userID,
+			FileID:         key.fileID,
+			WorkspaceID:    p.workspaceID,
+			AccessCount:    p.count,
+			LastAccessedAt: p.lastAt,
+		})
+	}
+
+	err := t.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "user_id"}, {Name: "file_id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"access_count":     gorm.Expr("file_accesses.access_count + excluded.access_count"),
+			"last_accessed_at": gorm.Expr("GREATEST(file_accesses.last_accessed_at, excluded.last_accessed_at)"),
+		}),
+	}).CreateInBatches(&rows, 200).Error
+	if err != nil {
+		log.Printf("[FileAccess] Failed to save %d file accesses: %v", len(rows), err)
+		return
+	}
+	log.Printf("[FileAccess] Saved %d file accesses", len(rows))
+}
+
+// =============================================================================
+// Recent / starred files
+// =============================================================================
+
+// RecentFileResponse 최근 열람 파일 응답
+type RecentFileResponse struct {
+	FileResponse
+	LastAccessedAt string `json:"last_accessed_at"`
+	AccessCount    int64  `json:"access_count"`
+}
+
+// StorageHomeResponse 스토리지 홈 화면 응답 (최근 파일 + 즐겨찾기)
+type StorageHomeResponse struct {
+	Recent  []RecentFileResponse `json:"recent"`
+	Starred []FileResponse       `json:"starred"`
+}
+
+// Close 대기 중인 열람 기록 저장 + 휴지통 정리 중단
+func (h *StorageHandler) Close() {
+	if h.accesses != nil {
+		h.accesses.Close()
+	}
+	if h.trashDone != nil {
+		close(h.trashDone)
+	}
+}
+
+// recordAccess 파일 열람 기록 (일괄 저장)
+func (h *StorageHandler) recordAccess(userID int64, file *model.WorkspaceFile) {
+	if h.accesses != nil && file.Type == "FILE" {
+		h.accesses.Record(userID, file.WorkspaceID, file.ID)
+	}
+}
+
+// GetRecentFiles 최근 열람한 파일 목록
+func (h *StorageHandler) GetRecentFiles(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	workspaceID, err := c.ParamsInt("workspaceId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid workspace id",
+		})
+	}
+
+	if !h.isWorkspaceMember(int64(workspaceID), claims.UserID) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "you are not a member of this workspace",
+		})
+	}
+
+	limit := c.QueryInt("limit", recentFilesDefaultLimit)
+	if limit <= 0 || limit > recentFilesMaxLimit {
+		limit = recentFilesDefaultLimit
+	}
+
+	recent, err := h.recentFiles(claims.UserID, int64(workspaceID), limit)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get recent files",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"files": recent,
+	})
+}
+
+// GetStarredFiles 즐겨찾기한 파일/폴더 목록
+func (h *StorageHandler) GetStarredFiles(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	workspaceID, err := c.ParamsInt("workspaceId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid workspace id",
+		})
+	}
+
+	if !h.isWorkspaceMember(int64(workspaceID), claims.UserID) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "you are not a member of this workspace",
+		})
+	}
+
+	starred, err := h.starredFiles(claims.UserID, int64(workspaceID))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get starred files",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"files": starred,
+	})
+}
+
+// GetStorageHome 스토리지 홈 화면 (최근 파일 + 즐겨찾기)
+func (h *StorageHandler) GetStorageHome(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	workspaceID, err := c.ParamsInt("workspaceId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid workspace id",
+		})
+	}
+
+	if !h.isWorkspaceMember(int64(workspaceID), claims.UserID) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "you are not a member of this workspace",
+		})
+	}
+
+	recent, err := h.recentFiles(claims.UserID, int64(workspaceID), recentFilesDefaultLimit)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get recent files",
+		})
+	}
+	starred, err := h.starredFiles(claims.UserID, int64(workspaceID))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get starred files",
+		})
+	}
+
+	return c.JSON(StorageHomeResponse{
+		Recent:  recent,
+		Starred: starred,
+	})
+}
+
+// StarFile 파일/폴더 즐겨찾기 추가
+func (h *StorageHandler) StarFile(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	workspaceID, err := c.ParamsInt("workspaceId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid workspace id",
+		})
+	}
+	fileID, err := c.ParamsInt("fileId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid file id",
+		})
+	}
+
+	if !h.isWorkspaceMember(int64(workspaceID), claims.UserID) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "you are not a member of this workspace",
+		})
+	}
+
+	var file model.WorkspaceFile
+	if err := h.db.Where("id = ? AND workspace_id = ?", fileID, workspaceID).First(&file).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "file not found",
+		})
+	}
+
+	access := h.requireFolderAccess(c, int64(workspaceID), claims.UserID)
+	if access == nil {
+		return nil
+	}
+	if !access.fileAllowed(&file) {
+		return folderForbidden(c)
+	}
+
+	star := model.FileStar{
+		UserID:      claims.UserID,
+		FileID:      int64(fileID),
+		WorkspaceID: int64(workspaceID),
+	}
+	if err := h.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&star).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to star file",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "file starred",
+	})
+}
+
+// UnstarFile 파일/폴더 즐겨찾기 해제
+func (h *StorageHandler) UnstarFile(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	workspaceID, err := c.ParamsInt("workspaceId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid workspace id",
+		})
+	}
+	fileID, err := c.ParamsInt("fileId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid file id",
+		})
+	}
+
+	if err := h.db.Where("user_id = ? AND file_id = ? AND workspace_id = ?", claims.UserID, fileID, workspaceID).
+		Delete(&model.FileStar{}).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to unstar file",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "file unstarred",
+	})
+}
+
+// recentFiles 저장된 열람 기록과 아직 저장되지 않은 기록을 합쳐 최근 순으로 반환
+func (h *StorageHandler) recentFiles(userID, workspaceID int64, limit int) ([]RecentFileResponse, error) {
+	var accesses []model.FileAccess
+	if err := h.db.Where("user_id = ? AND workspace_id = ?", userID, workspaceID).
+		Order("last_accessed_at DESC").
+		Limit(limit).
+		Find(&accesses).Error; err != nil {
+		return nil, err
+	}
+
+	merged := make(map[int64]model.FileAccess, len(accesses))
+	for _, a := range accesses {
+		merged[a.FileID] = a
+	}
+	if h.accesses != nil {
+		for fileID, p := range h.accesses.pendingFor(userID, workspaceID) {
+			a, ok := merged[fileID]
+			if !ok {
+				a = model.FileAccess{UserID: userID, FileID: fileID, WorkspaceID: workspaceID}
+			}
+			a.AccessCount += p.count
+			if p.lastAt.After(a.LastAccessedAt) {
+				a.LastAccessedAt = p.lastAt
+			}
+			merged[fileID] = a
+		}
+	}
+
+	accesses = accesses[:0]
+	for _, a := range merged {
+		accesses = append(accesses, a)
+	}
+	sort.Slice(accesses, func(i, j int) bool {
+		return accesses[i].LastAccessedAt.After(accesses[j].LastAccessedAt)
+	})
+	if len(accesses) > limit {
+		accesses = accesses[:limit]
+	}
+
+	fileIDs := make([]int64, 0, len(accesses))
+	for _, a := range accesses {
+		fileIDs = append(fileIDs, a.FileID)
+	}
+	files, err := h.filesByID(userID, workspaceID, fileIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	recent := make([]RecentFileResponse, 0, len(accesses))
+	for _, a := range accesses {
+		file, ok := files[a.FileID]
+		if !ok {
+			continue // 삭제된 파일
+		}
+		recent = append(recent, RecentFileResponse{
+			FileResponse:   h.toFileResponse(file),
+			LastAccessedAt: a.LastAccessedAt.Format("2006-01-02T15:04:05Z07:00"),
+			AccessCount:    a.AccessCount,
+		})
+	}
+	return recent, nil
+}
+
+// starredFiles 즐겨찾기 목록 (최근에 추가한 순)
+func (h *StorageHandler) starredFiles(userID, workspaceID int64) ([]FileResponse, error) {
+	var stars []model.FileStar
+	if err := h.db.Where("user_id = ? AND workspace_id = ?", userID, workspaceID).
+		Order("created_at DESC").
+		Find(&stars).Error; err != nil {
+		return nil, err
+	}
+
+	fileIDs := make([]int64, 0, len(stars))
+	for _, s := range stars {
+		fileIDs = append(fileIDs, s.FileID)
+	}
+	files, err := h.filesByID(userID, workspaceID, fileIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	starred := make([]FileResponse, 0, len(stars))
+	for _, s := range stars {
+		if file, ok := files[s.FileID]; ok {
+			starred = append(starred, h.toFileResponse(file))
+		}
+	}
+	return starred, nil
+}
+
+// filesByID 파일 조회 (삭제됐거나 사용자가 접근할 수 없는 제한 폴더 안의 파일은 제외)
+func (h *StorageHandler) filesByID(userID, workspaceID int64, fileIDs []int64) (map[int64]*model.WorkspaceFile, error) {
+	files := make(map[int64]*model.WorkspaceFile, len(fileIDs))
+	if len(fileIDs) == 0 {
+		return files, nil
+	}
+
+	var rows []model.WorkspaceFile
+	if err := h.db.Preload("Uploader").
+		Where("workspace_id = ? AND id IN ?", workspaceID, fileIDs).
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	access, err := h.loadFolderAccess(workspaceID, userID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range rows {
+		if access.fileAllowed(&rows[i]) {
+			files[rows[i].ID] = &rows[i]
+		}
+	}
+	return files, nil
+}
+
+// deleteFileActivityWithTx 삭제되는 파일의 열람 기록/즐겨찾기 정리
+func deleteFileActivityWithTx(tx *gorm.DB, fileIDs []int64) {
+	tx.Where("file_id IN ?", fileIDs).Delete(&model.FileAccess{})
+	tx.Where("file_id IN ?", fileIDs).Delete(&model.FileStar{})
+}
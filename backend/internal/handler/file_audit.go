@@ -0,0 +1,302 @@
+package handler
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm/clause"
+
+	"realtime-backend/internal/auth"
+	"realtime-backend/internal/model"
+)
+
+const (
+	fileActivityDefaultLimit = 50
+	fileActivityMaxLimit     = 200
+)
+
+// FileActivityResponse 파일 활동 기록 응답
+type FileActivityResponse struct {
+	ID        int64         `json:"id"`
+	FileID    int64         `json:"file_id"`
+	FolderID  *int64        `json:"folder_id,omitempty"`
+	ActorID   *int64        `json:"actor_id,omitempty"`
+	Actor     *UserResponse `json:"actor,omitempty"`
+	Action    string        `json:"action"`
+	FileName  string        `json:"file_name"`
+	FileType  string        `json:"file_type"`
+	Detail    *string       `json:"detail,omitempty"`
+	CreatedAt string        `json:"created_at"`
+}
+
+// UpdateFileActivitySettingsRequest 파일 활동 채팅방 알림 설정 요청
+type UpdateFileActivitySettingsRequest struct {
+	ChatRoomID       *int64 `json:"chat_room_id"` // null이면 알림 끔
+	IncludeDownloads bool   `json:"include_downloads"`
+}
+
+// GetFileActivities 워크스페이스 파일 활동 목록 (최신순, file_id/action/actor_id 필터)
+// 접근할 수 없는 폴더 안에서 일어난 활동은 제외
+func (h *StorageHandler) GetFileActivities(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	workspaceID, err := c.ParamsInt("workspaceId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid workspace id",
+		})
+	}
+
+	if !h.isWorkspaceMember(int64(workspaceID), claims.UserID) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "you are not a member of this workspace",
+		})
+	}
+
+	access := h.requireFolderAccess(c, int64(workspaceID), claims.UserID)
+	if access == nil {
+		return nil
+	}
+
+	limit := c.QueryInt("limit", fileActivityDefaultLimit)
+	if limit <= 0 || limit > fileActivityMaxLimit {
+		limit = fileActivityDefaultLimit
+	}
+	offset := c.QueryInt("offset", 0)
+	if offset < 0 {
+		offset = 0
+	}
+
+	query := h.db.Model(&model.FileActivity{}).Where("workspace_id = ?", workspaceID)
+	if fileID := c.QueryInt("file_id", 0); fileID > 0 {
+		query = query.Where("file_id = ?", fileID)
+	}
+	if action := c.Query("action"); action != "" {
+		query = query.Where("action = ?", action)
+	}
+	if actorID := c.QueryInt("actor_id", 0); actorID > 0 {
+		query = query.Where("actor_id = ?", actorID)
+	}
+
+	var denied []int64
+	for folderID := range access.parents {
+		if !access.folderAllowed(folderID) {
+			denied = append(denied, folderID)
+		}
+	}
+	if len(denied) > 0 {
+		query = query.Where("(folder_id IS NULL OR folder_id NOT IN ?) AND file_id NOT IN ?", denied, denied)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to count file activities",
+		})
+	}
+
+	var activities []model.FileActivity
+	err = query.Preload("Actor").
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&activities).Error
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get file activities",
+		})
+	}
+
+	responses := make([]FileActivityResponse, len(activities))
+	for i := range activities {
+		responses[i] = toFileActivityResponse(&activities[i])
+	}
+
+	return c.JSON(fiber.Map{
+		"activities": responses,
+		"total":      total,
+		"has_more":   int64(offset+len(activities)) < total,
+	})
+}
+
+// GetFileActivitySettings 파일 활동 채팅방 알림 설정 조회
+func (h *StorageHandler) GetFileActivitySettings(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	workspaceID, err := c.ParamsInt("workspaceId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid workspace id",
+		})
+	}
+
+	if !h.isWorkspaceMember(int64(workspaceID), claims.UserID) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "you are not a member of this workspace",
+		})
+	}
+
+	setting := model.FileActivitySetting{WorkspaceID: int64(workspaceID)}
+	h.db.Where("workspace_id = ?", workspaceID).First(&setting)
+
+	return c.JSON(setting)
+}
+
+// UpdateFileActivitySettings 파일 활동을 올릴 채팅방 지정 (ADMIN 권한 필요)
+func (h *StorageHandler) UpdateFileActivitySettings(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	workspaceID, err := c.ParamsInt("workspaceId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid workspace id",
+		})
+	}
+
+	hasPermission, err := auth.CheckPermission(h.db, int64(workspaceID), claims.UserID, "ADMIN")
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to check permission",
+		})
+	}
+	if !hasPermission {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "only workspace admins can change file activity settings",
+		})
+	}
+
+	var req UpdateFileActivitySettingsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+
+	if req.ChatRoomID != nil {
+		var count int64
+		h.db.Model(&model.Meeting{}).
+			Where("id = ? AND workspace_id = ? AND type = ?", *req.ChatRoomID, workspaceID, model.MeetingTypeChatRoom.String()).
+			Count(&count)
+		if count == 0 {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "chat room not found",
+			})
+		}
+	}
+
+	setting := model.FileActivitySetting{
+		WorkspaceID:      int64(workspaceID),
+		ChatRoomID:       req.ChatRoomID,
+		IncludeDownloads: req.IncludeDownloads,
+		UpdatedBy:        &claims.UserID,
+	}
+	err = h.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "workspace_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"chat_room_id", "include_downloads", "updated_by", "updated_at"}),
+	}).Create(&setting).Error
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to update file activity settings",
+		})
+	}
+	recordAuditEvent(h.db, setting.WorkspaceID, &claims.UserID, model.AuditSettingsUpdated, model.AuditTargetWorkspace, setting.WorkspaceID, map[string]interface{}{
+		"setting":           "file_activity",
+		"chat_room_id":      setting.ChatRoomID,
+		"include_downloads": setting.IncludeDownloads,
+	})
+
+	return c.JSON(setting)
+}
+
+// recordFileActivity 파일 활동 기록 + 설정된 채팅방에 SYSTEM 메시지 (기록 실패는 로그만)
+func (h *StorageHandler) recordFileActivity(file *model.WorkspaceFile, actorID *int64, action model.FileActivityAction, detail string) {
+	activity := model.FileActivity{
+		WorkspaceID: file.WorkspaceID,
+		FileID:      file.ID,
+		FolderID:    file.ParentFolderID,
+		ActorID:     actorID,
+		Action:      action.String(),
+		FileName:    file.Name,
+		FileType:    file.Type,
+	}
+	if detail != "" {
+		activity.Detail = &detail
+	}
+	if err := h.db.Create(&activity).Error; err != nil {
+		log.Printf("⚠️ 파일 활동 기록 실패: file=%d, action=%s, err=%v", file.ID, action, err)
+		return
+	}
+
+	var setting model.FileActivitySetting
+	if err := h.db.Where("workspace_id = ?", file.WorkspaceID).First(&setting).Error; err != nil || setting.ChatRoomID == nil {
+		return
+	}
+	if action == model.FileActionDownload && !setting.IncludeDownloads {
+		return
+	}
+
+	message := h.fileActivityMessage(&activity)
+	h.db.Create(&model.ChatLog{
+		MeetingID: *setting.ChatRoomID,
+		Message:   &message,
+		Type:      "SYSTEM",
+	})
+}
+
+// fileActivityMessage 채팅방에 올릴 활동 문구
+func (h *StorageHandler) fileActivityMessage(activity *model.FileActivity) string {
+	actor := "시스템이"
+	if activity.ActorID != nil {
+		var user model.User
+		if err := h.db.Select("nickname").First(&user, *activity.ActorID).Error; err == nil {
+			actor = user.Nickname + "님이"
+		}
+	} else if activity.Action == model.FileActionDownload.String() {
+		actor = "공유 링크 사용자가"
+	}
+
+	name := activity.FileName
+	switch model.FileActivityAction(activity.Action) {
+	case model.FileActionUpload:
+		return fmt.Sprintf("📁 %s '%s' 파일을 올렸습니다.", actor, name)
+	case model.FileActionCreateFolder:
+		return fmt.Sprintf("📁 %s '%s' 폴더를 만들었습니다.", actor, name)
+	case model.FileActionRename:
+		if activity.Detail != nil {
+			return fmt.Sprintf("📁 %s '%s'의 이름을 '%s'(으)로 바꿨습니다.", actor, *activity.Detail, name)
+		}
+		return fmt.Sprintf("📁 %s '%s'의 이름을 바꿨습니다.", actor, name)
+	case model.FileActionMove:
+		return fmt.Sprintf("📁 %s '%s'을(를) 옮겼습니다.", actor, name)
+	case model.FileActionDelete:
+		return fmt.Sprintf("📁 %s '%s'을(를) 휴지통으로 옮겼습니다.", actor, name)
+	case model.FileActionRestore:
+		return fmt.Sprintf("📁 %s '%s'을(를) 복원했습니다.", actor, name)
+	case model.FileActionPurge:
+		return fmt.Sprintf("📁 %s '%s'을(를) 영구 삭제했습니다.", actor, name)
+	case model.FileActionDownload:
+		return fmt.Sprintf("📁 %s '%s'을(를) 내려받았습니다.", actor, name)
+	}
+	return fmt.Sprintf("📁 %s: %s", name, activity.Action)
+}
+
+func toFileActivityResponse(activity *model.FileActivity) FileActivityResponse {
+	resp := FileActivityResponse{
+		ID:        activity.ID,
+		FileID:    activity.FileID,
+		FolderID:  activity.FolderID,
+		ActorID:   activity.ActorID,
+		Action:    activity.Action,
+		FileName:  activity.FileName,
+		FileType:  activity.FileType,
+		Detail:    activity.Detail,
+		CreatedAt: activity.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	if activity.Actor != nil && activity.Actor.ID != 0 {
+		resp.Actor = &UserResponse{
+			ID:         activity.Actor.ID,
+			Email:      activity.Actor.Email,
+			Nickname:   activity.Actor.Nickname,
+			ProfileImg: activity.Actor.ProfileImg,
+		}
+	}
+	return resp
+}
@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"encoding/hex"
+	"strings"
+
+	"realtime-backend/internal/model"
+)
+
+const (
+	// duplicateActionKeep 중복이어도 새 사본 저장 (응답에 기존 파일 목록 포함)
+	duplicateActionKeep = "keep"
+	// duplicateActionLink 새 사본 대신 기존 파일의 S3 객체를 공유하는 항목 생성
+	duplicateActionLink = "link"
+
+	maxDuplicateFiles = 10
+)
+
+// normalizeContentHash 클라이언트가 보낸 SHA-256 hex 정리 (형식이 틀리면 false)
+func normalizeContentHash(hash string) (string, bool) {
+	hash = strings.ToLower(strings.TrimSpace(hash))
+	if hash == "" {
+		return "", true
+	}
+	if len(hash) != 64 {
+		return "", false
+	}
+	if _, err := hex.DecodeString(hash); err != nil {
+		return "", false
+	}
+	return hash, true
+}
+
+// findDuplicateFiles 워크스페이스에서 같은 내용의 파일 조회 (접근할 수 없는 폴더의 파일 제외, 오래된 순)
+func (h *StorageHandler) findDuplicateFiles(workspaceID int64, hash string, access *folderAccess) []model.WorkspaceFile {
+	if hash == "" {
+		return nil
+	}
+
+	var files []model.WorkspaceFile
+	h.db.Where("workspace_id = ? AND type = ? AND content_hash = ? AND s3_key IS NOT NULL AND s3_key <> ''",
+		workspaceID, "FILE", hash).
+		Preload("Uploader").
+		Order("created_at ASC").
+		Limit(maxDuplicateFiles * 2).
+		Find(&files)
+
+	duplicates := make([]model.WorkspaceFile, 0, len(files))
+	for i := range files {
+		if access.fileAllowed(&files[i]) {
+			duplicates = append(duplicates, files[i])
+		}
+		if len(duplicates) == maxDuplicateFiles {
+			break
+		}
+	}
+	return duplicates
+}
+
+// unreferencedS3Keys 어떤 파일 항목도 더 이상 가리키지 않는 S3 키만 반환
+// 중복 연결한 파일은 S3 객체를 공유하므로 마지막 항목이 삭제될 때만 객체를 지움 (휴지통 항목도 참조로 취급)
+func (h *StorageHandler) unreferencedS3Keys(keys []string) []string {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	var referenced []string
+	h.db.Unscoped().Model(&model.WorkspaceFile{}).Where("s3_key IN ?", keys).Distinct().Pluck("s3_key", &referenced)
+	inUse := make(map[string]bool, len(referenced))
+	for _, key := range referenced {
+		inUse[key] = true
+	}
+
+	unreferenced := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if !inUse[key] {
+			unreferenced = append(unreferenced, key)
+		}
+	}
+	return unreferenced
+}
+
+// copyFileText 연결한 파일에 원본의 추출 본문 복사 (같은 내용을 다시 추출하지 않음)
+func (h *StorageHandler) copyFileText(sourceID int64, file *model.WorkspaceFile) {
+	var text model.FileText
+	err := h.db.Where("file_id = ? AND status = ?", sourceID, model.FileTextCompleted.String()).First(&text).Error
+	if err != nil {
+		h.queueTextExtraction(file)
+		return
+	}
+
+	text.FileID = file.ID
+	text.WorkspaceID = file.WorkspaceID
+	text.JobID = nil
+	h.db.Create(&text)
+}
@@ -0,0 +1,361 @@
+package handler
+
+import (
+	"context"
+	"io"
+	"log"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"realtime-backend/internal/auth"
+	awsai "realtime-backend/internal/aws"
+	appconfig "realtime-backend/internal/config"
+	"realtime-backend/internal/errorreport"
+	"realtime-backend/internal/model"
+	"realtime-backend/internal/storage"
+)
+
+const (
+	fileTextDownloadLimit   = 50 << 20 // 서버에서 파싱하는 문서 최대 크기
+	fileTextParseTimeout    = 2 * time.Minute
+	fileTextRequestLimit    = 30 * time.Second
+	fileTextOCRPollInterval = 10 * time.Second
+	fileTextOCRTimeout      = time.Hour // 이 시간이 지나도 끝나지 않으면 실패 처리
+)
+
+// FileTextResponse 문서 본문 추출 결과 응답
+type FileTextResponse struct {
+	FileID      int64   `json:"file_id"`
+	Status      string  `json:"status"`
+	Method      string  `json:"method"`
+	Pages       int     `json:"pages,omitempty"`
+	Content     *string `json:"content,omitempty"` // COMPLETED일 때만
+	Error       *string `json:"error,omitempty"`
+	CreatedAt   string  `json:"created_at"`
+	CompletedAt *string `json:"completed_at,omitempty"`
+}
+
+// SetTextExtraction PDF/이미지 OCR 활성화 (S3 자격 증명으로 Textract 호출)
+// 재시작 전에 진행 중이던 추출은 이어서 처리
+func (h *StorageHandler) SetTextExtraction(cfg *appconfig.Config) {
+	h.textractCfg = cfg
+	if h.s3 == nil {
+		return
+	}
+
+	var pending []model.FileText
+	h.db.Where("status = ?", model.FileTextPending.String()).Find(&pending)
+	for _, text := range pending {
+		if text.JobID != nil {
+			go h.pollFileTextOCR(text)
+			continue
+		}
+		// 서버 파싱 중에 중단된 문서는 처음부터 다시 추출
+		var file model.WorkspaceFile
+		if err := h.db.First(&file, text.FileID).Error; err != nil {
+			h.db.Delete(&text)
+			continue
+		}
+		go h.extractFileText(file)
+	}
+	if len(pending) > 0 {
+		log.Printf("📄 진행 중인 문서 본문 추출 %d개 재개", len(pending))
+	}
+}
+
+// queueTextExtraction 업로드된 문서의 본문 추출 시작 (지원하지 않는 형식은 무시)
+func (h *StorageHandler) queueTextExtraction(file *model.WorkspaceFile) bool {
+	if h.s3 == nil || file.Type != "FILE" || file.S3Key == nil || *file.S3Key == "" {
+		return false
+	}
+	mimeType := ""
+	if file.MimeType != nil {
+		mimeType = *file.MimeType
+	}
+	kind := storage.DetectDocumentKind(file.Name, mimeType)
+	if kind == storage.DocumentUnsupported || (kind == storage.DocumentScanned && h.textractCfg == nil) {
+		return false
+	}
+
+	method := "PARSER"
+	if kind == storage.DocumentScanned {
+		method = "TEXTRACT"
+	}
+	text := model.FileText{
+		FileID:      file.ID,
+		WorkspaceID: file.WorkspaceID,
+		Status:      model.FileTextPending.String(),
+		Method:      method,
+	}
+	err := h.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "file_id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"status":       text.Status,
+			"method":       text.Method,
+			"job_id":       nil,
+			"content":      "",
+			"pages":        0,
+			"error":        nil,
+			"created_at":   time.Now(),
+			"completed_at": nil,
+		}),
+	}).Create(&text).Error
+	if err != nil {
+		log.Printf("⚠️ 문서 본문 추출 등록 실패: file=%d, err=%v", file.ID, err)
+		return false
+	}
+
+	go h.extractFileText(*file)
+	return true
+}
+
+// ExtractFileText 문서 본문 (재)추출 요청 (기능 추가 전에 올린 파일용)
+func (h *StorageHandler) ExtractFileText(c *fiber.Ctx) error {
+	if h.s3 == nil {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+			"error": "storage is not configured",
+		})
+	}
+
+	claims := c.Locals("claims").(*auth.Claims)
+	file, ok := h.requireStoredFile(c, claims.UserID)
+	if !ok {
+		return nil
+	}
+
+	var existing model.FileText
+	if err := h.db.Where("file_id = ?", file.ID).First(&existing).Error; err == nil &&
+		existing.Status == model.FileTextPending.String() {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "text extraction is already in progress",
+		})
+	}
+
+	if !h.queueTextExtraction(file) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "file is not a supported document format",
+		})
+	}
+
+	var text model.FileText
+	h.db.Where("file_id = ?", file.ID).First(&text)
+	return c.Status(fiber.StatusAccepted).JSON(toFileTextResponse(&text, false))
+}
+
+// GetFileText 문서 본문 추출 상태와 본문 조회 (AI 어시스턴트가 문서 내용을 참조할 때 사용)
+func (h *StorageHandler) GetFileText(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	file, ok := h.requireStoredFile(c, claims.UserID)
+	if !ok {
+		return nil
+	}
+
+	var text model.FileText
+	if err := h.db.Where("file_id = ?", file.ID).First(&text).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "text has not been extracted from this file",
+		})
+	}
+	return c.JSON(toFileTextResponse(&text, true))
+}
+
+// extractFileText 문서 종류에 따라 서버에서 파싱하거나 Textract OCR 작업 시작
+func (h *StorageHandler) extractFileText(file model.WorkspaceFile) {
+	defer errorreport.Recover(errorreport.Context{Component: "storage.file_text"})
+
+	s3Service, err := h.s3ForWorkspace(file.WorkspaceID)
+	if err != nil {
+		h.failFileText(file.ID, "storage is not available in workspace data region")
+		return
+	}
+
+	mimeType := ""
+	if file.MimeType != nil {
+		mimeType = *file.MimeType
+	}
+	if storage.DetectDocumentKind(file.Name, mimeType) == storage.DocumentScanned {
+		h.startFileTextOCR(&file, s3Service)
+		return
+	}
+
+	if file.FileSize != nil && *file.FileSize > fileTextDownloadLimit {
+		h.failFileText(file.ID, "file is too large for text extraction")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), fileTextParseTimeout)
+	defer cancel()
+
+	body, err := s3Service.GetObject(ctx, *file.S3Key)
+	if err != nil {
+		log.Printf("❌ 문서 다운로드 실패: file=%d, err=%v", file.ID, err)
+		h.failFileText(file.ID, "failed to download file")
+		return
+	}
+	defer body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(body, fileTextDownloadLimit+1))
+	if err != nil {
+		h.failFileText(file.ID, "failed to download file")
+		return
+	}
+	if len(data) > fileTextDownloadLimit {
+		h.failFileText(file.ID, "file is too large for text extraction")
+		return
+	}
+
+	content, err := storage.ExtractDocumentText(file.Name, data)
+	if err != nil {
+		log.Printf("❌ 문서 본문 추출 실패: file=%d, err=%v", file.ID, err)
+		h.failFileText(file.ID, err.Error())
+		return
+	}
+	h.completeFileText(file.ID, content, 0)
+}
+
+// startFileTextOCR Textract 작업을 시작하고 완료될 때까지 확인
+func (h *StorageHandler) startFileTextOCR(file *model.WorkspaceFile, s3Service *storage.S3Service) {
+	if h.textractCfg == nil {
+		h.failFileText(file.ID, "OCR is not configured")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), fileTextRequestLimit)
+	defer cancel()
+
+	client, err := awsai.NewTextractClient(ctx, h.textractCfg, s3Service.Region())
+	if err != nil {
+		h.failFileText(file.ID, "OCR is not available")
+		return
+	}
+	jobID, err := client.StartTextDetection(ctx, s3Service.Bucket(), *file.S3Key)
+	if err != nil {
+		log.Printf("❌ Textract 작업 시작 실패: file=%d, err=%v", file.ID, err)
+		h.failFileText(file.ID, "failed to start OCR")
+		return
+	}
+
+	result := h.db.Model(&model.FileText{}).
+		Where("file_id = ? AND status = ?", file.ID, model.FileTextPending.String()).
+		Updates(map[string]interface{}{"job_id": jobID, "region": s3Service.Region()})
+	if result.Error != nil || result.RowsAffected == 0 {
+		return
+	}
+
+	var text model.FileText
+	if err := h.db.Where("file_id = ?", file.ID).First(&text).Error; err != nil {
+		return
+	}
+	h.pollFileTextOCR(text)
+}
+
+// pollFileTextOCR Textract 작업이 끝날 때까지 상태 확인 후 결과 저장
+func (h *StorageHandler) pollFileTextOCR(text model.FileText) {
+	defer errorreport.Recover(errorreport.Context{Component: "storage.file_text"})
+
+	ctx, cancel := context.WithDeadline(context.Background(), text.CreatedAt.Add(fileTextOCRTimeout))
+	defer cancel()
+
+	if h.textractCfg == nil {
+		h.failFileText(text.FileID, "OCR is not configured")
+		return
+	}
+	client, err := awsai.NewTextractClient(ctx, h.textractCfg, text.Region)
+	if err != nil {
+		h.failFileText(text.FileID, "OCR is not available")
+		return
+	}
+
+	ticker := time.NewTicker(fileTextOCRPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			h.failFileText(text.FileID, "OCR timed out")
+			return
+		case <-ticker.C:
+		}
+
+		result, err := client.GetTextDetection(ctx, *text.JobID)
+		if err != nil {
+			// 일시적인 오류는 다음 확인 때 재시도
+			log.Printf("⚠️ Textract 상태 확인 실패: file=%d, err=%v", text.FileID, err)
+			continue
+		}
+
+		switch result.Status {
+		case awsai.TextractSucceeded, awsai.TextractPartialSuccess:
+			h.completeFileText(text.FileID, storage.CleanDocumentText(result.Text), result.Pages)
+			return
+		case awsai.TextractFailed:
+			log.Printf("❌ Textract 작업 실패: file=%d, reason=%s", text.FileID, result.StatusMessage)
+			h.failFileText(text.FileID, result.StatusMessage)
+			return
+		}
+	}
+}
+
+// completeFileText 추출한 본문 저장 (추출 중 상태일 때만)
+func (h *StorageHandler) completeFileText(fileID int64, content string, pages int) {
+	result := h.db.Model(&model.FileText{}).
+		Where("file_id = ? AND status = ?", fileID, model.FileTextPending.String()).
+		Updates(map[string]interface{}{
+			"status":       model.FileTextCompleted.String(),
+			"content":      content,
+			"pages":        pages,
+			"completed_at": time.Now(),
+		})
+	if result.Error != nil {
+		log.Printf("❌ 문서 본문 저장 실패: file=%d, err=%v", fileID, result.Error)
+		h.failFileText(fileID, "failed to save text")
+		return
+	}
+	if result.RowsAffected == 0 {
+		// 그 사이 파일이 삭제되었거나 다른 인스턴스가 이미 마무리함
+		return
+	}
+	log.Printf("✅ 문서 본문 추출 완료: file=%d, chars=%d", fileID, len([]rune(content)))
+}
+
+// failFileText 추출 실패 처리
+func (h *StorageHandler) failFileText(fileID int64, reason string) {
+	if reason == "" {
+		reason = "text extraction failed"
+	}
+	h.db.Model(&model.FileText{}).
+		Where("file_id = ? AND status = ?", fileID, model.FileTextPending.String()).
+		Updates(map[string]interface{}{
+			"status":       model.FileTextFailed.String(),
+			"error":        reason,
+			"completed_at": time.Now(),
+		})
+}
+
+// deleteFileTextWithTx 삭제되는 파일의 추출 본문 정리
+func deleteFileTextWithTx(tx *gorm.DB, fileIDs []int64) {
+	tx.Where("file_id IN ?", fileIDs).Delete(&model.FileText{})
+}
+
+func toFileTextResponse(text *model.FileText, withContent bool) FileTextResponse {
+	resp := FileTextResponse{
+		FileID:    text.FileID,
+		Status:    text.Status,
+		Method:    text.Method,
+		Pages:     text.Pages,
+		Error:     text.Error,
+		CreatedAt: text.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	if withContent && text.Status == model.FileTextCompleted.String() {
+		content := text.Content
+		resp.Content = &content
+	}
+	if text.CompletedAt != nil {
+		completedAt := text.CompletedAt.Format("2006-01-02T15:04:05Z07:00")
+		resp.CompletedAt = &completedAt
+	}
+	return resp
+}
@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"realtime-backend/internal/errorreport"
+	"realtime-backend/internal/model"
+	"realtime-backend/internal/storage"
+)
+
+const fileThumbnailTimeout = 2 * time.Minute
+
+// queueThumbnails 업로드된 이미지의 썸네일/미리보기 생성 시작 (이미지가 아니거나 너무 크면 무시)
+func (h *StorageHandler) queueThumbnails(file *model.WorkspaceFile) bool {
+	if h.s3 == nil || file.Type != "FILE" || file.S3Key == nil || *file.S3Key == "" || file.MimeType == nil {
+		return false
+	}
+	if !storage.IsThumbnailSource(*file.MimeType) {
+		return false
+	}
+	if file.FileSize != nil && *file.FileSize > storage.ThumbnailSourceLimit {
+		return false
+	}
+
+	go h.generateThumbnails(*file)
+	return true
+}
+
+// generateThumbnails 썸네일을 S3 thumbnails/ 아래에 올리고 같은 객체를 쓰는 파일 모두에 URL 기록
+func (h *StorageHandler) generateThumbnails(file model.WorkspaceFile) {
+	defer errorreport.Recover(errorreport.Context{Component: "storage.thumbnail"})
+
+	s3Service, err := h.s3ForWorkspace(file.WorkspaceID)
+	if err != nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), fileThumbnailTimeout)
+	defer cancel()
+
+	keys, err := s3Service.CreateThumbnails(ctx, *file.S3Key)
+	if err != nil {
+		log.Printf("⚠️ 썸네일 생성 실패: file=%d, err=%v", file.ID, err)
+		return
+	}
+
+	err = h.db.Model(&model.WorkspaceFile{}).
+		Where("s3_key = ?", *file.S3Key).
+		Updates(map[string]interface{}{
+			"thumbnail_url": s3Service.GetPublicURL(keys[storage.ThumbnailSize]),
+			"preview_url":   s3Service.GetPublicURL(keys[storage.PreviewSize]),
+		}).Error
+	if err != nil {
+		log.Printf("⚠️ 썸네일 URL 저장 실패: file=%d, err=%v", file.ID, err)
+	}
+}
@@ -0,0 +1,478 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"realtime-backend/internal/auth"
+	awsai "realtime-backend/internal/aws"
+	appconfig "realtime-backend/internal/config"
+	"realtime-backend/internal/errorreport"
+	"realtime-backend/internal/language"
+	"realtime-backend/internal/model"
+)
+
+const (
+	fileTranscribePollInterval = 15 * time.Second
+	fileTranscribeTimeout      = 6 * time.Hour // 이 시간이 지나도 끝나지 않으면 실패 처리
+	fileTranscribeRequestLimit = 30 * time.Second
+)
+
+// errTranscriptionJobTaken 다른 인스턴스가 이미 작업을 마무리함
+var errTranscriptionJobTaken = errors.New("transcription job already finished")
+
+// fileTranscribeLanguageOptions 언어를 지정하지 않았을 때 자동 감지 후보
+var fileTranscribeLanguageOptions = []string{"ko", "en", "ja", "zh"}
+
+// transcribeMediaFormats MIME 타입 → Transcribe 미디어 형식
+var transcribeMediaFormats = map[string]string{
+	"audio/mpeg":   "mp3",
+	"audio/mp3":    "mp3",
+	"audio/mp4":    "m4a",
+	"audio/x-m4a":  "m4a",
+	"audio/m4a":    "m4a",
+	"video/mp4":    "mp4",
+	"audio/wav":    "wav",
+	"audio/x-wav":  "wav",
+	"audio/wave":   "wav",
+	"audio/flac":   "flac",
+	"audio/x-flac": "flac",
+	"audio/ogg":    "ogg",
+	"audio/webm":   "webm",
+	"video/webm":   "webm",
+	"audio/amr":    "amr",
+}
+
+// FileTranscriptionJobResponse 파일 전사 작업 응답
+type FileTranscriptionJobResponse struct {
+	ID           int64   `json:"id"`
+	FileID       int64   `json:"file_id"`
+	RequestedBy  int64   `json:"requested_by"`
+	Status       string  `json:"status"`
+	LanguageCode *string `json:"language_code,omitempty"`
+	RecordCount  int     `json:"record_count"`
+	Error        *string `json:"error,omitempty"`
+	CreatedAt    string  `json:"created_at"`
+	CompletedAt  *string `json:"completed_at,omitempty"`
+}
+
+// TranscribeFileRequest 파일 전사 요청 (language를 비우면 자동 감지)
+type TranscribeFileRequest struct {
+	Language string `json:"language"`
+}
+
+// SetTranscription 업로드 파일 전사 활성화 (S3 자격 증명으로 Transcribe 호출)
+// 재시작 전에 진행 중이던 작업은 다시 완료 여부를 확인
+func (h *StorageHandler) SetTranscription(cfg *appconfig.Config) {
+	h.transcribeCfg = cfg
+	if h.s3 == nil {
+		return
+	}
+
+	var jobs []model.FileTranscriptionJob
+	h.db.Where("status = ?", model.FileTranscriptionRunning.String()).Find(&jobs)
+	for _, job := range jobs {
+		go h.pollTranscriptionJob(job)
+	}
+	if len(jobs) > 0 {
+		log.Printf("🎙️ 진행 중인 파일 전사 작업 %d개 확인 재개", len(jobs))
+	}
+}
+
+// TranscribeFile 업로드된 오디오/비디오 파일 전사 시작 (완료되면 업로더에게 알림)
+func (h *StorageHandler) TranscribeFile(c *fiber.Ctx) error {
+	if h.s3 == nil || h.transcribeCfg == nil {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+			"error": "transcription is not configured",
+		})
+	}
+
+	claims := c.Locals("claims").(*auth.Claims)
+	file, ok := h.requireStoredFile(c, claims.UserID)
+	if !ok {
+		return nil
+	}
+
+	mediaFormat := transcribeMediaFormat(file)
+	if mediaFormat == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "file is not a supported audio/video format",
+		})
+	}
+
+	var req TranscribeFileRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "invalid request body",
+			})
+		}
+	}
+
+	var languageCode *string
+	var transcribeCode string
+	if req.Language != "" {
+		lang, ok := language.Get(req.Language)
+		if !ok || !lang.STT {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "unsupported language",
+			})
+		}
+		languageCode = &lang.Code
+		transcribeCode = lang.TranscribeCode
+	}
+
+	// 같은 파일의 작업은 하나씩
+	var running int64
+	h.db.Model(&model.FileTranscriptionJob{}).
+		Where("file_id = ? AND status = ?", file.ID, model.FileTranscriptionRunning.String()).
+		Count(&running)
+	if running > 0 {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "file is already being transcribed",
+		})
+	}
+
+	s3Service, err := h.s3ForWorkspace(file.WorkspaceID)
+	if err != nil {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+			"error": "storage is not available in workspace data region",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(c.UserContext(), fileTranscribeRequestLimit)
+	defer cancel()
+	client, err := awsai.NewBatchTranscribeClient(ctx, h.transcribeCfg, s3Service.Region())
+	if err != nil {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+			"error": "transcription is not available",
+		})
+	}
+
+	job := model.FileTranscriptionJob{
+		WorkspaceID:  file.WorkspaceID,
+		FileID:       file.ID,
+		RequestedBy:  claims.UserID,
+		JobName:      fmt.Sprintf("eum-file-%d-%s", file.ID, uuid.New().String()),
+		Region:       s3Service.Region(),
+		Status:       model.FileTranscriptionRunning.String(),
+		LanguageCode: languageCode,
+	}
+	if err := h.db.Create(&job).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to create transcription job",
+		})
+	}
+
+	var options []string
+	for _, code := range fileTranscribeLanguageOptions {
+		if lang, ok := language.Get(code); ok {
+			options = append(options, lang.TranscribeCode)
+		}
+	}
+	if err := client.StartJob(ctx, job.JobName, s3Service.ObjectURI(*file.S3Key), mediaFormat, transcribeCode, options); err != nil {
+		log.Printf("❌ 파일 전사 시작 실패: file=%d, err=%v", file.ID, err)
+		h.failTranscriptionJob(&job, "failed to start transcription")
+		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{
+			"error": "failed to start transcription",
+		})
+	}
+
+	go h.pollTranscriptionJob(job)
+
+	return c.Status(fiber.StatusAccepted).JSON(toFileTranscriptionJobResponse(&job))
+}
+
+// GetFileTranscript 파일의 최근 전사 작업과 전사 결과 조회
+func (h *StorageHandler) GetFileTranscript(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	file, ok := h.requireStoredFile(c, claims.UserID)
+	if !ok {
+		return nil
+	}
+
+	var job model.FileTranscriptionJob
+	if err := h.db.Where("file_id = ?", file.ID).Order("created_at DESC").First(&job).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "file has not been transcribed",
+		})
+	}
+
+	var records []model.VoiceRecord
+	if job.Status == model.FileTranscriptionCompleted.String() {
+		if err := h.db.Where("related_file_id = ?", file.ID).Order("created_at ASC").Find(&records).Error; err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "failed to get transcript",
+			})
+		}
+	}
+
+	responses := make([]VoiceRecordResponse, len(records))
+	for i := range records {
+		responses[i] = toVoiceRecordResponse(&records[i])
+	}
+
+	return c.JSON(fiber.Map{
+		"job":     toFileTranscriptionJobResponse(&job),
+		"records": responses,
+	})
+}
+
+// requireStoredFile S3에 저장된 파일 확인 (전사/본문 추출 대상, 실패 시 에러 응답을 기록하고 false 반환)
+func (h *StorageHandler) requireStoredFile(c *fiber.Ctx, userID int64) (*model.WorkspaceFile, bool) {
+	workspaceID, err := c.ParamsInt("workspaceId")
+	if err != nil {
+		c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid workspace id",
+		})
+		return nil, false
+	}
+	fileID, err := c.ParamsInt("fileId")
+	if err != nil {
+		c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid file id",
+		})
+		return nil, false
+	}
+
+	if !h.isWorkspaceMember(int64(workspaceID), userID) {
+		c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "you are not a member of this workspace",
+		})
+		return nil, false
+	}
+
+	var file model.WorkspaceFile
+	err = h.db.Where("id = ? AND workspace_id = ? AND type = ?", fileID, workspaceID, "FILE").First(&file).Error
+	if err != nil || file.S3Key == nil || *file.S3Key == "" {
+		c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "file not found",
+		})
+		return nil, false
+	}
+
+	access := h.requireFolderAccess(c, int64(workspaceID), userID)
+	if access == nil {
+		return nil, false
+	}
+	if !access.fileAllowed(&file) {
+		folderForbidden(c)
+		return nil, false
+	}
+	return &file, true
+}
+
+// pollTranscriptionJob 작업이 끝날 때까지 상태 확인 후 결과 저장
+func (h *StorageHandler) pollTranscriptionJob(job model.FileTranscriptionJob) {
+	defer errorreport.Recover(errorreport.Context{Component: "storage.file_transcription"})
+
+	ctx, cancel := context.WithDeadline(context.Background(), job.CreatedAt.Add(fileTranscribeTimeout))
+	defer cancel()
+
+	client, err := awsai.NewBatchTranscribeClient(ctx, h.transcribeCfg, job.Region)
+	if err != nil {
+		h.failTranscriptionJob(&job, "transcription is not available")
+		return
+	}
+
+	ticker := time.NewTicker(fileTranscribePollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			h.failTranscriptionJob(&job, "transcription timed out")
+			return
+		case <-ticker.C:
+		}
+
+		state, err := client.GetJob(ctx, job.JobName)
+		if err != nil {
+			// 일시적인 오류는 다음 확인 때 재시도
+			log.Printf("⚠️ 파일 전사 상태 확인 실패: job=%d, err=%v", job.ID, err)
+			continue
+		}
+
+		switch state.Status {
+		case awsai.BatchJobCompleted:
+			segments, err := client.FetchTranscript(ctx, state.TranscriptURI)
+			if err != nil {
+				log.Printf("❌ 파일 전사 결과 다운로드 실패: job=%d, err=%v", job.ID, err)
+				h.failTranscriptionJob(&job, "failed to download transcript")
+			} else {
+				h.completeTranscriptionJob(&job, state.LanguageCode, segments)
+			}
+			client.DeleteJob(ctx, job.JobName)
+			return
+		case awsai.BatchJobFailed:
+			log.Printf("❌ 파일 전사 실패: job=%d, reason=%s", job.ID, state.FailureReason)
+			h.failTranscriptionJob(&job, state.FailureReason)
+			return
+		}
+	}
+}
+
+// completeTranscriptionJob 전사 결과를 음성 기록으로 저장
+// 여러 인스턴스가 같은 작업을 확인해도 상태를 먼저 바꾼 한 곳만 저장
+func (h *StorageHandler) completeTranscriptionJob(job *model.FileTranscriptionJob, transcribeCode string, segments []awsai.BatchSegment) {
+	var file model.WorkspaceFile
+	if err := h.db.First(&file, job.FileID).Error; err != nil {
+		h.failTranscriptionJob(job, "file was deleted")
+		return
+	}
+
+	lang := language.Normalize(transcribeCode)
+	if lang == "" && job.LanguageCode != nil {
+		lang = *job.LanguageCode
+	}
+
+	now := time.Now()
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		updates := map[string]interface{}{
+			"status":       model.FileTranscriptionCompleted.String(),
+			"record_count": len(segments),
+			"completed_at": now,
+		}
+		if lang != "" {
+			updates["language_code"] = lang
+		}
+		result := tx.Model(&model.FileTranscriptionJob{}).
+			Where("id = ? AND status = ?", job.ID, model.FileTranscriptionRunning.String()).
+			Updates(updates)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errTranscriptionJobTaken
+		}
+
+		// 이전 전사 결과는 새 결과로 교체
+		if err := tx.Where("related_file_id = ?", file.ID).Delete(&model.VoiceRecord{}).Error; err != nil {
+			return err
+		}
+		if len(segments) == 0 {
+			return nil
+		}
+
+		records := make([]model.VoiceRecord, 0, len(segments))
+		for _, s := range segments {
+			record := model.VoiceRecord{
+				MeetingID:     file.RelatedMeetingID,
+				RelatedFileID: &file.ID,
+				SpeakerName:   batchSpeakerName(s.SpeakerLabel),
+				Original:      s.Text,
+				// 미디어 내 시작 위치만큼 더해 created_at 순서가 발화 순서와 같도록 함
+				CreatedAt: job.CreatedAt.Add(time.Duration(s.StartTime * float64(time.Second))),
+			}
+			if lang != "" {
+				sourceLang := lang
+				record.SourceLang = &sourceLang
+			}
+			records = append(records, record)
+		}
+		return tx.CreateInBatches(&records, 200).Error
+	})
+	if err == errTranscriptionJobTaken {
+		return
+	}
+	if err != nil {
+		log.Printf("❌ 파일 전사 결과 저장 실패: job=%d, err=%v", job.ID, err)
+		h.failTranscriptionJob(job, "failed to save transcript")
+		return
+	}
+
+	log.Printf("✅ 파일 전사 완료: job=%d, file=%d, segments=%d", job.ID, file.ID, len(segments))
+	h.notifyTranscription(job, &file, fmt.Sprintf("'%s' 파일의 음성 기록이 준비되었습니다.", file.Name))
+}
+
+// failTranscriptionJob 작업 실패 처리 후 알림
+func (h *StorageHandler) failTranscriptionJob(job *model.FileTranscriptionJob, reason string) {
+	if reason == "" {
+		reason = "transcription failed"
+	}
+	result := h.db.Model(&model.FileTranscriptionJob{}).
+		Where("id = ? AND status = ?", job.ID, model.FileTranscriptionRunning.String()).
+		Updates(map[string]interface{}{
+			"status":       model.FileTranscriptionFailed.String(),
+			"error":        reason,
+			"completed_at": time.Now(),
+		})
+	if result.Error != nil || result.RowsAffected == 0 {
+		return
+	}
+
+	var file model.WorkspaceFile
+	if err := h.db.First(&file, job.FileID).Error; err != nil {
+		return
+	}
+	h.notifyTranscription(job, &file, fmt.Sprintf("'%s' 파일의 음성 기록 생성에 실패했습니다.", file.Name))
+}
+
+// notifyTranscription 업로더(와 요청자)에게 알림
+func (h *StorageHandler) notifyTranscription(job *model.FileTranscriptionJob, file *model.WorkspaceFile, content string) {
+	relatedType := "FILE"
+	receivers := []int64{job.RequestedBy}
+	if file.UploaderID != nil && *file.UploaderID != job.RequestedBy {
+		receivers = append(receivers, *file.UploaderID)
+	}
+	for _, receiverID := range receivers {
+		CreateNotification(h.db, receiverID, nil, model.NotificationTypeFileTranscript.String(), content, &relatedType, &file.ID)
+	}
+}
+
+// deleteFileTranscriptsWithTx 삭제되는 파일의 전사 작업/결과 정리
+func deleteFileTranscriptsWithTx(tx *gorm.DB, fileIDs []int64) {
+	tx.Where("related_file_id IN ?", fileIDs).Delete(&model.VoiceRecord{})
+	tx.Where("file_id IN ?", fileIDs).Delete(&model.FileTranscriptionJob{})
+}
+
+// transcribeMediaFormat 파일의 Transcribe 미디어 형식 ("" = 지원하지 않음)
+func transcribeMediaFormat(file *model.WorkspaceFile) string {
+	if file.MimeType != nil {
+		mimeType := strings.ToLower(strings.TrimSpace(strings.SplitN(*file.MimeType, ";", 2)[0]))
+		if format, ok := transcribeMediaFormats[mimeType]; ok {
+			return format
+		}
+	}
+	switch ext := strings.TrimPrefix(strings.ToLower(filepath.Ext(file.Name)), "."); ext {
+	case "mp3", "mp4", "wav", "flac", "ogg", "amr", "webm", "m4a":
+		return ext
+	}
+	return ""
+}
+
+// batchSpeakerName Transcribe 화자 라벨("spk_0")을 표시용 이름으로 변환
+func batchSpeakerName(label string) string {
+	var n int
+	if _, err := fmt.Sscanf(label, "spk_%d", &n); err == nil {
+		return fmt.Sprintf("화자 %d", n+1)
+	}
+	return "화자"
+}
+
+func toFileTranscriptionJobResponse(job *model.FileTranscriptionJob) FileTranscriptionJobResponse {
+	resp := FileTranscriptionJobResponse{
+		ID:           job.ID,
+		FileID:       job.FileID,
+		RequestedBy:  job.RequestedBy,
+		Status:       job.Status,
+		LanguageCode: job.LanguageCode,
+		RecordCount:  job.RecordCount,
+		Error:        job.Error,
+		CreatedAt:    job.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	if job.CompletedAt != nil {
+		completedAt := job.CompletedAt.Format("2006-01-02T15:04:05Z07:00")
+		resp.CompletedAt = &completedAt
+	}
+	return resp
+}
@@ -0,0 +1,353 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+
+	"realtime-backend/internal/auth"
+	"realtime-backend/internal/model"
+)
+
+// permissionManageFiles 폴더 접근 제한 설정 권한 (보유자는 제한된 폴더도 모두 접근 가능)
+const permissionManageFiles = "MANAGE_FILES"
+
+// folderAccess 한 사용자가 워크스페이스의 어떤 폴더에 접근할 수 있는지 (요청마다 계산)
+type folderAccess struct {
+	bypass  bool // 소유자/ADMIN/MANAGE_FILES
+	userID  int64
+	roleID  int64
+	rules   map[int64][]model.FolderPermission // 제한된 폴더 ID → 허용 규칙
+	parents map[int64]*int64                   // 폴더 ID → 상위 폴더 ID
+	allowed map[int64]bool                     // 폴더별 계산 결과
+}
+
+// FolderPermissionResponse 폴더 접근 제한 규칙 응답
+type FolderPermissionResponse struct {
+	FolderID   int64                    `json:"folder_id"`
+	Restricted bool                     `json:"restricted"`
+	Rules      []model.FolderPermission `json:"rules"`
+}
+
+// UpdateFolderPermissionsRequest 폴더 접근 제한 설정 요청 (둘 다 비우면 제한 해제)
+type UpdateFolderPermissionsRequest struct {
+	RoleIDs []int64 `json:"role_ids"`
+	UserIDs []int64 `json:"user_ids"`
+}
+
+// loadFolderAccess 사용자의 폴더 접근 정보 조회
+func (h *StorageHandler) loadFolderAccess(workspaceID, userID int64) (*folderAccess, error) {
+	return queryFolderAccess(h.db, workspaceID, userID)
+}
+
+// queryFolderAccess 폴더 접근 정보 조회 (스토리지 외 핸들러에서 파일을 참조할 때도 사용)
+// 제한된 폴더가 없는 워크스페이스는 폴더 목록을 읽지 않음
+func queryFolderAccess(db *gorm.DB, workspaceID, userID int64) (*folderAccess, error) {
+	access := &folderAccess{
+		userID:  userID,
+		rules:   make(map[int64][]model.FolderPermission),
+		allowed: make(map[int64]bool),
+	}
+
+	var rules []model.FolderPermission
+	if err := db.Where("workspace_id = ?", workspaceID).Find(&rules).Error; err != nil {
+		return nil, err
+	}
+	if len(rules) == 0 {
+		return access, nil
+	}
+	for _, rule := range rules {
+		access.rules[rule.FolderID] = append(access.rules[rule.FolderID], rule)
+	}
+
+	bypass, err := auth.CheckPermission(db, workspaceID, userID, permissionManageFiles)
+	if err != nil {
+		return nil, err
+	}
+	access.bypass = bypass
+
+	var roleID *int64
+	db.Model(&model.WorkspaceMember{}).
+		Where("workspace_id = ? AND user_id = ?", workspaceID, userID).
+		Select("role_id").
+		Scan(&roleID)
+	if roleID != nil {
+		access.roleID = *roleID
+	}
+
+	// 휴지통의 폴더도 포함 (휴지통 항목의 상위 제한을 확인하기 위해)
+	var folders []model.WorkspaceFile
+	if err := db.Unscoped().Select("id", "parent_folder_id").
+		Where("workspace_id = ? AND type = ?", workspaceID, "FOLDER").
+		Find(&folders).Error; err != nil {
+		return nil, err
+	}
+	access.parents = make(map[int64]*int64, len(folders))
+	for _, f := range folders {
+		access.parents[f.ID] = f.ParentFolderID
+	}
+	return access, nil
+}
+
+// restricted 폴더 자체에 제한 규칙이 있는지
+func (a *folderAccess) restricted(folderID int64) bool {
+	return len(a.rules[folderID]) > 0
+}
+
+// matches 규칙 중 하나라도 사용자(또는 사용자의 역할)를 허용하는지
+func (a *folderAccess) matches(rules []model.FolderPermission) bool {
+	for _, rule := range rules {
+		if rule.UserID != nil && *rule.UserID == a.userID {
+			return true
+		}
+		if rule.RoleID != nil && a.roleID != 0 && *rule.RoleID == a.roleID {
+			return true
+		}
+	}
+	return false
+}
+
+// folderAllowed 폴더와 모든 상위 폴더의 제한을 통과하는지
+func (a *folderAccess) folderAllowed(folderID int64) bool {
+	if a.bypass || len(a.rules) == 0 {
+		return true
+	}
+	if allowed, ok := a.allowed[folderID]; ok {
+		return allowed
+	}
+
+	allowed := true
+	visited := make(map[int64]bool)
+	for id := folderID; id > 0 && !visited[id]; {
+		visited[id] = true
+		if rules := a.rules[id]; len(rules) > 0 && !a.matches(rules) {
+			allowed = false
+			break
+		}
+		parent := a.parents[id]
+		if parent == nil {
+			break
+		}
+		id = *parent
+	}
+
+	a.allowed[folderID] = allowed
+	return allowed
+}
+
+// parentAllowed 상위 폴더(nil이면 루트)에 접근 가능한지 - 업로드/폴더 생성 위치 확인용
+func (a *folderAccess) parentAllowed(parentFolderID *int64) bool {
+	return parentFolderID == nil || a.folderAllowed(*parentFolderID)
+}
+
+// fileAllowed 파일/폴더에 접근 가능한지
+func (a *folderAccess) fileAllowed(f *model.WorkspaceFile) bool {
+	if f.Type == "FOLDER" {
+		return a.folderAllowed(f.ID)
+	}
+	return a.parentAllowed(f.ParentFolderID)
+}
+
+// subtreeAllowed 폴더와 그 하위의 모든 제한 폴더에 접근 가능한지 - 폴더 삭제용
+func (a *folderAccess) subtreeAllowed(folderID int64) bool {
+	if !a.folderAllowed(folderID) {
+		return false
+	}
+	for restrictedID := range a.rules {
+		if a.isDescendant(restrictedID, folderID) && !a.folderAllowed(restrictedID) {
+			return false
+		}
+	}
+	return true
+}
+
+// isDescendant folderID가 ancestorID의 하위 폴더인지
+func (a *folderAccess) isDescendant(folderID, ancestorID int64) bool {
+	visited := make(map[int64]bool)
+	for parent := a.parents[folderID]; parent != nil && !visited[*parent]; parent = a.parents[*parent] {
+		if *parent == ancestorID {
+			return true
+		}
+		visited[*parent] = true
+	}
+	return false
+}
+
+// requireFolderAccess 접근 정보 조회 (실패 시 에러 응답을 기록하고 nil 반환)
+func (h *StorageHandler) requireFolderAccess(c *fiber.Ctx, workspaceID, userID int64) *folderAccess {
+	access, err := h.loadFolderAccess(workspaceID, userID)
+	if err != nil {
+		c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to check folder permissions",
+		})
+		return nil
+	}
+	return access
+}
+
+// folderForbidden 제한된 폴더 접근 거부 응답
+func folderForbidden(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+		"error": "you don't have access to this folder",
+	})
+}
+
+// GetFolderPermissions 폴더 접근 제한 규칙 조회
+func (h *StorageHandler) GetFolderPermissions(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	workspaceID, err := c.ParamsInt("workspaceId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid workspace id",
+		})
+	}
+	folderID, err := c.ParamsInt("folderId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid folder id",
+		})
+	}
+
+	if !h.isWorkspaceMember(int64(workspaceID), claims.UserID) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "you are not a member of this workspace",
+		})
+	}
+
+	var folder model.WorkspaceFile
+	if err := h.db.Where("id = ? AND workspace_id = ? AND type = ?", folderID, workspaceID, "FOLDER").First(&folder).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "folder not found",
+		})
+	}
+
+	access := h.requireFolderAccess(c, int64(workspaceID), claims.UserID)
+	if access == nil {
+		return nil
+	}
+	if !access.folderAllowed(folder.ID) {
+		return folderForbidden(c)
+	}
+
+	rules := access.rules[folder.ID]
+	if rules == nil {
+		rules = []model.FolderPermission{}
+	}
+	return c.JSON(FolderPermissionResponse{
+		FolderID:   folder.ID,
+		Restricted: len(rules) > 0,
+		Rules:      rules,
+	})
+}
+
+// UpdateFolderPermissions 폴더 접근 제한 규칙 교체 (MANAGE_FILES 권한 필요)
+func (h *StorageHandler) UpdateFolderPermissions(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	workspaceID, err := c.ParamsInt("workspaceId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid workspace id",
+		})
+	}
+	folderID, err := c.ParamsInt("folderId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid folder id",
+		})
+	}
+
+	hasPermission, err := auth.CheckPermission(h.db, int64(workspaceID), claims.UserID, permissionManageFiles)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to check permission",
+		})
+	}
+	if !hasPermission {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "you don't have permission to manage folder permissions",
+		})
+	}
+
+	var folder model.WorkspaceFile
+	if err := h.db.Where("id = ? AND workspace_id = ? AND type = ?", folderID, workspaceID, "FOLDER").First(&folder).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "folder not found",
+		})
+	}
+
+	var req UpdateFolderPermissionsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+	req.RoleIDs = uniqueInt64s(req.RoleIDs)
+	req.UserIDs = uniqueInt64s(req.UserIDs)
+
+	// 워크스페이스의 역할/활성 멤버만 허용
+	if len(req.RoleIDs) > 0 {
+		var count int64
+		h.db.Model(&model.Role{}).Where("workspace_id = ? AND id IN ?", workspaceID, req.RoleIDs).Count(&count)
+		if int(count) != len(req.RoleIDs) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "role not found in this workspace",
+			})
+		}
+	}
+	if len(req.UserIDs) > 0 {
+		var count int64
+		h.db.Model(&model.WorkspaceMember{}).
+			Where("workspace_id = ? AND user_id IN ? AND status = ?", workspaceID, req.UserIDs, model.MemberStatusActive.String()).
+			Count(&count)
+		if int(count) != len(req.UserIDs) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "user is not a member of this workspace",
+			})
+		}
+	}
+
+	rules := make([]model.FolderPermission, 0, len(req.RoleIDs)+len(req.UserIDs))
+	for i := range req.RoleIDs {
+		rules = append(rules, model.FolderPermission{WorkspaceID: int64(workspaceID), FolderID: folder.ID, RoleID: &req.RoleIDs[i], CreatedBy: claims.UserID})
+	}
+	for i := range req.UserIDs {
+		rules = append(rules, model.FolderPermission{WorkspaceID: int64(workspaceID), FolderID: folder.ID, UserID: &req.UserIDs[i], CreatedBy: claims.UserID})
+	}
+
+	err = h.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("folder_id = ?", folder.ID).Delete(&model.FolderPermission{}).Error; err != nil {
+			return err
+		}
+		if len(rules) == 0 {
+			return nil
+		}
+		return tx.Create(&rules).Error
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to update folder permissions",
+		})
+	}
+
+	return c.JSON(FolderPermissionResponse{
+		FolderID:   folder.ID,
+		Restricted: len(rules) > 0,
+		Rules:      rules,
+	})
+}
+
+// deleteFolderPermissionsWithTx 삭제되는 폴더의 접근 제한 규칙 정리
+func deleteFolderPermissionsWithTx(tx *gorm.DB, folderIDs []int64) {
+	tx.Where("folder_id IN ?", folderIDs).Delete(&model.FolderPermission{})
+}
+
+func uniqueInt64s(ids []int64) []int64 {
+	seen := make(map[int64]bool, len(ids))
+	unique := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if id > 0 && !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
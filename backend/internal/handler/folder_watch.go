@@ -0,0 +1,473 @@
+package handler
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+
+	"realtime-backend/internal/auth"
+	"realtime-backend/internal/errorreport"
+	"realtime-backend/internal/model"
+	"realtime-backend/internal/netguard"
+)
+
+// 폴더 구독 이벤트 타입
+const (
+	folderEventFileAdded   = "FILE_ADDED"
+	folderEventFileRenamed = "FILE_RENAMED"
+	folderEventFileDeleted = "FILE_DELETED"
+)
+
+const (
+	// folderWatchMaxDepth 구독자 탐색 시 올라가는 최대 상위 폴더 수
+	folderWatchMaxDepth = 64
+	// folderWebhookTimeout 웹훅 요청 타임아웃
+	folderWebhookTimeout = 5 * time.Second
+)
+
+// folderWebhookClient 폴더 웹훅 전송용 (공인 주소로만 연결, 리다이렉트 따라가지 않음)
+var folderWebhookClient = netguard.NewHTTPClient(folderWebhookTimeout)
+
+// FolderWatchResponse 폴더 구독 응답
+type FolderWatchResponse struct {
+	FolderID      int64   `json:"folder_id"`
+	FolderName    string  `json:"folder_name,omitempty"`
+	Watching      bool    `json:"watching"`
+	WebhookURL    *string `json:"webhook_url,omitempty"`
+	WebhookSecret *string `json:"webhook_secret,omitempty"` // 웹훅을 새로 등록했을 때만 포함
+	CreatedAt     string  `json:"created_at,omitempty"`
+}
+
+// WatchFolderRequest 폴더 구독 요청 (webhook_url을 빈 문자열로 보내면 웹훅 해제)
+type WatchFolderRequest struct {
+	WebhookURL *string `json:"webhook_url"`
+}
+
+// FolderWebhookPayload 폴더 웹훅 본문 (X-Eum-Signature: sha256=HMAC(secret, body))
+type FolderWebhookPayload struct {
+	Event       string          `json:"event"`
+	WorkspaceID int64           `json:"workspace_id"`
+	FolderID    int64           `json:"folder_id"` // 구독한 폴더
+	File        FolderEventFile `json:"file"`
+	OldName     string          `json:"old_name,omitempty"` // FILE_RENAMED
+	ActorID     int64           `json:"actor_id"`
+	OccurredAt  string          `json:"occurred_at"`
+}
+
+// FolderEventFile 이벤트 대상 파일/폴더
+type FolderEventFile struct {
+	ID             int64  `json:"id"`
+	Name           string `json:"name"`
+	Type           string `json:"type"`
+	ParentFolderID *int64 `json:"parent_folder_id,omitempty"`
+}
+
+// folderEvent 구독자에게 보낼 파일 변경 이벤트
+type folderEvent struct {
+	kind    string
+	actorID int64
+	file    model.WorkspaceFile
+	oldName string
+}
+
+// folderWatchTarget 이벤트를 받을 구독 (사용자당 가장 가까운 구독 하나)
+type folderWatchTarget struct {
+	watch      model.FolderWatch
+	folderName string
+}
+
+// GetMyFolderWatches 내가 구독 중인 폴더 목록
+func (h *StorageHandler) GetMyFolderWatches(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	workspaceID, err := c.ParamsInt("workspaceId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid workspace id",
+		})
+	}
+
+	if !h.isWorkspaceMember(int64(workspaceID), claims.UserID) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "you are not a member of this workspace",
+		})
+	}
+
+	var watches []model.FolderWatch
+	if err := h.db.Where("workspace_id = ? AND user_id = ?", workspaceID, claims.UserID).
+		Order("created_at DESC").
+		Find(&watches).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get folder watches",
+		})
+	}
+
+	folderIDs := make([]int64, 0, len(watches))
+	for _, w := range watches {
+		folderIDs = append(folderIDs, w.FolderID)
+	}
+	names := make(map[int64]string, len(folderIDs))
+	if len(folderIDs) > 0 {
+		var folders []model.WorkspaceFile
+		h.db.Select("id", "name").Where("id IN ?", folderIDs).Find(&folders)
+		for _, f := range folders {
+			names[f.ID] = f.Name
+		}
+	}
+
+	responses := make([]FolderWatchResponse, 0, len(watches))
+	for i := range watches {
+		responses = append(responses, toFolderWatchResponse(&watches[i], names[watches[i].FolderID]))
+	}
+
+	return c.JSON(fiber.Map{
+		"watches": responses,
+	})
+}
+
+// GetFolderWatch 폴더 구독 상태 조회
+func (h *StorageHandler) GetFolderWatch(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	folder, ok := h.requireWatchableFolder(c, claims.UserID)
+	if !ok {
+		return nil
+	}
+
+	var watch model.FolderWatch
+	if err := h.db.Where("folder_id = ? AND user_id = ?", folder.ID, claims.UserID).First(&watch).Error; err != nil {
+		return c.JSON(FolderWatchResponse{FolderID: folder.ID, FolderName: folder.Name})
+	}
+	return c.JSON(toFolderWatchResponse(&watch, folder.Name))
+}
+
+// WatchFolder 폴더 구독 (이미 구독 중이면 웹훅 설정만 변경)
+func (h *StorageHandler) WatchFolder(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	folder, ok := h.requireWatchableFolder(c, claims.UserID)
+	if !ok {
+		return nil
+	}
+
+	var req WatchFolderRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "invalid request body",
+			})
+		}
+	}
+
+	var watch model.FolderWatch
+	h.db.Where("folder_id = ? AND user_id = ?", folder.ID, claims.UserID).Limit(1).Find(&watch)
+	watch.WorkspaceID = folder.WorkspaceID
+	watch.FolderID = folder.ID
+	watch.UserID = claims.UserID
+
+	var newSecret *string
+	if req.WebhookURL != nil {
+		webhookURL := strings.TrimSpace(*req.WebhookURL)
+		if webhookURL == "" {
+			watch.WebhookURL = nil
+			watch.WebhookSecret = nil
+		} else {
+			if err := validateWebhookURL(webhookURL); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error": err.Error(),
+				})
+			}
+			if watch.WebhookURL == nil || *watch.WebhookURL != webhookURL {
+				secret, err := newWebhookSecret()
+				if err != nil {
+					return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+						"error": "failed to create webhook secret",
+					})
+				}
+				watch.WebhookSecret = &secret
+				newSecret = &secret
+			}
+			watch.WebhookURL = &webhookURL
+		}
+	}
+
+	if err := h.db.Save(&watch).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to watch folder",
+		})
+	}
+
+	resp := toFolderWatchResponse(&watch, folder.Name)
+	resp.WebhookSecret = newSecret
+	return c.JSON(resp)
+}
+
+// UnwatchFolder 폴더 구독 해제
+func (h *StorageHandler) UnwatchFolder(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	workspaceID, err := c.ParamsInt("workspaceId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid workspace id",
+		})
+	}
+	folderID, err := c.ParamsInt("folderId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid folder id",
+		})
+	}
+
+	// 접근이 제한된 뒤에도 구독은 해제할 수 있도록 폴더 접근은 확인하지 않음
+	if err := h.db.Where("workspace_id = ? AND folder_id = ? AND user_id = ?", workspaceID, folderID, claims.UserID).
+		Delete(&model.FolderWatch{}).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to unwatch folder",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "folder unwatched",
+	})
+}
+
+// requireWatchableFolder 구독할 폴더 확인 (실패 시 에러 응답을 기록하고 false 반환)
+func (h *StorageHandler) requireWatchableFolder(c *fiber.Ctx, userID int64) (*model.WorkspaceFile, bool) {
+	workspaceID, err := c.ParamsInt("workspaceId")
+	if err != nil {
+		c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid workspace id",
+		})
+		return nil, false
+	}
+	folderID, err := c.ParamsInt("folderId")
+	if err != nil {
+		c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid folder id",
+		})
+		return nil, false
+	}
+
+	if !h.isWorkspaceMember(int64(workspaceID), userID) {
+		c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "you are not a member of this workspace",
+		})
+		return nil, false
+	}
+
+	var folder model.WorkspaceFile
+	if err := h.db.Where("id = ? AND workspace_id = ? AND type = ?", folderID, workspaceID, "FOLDER").First(&folder).Error; err != nil {
+		c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "folder not found",
+		})
+		return nil, false
+	}
+
+	access := h.requireFolderAccess(c, int64(workspaceID), userID)
+	if access == nil {
+		return nil, false
+	}
+	if !access.folderAllowed(folder.ID) {
+		folderForbidden(c)
+		return nil, false
+	}
+	return &folder, true
+}
+
+// folderWatchTargets 파일 변경을 받을 구독 조회 - folderID와 그 상위 폴더의 구독자 중
+// 변경한 사용자를 제외하고 아직 멤버이며 대상 파일에 접근 가능한 사용자만 포함
+// 삭제 이벤트는 삭제 전에 호출해야 함 (폴더 구조와 접근 규칙이 남아 있어야 함)
+func (h *StorageHandler) folderWatchTargets(workspaceID int64, folderID *int64, actorID int64, file *model.WorkspaceFile) []folderWatchTarget {
+	if folderID == nil {
+		return nil
+	}
+
+	var watches []model.FolderWatch
+	if err := h.db.Where("workspace_id = ? AND user_id <> ?", workspaceID, actorID).Find(&watches).Error; err != nil || len(watches) == 0 {
+		return nil
+	}
+	byFolder := make(map[int64][]model.FolderWatch)
+	for _, w := range watches {
+		byFolder[w.FolderID] = append(byFolder[w.FolderID], w)
+	}
+
+	var folders []model.WorkspaceFile
+	h.db.Select("id", "name", "parent_folder_id").
+		Where("workspace_id = ? AND type = ?", workspaceID, "FOLDER").
+		Find(&folders)
+	byID := make(map[int64]*model.WorkspaceFile, len(folders))
+	for i := range folders {
+		byID[folders[i].ID] = &folders[i]
+	}
+
+	var targets []folderWatchTarget
+	seen := make(map[int64]bool)
+	id := *folderID
+	for depth := 0; depth < folderWatchMaxDepth; depth++ {
+		folder := byID[id]
+		if folder == nil {
+			break
+		}
+		for _, w := range byFolder[id] {
+			if seen[w.UserID] {
+				continue
+			}
+			seen[w.UserID] = true
+			if !h.isWorkspaceMember(workspaceID, w.UserID) {
+				continue
+			}
+			access, err := h.loadFolderAccess(workspaceID, w.UserID)
+			if err != nil || !access.fileAllowed(file) {
+				continue
+			}
+			targets = append(targets, folderWatchTarget{watch: w, folderName: folder.Name})
+		}
+		if folder.ParentFolderID == nil {
+			break
+		}
+		id = *folder.ParentFolderID
+	}
+	return targets
+}
+
+// notifyFolderWatchers 구독자에게 알림과 웹훅 전송 (비동기)
+func (h *StorageHandler) notifyFolderWatchers(targets []folderWatchTarget, event folderEvent) {
+	if len(targets) == 0 {
+		return
+	}
+
+	go func() {
+		defer errorreport.Recover(errorreport.Context{Component: "storage.folder_watch"})
+
+		actorName := "알 수 없는 사용자"
+		var actor model.User
+		if err := h.db.Select("id", "nickname").First(&actor, event.actorID).Error; err == nil {
+			actorName = actor.Nickname
+		}
+
+		relatedType := "FOLDER"
+		occurredAt := time.Now().Format("2006-01-02T15:04:05Z07:00")
+		for _, target := range targets {
+			watch := target.watch
+			content := folderEventContent(event, actorName, target)
+			if err := CreateNotification(h.db, watch.UserID, &event.actorID, model.NotificationTypeFileActivity.String(), content, &relatedType, &watch.FolderID); err != nil {
+				log.Printf("⚠️ 폴더 구독 알림 생성 실패: user=%d, folder=%d, err=%v", watch.UserID, watch.FolderID, err)
+			}
+
+			if watch.WebhookURL != nil && watch.WebhookSecret != nil {
+				sendFolderWebhook(&watch, FolderWebhookPayload{
+					Event:       event.kind,
+					WorkspaceID: watch.WorkspaceID,
+					FolderID:    watch.FolderID,
+					File: FolderEventFile{
+						ID:             event.file.ID,
+						Name:           event.file.Name,
+						Type:           event.file.Type,
+						ParentFolderID: event.file.ParentFolderID,
+					},
+					OldName:    event.oldName,
+					ActorID:    event.actorID,
+					OccurredAt: occurredAt,
+				})
+			}
+		}
+	}()
+}
+
+// folderEventContent 알림 문구
+func folderEventContent(event folderEvent, actorName string, target folderWatchTarget) string {
+	switch event.kind {
+	case folderEventFileAdded:
+		return fmt.Sprintf("%s님이 '%s' 폴더에 '%s'을(를) 추가했습니다.", actorName, target.folderName, event.file.Name)
+	case folderEventFileRenamed:
+		return fmt.Sprintf("%s님이 '%s' 폴더의 '%s' 이름을 '%s'(으)로 변경했습니다.", actorName, target.folderName, event.oldName, event.file.Name)
+	default:
+		if event.file.ID == target.watch.FolderID {
+			return fmt.Sprintf("%s님이 구독 중인 '%s' 폴더를 삭제했습니다.", actorName, target.folderName)
+		}
+		return fmt.Sprintf("%s님이 '%s' 폴더의 '%s'을(를) 삭제했습니다.", actorName, target.folderName, event.file.Name)
+	}
+}
+
+// sendFolderWebhook 웹훅 전송 (실패해도 재시도하지 않음)
+func sendFolderWebhook(watch *model.FolderWatch, payload FolderWebhookPayload) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return
+	}
+
+	mac := hmac.New(sha256.New, []byte(*watch.WebhookSecret))
+	mac.Write(body)
+
+	req, err := http.NewRequest(http.MethodPost, *watch.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		log.Printf("⚠️ 폴더 웹훅 요청 생성 실패: watch=%d, err=%v", watch.ID, err)
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Eum-Event", payload.Event)
+	req.Header.Set("X-Eum-Signature", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+
+	resp, err := folderWebhookClient.Do(req)
+	if err != nil {
+		log.Printf("⚠️ 폴더 웹훅 전송 실패: watch=%d, err=%v", watch.ID, err)
+		return
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		log.Printf("⚠️ 폴더 웹훅 응답 오류: watch=%d, status=%d", watch.ID, resp.StatusCode)
+	}
+}
+
+// validateWebhookURL https URL만 허용하고 내부 주소는 거부
+// 호스트명이 내부 주소로 풀리는 경우는 전송할 때 folderWebhookClient가 연결 단계에서 거부
+func validateWebhookURL(raw string) error {
+	if len(raw) > 2048 {
+		return fmt.Errorf("webhook_url is too long")
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Scheme != "https" || u.Hostname() == "" {
+		return fmt.Errorf("webhook_url must be an https URL")
+	}
+	host := strings.ToLower(u.Hostname())
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") || strings.HasSuffix(host, ".internal") {
+		return fmt.Errorf("webhook_url must be a public address")
+	}
+	if ip := net.ParseIP(host); ip != nil && !netguard.IsPublicIP(ip) {
+		return fmt.Errorf("webhook_url must be a public address")
+	}
+	return nil
+}
+
+func newWebhookSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// deleteFolderWatchesWithTx 삭제되는 폴더의 구독 정리
+func deleteFolderWatchesWithTx(tx *gorm.DB, folderIDs []int64) {
+	tx.Where("folder_id IN ?", folderIDs).Delete(&model.FolderWatch{})
+}
+
+func toFolderWatchResponse(w *model.FolderWatch, folderName string) FolderWatchResponse {
+	return FolderWatchResponse{
+		FolderID:   w.FolderID,
+		FolderName: folderName,
+		Watching:   true,
+		WebhookURL: w.WebhookURL,
+		CreatedAt:  w.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
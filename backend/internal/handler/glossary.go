@@ -0,0 +1,389 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+
+	"realtime-backend/internal/auth"
+	awsai "realtime-backend/internal/aws"
+	"realtime-backend/internal/config"
+	"realtime-backend/internal/language"
+	"realtime-backend/internal/model"
+)
+
+var errAWSNotConfigured = errors.New("AWS credentials are not configured")
+
+// GlossaryHandler 워크스페이스 용어집 관리 (Amazon Translate 사용자 지정 용어 동기화)
+type GlossaryHandler struct {
+	db  *gorm.DB
+	cfg *config.Config
+}
+
+// NewGlossaryHandler GlossaryHandler 생성
+func NewGlossaryHandler(db *gorm.DB, cfg *config.Config) *GlossaryHandler {
+	return &GlossaryHandler{db: db, cfg: cfg}
+}
+
+// GlossaryTermRequest 용어 생성/수정 요청
+type GlossaryTermRequest struct {
+	SourceLang string `json:"source_lang"`
+	TargetLang string `json:"target_lang"`
+	SourceTerm string `json:"source_term"`
+	TargetTerm string `json:"target_term"`
+}
+
+// GlossaryTermResponse 용어 응답
+type GlossaryTermResponse struct {
+	ID         int64  `json:"id"`
+	SourceLang string `json:"source_lang"`
+	TargetLang string `json:"target_lang"`
+	SourceTerm string `json:"source_term"`
+	TargetTerm string `json:"target_term"`
+	CreatedBy  *int64 `json:"created_by,omitempty"`
+	UpdatedAt  string `json:"updated_at"`
+}
+
+// GetGlossary 용어집 조회 (?source_lang=&target_lang=)
+func (h *GlossaryHandler) GetGlossary(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	workspaceID, err := c.ParamsInt("workspaceId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid workspace id",
+		})
+	}
+
+	if !h.isWorkspaceMember(int64(workspaceID), claims.UserID) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "you are not a member of this workspace",
+		})
+	}
+
+	query := h.db.Where("workspace_id = ?", workspaceID)
+	if src := c.Query("source_lang"); src != "" {
+		query = query.Where("source_lang = ?", src)
+	}
+	if tgt := c.Query("target_lang"); tgt != "" {
+		query = query.Where("target_lang = ?", tgt)
+	}
+
+	var terms []model.GlossaryTerm
+	if err := query.Order("source_lang, target_lang, source_term").Find(&terms).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get glossary",
+		})
+	}
+
+	var terminologies []model.GlossaryTerminology
+	h.db.Where("workspace_id = ?", workspaceID).Find(&terminologies)
+
+	responses := make([]GlossaryTermResponse, len(terms))
+	for i := range terms {
+		responses[i] = toGlossaryTermResponse(&terms[i])
+	}
+
+	return c.JSON(fiber.Map{
+		"terms":         responses,
+		"total":         len(responses),
+		"terminologies": terminologies,
+	})
+}
+
+// CreateGlossaryTerm 용어 추가
+func (h *GlossaryHandler) CreateGlossaryTerm(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	workspaceID, err := c.ParamsInt("workspaceId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid workspace id",
+		})
+	}
+
+	if !h.canManageGlossary(c, int64(workspaceID), claims.UserID) {
+		return nil
+	}
+
+	var req GlossaryTermRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+	if msg := normalizeGlossaryRequest(&req); msg != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": msg,
+		})
+	}
+
+	var count int64
+	h.db.Model(&model.GlossaryTerm{}).
+		Where("workspace_id = ? AND source_lang = ? AND target_lang = ? AND source_term = ?",
+			workspaceID, req.SourceLang, req.TargetLang, req.SourceTerm).
+		Count(&count)
+	if count > 0 {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "term already exists for this language pair",
+		})
+	}
+
+	term := model.GlossaryTerm{
+		WorkspaceID: int64(workspaceID),
+		SourceLang:  req.SourceLang,
+		TargetLang:  req.TargetLang,
+		SourceTerm:  req.SourceTerm,
+		TargetTerm:  req.TargetTerm,
+		CreatedBy:   &claims.UserID,
+	}
+	if err := h.db.Create(&term).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to create term",
+		})
+	}
+
+	terminology := h.syncTerminology(int64(workspaceID), term.SourceLang, term.TargetLang)
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"term":        toGlossaryTermResponse(&term),
+		"terminology": terminology,
+	})
+}
+
+// UpdateGlossaryTerm 용어 수정
+func (h *GlossaryHandler) UpdateGlossaryTerm(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	workspaceID, err := c.ParamsInt("workspaceId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid workspace id",
+		})
+	}
+	termID, err := c.ParamsInt("termId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid term id",
+		})
+	}
+
+	if !h.canManageGlossary(c, int64(workspaceID), claims.UserID) {
+		return nil
+	}
+
+	var term model.GlossaryTerm
+	if err := h.db.Where("id = ? AND workspace_id = ?", termID, workspaceID).First(&term).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "term not found",
+		})
+	}
+
+	var req GlossaryTermRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+
+	// 언어 쌍은 변경 불가 (삭제 후 재등록)
+	req.SourceLang = term.SourceLang
+	req.TargetLang = term.TargetLang
+	if req.SourceTerm == "" {
+		req.SourceTerm = term.SourceTerm
+	}
+	if msg := normalizeGlossaryRequest(&req); msg != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": msg,
+		})
+	}
+
+	term.SourceTerm = req.SourceTerm
+	term.TargetTerm = req.TargetTerm
+	if err := h.db.Save(&term).Error; err != nil {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "failed to update term",
+		})
+	}
+
+	terminology := h.syncTerminology(int64(workspaceID), term.SourceLang, term.TargetLang)
+
+	return c.JSON(fiber.Map{
+		"term":        toGlossaryTermResponse(&term),
+		"terminology": terminology,
+	})
+}
+
+// DeleteGlossaryTerm 용어 삭제
+func (h *GlossaryHandler) DeleteGlossaryTerm(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	workspaceID, err := c.ParamsInt("workspaceId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid workspace id",
+		})
+	}
+	termID, err := c.ParamsInt("termId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid term id",
+		})
+	}
+
+	if !h.canManageGlossary(c, int64(workspaceID), claims.UserID) {
+		return nil
+	}
+
+	var term model.GlossaryTerm
+	if err := h.db.Where("id = ? AND workspace_id = ?", termID, workspaceID).First(&term).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "term not found",
+		})
+	}
+
+	if err := h.db.Delete(&term).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to delete term",
+		})
+	}
+
+	terminology := h.syncTerminology(int64(workspaceID), term.SourceLang, term.TargetLang)
+
+	return c.JSON(fiber.Map{
+		"message":     "term deleted",
+		"terminology": terminology,
+	})
+}
+
+// syncTerminology 언어 쌍의 용어 전체를 Amazon Translate 사용자 지정 용어로 업로드
+// 실패해도 용어집 자체는 저장되어 있으므로 상태만 기록 (다음 변경 시 재시도)
+func (h *GlossaryHandler) syncTerminology(workspaceID int64, sourceLang, targetLang string) *model.GlossaryTerminology {
+	var terms []model.GlossaryTerm
+	h.db.Where("workspace_id = ? AND source_lang = ? AND target_lang = ?", workspaceID, sourceLang, targetLang).
+		Order("source_term").
+		Find(&terms)
+
+	var status model.GlossaryTerminology
+	h.db.Where("workspace_id = ? AND source_lang = ? AND target_lang = ?", workspaceID, sourceLang, targetLang).
+		First(&status)
+	status.WorkspaceID = workspaceID
+	status.SourceLang = sourceLang
+	status.TargetLang = targetLang
+	status.TerminologyName = awsai.TerminologyName(workspaceID, sourceLang, targetLang)
+	status.TermCount = len(terms)
+
+	if err := h.importTerminology(workspaceID, status.TerminologyName, sourceLang, targetLang, terms); err != nil {
+		msg := err.Error()
+		status.LastError = &msg
+		log.Printf("[Glossary] Failed to sync terminology %s: %v", status.TerminologyName, err)
+	} else {
+		now := time.Now()
+		status.SyncedAt = &now
+		status.LastError = nil
+	}
+
+	if err := h.db.Save(&status).Error; err != nil {
+		log.Printf("[Glossary] Failed to save terminology status %s: %v", status.TerminologyName, err)
+	}
+	return &status
+}
+
+func (h *GlossaryHandler) importTerminology(workspaceID int64, name, sourceLang, targetLang string, terms []model.GlossaryTerm) error {
+	if h.cfg == nil || h.cfg.S3.AccessKeyID == "" || h.cfg.S3.SecretAccessKey == "" {
+		return errAWSNotConfigured
+	}
+
+	// 워크스페이스 데이터 리전의 Translate에 등록
+	var region string
+	h.db.Model(&model.Workspace{}).
+		Where("id = ?", workspaceID).
+		Select("COALESCE(data_region, '')").
+		Scan(&region)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	client, err := awsai.NewRegionalTranslateClient(ctx, h.cfg, region)
+	if err != nil {
+		return err
+	}
+
+	entries := make([]awsai.TerminologyEntry, len(terms))
+	for i, t := range terms {
+		entries[i] = awsai.TerminologyEntry{Source: t.SourceTerm, Target: t.TargetTerm}
+	}
+	return client.ImportTerminology(ctx, name, sourceLang, targetLang, entries)
+}
+
+// canManageGlossary 용어집 관리 권한 확인 (MANAGE_GLOSSARY)
+// 실패 시 에러 응답을 기록하고 false 반환
+func (h *GlossaryHandler) canManageGlossary(c *fiber.Ctx, workspaceID, userID int64) bool {
+	hasPermission, err := auth.CheckPermission(h.db, workspaceID, userID, "MANAGE_GLOSSARY")
+	if err != nil {
+		c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to check permission",
+		})
+		return false
+	}
+	if !hasPermission {
+		c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "you do not have permission to manage glossary",
+		})
+		return false
+	}
+	return true
+}
+
+func (h *GlossaryHandler) isWorkspaceMember(workspaceID, userID int64) bool {
+	var count int64
+	h.db.Model(&model.WorkspaceMember{}).
+		Where("workspace_id = ? AND user_id = ? AND status = ?", workspaceID, userID, model.MemberStatusActive.String()).
+		Count(&count)
+	return count > 0
+}
+
+// normalizeGlossaryRequest 요청 값 정리 및 검증 (에러 메시지 반환, 정상이면 "")
+func normalizeGlossaryRequest(req *GlossaryTermRequest) string {
+	req.SourceLang = language.Normalize(req.SourceLang)
+	req.TargetLang = language.Normalize(req.TargetLang)
+	req.SourceTerm = strings.TrimSpace(sanitizeString(req.SourceTerm))
+	req.TargetTerm = strings.TrimSpace(sanitizeString(req.TargetTerm))
+
+	// 용어집은 번역 지원 언어에서만 사용 가능
+	if !isTranslateLanguage(req.SourceLang) || !isTranslateLanguage(req.TargetLang) {
+		return "unsupported language (see /api/languages)"
+	}
+	if req.SourceLang == req.TargetLang {
+		return "source and target language must differ"
+	}
+	if req.SourceTerm == "" || req.TargetTerm == "" {
+		return "source_term and target_term are required"
+	}
+	if len(req.SourceTerm) > 200 || len(req.TargetTerm) > 200 {
+		return "term is too long (max 200)"
+	}
+	// CSV 용어 파일에 개행이 들어가면 항목이 깨짐
+	if strings.ContainsAny(req.SourceTerm+req.TargetTerm, "\r\n") {
+		return "term must be a single line"
+	}
+	return ""
+}
+
+func toGlossaryTermResponse(t *model.GlossaryTerm) GlossaryTermResponse {
+	return GlossaryTermResponse{
+		ID:         t.ID,
+		SourceLang: t.SourceLang,
+		TargetLang: t.TargetLang,
+		SourceTerm: t.SourceTerm,
+		TargetTerm: t.TargetTerm,
+		CreatedBy:  t.CreatedBy,
+		UpdatedAt:  t.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
+
+func isTranslateLanguage(code string) bool {
+	l, ok := language.Get(code)
+	return ok && l.Translate
+}
@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"realtime-backend/internal/auth"
+	"realtime-backend/internal/model"
+)
+
+var errNotGuestMember = errors.New("user is already a workspace member")
+
+// DenyGuests 게스트 역할 멤버의 채팅/파일 API 접근 차단 (:workspaceId 경로에 거는 미들웨어)
+func DenyGuests(db *gorm.DB) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims, ok := c.Locals("claims").(*auth.Claims)
+		workspaceID, err := c.ParamsInt("workspaceId")
+		if !ok || err != nil {
+			return c.Next()
+		}
+
+		guest, err := auth.IsWorkspaceGuest(db, int64(workspaceID), claims.UserID)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to check permission"})
+		}
+		if guest {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "guests can only join meetings they are invited to"})
+		}
+		return c.Next()
+	}
+}
+
+// InviteMeetingGuest 회의 전용 게스트 초대 (MANAGE_MEMBERS)
+// 워크스페이스 멤버가 아니면 게스트 역할로 초대하고, 이미 게스트면 이 회의 접속 권한만 추가
+func (h *WorkspaceHandler) InviteMeetingGuest(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	workspaceID, err := c.ParamsInt("workspaceId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid workspace id"})
+	}
+	meetingID, err := c.ParamsInt("meetingId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid meeting id"})
+	}
+
+	var req struct {
+		UserID int64 `json:"user_id"`
+	}
+	if err := c.BodyParser(&req); err != nil || req.UserID == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "user_id is required"})
+	}
+
+	if !h.requireManageMembers(c, int64(workspaceID), claims.UserID) {
+		return nil
+	}
+
+	var workspace model.Workspace
+	if err := h.db.Select("id", "name", "owner_id").First(&workspace, workspaceID).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "workspace not found"})
+	}
+	if workspace.OwnerID == req.UserID {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": errNotGuestMember.Error()})
+	}
+
+	var meeting model.Meeting
+	if err := h.db.Select("id", "title").
+		Where("id = ? AND workspace_id = ? AND type NOT IN ?", meetingID, workspaceID, []string{model.MeetingTypeChatRoom.String(), model.MeetingTypeDM.String()}).
+		First(&meeting).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "meeting not found"})
+	}
+
+	var guestUser model.User
+	if err := h.db.Select("id").First(&guestUser, req.UserID).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "user not found"})
+	}
+
+	invited := false
+	err = h.db.Transaction(func(tx *gorm.DB) error {
+		guestRoleID, err := guestRoleIDWithTx(tx, workspace.ID)
+		if err != nil {
+			return err
+		}
+
+		var member model.WorkspaceMember
+		err = tx.Where("workspace_id = ? AND user_id = ?", workspace.ID, req.UserID).First(&member).Error
+		switch {
+		case err == nil:
+			if member.RoleID == nil || *member.RoleID != guestRoleID {
+				return errNotGuestMember
+			}
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			if err := tx.Create(&model.WorkspaceMember{
+				WorkspaceID: workspace.ID,
+				UserID:      req.UserID,
+				RoleID:      &guestRoleID,
+				Status:      model.MemberStatusPending.String(),
+			}).Error; err != nil {
+				return err
+			}
+			invited = true
+		default:
+			return err
+		}
+
+		// 이 회의에만 CONNECT_MEDIA 허용
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "meeting_id"}, {Name: "target_type"}, {Name: "target_id"}, {Name: "permission_code"}},
+			DoUpdates: clause.AssignmentColumns([]string{"effect", "updated_by"}),
+		}).Create(&model.ChannelPermissionOverride{
+			WorkspaceID:    workspace.ID,
+			MeetingID:      meeting.ID,
+			TargetType:     model.OverrideTargetUser,
+			TargetID:       req.UserID,
+			PermissionCode: model.PermissionConnectMedia,
+			Effect:         model.OverrideEffectAllow,
+			UpdatedBy:      &claims.UserID,
+		}).Error
+	})
+	if errors.Is(err, errNotGuestMember) {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": errNotGuestMember.Error()})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to invite guest"})
+	}
+	auth.InvalidateMemberPermissions(workspace.ID, req.UserID)
+	auth.InvalidateChannelPermissions(workspace.ID, meeting.ID)
+
+	recordAuditEvent(h.db, workspace.ID, &claims.UserID, model.AuditMemberInvited, model.AuditTargetMember, req.UserID, map[string]interface{}{
+		"guest":      true,
+		"meeting_id": meeting.ID,
+	})
+
+	if invited {
+		var inviter model.User
+		h.db.Select("id", "nickname").First(&inviter, claims.UserID)
+		CreateWorkspaceInviteNotification(h.db, claims.UserID, req.UserID, workspace.ID, workspace.Name, inviter.Nickname)
+	} else {
+		content := fmt.Sprintf("%s 워크스페이스의 '%s' 회의에 게스트로 초대되었습니다.", workspace.Name, meeting.Title)
+		relatedType := "MEETING"
+		if err := CreateNotification(h.db, req.UserID, &claims.UserID, model.NotificationTypeMeetingAlert.String(), content, &relatedType, &meeting.ID); err != nil {
+			log.Printf("⚠️ 게스트 회의 초대 알림 생성 실패: meeting=%d, user=%d, err=%v", meeting.ID, req.UserID, err)
+		}
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"workspace_id": workspace.ID,
+		"meeting_id":   meeting.ID,
+		"user_id":      req.UserID,
+		"invited":      invited,
+	})
+}
@@ -6,6 +6,8 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	"gorm.io/gorm"
+
+	"realtime-backend/internal/metrics"
 )
 
 // HealthHandler 헬스체크 핸들러
@@ -109,3 +111,9 @@ func (h *HealthHandler) Readiness(c *fiber.Ctx) error {
 	}
 	return c.SendString("READY")
 }
+
+// Metrics Prometheus 지표 (고루틴 수명/누수 카운터 등)
+func (h *HealthHandler) Metrics(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderContentType, "text/plain; version=0.0.4; charset=utf-8")
+	return c.SendString(metrics.Render())
+}
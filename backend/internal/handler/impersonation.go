@@ -0,0 +1,278 @@
+package handler
+
+import (
+	"log"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+
+	"realtime-backend/internal/auth"
+	"realtime-backend/internal/model"
+	"realtime-backend/internal/service"
+)
+
+// ImpersonationHandler 플랫폼 관리자 대리 접속 (지원/디버깅용)
+type ImpersonationHandler struct {
+	db          *gorm.DB
+	jwtManager  *auth.JWTManager
+	audit       *service.ImpersonationService
+	maxDuration time.Duration
+}
+
+// NewImpersonationHandler ImpersonationHandler 생성
+func NewImpersonationHandler(db *gorm.DB, jwtManager *auth.JWTManager, audit *service.ImpersonationService, maxDuration time.Duration) *ImpersonationHandler {
+	return &ImpersonationHandler{db: db, jwtManager: jwtManager, audit: audit, maxDuration: maxDuration}
+}
+
+// StartImpersonationRequest 대리 접속 시작 요청
+type StartImpersonationRequest struct {
+	UserID          int64  `json:"user_id"`
+	Reason          string `json:"reason"`
+	AllowWrite      bool   `json:"allow_write"`                // 기본은 읽기 전용
+	DurationMinutes int    `json:"duration_minutes,omitempty"` // 최대 maxDuration
+}
+
+// ImpersonationSessionResponse 대리 접속 세션 응답
+type ImpersonationSessionResponse struct {
+	ID         int64         `json:"id"`
+	AdminID    int64         `json:"admin_id"`
+	TargetUser *UserResponse `json:"target_user,omitempty"`
+	Reason     string        `json:"reason"`
+	ReadOnly   bool          `json:"read_only"`
+	Active     bool          `json:"active"`
+	ExpiresAt  string        `json:"expires_at"`
+	EndedAt    *string       `json:"ended_at,omitempty"`
+	CreatedAt  string        `json:"created_at"`
+}
+
+// StartImpersonation 대리 접속 시작 (토큰은 응답 본문으로만 전달, 쿠키 미설정)
+func (h *ImpersonationHandler) StartImpersonation(c *fiber.Ctx) error {
+	admin, ok := requirePlatformAdmin(c, h.db)
+	if !ok {
+		return nil
+	}
+
+	var req StartImpersonationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+
+	req.Reason = strings.TrimSpace(sanitizeString(req.Reason))
+	if req.UserID == 0 || req.Reason == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "user_id and reason are required",
+		})
+	}
+	if req.UserID == admin.ID {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "cannot impersonate yourself",
+		})
+	}
+
+	var target model.User
+	if err := h.db.First(&target, req.UserID).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "user not found",
+		})
+	}
+	if target.IsPlatformAdmin {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "cannot impersonate another platform admin",
+		})
+	}
+
+	duration := h.maxDuration
+	if req.DurationMinutes > 0 && time.Duration(req.DurationMinutes)*time.Minute < duration {
+		duration = time.Duration(req.DurationMinutes) * time.Minute
+	}
+
+	ip := c.IP()
+	session := model.ImpersonationSession{
+		AdminID:      admin.ID,
+		TargetUserID: target.ID,
+		Reason:       req.Reason,
+		ReadOnly:     !req.AllowWrite,
+		ExpiresAt:    time.Now().Add(duration),
+		IPAddress:    &ip,
+	}
+	if err := h.db.Create(&session).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to start impersonation",
+		})
+	}
+
+	token, err := h.jwtManager.GenerateImpersonationToken(target.ID, target.Email, target.Nickname, auth.ImpersonationClaim{
+		SessionID: session.ID,
+		AdminID:   admin.ID,
+		ReadOnly:  session.ReadOnly,
+	}, session.ExpiresAt)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to generate token",
+		})
+	}
+
+	log.Printf("🚨 [IMPERSONATION] START session=%d admin=%d (%s) as user=%d (%s) read_only=%v expires=%s reason=%q",
+		session.ID, admin.ID, admin.Email, target.ID, target.Email, session.ReadOnly,
+		session.ExpiresAt.Format(time.RFC3339), session.Reason)
+	h.audit.Record(&model.ImpersonationAuditLog{
+		SessionID:    session.ID,
+		AdminID:      admin.ID,
+		TargetUserID: target.ID,
+		Action:       model.ImpersonationActionStart.String(),
+	})
+
+	session.TargetUser = target
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"session":      toImpersonationSessionResponse(&session),
+		"access_token": token,
+	})
+}
+
+// EndImpersonation 대리 접속 종료 (즉시 토큰 무효화)
+func (h *ImpersonationHandler) EndImpersonation(c *fiber.Ctx) error {
+	admin, ok := requirePlatformAdmin(c, h.db)
+	if !ok {
+		return nil
+	}
+
+	sessionID, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid session id",
+		})
+	}
+
+	var session model.ImpersonationSession
+	if err := h.db.Preload("TargetUser").First(&session, sessionID).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "session not found",
+		})
+	}
+
+	if session.EndedAt == nil {
+		now := time.Now()
+		if err := h.db.Model(&session).Update("ended_at", now).Error; err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "failed to end impersonation",
+			})
+		}
+		session.EndedAt = &now
+
+		log.Printf("🚨 [IMPERSONATION] END session=%d by admin=%d", session.ID, admin.ID)
+		h.audit.Record(&model.ImpersonationAuditLog{
+			SessionID:    session.ID,
+			AdminID:      admin.ID,
+			TargetUserID: session.TargetUserID,
+			Action:       model.ImpersonationActionEnd.String(),
+		})
+	}
+
+	return c.JSON(toImpersonationSessionResponse(&session))
+}
+
+// GetImpersonationSessions 대리 접속 세션 목록 (?active=true)
+func (h *ImpersonationHandler) GetImpersonationSessions(c *fiber.Ctx) error {
+	if _, ok := requirePlatformAdmin(c, h.db); !ok {
+		return nil
+	}
+
+	query := h.db.Preload("TargetUser").Order("created_at DESC").Limit(100)
+	if c.QueryBool("active") {
+		query = query.Where("ended_at IS NULL AND expires_at > ?", time.Now())
+	}
+
+	var sessions []model.ImpersonationSession
+	if err := query.Find(&sessions).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get sessions",
+		})
+	}
+
+	responses := make([]ImpersonationSessionResponse, len(sessions))
+	for i := range sessions {
+		responses[i] = toImpersonationSessionResponse(&sessions[i])
+	}
+
+	return c.JSON(fiber.Map{
+		"sessions": responses,
+		"total":    len(responses),
+	})
+}
+
+// GetImpersonationAuditLogs 대리 접속 세션 감사 기록
+func (h *ImpersonationHandler) GetImpersonationAuditLogs(c *fiber.Ctx) error {
+	if _, ok := requirePlatformAdmin(c, h.db); !ok {
+		return nil
+	}
+
+	sessionID, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid session id",
+		})
+	}
+
+	var logs []model.ImpersonationAuditLog
+	if err := h.db.Where("session_id = ?", sessionID).Order("created_at ASC").Find(&logs).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get audit logs",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"session_id": sessionID,
+		"logs":       logs,
+		"total":      len(logs),
+	})
+}
+
+// requirePlatformAdmin 플랫폼 관리자 확인 (대리 접속 토큰으로는 호출 불가)
+// 실패 시 에러 응답을 기록하고 false 반환
+func requirePlatformAdmin(c *fiber.Ctx, db *gorm.DB) (*model.User, bool) {
+	claims := c.Locals("claims").(*auth.Claims)
+	if claims.IsImpersonating() {
+		c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "not allowed during impersonation",
+		})
+		return nil, false
+	}
+
+	var user model.User
+	if err := db.First(&user, claims.UserID).Error; err != nil || !user.IsPlatformAdmin {
+		c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "platform admin only",
+		})
+		return nil, false
+	}
+	return &user, true
+}
+
+func toImpersonationSessionResponse(s *model.ImpersonationSession) ImpersonationSessionResponse {
+	resp := ImpersonationSessionResponse{
+		ID:        s.ID,
+		AdminID:   s.AdminID,
+		Reason:    s.Reason,
+		ReadOnly:  s.ReadOnly,
+		Active:    s.IsActive(),
+		ExpiresAt: s.ExpiresAt.Format("2006-01-02T15:04:05Z07:00"),
+		CreatedAt: s.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	if s.EndedAt != nil {
+		t := s.EndedAt.Format("2006-01-02T15:04:05Z07:00")
+		resp.EndedAt = &t
+	}
+	if s.TargetUser.ID != 0 {
+		resp.TargetUser = &UserResponse{
+			ID:         s.TargetUser.ID,
+			Email:      s.TargetUser.Email,
+			Nickname:   s.TargetUser.Nickname,
+			ProfileImg: s.TargetUser.ProfileImg,
+		}
+	}
+	return resp
+}
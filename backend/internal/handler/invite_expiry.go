@@ -0,0 +1,203 @@
+package handler
+
+import (
+	"log"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+
+	"realtime-backend/internal/auth"
+	"realtime-backend/internal/errorreport"
+	"realtime-backend/internal/model"
+)
+
+const (
+	inviteSweepInterval = 10 * time.Minute
+	inviteSweepBatch    = 100
+
+	// expiredInviteRetention 만료된 초대 알림을 목록에 남겨 두는 기간 (지나면 삭제)
+	expiredInviteRetention = 30 * 24 * time.Hour
+)
+
+// workspaceInviteTTL 새 워크스페이스 초대의 유효 기간 (0이면 만료 없음)
+var workspaceInviteTTL time.Duration
+
+// SetInviteTTL 초대 유효 기간 설정 및 만료 초대 정리 시작
+func (h *NotificationHandler) SetInviteTTL(ttl time.Duration) {
+	if ttl <= 0 {
+		return
+	}
+	workspaceInviteTTL = ttl
+	if h.inviteDone == nil {
+		h.inviteDone = make(chan struct{})
+		go h.runInviteSweeper(h.inviteDone)
+	}
+}
+
+// Close 만료 초대 정리, 보류된 알림 요약 전송 중단
+func (h *NotificationHandler) Close() {
+	if h.inviteDone != nil {
+		close(h.inviteDone)
+		h.inviteDone = nil
+	}
+	if h.summaryDone != nil {
+		close(h.summaryDone)
+		h.summaryDone = nil
+	}
+}
+
+// inviteExpiresAt 지금 만드는 초대의 만료 시각 (유효 기간이 없으면 nil)
+func inviteExpiresAt() *time.Time {
+	if workspaceInviteTTL <= 0 {
+		return nil
+	}
+	t := time.Now().Add(workspaceInviteTTL)
+	return &t
+}
+
+// inviteExpired 아직 정리되지 않았지만 유효 기간이 지난 초대 알림인지
+func inviteExpired(n *model.Notification) bool {
+	return n.ExpiredAt == nil && n.ExpiresAt != nil && time.Now().After(*n.ExpiresAt)
+}
+
+// inviteGone 더 이상 수락/거절할 수 없는 초대 응답 (410)
+func inviteGone(c *fiber.Ctx, n *model.Notification) error {
+	message := "this invitation has been revoked"
+	if inviteExpired(n) || (n.ExpiredReason != nil && *n.ExpiredReason == model.InviteExpiredTTL) {
+		message = "this invitation has expired"
+	}
+	return c.Status(fiber.StatusGone).JSON(fiber.Map{
+		"error":  message,
+		"reason": notificationExpiredReason(n),
+	})
+}
+
+// notificationExpiredReason 응답에 내보낼 만료 사유 (정리 전이라도 기간이 지났으면 EXPIRED)
+func notificationExpiredReason(n *model.Notification) *string {
+	if n.ExpiredReason != nil {
+		return n.ExpiredReason
+	}
+	if n.ExpiredAt != nil {
+		reason := model.InviteExpiredRevoked
+		return &reason
+	}
+	if inviteExpired(n) {
+		reason := model.InviteExpiredTTL
+		return &reason
+	}
+	return nil
+}
+
+// expireInvitation 유효 기간이 지난 초대를 만료 처리 (대기 중인 멤버십 삭제, 알림 만료 표시 후 실시간 갱신)
+func expireInvitation(db *gorm.DB, n *model.Notification) error {
+	if n.RelatedID == nil {
+		return nil
+	}
+	workspaceID := *n.RelatedID
+	now := time.Now()
+	reason := model.InviteExpiredTTL
+
+	var expired []model.Notification
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("workspace_id = ? AND user_id = ? AND status = ?", workspaceID, n.ReceiverID, model.MemberStatusPending.String()).
+			Delete(&model.WorkspaceMember{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("receiver_id = ? AND type = ? AND related_id = ? AND expired_at IS NULL",
+			n.ReceiverID, model.NotificationTypeWorkspaceInvite.String(), workspaceID).
+			Find(&expired).Error; err != nil {
+			return err
+		}
+		return tx.Model(&model.Notification{}).
+			Where("receiver_id = ? AND type = ? AND related_id = ? AND expired_at IS NULL",
+				n.ReceiverID, model.NotificationTypeWorkspaceInvite.String(), workspaceID).
+			Updates(map[string]interface{}{"expired_at": now, "expired_reason": reason}).Error
+	})
+	if err != nil {
+		return err
+	}
+	auth.InvalidateMemberPermissions(workspaceID, n.ReceiverID)
+
+	// 알림 목록을 열어 둔 클라이언트가 초대 버튼을 바로 비활성화하도록
+	if ws := GetNotificationWSHandler(); ws != nil && len(expired) > 0 {
+		payloads := make([]NotificationPayload, len(expired))
+		for i := range expired {
+			expired[i].ExpiredAt = &now
+			expired[i].ExpiredReason = &reason
+			payloads[i] = toNotificationPayload(&expired[i], nil)
+		}
+		ws.Deliver(payloads)
+	}
+	return nil
+}
+
+func (h *NotificationHandler) runInviteSweeper(done <-chan struct{}) {
+	defer errorreport.Recover(errorreport.Context{Component: "notifications.invite_sweeper"})
+
+	h.backfillInviteExpiry()
+
+	ticker := time.NewTicker(inviteSweepInterval)
+	defer ticker.Stop()
+
+	for {
+		h.sweepExpiredInvites(done)
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// backfillInviteExpiry 유효 기간이 도입되기 전에 만든 대기 중인 초대에 만료 시각 지정 (생성 시각 기준)
+func (h *NotificationHandler) backfillInviteExpiry() {
+	err := h.db.Exec(`
+		UPDATE notifications SET expires_at = created_at + ? * INTERVAL '1 second'
+		WHERE type = ? AND expires_at IS NULL AND expired_at IS NULL
+		  AND EXISTS (
+			SELECT 1 FROM workspace_members wm
+			WHERE wm.workspace_id = notifications.related_id
+			  AND wm.user_id = notifications.receiver_id
+			  AND wm.status = ?)
+	`, int64(workspaceInviteTTL/time.Second), model.NotificationTypeWorkspaceInvite.String(), model.MemberStatusPending.String()).Error
+	if err != nil {
+		log.Printf("⚠️ 초대 만료 시각 지정 실패: %v", err)
+	}
+}
+
+// sweepExpiredInvites 유효 기간이 지난 초대 만료 처리, 오래된 만료 초대 알림 삭제
+func (h *NotificationHandler) sweepExpiredInvites(done <-chan struct{}) {
+	for {
+		var invites []model.Notification
+		if err := h.db.Where("type = ? AND expired_at IS NULL AND expires_at < ?", model.NotificationTypeWorkspaceInvite.String(), time.Now()).
+			Order("id").
+			Limit(inviteSweepBatch).
+			Find(&invites).Error; err != nil {
+			log.Printf("⚠️ 만료된 초대 조회 실패: %v", err)
+			return
+		}
+		for i := range invites {
+			if err := expireInvitation(h.db, &invites[i]); err != nil {
+				log.Printf("⚠️ 초대 만료 처리 실패: notification=%d, err=%v", invites[i].ID, err)
+				return
+			}
+		}
+		if len(invites) < inviteSweepBatch {
+			break
+		}
+		select {
+		case <-done:
+			return
+		default:
+		}
+	}
+
+	result := h.db.Where("type = ? AND expired_at < ?", model.NotificationTypeWorkspaceInvite.String(), time.Now().Add(-expiredInviteRetention)).
+		Delete(&model.Notification{})
+	if result.Error != nil {
+		log.Printf("⚠️ 만료된 초대 알림 삭제 실패: %v", result.Error)
+	} else if result.RowsAffected > 0 {
+		log.Printf("🧹 만료된 초대 알림 %d개 삭제", result.RowsAffected)
+	}
+}
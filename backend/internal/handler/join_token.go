@@ -0,0 +1,205 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/contrib/websocket"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+
+	"realtime-backend/internal/auth"
+	"realtime-backend/internal/cache"
+	"realtime-backend/internal/language"
+	"realtime-backend/internal/model"
+)
+
+const maxJoinRoomIDLength = 128
+
+// JoinTokenHandler 음성 Room 일회용 입장 토큰 발급 및 WebSocket 업그레이드 검증
+type JoinTokenHandler struct {
+	db         *gorm.DB
+	jwtManager *auth.JWTManager
+	store      auth.JoinTokenStore
+	ttl        time.Duration
+	required   bool
+}
+
+// NewJoinTokenHandler JoinTokenHandler 생성 (Redis가 있으면 인스턴스 간 재사용 방지)
+func NewJoinTokenHandler(db *gorm.DB, jwtManager *auth.JWTManager, redisClient *cache.RedisClient, ttl time.Duration, required bool) *JoinTokenHandler {
+	var store auth.JoinTokenStore = auth.NewMemoryJoinTokenStore()
+	if redisClient != nil {
+		store = &redisJoinTokenStore{client: redisClient}
+	}
+	return &JoinTokenHandler{db: db, jwtManager: jwtManager, store: store, ttl: ttl, required: required}
+}
+
+// CreateJoinTokenRequest 입장 토큰 발급 요청
+type CreateJoinTokenRequest struct {
+	RoomID string `json:"room_id"`
+	Role   string `json:"role"` // speaker, listener
+	Lang   string `json:"lang"` // speaker: 발화 언어, listener: 번역 대상 언어
+}
+
+// JoinTokenResponse 입장 토큰 응답
+type JoinTokenResponse struct {
+	Token     string `json:"token"`
+	RoomID    string `json:"room_id"`
+	Role      string `json:"role"`
+	Lang      string `json:"lang"`
+	ExpiresAt string `json:"expires_at"`
+}
+
+// CreateJoinToken 입장 토큰 발급 (/ws/audio, /ws/room 연결 시 ?token= 으로 한 번만 사용)
+func (h *JoinTokenHandler) CreateJoinToken(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+
+	var req CreateJoinTokenRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+
+	req.RoomID = strings.TrimSpace(req.RoomID)
+	if req.RoomID == "" || len(req.RoomID) > maxJoinRoomIDLength {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "room_id is required (max 128 characters)",
+		})
+	}
+
+	if req.Role != auth.JoinRoleSpeaker && req.Role != auth.JoinRoleListener {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "role must be speaker or listener",
+		})
+	}
+
+	lang := language.Normalize(req.Lang)
+	if lang == "" || (req.Role == auth.JoinRoleSpeaker && !language.SupportsSTT(lang)) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "unsupported language",
+		})
+	}
+
+	if ok := h.checkRoomAccess(c, req.RoomID, claims.UserID); !ok {
+		return nil
+	}
+
+	token, expiresAt, err := h.jwtManager.GenerateJoinToken(claims.UserID, claims.Nickname, req.RoomID, req.Role, lang, h.ttl)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to generate join token",
+		})
+	}
+
+	return c.JSON(JoinTokenResponse{
+		Token:     token,
+		RoomID:    req.RoomID,
+		Role:      req.Role,
+		Lang:      lang,
+		ExpiresAt: expiresAt.Format("2006-01-02T15:04:05Z07:00"),
+	})
+}
+
+// checkRoomAccess 미팅 Room(meeting-{id})이면 종료 여부와 워크스페이스 멤버 여부 확인 (실패 시 응답 작성)
+func (h *JoinTokenHandler) checkRoomAccess(c *fiber.Ctx, roomID string, userID int64) (ok bool) {
+	if !strings.HasPrefix(roomID, "meeting-") {
+		return true
+	}
+
+	meetingID, err := strconv.ParseInt(strings.TrimPrefix(roomID, "meeting-"), 10, 64)
+	if err != nil {
+		c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid room id"})
+		return false
+	}
+
+	var meeting model.Meeting
+	if err := h.db.Select("id, workspace_id, status").First(&meeting, meetingID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "meeting not found"})
+			return false
+		}
+		c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get meeting"})
+		return false
+	}
+	if meeting.Status == "ENDED" {
+		c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "meeting has ended"})
+		return false
+	}
+
+	if meeting.WorkspaceID != nil {
+		var count int64
+		h.db.Model(&model.WorkspaceMember{}).
+			Where("workspace_id = ? AND user_id = ? AND status = ?", *meeting.WorkspaceID, userID, model.MemberStatusActive.String()).
+			Count(&count)
+		if count == 0 {
+			c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "you are not a member of this workspace"})
+			return false
+		}
+	}
+	return true
+}
+
+// RequireJoinToken /ws/audio, /ws/room 업그레이드 미들웨어
+// ?token= 이 있으면 검증 후 쿼리의 참가자 ID/Room/언어를 토큰 값으로 덮어써 위조를 막음
+// 토큰이 없으면 ROOM_JOIN_TOKEN_REQUIRED 설정에 따라 거부하거나 기존 쿼리 방식 허용
+func (h *JoinTokenHandler) RequireJoinToken(c *fiber.Ctx) error {
+	if !websocket.IsWebSocketUpgrade(c) {
+		return fiber.ErrUpgradeRequired
+	}
+
+	tokenString := c.Query("token")
+	if tokenString == "" {
+		if h.required {
+			return RejectWebSocket(c, WSCloseUnauthorized, "join token required")
+		}
+		return c.Next()
+	}
+
+	claims, err := h.jwtManager.ValidateJoinToken(tokenString, h.store)
+	switch {
+	case errors.Is(err, auth.ErrJoinTokenReused):
+		return RejectWebSocket(c, WSCloseUnauthorized, "join token already used")
+	case errors.Is(err, auth.ErrExpiredToken):
+		return RejectWebSocket(c, WSCloseUnauthorized, "join token expired")
+	case err != nil:
+		return RejectWebSocket(c, WSCloseUnauthorized, "invalid join token")
+	}
+
+	if roomID := c.Query("roomId"); roomID != "" && roomID != claims.RoomID {
+		return RejectWebSocket(c, WSCloseForbidden, "join token is for another room")
+	}
+
+	args := c.Request().URI().QueryArgs()
+	userID := strconv.FormatInt(claims.UserID, 10)
+	args.Set("roomId", claims.RoomID)
+	if claims.Role == auth.JoinRoleSpeaker {
+		args.Set("participantId", userID)
+		args.Set("sourceLang", claims.Lang)
+	} else {
+		args.Set("listenerId", userID)
+		args.Set("targetLang", claims.Lang)
+	}
+
+	c.Locals("joinClaims", claims)
+	return c.Next()
+}
+
+// redisJoinTokenStore Redis SETNX 기반 입장 토큰 저장소 (여러 인스턴스에서 공유)
+type redisJoinTokenStore struct {
+	client *cache.RedisClient
+}
+
+func (s *redisJoinTokenStore) Consume(tokenID string, expiresAt time.Time) (bool, error) {
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		return false, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	return s.client.SetNX(ctx, "room-join:"+tokenID, 1, ttl)
+}
@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+
+	"realtime-backend/internal/language"
+)
+
+// LanguageHandler 지원 언어 조회 및 워크스페이스 번역 언어 설정 핸들러
+type LanguageHandler struct {
+	db *gorm.DB
+}
+
+// NewLanguageHandler LanguageHandler 생성
+func NewLanguageHandler(db *gorm.DB) *LanguageHandler {
+	return &LanguageHandler{db: db}
+}
+
+// LanguagesResponse 지원 언어 목록 응답
+type LanguagesResponse struct {
+	Languages     []language.Language `json:"languages"`
+	DefaultSource string              `json:"default_source"`
+	DefaultTarget string              `json:"default_target"`
+}
+
+// GetLanguages 지원 언어 목록 조회 (?capability=stt|translate|tts 로 필터)
+func (h *LanguageHandler) GetLanguages(c *fiber.Ctx) error {
+	capability := strings.ToLower(c.Query("capability"))
+
+	languages := make([]language.Language, 0)
+	for _, l := range language.All() {
+		switch capability {
+		case "":
+		case "stt":
+			if !l.STT {
+				continue
+			}
+		case "translate":
+			if !l.Translate {
+				continue
+			}
+		case "tts":
+			if !l.TTS {
+				continue
+			}
+		default:
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "capability must be stt, translate or tts",
+			})
+		}
+		languages = append(languages, l)
+	}
+
+	return c.JSON(LanguagesResponse{
+		Languages:     languages,
+		DefaultSource: language.DefaultSource,
+		DefaultTarget: language.DefaultTarget,
+	})
+}
@@ -0,0 +1,365 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+
+	"realtime-backend/internal/auth"
+	"realtime-backend/internal/mail"
+	"realtime-backend/internal/model"
+)
+
+const (
+	emailVerifyTTL    = 24 * time.Hour
+	passwordResetTTL  = time.Hour
+	minPasswordLength = 8
+	maxPasswordLength = 128
+
+	localAuthProvider = "local"
+)
+
+// dummyPasswordHash 없는 계정으로 로그인할 때도 같은 시간이 걸리도록 비교하는 해시 (계정 존재 여부 노출 방지)
+var dummyPasswordHash = sync.OnceValue(func() string {
+	hash, _ := auth.HashPassword("eum-dummy-password")
+	return hash
+})
+
+// RegisterRequest 이메일 회원가입 요청
+type RegisterRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+	Nickname string `json:"nickname"`
+}
+
+// LoginRequest 이메일 로그인 요청
+type LoginRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// AccountTokenRequest 메일로 받은 토큰 확인 요청
+type AccountTokenRequest struct {
+	Token string `json:"token"`
+}
+
+// AccountEmailRequest 인증 메일 재전송 / 비밀번호 재설정 메일 요청
+type AccountEmailRequest struct {
+	Email string `json:"email"`
+}
+
+// ResetPasswordRequest 비밀번호 재설정 요청
+type ResetPasswordRequest struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
+
+// validatePassword 비밀번호 길이 검증 (8-128자)
+func validatePassword(password string) error {
+	n := utf8.RuneCountInString(password)
+	if n < minPasswordLength {
+		return fmt.Errorf("password must be at least %d characters", minPasswordLength)
+	}
+	if n > maxPasswordLength {
+		return fmt.Errorf("password must be at most %d characters", maxPasswordLength)
+	}
+	return nil
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// Register 이메일/비밀번호 회원가입 (인증 메일 발송, 인증 전에는 로그인 불가)
+func (h *AuthHandler) Register(c *fiber.Ctx) error {
+	var req RegisterRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid request body"})
+	}
+
+	email := normalizeEmail(req.Email)
+	if !validateEmail(email) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid email format"})
+	}
+	nickname := sanitizeString(strings.TrimSpace(req.Nickname))
+	if !validateNickname(nickname) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid nickname"})
+	}
+	if err := validatePassword(req.Password); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	hash, err := auth.HashPassword(req.Password)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to hash password"})
+	}
+
+	provider := localAuthProvider
+	user := model.User{
+		Email:        email,
+		Nickname:     nickname,
+		Provider:     &provider,
+		PasswordHash: &hash,
+	}
+	var taken bool
+	err = h.db.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&model.User{}).Where("LOWER(email) = ?", email).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			taken = true
+			return nil
+		}
+		return tx.Create(&user).Error
+	})
+	if taken {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "email already registered"})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to create user"})
+	}
+
+	if err := h.sendAccountToken(&user, model.AccountTokenVerifyEmail); err != nil {
+		log.Printf("⚠️ 인증 메일 발송 실패: user=%d, err=%v", user.ID, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"user": UserResponse{
+			ID:       user.ID,
+			Email:    user.Email,
+			Nickname: user.Nickname,
+			Provider: user.Provider,
+		},
+		"message": "verification email sent",
+	})
+}
+
+// Login 이메일/비밀번호 로그인
+func (h *AuthHandler) Login(c *fiber.Ctx) error {
+	var req LoginRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid request body"})
+	}
+	email := normalizeEmail(req.Email)
+	if email == "" || req.Password == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "email and password are required"})
+	}
+
+	var user model.User
+	err := h.db.Where("LOWER(email) = ?", email).First(&user).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to load user"})
+	}
+
+	// 계정이 없거나 비밀번호가 없는 계정(Google)도 같은 응답
+	hash := dummyPasswordHash()
+	if err == nil && user.PasswordHash != nil {
+		hash = *user.PasswordHash
+	}
+	matched, _ := auth.CheckPassword(hash, req.Password)
+	if err != nil || user.PasswordHash == nil || !matched {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "invalid email or password"})
+	}
+
+	if user.EmailVerifiedAt == nil {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "email not verified",
+			"code":  "EMAIL_NOT_VERIFIED",
+		})
+	}
+
+	return h.issueSession(c, &user)
+}
+
+// VerifyEmail 메일의 인증 토큰 확인
+func (h *AuthHandler) VerifyEmail(c *fiber.Ctx) error {
+	var req AccountTokenRequest
+	if err := c.BodyParser(&req); err != nil || req.Token == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "token is required"})
+	}
+
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		token, err := consumeAccountToken(tx, req.Token, model.AccountTokenVerifyEmail)
+		if err != nil {
+			return err
+		}
+		return tx.Model(&model.User{}).
+			Where("id = ? AND email_verified_at IS NULL", token.UserID).
+			Update("email_verified_at", time.Now()).Error
+	})
+	if errors.Is(err, errInvalidAccountToken) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid or expired token"})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to verify email"})
+	}
+
+	return c.JSON(fiber.Map{"message": "email verified"})
+}
+
+// ResendVerification 인증 메일 재전송 (계정 존재 여부와 관계없이 같은 응답)
+func (h *AuthHandler) ResendVerification(c *fiber.Ctx) error {
+	var req AccountEmailRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid request body"})
+	}
+
+	var user model.User
+	if err := h.db.Where("LOWER(email) = ? AND password_hash IS NOT NULL AND email_verified_at IS NULL", normalizeEmail(req.Email)).
+		First(&user).Error; err == nil {
+		if err := h.sendAccountToken(&user, model.AccountTokenVerifyEmail); err != nil {
+			log.Printf("⚠️ 인증 메일 재전송 실패: user=%d, err=%v", user.ID, err)
+		}
+	}
+
+	return c.JSON(fiber.Map{"message": "if the account exists, a verification email has been sent"})
+}
+
+// ForgotPassword 비밀번호 재설정 메일 발송 (계정 존재 여부와 관계없이 같은 응답)
+func (h *AuthHandler) ForgotPassword(c *fiber.Ctx) error {
+	var req AccountEmailRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid request body"})
+	}
+
+	var user model.User
+	if err := h.db.Where("LOWER(email) = ? AND password_hash IS NOT NULL", normalizeEmail(req.Email)).
+		First(&user).Error; err == nil {
+		if err := h.sendAccountToken(&user, model.AccountTokenResetPassword); err != nil {
+			log.Printf("⚠️ 비밀번호 재설정 메일 발송 실패: user=%d, err=%v", user.ID, err)
+		}
+	}
+
+	return c.JSON(fiber.Map{"message": "if the account exists, a password reset email has been sent"})
+}
+
+// ResetPassword 메일의 토큰으로 새 비밀번호 설정
+// 메일을 받았다는 것은 주소 소유 확인이므로 미인증 계정도 인증 처리
+func (h *AuthHandler) ResetPassword(c *fiber.Ctx) error {
+	var req ResetPasswordRequest
+	if err := c.BodyParser(&req); err != nil || req.Token == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "token is required"})
+	}
+	if err := validatePassword(req.Password); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	hash, err := auth.HashPassword(req.Password)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to hash password"})
+	}
+
+	var userID int64
+	err = h.db.Transaction(func(tx *gorm.DB) error {
+		token, err := consumeAccountToken(tx, req.Token, model.AccountTokenResetPassword)
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		if err := tx.Model(&model.User{}).Where("id = ?", token.UserID).
+			Updates(map[string]interface{}{
+				"password_hash":     hash,
+				"email_verified_at": gorm.Expr("COALESCE(email_verified_at, ?)", now),
+			}).Error; err != nil {
+			return err
+		}
+		// 같이 발급된 다른 재설정 링크도 무효화
+		if err := tx.Model(&model.AccountToken{}).
+			Where("user_id = ? AND purpose = ? AND used_at IS NULL", token.UserID, model.AccountTokenResetPassword).
+			Update("used_at", now).Error; err != nil {
+			return err
+		}
+		userID = token.UserID
+		return nil
+	})
+	if errors.Is(err, errInvalidAccountToken) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid or expired token"})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to reset password"})
+	}
+
+	// 이전 비밀번호로 로그인된 기기는 모두 로그아웃
+	if err := revokeSessions(h.db, model.SessionRevokedPasswordReset, "user_id = ?", userID); err != nil {
+		log.Printf("⚠️ 비밀번호 재설정 후 세션 취소 실패: user=%d, err=%v", userID, err)
+	}
+
+	return c.JSON(fiber.Map{"message": "password updated"})
+}
+
+var errInvalidAccountToken = errors.New("invalid account token")
+
+// consumeAccountToken 유효한 토큰을 사용 처리 (동시에 두 번 쓰지 못하도록 조건부 UPDATE)
+func consumeAccountToken(tx *gorm.DB, raw, purpose string) (*model.AccountToken, error) {
+	var token model.AccountToken
+	if err := tx.Where("token_hash = ? AND purpose = ? AND used_at IS NULL AND expires_at > ?",
+		auth.HashOpaqueToken(raw), purpose, time.Now()).First(&token).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errInvalidAccountToken
+		}
+		return nil, err
+	}
+	result := tx.Model(&model.AccountToken{}).
+		Where("id = ? AND used_at IS NULL", token.ID).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, errInvalidAccountToken
+	}
+	return &token, nil
+}
+
+// sendAccountToken 토큰 발급 후 인증/재설정 메일 발송 (메일 미설정이면 토큰만 저장하고 로그)
+func (h *AuthHandler) sendAccountToken(user *model.User, purpose string) error {
+	raw, hash, err := auth.NewOpaqueToken()
+	if err != nil {
+		return err
+	}
+
+	ttl, template, path, subject := emailVerifyTTL, mail.TemplateVerifyEmail, "/verify-email", "[EUM] 이메일 주소를 인증해 주세요"
+	validFor := "24시간"
+	if purpose == model.AccountTokenResetPassword {
+		ttl, template, path, subject = passwordResetTTL, mail.TemplatePasswordReset, "/reset-password", "[EUM] 비밀번호 재설정"
+		validFor = "1시간"
+	}
+
+	if err := h.db.Create(&model.AccountToken{
+		UserID:    user.ID,
+		Purpose:   purpose,
+		TokenHash: hash,
+		ExpiresAt: time.Now().Add(ttl),
+	}).Error; err != nil {
+		return err
+	}
+
+	if mailDispatcher == nil {
+		log.Printf("ℹ️ 메일 미설정: %s 메일을 보내지 않음 (user=%d)", purpose, user.ID)
+		return nil
+	}
+	html, text, err := mail.Render(template, mail.AccountData{
+		RecipientName: user.Nickname,
+		ActionURL:     mailDispatcher.webURL + path + "?" + url.Values{"token": {raw}}.Encode(),
+		ValidFor:      validFor,
+	})
+	if err != nil {
+		return err
+	}
+	mailDispatcher.enqueue(mail.Message{
+		To:      user.Email,
+		Subject: subject,
+		HTML:    html,
+		Text:    text,
+	})
+	return nil
+}
@@ -0,0 +1,614 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"realtime-backend/internal/auth"
+	"realtime-backend/internal/config"
+	"realtime-backend/internal/errorreport"
+	"realtime-backend/internal/mail"
+	"realtime-backend/internal/model"
+)
+
+// 메일 전송 설정
+const (
+	mailQueueSize       = 500
+	mailDigestInterval  = 5 * time.Minute
+	mailDigestWindow    = 24 * time.Hour // 요약에 담는 최대 기간 (마지막 요약 이후와 둘 중 짧은 쪽)
+	maxDigestMentions   = 20
+	maxDigestDMRooms    = 10
+	digestPreviewLength = 80
+)
+
+// emailNotificationTemplates 저장과 동시에 메일로도 보내는 알림 종류 (초대)
+var emailNotificationTemplates = map[string]string{
+	model.NotificationTypeWorkspaceInvite.String(): mail.TemplateWorkspaceInvite,
+	model.NotificationTypeMeetingAlert.String():    mail.TemplateMeetingInvite,
+}
+
+// digestNotificationTypes 일일 요약에 담는 알림 종류
+var digestNotificationTypes = []string{
+	model.NotificationTypeCommentMention.String(),
+	model.NotificationTypeRoleMention.String(),
+}
+
+// MailHandler 초대 메일, 일일 요약 메일, 메일 수신 설정
+type MailHandler struct {
+	db             *gorm.DB
+	sender         mail.Sender // nil이면 메일 비활성화 (설정 API는 동작)
+	webURL         string
+	unsubscribeURL string
+	digestHour     int
+	digestLoc      *time.Location
+	sendTimeout    time.Duration
+
+	jobs chan mail.Message
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+// mailDispatcher 알림 생성 경로에서 쓰는 전역 인스턴스 (메일 미설정이면 nil)
+var mailDispatcher *MailHandler
+
+// NewMailHandler SMTP 전송기를 만들고 전송 워커와 일일 요약 스케줄러 시작
+func NewMailHandler(db *gorm.DB, cfg config.MailConfig, webURL string) *MailHandler {
+	h := &MailHandler{
+		db:          db,
+		webURL:      webURL,
+		digestHour:  cfg.DigestHour,
+		digestLoc:   time.UTC,
+		sendTimeout: cfg.SendTimeout,
+		jobs:        make(chan mail.Message, mailQueueSize),
+		done:        make(chan struct{}),
+	}
+	if webURL != "" {
+		h.unsubscribeURL = webURL + "/unsubscribe"
+	}
+	if h.digestHour < 0 || h.digestHour > 23 {
+		h.digestHour = 9
+	}
+	if loc, err := resolveTimeZone(cfg.DigestTZ); err == nil {
+		h.digestLoc = loc
+	}
+
+	if cfg.SMTPHost == "" {
+		log.Println("ℹ️ Email notifications not configured (SMTP_HOST is empty)")
+		return h
+	}
+	sender, err := mail.NewSMTPSender(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.From)
+	if err != nil {
+		log.Printf("⚠️ 메일 전송 초기화 실패: %v", err)
+		return h
+	}
+	h.sender = sender
+
+	h.wg.Add(2)
+	go h.runWorker()
+	go h.runDigests()
+	mailDispatcher = h
+	return h
+}
+
+// Close 대기 중인 메일을 보내고 워커/스케줄러 종료
+func (h *MailHandler) Close() {
+	if h.sender == nil {
+		return
+	}
+	close(h.done)
+	h.wg.Wait()
+}
+
+// enqueue 메일 예약 (큐가 가득 차면 버림 - 같은 내용은 알림 목록에 남아 있음)
+func (h *MailHandler) enqueue(msg mail.Message) {
+	select {
+	case h.jobs <- msg:
+	default:
+		log.Printf("⚠️ 메일 큐가 가득 차 전송을 건너뜀: subject=%q", msg.Subject)
+	}
+}
+
+func (h *MailHandler) runWorker() {
+	defer h.wg.Done()
+	defer errorreport.Recover(errorreport.Context{Component: "mail.worker"})
+
+	for {
+		select {
+		case msg := <-h.jobs:
+			h.send(msg)
+		case <-h.done:
+			for {
+				select {
+				case msg := <-h.jobs:
+					h.send(msg)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (h *MailHandler) send(msg mail.Message) {
+	ctx, cancel := context.WithTimeout(context.Background(), h.sendTimeout)
+	defer cancel()
+	if err := h.sender.Send(ctx, msg); err != nil {
+		log.Printf("⚠️ 메일 전송 실패: subject=%q, err=%v", msg.Subject, err)
+	}
+}
+
+// loadEmailPreference 사용자 메일 설정 (없으면 기본값으로 생성 - 수신 거부 토큰 발급)
+func loadEmailPreference(db *gorm.DB, userID int64) (*model.EmailPreference, error) {
+	var pref model.EmailPreference
+	err := db.First(&pref, "user_id = ?", userID).Error
+	if err == nil {
+		return &pref, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	token, err := newShareToken()
+	if err != nil {
+		return nil, err
+	}
+	pref = model.EmailPreference{UserID: userID, UnsubscribeToken: token}
+	// 동시에 만들어진 경우 먼저 만든 쪽을 사용
+	if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&pref).Error; err != nil {
+		return nil, err
+	}
+	if err := db.First(&pref, "user_id = ?", userID).Error; err != nil {
+		return nil, err
+	}
+	return &pref, nil
+}
+
+// unsubscribeLink 메일 하단의 수신 거부 링크 (프론트엔드 페이지가 /api/mail/unsubscribe 호출)
+func (h *MailHandler) unsubscribeLink(pref *model.EmailPreference, list string) string {
+	if h.unsubscribeURL == "" {
+		return ""
+	}
+	return h.unsubscribeURL + "?" + url.Values{"token": {pref.UnsubscribeToken}, "list": {list}}.Encode()
+}
+
+// emailNotificationPayloads 초대 알림을 수신자에게 메일로도 전송 (수신 거부한 사용자 제외)
+func emailNotificationPayloads(payloads []NotificationPayload) {
+	if mailDispatcher == nil || len(payloads) == 0 {
+		return
+	}
+	name, ok := emailNotificationTemplates[payloads[0].Type]
+	if !ok {
+		return
+	}
+	for i := range payloads {
+		if msg, ok := mailDispatcher.inviteMessage(name, &payloads[i]); ok {
+			mailDispatcher.enqueue(msg)
+		}
+	}
+}
+
+// inviteMessage 초대 알림 하나의 메일 (대상 워크스페이스/회의를 찾지 못하면 보내지 않음)
+func (h *MailHandler) inviteMessage(name string, p *NotificationPayload) (mail.Message, bool) {
+	if p.RelatedID == nil {
+		return mail.Message{}, false
+	}
+	var receiver model.User
+	if err := h.db.Select("id", "email", "nickname").First(&receiver, p.ReceiverID).Error; err != nil {
+		return mail.Message{}, false
+	}
+	pref, err := loadEmailPreference(h.db, receiver.ID)
+	if err != nil || pref.InvitesOptOut {
+		return mail.Message{}, false
+	}
+
+	data := mail.InviteData{
+		RecipientName:  receiver.Nickname,
+		InviterName:    "EUM",
+		UnsubscribeURL: h.unsubscribeLink(pref, model.EmailListInvites),
+	}
+	if p.Sender != nil {
+		data.InviterName = p.Sender.Nickname
+	}
+
+	var subject string
+	switch name {
+	case mail.TemplateWorkspaceInvite:
+		var workspace model.Workspace
+		if err := h.db.Select("id", "name").First(&workspace, *p.RelatedID).Error; err != nil {
+			return mail.Message{}, false
+		}
+		data.WorkspaceName = workspace.Name
+		if h.webURL != "" {
+			data.ActionURL = h.webURL + "/workspace"
+		}
+		subject = fmt.Sprintf("[EUM] %s님이 %s 워크스페이스에 초대했습니다", data.InviterName, workspace.Name)
+	case mail.TemplateMeetingInvite:
+		var meeting model.Meeting
+		if err := h.db.Select("id", "workspace_id", "title", "code").First(&meeting, *p.RelatedID).Error; err != nil || meeting.WorkspaceID == nil {
+			return mail.Message{}, false
+		}
+		var workspace model.Workspace
+		if err := h.db.Select("id", "name").First(&workspace, *meeting.WorkspaceID).Error; err != nil {
+			return mail.Message{}, false
+		}
+		data.WorkspaceName = workspace.Name
+		data.MeetingTitle = meeting.Title
+		if h.webURL != "" {
+			data.ActionURL = fmt.Sprintf("%s/workspace/%d?meeting=%s", h.webURL, workspace.ID, url.QueryEscape(meeting.Code))
+		}
+		subject = fmt.Sprintf("[EUM] 회의 초대: %s", meeting.Title)
+	default:
+		return mail.Message{}, false
+	}
+
+	html, text, err := mail.Render(name, data)
+	if err != nil {
+		log.Printf("⚠️ 메일 템플릿 렌더링 실패: template=%s, err=%v", name, err)
+		return mail.Message{}, false
+	}
+	return mail.Message{
+		To:             receiver.Email,
+		Subject:        subject,
+		HTML:           html,
+		Text:           text,
+		UnsubscribeURL: data.UnsubscribeURL,
+	}, true
+}
+
+func (h *MailHandler) runDigests() {
+	defer h.wg.Done()
+	defer errorreport.Recover(errorreport.Context{Component: "mail.digest"})
+
+	ticker := time.NewTicker(mailDigestInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := h.sendDueDigests(time.Now()); err != nil {
+			log.Printf("⚠️ 일일 요약 메일 발송 실패: %v", err)
+		}
+		select {
+		case <-h.done:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// sendDueDigests 읽지 않은 멘션/DM이 있는 사용자 중 오늘 요약 시각이 지난 사용자에게 요약 발송
+// 여러 인스턴스가 동시에 돌아도 last_digest_at 조건부 갱신으로 한 번만 보냄
+func (h *MailHandler) sendDueDigests(now time.Time) error {
+	since := now.Add(-mailDigestWindow)
+
+	var userIDs []int64
+	err := h.db.Raw(`
+		SELECT receiver_id FROM notifications
+		WHERE is_read = false AND type IN ? AND created_at > ?
+		UNION
+		SELECT p.user_id FROM participants p
+		INNER JOIN meetings m ON m.id = p.meeting_id AND m.type = 'DM'
+		WHERE p.user_id IS NOT NULL AND EXISTS (
+			SELECT 1 FROM chat_logs cl
+			WHERE cl.meeting_id = p.meeting_id
+			  AND cl.sender_id <> p.user_id
+			  AND cl.type <> 'SYSTEM'
+			  AND cl.created_at > ?
+			  AND (p.last_read_at IS NULL OR cl.created_at > p.last_read_at))
+	`, digestNotificationTypes, since, since).Scan(&userIDs).Error
+	if err != nil {
+		return err
+	}
+
+	for _, userID := range userIDs {
+		select {
+		case <-h.done:
+			return nil
+		default:
+		}
+		h.sendDigest(userID, now)
+	}
+	return nil
+}
+
+// sendDigest 사용자 한 명의 요약 (오늘 이미 보냈거나 수신 거부했으면 건너뜀)
+func (h *MailHandler) sendDigest(userID int64, now time.Time) {
+	pref, err := loadEmailPreference(h.db, userID)
+	if err != nil || pref.DigestOptOut {
+		return
+	}
+
+	loc := h.digestLoc
+	if pref.TimeZone != nil {
+		if l, err := resolveTimeZone(*pref.TimeZone); err == nil {
+			loc = l
+		}
+	}
+	hour := h.digestHour
+	if pref.DigestHour != nil {
+		hour = *pref.DigestHour
+	}
+	local := now.In(loc)
+	due := time.Date(local.Year(), local.Month(), local.Day(), hour, 0, 0, 0, loc)
+	if local.Before(due) || (pref.LastDigestAt != nil && !pref.LastDigestAt.Before(due)) {
+		return
+	}
+
+	since := now.Add(-mailDigestWindow)
+	if pref.LastDigestAt != nil && pref.LastDigestAt.After(since) {
+		since = *pref.LastDigestAt
+	}
+
+	claim := h.db.Model(&model.EmailPreference{}).
+		Where("user_id = ? AND (last_digest_at IS NULL OR last_digest_at < ?)", userID, due).
+		Update("last_digest_at", now)
+	if claim.Error != nil || claim.RowsAffected == 0 {
+		return
+	}
+
+	var receiver model.User
+	if err := h.db.Select("id", "email", "nickname").First(&receiver, userID).Error; err != nil {
+		return
+	}
+	data := mail.DigestData{
+		RecipientName:  receiver.Nickname,
+		Date:           local.Format("2006년 1월 2일"),
+		UnsubscribeURL: h.unsubscribeLink(pref, model.EmailListDigest),
+	}
+	if h.webURL != "" {
+		data.ActionURL = h.webURL + "/workspace"
+	}
+	data.Mentions = h.digestMentions(userID, since, loc)
+	data.DirectMessages = h.digestDirectMessages(userID, since)
+	if len(data.Mentions) == 0 && len(data.DirectMessages) == 0 {
+		return
+	}
+
+	html, text, err := mail.Render(mail.TemplateDigest, data)
+	if err != nil {
+		log.Printf("⚠️ 메일 템플릿 렌더링 실패: template=%s, err=%v", mail.TemplateDigest, err)
+		return
+	}
+	h.enqueue(mail.Message{
+		To:             receiver.Email,
+		Subject:        fmt.Sprintf("[EUM] %s 읽지 않은 멘션과 메시지", data.Date),
+		HTML:           html,
+		Text:           text,
+		UnsubscribeURL: data.UnsubscribeURL,
+	})
+}
+
+// digestMentions since 이후 읽지 않은 멘션 알림 (최신순)
+func (h *MailHandler) digestMentions(userID int64, since time.Time, loc *time.Location) []mail.DigestMention {
+	var notifications []model.Notification
+	h.db.Preload("Sender").
+		Where("receiver_id = ? AND is_read = ? AND type IN ? AND created_at > ?", userID, false, digestNotificationTypes, since).
+		Order("id DESC").
+		Limit(maxDigestMentions).
+		Find(&notifications)
+
+	mentions := make([]mail.DigestMention, 0, len(notifications))
+	for _, n := range notifications {
+		mention := mail.DigestMention{
+			SenderName: "EUM",
+			Content:    n.Content,
+			At:         n.CreatedAt.In(loc).Format("01/02 15:04"),
+		}
+		if n.Sender != nil && n.Sender.ID != 0 {
+			mention.SenderName = n.Sender.Nickname
+		}
+		mentions = append(mentions, mention)
+	}
+	return mentions
+}
+
+// digestDirectMessages since 이후 읽지 않은 메시지가 있는 DM 방 (최근 메시지 순)
+func (h *MailHandler) digestDirectMessages(userID int64, since time.Time) []mail.DigestDirectMessage {
+	type dmRow struct {
+		MeetingID     int64
+		WorkspaceName string
+		UnreadCount   int64
+		LastID        int64
+	}
+	var rows []dmRow
+	h.db.Raw(`
+		SELECT cl.meeting_id, COALESCE(w.name, '') AS workspace_name, COUNT(*) AS unread_count, MAX(cl.id) AS last_id
+		FROM chat_logs cl
+		INNER JOIN participants p ON p.meeting_id = cl.meeting_id AND p.user_id = ?
+		INNER JOIN meetings m ON m.id = cl.meeting_id AND m.type = 'DM'
+		LEFT JOIN workspaces w ON w.id = m.workspace_id
+		WHERE cl.sender_id <> ?
+		  AND cl.type <> 'SYSTEM'
+		  AND cl.created_at > ?
+		  AND (p.last_read_at IS NULL OR cl.created_at > p.last_read_at)
+		GROUP BY cl.meeting_id, w.name
+		ORDER BY last_id DESC
+		LIMIT ?
+	`, userID, userID, since, maxDigestDMRooms).Scan(&rows)
+	if len(rows) == 0 {
+		return nil
+	}
+
+	lastIDs := make([]int64, len(rows))
+	for i, r := range rows {
+		lastIDs[i] = r.LastID
+	}
+	var logs []model.ChatLog
+	h.db.Preload("Sender").Where("id IN ?", lastIDs).Find(&logs)
+	lastByID := make(map[int64]*model.ChatLog, len(logs))
+	for i := range logs {
+		lastByID[logs[i].ID] = &logs[i]
+	}
+
+	out := make([]mail.DigestDirectMessage, 0, len(rows))
+	for _, r := range rows {
+		dm := mail.DigestDirectMessage{
+			SenderName:    "EUM",
+			WorkspaceName: r.WorkspaceName,
+			Count:         r.UnreadCount,
+		}
+		if last := lastByID[r.LastID]; last != nil {
+			if last.Sender != nil {
+				dm.SenderName = last.Sender.Nickname
+			}
+			if last.Message != nil {
+				dm.Preview = *last.Message
+				if runes := []rune(dm.Preview); len(runes) > digestPreviewLength {
+					dm.Preview = string(runes[:digestPreviewLength]) + "…"
+				}
+			}
+		}
+		out = append(out, dm)
+	}
+	return out
+}
+
+// EmailPreferenceResponse 메일 수신 설정 응답
+type EmailPreferenceResponse struct {
+	Enabled    bool   `json:"enabled"` // 서버에 메일이 설정되어 있는지
+	Invites    bool   `json:"invites"`
+	Digest     bool   `json:"digest"`
+	DigestHour int    `json:"digest_hour"`
+	TimeZone   string `json:"time_zone"`
+}
+
+// UpdateEmailPreferenceRequest 메일 수신 설정 변경 (보낸 항목만 변경)
+type UpdateEmailPreferenceRequest struct {
+	Invites    *bool   `json:"invites"`
+	Digest     *bool   `json:"digest"`
+	DigestHour *int    `json:"digest_hour"`
+	TimeZone   *string `json:"time_zone"`
+}
+
+// GetEmailPreferences 내 메일 수신 설정
+func (h *MailHandler) GetEmailPreferences(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+
+	pref, err := loadEmailPreference(h.db, claims.UserID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get email preferences",
+		})
+	}
+	return c.JSON(h.toEmailPreferenceResponse(pref))
+}
+
+// UpdateEmailPreferences 내 메일 수신 설정 변경
+func (h *MailHandler) UpdateEmailPreferences(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+
+	var req UpdateEmailPreferenceRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+	if req.DigestHour != nil && (*req.DigestHour < 0 || *req.DigestHour > 23) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "digest_hour must be between 0 and 23",
+		})
+	}
+	if req.TimeZone != nil {
+		if _, err := resolveTimeZone(*req.TimeZone); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+	}
+
+	pref, err := loadEmailPreference(h.db, claims.UserID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to update email preferences",
+		})
+	}
+
+	updates := map[string]interface{}{}
+	if req.Invites != nil {
+		updates["invites_opt_out"] = !*req.Invites
+	}
+	if req.Digest != nil {
+		updates["digest_opt_out"] = !*req.Digest
+	}
+	if req.DigestHour != nil {
+		updates["digest_hour"] = *req.DigestHour
+	}
+	if req.TimeZone != nil {
+		updates["time_zone"] = *req.TimeZone
+	}
+	if len(updates) > 0 {
+		if err := h.db.Model(pref).Updates(updates).Error; err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "failed to update email preferences",
+			})
+		}
+		h.db.First(pref, "user_id = ?", claims.UserID)
+	}
+
+	return c.JSON(h.toEmailPreferenceResponse(pref))
+}
+
+// Unsubscribe 메일의 수신 거부 링크 처리 (로그인 없이 토큰으로)
+// POST /api/mail/unsubscribe?token=..&list=invites|digest
+func (h *MailHandler) Unsubscribe(c *fiber.Ctx) error {
+	token := c.Query("token")
+	list := c.Query("list")
+	if token == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "token is required",
+		})
+	}
+
+	column := ""
+	switch list {
+	case model.EmailListInvites:
+		column = "invites_opt_out"
+	case model.EmailListDigest:
+		column = "digest_opt_out"
+	default:
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "list must be invites or digest",
+		})
+	}
+
+	result := h.db.Model(&model.EmailPreference{}).Where("unsubscribe_token = ?", token).Update(column, true)
+	if result.Error != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to unsubscribe",
+		})
+	}
+	if result.RowsAffected == 0 {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "invalid unsubscribe token",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "unsubscribed",
+		"list":    list,
+	})
+}
+
+func (h *MailHandler) toEmailPreferenceResponse(pref *model.EmailPreference) EmailPreferenceResponse {
+	resp := EmailPreferenceResponse{
+		Enabled:    h.sender != nil,
+		Invites:    !pref.InvitesOptOut,
+		Digest:     !pref.DigestOptOut,
+		DigestHour: h.digestHour,
+		TimeZone:   h.digestLoc.String(),
+	}
+	if pref.DigestHour != nil {
+		resp.DigestHour = *pref.DigestHour
+	}
+	if pref.TimeZone != nil {
+		resp.TimeZone = *pref.TimeZone
+	}
+	return resp
+}
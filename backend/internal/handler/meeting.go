@@ -3,6 +3,7 @@ package handler
 import (
 	"crypto/rand"
 	"encoding/hex"
+	"fmt"
 	"log"
 	"time"
 
@@ -25,17 +26,18 @@ func NewMeetingHandler(db *gorm.DB) *MeetingHandler {
 
 // MeetingResponse 미팅 응답
 type MeetingResponse struct {
-	ID           int64                 `json:"id"`
-	WorkspaceID  *int64                `json:"workspace_id,omitempty"`
-	HostID       int64                 `json:"host_id"`
-	Title        string                `json:"title"`
-	Code         string                `json:"code"`
-	Type         string                `json:"type"`
-	Status       string                `json:"status"`
-	StartedAt    *string               `json:"started_at,omitempty"`
-	EndedAt      *string               `json:"ended_at,omitempty"`
-	Host         *UserResponse         `json:"host,omitempty"`
-	Participants []ParticipantResponse `json:"participants,omitempty"`
+	ID               int64                 `json:"id"`
+	WorkspaceID      *int64                `json:"workspace_id,omitempty"`
+	HostID           int64                 `json:"host_id"`
+	Title            string                `json:"title"`
+	Code             string                `json:"code"`
+	Type             string                `json:"type"`
+	Status           string                `json:"status"`
+	RecordingEnabled bool                  `json:"recording_enabled"`
+	StartedAt        *string               `json:"started_at,omitempty"`
+	EndedAt          *string               `json:"ended_at,omitempty"`
+	Host             *UserResponse         `json:"host,omitempty"`
+	Participants     []ParticipantResponse `json:"participants,omitempty"`
 }
 
 // ParticipantResponse 참가자 응답
@@ -260,10 +262,45 @@ func (h *MeetingHandler) StartMeeting(c *fiber.Ctx) error {
 	}
 
 	h.db.Preload("Host").Preload("Participants.User").First(&meeting, meeting.ID)
+	h.notifyMeetingStarted(&meeting)
 
 	return c.JSON(h.toMeetingResponse(&meeting))
 }
 
+// notifyMeetingStarted 회의 참여자와 연결된 일정의 참석자(거절 제외)에게 회의 시작 알림
+func (h *MeetingHandler) notifyMeetingStarted(meeting *model.Meeting) {
+	if meeting.WorkspaceID == nil {
+		return
+	}
+
+	var receivers []int64
+	seen := map[int64]bool{meeting.HostID: true}
+	for _, p := range meeting.Participants {
+		if p.UserID != nil && !seen[*p.UserID] {
+			seen[*p.UserID] = true
+			receivers = append(receivers, *p.UserID)
+		}
+	}
+	var attendees []int64
+	h.db.Model(&model.EventAttendee{}).
+		Joins("JOIN calendar_events ce ON ce.id = event_attendees.event_id").
+		Where("ce.linked_meeting_id = ? AND event_attendees.status <> ?", meeting.ID, "DECLINED").
+		Distinct().
+		Pluck("event_attendees.user_id", &attendees)
+	for _, userID := range attendees {
+		if !seen[userID] {
+			seen[userID] = true
+			receivers = append(receivers, userID)
+		}
+	}
+
+	relatedType := "MEETING"
+	content := fmt.Sprintf("%s님이 '%s' 회의를 시작했습니다.", meeting.Host.Nickname, meeting.Title)
+	if err := createActionNotifications(h.db, receivers, &meeting.HostID, model.NotificationTypeMeetingStarted.String(), content, &relatedType, &meeting.ID, meetingNotificationData(*meeting.WorkspaceID, meeting)); err != nil {
+		log.Printf("⚠️ 회의 시작 알림 생성 실패: meeting=%d, err=%v", meeting.ID, err)
+	}
+}
+
 // EndMeeting 미팅 종료
 func (h *MeetingHandler) EndMeeting(c *fiber.Ctx) error {
 	claims := c.Locals("claims").(*auth.Claims)
@@ -320,12 +357,13 @@ func (h *MeetingHandler) isWorkspaceMember(workspaceID, userID int64) bool {
 
 func (h *MeetingHandler) toMeetingResponse(m *model.Meeting) MeetingResponse {
 	resp := MeetingResponse{
-		ID:     m.ID,
-		HostID: m.HostID,
-		Title:  m.Title,
-		Code:   m.Code,
-		Type:   m.Type,
-		Status: m.Status,
+		ID:               m.ID,
+		HostID:           m.HostID,
+		Title:            m.Title,
+		Code:             m.Code,
+		Type:             m.Type,
+		Status:           m.Status,
+		RecordingEnabled: m.RecordingEnabled,
 	}
 
 	if m.WorkspaceID != nil {
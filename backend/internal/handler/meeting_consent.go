@@ -0,0 +1,308 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+
+	"realtime-backend/internal/auth"
+	"realtime-backend/internal/model"
+)
+
+// RecordingConsentResponse 녹음 동의 응답
+type RecordingConsentResponse struct {
+	ID          int64         `json:"id"`
+	MeetingID   int64         `json:"meeting_id"`
+	UserID      int64         `json:"user_id"`
+	Status      string        `json:"status"`
+	ConsentedAt *string       `json:"consented_at,omitempty"`
+	RevokedAt   *string       `json:"revoked_at,omitempty"`
+	UpdatedAt   string        `json:"updated_at"`
+	User        *UserResponse `json:"user,omitempty"`
+}
+
+// UpdateRecordingRequest 녹음 설정 변경 요청
+type UpdateRecordingRequest struct {
+	Enabled bool `json:"enabled"`
+}
+
+// UpdateRecordingSettings 미팅 녹음/자막 저장 설정 (호스트 전용)
+func (h *MeetingHandler) UpdateRecordingSettings(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	meeting, ok := h.findWorkspaceMeeting(c)
+	if !ok {
+		return nil
+	}
+
+	if meeting.HostID != claims.UserID {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "only host can change recording settings",
+		})
+	}
+
+	var req UpdateRecordingRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+
+	if err := h.db.Model(meeting).Update("recording_enabled", req.Enabled).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to update recording settings",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"meeting_id":        meeting.ID,
+		"recording_enabled": req.Enabled,
+	})
+}
+
+// GiveRecordingConsent 녹음 동의
+func (h *MeetingHandler) GiveRecordingConsent(c *fiber.Ctx) error {
+	return h.setRecordingConsent(c, model.ConsentStatusGranted)
+}
+
+// RevokeRecordingConsent 녹음 동의 철회
+func (h *MeetingHandler) RevokeRecordingConsent(c *fiber.Ctx) error {
+	return h.setRecordingConsent(c, model.ConsentStatusRevoked)
+}
+
+// GetMyRecordingConsent 내 녹음 동의 상태 조회
+func (h *MeetingHandler) GetMyRecordingConsent(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	meeting, ok := h.findWorkspaceMeeting(c)
+	if !ok {
+		return nil
+	}
+
+	var consent model.RecordingConsent
+	err := h.db.Where("meeting_id = ? AND user_id = ?", meeting.ID, claims.UserID).First(&consent).Error
+	if err == gorm.ErrRecordNotFound {
+		return c.JSON(fiber.Map{
+			"meeting_id":        meeting.ID,
+			"recording_enabled": meeting.RecordingEnabled,
+			"consent":           nil,
+		})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get consent",
+		})
+	}
+
+	resp := toRecordingConsentResponse(&consent)
+	return c.JSON(fiber.Map{
+		"meeting_id":        meeting.ID,
+		"recording_enabled": meeting.RecordingEnabled,
+		"consent":           resp,
+	})
+}
+
+// GetRecordingConsents 미팅 녹음 동의 기록 조회/내보내기 (호스트 전용, ?format=csv)
+func (h *MeetingHandler) GetRecordingConsents(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	meeting, ok := h.findWorkspaceMeeting(c)
+	if !ok {
+		return nil
+	}
+
+	if meeting.HostID != claims.UserID {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "only host can export consent log",
+		})
+	}
+
+	var consents []model.RecordingConsent
+	if err := h.db.
+		Where("meeting_id = ?", meeting.ID).
+		Preload("User").
+		Order("updated_at ASC").
+		Find(&consents).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get consents",
+		})
+	}
+
+	if c.Query("format") == "csv" {
+		data, err := recordingConsentsToCSV(consents)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "failed to export consents",
+			})
+		}
+		c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+		c.Set(fiber.HeaderContentDisposition,
+			fmt.Sprintf(`attachment; filename="meeting-%d-consents.csv"`, meeting.ID))
+		return c.Send(data)
+	}
+
+	responses := make([]RecordingConsentResponse, len(consents))
+	for i := range consents {
+		responses[i] = toRecordingConsentResponse(&consents[i])
+	}
+
+	return c.JSON(fiber.Map{
+		"meeting_id":        meeting.ID,
+		"recording_enabled": meeting.RecordingEnabled,
+		"consents":          responses,
+		"total":             len(responses),
+	})
+}
+
+// setRecordingConsent 동의 상태 저장 (미팅당 사용자 1건, 타임스탬프 갱신)
+func (h *MeetingHandler) setRecordingConsent(c *fiber.Ctx, status model.ConsentStatus) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	meeting, ok := h.findWorkspaceMeeting(c)
+	if !ok {
+		return nil
+	}
+
+	now := time.Now()
+	ip := c.IP()
+	userAgent := c.Get(fiber.HeaderUserAgent)
+	if len(userAgent) > 255 {
+		userAgent = userAgent[:255]
+	}
+
+	var consent model.RecordingConsent
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("meeting_id = ? AND user_id = ?", meeting.ID, claims.UserID).First(&consent).Error
+		if err != nil && err != gorm.ErrRecordNotFound {
+			return err
+		}
+
+		consent.MeetingID = meeting.ID
+		consent.UserID = claims.UserID
+		consent.Status = status.String()
+		consent.IPAddress = &ip
+		consent.UserAgent = &userAgent
+		if status == model.ConsentStatusGranted {
+			consent.ConsentedAt = &now
+			consent.RevokedAt = nil
+		} else {
+			consent.RevokedAt = &now
+		}
+
+		return tx.Save(&consent).Error
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to save consent",
+		})
+	}
+
+	return c.JSON(toRecordingConsentResponse(&consent))
+}
+
+// findWorkspaceMeeting 경로 파라미터로 미팅 조회 (멤버 확인 포함)
+// 실패 시 에러 응답을 기록하고 false 반환
+func (h *MeetingHandler) findWorkspaceMeeting(c *fiber.Ctx) (*model.Meeting, bool) {
+	claims := c.Locals("claims").(*auth.Claims)
+	workspaceID, err := c.ParamsInt("workspaceId")
+	if err != nil {
+		c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid workspace id",
+		})
+		return nil, false
+	}
+	meetingID, err := c.ParamsInt("meetingId")
+	if err != nil {
+		c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid meeting id",
+		})
+		return nil, false
+	}
+
+	if !h.isWorkspaceMember(int64(workspaceID), claims.UserID) {
+		c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "you are not a member of this workspace",
+		})
+		return nil, false
+	}
+
+	var meeting model.Meeting
+	if err := h.db.Where("id = ? AND workspace_id = ?", meetingID, workspaceID).First(&meeting).Error; err != nil {
+		c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "meeting not found",
+		})
+		return nil, false
+	}
+
+	return &meeting, true
+}
+
+func toRecordingConsentResponse(rc *model.RecordingConsent) RecordingConsentResponse {
+	resp := RecordingConsentResponse{
+		ID:        rc.ID,
+		MeetingID: rc.MeetingID,
+		UserID:    rc.UserID,
+		Status:    rc.Status,
+		UpdatedAt: rc.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	if rc.ConsentedAt != nil {
+		t := rc.ConsentedAt.Format("2006-01-02T15:04:05Z07:00")
+		resp.ConsentedAt = &t
+	}
+	if rc.RevokedAt != nil {
+		t := rc.RevokedAt.Format("2006-01-02T15:04:05Z07:00")
+		resp.RevokedAt = &t
+	}
+	if rc.User.ID != 0 {
+		resp.User = &UserResponse{
+			ID:         rc.User.ID,
+			Email:      rc.User.Email,
+			Nickname:   rc.User.Nickname,
+			ProfileImg: rc.User.ProfileImg,
+		}
+	}
+	return resp
+}
+
+// recordingConsentsToCSV 동의 기록을 CSV로 변환
+func recordingConsentsToCSV(consents []model.RecordingConsent) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	header := []string{"meeting_id", "user_id", "email", "nickname", "status", "consented_at", "revoked_at", "ip_address", "updated_at"}
+	if err := w.Write(header); err != nil {
+		return nil, err
+	}
+
+	formatTime := func(t *time.Time) string {
+		if t == nil {
+			return ""
+		}
+		return t.Format(time.RFC3339)
+	}
+
+	for _, rc := range consents {
+		ip := ""
+		if rc.IPAddress != nil {
+			ip = *rc.IPAddress
+		}
+		row := []string{
+			strconv.FormatInt(rc.MeetingID, 10),
+			strconv.FormatInt(rc.UserID, 10),
+			rc.User.Email,
+			rc.User.Nickname,
+			rc.Status,
+			formatTime(rc.ConsentedAt),
+			formatTime(rc.RevokedAt),
+			ip,
+			rc.UpdatedAt.Format(time.RFC3339),
+		}
+		if err := w.Write(row); err != nil {
+			return nil, err
+		}
+	}
+
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
@@ -0,0 +1,406 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+
+	"realtime-backend/internal/auth"
+	"realtime-backend/internal/errorreport"
+	"realtime-backend/internal/minutes"
+	"realtime-backend/internal/model"
+	"realtime-backend/internal/storage"
+)
+
+// minutesFolderName 회의록 기본 저장 폴더 (워크스페이스 루트)
+const minutesFolderName = "회의록"
+
+// errNoVoiceRecords 회의록으로 만들 음성 기록이 없음
+var errNoVoiceRecords = errors.New("meeting has no voice records")
+
+// MeetingMinutesHandler 회의 음성 기록 → 회의록 문서 변환
+type MeetingMinutesHandler struct {
+	db      *gorm.DB
+	storage *StorageHandler // 파일 저장 (워크스페이스 리전 S3, 폴더 권한)
+	roomHub *RoomHub
+}
+
+// NewMeetingMinutesHandler MeetingMinutesHandler 생성 (방이 닫히면 회의록 자동 생성)
+func NewMeetingMinutesHandler(db *gorm.DB, storageHandler *StorageHandler, roomHub *RoomHub) *MeetingMinutesHandler {
+	h := &MeetingMinutesHandler{db: db, storage: storageHandler, roomHub: roomHub}
+	if roomHub != nil {
+		roomHub.SetRoomClosedHook(h.exportOnRoomClose)
+	}
+	return h
+}
+
+// ExportMinutesRequest 회의록 생성 요청
+type ExportMinutesRequest struct {
+	Format   string `json:"format"`              // markdown(기본), pdf
+	FolderID *int64 `json:"folder_id,omitempty"` // 없으면 루트의 "회의록" 폴더
+}
+
+// MeetingMinutesResponse 회의록 응답
+type MeetingMinutesResponse struct {
+	ID           int64         `json:"id"`
+	MeetingID    int64         `json:"meeting_id"`
+	Format       string        `json:"format"`
+	RecordCount  int           `json:"record_count"`
+	LastRecordAt *string       `json:"last_record_at,omitempty"`
+	CreatedBy    *int64        `json:"created_by,omitempty"`
+	CreatedAt    string        `json:"created_at"`
+	File         *FileResponse `json:"file,omitempty"`
+}
+
+// ExportMinutes 지금까지의 음성 기록으로 회의록 생성 (진행 중인 회의도 가능)
+func (h *MeetingMinutesHandler) ExportMinutes(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	meeting, ok := h.requireMeeting(c, claims.UserID)
+	if !ok {
+		return nil
+	}
+
+	var req ExportMinutesRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "invalid request body",
+			})
+		}
+	}
+	if req.Format == "" {
+		req.Format = minutes.FormatMarkdown
+	}
+	if !minutes.Valid(req.Format) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "format must be markdown or pdf",
+		})
+	}
+
+	// 저장 폴더는 요청자가 접근할 수 있어야 함
+	if req.FolderID != nil {
+		var folder model.WorkspaceFile
+		err := h.db.Where("id = ? AND workspace_id = ? AND type = ?", *req.FolderID, *meeting.WorkspaceID, "FOLDER").First(&folder).Error
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "folder not found",
+			})
+		}
+		access := h.storage.requireFolderAccess(c, *meeting.WorkspaceID, claims.UserID)
+		if access == nil {
+			return nil
+		}
+		if !access.folderAllowed(folder.ID) {
+			return folderForbidden(c)
+		}
+	}
+
+	// 아직 DB에 쓰이지 않은 실시간 자막까지 포함
+	if h.roomHub != nil {
+		h.roomHub.FlushTranscripts()
+	}
+
+	record, file, err := h.export(meeting, req.Format, req.FolderID, &claims.UserID)
+	if err == errNoVoiceRecords {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "meeting has no voice records",
+		})
+	}
+	if err != nil {
+		log.Printf("❌ 회의록 생성 실패: meeting=%d, err=%v", meeting.ID, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to export minutes",
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(h.toMinutesResponse(record, file))
+}
+
+// GetMinutes 미팅의 회의록 목록 (최신순)
+func (h *MeetingMinutesHandler) GetMinutes(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	meeting, ok := h.requireMeeting(c, claims.UserID)
+	if !ok {
+		return nil
+	}
+
+	var records []model.MeetingMinutes
+	if err := h.db.Where("meeting_id = ?", meeting.ID).Order("created_at DESC").Find(&records).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get minutes",
+		})
+	}
+
+	access := h.storage.requireFolderAccess(c, *meeting.WorkspaceID, claims.UserID)
+	if access == nil {
+		return nil
+	}
+
+	responses := make([]MeetingMinutesResponse, 0, len(records))
+	for i := range records {
+		var file model.WorkspaceFile
+		if err := h.db.Preload("Uploader").First(&file, records[i].FileID).Error; err != nil || !access.fileAllowed(&file) {
+			continue
+		}
+		responses = append(responses, h.toMinutesResponse(&records[i], &file))
+	}
+
+	return c.JSON(fiber.Map{
+		"minutes": responses,
+		"total":   len(responses),
+	})
+}
+
+// requireMeeting 워크스페이스 미팅 확인 (실패 시 에러 응답을 기록하고 false 반환)
+func (h *MeetingMinutesHandler) requireMeeting(c *fiber.Ctx, userID int64) (*model.Meeting, bool) {
+	workspaceID, err := c.ParamsInt("workspaceId")
+	if err != nil {
+		c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid workspace id",
+		})
+		return nil, false
+	}
+	meetingID, err := c.ParamsInt("meetingId")
+	if err != nil {
+		c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid meeting id",
+		})
+		return nil, false
+	}
+
+	if !h.storage.isWorkspaceMember(int64(workspaceID), userID) {
+		c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "you are not a member of this workspace",
+		})
+		return nil, false
+	}
+
+	var meeting model.Meeting
+	if err := h.db.Where("id = ? AND workspace_id = ?", meetingID, workspaceID).First(&meeting).Error; err != nil {
+		c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "meeting not found",
+		})
+		return nil, false
+	}
+	return &meeting, true
+}
+
+// exportOnRoomClose 방이 닫히면 마지막 회의록 이후 새 발언이 있을 때 회의록을 만들고 채팅에 안내
+func (h *MeetingMinutesHandler) exportOnRoomClose(roomID string) {
+	defer errorreport.Recover(errorreport.Context{Component: "meeting.minutes"})
+
+	meeting, err := findRoomMeeting(h.db, roomID)
+	if err != nil || meeting.WorkspaceID == nil {
+		return
+	}
+
+	var last model.MeetingMinutes
+	query := h.db.Model(&model.VoiceRecord{}).Where("meeting_id = ?", meeting.ID)
+	if err := h.db.Where("meeting_id = ?", meeting.ID).Order("created_at DESC").First(&last).Error; err == nil && last.LastRecordAt != nil {
+		query = query.Where("created_at > ?", *last.LastRecordAt)
+	}
+	var newRecords int64
+	query.Count(&newRecords)
+	if newRecords == 0 {
+		return
+	}
+
+	record, file, err := h.export(meeting, minutes.FormatMarkdown, nil, nil)
+	if err != nil {
+		log.Printf("❌ 방 종료 회의록 생성 실패: room=%s, err=%v", roomID, err)
+		return
+	}
+	log.Printf("📝 방 종료 회의록 생성: room=%s, file=%d, records=%d", roomID, file.ID, record.RecordCount)
+
+	message := fmt.Sprintf("회의록이 저장되었습니다: %s\n/api/workspaces/%d/files/%d/download", file.Name, file.WorkspaceID, file.ID)
+	h.db.Create(&model.ChatLog{
+		MeetingID: meeting.ID,
+		Message:   &message,
+		Type:      "SYSTEM",
+	})
+}
+
+// export 미팅의 음성 기록 전체로 회의록 파일을 만들어 저장
+// createdBy가 nil이면(자동 생성) 호스트를 업로더로 기록해 호스트가 관리할 수 있게 함
+func (h *MeetingMinutesHandler) export(meeting *model.Meeting, format string, folderID *int64, createdBy *int64) (*model.MeetingMinutes, *model.WorkspaceFile, error) {
+	var records []model.VoiceRecord
+	if err := h.db.Where("meeting_id = ?", meeting.ID).Order("created_at ASC, id ASC").Find(&records).Error; err != nil {
+		return nil, nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil, errNoVoiceRecords
+	}
+
+	doc := buildMinutesDocument(meeting, records)
+	data, ext, mimeType := minutes.Render(doc, format)
+
+	workspaceID := *meeting.WorkspaceID
+	s3Service, err := h.storage.s3ForWorkspace(workspaceID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	uploaderID := meeting.HostID
+	if createdBy != nil {
+		uploaderID = *createdBy
+	}
+	if folderID == nil {
+		folder, err := h.minutesFolder(workspaceID, uploaderID)
+		if err != nil {
+			return nil, nil, err
+		}
+		folderID = &folder.ID
+	}
+
+	name := fmt.Sprintf("%s 회의록 %s%s", sanitizeStorageString(meeting.Title), time.Now().Format("2006-01-02 1504"), ext)
+	key := storage.WorkspaceObjectKey(workspaceID, name)
+	if err := s3Service.PutObject(key, mimeType, bytes.NewReader(data), int64(len(data))); err != nil {
+		return nil, nil, err
+	}
+
+	fileURL := s3Service.GetPublicURL(key)
+	size := int64(len(data))
+	lastRecordAt := records[len(records)-1].CreatedAt
+	file := model.WorkspaceFile{
+		WorkspaceID:      workspaceID,
+		UploaderID:       &uploaderID,
+		ParentFolderID:   folderID,
+		Name:             name,
+		Type:             "FILE",
+		FileURL:          &fileURL,
+		FileSize:         &size,
+		MimeType:         &mimeType,
+		S3Key:            &key,
+		RelatedMeetingID: &meeting.ID,
+	}
+	record := model.MeetingMinutes{
+		MeetingID:    meeting.ID,
+		Format:       format,
+		RecordCount:  len(records),
+		LastRecordAt: &lastRecordAt,
+		CreatedBy:    createdBy,
+	}
+
+	err = h.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&file).Error; err != nil {
+			return err
+		}
+		record.FileID = file.ID
+
+		// 파일 검색에서 회의록 내용도 찾을 수 있도록 본문 색인 (see file_text.go)
+		now := time.Now()
+		if err := tx.Create(&model.FileText{
+			FileID:      file.ID,
+			WorkspaceID: workspaceID,
+			Status:      model.FileTextCompleted.String(),
+			Method:      "PARSER",
+			Content:     storage.CleanDocumentText(minutes.RenderMarkdown(doc)),
+			CompletedAt: &now,
+		}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&record).Error
+	})
+	if err != nil {
+		s3Service.DeleteFile(key)
+		return nil, nil, err
+	}
+	h.storage.addStorageUsage(workspaceID, size)
+	h.storage.recordFileActivity(&file, createdBy, model.FileActionUpload, "meeting minutes")
+
+	h.db.Preload("Uploader").First(&file, file.ID)
+	return &record, &file, nil
+}
+
+// minutesFolder 루트의 회의록 폴더 조회 (없으면 생성)
+func (h *MeetingMinutesHandler) minutesFolder(workspaceID, creatorID int64) (*model.WorkspaceFile, error) {
+	var folder model.WorkspaceFile
+	err := h.db.Where("workspace_id = ? AND parent_folder_id IS NULL AND type = ? AND name = ?", workspaceID, "FOLDER", minutesFolderName).
+		First(&folder).Error
+	if err == nil {
+		return &folder, nil
+	}
+	if err != gorm.ErrRecordNotFound {
+		return nil, err
+	}
+
+	folder = model.WorkspaceFile{
+		WorkspaceID: workspaceID,
+		UploaderID:  &creatorID,
+		Name:        minutesFolderName,
+		Type:        "FOLDER",
+	}
+	if err := h.db.Create(&folder).Error; err != nil {
+		return nil, err
+	}
+	return &folder, nil
+}
+
+// buildMinutesDocument 음성 기록을 회의록 내용으로 변환 (참석자는 처음 발언한 순서)
+func buildMinutesDocument(meeting *model.Meeting, records []model.VoiceRecord) *minutes.Document {
+	doc := &minutes.Document{
+		Title:     meeting.Title,
+		StartedAt: records[0].CreatedAt,
+		EndedAt:   records[len(records)-1].CreatedAt,
+		Entries:   make([]minutes.Entry, 0, len(records)),
+	}
+	if meeting.StartedAt != nil {
+		doc.StartedAt = *meeting.StartedAt
+	}
+	if meeting.EndedAt != nil {
+		doc.EndedAt = *meeting.EndedAt
+	}
+
+	seen := make(map[string]bool)
+	for _, r := range records {
+		if r.SpeakerName != "" && !seen[r.SpeakerName] {
+			seen[r.SpeakerName] = true
+			doc.Participants = append(doc.Participants, r.SpeakerName)
+		}
+
+		entry := minutes.Entry{
+			Time:     r.CreatedAt,
+			Speaker:  r.SpeakerName,
+			Original: r.Original,
+		}
+		if r.SourceLang != nil {
+			entry.SourceLang = *r.SourceLang
+		}
+		if r.Translated != nil && r.TargetLang != nil {
+			entry.Translated = *r.Translated
+			entry.TargetLang = *r.TargetLang
+		}
+		doc.Entries = append(doc.Entries, entry)
+	}
+	return doc
+}
+
+// deleteMeetingMinutesWithTx 삭제되는 파일의 회의록 기록 정리
+func deleteMeetingMinutesWithTx(tx *gorm.DB, fileIDs []int64) {
+	tx.Where("file_id IN ?", fileIDs).Delete(&model.MeetingMinutes{})
+}
+
+func (h *MeetingMinutesHandler) toMinutesResponse(record *model.MeetingMinutes, file *model.WorkspaceFile) MeetingMinutesResponse {
+	resp := MeetingMinutesResponse{
+		ID:          record.ID,
+		MeetingID:   record.MeetingID,
+		Format:      record.Format,
+		RecordCount: record.RecordCount,
+		CreatedBy:   record.CreatedBy,
+		CreatedAt:   record.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	if record.LastRecordAt != nil {
+		lastRecordAt := record.LastRecordAt.Format("2006-01-02T15:04:05Z07:00")
+		resp.LastRecordAt = &lastRecordAt
+	}
+	if file != nil {
+		fileResp := h.storage.toFileResponse(file)
+		resp.File = &fileResp
+	}
+	return resp
+}
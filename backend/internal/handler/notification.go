@@ -2,6 +2,8 @@ package handler
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
 	"gorm.io/gorm"
@@ -12,7 +14,9 @@ import (
 
 // NotificationHandler 알림 핸들러
 type NotificationHandler struct {
-	db *gorm.DB
+	db          *gorm.DB
+	inviteDone  chan struct{} // 만료 초대 정리 중단
+	summaryDone chan struct{} // 보류된 알림 요약 전송 중단
 }
 
 // NewNotificationHandler NotificationHandler 생성
@@ -20,28 +24,64 @@ func NewNotificationHandler(db *gorm.DB) *NotificationHandler {
 	return &NotificationHandler{db: db}
 }
 
+// 알림 목록 페이지 크기
+const (
+	notificationListDefaultLimit = 50
+	notificationListMaxLimit     = 100
+)
+
 // NotificationResponse 알림 응답
 type NotificationResponse struct {
-	ID          int64         `json:"id"`
-	Type        string        `json:"type"`
-	Content     string        `json:"content"`
-	IsRead      bool          `json:"is_read"`
-	RelatedType *string       `json:"related_type,omitempty"`
-	RelatedID   *int64        `json:"related_id,omitempty"`
-	CreatedAt   string        `json:"created_at"`
-	Sender      *UserResponse `json:"sender,omitempty"`
+	ID            int64             `json:"id"`
+	Type          string            `json:"type"`
+	Content       string            `json:"content"`
+	IsRead        bool              `json:"is_read"`
+	RelatedType   *string           `json:"related_type,omitempty"`
+	RelatedID     *int64            `json:"related_id,omitempty"`
+	CreatedAt     string            `json:"created_at"`
+	IsExpired     bool              `json:"is_expired"`
+	ExpiresAt     *string           `json:"expires_at,omitempty"`     // 초대 유효 기간
+	ExpiredReason *string           `json:"expired_reason,omitempty"` // REVOKED, EXPIRED
+	Sender        *UserResponse     `json:"sender,omitempty"`
+	GroupKey      *string           `json:"group_key,omitempty"`
+	GroupCount    int               `json:"group_count"` // 묶인 알림 수 (1이면 단일 알림)
+	UpdatedAt     string            `json:"updated_at"`
+	Data          *NotificationData `json:"data,omitempty"`
 }
 
-// GetMyNotifications 내 알림 목록 조회
+// GetMyNotifications 내 알림 목록 조회 (최신순, cursor 페이지네이션)
+// 기본은 읽지 않은 알림만, include_read=true면 읽은 알림도 포함, type은 쉼표로 여러 개 지정
 func (h *NotificationHandler) GetMyNotifications(c *fiber.Ctx) error {
 	claims := c.Locals("claims").(*auth.Claims)
 
+	limit := c.QueryInt("limit", notificationListDefaultLimit)
+	if limit <= 0 || limit > notificationListMaxLimit {
+		limit = notificationListDefaultLimit
+	}
+
+	query := h.db.Where("receiver_id = ?", claims.UserID)
+	if !c.QueryBool("include_read", false) {
+		query = query.Where("is_read = ?", false)
+	}
+	if types := notificationTypeFilter(c.Query("type")); len(types) > 0 {
+		query = query.Where("type IN ?", types)
+	}
+	if cursor := c.Query("cursor"); cursor != "" {
+		beforeID, err := strconv.ParseInt(cursor, 10, 64)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "invalid cursor",
+			})
+		}
+		query = query.Where("id < ?", beforeID)
+	}
+
+	// 다음 페이지가 있는지 알기 위해 하나 더 조회
 	var notifications []model.Notification
-	err := h.db.
-		Where("receiver_id = ? AND is_read = ?", claims.UserID, false).
+	err := query.
 		Preload("Sender").
-		Order("created_at DESC").
-		Limit(50).
+		Order("id DESC").
+		Limit(limit + 1).
 		Find(&notifications).Error
 
 	if err != nil {
@@ -50,6 +90,13 @@ func (h *NotificationHandler) GetMyNotifications(c *fiber.Ctx) error {
 		})
 	}
 
+	var nextCursor *string
+	if len(notifications) > limit {
+		notifications = notifications[:limit]
+		next := strconv.FormatInt(notifications[limit-1].ID, 10)
+		nextCursor = &next
+	}
+
 	responses := make([]NotificationResponse, len(notifications))
 	for i, n := range notifications {
 		responses[i] = h.toNotificationResponse(&n)
@@ -58,9 +105,73 @@ func (h *NotificationHandler) GetMyNotifications(c *fiber.Ctx) error {
 	return c.JSON(fiber.Map{
 		"notifications": responses,
 		"total":         len(responses),
+		"next_cursor":   nextCursor,
+		"has_more":      nextCursor != nil,
+	})
+}
+
+// GetUnreadCount 읽지 않은 알림 수 (알림 배지용)
+func (h *NotificationHandler) GetUnreadCount(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+
+	var count int64
+	if err := h.db.Model(&model.Notification{}).
+		Where("receiver_id = ? AND is_read = ?", claims.UserID, false).
+		Count(&count).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to count notifications",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"unread_count": count,
+	})
+}
+
+// MarkAllAsRead 내 알림 모두 읽음 처리
+// type으로 종류를 제한하고, up_to(알림 ID)를 주면 목록을 본 이후 도착한 알림은 그대로 둠
+func (h *NotificationHandler) MarkAllAsRead(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+
+	query := h.db.Model(&model.Notification{}).
+		Where("receiver_id = ? AND is_read = ?", claims.UserID, false)
+	if types := notificationTypeFilter(c.Query("type")); len(types) > 0 {
+		query = query.Where("type IN ?", types)
+	}
+	if upTo := c.Query("up_to"); upTo != "" {
+		maxID, err := strconv.ParseInt(upTo, 10, 64)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "invalid up_to",
+			})
+		}
+		query = query.Where("id <= ?", maxID)
+	}
+
+	result := query.Update("is_read", true)
+	if result.Error != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to mark notifications as read",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "notifications marked as read",
+		"updated": result.RowsAffected,
 	})
 }
 
+// notificationTypeFilter 쉼표로 구분한 알림 타입 목록 (예: "EVENT_REMINDER,ROLE_MENTION")
+func notificationTypeFilter(raw string) []string {
+	var types []string
+	for _, t := range strings.Split(raw, ",") {
+		if t = strings.ToUpper(strings.TrimSpace(t)); t != "" {
+			types = append(types, t)
+		}
+	}
+	return types
+}
+
 // AcceptInvitation 초대 수락 (WORKSPACE_INVITE 타입의 알림)
 func (h *NotificationHandler) AcceptInvitation(c *fiber.Ctx) error {
 	claims := c.Locals("claims").(*auth.Claims)
@@ -99,13 +210,26 @@ func (h *NotificationHandler) AcceptInvitation(c *fiber.Ctx) error {
 		})
 	}
 
+	// 관리자가 취소했거나 유효 기간이 지난 초대
+	if notification.ExpiredAt != nil {
+		return inviteGone(c, &notification)
+	}
+	if inviteExpired(&notification) {
+		if err := expireInvitation(h.db, &notification); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "failed to process notification",
+			})
+		}
+		return inviteGone(c, &notification)
+	}
+
 	workspaceID := *notification.RelatedID
 
 	// 트랜잭션으로 처리
 	tx := h.db.Begin()
 
-	// 알림 읽음 처리
-	if err := tx.Model(&notification).Update("is_read", true).Error; err != nil {
+	// 알림 읽음 처리 (처리한 초대는 더 이상 만료 대상이 아님)
+	if err := tx.Model(&notification).Updates(map[string]interface{}{"is_read": true, "expires_at": nil}).Error; err != nil {
 		tx.Rollback()
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "failed to process notification",
@@ -130,17 +254,16 @@ func (h *NotificationHandler) AcceptInvitation(c *fiber.Ctx) error {
 		})
 	}
 
-	// 기본 역할(Default Role) 찾기 및 할당
-	var defaultRole model.Role
-	if err := tx.Where("workspace_id = ? AND is_default = ?", workspaceID, true).First(&defaultRole).Error; err == nil {
-		// 기본 역할이 있으면 할당
-		if err := tx.Model(&member).Update("role_id", defaultRole.ID).Error; err != nil {
+	// 초대할 때 역할을 받지 못한 멤버(기존 초대)는 기본 역할 할당
+	if defaultRoleID := defaultRoleIDWithTx(tx, workspaceID); member.RoleID == nil && defaultRoleID != nil {
+		if err := tx.Model(&member).Update("role_id", *defaultRoleID).Error; err != nil {
 			// 역할 할당 실패는 로그만 남기고 계속 진행 (치명적이지 않음)
 			fmt.Printf("Failed to assign default role to user %d in workspace %d: %v\n", claims.UserID, workspaceID, err)
 		}
 	}
 
 	tx.Commit()
+	auth.InvalidateMemberPermissions(workspaceID, claims.UserID)
 
 	return c.JSON(fiber.Map{
 		"message":      "invitation accepted",
@@ -186,13 +309,26 @@ func (h *NotificationHandler) DeclineInvitation(c *fiber.Ctx) error {
 		})
 	}
 
+	// 관리자가 취소했거나 유효 기간이 지난 초대
+	if notification.ExpiredAt != nil {
+		return inviteGone(c, &notification)
+	}
+	if inviteExpired(&notification) {
+		if err := expireInvitation(h.db, &notification); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "failed to process notification",
+			})
+		}
+		return inviteGone(c, &notification)
+	}
+
 	workspaceID := *notification.RelatedID
 
 	// 트랜잭션으로 처리
 	tx := h.db.Begin()
 
-	// 알림 읽음 처리
-	if err := tx.Model(&notification).Update("is_read", true).Error; err != nil {
+	// 알림 읽음 처리 (처리한 초대는 더 이상 만료 대상이 아님)
+	if err := tx.Model(&notification).Updates(map[string]interface{}{"is_read": true, "expires_at": nil}).Error; err != nil {
 		tx.Rollback()
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "failed to process notification",
@@ -208,6 +344,7 @@ func (h *NotificationHandler) DeclineInvitation(c *fiber.Ctx) error {
 	}
 
 	tx.Commit()
+	auth.InvalidateMemberPermissions(workspaceID, claims.UserID)
 
 	return c.JSON(fiber.Map{
 		"message": "invitation declined",
@@ -241,72 +378,64 @@ func (h *NotificationHandler) MarkAsRead(c *fiber.Ctx) error {
 
 // 헬퍼: 알림 생성 (다른 핸들러에서 사용)
 func CreateNotification(db *gorm.DB, receiverID int64, senderID *int64, notificationType, content string, relatedType *string, relatedID *int64) error {
-	notification := model.Notification{
-		ReceiverID:  receiverID,
-		SenderID:    senderID,
-		Type:        notificationType,
-		Content:     content,
-		RelatedType: relatedType,
-		RelatedID:   relatedID,
-	}
+	return CreateNotifications(db, []int64{receiverID}, senderID, notificationType, content, relatedType, relatedID)
+}
 
-	if err := db.Create(&notification).Error; err != nil {
-		return err
+// CreateNotifications 같은 내용의 알림을 여러 사용자에게 한 번에 생성 (멘션 등 대량 발송용)
+func CreateNotifications(db *gorm.DB, receiverIDs []int64, senderID *int64, notificationType, content string, relatedType *string, relatedID *int64) error {
+	if len(receiverIDs) == 0 {
+		return nil
 	}
 
-	// WebSocket으로 실시간 푸시
-	go func() {
-		// Sender 정보 로드
-		var sender *model.User
-		if senderID != nil {
-			var user model.User
-			if err := db.First(&user, *senderID).Error; err == nil {
-				sender = &user
-			}
-		}
-
-		payload := NotificationPayload{
-			ID:          notification.ID,
-			Type:        notification.Type,
-			Content:     notification.Content,
-			IsRead:      notification.IsRead,
-			RelatedType: notification.RelatedType,
-			RelatedID:   notification.RelatedID,
-			CreatedAt:   notification.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	notifications := make([]model.Notification, len(receiverIDs))
+	for i, receiverID := range receiverIDs {
+		notifications[i] = model.Notification{
+			ReceiverID:  receiverID,
+			SenderID:    senderID,
+			Type:        notificationType,
+			Content:     content,
+			RelatedType: relatedType,
+			RelatedID:   relatedID,
 		}
-
-		if sender != nil {
-			payload.Sender = &UserResponse{
-				ID:         sender.ID,
-				Email:      sender.Email,
-				Nickname:   sender.Nickname,
-				ProfileImg: sender.ProfileImg,
-			}
-		}
-
-		GetNotificationWSHandler().SendToUser(receiverID, payload)
-	}()
-
-	return nil
+	}
+	return dispatchNotifications(db, notifications)
 }
 
 // 헬퍼: 초대 알림 생성
 func CreateWorkspaceInviteNotification(db *gorm.DB, inviterID, inviteeID, workspaceID int64, workspaceName, inviterName string) error {
 	content := fmt.Sprintf("%s님이 %s 워크스페이스에 초대했습니다.", inviterName, workspaceName)
 	relatedType := "WORKSPACE"
-	return CreateNotification(db, inviteeID, &inviterID, model.NotificationTypeWorkspaceInvite.String(), content, &relatedType, &workspaceID)
+	return dispatchNotifications(db, []model.Notification{{
+		ReceiverID:  inviteeID,
+		SenderID:    &inviterID,
+		Type:        model.NotificationTypeWorkspaceInvite.String(),
+		Content:     content,
+		RelatedType: &relatedType,
+		RelatedID:   &workspaceID,
+		ExpiresAt:   inviteExpiresAt(),
+	}})
 }
 
 // 응답 변환
 func (h *NotificationHandler) toNotificationResponse(n *model.Notification) NotificationResponse {
 	resp := NotificationResponse{
-		ID:          n.ID,
-		Type:        n.Type,
-		Content:     n.Content,
-		IsRead:      n.IsRead,
-		RelatedType: n.RelatedType,
-		RelatedID:   n.RelatedID,
-		CreatedAt:   n.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		ID:            n.ID,
+		Type:          n.Type,
+		Content:       n.Content,
+		IsRead:        n.IsRead,
+		RelatedType:   n.RelatedType,
+		RelatedID:     n.RelatedID,
+		CreatedAt:     n.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		IsExpired:     n.ExpiredAt != nil || inviteExpired(n),
+		ExpiredReason: notificationExpiredReason(n),
+		GroupKey:      n.GroupKey,
+		GroupCount:    max(n.GroupCount, 1),
+		UpdatedAt:     n.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		Data:          parseNotificationData(n.Data),
+	}
+	if n.ExpiresAt != nil {
+		expiresAt := n.ExpiresAt.Format("2006-01-02T15:04:05Z07:00")
+		resp.ExpiresAt = &expiresAt
 	}
 
 	if n.Sender != nil && n.Sender.ID != 0 {
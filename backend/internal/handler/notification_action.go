@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+
+	"realtime-backend/internal/model"
+)
+
+// 알림 주 동작 종류 (클라이언트 토스트의 버튼)
+const (
+	NotificationActionJoinMeeting = "JOIN_MEETING"
+	NotificationActionOpenEvent   = "OPEN_EVENT"
+	NotificationActionOpenFile    = "OPEN_FILE"
+)
+
+// NotificationData 실행 가능한 알림의 구조화 정보
+type NotificationData struct {
+	Route  string              `json:"route"`            // 딥 링크 (웹 앱 기준 경로, section으로 워크스페이스 화면 선택)
+	Action *NotificationAction `json:"action,omitempty"` // 주 동작
+}
+
+// NotificationAction 알림의 주 동작
+type NotificationAction struct {
+	Type   string            `json:"type"` // JOIN_MEETING, OPEN_EVENT, OPEN_FILE
+	Label  string            `json:"label"`
+	Params map[string]string `json:"params,omitempty"` // 동작에 필요한 값 (meeting_code, event_id, file_id 등)
+}
+
+// createActionNotifications 구조화 정보가 있는 알림을 여러 사용자에게 생성
+func createActionNotifications(db *gorm.DB, receiverIDs []int64, senderID *int64, notificationType, content string, relatedType *string, relatedID *int64, data NotificationData) error {
+	if len(receiverIDs) == 0 {
+		return nil
+	}
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	raw := string(encoded)
+
+	notifications := make([]model.Notification, len(receiverIDs))
+	for i, receiverID := range receiverIDs {
+		notifications[i] = model.Notification{
+			ReceiverID:  receiverID,
+			SenderID:    senderID,
+			Type:        notificationType,
+			Content:     content,
+			RelatedType: relatedType,
+			RelatedID:   relatedID,
+			Data:        &raw,
+		}
+	}
+	return dispatchNotifications(db, notifications)
+}
+
+// parseNotificationData 저장된 구조화 정보 (없거나 잘못된 JSON이면 nil)
+func parseNotificationData(raw *string) *NotificationData {
+	if raw == nil || *raw == "" {
+		return nil
+	}
+	var data NotificationData
+	if err := json.Unmarshal([]byte(*raw), &data); err != nil {
+		return nil
+	}
+	return &data
+}
+
+// meetingNotificationData 회의 참여 동작
+func meetingNotificationData(workspaceID int64, meeting *model.Meeting) NotificationData {
+	return NotificationData{
+		Route: fmt.Sprintf("/workspace/%d?meeting=%s", workspaceID, url.QueryEscape(meeting.Code)),
+		Action: &NotificationAction{
+			Type:  NotificationActionJoinMeeting,
+			Label: "참여하기",
+			Params: map[string]string{
+				"workspace_id": strconv.FormatInt(workspaceID, 10),
+				"meeting_id":   strconv.FormatInt(meeting.ID, 10),
+				"meeting_code": meeting.Code,
+			},
+		},
+	}
+}
+
+// eventNotificationData 일정 열기 동작 (반복 일정은 회차의 원래 시작 시각 포함)
+// 연결된 회의가 있으면 주 동작은 회의 참여
+func eventNotificationData(o eventOccurrence) NotificationData {
+	event := o.event
+	query := url.Values{
+		"section": {"calendar"},
+		"event":   {strconv.FormatInt(event.ID, 10)},
+	}
+	params := map[string]string{
+		"workspace_id": strconv.FormatInt(event.WorkspaceID, 10),
+		"event_id":     strconv.FormatInt(event.ID, 10),
+		"start":        o.start.UTC().Format(time.RFC3339),
+	}
+	if o.occurrence != nil {
+		occurrence := o.occurrence.UTC().Format(time.RFC3339)
+		query.Set("occurrence", occurrence)
+		params["occurrence"] = occurrence
+	}
+	data := NotificationData{
+		Route: fmt.Sprintf("/workspace/%d?%s", event.WorkspaceID, query.Encode()),
+		Action: &NotificationAction{
+			Type:   NotificationActionOpenEvent,
+			Label:  "일정 보기",
+			Params: params,
+		},
+	}
+	if m := event.LinkedMeeting; m != nil && m.ID != 0 {
+		data.Route = fmt.Sprintf("/workspace/%d?meeting=%s", event.WorkspaceID, url.QueryEscape(m.Code))
+		params["meeting_id"] = strconv.FormatInt(m.ID, 10)
+		params["meeting_code"] = m.Code
+		data.Action.Type = NotificationActionJoinMeeting
+		data.Action.Label = "회의 참여"
+	}
+	return data
+}
+
+// fileNotificationData 파일/폴더 열기 동작 (파일은 상위 폴더를 열고 선택)
+func fileNotificationData(file *model.WorkspaceFile) NotificationData {
+	query := url.Values{"section": {"storage"}}
+	params := map[string]string{
+		"workspace_id": strconv.FormatInt(file.WorkspaceID, 10),
+		"file_id":      strconv.FormatInt(file.ID, 10),
+	}
+	if file.Type == "FOLDER" {
+		query.Set("folder", strconv.FormatInt(file.ID, 10))
+	} else {
+		if file.ParentFolderID != nil {
+			query.Set("folder", strconv.FormatInt(*file.ParentFolderID, 10))
+		}
+		query.Set("file", strconv.FormatInt(file.ID, 10))
+	}
+	return NotificationData{
+		Route: fmt.Sprintf("/workspace/%d?%s", file.WorkspaceID, query.Encode()),
+		Action: &NotificationAction{
+			Type:   NotificationActionOpenFile,
+			Label:  "열기",
+			Params: params,
+		},
+	}
+}
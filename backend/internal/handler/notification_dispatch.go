@@ -0,0 +1,284 @@
+package handler
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+
+	"realtime-backend/internal/errorreport"
+	"realtime-backend/internal/model"
+)
+
+const (
+	// notificationGroupWindow 마지막으로 묶인 뒤 이 시간 안에 온 알림만 같은 행으로 묶음
+	notificationGroupWindow = 30 * time.Minute
+
+	// notificationCoalesceWindow 같은 묶음의 실시간 전송은 이 간격에 한 번 (마지막 상태만)
+	notificationCoalesceWindow = 3 * time.Second
+)
+
+// groupedNotificationTypes 같은 대상(채널, 일정, 폴더)에 대한 알림을 하나로 묶는 종류
+var groupedNotificationTypes = map[string]bool{
+	model.NotificationTypeRoleMention.String():  true,
+	model.NotificationTypeEventComment.String(): true,
+	model.NotificationTypeFileActivity.String(): true,
+}
+
+// dispatchNotifications 알림 저장 후 수신자에게 실시간 전달
+// 모든 알림 생성은 이 경로를 거침 (저장에 성공한 알림만 전달)
+// 트랜잭션 안에서 호출하면 롤백돼도 전달되므로 커밋 후 db로 호출할 것
+func dispatchNotifications(db *gorm.DB, notifications []model.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	// 묶을 수 있는 알림은 기존 행의 개수/내용만 갱신
+	for i := range notifications {
+		if key := notificationGroupKey(&notifications[i]); key != "" {
+			notifications[i].GroupKey = &key
+		}
+	}
+	merged := mergeGroupedNotifications(db, notifications)
+
+	fresh := make([]model.Notification, 0, len(notifications))
+	var grouped []model.Notification
+	for i := range notifications {
+		if m, ok := merged[i]; ok {
+			grouped = append(grouped, m)
+			continue
+		}
+		fresh = append(fresh, notifications[i])
+	}
+
+	if len(fresh) > 0 {
+		if err := db.CreateInBatches(&fresh, 500).Error; err != nil {
+			return err
+		}
+	}
+
+	// 발신자는 요청 안에서 한 번만 조회 (전달 고루틴에서 db를 쓰지 않도록)
+	var sender *UserResponse
+	if senderID := notifications[0].SenderID; senderID != nil {
+		var user model.User
+		if err := db.Select("id", "email", "nickname", "profile_img").First(&user, *senderID).Error; err == nil {
+			sender = &UserResponse{
+				ID:         user.ID,
+				Email:      user.Email,
+				Nickname:   user.Nickname,
+				ProfileImg: user.ProfileImg,
+			}
+		}
+	}
+
+	payloads := make([]NotificationPayload, 0, len(fresh)+len(grouped))
+	for i := range fresh {
+		payloads = append(payloads, toNotificationPayload(&fresh[i], sender))
+	}
+	for i := range grouped {
+		payloads = append(payloads, toNotificationPayload(&grouped[i], sender))
+	}
+	go pushNotifications(payloads)
+	return nil
+}
+
+// notificationGroupKey 묶음 알림 키 (종류:대상 종류:대상 ID), 묶지 않는 알림은 빈 문자열
+func notificationGroupKey(n *model.Notification) string {
+	if !groupedNotificationTypes[n.Type] || n.RelatedType == nil || n.RelatedID == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s:%s:%d", n.Type, *n.RelatedType, *n.RelatedID)
+}
+
+// mergeGroupedNotifications 같은 키의 읽지 않은 최근 알림에 합침 (합쳐진 알림의 인덱스 → 갱신된 행)
+// 동시에 다른 요청이 같은 행을 갱신해 조건부 UPDATE가 실패하면 새 알림으로 저장
+func mergeGroupedNotifications(db *gorm.DB, notifications []model.Notification) map[int]model.Notification {
+	byKey := make(map[string][]int)
+	for i := range notifications {
+		if key := notifications[i].GroupKey; key != nil {
+			byKey[*key] = append(byKey[*key], i)
+		}
+	}
+	if len(byKey) == 0 {
+		return nil
+	}
+
+	merged := make(map[int]model.Notification)
+	since := time.Now().Add(-notificationGroupWindow)
+	for key, indexes := range byKey {
+		receiverIDs := make([]int64, len(indexes))
+		for j, i := range indexes {
+			receiverIDs[j] = notifications[i].ReceiverID
+		}
+
+		var existing []model.Notification
+		if err := db.Where("receiver_id IN ? AND group_key = ? AND is_read = ? AND expired_at IS NULL AND updated_at > ?", receiverIDs, key, false, since).
+			Order("id DESC").
+			Find(&existing).Error; err != nil || len(existing) == 0 {
+			continue
+		}
+		latest := make(map[int64]*model.Notification, len(existing))
+		for j := range existing {
+			if _, ok := latest[existing[j].ReceiverID]; !ok {
+				latest[existing[j].ReceiverID] = &existing[j]
+			}
+		}
+
+		label := ""
+		for _, i := range indexes {
+			n := &notifications[i]
+			row := latest[n.ReceiverID]
+			if row == nil {
+				continue
+			}
+			if label == "" {
+				label = notificationGroupLabel(db, n)
+			}
+			count := max(row.GroupCount, 1) + 1
+			content := groupedNotificationContent(n, label, count)
+			now := time.Now()
+
+			result := db.Model(&model.Notification{}).
+				Where("id = ? AND is_read = ? AND group_count = ?", row.ID, false, row.GroupCount).
+				Updates(map[string]interface{}{
+					"group_count": count,
+					"content":     content,
+					"sender_id":   n.SenderID,
+					"updated_at":  now,
+				})
+			if result.Error != nil || result.RowsAffected == 0 {
+				continue
+			}
+			row.GroupCount = count
+			row.Content = content
+			row.SenderID = n.SenderID
+			row.UpdatedAt = now
+			merged[i] = *row
+		}
+	}
+	return merged
+}
+
+// notificationGroupLabel 묶음 알림 문구에 쓰는 대상 이름 (채널/일정/폴더 이름)
+func notificationGroupLabel(db *gorm.DB, n *model.Notification) string {
+	var name string
+	switch n.Type {
+	case model.NotificationTypeRoleMention.String():
+		db.Model(&model.Meeting{}).Where("id = ?", *n.RelatedID).Pluck("title", &name)
+	case model.NotificationTypeEventComment.String():
+		db.Model(&model.CalendarEvent{}).Where("id = ?", *n.RelatedID).Pluck("title", &name)
+	case model.NotificationTypeFileActivity.String():
+		db.Model(&model.WorkspaceFile{}).Where("id = ?", *n.RelatedID).Pluck("name", &name)
+	}
+	return name
+}
+
+// groupedNotificationContent 묶인 알림 내용 ("#general 채널에 새 멘션 5개")
+func groupedNotificationContent(n *model.Notification, label string, count int) string {
+	if label == "" {
+		return fmt.Sprintf("%s (외 %d건)", n.Content, count-1)
+	}
+	switch n.Type {
+	case model.NotificationTypeRoleMention.String():
+		return fmt.Sprintf("#%s 채널에 새 멘션 %d개", label, count)
+	case model.NotificationTypeEventComment.String():
+		return fmt.Sprintf("'%s' 일정에 새 댓글 %d개", label, count)
+	case model.NotificationTypeFileActivity.String():
+		return fmt.Sprintf("%s 폴더에 새 변경 사항 %d건", label, count)
+	}
+	return fmt.Sprintf("%s (외 %d건)", n.Content, count-1)
+}
+
+// pushNotifications 묶음 알림은 짧은 간격으로 모아 보내고 나머지는 바로 전달
+func pushNotifications(payloads []NotificationPayload) {
+	defer errorreport.Recover(errorreport.Context{Component: "notifications.push"})
+
+	deliverNotificationPayloads(notificationCoalescer.admit(payloads))
+}
+
+// deliverNotificationPayloads 수신자의 알림 WebSocket으로 전송, 오프라인 수신자는 기기 푸시, 초대는 메일로도
+// 방해 금지 중인 수신자는 실시간 전송/푸시를 건너뛰고 끝난 뒤 요약으로 받음 (메일은 그대로)
+func deliverNotificationPayloads(payloads []NotificationPayload) {
+	if len(payloads) == 0 {
+		return
+	}
+	if ping := holdMutedPayloads(payloads); len(ping) > 0 {
+		if ws := GetNotificationWSHandler(); ws != nil {
+			ws.Deliver(ping)
+		}
+		pushNotificationPayloads(ping)
+	}
+	emailNotificationPayloads(payloads)
+}
+
+// notificationCoalescer 수신자별 묶음 알림의 실시간 전송 모음
+// 창의 첫 알림은 바로 보내고, 창 안에 온 나머지는 마지막 상태 하나만 창이 끝날 때 보냄
+var notificationCoalescer = &coalescedNotifications{pending: make(map[string]*NotificationPayload)}
+
+type coalescedNotifications struct {
+	mu      sync.Mutex
+	pending map[string]*NotificationPayload // 창이 열린 키 → 창 끝에 보낼 알림 (없으면 nil)
+}
+
+// admit 지금 보낼 알림만 반환하고 나머지는 창 끝으로 미룸
+func (c *coalescedNotifications) admit(payloads []NotificationPayload) []NotificationPayload {
+	now := make([]NotificationPayload, 0, len(payloads))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, p := range payloads {
+		if p.GroupKey == nil {
+			now = append(now, p)
+			continue
+		}
+		key := fmt.Sprintf("%d:%s", p.ReceiverID, *p.GroupKey)
+		if _, open := c.pending[key]; open {
+			latest := p
+			c.pending[key] = &latest
+			continue
+		}
+		c.pending[key] = nil
+		time.AfterFunc(notificationCoalesceWindow, func() { c.flush(key) })
+		now = append(now, p)
+	}
+	return now
+}
+
+func (c *coalescedNotifications) flush(key string) {
+	defer errorreport.Recover(errorreport.Context{Component: "notifications.coalesce"})
+
+	c.mu.Lock()
+	latest := c.pending[key]
+	delete(c.pending, key)
+	c.mu.Unlock()
+
+	if latest != nil {
+		deliverNotificationPayloads([]NotificationPayload{*latest})
+	}
+}
+
+// toNotificationPayload 저장된 알림의 WebSocket 페이로드
+func toNotificationPayload(n *model.Notification, sender *UserResponse) NotificationPayload {
+	p := NotificationPayload{
+		ID:            n.ID,
+		ReceiverID:    n.ReceiverID,
+		Type:          n.Type,
+		Content:       n.Content,
+		IsRead:        n.IsRead,
+		RelatedType:   n.RelatedType,
+		RelatedID:     n.RelatedID,
+		CreatedAt:     n.CreatedAt.Format(time.RFC3339),
+		Sender:        sender,
+		IsExpired:     n.ExpiredAt != nil,
+		ExpiredReason: n.ExpiredReason,
+		GroupKey:      n.GroupKey,
+		GroupCount:    max(n.GroupCount, 1),
+		Data:          parseNotificationData(n.Data),
+	}
+	if n.ExpiresAt != nil {
+		expiresAt := n.ExpiresAt.Format(time.RFC3339)
+		p.ExpiresAt = &expiresAt
+	}
+	return p
+}
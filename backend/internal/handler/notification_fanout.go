@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+
+	"realtime-backend/internal/cache"
+	"realtime-backend/internal/errorreport"
+)
+
+// notificationFanoutChannel 모든 인스턴스가 구독하는 알림 전달 채널
+// 수신자의 알림 소켓이 어느 인스턴스에 붙어 있는지 모르므로 모두에게 보내고 각자 자기 연결에만 전달
+const notificationFanoutChannel = "notifications:deliver"
+
+// notificationFanoutEvent 채널로 발행하는 메시지 묶음
+type notificationFanoutEvent struct {
+	Origin  string                     `json:"origin"` // 발행한 인스턴스 (자기 이벤트는 무시)
+	Targets []notificationFanoutTarget `json:"targets"`
+}
+
+// notificationFanoutTarget 수신자와 소켓으로 보낼 메시지 (직렬화된 NotificationWSMessage)
+type notificationFanoutTarget struct {
+	ReceiverID int64           `json:"receiver_id"`
+	Message    json.RawMessage `json:"message"`
+}
+
+// SetRedisClient 인스턴스 간 알림 전달 활성화 (nil이면 이 인스턴스의 연결에만 전달)
+func (h *NotificationWSHandler) SetRedisClient(client *cache.RedisClient) {
+	if client == nil || h.redisClient != nil {
+		return
+	}
+	h.redisClient = client
+	h.instanceID = uuid.NewString()
+	go h.runFanoutSubscriber()
+}
+
+// Deliver 알림을 수신자의 모든 알림 소켓으로 전달 (이 인스턴스 연결 + 다른 인스턴스로 발행)
+func (h *NotificationWSHandler) Deliver(payloads []NotificationPayload) {
+	targets := make([]notificationFanoutTarget, 0, len(payloads))
+	for _, p := range payloads {
+		msg, err := json.Marshal(NotificationWSMessage{Type: "notification", Payload: p})
+		if err != nil {
+			log.Printf("알림 직렬화 실패: %v", err)
+			continue
+		}
+		targets = append(targets, notificationFanoutTarget{ReceiverID: p.ReceiverID, Message: msg})
+	}
+	h.deliverTargets(targets)
+}
+
+// DeliverMessage 알림 외 메시지(요약 등)를 사용자의 모든 알림 소켓으로 전달
+func (h *NotificationWSHandler) DeliverMessage(userID int64, msg NotificationWSMessage) {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		log.Printf("알림 메시지 직렬화 실패: %v", err)
+		return
+	}
+	h.deliverTargets([]notificationFanoutTarget{{ReceiverID: userID, Message: data}})
+}
+
+func (h *NotificationWSHandler) deliverTargets(targets []notificationFanoutTarget) {
+	if len(targets) == 0 {
+		return
+	}
+	for _, t := range targets {
+		h.sendRaw(t.ReceiverID, t.Message)
+	}
+	if h.redisClient == nil {
+		return
+	}
+
+	data, err := json.Marshal(notificationFanoutEvent{Origin: h.instanceID, Targets: targets})
+	if err != nil {
+		log.Printf("⚠️ 알림 전달 이벤트 직렬화 실패: %v", err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := h.redisClient.Publish(ctx, notificationFanoutChannel, data); err != nil {
+		log.Printf("⚠️ 알림 전달 이벤트 발행 실패: %v", err)
+	}
+}
+
+// runFanoutSubscriber 다른 인스턴스가 발행한 메시지를 이 인스턴스의 연결로 전달
+func (h *NotificationWSHandler) runFanoutSubscriber() {
+	defer errorreport.Recover(errorreport.Context{Component: "notifications.fanout_subscriber"})
+
+	pubsub := h.redisClient.Subscribe(context.Background(), notificationFanoutChannel)
+	defer pubsub.Close()
+
+	log.Printf("📡 Notification fan-out subscriber started (instance: %s)", h.instanceID)
+
+	for msg := range pubsub.Channel() {
+		var event notificationFanoutEvent
+		if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+			log.Printf("⚠️ 잘못된 알림 전달 이벤트: %v", err)
+			continue
+		}
+		if event.Origin == h.instanceID {
+			continue
+		}
+		for _, t := range event.Targets {
+			h.sendRaw(t.ReceiverID, t.Message)
+		}
+	}
+}
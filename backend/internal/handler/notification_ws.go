@@ -2,23 +2,48 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"log"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/gofiber/contrib/websocket"
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 
+	"realtime-backend/internal/cache"
+	"realtime-backend/internal/errorreport"
 	"realtime-backend/internal/model"
 	"realtime-backend/internal/presence"
 )
 
+// notificationWriteTimeout 알림 소켓 한 번 쓰기 제한 시간 (느린 클라이언트가 전송을 막지 않도록)
+const notificationWriteTimeout = 5 * time.Second
+
+// notificationClient 알림 WebSocket 연결 하나
+// 알림 생성, presence 브로드캐스트, pong 응답이 서로 다른 고루틴에서 쓰므로 쓰기를 직렬화
+type notificationClient struct {
+	conn    *websocket.Conn
+	writeMu sync.Mutex
+}
+
+// write 메시지 전송 (연결별 쓰기 잠금)
+func (c *notificationClient) write(msg []byte) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	c.conn.SetWriteDeadline(time.Now().Add(notificationWriteTimeout))
+	return c.conn.WriteMessage(websocket.TextMessage, msg)
+}
+
 // NotificationWSHandler 알림 WebSocket 핸들러
 type NotificationWSHandler struct {
-	clients         map[int64]map[*websocket.Conn]bool // userID -> connections
-	subscriptions   map[int64]map[int64]bool           // targetUserID -> set of subscriberUserIDs
+	clients         map[int64]map[*websocket.Conn]*notificationClient // userID -> connections
+	subscriptions   map[int64]map[int64]bool                          // targetUserID -> set of subscriberUserIDs
 	presenceManager *presence.Manager
 	db              *gorm.DB
+	redisClient     *cache.RedisClient // 인스턴스 간 알림 전달 (nil이면 이 인스턴스만)
+	instanceID      string
 
 	mu    sync.RWMutex // clients 보호용
 	subMu sync.RWMutex // subscriptions 보호용
@@ -26,20 +51,27 @@ type NotificationWSHandler struct {
 
 // NotificationWSMessage 알림 WebSocket 메시지
 type NotificationWSMessage struct {
-	Type    string      `json:"type"` // notification, ping, pong, heartbeat, change_status
+	Type    string      `json:"type"` // notification, notification_summary, ping, pong, heartbeat, change_status
 	Payload interface{} `json:"payload,omitempty"`
 }
 
 // NotificationPayload 알림 페이로드
 type NotificationPayload struct {
-	ID          int64         `json:"id"`
-	Type        string        `json:"type"`
-	Content     string        `json:"content"`
-	IsRead      bool          `json:"is_read"`
-	RelatedType *string       `json:"related_type,omitempty"`
-	RelatedID   *int64        `json:"related_id,omitempty"`
-	CreatedAt   string        `json:"created_at"`
-	Sender      *UserResponse `json:"sender,omitempty"`
+	ID            int64             `json:"id"`
+	ReceiverID    int64             `json:"-"`
+	Type          string            `json:"type"`
+	Content       string            `json:"content"`
+	IsRead        bool              `json:"is_read"`
+	RelatedType   *string           `json:"related_type,omitempty"`
+	RelatedID     *int64            `json:"related_id,omitempty"`
+	CreatedAt     string            `json:"created_at"`
+	Sender        *UserResponse     `json:"sender,omitempty"`
+	IsExpired     bool              `json:"is_expired"`
+	ExpiresAt     *string           `json:"expires_at,omitempty"`
+	ExpiredReason *string           `json:"expired_reason,omitempty"`
+	GroupKey      *string           `json:"group_key,omitempty"` // 묶음 알림이면 같은 id로 개수/내용이 갱신되어 다시 옴
+	GroupCount    int               `json:"group_count"`
+	Data          *NotificationData `json:"data,omitempty"` // 딥 링크와 주 동작 (실행 가능한 알림만)
 }
 
 // 글로벌 인스턴스 (싱글톤)
@@ -55,7 +87,7 @@ func GetNotificationWSHandler() *NotificationWSHandler {
 func NewNotificationWSHandler(db *gorm.DB, pm *presence.Manager) *NotificationWSHandler {
 	notificationWSOnce.Do(func() {
 		notificationWSHandler = &NotificationWSHandler{
-			clients:         make(map[int64]map[*websocket.Conn]bool),
+			clients:         make(map[int64]map[*websocket.Conn]*notificationClient),
 			subscriptions:   make(map[int64]map[int64]bool),
 			presenceManager: pm,
 			db:              db,
@@ -69,8 +101,57 @@ func NewNotificationWSHandler(db *gorm.DB, pm *presence.Manager) *NotificationWS
 	return notificationWSHandler
 }
 
+// restorePresence 저장된 기본 상태/커스텀 상태로 Presence 설정 후 발행 (연결 직후, 만료된 뒤 heartbeat가 올 때)
+func (h *NotificationWSHandler) restorePresence(userID int64) {
+	status := presence.StatusOnline
+	var statusMsg *string
+	var statusEmoji *string
+
+	// DB에서 사용자 조회 (커스텀 상태 확인)
+	if h.db != nil {
+		var user model.User
+		if err := h.db.Select("default_status, custom_status_text, custom_status_emoji, custom_status_expires_at").First(&user, userID).Error; err == nil {
+			if user.DefaultStatus != "" {
+				status = presence.PresenceStatus(user.DefaultStatus)
+			}
+			// 만료됐지만 아직 정리되지 않은 메시지는 복원하지 않음
+			if user.CustomStatusExpiresAt != nil && !user.CustomStatusExpiresAt.After(time.Now()) {
+				user.CustomStatusText = nil
+				user.CustomStatusEmoji = nil
+			}
+			if user.CustomStatusText != nil && *user.CustomStatusText != "" {
+				statusMsg = user.CustomStatusText
+			}
+			if user.CustomStatusEmoji != nil && *user.CustomStatusEmoji != "" {
+				statusEmoji = user.CustomStatusEmoji
+			}
+		}
+	}
+
+	// Redis에 초기 상태 설정 (DB 값 포함)
+	if err := h.presenceManager.SetPresence(userID, status, h.presenceManager.ServerID(), statusMsg, statusEmoji); err != nil {
+		log.Printf("Presence 설정 실패: %v", err)
+	}
+
+	// 브로드캐스트 (내 상태를 다른 사람들에게 알림)
+	data := presence.PresenceData{
+		UserID:             userID,
+		Status:             status,
+		LastHeartbeat:      time.Now().Unix(),
+		ServerID:           h.presenceManager.ServerID(),
+		StatusMessage:      statusMsg,
+		StatusMessageEmoji: statusEmoji,
+	}
+	if devices, err := h.presenceManager.Devices(userID); err == nil {
+		data.Devices = devices
+	}
+	h.presenceManager.PublishPresence(data)
+}
+
 // listenPresenceUpdates Redis로부터 상태 변경 이벤트 수신 및 브로드캐스트
 func (h *NotificationWSHandler) listenPresenceUpdates() {
+	defer errorreport.Recover(errorreport.Context{Component: "notifications.presence_listener"})
+
 	pubsub := h.presenceManager.SubscribePresence()
 	defer pubsub.Close()
 
@@ -115,10 +196,8 @@ func (h *NotificationWSHandler) broadcastPresenceUpdate(data presence.PresenceDa
 	defer h.mu.RUnlock()
 
 	for _, userID := range targetUserIDs {
-		if conns, ok := h.clients[userID]; ok {
-			for conn := range conns {
-				conn.WriteMessage(websocket.TextMessage, msgBytes)
-			}
+		for _, client := range h.clients[userID] {
+			client.write(msgBytes)
 		}
 	}
 }
@@ -128,7 +207,7 @@ func (h *NotificationWSHandler) HandleWebSocket(c *websocket.Conn) {
 	// 패닉 복구 - 서버 크래시 방지
 	defer func() {
 		if r := recover(); r != nil {
-			log.Printf("알림 WebSocket 패닉 복구: %v", r)
+			errorreport.CapturePanic(r, wsReportContext(c, "ws.notifications"))
 		}
 	}()
 
@@ -136,55 +215,27 @@ func (h *NotificationWSHandler) HandleWebSocket(c *websocket.Conn) {
 	userID, ok := userIDInterface.(int64)
 	if !ok {
 		c.WriteMessage(websocket.TextMessage, []byte(`{"type":"error","message":"invalid session"}`))
-		c.Close()
+		closeWS(c, WSCloseInternalError, "invalid session")
 		return
 	}
 
-	// 클라이언트 등록
+	// 클라이언트 등록 (기기별 세션은 Redis에 남겨 다른 인스턴스/기기와 합산)
+	client := &notificationClient{conn: c}
+	sessionID := uuid.NewString()
+	device := presence.NormalizeDevice(c.Query("device"))
 	h.mu.Lock()
 	if h.clients[userID] == nil {
-		h.clients[userID] = make(map[*websocket.Conn]bool)
+		h.clients[userID] = make(map[*websocket.Conn]*notificationClient)
 	}
-	h.clients[userID][c] = true
+	h.clients[userID][c] = client
 	h.mu.Unlock()
 
 	// Presence: Online 설정 (DB에서 커스텀 상태 조회)
 	if h.presenceManager != nil {
-		status := presence.StatusOnline
-		var statusMsg *string
-		var statusEmoji *string
-
-		// DB에서 사용자 조회 (커스텀 상태 확인)
-		if h.db != nil {
-			var user model.User
-			if err := h.db.Select("default_status, custom_status_text, custom_status_emoji").First(&user, userID).Error; err == nil {
-				if user.DefaultStatus != "" {
-					status = presence.PresenceStatus(user.DefaultStatus)
-				}
-				if user.CustomStatusText != nil && *user.CustomStatusText != "" {
-					statusMsg = user.CustomStatusText
-				}
-				if user.CustomStatusEmoji != nil && *user.CustomStatusEmoji != "" {
-					statusEmoji = user.CustomStatusEmoji
-				}
-			}
+		if err := h.presenceManager.TouchSession(userID, sessionID, device); err != nil {
+			log.Printf("Presence 세션 등록 실패: %v", err)
 		}
-
-		// Redis에 초기 상태 설정 (DB 값 포함)
-		if err := h.presenceManager.SetPresence(userID, status, "server-1", statusMsg, statusEmoji); err != nil {
-			log.Printf("Presence 설정 실패: %v", err)
-		}
-
-		// 연결 직후 브로드캐스트 (내 상태를 다른 사람들에게 알림)
-		data := presence.PresenceData{
-			UserID:             userID,
-			Status:             status,
-			LastHeartbeat:      time.Now().Unix(),
-			ServerID:           "server-1",
-			StatusMessage:      statusMsg,
-			StatusMessageEmoji: statusEmoji,
-		}
-		h.presenceManager.PublishPresence(data)
+		h.restorePresence(userID)
 	}
 
 	log.Printf("알림 WebSocket 연결: user=%d", userID)
@@ -193,21 +244,33 @@ func (h *NotificationWSHandler) HandleWebSocket(c *websocket.Conn) {
 	defer func() {
 		h.mu.Lock()
 		delete(h.clients[userID], c)
-		if len(h.clients[userID]) == 0 {
+		localEmpty := len(h.clients[userID]) == 0
+		if localEmpty {
 			delete(h.clients, userID)
-			// 마지막 연결이 끊기면 Offline 처리
-			if h.presenceManager != nil {
-				h.presenceManager.RemovePresence(userID)
-				// FIX: Broadcast offline status
+		}
+		h.mu.Unlock()
+
+		// 모든 기기의 마지막 연결이 끊길 때만 Offline 처리 (다른 기기가 남아 있으면 기기 목록만 갱신)
+		if h.presenceManager != nil {
+			last, err := h.presenceManager.EndSession(userID, sessionID, device)
+			if err != nil {
+				log.Printf("Presence 세션 종료 실패: %v", err)
+				last = localEmpty
+				if last {
+					h.presenceManager.RemovePresence(userID)
+				}
+			}
+			if last {
 				offData := presence.PresenceData{
 					UserID:   userID,
 					Status:   presence.StatusOffline,
-					ServerID: "server-1",
+					ServerID: h.presenceManager.ServerID(),
 				}
 				h.presenceManager.PublishPresence(offData)
+			} else if data, err := h.presenceManager.RefreshDevices(userID); err == nil && data != nil {
+				h.presenceManager.PublishPresence(*data)
 			}
 		}
-		h.mu.Unlock()
 		c.Close()
 		log.Printf("알림 WebSocket 연결 해제: user=%d", userID)
 	}()
@@ -228,12 +291,16 @@ func (h *NotificationWSHandler) HandleWebSocket(c *websocket.Conn) {
 		case "ping":
 			pong := NotificationWSMessage{Type: "pong"}
 			pongBytes, _ := json.Marshal(pong)
-			c.WriteMessage(websocket.TextMessage, pongBytes)
+			client.write(pongBytes)
 
 		case "heartbeat":
-			// 생존 신고 (TTL 연장)
+			// 생존 신고 (TTL 연장, 자동 IDLE이면 ONLINE 복귀)
+			// 오래 heartbeat가 없어 OFFLINE으로 내려간 뒤 다시 오면 상태를 새로 설정
 			if h.presenceManager != nil {
-				h.presenceManager.UpdateHeartbeat(userID)
+				h.presenceManager.TouchSession(userID, sessionID, device)
+				if err := h.presenceManager.UpdateHeartbeat(userID); errors.Is(err, presence.ErrOffline) {
+					h.restorePresence(userID)
+				}
 			}
 
 		case "change_status":
@@ -263,18 +330,47 @@ func (h *NotificationWSHandler) HandleWebSocket(c *websocket.Conn) {
 
 						status := presence.PresenceStatus(statusStr)
 						// Update Redis with preserved message/emoji
-						h.presenceManager.SetPresence(userID, status, "server-1", currentMsg, currentEmoji)
+						h.presenceManager.SetPresence(userID, status, h.presenceManager.ServerID(), currentMsg, currentEmoji)
 
 						// 변경된 상태 전파
 						data := presence.PresenceData{
 							UserID:             userID,
 							Status:             status,
 							LastHeartbeat:      time.Now().Unix(),
-							ServerID:           "server-1",
+							ServerID:           h.presenceManager.ServerID(),
 							StatusMessage:      currentMsg,
 							StatusMessageEmoji: currentEmoji,
 						}
+						if devices, err := h.presenceManager.Devices(userID); err == nil {
+							data.Devices = devices
+						}
 						h.presenceManager.PublishPresence(data)
+
+						// DND를 끄면 그동안 쌓인 알림 요약을 바로 전달
+						if h.db != nil && !strings.EqualFold(statusStr, string(presence.StatusDND)) {
+							go flushHeldNotifications(h.db, userID)
+						}
+					}
+				}
+			}
+
+		case "change_activity":
+			// 통화 중 활동 변경 (화면 공유 시작/종료, 통화 종료 시 activity 빈 문자열)
+			if h.presenceManager != nil {
+				if payloadMap, ok := msg.Payload.(map[string]interface{}); ok {
+					activity, _ := payloadMap["activity"].(string)
+					room, _ := payloadMap["room"].(string)
+					var err error
+					switch {
+					case activity == "":
+						err = h.presenceManager.ClearActivity(userID, room)
+					case presence.ValidActivity(presence.Activity(activity)):
+						err = h.presenceManager.SetActivity(userID, presence.Activity(activity), room, inCallActivityTTL)
+					default:
+						log.Printf("알 수 없는 활동: user=%d, activity=%s", userID, activity)
+					}
+					if err != nil {
+						log.Printf("활동 변경 실패: user=%d, err=%v", userID, err)
 					}
 				}
 			}
@@ -286,13 +382,25 @@ func (h *NotificationWSHandler) HandleWebSocket(c *websocket.Conn) {
 					text, _ := payloadMap["text"].(string)
 					emoji, _ := payloadMap["emoji"].(string)
 
+					// 자동 삭제 시각 (expires_at 또는 clear_after, 없으면 만료 없음)
+					expiresAt, _ := payloadMap["expires_at"].(string)
+					clearAfter, _ := payloadMap["clear_after"].(string)
+					expiry, err := parseStatusExpiry(expiresAt, clearAfter, time.Now())
+					if err != nil {
+						log.Printf("상태 메시지 만료 시간 오류: user=%d, err=%v", userID, err)
+						break
+					}
+					if text == "" && emoji == "" {
+						expiry = nil
+					}
+
 					// DB 업데이트
 					if h.db != nil {
 						updates := map[string]interface{}{
-							"custom_status_text":  text,
-							"custom_status_emoji": emoji,
+							"custom_status_text":       text,
+							"custom_status_emoji":      emoji,
+							"custom_status_expires_at": expiry,
 						}
-						// expires_at 등은 추후 구현
 						if err := h.db.Model(&model.User{}).Where("id = ?", userID).Updates(updates).Error; err != nil {
 							log.Printf("DB Custom Status update failed: %v", err)
 						}
@@ -311,13 +419,13 @@ func (h *NotificationWSHandler) HandleWebSocket(c *websocket.Conn) {
 					}
 
 					// Redis 업데이트 (새로운 메시지/이모지 반영)
-					h.presenceManager.SetPresence(userID, currentStatus, "server-1", &text, &emoji)
+					h.presenceManager.SetPresence(userID, currentStatus, h.presenceManager.ServerID(), &text, &emoji)
 
 					data := presence.PresenceData{
 						UserID:             userID,
 						Status:             currentStatus,
 						LastHeartbeat:      time.Now().Unix(),
-						ServerID:           "server-1",
+						ServerID:           h.presenceManager.ServerID(),
 						StatusMessage:      &text,
 						StatusMessageEmoji: &emoji,
 					}
@@ -356,7 +464,7 @@ func (h *NotificationWSHandler) HandleWebSocket(c *websocket.Conn) {
 								Payload: presenceMap,
 							}
 							syncBytes, _ := json.Marshal(syncMsg)
-							c.WriteMessage(websocket.TextMessage, syncBytes)
+							client.write(syncBytes)
 						}
 					}
 				}
@@ -365,16 +473,8 @@ func (h *NotificationWSHandler) HandleWebSocket(c *websocket.Conn) {
 	}
 }
 
-// SendToUser 특정 사용자에게 알림 전송
+// SendToUser 특정 사용자에게 알림 전송 (이 인스턴스에 연결된 소켓만, 다른 인스턴스까지는 Deliver)
 func (h *NotificationWSHandler) SendToUser(userID int64, notification NotificationPayload) {
-	h.mu.RLock()
-	connections := h.clients[userID]
-	h.mu.RUnlock()
-
-	if len(connections) == 0 {
-		return
-	}
-
 	msg := NotificationWSMessage{
 		Type:    "notification",
 		Payload: notification,
@@ -385,17 +485,40 @@ func (h *NotificationWSHandler) SendToUser(userID int64, notification Notificati
 		log.Printf("알림 직렬화 실패: %v", err)
 		return
 	}
+	h.sendRaw(userID, msgBytes)
+}
 
+// sendRaw 직렬화된 메시지를 이 인스턴스에 연결된 사용자 소켓으로 전송
+func (h *NotificationWSHandler) sendRaw(userID int64, msgBytes []byte) {
 	h.mu.RLock()
-	defer h.mu.RUnlock()
+	clients := make([]*notificationClient, 0, len(h.clients[userID]))
+	for _, client := range h.clients[userID] {
+		clients = append(clients, client)
+	}
+	h.mu.RUnlock()
 
-	for conn := range h.clients[userID] {
-		if err := conn.WriteMessage(websocket.TextMessage, msgBytes); err != nil {
+	for _, client := range clients {
+		if err := client.write(msgBytes); err != nil {
 			log.Printf("알림 전송 실패: user=%d, err=%v", userID, err)
 		}
 	}
 }
 
+// IsOnline 알림 WebSocket이 연결된 사용자인지 (이 인스턴스 연결 또는 presence 기준)
+func (h *NotificationWSHandler) IsOnline(userID int64) bool {
+	h.mu.RLock()
+	local := len(h.clients[userID]) > 0
+	h.mu.RUnlock()
+	if local {
+		return true
+	}
+	if h.presenceManager == nil {
+		return false
+	}
+	p, err := h.presenceManager.GetPresence(userID)
+	return err == nil && p != nil && p.Status != presence.StatusOffline
+}
+
 // GetConnectedUsers 연결된 사용자 수 반환
 func (h *NotificationWSHandler) GetConnectedUsers() int {
 	h.mu.RLock()
@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"realtime-backend/internal/auth"
+	"realtime-backend/internal/model"
+)
+
+const (
+	maxPersonalTokens         = 25
+	maxPersonalTokenNameLen   = 100
+	maxPersonalTokenValidDays = 365
+)
+
+// CreatePersonalTokenRequest 개인 액세스 토큰 발급 요청
+type CreatePersonalTokenRequest struct {
+	Name          string   `json:"name"`
+	Scopes        []string `json:"scopes"`          // read, write (비어 있으면 read)
+	ExpiresInDays *int     `json:"expires_in_days"` // nil이면 만료 없음
+}
+
+// PersonalTokenResponse 개인 액세스 토큰 정보 (원문 토큰은 발급 응답에만)
+type PersonalTokenResponse struct {
+	ID          int64    `json:"id"`
+	Name        string   `json:"name"`
+	TokenPrefix string   `json:"token_prefix"`
+	Scopes      []string `json:"scopes"`
+	ExpiresAt   *string  `json:"expires_at,omitempty"`
+	LastUsedAt  *string  `json:"last_used_at,omitempty"`
+	CreatedAt   string   `json:"created_at"`
+	Token       string   `json:"token,omitempty"`
+}
+
+// ListPersonalTokens 내 개인 액세스 토큰 목록 (취소된 토큰 제외)
+func (h *AuthHandler) ListPersonalTokens(c *fiber.Ctx) error {
+	claims, err := auth.GetClaimsFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "authentication required"})
+	}
+
+	var tokens []model.PersonalAccessToken
+	if err := h.db.Where("user_id = ? AND revoked_at IS NULL", claims.UserID).
+		Order("created_at DESC").
+		Find(&tokens).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to load tokens"})
+	}
+
+	resp := make([]PersonalTokenResponse, len(tokens))
+	for i := range tokens {
+		resp[i] = toPersonalTokenResponse(&tokens[i])
+	}
+	return c.JSON(fiber.Map{"tokens": resp})
+}
+
+// CreatePersonalToken 개인 액세스 토큰 발급 (원문은 이 응답에서만 확인 가능)
+func (h *AuthHandler) CreatePersonalToken(c *fiber.Ctx) error {
+	claims, err := auth.GetClaimsFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "authentication required"})
+	}
+	// 대리 접속 중에 만든 토큰은 대리 접속이 끝나도 남고 감사 기록과 이어지지 않으므로 거부 (라우트의 RejectImpersonation과 별도로 확인)
+	if claims.IsImpersonating() {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "personal access tokens cannot be created while impersonating",
+			"code":  "IMPERSONATION_NOT_ALLOWED",
+		})
+	}
+
+	var req CreatePersonalTokenRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid request body"})
+	}
+	name := sanitizeString(strings.TrimSpace(req.Name))
+	if name == "" || len([]rune(name)) > maxPersonalTokenNameLen {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "name must be 1-100 characters"})
+	}
+	scopes := []string{auth.ScopeRead}
+	if len(req.Scopes) > 0 {
+		scopes = nil
+		for _, scope := range req.Scopes {
+			if !auth.ValidScope(scope) {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid scope: " + scope})
+			}
+			if !slices.Contains(scopes, scope) {
+				scopes = append(scopes, scope)
+			}
+		}
+	}
+	var expiresAt *time.Time
+	if req.ExpiresInDays != nil {
+		if *req.ExpiresInDays < 1 || *req.ExpiresInDays > maxPersonalTokenValidDays {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "expires_in_days must be between 1 and 365"})
+		}
+		t := time.Now().AddDate(0, 0, *req.ExpiresInDays)
+		expiresAt = &t
+	}
+
+	var count int64
+	h.db.Model(&model.PersonalAccessToken{}).Where("user_id = ? AND revoked_at IS NULL", claims.UserID).Count(&count)
+	if count >= maxPersonalTokens {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "too many tokens, revoke an unused one first"})
+	}
+
+	raw, _, err := auth.NewOpaqueToken()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to generate token"})
+	}
+	raw = auth.PersonalTokenPrefix + raw
+
+	token := model.PersonalAccessToken{
+		UserID:      claims.UserID,
+		Name:        name,
+		TokenPrefix: raw[:len(auth.PersonalTokenPrefix)+4],
+		TokenHash:   auth.HashOpaqueToken(raw),
+		Scopes:      strings.Join(scopes, ","),
+		ExpiresAt:   expiresAt,
+	}
+	if err := h.db.Create(&token).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to create token"})
+	}
+
+	resp := toPersonalTokenResponse(&token)
+	resp.Token = raw
+	return c.Status(fiber.StatusCreated).JSON(resp)
+}
+
+// RevokePersonalToken 개인 액세스 토큰 취소
+func (h *AuthHandler) RevokePersonalToken(c *fiber.Ctx) error {
+	claims, err := auth.GetClaimsFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "authentication required"})
+	}
+	tokenID, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid token id"})
+	}
+
+	var token model.PersonalAccessToken
+	if err := h.db.Where("id = ? AND user_id = ? AND revoked_at IS NULL", tokenID, claims.UserID).First(&token).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "token not found"})
+	}
+	if err := h.db.Model(&token).Update("revoked_at", time.Now()).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to revoke token"})
+	}
+	auth.InvalidatePersonalToken(token.TokenHash)
+
+	return c.JSON(fiber.Map{"message": "token revoked"})
+}
+
+func toPersonalTokenResponse(t *model.PersonalAccessToken) PersonalTokenResponse {
+	resp := PersonalTokenResponse{
+		ID:          t.ID,
+		Name:        t.Name,
+		TokenPrefix: t.TokenPrefix,
+		Scopes:      t.ScopeList(),
+		CreatedAt:   t.CreatedAt.Format(time.RFC3339),
+	}
+	if t.ExpiresAt != nil {
+		s := t.ExpiresAt.Format(time.RFC3339)
+		resp.ExpiresAt = &s
+	}
+	if t.LastUsedAt != nil {
+		s := t.LastUsedAt.Format(time.RFC3339)
+		resp.LastUsedAt = &s
+	}
+	return resp
+}
@@ -1,38 +1,73 @@
 package handler
 
 import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
+	"strconv"
+	"strings"
 	"time"
+	"unicode/utf8"
 
+	"realtime-backend/internal/auth"
 	"realtime-backend/internal/cache"
+	"realtime-backend/internal/errorreport"
+	"realtime-backend/internal/model"
 
 	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+const (
+	pollMaxQuestionLength  = 300
+	pollMaxOptionLength    = 100
+	pollMinOptions         = 2
+	pollMaxOptions         = 10
+	pollCountsCacheTTL     = 10 * time.Minute // live counts are rebuilt from poll_votes after this
+	pollExpiryScanInterval = 30 * time.Second
+	pollExpiryBatchSize    = 100
+)
+
+var (
+	errPollMeetingRequired = errors.New("meetingId or room is required")
+	errPollMeetingNotFound = errors.New("meeting not found")
+)
+
+// PollHandler stores polls and votes in Postgres. Redis (optional) only caches live vote counts.
 type PollHandler struct {
+	db    *gorm.DB
 	redis *cache.RedisClient
+	done  chan struct{}
 }
 
-func NewPollHandler(redis *cache.RedisClient) *PollHandler {
-	return &PollHandler{redis: redis}
+func NewPollHandler(db *gorm.DB, redis *cache.RedisClient) *PollHandler {
+	return &PollHandler{db: db, redis: redis}
 }
 
+// CreatePollRequest creates a poll in a meeting or chat room (meetingId, or room as used by the call/whiteboard APIs)
 type CreatePollRequest struct {
+	MeetingID   int64    `json:"meetingId"`
+	Room        string   `json:"room"`
 	Question    string   `json:"question"`
 	Options     []string `json:"options"`
-	Duration    int64    `json:"duration"` // ms
+	Duration    int64    `json:"duration"` // ms, 0 = open until closed
 	IsAnonymous bool     `json:"isAnonymous"`
 }
 
 type PollData struct {
 	ID          string   `json:"id"`
+	MeetingID   int64    `json:"meetingId"`
 	Question    string   `json:"question"`
 	Options     []string `json:"options"`
 	IsAnonymous bool     `json:"isAnonymous"`
 	CreatedAt   int64    `json:"createdAt"`
 	ExpiresAt   int64    `json:"expiresAt"`
-	CreatedBy   string   `json:"createdBy"` // User ID or Name
+	CreatedBy   string   `json:"createdBy"` // User ID
 	IsClosed    bool     `json:"isClosed"`
 }
 
@@ -40,201 +75,545 @@ type VoteRequest struct {
 	OptionIndex int `json:"optionIndex"`
 }
 
-// CreatePoll handles poll creation
-func (h *PollHandler) CreatePoll(c *fiber.Ctx) error {
-	ctx := c.UserContext()
-	if h.redis == nil {
-		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"error": "Redis not available"})
+// StartExpiryCloser starts closing polls whose duration has passed
+func (h *PollHandler) StartExpiryCloser() {
+	if h.done == nil {
+		h.done = make(chan struct{})
+		go h.runExpiryCloser(h.done)
 	}
+}
+
+// Close stops the expiry closer
+func (h *PollHandler) Close() {
+	if h.done != nil {
+		close(h.done)
+		h.done = nil
+	}
+}
+
+// CreatePoll handles poll creation and announces it in the room
+func (h *PollHandler) CreatePoll(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
 
 	var req CreatePollRequest
 	if err := c.BodyParser(&req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
 	}
+	question, options, msg := normalizePoll(req.Question, req.Options)
+	if msg != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": msg})
+	}
+	if req.Duration < 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "duration must not be negative"})
+	}
 
-	userID := c.Locals("userID")
-	// If userID is missing (e.g. from middleware), try to get from query or header, or fail.
-	// Assuming AuthMiddleware sets "userID" (int64) or similar.
-	// For simplified chat logic, we might use "sender" name if available, but AuthMiddleware uses ID.
-	userIdStr := fmt.Sprintf("%v", userID)
-	if userID == nil {
-		// Fallback for demo if auth is loose, or error
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	meeting, err := h.findMeeting(req.MeetingID, req.Room)
+	if err != nil {
+		return pollMeetingError(c, err)
+	}
+	if !h.requireAccess(c, meeting, claims.UserID, model.PermissionSendMessages) {
+		return nil
 	}
 
-	pollID := fmt.Sprintf("poll-%d", time.Now().UnixNano())
-	now := time.Now().UnixMilli()
-	expiresAt := now + req.Duration
-	if req.Duration <= 0 {
-		expiresAt = 0 // No expiry
+	publicID, err := newPollPublicID()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to save poll"})
 	}
 
-	poll := PollData{
-		ID:          pollID,
-		Question:    req.Question,
-		Options:     req.Options,
+	encoded, _ := json.Marshal(options)
+	poll := model.Poll{
+		PublicID:    publicID,
+		MeetingID:   meeting.ID,
+		Question:    question,
+		Options:     string(encoded),
 		IsAnonymous: req.IsAnonymous,
-		CreatedAt:   now,
-		ExpiresAt:   expiresAt,
-		CreatedBy:   userIdStr,
-		IsClosed:    false,
+		CreatedBy:   claims.UserID,
+	}
+	if req.Duration > 0 {
+		expiresAt := time.Now().Add(time.Duration(req.Duration) * time.Millisecond)
+		poll.ExpiresAt = &expiresAt
+	}
+	if err := h.db.Create(&poll).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to save poll"})
 	}
 
-	// Save Metadata to Redis
-	// Key: poll:{id}:meta
-	metaKey := fmt.Sprintf("poll:%s:meta", pollID)
-	data, _ := json.Marshal(poll)
+	message := fmt.Sprintf("📊 %s님이 투표를 만들었습니다: %s", claims.Nickname, poll.Question)
+	h.db.Create(&model.ChatLog{
+		MeetingID: meeting.ID,
+		Message:   &message,
+		Type:      "SYSTEM",
+	})
+
+	return c.JSON(toPollData(&poll, options))
+}
 
-	// Save with TTL (if duration exists, + buffer)
-	ttl := time.Duration(req.Duration)*time.Millisecond + 24*time.Hour
-	if req.Duration <= 0 {
-		ttl = 24 * time.Hour // Default 24h retention
+// newPollPublicID returns an unguessable "poll-{32 hex}" ID (fits the 40-char unique column)
+func newPollPublicID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
 	}
+	return "poll-" + hex.EncodeToString(buf), nil
+}
+
+// ListPolls returns the polls of a meeting or chat room (?meetingId= or ?room=), newest first
+func (h *PollHandler) ListPolls(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
 
-	err := h.redis.Set(ctx, metaKey, string(data), ttl)
+	meeting, err := h.findMeeting(int64(c.QueryInt("meetingId")), c.Query("room"))
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to save poll"})
+		return pollMeetingError(c, err)
+	}
+	if !h.requireAccess(c, meeting, claims.UserID, "") {
+		return nil
 	}
 
-	return c.JSON(poll)
+	var polls []model.Poll
+	if err := h.db.Where("meeting_id = ?", meeting.ID).Order("id DESC").Limit(100).Find(&polls).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get polls"})
+	}
+
+	result := make([]fiber.Map, 0, len(polls))
+	for i := range polls {
+		options := pollOptions(&polls[i])
+		result = append(result, fiber.Map{
+			"poll":  toPollData(&polls[i], options),
+			"votes": h.voteCounts(c.UserContext(), &polls[i], len(options)),
+		})
+	}
+	return c.JSON(fiber.Map{"polls": result})
 }
 
 // GetPoll returns poll status and votes
 func (h *PollHandler) GetPoll(c *fiber.Ctx) error {
-	ctx := c.UserContext()
-	pollID := c.Params("id")
-	if pollID == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Poll ID required"})
-	}
+	claims := c.Locals("claims").(*auth.Claims)
 
-	// Get Meta
-	metaKey := fmt.Sprintf("poll:%s:meta", pollID)
-	val, err := h.redis.Get(ctx, metaKey)
+	poll, meeting, err := h.loadPoll(c.Params("id"))
 	if err != nil {
 		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Poll not found"})
 	}
-
-	var poll PollData
-	json.Unmarshal([]byte(val), &poll)
-
-	// Get Votes
-	// Key: poll:{id}:votes (Hash: optionIdx -> count)
-	votesKey := fmt.Sprintf("poll:%s:votes", pollID)
-	counts, err := h.redis.HGetAll(ctx, votesKey)
-	if err != nil {
-		// No votes yet is fine
-		counts = make(map[string]string)
+	if !h.requireAccess(c, meeting, claims.UserID, "") {
+		return nil
 	}
 
-	// Convert counts map[string]string to map[int]int
-	voteCounts := make(map[int]int)
-	for k, v := range counts {
-		var idx int
-		var count int
-		fmt.Sscanf(k, "%d", &idx)
-		fmt.Sscanf(v, "%d", &count)
-		voteCounts[idx] = count
+	options := pollOptions(poll)
+	resp := fiber.Map{
+		"poll":  toPollData(poll, options),
+		"votes": h.voteCounts(c.UserContext(), poll, len(options)),
 	}
-
-	return c.JSON(fiber.Map{
-		"poll":  poll,
-		"votes": voteCounts,
-	})
+	var vote model.PollVote
+	if err := h.db.Where("poll_id = ? AND user_id = ?", poll.ID, claims.UserID).First(&vote).Error; err == nil {
+		resp["myVote"] = vote.OptionIndex
+	}
+	return c.JSON(resp)
 }
 
-// Vote handles casting a vote
+// Vote handles casting a vote (one per user)
 func (h *PollHandler) Vote(c *fiber.Ctx) error {
-	ctx := c.UserContext()
-	pollID := c.Params("id")
-	if pollID == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Poll ID required"})
-	}
+	claims := c.Locals("claims").(*auth.Claims)
 
 	var req VoteRequest
 	if err := c.BodyParser(&req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid body"})
 	}
 
-	userID := c.Locals("userID")
-	userIdStr := fmt.Sprintf("%v", userID)
-
-	// 1. Check Poll Status
-	metaKey := fmt.Sprintf("poll:%s:meta", pollID)
-	val, err := h.redis.Get(ctx, metaKey)
+	poll, meeting, err := h.loadPoll(c.Params("id"))
 	if err != nil {
 		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Poll not found"})
 	}
-	var poll PollData
-	json.Unmarshal([]byte(val), &poll)
+	if !h.requireAccess(c, meeting, claims.UserID, model.PermissionSendMessages) {
+		return nil
+	}
 
-	if poll.IsClosed {
+	if poll.ClosedAt != nil {
 		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "Poll is closed"})
 	}
-	if poll.ExpiresAt > 0 && time.Now().UnixMilli() > poll.ExpiresAt {
+	if pollExpired(poll) {
 		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "Poll expired"})
 	}
+	options := pollOptions(poll)
+	if req.OptionIndex < 0 || req.OptionIndex >= len(options) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid option"})
+	}
 
-	// 2. Check Double Vote
-	// Key: poll:{id}:voted_users (Set)
-	votedKey := fmt.Sprintf("poll:%s:voted_users", pollID)
-	isMember, err := h.redis.SIsMember(ctx, votedKey, userIdStr)
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Redis error"})
+	// The composite primary key (poll_id, user_id) rejects a second vote even under concurrent requests
+	result := h.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&model.PollVote{
+		PollID:      poll.ID,
+		UserID:      claims.UserID,
+		OptionIndex: req.OptionIndex,
+	})
+	if result.Error != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to count vote"})
 	}
-	if isMember {
+	if result.RowsAffected == 0 {
 		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Already voted"})
 	}
 
-	// 3. Cast Vote
-	// Add user to set
-	h.redis.SAdd(ctx, votedKey, userIdStr)
-
-	// Increment Option Count
-	votesKey := fmt.Sprintf("poll:%s:votes", pollID)
-	newCount, err := h.redis.HIncrBy(ctx, votesKey, fmt.Sprintf("%d", req.OptionIndex), 1)
-	if err != nil {
-		// Rollback user add? (Optional, implies data inconsistency risk if we don't)
-		h.redis.SRem(ctx, votedKey, userIdStr)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to count vote"})
+	newCount, cached := h.incrementCachedCount(c.UserContext(), poll, req.OptionIndex)
+	if !cached {
+		var count int64
+		h.db.Model(&model.PollVote{}).Where("poll_id = ? AND option_index = ?", poll.ID, req.OptionIndex).Count(&count)
+		newCount = count
 	}
 
 	return c.JSON(fiber.Map{
 		"success":     true,
-		"pollId":      pollID,
+		"pollId":      poll.PublicID,
 		"optionIndex": req.OptionIndex,
 		"newCount":    newCount,
 	})
 }
 
-// ClosePoll handles manual closing
+// ClosePoll handles manual closing by the creator (or whoever manages the room)
 func (h *PollHandler) ClosePoll(c *fiber.Ctx) error {
-	ctx := c.UserContext()
-	pollID := c.Params("id")
-	userID := c.Locals("userID")
-	userIdStr := fmt.Sprintf("%v", userID)
+	claims := c.Locals("claims").(*auth.Claims)
 
-	metaKey := fmt.Sprintf("poll:%s:meta", pollID)
-	val, err := h.redis.Get(ctx, metaKey)
+	poll, meeting, err := h.loadPoll(c.Params("id"))
 	if err != nil {
 		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Poll not found"})
 	}
-	var poll PollData
-	json.Unmarshal([]byte(val), &poll)
-
-	// Check ownership
-	if poll.CreatedBy != userIdStr {
+	if !h.requireAccess(c, meeting, claims.UserID, "") {
+		return nil
+	}
+	if poll.CreatedBy != claims.UserID && !h.canManageRoom(meeting, claims.UserID) {
 		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "Only creator can close"})
 	}
 
-	// Update Close Status
-	poll.IsClosed = true
-	updatedData, _ := json.Marshal(poll)
+	closed, err := h.closePoll(c.UserContext(), poll, claims.Nickname)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to close poll"})
+	}
+	if !closed {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Poll is already closed"})
+	}
+	return c.JSON(fiber.Map{"success": true})
+}
 
-	// TTL remains same
-	h.redis.Set(ctx, metaKey, string(updatedData), 0) // 0 = keep existing TTL ideally, but Set might reset.
-	// To be safe, we should assume Redis wrapper handles it or we re-set TTL.
-	// For MVP, just setting it is fine (default persistence or long enough).
-	// Actually `Set` usually overwrites. Detailed implementation depends on `cache/redis.go`.
+// closePoll marks the poll closed and posts the final results to the room.
+// Returns false if another request (or the expiry closer) closed it first.
+func (h *PollHandler) closePoll(ctx context.Context, poll *model.Poll, closedBy string) (bool, error) {
+	now := time.Now()
+	result := h.db.Model(&model.Poll{}).
+		Where("id = ? AND closed_at IS NULL", poll.ID).
+		Update("closed_at", now)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+	poll.ClosedAt = &now
 
-	return c.JSON(fiber.Map{"success": true})
+	options := pollOptions(poll)
+	counts, err := h.countVotes(poll.ID, len(options))
+	if err != nil {
+		log.Printf("⚠️ 투표 결과 집계 실패: poll=%s, err=%v", poll.PublicID, err)
+	} else {
+		h.cacheCounts(ctx, poll, counts)
+	}
+
+	message := pollClosedMessage(poll, options, counts, closedBy)
+	h.db.Create(&model.ChatLog{
+		MeetingID: poll.MeetingID,
+		Message:   &message,
+		Type:      "SYSTEM",
+	})
+	return true, nil
+}
+
+// voteCounts returns option index -> vote count, from the Redis cache when it holds the poll,
+// otherwise from poll_votes (and warms the cache)
+func (h *PollHandler) voteCounts(ctx context.Context, poll *model.Poll, optionCount int) map[int]int {
+	if h.redis != nil {
+		cached, err := h.redis.HGetAll(ctx, pollVotesKey(poll))
+		if err == nil && len(cached) > 0 {
+			counts := make(map[int]int, optionCount)
+			for k, v := range cached {
+				idx, err1 := strconv.Atoi(k)
+				count, err2 := strconv.Atoi(v)
+				if err1 == nil && err2 == nil && idx >= 0 && idx < optionCount {
+					counts[idx] = count
+				}
+			}
+			return counts
+		}
+	}
+
+	counts, err := h.countVotes(poll.ID, optionCount)
+	if err != nil {
+		log.Printf("⚠️ 투표 집계 실패: poll=%s, err=%v", poll.PublicID, err)
+		return counts
+	}
+	h.cacheCounts(ctx, poll, counts)
+	return counts
+}
+
+// countVotes aggregates poll_votes; every option is present so the cached hash is never empty
+func (h *PollHandler) countVotes(pollID int64, optionCount int) (map[int]int, error) {
+	counts := make(map[int]int, optionCount)
+	for i := 0; i < optionCount; i++ {
+		counts[i] = 0
+	}
+
+	var rows []struct {
+		OptionIndex int
+		Count       int
+	}
+	if err := h.db.Model(&model.PollVote{}).
+		Select("option_index, COUNT(*) AS count").
+		Where("poll_id = ?", pollID).
+		Group("option_index").
+		Scan(&rows).Error; err != nil {
+		return counts, err
+	}
+	for _, row := range rows {
+		if row.OptionIndex >= 0 && row.OptionIndex < optionCount {
+			counts[row.OptionIndex] = row.Count
+		}
+	}
+	return counts, nil
+}
+
+// cacheCounts writes counts read from poll_votes to Redis.
+// A vote committed between that read and this write is missing until the entry expires,
+// which is why the cache is short-lived and poll_votes stays the source of truth.
+func (h *PollHandler) cacheCounts(ctx context.Context, poll *model.Poll, counts map[int]int) {
+	if h.redis == nil || len(counts) == 0 {
+		return
+	}
+	values := make(map[string]interface{}, len(counts))
+	for idx, count := range counts {
+		values[strconv.Itoa(idx)] = count
+	}
+	if err := h.redis.HSetWithExpiry(ctx, pollVotesKey(poll), values, pollCountsCacheTTL); err != nil {
+		log.Printf("⚠️ 투표 집계 캐시 저장 실패: poll=%s, err=%v", poll.PublicID, err)
+	}
+}
+
+// incrementCachedCount bumps the cached count for a new vote; false if the poll is not cached
+func (h *PollHandler) incrementCachedCount(ctx context.Context, poll *model.Poll, optionIndex int) (int64, bool) {
+	if h.redis == nil {
+		return 0, false
+	}
+	count, ok, err := h.redis.HIncrByIfExists(ctx, pollVotesKey(poll), strconv.Itoa(optionIndex), 1)
+	if err != nil {
+		log.Printf("⚠️ 투표 집계 캐시 갱신 실패: poll=%s, err=%v", poll.PublicID, err)
+		return 0, false
+	}
+	return count, ok
+}
+
+// loadPoll finds a poll by its public ID together with its meeting
+func (h *PollHandler) loadPoll(publicID string) (*model.Poll, *model.Meeting, error) {
+	if publicID == "" {
+		return nil, nil, gorm.ErrRecordNotFound
+	}
+	var poll model.Poll
+	if err := h.db.Where("public_id = ?", publicID).First(&poll).Error; err != nil {
+		return nil, nil, err
+	}
+	var meeting model.Meeting
+	if err := h.db.First(&meeting, poll.MeetingID).Error; err != nil {
+		return nil, nil, err
+	}
+	return &poll, &meeting, nil
+}
+
+// findMeeting resolves the meeting or chat room a poll belongs to.
+// room accepts "meeting-{id}", a raw ID or a meeting code (e.g. "workspace-46-call-general").
+func (h *PollHandler) findMeeting(meetingID int64, room string) (*model.Meeting, error) {
+	if meetingID == 0 && room != "" {
+		if id, err := strconv.ParseInt(strings.TrimPrefix(room, "meeting-"), 10, 64); err == nil {
+			meetingID = id
+		}
+	}
+
+	var meeting model.Meeting
+	var err error
+	switch {
+	case meetingID != 0:
+		err = h.db.First(&meeting, meetingID).Error
+	case room != "":
+		err = h.db.Where("code = ?", room).First(&meeting).Error
+	default:
+		return nil, errPollMeetingRequired
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errPollMeetingNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &meeting, nil
+}
+
+// requireAccess checks that the user belongs to the meeting: workspace members for workspace
+// meetings/chat rooms (plus the given channel permission, if any), otherwise the host and participants.
+// Writes the error response and returns false on failure.
+func (h *PollHandler) requireAccess(c *fiber.Ctx, meeting *model.Meeting, userID int64, permission string) bool {
+	if meeting.WorkspaceID == nil {
+		if meeting.HostID == userID {
+			return true
+		}
+		var count int64
+		h.db.Model(&model.Participant{}).
+			Where("meeting_id = ? AND user_id = ?", meeting.ID, userID).
+			Count(&count)
+		if count == 0 {
+			c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "you are not a participant of this meeting"})
+			return false
+		}
+		return true
+	}
+
+	var count int64
+	h.db.Model(&model.WorkspaceMember{}).
+		Where("workspace_id = ? AND user_id = ? AND status = ?", *meeting.WorkspaceID, userID, model.MemberStatusActive.String()).
+		Count(&count)
+	if count == 0 {
+		c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "you are not a member of this workspace"})
+		return false
+	}
+	if permission == "" {
+		return true
+	}
+
+	allowed, err := auth.CheckChannelPermission(h.db, *meeting.WorkspaceID, meeting.ID, userID, permission)
+	if err != nil {
+		c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "permission check failed"})
+		return false
+	}
+	if !allowed {
+		c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "permission denied: " + permission})
+		return false
+	}
+	return true
+}
+
+// canManageRoom reports whether the user may close others' polls: the meeting host or MANAGE_CHANNELS
+func (h *PollHandler) canManageRoom(meeting *model.Meeting, userID int64) bool {
+	if meeting.HostID == userID {
+		return true
+	}
+	if meeting.WorkspaceID == nil {
+		return false
+	}
+	allowed, _ := auth.CheckPermission(h.db, *meeting.WorkspaceID, userID, model.PermissionManageChannels)
+	return allowed
+}
+
+func (h *PollHandler) runExpiryCloser(done <-chan struct{}) {
+	defer errorreport.Recover(errorreport.Context{Component: "poll.expiry_closer"})
+
+	ticker := time.NewTicker(pollExpiryScanInterval)
+	defer ticker.Stop()
+
+	for {
+		h.closeExpired()
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// closeExpired closes polls whose duration has passed so their results are posted to the room
+func (h *PollHandler) closeExpired() {
+	var polls []model.Poll
+	if err := h.db.Where("closed_at IS NULL AND expires_at <= ?", time.Now()).
+		Order("expires_at ASC").
+		Limit(pollExpiryBatchSize).
+		Find(&polls).Error; err != nil {
+		log.Printf("⚠️ 만료 투표 조회 실패: %v", err)
+		return
+	}
+	for i := range polls {
+		if _, err := h.closePoll(context.Background(), &polls[i], ""); err != nil {
+			log.Printf("⚠️ 만료 투표 마감 실패: poll=%s, err=%v", polls[i].PublicID, err)
+		}
+	}
+}
+
+// normalizePoll trims the question and options and returns an error message if they are invalid
+func normalizePoll(question string, options []string) (string, []string, string) {
+	question = strings.TrimSpace(question)
+	if question == "" {
+		return "", nil, "question is required"
+	}
+	if utf8.RuneCountInString(question) > pollMaxQuestionLength {
+		return "", nil, fmt.Sprintf("question must be at most %d characters", pollMaxQuestionLength)
+	}
+	if len(options) < pollMinOptions || len(options) > pollMaxOptions {
+		return "", nil, fmt.Sprintf("a poll needs %d to %d options", pollMinOptions, pollMaxOptions)
+	}
+
+	normalized := make([]string, 0, len(options))
+	for _, option := range options {
+		option = strings.TrimSpace(option)
+		if option == "" {
+			return "", nil, "options must not be empty"
+		}
+		if utf8.RuneCountInString(option) > pollMaxOptionLength {
+			return "", nil, fmt.Sprintf("options must be at most %d characters", pollMaxOptionLength)
+		}
+		normalized = append(normalized, option)
+	}
+	return question, normalized, ""
+}
+
+// pollClosedMessage is the SYSTEM message announcing the final results
+func pollClosedMessage(poll *model.Poll, options []string, counts map[int]int, closedBy string) string {
+	var b strings.Builder
+	if closedBy != "" {
+		fmt.Fprintf(&b, "📊 %s님이 투표를 마감했습니다: %s", closedBy, poll.Question)
+	} else {
+		fmt.Fprintf(&b, "📊 투표가 마감되었습니다: %s", poll.Question)
+	}
+	for i, option := range options {
+		fmt.Fprintf(&b, "\n- %s: %d표", option, counts[i])
+	}
+	return b.String()
+}
+
+func pollMeetingError(c *fiber.Ctx, err error) error {
+	if errors.Is(err, errPollMeetingNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+	}
+	if errors.Is(err, errPollMeetingRequired) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get meeting"})
+}
+
+func pollOptions(poll *model.Poll) []string {
+	var options []string
+	json.Unmarshal([]byte(poll.Options), &options)
+	return options
+}
+
+func pollExpired(poll *model.Poll) bool {
+	return poll.ExpiresAt != nil && time.Now().After(*poll.ExpiresAt)
+}
+
+// pollVotesKey is the Redis hash of live counts (option index -> votes)
+func pollVotesKey(poll *model.Poll) string {
+	return fmt.Sprintf("poll:%s:votes", poll.PublicID)
+}
+
+func toPollData(poll *model.Poll, options []string) PollData {
+	data := PollData{
+		ID:          poll.PublicID,
+		MeetingID:   poll.MeetingID,
+		Question:    poll.Question,
+		Options:     options,
+		IsAnonymous: poll.IsAnonymous,
+		CreatedAt:   poll.CreatedAt.UnixMilli(),
+		CreatedBy:   strconv.FormatInt(poll.CreatedBy, 10),
+		IsClosed:    poll.ClosedAt != nil || pollExpired(poll),
+	}
+	if poll.ExpiresAt != nil {
+		data.ExpiresAt = poll.ExpiresAt.UnixMilli()
+	}
+	return data
 }
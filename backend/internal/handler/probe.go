@@ -0,0 +1,301 @@
+package handler
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	wsclient "github.com/fasthttp/websocket"
+	"github.com/gofiber/contrib/websocket"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"realtime-backend/internal/config"
+)
+
+// probeChatRoomID 채팅 프로브 전용 채팅방 ID (실제 meetings.id와 겹치지 않도록 음수)
+const probeChatRoomID int64 = -1
+
+// ProbeHandler 외부 업타임 모니터용 합성 프로브
+// 서버 자신에게 WebSocket으로 접속해 실제 경로를 한 바퀴 돌고 단계별 소요 시간을 보고
+type ProbeHandler struct {
+	cfg          *config.Config
+	roomHub      *RoomHub
+	probeUserSeq atomic.Int64
+}
+
+// NewProbeHandler ProbeHandler 생성
+func NewProbeHandler(cfg *config.Config, roomHub *RoomHub) *ProbeHandler {
+	return &ProbeHandler{cfg: cfg, roomHub: roomHub}
+}
+
+// ProbeStep 프로브 단계별 결과
+type ProbeStep struct {
+	Name       string `json:"name"`
+	OK         bool   `json:"ok"`
+	DurationMs int64  `json:"duration_ms"`
+	Error      string `json:"error,omitempty"`
+}
+
+// ProbeResult 프로브 결과 (status: pass | fail)
+type ProbeResult struct {
+	Probe     string      `json:"probe"`
+	Status    string      `json:"status"`
+	Mode      string      `json:"mode,omitempty"`
+	Steps     []ProbeStep `json:"steps"`
+	TotalMs   int64       `json:"total_ms"`
+	CheckedAt string      `json:"checked_at"`
+}
+
+// RequireProbeToken X-Probe-Token 헤더 검증 (PROBE_TOKEN 미설정 시 프로브 비활성화)
+func (h *ProbeHandler) RequireProbeToken(c *fiber.Ctx) error {
+	if h.cfg.Probe.Token == "" {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "probes are disabled",
+		})
+	}
+
+	token := c.Get("X-Probe-Token")
+	if subtle.ConstantTimeCompare([]byte(token), []byte(h.cfg.Probe.Token)) != 1 {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "invalid probe token",
+		})
+	}
+	return c.Next()
+}
+
+// PrepareChatProbe /ws/probe/chat 연결에 채팅 세션 정보 설정
+// 전용 채팅방(probeChatRoomID)과 가상 사용자(음수 ID)로 ChatWSHandler를 그대로 사용
+func (h *ProbeHandler) PrepareChatProbe(c *fiber.Ctx) error {
+	if !websocket.IsWebSocketUpgrade(c) {
+		return fiber.ErrUpgradeRequired
+	}
+
+	c.Locals("roomId", probeChatRoomID)
+	c.Locals("workspaceId", int64(0))
+	c.Locals("userId", -h.probeUserSeq.Add(1))
+	c.Locals("nickname", sanitizeString(c.Query("nickname", "probe")))
+	return c.Next()
+}
+
+// ProbeWSChat 채팅 WebSocket 프로브
+// 가상 사용자 2명이 접속해 한쪽의 typing 이벤트가 다른 쪽에 전달되는지 확인 (DB에 저장되는 메시지 없음)
+func (h *ProbeHandler) ProbeWSChat(c *fiber.Ctx) error {
+	result := h.newResult("ws-chat", "")
+	deadline := time.Now().Add(h.cfg.Probe.Timeout)
+	nonce := uuid.NewString()[:8]
+
+	sender, err := runProbeStep(result, "connect_sender", func() (*wsclient.Conn, error) {
+		return h.dial(deadline, "/ws/probe/chat", url.Values{"nickname": {"probe-sender-" + nonce}})
+	})
+	if err != nil {
+		return h.finish(c, result)
+	}
+	defer sender.Close()
+
+	receiver, err := runProbeStep(result, "connect_receiver", func() (*wsclient.Conn, error) {
+		return h.dial(deadline, "/ws/probe/chat", url.Values{"nickname": {"probe-receiver-" + nonce}})
+	})
+	if err != nil {
+		return h.finish(c, result)
+	}
+	defer receiver.Close()
+
+	runProbeStep(result, "broadcast", func() (any, error) {
+		sender.SetWriteDeadline(deadline)
+		if err := sender.WriteMessage(wsclient.TextMessage, []byte(`{"type":"typing"}`)); err != nil {
+			return nil, err
+		}
+
+		receiver.SetReadDeadline(deadline)
+		for {
+			_, data, err := receiver.ReadMessage()
+			if err != nil {
+				return nil, fmt.Errorf("no typing event received: %w", err)
+			}
+
+			var msg struct {
+				Type    string        `json:"type"`
+				Payload TypingPayload `json:"payload"`
+			}
+			if json.Unmarshal(data, &msg) != nil {
+				continue
+			}
+			// 동시에 실행된 다른 프로브의 이벤트는 무시
+			if msg.Type == "typing" && msg.Payload.Nickname == "probe-sender-"+nonce {
+				return nil, nil
+			}
+		}
+	})
+
+	return h.finish(c, result)
+}
+
+// ProbeRoomPipeline 음성 번역 Room 파이프라인 프로브
+// 프로브 전용 Room(probe-*)에 리스너로 접속해 합성 오디오를 보내고 자막 응답을 확인
+// 프로브 Room은 AWS/AI 서버 대신 모의 파이프라인이 응답하므로 비용이 발생하지 않음
+func (h *ProbeHandler) ProbeRoomPipeline(c *fiber.Ctx) error {
+	result := h.newResult("room-pipeline", "mock")
+	deadline := time.Now().Add(h.cfg.Probe.Timeout)
+
+	if h.roomHub == nil {
+		result.Steps = append(result.Steps, ProbeStep{Name: "connect", Error: "room hub is not available"})
+		return h.finish(c, result)
+	}
+
+	roomID := probeRoomPrefix + uuid.NewString()
+	speakerID := uuid.NewString() // 36 bytes, 바이너리 프레임 헤더 규격
+	defer h.roomHub.RemoveRoom(roomID)
+
+	conn, err := runProbeStep(result, "connect", func() (*wsclient.Conn, error) {
+		conn, err := h.dial(deadline, "/ws/room", url.Values{
+			"roomId":     {roomID},
+			"listenerId": {"probe-listener"},
+			"targetLang": {"en"},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		conn.SetReadDeadline(deadline)
+		var ready struct {
+			Status string `json:"status"`
+		}
+		if err := conn.ReadJSON(&ready); err != nil || ready.Status != "ready" {
+			conn.Close()
+			return nil, fmt.Errorf("room did not become ready (status=%q, err=%v)", ready.Status, err)
+		}
+		return conn, nil
+	})
+	if err != nil {
+		return h.finish(c, result)
+	}
+	defer conn.Close()
+
+	_, err = runProbeStep(result, "send_audio", func() (any, error) {
+		frame := append([]byte(speakerID+"en"), probeAudioSample()...)
+		conn.SetWriteDeadline(deadline)
+		return nil, conn.WriteMessage(wsclient.BinaryMessage, frame)
+	})
+	if err != nil {
+		return h.finish(c, result)
+	}
+
+	runProbeStep(result, "transcript", func() (any, error) {
+		conn.SetReadDeadline(deadline)
+		for {
+			messageType, data, err := conn.ReadMessage()
+			if err != nil {
+				return nil, fmt.Errorf("no transcript received: %w", err)
+			}
+			if messageType != wsclient.TextMessage {
+				continue
+			}
+
+			var msg struct {
+				Type      string         `json:"type"`
+				SpeakerID string         `json:"speakerId"`
+				Data      TranscriptData `json:"data"`
+			}
+			if json.Unmarshal(data, &msg) != nil || msg.Type != "transcript" || msg.SpeakerID != speakerID {
+				continue
+			}
+			if msg.Data.Original == "" {
+				return nil, fmt.Errorf("transcript is empty")
+			}
+			return nil, nil
+		}
+	})
+
+	return h.finish(c, result)
+}
+
+func (h *ProbeHandler) newResult(name, mode string) *ProbeResult {
+	return &ProbeResult{
+		Probe:     name,
+		Mode:      mode,
+		Steps:     []ProbeStep{},
+		CheckedAt: time.Now().Format(time.RFC3339),
+	}
+}
+
+// runProbeStep 단계를 실행하고 소요 시간/결과를 기록
+func runProbeStep[T any](result *ProbeResult, name string, fn func() (T, error)) (T, error) {
+	start := time.Now()
+	value, err := fn()
+
+	s := ProbeStep{Name: name, OK: err == nil, DurationMs: time.Since(start).Milliseconds()}
+	if err != nil {
+		s.Error = err.Error()
+	}
+	result.Steps = append(result.Steps, s)
+	return value, err
+}
+
+// finish 결과 집계 후 응답 (실패 시 503)
+func (h *ProbeHandler) finish(c *fiber.Ctx, result *ProbeResult) error {
+	result.Status = "pass"
+	for _, s := range result.Steps {
+		result.TotalMs += s.DurationMs
+		if !s.OK {
+			result.Status = "fail"
+		}
+	}
+
+	if result.Status != "pass" {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(result)
+	}
+	return c.JSON(result)
+}
+
+// dial 서버 자신에게 WebSocket 연결
+func (h *ProbeHandler) dial(deadline time.Time, path string, query url.Values) (*wsclient.Conn, error) {
+	ctx, cancel := context.WithDeadline(context.Background(), deadline)
+	defer cancel()
+
+	header := http.Header{}
+	header.Set("X-Probe-Token", h.cfg.Probe.Token)
+
+	target := h.baseURL() + path + "?" + query.Encode()
+	conn, resp, err := wsclient.DefaultDialer.DialContext(ctx, target, header)
+	if err != nil {
+		if resp != nil {
+			return nil, fmt.Errorf("websocket handshake failed: %s", resp.Status)
+		}
+		return nil, err
+	}
+	return conn, nil
+}
+
+func (h *ProbeHandler) baseURL() string {
+	if h.cfg.Probe.BaseURL != "" {
+		return strings.TrimSuffix(h.cfg.Probe.BaseURL, "/")
+	}
+
+	addr := h.cfg.Server.Port
+	if strings.HasPrefix(addr, ":") {
+		addr = "127.0.0.1" + addr
+	}
+	return "ws://" + addr
+}
+
+// probeAudioSample 합성 오디오 (16kHz mono PCM16, 440Hz 사인파 200ms)
+func probeAudioSample() []byte {
+	const sampleRate = 16000
+	const samples = sampleRate / 5
+
+	buf := make([]byte, samples*2)
+	for i := 0; i < samples; i++ {
+		v := int16(math.Sin(2*math.Pi*440*float64(i)/sampleRate) * 8000)
+		binary.LittleEndian.PutUint16(buf[i*2:], uint16(v))
+	}
+	return buf
+}
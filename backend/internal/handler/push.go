@@ -0,0 +1,410 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"realtime-backend/internal/auth"
+	"realtime-backend/internal/config"
+	"realtime-backend/internal/errorreport"
+	"realtime-backend/internal/model"
+	"realtime-backend/internal/push"
+)
+
+// 푸시 전송 설정
+const (
+	pushQueueSize       = 1000
+	pushWorkers         = 4
+	pushSendTimeout     = 10 * time.Second
+	pushRetryBaseDelay  = time.Second
+	pushMaxFailureCount = 5 // 연속 실패가 이만큼 쌓인 기기는 삭제
+	pushMaxDevices      = 20
+)
+
+// pushNotificationTypes 오프라인 사용자에게 푸시로도 보내는 알림 (나머지는 알림 목록에서만 확인)
+var pushNotificationTypes = map[string]bool{
+	model.NotificationTypeWorkspaceInvite.String(): true,
+	model.NotificationTypeCommentMention.String():  true,
+	model.NotificationTypeRoleMention.String():     true,
+	model.NotificationTypeMeetingStarted.String():  true,
+	model.NotificationTypeEventReminder.String():   true,
+	model.NotificationTypeFileShared.String():      true,
+}
+
+// PushHandler 기기 토큰 등록과 푸시 전송 (FCM, Web Push)
+type PushHandler struct {
+	db          *gorm.DB
+	senders     map[string]push.Sender
+	webPush     *push.WebPushSender
+	maxAttempts int
+
+	jobs chan pushJob
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+// pushJob 사용자들의 모든 기기로 보낼 알림 하나
+type pushJob struct {
+	userIDs []int64
+	message push.Message
+}
+
+// pushDispatcher 알림 생성 경로에서 쓰는 전역 인스턴스 (푸시 미설정이면 nil)
+var pushDispatcher *PushHandler
+
+// NewPushHandler 설정된 플랫폼의 전송기를 만들고 전송 워커 시작
+func NewPushHandler(db *gorm.DB, cfg config.PushConfig) *PushHandler {
+	h := &PushHandler{
+		db:          db,
+		senders:     make(map[string]push.Sender),
+		maxAttempts: max(cfg.MaxAttempts, 1),
+		jobs:        make(chan pushJob, pushQueueSize),
+		done:        make(chan struct{}),
+	}
+
+	if cfg.FCMProjectID != "" && cfg.FCMCredentialsFile != "" {
+		sender, err := push.NewFCMSender(context.Background(), cfg.FCMProjectID, cfg.FCMCredentialsFile)
+		if err != nil {
+			log.Printf("⚠️ FCM 푸시 초기화 실패: %v", err)
+		} else {
+			h.senders[push.PlatformFCM] = sender
+		}
+	}
+	if cfg.VAPIDPrivateKey != "" {
+		sender, err := push.NewWebPushSender(cfg.VAPIDPrivateKey, cfg.VAPIDSubject)
+		if err != nil {
+			log.Printf("⚠️ Web Push 초기화 실패: %v", err)
+		} else {
+			h.senders[push.PlatformWebPush] = sender
+			h.webPush = sender
+		}
+	}
+
+	if len(h.senders) == 0 {
+		log.Println("ℹ️ Push notifications not configured (FCM_PROJECT_ID / VAPID_PRIVATE_KEY are empty)")
+		return h
+	}
+	for i := 0; i < pushWorkers; i++ {
+		h.wg.Add(1)
+		go h.runWorker()
+	}
+	pushDispatcher = h
+	return h
+}
+
+// Close 대기 중인 푸시를 보내고 워커 종료
+func (h *PushHandler) Close() {
+	if len(h.senders) == 0 {
+		return
+	}
+	close(h.done)
+	h.wg.Wait()
+}
+
+// enqueue 푸시 예약 (큐가 가득 차면 버림 - 알림 자체는 이미 저장됨)
+func (h *PushHandler) enqueue(userIDs []int64, msg push.Message) {
+	if len(userIDs) == 0 {
+		return
+	}
+	select {
+	case h.jobs <- pushJob{userIDs: userIDs, message: msg}:
+	default:
+		log.Printf("⚠️ 푸시 큐가 가득 차 전송을 건너뜀: users=%d", len(userIDs))
+	}
+}
+
+func (h *PushHandler) runWorker() {
+	defer h.wg.Done()
+	defer errorreport.Recover(errorreport.Context{Component: "push.worker"})
+
+	for {
+		select {
+		case job := <-h.jobs:
+			h.deliver(job)
+		case <-h.done:
+			// 종료 전에 남은 작업 처리
+			for {
+				select {
+				case job := <-h.jobs:
+					h.deliver(job)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// deliver 사용자들의 등록된 기기로 전송
+func (h *PushHandler) deliver(job pushJob) {
+	var devices []model.PushDevice
+	if err := h.db.Where("user_id IN ? AND platform IN ?", job.userIDs, h.platforms()).Find(&devices).Error; err != nil {
+		log.Printf("⚠️ 푸시 기기 조회 실패: %v", err)
+		return
+	}
+	for i := range devices {
+		h.sendToDevice(&devices[i], job.message)
+	}
+}
+
+// sendToDevice 일시적 오류는 지수 백오프로 재시도, 만료된 토큰과 계속 실패하는 기기는 삭제
+func (h *PushHandler) sendToDevice(device *model.PushDevice, msg push.Message) {
+	sender := h.senders[device.Platform]
+	sub := push.Subscription{Platform: device.Platform, Token: device.Token}
+	if device.P256dh != nil && device.Auth != nil {
+		sub.P256dh, sub.Auth = *device.P256dh, *device.Auth
+	}
+
+	var err error
+	for attempt := 0; attempt < h.maxAttempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-time.After(pushRetryBaseDelay << (attempt - 1)):
+			case <-h.done:
+				return
+			}
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), pushSendTimeout)
+		err = sender.Send(ctx, sub, msg)
+		cancel()
+		if err == nil || !push.IsTemporary(err) {
+			break
+		}
+	}
+
+	switch {
+	case err == nil:
+		now := time.Now()
+		h.db.Model(device).Updates(map[string]interface{}{"failure_count": 0, "last_used_at": now})
+	case errors.Is(err, push.ErrSubscriptionGone):
+		h.db.Delete(device)
+	default:
+		log.Printf("⚠️ 푸시 전송 실패: device=%d, platform=%s, err=%v", device.ID, device.Platform, err)
+		if device.FailureCount+1 >= pushMaxFailureCount {
+			h.db.Delete(device)
+			return
+		}
+		h.db.Model(device).UpdateColumn("failure_count", gorm.Expr("failure_count + 1"))
+	}
+}
+
+func (h *PushHandler) platforms() []string {
+	platforms := make([]string, 0, len(h.senders))
+	for platform := range h.senders {
+		platforms = append(platforms, platform)
+	}
+	return platforms
+}
+
+// pushToOfflineUsers 알림 WebSocket에 연결되지 않은 사용자에게만 푸시
+func pushToOfflineUsers(userIDs []int64, msg push.Message) {
+	if pushDispatcher == nil {
+		return
+	}
+	ws := GetNotificationWSHandler()
+	offline := make([]int64, 0, len(userIDs))
+	for _, userID := range userIDs {
+		if ws == nil || !ws.IsOnline(userID) {
+			offline = append(offline, userID)
+		}
+	}
+	pushDispatcher.enqueue(offline, msg)
+}
+
+// pushNotificationPayloads 저장된 알림 중 푸시 대상 종류만 오프라인 수신자에게 전송
+func pushNotificationPayloads(payloads []NotificationPayload) {
+	if pushDispatcher == nil || len(payloads) == 0 || !pushNotificationTypes[payloads[0].Type] {
+		return
+	}
+	p := payloads[0]
+	msg := push.Message{
+		Title: "EUM",
+		Body:  p.Content,
+		Data:  map[string]string{"type": p.Type},
+	}
+	if p.Sender != nil {
+		msg.Title = p.Sender.Nickname
+	}
+	if p.GroupKey != nil {
+		msg.Tag = *p.GroupKey // 묶음 알림은 기기에서도 하나로 교체
+	}
+	if p.RelatedType != nil && p.RelatedID != nil {
+		msg.Data["related_type"] = *p.RelatedType
+		msg.Data["related_id"] = strconv.FormatInt(*p.RelatedID, 10)
+	}
+	if p.Data != nil {
+		msg.Data["route"] = p.Data.Route // 푸시를 누르면 바로 이동
+		if p.Data.Action != nil {
+			msg.Data["action"] = p.Data.Action.Type
+		}
+	}
+
+	userIDs := make([]int64, len(payloads))
+	for i := range payloads {
+		userIDs[i] = payloads[i].ReceiverID
+	}
+	pushToOfflineUsers(userIDs, msg)
+}
+
+// pushDirectMessage DM 방이면 상대방이 오프라인일 때 푸시 (알림 목록에는 남기지 않음)
+func pushDirectMessage(db *gorm.DB, roomID, senderID int64, senderName, message string) {
+	if pushDispatcher == nil {
+		return
+	}
+	defer errorreport.Recover(errorreport.Context{Component: "push.direct_message"})
+
+	var room model.Meeting
+	if err := db.Select("id", "workspace_id", "type").First(&room, roomID).Error; err != nil || room.Type != model.MeetingTypeDM.String() {
+		return
+	}
+
+	var receiverIDs []int64
+	db.Model(&model.Participant{}).
+		Where("meeting_id = ? AND user_id IS NOT NULL AND user_id <> ?", roomID, senderID).
+		Distinct().
+		Pluck("user_id", &receiverIDs)
+
+	// 방해 금지 중인 상대에게는 울리지 않음 (읽지 않은 메시지는 DM 목록에 남음)
+	muted := mutedUsers(receiverIDs)
+	receiverIDs = slices.DeleteFunc(receiverIDs, func(id int64) bool { return muted[id] })
+
+	body := message
+	if len([]rune(body)) > 120 {
+		body = string([]rune(body)[:120]) + "…"
+	}
+	msg := push.Message{
+		Title: senderName,
+		Body:  body,
+		Tag:   "dm:" + strconv.FormatInt(roomID, 10), // 같은 DM 방의 푸시는 기기에서 하나로 교체
+		Data: map[string]string{
+			"type":    "DIRECT_MESSAGE",
+			"room_id": strconv.FormatInt(roomID, 10),
+		},
+	}
+	if room.WorkspaceID != nil {
+		msg.Data["workspace_id"] = strconv.FormatInt(*room.WorkspaceID, 10)
+	}
+	pushToOfflineUsers(receiverIDs, msg)
+}
+
+// PushDeviceRequest 기기 등록/해제 요청
+type PushDeviceRequest struct {
+	Platform string `json:"platform"` // FCM, WEBPUSH
+	Token    string `json:"token"`    // FCM 등록 토큰 또는 PushSubscription.endpoint
+	Keys     struct {
+		P256dh string `json:"p256dh"`
+		Auth   string `json:"auth"`
+	} `json:"keys"` // WEBPUSH만 (PushSubscription.toJSON().keys)
+}
+
+// GetPushConfig 클라이언트가 구독에 필요한 설정 (Web Push 공개키, 사용 가능한 플랫폼)
+func (h *PushHandler) GetPushConfig(c *fiber.Ctx) error {
+	resp := fiber.Map{
+		"platforms": h.platforms(),
+	}
+	if h.webPush != nil {
+		resp["vapid_public_key"] = h.webPush.PublicKey()
+	}
+	return c.JSON(resp)
+}
+
+// RegisterPushDevice 푸시 기기 등록 (같은 토큰이면 갱신)
+func (h *PushHandler) RegisterPushDevice(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+
+	var req PushDeviceRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+	req.Platform = strings.ToUpper(req.Platform)
+	req.Token = strings.TrimSpace(req.Token)
+	if _, ok := h.senders[req.Platform]; !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "unsupported push platform",
+		})
+	}
+	if req.Token == "" || len(req.Token) > 4096 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "token is required",
+		})
+	}
+
+	device := model.PushDevice{
+		UserID:   claims.UserID,
+		Platform: req.Platform,
+		Token:    req.Token,
+	}
+	if req.Platform == push.PlatformWebPush {
+		if !push.ValidWebPushEndpoint(req.Token) || req.Keys.P256dh == "" || req.Keys.Auth == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "web push subscription requires a push service endpoint and keys",
+			})
+		}
+		device.P256dh = &req.Keys.P256dh
+		device.Auth = &req.Keys.Auth
+	}
+	if ua := c.Get(fiber.HeaderUserAgent); ua != "" {
+		if len(ua) > 255 {
+			ua = ua[:255]
+		}
+		device.UserAgent = &ua
+	}
+
+	var count int64
+	h.db.Model(&model.PushDevice{}).Where("user_id = ? AND token <> ?", claims.UserID, req.Token).Count(&count)
+	if count >= pushMaxDevices {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "too many push devices",
+		})
+	}
+
+	err := h.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "token"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{"user_id": device.UserID, "platform": device.Platform, "p256dh": device.P256dh, "auth": device.Auth, "user_agent": device.UserAgent, "failure_count": 0}),
+	}).Create(&device).Error
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to register push device",
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message":  "push device registered",
+		"platform": device.Platform,
+	})
+}
+
+// UnregisterPushDevice 푸시 기기 해제 (로그아웃, 알림 끄기)
+func (h *PushHandler) UnregisterPushDevice(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+
+	var req PushDeviceRequest
+	if err := c.BodyParser(&req); err != nil || strings.TrimSpace(req.Token) == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "token is required",
+		})
+	}
+
+	if err := h.db.Where("user_id = ? AND token = ?", claims.UserID, strings.TrimSpace(req.Token)).
+		Delete(&model.PushDevice{}).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to unregister push device",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "push device unregistered",
+	})
+}
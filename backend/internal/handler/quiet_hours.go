@@ -0,0 +1,339 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"realtime-backend/internal/auth"
+	"realtime-backend/internal/errorreport"
+	"realtime-backend/internal/model"
+	"realtime-backend/internal/presence"
+	"realtime-backend/internal/push"
+)
+
+// heldSummaryInterval 방해 금지가 끝난 사용자에게 보류된 알림 요약을 보내는 주기
+const heldSummaryInterval = time.Minute
+
+// NotificationSummaryPayload 방해 금지 동안 쌓인 알림 요약 (WebSocket notification_summary)
+type NotificationSummaryPayload struct {
+	Count  int64            `json:"count"`
+	ByType map[string]int64 `json:"by_type"`
+	Since  string           `json:"since"`
+}
+
+// StartHeldSummaries 보류된 알림 요약 전송 시작
+func (h *NotificationHandler) StartHeldSummaries() {
+	if h.summaryDone == nil {
+		h.summaryDone = make(chan struct{})
+		go h.runHeldSummaries(h.summaryDone)
+	}
+}
+
+func (h *NotificationHandler) runHeldSummaries(done <-chan struct{}) {
+	defer errorreport.Recover(errorreport.Context{Component: "notifications.held_summary"})
+
+	ticker := time.NewTicker(heldSummaryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+		}
+
+		var userIDs []int64
+		if err := h.db.Model(&model.NotificationSettings{}).Where("held_since IS NOT NULL").Pluck("user_id", &userIDs).Error; err != nil {
+			log.Printf("⚠️ 보류된 알림 조회 실패: %v", err)
+			continue
+		}
+		muted := mutedUsers(userIDs)
+		for _, userID := range userIDs {
+			if !muted[userID] {
+				flushHeldNotifications(h.db, userID)
+			}
+		}
+	}
+}
+
+// mutedUsers 지금 알림을 울리면 안 되는 사용자 (DND 상태 또는 방해 금지 시간)
+// 접속 중이면 presence, 오프라인이면 마지막으로 고른 기본 상태 기준
+func mutedUsers(userIDs []int64) map[int64]bool {
+	ws := GetNotificationWSHandler()
+	if ws == nil || ws.db == nil || len(userIDs) == 0 {
+		return nil
+	}
+	muted := make(map[int64]bool)
+
+	offline := userIDs
+	if ws.presenceManager != nil {
+		if presences, err := ws.presenceManager.GetMultiPresence(userIDs); err == nil {
+			offline = make([]int64, 0, len(userIDs))
+			for _, userID := range userIDs {
+				p, ok := presences[userID]
+				if !ok || p == nil {
+					offline = append(offline, userID)
+					continue
+				}
+				if strings.EqualFold(string(p.Status), string(presence.StatusDND)) {
+					muted[userID] = true
+				}
+			}
+		}
+	}
+	if len(offline) > 0 {
+		var dnd []int64
+		ws.db.Model(&model.User{}).
+			Where("id IN ? AND UPPER(default_status) = ?", offline, string(presence.StatusDND)).
+			Pluck("id", &dnd)
+		for _, userID := range dnd {
+			muted[userID] = true
+		}
+	}
+
+	var settings []model.NotificationSettings
+	ws.db.Where("user_id IN ? AND quiet_hours_enabled = ?", userIDs, true).Find(&settings)
+	now := time.Now()
+	for i := range settings {
+		if settings[i].InQuietHours(now, quietHoursLocation(&settings[i])) {
+			muted[settings[i].UserID] = true
+		}
+	}
+	return muted
+}
+
+// holdMutedPayloads 방해 금지 중인 수신자의 알림은 빼고 보류 시작 시각을 기록 (저장된 알림은 목록에 그대로)
+func holdMutedPayloads(payloads []NotificationPayload) []NotificationPayload {
+	userIDs := make([]int64, 0, len(payloads))
+	for i := range payloads {
+		userIDs = append(userIDs, payloads[i].ReceiverID)
+	}
+	muted := mutedUsers(userIDs)
+	if len(muted) == 0 {
+		return payloads
+	}
+
+	// 묶음 전송으로 늦게 전달되는 알림도 요약에 들어가도록 조금 앞당김
+	since := time.Now().Add(-notificationCoalesceWindow)
+	ws := GetNotificationWSHandler()
+	deliver := make([]NotificationPayload, 0, len(payloads))
+	held := make(map[int64]bool)
+	for _, p := range payloads {
+		if !muted[p.ReceiverID] {
+			deliver = append(deliver, p)
+			continue
+		}
+		if held[p.ReceiverID] {
+			continue
+		}
+		held[p.ReceiverID] = true
+		err := ws.db.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "user_id"}},
+			DoUpdates: clause.Set{{
+				Column: clause.Column{Name: "held_since"},
+				Value:  gorm.Expr("COALESCE(notification_settings.held_since, EXCLUDED.held_since)"),
+			}},
+		}).Create(&model.NotificationSettings{UserID: p.ReceiverID, HeldSince: &since}).Error
+		if err != nil {
+			log.Printf("⚠️ 알림 보류 기록 실패: user=%d, err=%v", p.ReceiverID, err)
+		}
+	}
+	return deliver
+}
+
+// flushHeldNotifications 방해 금지가 끝났으면 보류된 동안 쌓인 읽지 않은 알림 요약을 전달
+// 여러 인스턴스가 동시에 호출해도 held_since 조건부 갱신으로 한 번만 보냄
+func flushHeldNotifications(db *gorm.DB, userID int64) {
+	defer errorreport.Recover(errorreport.Context{Component: "notifications.held_summary", UserID: userID})
+
+	var settings model.NotificationSettings
+	if err := db.First(&settings, "user_id = ?", userID).Error; err != nil || settings.HeldSince == nil {
+		return
+	}
+	if mutedUsers([]int64{userID})[userID] {
+		return
+	}
+	since := *settings.HeldSince
+
+	claim := db.Model(&model.NotificationSettings{}).
+		Where("user_id = ? AND held_since = ?", userID, since).
+		Update("held_since", nil)
+	if claim.Error != nil || claim.RowsAffected == 0 {
+		return
+	}
+
+	var rows []struct {
+		Type  string
+		Count int64
+	}
+	if err := db.Model(&model.Notification{}).
+		Select("type, COUNT(*) AS count").
+		Where("receiver_id = ? AND is_read = ? AND updated_at >= ?", userID, false, since).
+		Group("type").
+		Scan(&rows).Error; err != nil {
+		log.Printf("⚠️ 보류된 알림 집계 실패: user=%d, err=%v", userID, err)
+		return
+	}
+
+	summary := NotificationSummaryPayload{ByType: make(map[string]int64, len(rows)), Since: since.Format(time.RFC3339)}
+	for _, r := range rows {
+		summary.ByType[r.Type] = r.Count
+		summary.Count += r.Count
+	}
+	if summary.Count == 0 {
+		return
+	}
+
+	if ws := GetNotificationWSHandler(); ws != nil {
+		ws.DeliverMessage(userID, NotificationWSMessage{Type: "notification_summary", Payload: summary})
+	}
+	pushToOfflineUsers([]int64{userID}, push.Message{
+		Title: "EUM",
+		Body:  fmt.Sprintf("방해 금지 중 받은 알림 %d개", summary.Count),
+		Tag:   "notification_summary",
+		Data:  map[string]string{"type": "NOTIFICATION_SUMMARY"},
+	})
+}
+
+// quietHoursLocation 방해 금지 시간 기준 타임존 (없거나 잘못되면 서버 기본값)
+func quietHoursLocation(s *model.NotificationSettings) *time.Location {
+	if loc, err := resolveTimeZone(s.TimeZone); err == nil {
+		return loc
+	}
+	loc, err := resolveTimeZone(defaultEventTimeZone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// parseClockMinutes "HH:MM" → 자정부터 분
+func parseClockMinutes(value string) (int, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, errors.New("time must be HH:MM")
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+func formatClockMinutes(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+// QuietHoursResponse 방해 금지 시간 설정 응답
+type QuietHoursResponse struct {
+	Enabled  bool   `json:"enabled"`
+	Start    string `json:"start"` // HH:MM
+	End      string `json:"end"`   // HH:MM (시작보다 이르면 다음 날)
+	TimeZone string `json:"time_zone"`
+	Active   bool   `json:"active"` // 지금 방해 금지 시간인지
+}
+
+// UpdateQuietHoursRequest 방해 금지 시간 변경 (보낸 항목만 변경)
+type UpdateQuietHoursRequest struct {
+	Enabled  *bool   `json:"enabled"`
+	Start    *string `json:"start"`
+	End      *string `json:"end"`
+	TimeZone *string `json:"time_zone"`
+}
+
+// GetQuietHours 내 방해 금지 시간
+func (h *NotificationHandler) GetQuietHours(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+
+	var settings model.NotificationSettings
+	if err := h.db.First(&settings, "user_id = ?", claims.UserID).Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get quiet hours",
+		})
+	}
+	settings.UserID = claims.UserID
+	return c.JSON(toQuietHoursResponse(&settings))
+}
+
+// UpdateQuietHours 내 방해 금지 시간 변경
+func (h *NotificationHandler) UpdateQuietHours(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+
+	var req UpdateQuietHoursRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+
+	updates := map[string]interface{}{}
+	if req.Enabled != nil {
+		updates["quiet_hours_enabled"] = *req.Enabled
+	}
+	if req.Start != nil {
+		minutes, err := parseClockMinutes(*req.Start)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "start: " + err.Error(),
+			})
+		}
+		updates["quiet_start"] = minutes
+	}
+	if req.End != nil {
+		minutes, err := parseClockMinutes(*req.End)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "end: " + err.Error(),
+			})
+		}
+		updates["quiet_end"] = minutes
+	}
+	if req.TimeZone != nil {
+		if _, err := resolveTimeZone(*req.TimeZone); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		updates["time_zone"] = *req.TimeZone
+	}
+
+	if err := h.db.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&model.NotificationSettings{UserID: claims.UserID}).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to update quiet hours",
+		})
+	}
+	if len(updates) > 0 {
+		if err := h.db.Model(&model.NotificationSettings{}).Where("user_id = ?", claims.UserID).Updates(updates).Error; err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "failed to update quiet hours",
+			})
+		}
+	}
+
+	var settings model.NotificationSettings
+	if err := h.db.First(&settings, "user_id = ?", claims.UserID).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to update quiet hours",
+		})
+	}
+	// 방해 금지 시간을 끄거나 줄였으면 쌓인 알림 요약을 바로 받도록
+	if settings.HeldSince != nil {
+		go flushHeldNotifications(h.db, claims.UserID)
+	}
+	return c.JSON(toQuietHoursResponse(&settings))
+}
+
+func toQuietHoursResponse(s *model.NotificationSettings) QuietHoursResponse {
+	loc := quietHoursLocation(s)
+	return QuietHoursResponse{
+		Enabled:  s.QuietHoursEnabled,
+		Start:    formatClockMinutes(s.QuietStart),
+		End:      formatClockMinutes(s.QuietEnd),
+		TimeZone: loc.String(),
+		Active:   s.InQuietHours(time.Now(), loc),
+	}
+}
@@ -0,0 +1,193 @@
+package handler
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+
+	"realtime-backend/internal/auth"
+	"realtime-backend/internal/model"
+)
+
+const (
+	maxRedactionDenyListSize = 500
+	maxRedactionWordLength   = 100
+)
+
+// RedactionHandler 음성 파이프라인 비속어/개인정보 가림 설정 관리
+// 변경 사항은 다음에 시작되는 음성 파이프라인부터 적용됨
+type RedactionHandler struct {
+	db *gorm.DB
+}
+
+// NewRedactionHandler RedactionHandler 생성
+func NewRedactionHandler(db *gorm.DB) *RedactionHandler {
+	return &RedactionHandler{db: db}
+}
+
+// UpdateRedactionRequest 가림 설정 변경 요청
+type UpdateRedactionRequest struct {
+	Mode      string   `json:"mode"` // OFF, MASK, DROP
+	RedactPII bool     `json:"redact_pii"`
+	DenyList  []string `json:"deny_list"`
+}
+
+// RedactionResponse 가림 설정 응답
+type RedactionResponse struct {
+	WorkspaceID int64    `json:"workspace_id"`
+	Mode        string   `json:"mode"`
+	RedactPII   bool     `json:"redact_pii"`
+	DenyList    []string `json:"deny_list"`
+	UpdatedBy   *int64   `json:"updated_by,omitempty"`
+	UpdatedAt   *string  `json:"updated_at,omitempty"`
+}
+
+// GetRedaction 가림 설정 조회 (설정이 없으면 OFF)
+func (h *RedactionHandler) GetRedaction(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	workspaceID, err := c.ParamsInt("workspaceId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid workspace id",
+		})
+	}
+
+	if !h.isWorkspaceMember(int64(workspaceID), claims.UserID) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "you are not a member of this workspace",
+		})
+	}
+
+	setting := model.RedactionSetting{WorkspaceID: int64(workspaceID), Mode: model.RedactionModeOff.String()}
+	if err := h.db.Where("workspace_id = ?", workspaceID).Limit(1).Find(&setting).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get redaction settings",
+		})
+	}
+
+	return c.JSON(toRedactionResponse(&setting))
+}
+
+// UpdateRedaction 가림 설정 변경 (ADMIN)
+func (h *RedactionHandler) UpdateRedaction(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	workspaceID, err := c.ParamsInt("workspaceId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid workspace id",
+		})
+	}
+
+	hasPermission, err := auth.CheckPermission(h.db, int64(workspaceID), claims.UserID, "ADMIN")
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to check permission",
+		})
+	}
+	if !hasPermission {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "you do not have permission to manage redaction settings",
+		})
+	}
+
+	var req UpdateRedactionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+
+	mode := model.RedactionMode(strings.ToUpper(strings.TrimSpace(req.Mode)))
+	switch mode {
+	case model.RedactionModeOff, model.RedactionModeMask, model.RedactionModeDrop:
+	default:
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "mode must be OFF, MASK or DROP",
+		})
+	}
+
+	words, msg := normalizeDenyList(req.DenyList)
+	if msg != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": msg,
+		})
+	}
+
+	setting := model.RedactionSetting{
+		WorkspaceID: int64(workspaceID),
+		Mode:        mode.String(),
+		RedactPII:   req.RedactPII,
+		DenyList:    strings.Join(words, "\n"),
+		UpdatedBy:   &claims.UserID,
+	}
+	if err := h.db.Save(&setting).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to update redaction settings",
+		})
+	}
+	// 금칙어 자체는 감사 기록에 남기지 않음
+	recordAuditEvent(h.db, setting.WorkspaceID, &claims.UserID, model.AuditSettingsUpdated, model.AuditTargetWorkspace, setting.WorkspaceID, map[string]interface{}{
+		"setting":        "redaction",
+		"mode":           setting.Mode,
+		"redact_pii":     setting.RedactPII,
+		"deny_list_size": len(words),
+	})
+
+	return c.JSON(toRedactionResponse(&setting))
+}
+
+func (h *RedactionHandler) isWorkspaceMember(workspaceID, userID int64) bool {
+	var count int64
+	h.db.Model(&model.WorkspaceMember{}).
+		Where("workspace_id = ? AND user_id = ? AND status = ?", workspaceID, userID, model.MemberStatusActive.String()).
+		Count(&count)
+	return count > 0
+}
+
+// normalizeDenyList 금칙어 정리 (공백 제거, 중복 제거) 및 검증 (에러 메시지 반환, 정상이면 "")
+func normalizeDenyList(list []string) ([]string, string) {
+	seen := make(map[string]bool, len(list))
+	words := make([]string, 0, len(list))
+	for _, w := range list {
+		w = strings.TrimSpace(sanitizeString(w))
+		if w == "" || seen[strings.ToLower(w)] {
+			continue
+		}
+		if len(w) > maxRedactionWordLength {
+			return nil, "deny list word is too long (max 100)"
+		}
+		if strings.ContainsAny(w, "\r\n") {
+			return nil, "deny list word must be a single line"
+		}
+		seen[strings.ToLower(w)] = true
+		words = append(words, w)
+	}
+	if len(words) > maxRedactionDenyListSize {
+		return nil, "deny list is too long (max 500 words)"
+	}
+	return words, ""
+}
+
+// splitDenyList 저장된 금칙어 목록을 슬라이스로 변환
+func splitDenyList(denyList string) []string {
+	if denyList == "" {
+		return []string{}
+	}
+	return strings.Split(denyList, "\n")
+}
+
+func toRedactionResponse(s *model.RedactionSetting) RedactionResponse {
+	resp := RedactionResponse{
+		WorkspaceID: s.WorkspaceID,
+		Mode:        s.Mode,
+		RedactPII:   s.RedactPII,
+		DenyList:    splitDenyList(s.DenyList),
+		UpdatedBy:   s.UpdatedBy,
+	}
+	if !s.UpdatedAt.IsZero() {
+		t := s.UpdatedAt.Format("2006-01-02T15:04:05Z07:00")
+		resp.UpdatedAt = &t
+	}
+	return resp
+}
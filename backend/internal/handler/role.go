@@ -1,6 +1,8 @@
 package handler
 
 import (
+	"errors"
+
 	"github.com/gofiber/fiber/v2"
 	"gorm.io/gorm"
 
@@ -32,6 +34,14 @@ type UpdateRoleRequest struct {
 	Permissions []string `json:"permissions,omitempty"`
 }
 
+// GetPermissionCatalog 권한 목록 조회 (역할 편집 UI용)
+func (h *RoleHandler) GetPermissionCatalog(c *fiber.Ctx) error {
+	return c.JSON(fiber.Map{
+		"permissions": model.AllPermissions(),
+		"categories":  model.PermissionCategories,
+	})
+}
+
 // GetRoles 역할 목록 조회
 func (h *RoleHandler) GetRoles(c *fiber.Ctx) error {
 	claims := c.Locals("claims").(*auth.Claims)
@@ -80,6 +90,9 @@ func (h *RoleHandler) CreateRole(c *fiber.Ctx) error {
 	if err := c.BodyParser(&req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid request body"})
 	}
+	if code, ok := unknownPermissionCode(req.Permissions); ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "unknown permission: " + code})
+	}
 
 	role := model.Role{
 		WorkspaceID: int64(workspaceID),
@@ -112,6 +125,8 @@ func (h *RoleHandler) CreateRole(c *fiber.Ctx) error {
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to create role"})
 	}
+	recordAuditEvent(h.db, role.WorkspaceID, &claims.UserID, model.AuditRoleCreated, model.AuditTargetRole, role.ID,
+		roleAuditDiff(nil, loadRoleSnapshot(h.db, role.WorkspaceID, role.ID)))
 
 	// 생성된 역할정보 다시 조회 (권한 포함)
 	h.db.Preload("Permissions").First(&role, role.ID)
@@ -144,11 +159,18 @@ func (h *RoleHandler) UpdateRole(c *fiber.Ctx) error {
 	if err := c.BodyParser(&req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid request body"})
 	}
+	if code, ok := unknownPermissionCode(req.Permissions); ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "unknown permission: " + code})
+	}
 
 	var role model.Role
 	if err := h.db.Where("id = ? AND workspace_id = ?", roleID, workspaceID).First(&role).Error; err != nil {
 		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "role not found"})
 	}
+	if role.IsGuest && len(req.Permissions) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "guest role cannot have workspace permissions; grant meeting access with channel overrides"})
+	}
+	before := loadRoleSnapshot(h.db, role.WorkspaceID, role.ID)
 
 	// 트랜잭션으로 역할 정보 및 권한 업데이트
 	err = h.db.Transaction(func(tx *gorm.DB) error {
@@ -186,6 +208,11 @@ func (h *RoleHandler) UpdateRole(c *fiber.Ctx) error {
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to update role"})
 	}
+	if req.Permissions != nil {
+		auth.InvalidateWorkspacePermissions(role.WorkspaceID)
+	}
+	recordAuditEvent(h.db, role.WorkspaceID, &claims.UserID, model.AuditRoleUpdated, model.AuditTargetRole, role.ID,
+		roleAuditDiff(before, loadRoleSnapshot(h.db, role.WorkspaceID, role.ID)))
 
 	// 업데이트된 정보 다시 조회
 	h.db.Preload("Permissions").First(&role, role.ID)
@@ -214,10 +241,30 @@ func (h *RoleHandler) DeleteRole(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "you do not have permission to manage roles"})
 	}
 
+	// 게스트 역할은 기본 제공 역할이라 삭제 불가
+	var guestCount int64
+	h.db.Model(&model.Role{}).Where("id = ? AND workspace_id = ? AND is_guest = ?", roleID, workspaceID, true).Count(&guestCount)
+	if guestCount > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "the built-in guest role cannot be deleted"})
+	}
+
+	// 감사 기록용 삭제 전 역할 상태
+	before := loadRoleSnapshot(h.db, int64(workspaceID), int64(roleID))
+
 	// 역할 삭제 트랜잭션
+	var unassigned int64
 	err = h.db.Transaction(func(tx *gorm.DB) error {
 		// 1. 해당 역할을 가진 멤버들의 RoleID를 null로 설정
-		if err := tx.Model(&model.WorkspaceMember{}).
+		result := tx.Model(&model.WorkspaceMember{}).
+			Where("workspace_id = ? AND role_id = ?", workspaceID, roleID).
+			Update("role_id", nil)
+		if result.Error != nil {
+			return result.Error
+		}
+		unassigned = result.RowsAffected
+
+		// 초대 링크는 기본 역할로 가입하도록
+		if err := tx.Model(&model.WorkspaceInviteLink{}).
 			Where("workspace_id = ? AND role_id = ?", workspaceID, roleID).
 			Update("role_id", nil).Error; err != nil {
 			return err
@@ -228,6 +275,12 @@ func (h *RoleHandler) DeleteRole(c *fiber.Ctx) error {
 			return err
 		}
 
+		// 채널 권한 오버라이드 중 이 역할 대상 삭제
+		if err := tx.Where("workspace_id = ? AND target_type = ? AND target_id = ?", workspaceID, model.OverrideTargetRole, roleID).
+			Delete(&model.ChannelPermissionOverride{}).Error; err != nil {
+			return err
+		}
+
 		// 3. 역할 삭제
 		if err := tx.Where("id = ? AND workspace_id = ?", roleID, workspaceID).Delete(&model.Role{}).Error; err != nil {
 			return err
@@ -238,6 +291,74 @@ func (h *RoleHandler) DeleteRole(c *fiber.Ctx) error {
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to delete role"})
 	}
+	auth.InvalidateWorkspacePermissions(int64(workspaceID))
+	metadata := roleAuditDiff(before, nil)
+	metadata["members_unassigned"] = unassigned
+	recordAuditEvent(h.db, int64(workspaceID), &claims.UserID, model.AuditRoleDeleted, model.AuditTargetRole, int64(roleID), metadata)
 
 	return c.SendStatus(fiber.StatusNoContent)
 }
+
+// seedDefaultRolesWithTx 새 워크스페이스의 기본 역할(Owner/Admin/Member)과 권한 생성, 역할 이름별 ID 반환
+func seedDefaultRolesWithTx(tx *gorm.DB, workspaceID int64) (map[string]int64, error) {
+	roleIDs := make(map[string]int64, len(model.DefaultRoleTemplates))
+	for _, tmpl := range model.DefaultRoleTemplates {
+		role := model.Role{
+			WorkspaceID: workspaceID,
+			Name:        tmpl.Name,
+			Color:       valPtr(tmpl.Color),
+			IsDefault:   tmpl.IsDefault,
+			IsGuest:     tmpl.IsGuest,
+		}
+		for _, code := range tmpl.Permissions {
+			role.Permissions = append(role.Permissions, model.RolePermission{PermissionCode: code})
+		}
+		if err := tx.Create(&role).Error; err != nil {
+			return nil, err
+		}
+		roleIDs[tmpl.Name] = role.ID
+	}
+	return roleIDs, nil
+}
+
+// defaultRoleIDWithTx 초대/가입한 멤버에게 줄 기본 역할 ID (없으면 nil)
+func defaultRoleIDWithTx(tx *gorm.DB, workspaceID int64) *int64 {
+	var role model.Role
+	if err := tx.Select("id").Where("workspace_id = ? AND is_default = ?", workspaceID, true).First(&role).Error; err != nil {
+		return nil
+	}
+	return &role.ID
+}
+
+// unknownPermissionCode 등록되지 않은 권한 코드가 있으면 첫 번째 코드를 반환
+func unknownPermissionCode(codes []string) (string, bool) {
+	for _, code := range codes {
+		if !model.IsKnownPermission(code) {
+			return code, true
+		}
+	}
+	return "", false
+}
+
+// guestRoleIDWithTx 게스트 역할 ID (기본 역할 도입 전에 만든 워크스페이스면 새로 생성)
+func guestRoleIDWithTx(tx *gorm.DB, workspaceID int64) (int64, error) {
+	var role model.Role
+	err := tx.Select("id").Where("workspace_id = ? AND is_guest = ?", workspaceID, true).First(&role).Error
+	if err == nil {
+		return role.ID, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, err
+	}
+
+	for _, tmpl := range model.DefaultRoleTemplates {
+		if tmpl.IsGuest {
+			role = model.Role{WorkspaceID: workspaceID, Name: tmpl.Name, Color: valPtr(tmpl.Color), IsGuest: true}
+			break
+		}
+	}
+	if err := tx.Create(&role).Error; err != nil {
+		return 0, err
+	}
+	return role.ID, nil
+}
@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"slices"
+
+	"gorm.io/gorm"
+
+	"realtime-backend/internal/model"
+)
+
+// roleSnapshot 감사 기록용 역할 상태 (변경 전/후 비교)
+type roleSnapshot struct {
+	Name        string   `json:"name"`
+	Color       *string  `json:"color,omitempty"`
+	Permissions []string `json:"permissions"`
+}
+
+// loadRoleSnapshot 역할 이름/색상/권한 조회 (없으면 nil)
+func loadRoleSnapshot(db *gorm.DB, workspaceID, roleID int64) *roleSnapshot {
+	var role model.Role
+	if err := db.Preload("Permissions").Where("id = ? AND workspace_id = ?", roleID, workspaceID).First(&role).Error; err != nil {
+		return nil
+	}
+	snapshot := &roleSnapshot{Name: role.Name, Color: role.Color, Permissions: []string{}}
+	for _, p := range role.Permissions {
+		snapshot.Permissions = append(snapshot.Permissions, p.PermissionCode)
+	}
+	slices.Sort(snapshot.Permissions)
+	return snapshot
+}
+
+// roleAuditDiff 역할 변경 전/후와 추가·제거된 권한 (생성이면 before, 삭제면 after가 nil)
+// permissions_added로 "누가 이 권한을 줬는지" 감사 기록을 검색할 수 있음
+func roleAuditDiff(before, after *roleSnapshot) map[string]interface{} {
+	var beforePerms, afterPerms []string
+	if before != nil {
+		beforePerms = before.Permissions
+	}
+	if after != nil {
+		afterPerms = after.Permissions
+	}
+	added, removed := permissionDelta(beforePerms, afterPerms)
+	return map[string]interface{}{
+		"before":              before,
+		"after":               after,
+		"permissions_added":   added,
+		"permissions_removed": removed,
+	}
+}
+
+// memberRoleAuditDiff 멤버 역할 변경 기록 (이전/새 역할 이름과 그로 인해 생기거나 잃은 권한)
+func memberRoleAuditDiff(db *gorm.DB, workspaceID int64, fromRoleID, toRoleID *int64) map[string]interface{} {
+	var from, to *roleSnapshot
+	if fromRoleID != nil {
+		from = loadRoleSnapshot(db, workspaceID, *fromRoleID)
+	}
+	if toRoleID != nil {
+		to = loadRoleSnapshot(db, workspaceID, *toRoleID)
+	}
+
+	metadata := roleAuditDiff(from, to)
+	delete(metadata, "before")
+	delete(metadata, "after")
+	metadata["from_role_id"] = fromRoleID
+	metadata["to_role_id"] = toRoleID
+	if from != nil {
+		metadata["from_role"] = from.Name
+	}
+	if to != nil {
+		metadata["to_role"] = to.Name
+	}
+	return metadata
+}
+
+// permissionDelta before → after 사이에 추가/제거된 권한 코드 (정렬)
+func permissionDelta(before, after []string) (added, removed []string) {
+	added, removed = []string{}, []string{}
+	for _, code := range after {
+		if !slices.Contains(before, code) && !slices.Contains(added, code) {
+			added = append(added, code)
+		}
+	}
+	for _, code := range before {
+		if !slices.Contains(after, code) && !slices.Contains(removed, code) {
+			removed = append(removed, code)
+		}
+	}
+	slices.Sort(added)
+	slices.Sort(removed)
+	return added, removed
+}
@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+
+	"realtime-backend/internal/auth"
+	"realtime-backend/internal/model"
+)
+
+// maxBulkRoleAssignment 한 번에 역할을 변경할 수 있는 최대 멤버 수
+const maxBulkRoleAssignment = 200
+
+// 일괄 역할 변경 동작
+const (
+	roleMembersAssign   = "assign"
+	roleMembersUnassign = "unassign"
+)
+
+// BulkRoleMembersRequest 일괄 역할 할당/해제 요청
+type BulkRoleMembersRequest struct {
+	Action  string  `json:"action"` // assign, unassign
+	UserIDs []int64 `json:"user_ids"`
+}
+
+// RoleMemberFailure 역할 변경에 실패한 사용자와 사유
+type RoleMemberFailure struct {
+	UserID int64  `json:"user_id"`
+	Reason string `json:"reason"`
+}
+
+// UpdateRoleMembers 여러 멤버에게 역할을 한 번에 할당/해제 (MANAGE_ROLES)
+// 해제하면 역할이 없는 상태가 되며, 해당 역할을 가지지 않은 멤버는 실패로 보고
+func (h *RoleHandler) UpdateRoleMembers(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	workspaceID, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid workspace id"})
+	}
+	roleID, err := c.ParamsInt("roleId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid role id"})
+	}
+
+	hasPermission, err := auth.CheckPermission(h.db, int64(workspaceID), claims.UserID, "MANAGE_ROLES")
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to check permission"})
+	}
+	if !hasPermission {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "you do not have permission to manage roles"})
+	}
+
+	var req BulkRoleMembersRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid request body"})
+	}
+	if req.Action != roleMembersAssign && req.Action != roleMembersUnassign {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "action must be assign or unassign"})
+	}
+	if len(req.UserIDs) == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "user_ids is required"})
+	}
+	if len(req.UserIDs) > maxBulkRoleAssignment {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "too many user_ids"})
+	}
+
+	var role model.Role
+	if err := h.db.Select("id", "name").Where("id = ? AND workspace_id = ?", roleID, workspaceID).First(&role).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "role not found"})
+	}
+
+	// 중복 제거 (요청 순서 유지)
+	userIDs := make([]int64, 0, len(req.UserIDs))
+	seen := make(map[int64]bool, len(req.UserIDs))
+	for _, id := range req.UserIDs {
+		if !seen[id] {
+			seen[id] = true
+			userIDs = append(userIDs, id)
+		}
+	}
+
+	type roleChange struct {
+		userID int64
+		from   *int64
+	}
+	var changes []roleChange
+	failures := []RoleMemberFailure{}
+	updated := []int64{}
+
+	err = h.db.Transaction(func(tx *gorm.DB) error {
+		var members []model.WorkspaceMember
+		if err := tx.Where("workspace_id = ? AND user_id IN ? AND status = ?", workspaceID, userIDs, model.MemberStatusActive.String()).
+			Find(&members).Error; err != nil {
+			return err
+		}
+		byUser := make(map[int64]model.WorkspaceMember, len(members))
+		for _, m := range members {
+			byUser[m.UserID] = m
+		}
+
+		for _, userID := range userIDs {
+			member, ok := byUser[userID]
+			if !ok {
+				failures = append(failures, RoleMemberFailure{UserID: userID, Reason: "not a member"})
+				continue
+			}
+
+			hasRole := member.RoleID != nil && *member.RoleID == role.ID
+			var newRoleID *int64
+			switch req.Action {
+			case roleMembersAssign:
+				if hasRole {
+					updated = append(updated, userID)
+					continue
+				}
+				newRoleID = &role.ID
+			case roleMembersUnassign:
+				if !hasRole {
+					failures = append(failures, RoleMemberFailure{UserID: userID, Reason: "member does not have this role"})
+					continue
+				}
+			}
+
+			if err := tx.Model(&model.WorkspaceMember{}).Where("id = ?", member.ID).Update("role_id", newRoleID).Error; err != nil {
+				return err
+			}
+			changes = append(changes, roleChange{userID: userID, from: member.RoleID})
+			updated = append(updated, userID)
+		}
+		return nil
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to update role members"})
+	}
+
+	var to *int64
+	if req.Action == roleMembersAssign {
+		to = &role.ID
+	}
+	// 이전 역할이 같은 멤버끼리는 변경 내용이 같으므로 한 번만 계산
+	diffs := make(map[int64]map[string]interface{})
+	for _, change := range changes {
+		auth.InvalidateMemberPermissions(int64(workspaceID), change.userID)
+
+		var fromKey int64
+		if change.from != nil {
+			fromKey = *change.from
+		}
+		metadata, ok := diffs[fromKey]
+		if !ok {
+			metadata = memberRoleAuditDiff(h.db, int64(workspaceID), change.from, to)
+			metadata["bulk"] = true
+			diffs[fromKey] = metadata
+		}
+		recordAuditEvent(h.db, int64(workspaceID), &claims.UserID, model.AuditMemberRoleChanged, model.AuditTargetMember, change.userID, metadata)
+	}
+
+	return c.JSON(fiber.Map{
+		"role_id":   role.ID,
+		"action":    req.Action,
+		"succeeded": updated,
+		"failed":    failures,
+	})
+}
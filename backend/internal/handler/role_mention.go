@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"realtime-backend/internal/auth"
+	"realtime-backend/internal/errorreport"
+	"realtime-backend/internal/model"
+)
+
+// maxRoleMentionsPerMessage 메시지 하나에서 알림을 보내는 최대 역할 수
+const maxRoleMentionsPerMessage = 5
+
+// roleMentionPattern 메시지 본문의 역할 멘션 토큰 (<@&역할ID>)
+var roleMentionPattern = regexp.MustCompile(`<@&(\d+)>`)
+
+// parseRoleMentions 메시지에서 멘션된 역할 ID 추출 (중복 제거, 최대 maxRoleMentionsPerMessage개)
+func parseRoleMentions(message string) []int64 {
+	var roleIDs []int64
+	seen := make(map[int64]bool)
+	for _, match := range roleMentionPattern.FindAllStringSubmatch(message, -1) {
+		id, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil || seen[id] {
+			continue
+		}
+		seen[id] = true
+		roleIDs = append(roleIDs, id)
+		if len(roleIDs) == maxRoleMentionsPerMessage {
+			break
+		}
+	}
+	return roleIDs
+}
+
+// notifyRoleMentions 메시지에 역할 멘션이 있으면 해당 역할 멤버들에게 알림 (요청 경로 밖에서 실행)
+// MENTION_ROLES 권한이 없으면 메시지는 그대로 두고 알림만 보내지 않음
+func notifyRoleMentions(db *gorm.DB, workspaceID, roomID, senderID int64, senderName, message string) {
+	roleIDs := parseRoleMentions(message)
+	if len(roleIDs) == 0 {
+		return
+	}
+
+	go func() {
+		defer errorreport.Recover(errorreport.Context{Component: "chat.role_mention", WorkspaceID: workspaceID, UserID: senderID})
+
+		allowed, err := auth.CheckChannelPermission(db, workspaceID, roomID, senderID, model.PermissionMentionRoles)
+		if err != nil || !allowed {
+			return
+		}
+
+		var roles []model.Role
+		if err := db.Select("id", "name").Where("id IN ? AND workspace_id = ?", roleIDs, workspaceID).Find(&roles).Error; err != nil || len(roles) == 0 {
+			return
+		}
+		names := make([]string, len(roles))
+		ids := make([]int64, len(roles))
+		for i, role := range roles {
+			names[i] = "@" + role.Name
+			ids[i] = role.ID
+		}
+
+		var receiverIDs []int64
+		if err := db.Model(&model.WorkspaceMember{}).
+			Where("workspace_id = ? AND role_id IN ? AND status = ? AND user_id <> ?", workspaceID, ids, model.MemberStatusActive.String(), senderID).
+			Distinct().
+			Pluck("user_id", &receiverIDs).Error; err != nil {
+			log.Printf("⚠️ 역할 멘션 대상 조회 실패: workspace=%d, room=%d, err=%v", workspaceID, roomID, err)
+			return
+		}
+
+		content := fmt.Sprintf("%s님이 %s 역할을 멘션했습니다.", senderName, strings.Join(names, ", "))
+		relatedType := "MEETING"
+		if err := CreateNotifications(db, receiverIDs, &senderID, model.NotificationTypeRoleMention.String(), content, &relatedType, &roomID); err != nil {
+			log.Printf("⚠️ 역할 멘션 알림 생성 실패: workspace=%d, room=%d, err=%v", workspaceID, roomID, err)
+		}
+	}()
+}
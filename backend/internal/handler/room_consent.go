@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"realtime-backend/internal/model"
+)
+
+// consentRefreshInterval bounds how stale a room's consent snapshot can get
+const consentRefreshInterval = 5 * time.Second
+
+// roomConsent caches the recording consent state of the meeting behind a room.
+// Speaker IDs are LiveKit identities, which are user IDs as strings.
+type roomConsent struct {
+	mu       sync.Mutex
+	loadedAt time.Time
+	required bool
+	granted  map[string]bool
+	excluded map[string]bool // speakers currently being dropped (for one-shot logging)
+}
+
+// isSpeakerConsented reports whether audio from the speaker may enter the pipeline.
+// When recording is disabled for the meeting (or the room has no meeting) everyone passes.
+func (r *Room) isSpeakerConsented(speakerID, sourceLang string) bool {
+	if r.hub.db == nil {
+		return true
+	}
+
+	c := &r.consent
+	c.mu.Lock()
+	if time.Since(c.loadedAt) > consentRefreshInterval {
+		r.loadConsentLocked()
+	}
+
+	if !c.required || c.granted[speakerID] {
+		delete(c.excluded, speakerID)
+		c.mu.Unlock()
+		return true
+	}
+
+	newlyExcluded := !c.excluded[speakerID]
+	c.excluded[speakerID] = true
+	c.mu.Unlock()
+
+	if newlyExcluded {
+		log.Printf("[Room %s] 🔇 Speaker %s has not consented to recording, excluding audio", r.ID, speakerID)
+
+		// Close any Transcribe stream opened before consent was revoked
+		r.mu.RLock()
+		pipeline := r.awsPipeline
+		r.mu.RUnlock()
+		if pipeline != nil {
+			pipeline.RemoveSpeakerStream(speakerID, sourceLang)
+		}
+	}
+	return false
+}
+
+// loadConsentLocked refreshes the consent snapshot from the database. Caller holds c.mu.
+func (r *Room) loadConsentLocked() {
+	c := &r.consent
+	c.loadedAt = time.Now()
+	if c.excluded == nil {
+		c.excluded = make(map[string]bool)
+	}
+
+	meeting, err := r.findMeeting()
+	if err != nil {
+		// No meeting behind this room: nothing is persisted, so no consent is needed
+		c.required = false
+		return
+	}
+
+	c.required = meeting.RecordingEnabled
+	if !c.required {
+		return
+	}
+
+	var userIDs []int64
+	if err := r.hub.db.Model(&model.RecordingConsent{}).
+		Where("meeting_id = ? AND status = ?", meeting.ID, model.ConsentStatusGranted.String()).
+		Pluck("user_id", &userIDs).Error; err != nil {
+		log.Printf("[Room %s] Failed to load recording consents: %v", r.ID, err)
+		return
+	}
+
+	granted := make(map[string]bool, len(userIDs))
+	for _, id := range userIDs {
+		granted[strconv.FormatInt(id, 10)] = true
+	}
+	c.granted = granted
+}
@@ -0,0 +1,127 @@
+package handler
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	awsai "realtime-backend/internal/aws"
+)
+
+// mixedSpeakerID is the speaker ID of a whole-room mix sent on one WebSocket. The AWS pipeline
+// diarizes it; the gRPC backend receives it as a single participant.
+const mixedSpeakerID = awsai.MixedSpeakerID
+
+const (
+	// activeSpeakerHistory bounds how long LiveKit active-speaker hints are kept
+	activeSpeakerHistory = 2 * time.Minute
+	// diarizationVoteDecay fades old votes so a label can be re-attributed when the mix changes
+	diarizationVoteDecay = 0.8
+)
+
+// activeSpeakerHint is one LiveKit ActiveSpeakersChanged update (loudest speaker first)
+type activeSpeakerHint struct {
+	at       time.Time
+	speakers []string
+}
+
+// roomDiarization maps Transcribe speaker labels of a mixed room feed to participants
+type roomDiarization struct {
+	mu    sync.Mutex
+	hints []activeSpeakerHint
+	votes map[string]map[string]float64 // label → participant ID → seconds spoken while active
+}
+
+// recordActiveSpeakers stores an active-speaker hint sent by a client that carries the mixed feed
+func (r *Room) recordActiveSpeakers(speakerIDs []string) {
+	d := &r.diarization
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := time.Now()
+	d.hints = append(d.hints, activeSpeakerHint{at: now, speakers: speakerIDs})
+
+	// Keep the most recent hint even if it is old: it is still in effect
+	drop := 0
+	for drop < len(d.hints)-1 && now.Sub(d.hints[drop+1].at) > activeSpeakerHistory {
+		drop++
+	}
+	d.hints = d.hints[drop:]
+}
+
+// resolveDiarizedSpeaker attributes a diarized label to the participant LiveKit reported as the
+// loudest speaker for most of the time that label was speaking. Used as the AWS pipeline's
+// SpeakerResolver; returns "" when there is no hint yet.
+func (r *Room) resolveDiarizedSpeaker(label string, start, end time.Time) string {
+	d := &r.diarization
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.votes == nil {
+		d.votes = make(map[string]map[string]float64)
+	}
+	votes := d.votes[label]
+	if votes == nil {
+		votes = make(map[string]float64)
+		d.votes[label] = votes
+	}
+
+	overlaps := d.overlapsLocked(start, end)
+	if len(overlaps) > 0 {
+		for participantID := range votes {
+			votes[participantID] *= diarizationVoteDecay
+		}
+		for participantID, seconds := range overlaps {
+			votes[participantID] += seconds
+		}
+	}
+
+	best := ""
+	for participantID, score := range votes {
+		if best == "" || score > votes[best] {
+			best = participantID
+		}
+	}
+	if best != "" && len(overlaps) > 0 {
+		log.Printf("[Room %s] 🗣️ Diarized %s → %s", r.ID, label, best)
+	}
+	return best
+}
+
+// overlapsLocked returns, per participant, how long they were the loudest speaker within [start, end].
+// A hint is in effect from its timestamp until the next hint. Caller holds d.mu.
+func (d *roomDiarization) overlapsLocked(start, end time.Time) map[string]float64 {
+	if !end.After(start) {
+		end = start.Add(time.Second)
+	}
+
+	overlaps := make(map[string]float64)
+	for i, hint := range d.hints {
+		if len(hint.speakers) == 0 {
+			continue
+		}
+		from := hint.at
+		until := end
+		if i+1 < len(d.hints) {
+			until = d.hints[i+1].at
+		}
+		if from.Before(start) {
+			from = start
+		}
+		if until.After(end) {
+			until = end
+		}
+		if until.After(from) {
+			overlaps[hint.speakers[0]] += until.Sub(from).Seconds()
+		}
+	}
+	return overlaps
+}
+
+// resetDiarization forgets hints and label mappings (the mixed feed left or restarted)
+func (r *Room) resetDiarization() {
+	r.diarization.mu.Lock()
+	r.diarization.hints = nil
+	r.diarization.votes = nil
+	r.diarization.mu.Unlock()
+}
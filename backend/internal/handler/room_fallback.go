@@ -0,0 +1,350 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"realtime-backend/internal/metrics"
+)
+
+// aiBackend identifies the speech pipeline a room sends audio to
+type aiBackend string
+
+const (
+	backendAWS  aiBackend = "aws"
+	backendGRPC aiBackend = "grpc"
+)
+
+// Circuit breaker states, as reported by the diagnostics endpoint
+const (
+	breakerClosed   = "closed"
+	breakerOpen     = "open"
+	breakerHalfOpen = "half_open"
+)
+
+var failoversTotal = metrics.NewCounterVec("eum_ai_backend_failovers_total",
+	"Room AI backend switches caused by repeated pipeline failures", "from", "to")
+
+var errBackendStreamLost = errors.New("stream closed unexpectedly")
+
+// circuitBreaker counts consecutive failures of one backend within a room.
+// It opens at the failure threshold (or immediately on a fatal failure such as a stream
+// that could not be started) and lets a single trial through once the cooldown has passed.
+type circuitBreaker struct {
+	state         string
+	failures      int // consecutive
+	totalFailures int
+	trips         int
+	openedAt      time.Time
+	lastFailureAt time.Time
+	lastError     string
+}
+
+func (b *circuitBreaker) recordSuccess() {
+	b.failures = 0
+	b.state = breakerClosed
+}
+
+// recordFailure returns true when this failure opened the breaker
+func (b *circuitBreaker) recordFailure(err error, fatal bool, threshold int) bool {
+	b.failures++
+	b.totalFailures++
+	b.lastFailureAt = time.Now()
+	if err != nil {
+		b.lastError = err.Error()
+	}
+
+	if b.state == breakerOpen {
+		return false
+	}
+	// A failed half-open trial re-opens right away
+	if fatal || b.state == breakerHalfOpen || b.failures >= threshold {
+		b.state = breakerOpen
+		b.openedAt = time.Now()
+		b.trips++
+		return true
+	}
+	return false
+}
+
+// allows reports whether the backend may receive audio (closed, or open past its cooldown)
+func (b *circuitBreaker) allows(cooldown time.Duration) bool {
+	return b.state != breakerOpen || time.Since(b.openedAt) >= cooldown
+}
+
+func (b *circuitBreaker) currentState() string {
+	if b.state == "" {
+		return breakerClosed
+	}
+	return b.state
+}
+
+// roomFallback tracks which backend a room is on and the health of both backends.
+// Switching only happens on the room's audio processor goroutine.
+type roomFallback struct {
+	mu           sync.Mutex
+	active       aiBackend // empty until the first stream starts
+	aws          circuitBreaker
+	grpc         circuitBreaker
+	switches     int
+	lastSwitchAt time.Time
+}
+
+func (f *roomFallback) breaker(b aiBackend) *circuitBreaker {
+	if b == backendAWS {
+		return &f.aws
+	}
+	return &f.grpc
+}
+
+func otherBackend(b aiBackend) aiBackend {
+	if b == backendAWS {
+		return backendGRPC
+	}
+	return backendAWS
+}
+
+// preferredBackend is the backend selected by AI_USE_AWS
+func (h *RoomHub) preferredBackend() aiBackend {
+	if h.useAWS {
+		return backendAWS
+	}
+	return backendGRPC
+}
+
+// fallbackEnabled reports whether rooms may switch to the other backend at all
+func (h *RoomHub) fallbackEnabled() bool {
+	return h.cfg != nil && h.cfg.AI.FallbackEnabled
+}
+
+// backendAvailable reports whether the backend is configured on this instance
+func (h *RoomHub) backendAvailable(b aiBackend) bool {
+	if b == backendAWS {
+		return h.cfg != nil && (h.useAWS || h.cfg.S3.AccessKeyID != "")
+	}
+	return h.aiClient != nil
+}
+
+// activeBackend returns the backend the room currently sends audio to
+func (r *Room) activeBackend() aiBackend {
+	r.fallback.mu.Lock()
+	defer r.fallback.mu.Unlock()
+	if r.fallback.active == "" {
+		return r.hub.preferredBackend()
+	}
+	return r.fallback.active
+}
+
+// recordBackendSuccess closes the backend's breaker after audio went through
+func (r *Room) recordBackendSuccess(b aiBackend) {
+	r.fallback.mu.Lock()
+	r.fallback.breaker(b).recordSuccess()
+	r.fallback.mu.Unlock()
+}
+
+// recordBackendFailure counts a backend failure. Fatal failures (stream could not start
+// or died) open the breaker immediately since audio would otherwise be dropped.
+func (r *Room) recordBackendFailure(b aiBackend, err error, fatal bool) {
+	threshold := 5
+	if r.hub.cfg != nil && r.hub.cfg.AI.FallbackFailureThreshold > 0 {
+		threshold = r.hub.cfg.AI.FallbackFailureThreshold
+	}
+
+	r.fallback.mu.Lock()
+	opened := r.fallback.breaker(b).recordFailure(err, fatal, threshold)
+	r.fallback.mu.Unlock()
+
+	if opened {
+		log.Printf("[Room %s] ⚡ %s backend circuit opened: %v", r.ID, b, err)
+	}
+}
+
+// nextBackend decides whether the room should move to another backend (or restart the
+// current one after its cooldown). ok is false when the room should stay where it is.
+func (r *Room) nextBackend() (target aiBackend, ok bool) {
+	cooldown := 30 * time.Second
+	if r.hub.cfg != nil && r.hub.cfg.AI.FallbackCooldown > 0 {
+		cooldown = r.hub.cfg.AI.FallbackCooldown
+	}
+	preferred := r.hub.preferredBackend()
+
+	r.fallback.mu.Lock()
+	defer r.fallback.mu.Unlock()
+
+	active := r.fallback.active
+	if active == "" {
+		return "", false
+	}
+	other := otherBackend(active)
+	activeBreaker := r.fallback.breaker(active)
+	otherBreaker := r.fallback.breaker(other)
+	canUseOther := r.hub.fallbackEnabled() && r.hub.backendAvailable(other)
+
+	if activeBreaker.currentState() == breakerOpen {
+		if canUseOther && otherBreaker.allows(cooldown) {
+			return other, true
+		}
+		if activeBreaker.allows(cooldown) {
+			return active, true
+		}
+		return "", false
+	}
+
+	// Running on the fallback: try the preferred backend again once it has cooled down
+	if active != preferred && canUseOther && otherBreaker.currentState() == breakerOpen && otherBreaker.allows(cooldown) {
+		return preferred, true
+	}
+	return "", false
+}
+
+// ensureBackend runs before each audio chunk and performs any pending switch.
+// It returns the backend the chunk should go to.
+func (r *Room) ensureBackend() aiBackend {
+	from := r.activeBackend()
+	to, ok := r.nextBackend()
+	if !ok {
+		return from
+	}
+
+	r.stopBackend(from)
+
+	r.fallback.mu.Lock()
+	if b := r.fallback.breaker(to); b.currentState() == breakerOpen {
+		b.state = breakerHalfOpen
+	}
+	r.fallback.mu.Unlock()
+
+	if err := r.startBackend(to); err != nil {
+		log.Printf("[Room %s] Failed to start %s backend: %v", r.ID, to, err)
+		r.recordBackendFailure(to, err, true)
+		return from
+	}
+
+	r.fallback.mu.Lock()
+	r.fallback.active = to
+	if to != from {
+		r.fallback.switches++
+		r.fallback.lastSwitchAt = time.Now()
+	}
+	r.fallback.mu.Unlock()
+
+	if to != from {
+		failoversTotal.Inc(string(from), string(to))
+		log.Printf("[Room %s] 🔀 AI backend switched: %s → %s", r.ID, from, to)
+	} else {
+		log.Printf("[Room %s] 🔁 AI backend %s restarted after cooldown", r.ID, to)
+	}
+	return to
+}
+
+// startBackend starts the pipeline for the given backend
+func (r *Room) startBackend(b aiBackend) error {
+	if b == backendAWS {
+		return r.startAWSPipeline()
+	}
+	return r.startGrpcStream()
+}
+
+// stopBackend tears down the backend's pipeline without closing the room
+func (r *Room) stopBackend(b aiBackend) {
+	r.mu.Lock()
+	pipeline := r.awsPipeline
+	stream := r.grpcStream
+	if b == backendAWS {
+		r.awsPipeline = nil
+	} else {
+		r.grpcStream = nil
+	}
+	r.mu.Unlock()
+
+	if b == backendAWS && pipeline != nil {
+		pipeline.Close()
+	}
+	if b == backendGRPC && stream != nil {
+		stream.Cancel()
+	}
+}
+
+// =============================================================================
+// Diagnostics
+// =============================================================================
+
+// BreakerStatus is the circuit breaker state of one backend in a room
+type BreakerStatus struct {
+	State         string  `json:"state"`
+	Available     bool    `json:"available"`
+	Failures      int     `json:"consecutive_failures"`
+	TotalFailures int     `json:"total_failures"`
+	Trips         int     `json:"trips"`
+	OpenedAt      *string `json:"opened_at,omitempty"`
+	LastFailureAt *string `json:"last_failure_at,omitempty"`
+	LastError     string  `json:"last_error,omitempty"`
+}
+
+// RoomBackendStatus is the AI backend state of a room
+type RoomBackendStatus struct {
+	RoomID       string                   `json:"room_id"`
+	Active       string                   `json:"active"`
+	Preferred    string                   `json:"preferred"`
+	Switches     int                      `json:"switches"`
+	LastSwitchAt *string                  `json:"last_switch_at,omitempty"`
+	Breakers     map[string]BreakerStatus `json:"breakers"`
+}
+
+// BackendStatuses returns the AI backend state of every room on this instance
+func (h *RoomHub) BackendStatuses() []RoomBackendStatus {
+	h.mu.RLock()
+	rooms := make([]*Room, 0, len(h.rooms))
+	for _, room := range h.rooms {
+		rooms = append(rooms, room)
+	}
+	h.mu.RUnlock()
+
+	statuses := make([]RoomBackendStatus, 0, len(rooms))
+	for _, room := range rooms {
+		statuses = append(statuses, room.backendStatus())
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].RoomID < statuses[j].RoomID })
+	return statuses
+}
+
+func (r *Room) backendStatus() RoomBackendStatus {
+	active := r.activeBackend()
+
+	r.fallback.mu.Lock()
+	defer r.fallback.mu.Unlock()
+
+	status := RoomBackendStatus{
+		RoomID:       r.ID,
+		Active:       string(active),
+		Preferred:    string(r.hub.preferredBackend()),
+		Switches:     r.fallback.switches,
+		LastSwitchAt: formatOptionalTime(r.fallback.lastSwitchAt),
+		Breakers:     make(map[string]BreakerStatus, 2),
+	}
+	for _, b := range []aiBackend{backendAWS, backendGRPC} {
+		br := r.fallback.breaker(b)
+		status.Breakers[string(b)] = BreakerStatus{
+			State:         br.currentState(),
+			Available:     r.hub.backendAvailable(b) && (b == r.hub.preferredBackend() || r.hub.fallbackEnabled()),
+			Failures:      br.failures,
+			TotalFailures: br.totalFailures,
+			Trips:         br.trips,
+			OpenedAt:      formatOptionalTime(br.openedAt),
+			LastFailureAt: formatOptionalTime(br.lastFailureAt),
+			LastError:     br.lastError,
+		}
+	}
+	return status
+}
+
+func formatOptionalTime(t time.Time) *string {
+	if t.IsZero() {
+		return nil
+	}
+	s := t.Format("2006-01-02T15:04:05Z07:00")
+	return &s
+}
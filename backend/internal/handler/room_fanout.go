@@ -0,0 +1,242 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"realtime-backend/internal/errorreport"
+)
+
+// =============================================================================
+// Room Fan-out - Redis pub/sub so a room can span multiple backend instances
+// =============================================================================
+//
+// Each instance runs the pipeline for the speakers connected to it. Broadcasts
+// (transcripts and TTS audio) are published on the room's channel and delivered
+// to the listeners of every other instance. Instances also exchange the target
+// languages of their local listeners so each pipeline translates for the whole room.
+
+const (
+	roomChannelPrefix = "roomhub:room:"
+	roomChannelSuffix = ":events"
+
+	roomEventBroadcast   = "broadcast"
+	roomEventTargetLangs = "target_langs"
+	roomEventSyncRequest = "sync_request"
+)
+
+// roomEvent is the envelope published on a room channel
+type roomEvent struct {
+	Origin  string            `json:"origin"` // instance that published the event
+	Kind    string            `json:"kind"`
+	Message *BroadcastMessage `json:"message,omitempty"`
+	Audio   []byte            `json:"audio,omitempty"` // BroadcastMessage.AudioData is not JSON serialized
+	Langs   []string          `json:"langs,omitempty"`
+}
+
+func roomChannel(roomID string) string {
+	return roomChannelPrefix + roomID + roomChannelSuffix
+}
+
+func roomInstancesKey(roomID string) string {
+	return roomChannelPrefix + roomID + ":instances"
+}
+
+// runFanoutSubscriber receives events for all rooms and delivers those for rooms open on this instance
+func (h *RoomHub) runFanoutSubscriber() {
+	defer errorreport.Recover(errorreport.Context{Component: "room.fanout_subscriber"})
+
+	pubsub := h.redisClient.PSubscribe(context.Background(), roomChannelPrefix+"*"+roomChannelSuffix)
+	defer pubsub.Close()
+
+	log.Printf("[RoomHub] Fan-out subscriber started (instance: %s)", h.instanceID)
+	defer log.Printf("[RoomHub] Fan-out subscriber stopped")
+
+	for msg := range pubsub.Channel() {
+		roomID := strings.TrimSuffix(strings.TrimPrefix(msg.Channel, roomChannelPrefix), roomChannelSuffix)
+
+		var event roomEvent
+		if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+			log.Printf("[RoomHub] Invalid fan-out event on %s: %v", msg.Channel, err)
+			continue
+		}
+		if event.Origin == h.instanceID {
+			continue
+		}
+
+		h.handleRoomEvent(roomID, &event)
+	}
+}
+
+func (h *RoomHub) handleRoomEvent(roomID string, event *roomEvent) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	room, exists := h.rooms[roomID]
+	if !exists {
+		return
+	}
+
+	switch event.Kind {
+	case roomEventBroadcast:
+		if event.Message == nil {
+			return
+		}
+		event.Message.AudioData = event.Audio
+		room.enqueueBroadcast(event.Message)
+	case roomEventTargetLangs:
+		room.setRemoteLanguages(event.Origin, event.Langs)
+	case roomEventSyncRequest:
+		room.workers.Go("fanout_publish", room.publishLocalLanguages)
+	case roomEventTranslationSettings:
+		room.workers.Go("translation_settings", room.reloadTranslationSettings)
+	}
+}
+
+// publishRoomEvent publishes an event on the room channel (no-op without Redis)
+func (h *RoomHub) publishRoomEvent(roomID string, event *roomEvent) {
+	if h.redisClient == nil {
+		return
+	}
+
+	event.Origin = h.instanceID
+	payload, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("[Room %s] Failed to marshal fan-out event: %v", roomID, err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	if err := h.redisClient.Publish(ctx, roomChannel(roomID), payload); err != nil {
+		log.Printf("[Room %s] Failed to publish fan-out event: %v", roomID, err)
+	}
+}
+
+// joinRoomCluster registers this instance for the room and asks peers for their languages
+func (h *RoomHub) joinRoomCluster(roomID string) {
+	if h.redisClient == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	if err := h.redisClient.SAdd(ctx, roomInstancesKey(roomID), h.instanceID); err != nil {
+		log.Printf("[Room %s] Failed to register instance: %v", roomID, err)
+	}
+	h.publishRoomEvent(roomID, &roomEvent{Kind: roomEventSyncRequest})
+}
+
+// leaveRoomCluster unregisters this instance and reports whether other instances still host the room
+func (h *RoomHub) leaveRoomCluster(roomID string) (activeElsewhere bool) {
+	if h.redisClient == nil {
+		return false
+	}
+
+	h.publishRoomEvent(roomID, &roomEvent{Kind: roomEventTargetLangs})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	key := roomInstancesKey(roomID)
+	if err := h.redisClient.SRem(ctx, key, h.instanceID); err != nil {
+		log.Printf("[Room %s] Failed to unregister instance: %v", roomID, err)
+		return false
+	}
+	remaining, err := h.redisClient.SCard(ctx, key)
+	if err != nil {
+		return false
+	}
+	return remaining > 0
+}
+
+// enqueueBroadcast queues a message for local listeners only
+func (r *Room) enqueueBroadcast(msg *BroadcastMessage) {
+	select {
+	case r.broadcast <- msg:
+	default:
+		roomDroppedTotal.Inc("room_broadcast")
+		log.Printf("[Room %s] Broadcast buffer full", r.ID)
+	}
+}
+
+// publishBroadcast forwards a locally produced message to the other instances
+func (r *Room) publishBroadcast(msg *BroadcastMessage) {
+	if r.hub.redisClient == nil {
+		return
+	}
+	r.hub.publishRoomEvent(r.ID, &roomEvent{
+		Kind:    roomEventBroadcast,
+		Message: msg,
+		Audio:   msg.AudioData,
+	})
+}
+
+// localLanguagesLocked returns the target languages of listeners on this instance. Caller holds r.mu.
+func (r *Room) localLanguagesLocked() []string {
+	langSet := make(map[string]bool)
+	langs := make([]string, 0)
+	for _, l := range r.Listeners {
+		if !langSet[l.TargetLang] {
+			langSet[l.TargetLang] = true
+			langs = append(langs, l.TargetLang)
+		}
+	}
+	return langs
+}
+
+// targetLanguagesLocked returns local and remote target languages, deduplicated. Caller holds r.mu.
+func (r *Room) targetLanguagesLocked() []string {
+	langs := r.localLanguagesLocked()
+	langSet := make(map[string]bool, len(langs))
+	for _, lang := range langs {
+		langSet[lang] = true
+	}
+	for _, remote := range r.remoteLangs {
+		for _, lang := range remote {
+			if !langSet[lang] {
+				langSet[lang] = true
+				langs = append(langs, lang)
+			}
+		}
+	}
+	return langs
+}
+
+// publishLocalLanguages announces this instance's listener languages to peers
+func (r *Room) publishLocalLanguages() {
+	if r.hub.redisClient == nil {
+		return
+	}
+
+	r.mu.RLock()
+	langs := r.localLanguagesLocked()
+	r.mu.RUnlock()
+	sort.Strings(langs)
+
+	r.hub.publishRoomEvent(r.ID, &roomEvent{Kind: roomEventTargetLangs, Langs: langs})
+}
+
+// setRemoteLanguages stores the listener languages of another instance and updates the pipeline
+func (r *Room) setRemoteLanguages(origin string, langs []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(langs) == 0 {
+		delete(r.remoteLangs, origin)
+	} else {
+		r.remoteLangs[origin] = langs
+	}
+
+	if r.awsPipeline != nil {
+		targetLangs := r.targetLanguagesLocked()
+		log.Printf("[Room %s] 🔄 Updating target languages (remote %s): %v", r.ID, origin, targetLangs)
+		r.awsPipeline.UpdateTargetLanguages(targetLangs)
+	}
+}
@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"encoding/binary"
+	"encoding/json"
+)
+
+// listenerProtocol is how a /ws/room listener receives binary (audio) messages.
+// Text messages are plain JSON BroadcastMessages under every protocol.
+type listenerProtocol int
+
+const (
+	// listenerProtocolRaw: one raw MP3 blob per utterance, no metadata (default)
+	listenerProtocolRaw listenerProtocol = iota
+	// listenerProtocolTTS (?audioStream=1): TTS chunks in the v1 frame of encodeTTSFrame
+	listenerProtocolTTS
+	// listenerProtocolFramed (?protocol=2): TTS chunks in typed frames with a JSON header, see encodeListenerFrame
+	listenerProtocolFramed
+)
+
+// Frame types of listenerProtocolFramed
+const (
+	frameTypeAudio byte = 0x01 // TTS audio chunk (MP3)
+)
+
+// audioFrameHeader is the JSON header of a frameTypeAudio frame. It names the speaker and
+// language so clients can keep concurrent speakers' audio apart.
+type audioFrameHeader struct {
+	SpeakerID    string `json:"speakerId"`
+	TargetLang   string `json:"targetLang"`
+	TranscriptID string `json:"transcriptId,omitempty"`
+	Sequence     uint32 `json:"sequence"`
+	Last         bool   `json:"last"`
+}
+
+// streamsAudio reports whether the listener receives TTS chunk by chunk
+func (l *Listener) streamsAudio() bool {
+	return l.Protocol == listenerProtocolTTS || l.Protocol == listenerProtocolFramed
+}
+
+// encodeListenerFrame builds a listenerProtocolFramed message:
+//
+//	[type:1][header length:2, big endian][header: JSON][payload]
+//
+// Clients must skip frame types they do not know. Whole blobs (gRPC backend, cache hits)
+// are sent as sequence 0 with last set.
+func encodeListenerFrame(msg *BroadcastMessage) ([]byte, error) {
+	header, err := json.Marshal(audioFrameHeader{
+		SpeakerID:    msg.SpeakerID,
+		TargetLang:   msg.TargetLang,
+		TranscriptID: msg.TranscriptID,
+		Sequence:     msg.Sequence,
+		Last:         msg.Last || msg.Audience == "",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	frame := make([]byte, 0, 3+len(header)+len(msg.AudioData))
+	frame = append(frame, frameTypeAudio)
+	frame = binary.BigEndian.AppendUint16(frame, uint16(len(header)))
+	frame = append(frame, header...)
+	return append(frame, msg.AudioData...), nil
+}
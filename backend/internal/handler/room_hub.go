@@ -9,28 +9,42 @@ import (
 	"time"
 
 	"github.com/gofiber/contrib/websocket"
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 
 	"realtime-backend/internal/ai"
 	awsai "realtime-backend/internal/aws"
 	"realtime-backend/internal/cache"
 	"realtime-backend/internal/config"
+	"realtime-backend/internal/errorreport"
+	"realtime-backend/internal/lifecycle"
+	"realtime-backend/internal/metrics"
 	"realtime-backend/internal/model"
+	"realtime-backend/internal/presence"
 )
 
 // =============================================================================
 // Room Hub - Room 단위 WebSocket 및 gRPC 관리
 // =============================================================================
 
+// roomWorkerShutdownGrace is how long room goroutines may take to exit after Shutdown
+// before they are counted as leaked
+const roomWorkerShutdownGrace = 10 * time.Second
+
 // RoomHub manages all rooms and their connections
 type RoomHub struct {
 	rooms       map[string]*Room
 	mu          sync.RWMutex
-	aiClient    *ai.GrpcClient     // Python gRPC 클라이언트
-	useAWS      bool               // AWS 직접 사용 여부
-	cfg         *config.Config     // 앱 설정
-	redisClient *cache.RedisClient // Redis/Valkey 클라이언트
-	db          *gorm.DB           // Database for saving transcripts
+	aiClient    *ai.GrpcClient      // Python gRPC 클라이언트
+	useAWS      bool                // AWS 직접 사용 여부
+	cfg         *config.Config      // 앱 설정
+	redisClient *cache.RedisClient  // Redis/Valkey 클라이언트
+	db          *gorm.DB            // Database for saving transcripts
+	instanceID  string              // Identifies this instance in room fan-out events
+	transcripts *transcriptWriter   // Async batched writer to voice_records (see room_transcripts.go)
+	usage       *aiUsageTracker     // AWS AI usage per room/workspace and caps (see room_usage.go)
+	onClosed    func(roomID string) // Runs once the last instance closes a room (minutes export, see meeting_minutes.go)
+	presence    *presence.Manager   // Marks verified listeners as in a call (see room_presence.go)
 }
 
 // Room represents a single room with listeners and speakers
@@ -47,14 +61,26 @@ type Room struct {
 	mu          sync.RWMutex
 	hub         *RoomHub
 	isRunning   bool
+	consent     roomConsent               // Recording consent snapshot (see room_consent.go)
+	remoteLangs map[string][]string       // Listener languages on other instances (see room_fanout.go)
+	redactor    *awsai.Redactor           // gRPC path only; the AWS pipeline redacts before Translate
+	workers     *lifecycle.Group          // Tracked room goroutines; leaks after Shutdown surface in /metrics
+	fallback    roomFallback              // AWS/gRPC circuit breakers (see room_fallback.go)
+	translation awsai.TranslationSettings // TTS/partial/confidence/target settings (see room_translation.go)
+	identities  roomSpeakerIdentities     // Verified speaker IDs and profiles (see room_identity.go)
+	vad         roomVAD                   // Per-speaker silence gates before Transcribe (see room_vad.go)
+	diarization roomDiarization           // Mixed-feed speaker labels → participants (see room_diarization.go)
+	ttsAssembly roomTTSAssembly           // Streamed TTS chunks joined for legacy listeners (see room_tts_stream.go)
+	resume      roomResume                // Resumable /ws/room sessions after network blips (see room_resume.go)
 }
 
 // Listener represents a user receiving translations
 type Listener struct {
 	ID         string
 	TargetLang string
+	Protocol   listenerProtocol // Binary message format (see room_frames.go)
 	Conn       *websocket.Conn
-	writeMu    sync.Mutex
+	queue      *listenerQueue // Outgoing frames, written by runListenerWriter (see room_listener_queue.go)
 }
 
 // Speaker represents a user whose audio is being captured
@@ -67,11 +93,15 @@ type Speaker struct {
 
 // BroadcastMessage is sent to listeners
 type BroadcastMessage struct {
-	Type       string `json:"type"` // "transcript" | "audio"
-	SpeakerID  string `json:"speakerId"`
-	TargetLang string `json:"targetLang,omitempty"`
-	Data       any    `json:"data,omitempty"`
-	AudioData  []byte `json:"-"` // Binary audio data (not JSON serialized)
+	Type         string `json:"type"` // "transcript" | "audio"
+	SpeakerID    string `json:"speakerId"`
+	TargetLang   string `json:"targetLang,omitempty"`
+	Data         any    `json:"data,omitempty"`
+	AudioData    []byte `json:"-"` // Binary audio data (not JSON serialized)
+	TranscriptID string `json:"transcriptId,omitempty"`
+	Sequence     uint32 `json:"sequence,omitempty"` // TTS chunk number
+	Last         bool   `json:"last,omitempty"`     // Last TTS chunk of the transcript
+	Audience     string `json:"audience,omitempty"` // "" (everyone) | "streaming" | "legacy"
 }
 
 // AudioMessage is received from listeners (speaker's audio)
@@ -90,20 +120,67 @@ type TranscriptData struct {
 	Language      string `json:"language"`
 }
 
+// roomDroppedTotal counts frames and messages dropped on full room/session buffers
+var roomDroppedTotal = metrics.NewCounterVec("eum_room_dropped_total",
+	"Room and session messages dropped because a buffer was full", "buffer")
+
 // NewRoomHub creates a new RoomHub instance
 func NewRoomHub(aiClient *ai.GrpcClient, cfg *config.Config, useAWS bool, redisClient *cache.RedisClient) *RoomHub {
-	return &RoomHub{
+	hub := &RoomHub{
 		rooms:       make(map[string]*Room),
 		aiClient:    aiClient,
 		cfg:         cfg,
 		useAWS:      useAWS,
 		redisClient: redisClient,
+		instanceID:  uuid.New().String(),
+	}
+
+	metrics.NewGaugeFunc("eum_rooms", "Rooms open on this instance", func() float64 {
+		hub.mu.RLock()
+		defer hub.mu.RUnlock()
+		return float64(len(hub.rooms))
+	})
+	metrics.NewGaugeFunc("eum_rooms_idle_with_workers",
+		"Rooms with no listeners whose goroutines are still running (not cleaned up)", hub.countIdleRoomsWithWorkers)
+
+	// Share rooms with other backend instances through Redis pub/sub
+	if redisClient != nil {
+		go hub.runFanoutSubscriber()
 	}
+	return hub
 }
 
 // SetDB sets the database connection for saving transcripts
 func (h *RoomHub) SetDB(db *gorm.DB) {
 	h.db = db
+	if db != nil && h.transcripts == nil {
+		h.transcripts = newTranscriptWriter(db)
+	}
+	if db != nil && h.usage == nil {
+		h.usage = newAIUsageTracker(db)
+	}
+}
+
+// SetRoomClosedHook registers fn to run (in its own goroutine) after the last instance closes a room
+func (h *RoomHub) SetRoomClosedHook(fn func(roomID string)) {
+	h.onClosed = fn
+}
+
+// FlushTranscripts waits until queued final transcripts are stored in voice_records
+func (h *RoomHub) FlushTranscripts() {
+	if h.transcripts != nil {
+		h.transcripts.Flush()
+	}
+}
+
+// Close flushes pending transcripts and AI usage to the database
+func (h *RoomHub) Close() {
+	if h.transcripts != nil {
+		h.transcripts.Close()
+	}
+	if h.usage != nil {
+		h.usage.Close()
+	}
 }
 
 // GetTranscripts retrieves transcripts from Redis for a room
@@ -129,20 +206,25 @@ func (h *RoomHub) GetOrCreateRoom(roomID string) *Room {
 
 	ctx, cancel := context.WithCancel(context.Background())
 	room := &Room{
-		ID:        roomID,
-		Listeners: make(map[string]*Listener),
-		Speakers:  make(map[string]*Speaker),
-		broadcast: make(chan *BroadcastMessage, 100),
-		audioIn:   make(chan *AudioMessage, 100),
-		ctx:       ctx,
-		cancel:    cancel,
-		hub:       h,
-		isRunning: false,
+		ID:          roomID,
+		Listeners:   make(map[string]*Listener),
+		Speakers:    make(map[string]*Speaker),
+		broadcast:   make(chan *BroadcastMessage, 100),
+		audioIn:     make(chan *AudioMessage, 100),
+		ctx:         ctx,
+		cancel:      cancel,
+		hub:         h,
+		isRunning:   false,
+		remoteLangs: make(map[string][]string),
+		workers:     lifecycle.NewGroup("room", roomID),
+		translation: awsai.DefaultTranslationSettings(),
 	}
 
 	h.rooms[roomID] = room
 	log.Printf("[RoomHub] Created room: %s", roomID)
 
+	go h.joinRoomCluster(roomID)
+
 	return room
 }
 
@@ -162,63 +244,75 @@ func (h *RoomHub) RemoveRoom(roomID string) {
 // Room Methods
 // =============================================================================
 
-// AddListener adds a listener to the room
-func (r *Room) AddListener(listenerID, targetLang string, conn *websocket.Conn) {
+// AddListener adds a listener to the room and starts its writer.
+// A reconnecting listener replaces its previous connection.
+func (r *Room) AddListener(listenerID, targetLang string, protocol listenerProtocol, conn *websocket.Conn) *Listener {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	r.Listeners[listenerID] = &Listener{
+	if previous, exists := r.Listeners[listenerID]; exists {
+		previous.queue.close()
+	}
+	listener := &Listener{
 		ID:         listenerID,
 		TargetLang: targetLang,
+		Protocol:   protocol,
 		Conn:       conn,
+		queue:      newListenerQueue(),
 	}
+	r.Listeners[listenerID] = listener
+	r.workers.Go("listener_writer", func() { r.runListenerWriter(listener) })
 
 	log.Printf("[Room %s] Added listener: %s (target: %s), total: %d",
 		r.ID, listenerID, targetLang, len(r.Listeners))
 
 	// Update target languages in AWS pipeline when new listener joins
-	if r.hub.useAWS && r.awsPipeline != nil {
-		targetLangs := make([]string, 0)
-		langSet := make(map[string]bool)
-		for _, l := range r.Listeners {
-			if !langSet[l.TargetLang] {
-				langSet[l.TargetLang] = true
-				targetLangs = append(targetLangs, l.TargetLang)
-			}
-		}
+	if r.awsPipeline != nil {
+		targetLangs := r.targetLanguagesLocked()
 		log.Printf("[Room %s] 🔄 Updating target languages: %v", r.ID, targetLangs)
 		r.awsPipeline.UpdateTargetLanguages(targetLangs)
 	}
+	r.workers.Go("fanout_publish", r.publishLocalLanguages)
 
 	// Start room processing if not already running
 	if !r.isRunning {
 		r.isRunning = true
-		go r.runBroadcaster()
-		go r.runAudioProcessor()
+		r.workers.Go("broadcaster", r.runBroadcaster)
+		r.workers.Go("audio_processor", r.runAudioProcessor)
+	}
+	return listener
+}
+
+// HasListenerCapacity reports whether the listener may join without exceeding max listeners.
+// A listener that is already in the room (reconnecting) always fits.
+func (r *Room) HasListenerCapacity(listenerID string, max int) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if _, exists := r.Listeners[listenerID]; exists {
+		return true
 	}
+	return len(r.Listeners) < max
 }
 
-// RemoveListener removes a listener from the room
-func (r *Room) RemoveListener(listenerID string) {
+// RemoveListener removes a listener from the room and stops its writer.
+// A listener that was already replaced by a reconnect leaves the new connection alone.
+func (r *Room) RemoveListener(listener *Listener) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	delete(r.Listeners, listenerID)
+	listener.queue.close()
+	if r.Listeners[listener.ID] != listener {
+		return
+	}
+	delete(r.Listeners, listener.ID)
 	log.Printf("[Room %s] Removed listener: %s, remaining: %d",
-		r.ID, listenerID, len(r.Listeners))
+		r.ID, listener.ID, len(r.Listeners))
 
 	// Update target languages in AWS pipeline (deduplicated)
-	if r.hub.useAWS && r.awsPipeline != nil {
-		targetLangs := make([]string, 0)
-		langSet := make(map[string]bool)
-		for _, l := range r.Listeners {
-			if !langSet[l.TargetLang] {
-				langSet[l.TargetLang] = true
-				targetLangs = append(targetLangs, l.TargetLang)
-			}
-		}
-		r.awsPipeline.UpdateTargetLanguages(targetLangs)
+	if r.awsPipeline != nil {
+		r.awsPipeline.UpdateTargetLanguages(r.targetLanguagesLocked())
 	}
+	r.workers.Go("fanout_publish", r.publishLocalLanguages)
 }
 
 // UpdateListenerTargetLang updates a listener's target language
@@ -238,18 +332,12 @@ func (r *Room) UpdateListenerTargetLang(listenerID, newTargetLang string) {
 		r.ID, listenerID, oldLang, newTargetLang)
 
 	// Update target languages in AWS pipeline
-	if r.hub.useAWS && r.awsPipeline != nil {
-		targetLangs := make([]string, 0)
-		langSet := make(map[string]bool)
-		for _, l := range r.Listeners {
-			if !langSet[l.TargetLang] {
-				langSet[l.TargetLang] = true
-				targetLangs = append(targetLangs, l.TargetLang)
-			}
-		}
+	if r.awsPipeline != nil {
+		targetLangs := r.targetLanguagesLocked()
 		log.Printf("[Room %s] 🔄 Updating target languages: %v", r.ID, targetLangs)
 		r.awsPipeline.UpdateTargetLanguages(targetLangs)
 	}
+	r.workers.Go("fanout_publish", r.publishLocalLanguages)
 
 	// If no listeners and no speakers, cleanup room
 	if len(r.Listeners) == 0 && len(r.Speakers) == 0 {
@@ -270,9 +358,13 @@ func (r *Room) RemoveSpeaker(speakerID string) {
 	if !exists {
 		return
 	}
+	r.forgetSpeakerVAD(speakerID)
+	if speakerID == mixedSpeakerID {
+		r.resetDiarization()
+	}
 
 	// Close the speaker's Transcribe stream (AWS mode)
-	if r.hub.useAWS && pipeline != nil {
+	if pipeline != nil {
 		pipeline.RemoveSpeakerStream(speakerID, speaker.SourceLang)
 		log.Printf("[Room %s] Closed Transcribe stream for speaker: %s", r.ID, speakerID)
 	}
@@ -311,7 +403,7 @@ func (r *Room) AddOrUpdateSpeaker(speakerID, sourceLang, nickname, profileImg st
 	if oldSourceLang != "" && oldSourceLang != sourceLang {
 		log.Printf("[Room %s] Speaker %s changed language: %s -> %s, cleaning up old stream",
 			r.ID, speakerID, oldSourceLang, sourceLang)
-		if r.hub.useAWS && r.awsPipeline != nil {
+		if r.awsPipeline != nil {
 			r.awsPipeline.RemoveSpeakerStream(speakerID, oldSourceLang)
 		}
 	}
@@ -320,21 +412,12 @@ func (r *Room) AddOrUpdateSpeaker(speakerID, sourceLang, nickname, profileImg st
 		r.ID, speakerID, sourceLang)
 }
 
-// GetTargetLanguages returns all unique target languages in the room
+// GetTargetLanguages returns all unique target languages in the room (including other instances)
 func (r *Room) GetTargetLanguages() []string {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	langSet := make(map[string]bool)
-	for _, listener := range r.Listeners {
-		langSet[listener.TargetLang] = true
-	}
-
-	langs := make([]string, 0, len(langSet))
-	for lang := range langSet {
-		langs = append(langs, lang)
-	}
-	return langs
+	return r.targetLanguagesLocked()
 }
 
 // SendAudio sends audio from a speaker to be processed
@@ -350,17 +433,15 @@ func (r *Room) SendAudio(speakerID, sourceLang string, audioData []byte) {
 		AudioData:  audioData,
 	}:
 	default:
+		roomDroppedTotal.Inc("room_audio_in")
 		log.Printf("[Room %s] Audio buffer full, dropping frame from %s", r.ID, speakerID)
 	}
 }
 
-// Broadcast sends a message to all relevant listeners
+// Broadcast sends a message to all relevant listeners, on this and other instances
 func (r *Room) Broadcast(msg *BroadcastMessage) {
-	select {
-	case r.broadcast <- msg:
-	default:
-		log.Printf("[Room %s] Broadcast buffer full", r.ID)
-	}
+	r.enqueueBroadcast(msg)
+	r.publishBroadcast(msg)
 }
 
 // Shutdown gracefully shuts down the room
@@ -375,92 +456,143 @@ func (r *Room) Shutdown() {
 	}
 	r.mu.Unlock()
 
-	// Save transcripts to database before shutdown
-	r.saveTranscriptsToDatabase()
+	// Make sure queued transcripts reach the database, then drop the live Redis list
+	// unless the room is still open on another instance
+	if r.hub.transcripts != nil {
+		r.hub.transcripts.Flush()
+		r.hub.transcripts.ForgetRoom(r.ID)
+	}
+	if r.hub.leaveRoomCluster(r.ID) {
+		log.Printf("[Room %s] Room still active on other instances, keeping transcripts in Redis", r.ID)
+	} else {
+		r.clearLiveTranscripts()
+		if r.hub.onClosed != nil {
+			go r.hub.onClosed(r.ID)
+		}
+	}
 
 	close(r.broadcast)
 	close(r.audioIn)
 	r.isRunning = false
 	log.Printf("[Room %s] Shutdown complete", r.ID)
+
+	go r.workers.Close(roomWorkerShutdownGrace)
 }
 
-// saveTranscriptsToDatabase flushes Redis transcripts to the database
-func (r *Room) saveTranscriptsToDatabase() {
-	if r.hub.redisClient == nil || r.hub.db == nil {
+// clearLiveTranscripts removes the room's live transcript list from Redis.
+// Final transcripts are already persisted by the hub's transcript writer.
+func (r *Room) clearLiveTranscripts() {
+	if r.hub.redisClient == nil {
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	// Get and delete transcripts from Redis
-	transcripts, err := r.hub.redisClient.FlushRoom(ctx, r.ID)
-	if err != nil {
-		log.Printf("[Room %s] Failed to flush transcripts from Redis: %v", r.ID, err)
-		return
+	if err := r.hub.redisClient.DeleteRoom(ctx, r.ID); err != nil {
+		log.Printf("[Room %s] Failed to clear transcripts from Redis: %v", r.ID, err)
 	}
+}
 
-	if len(transcripts) == 0 {
-		log.Printf("[Room %s] No transcripts to save to database", r.ID)
-		return
-	}
+// findMeeting resolves the meeting behind this room.
+func (r *Room) findMeeting() (*model.Meeting, error) {
+	return findRoomMeeting(r.hub.db, r.ID)
+}
 
-	// Parse meetingID from roomID (format: "meeting-{id}")
+// findRoomMeeting resolves the meeting behind a room ID.
+// Room IDs are either "meeting-{id}" or a meeting code.
+func findRoomMeeting(db *gorm.DB, roomID string) (*model.Meeting, error) {
 	var meeting model.Meeting
-	if strings.HasPrefix(r.ID, "meeting-") {
-		meetingIDStr := strings.TrimPrefix(r.ID, "meeting-")
-		if err := r.hub.db.Where("id = ?", meetingIDStr).First(&meeting).Error; err != nil {
-			log.Printf("[Room %s] Meeting ID %s not found, skipping DB save: %v", r.ID, meetingIDStr, err)
-			return
-		}
-	} else {
-		// Try to find by code as fallback
-		if err := r.hub.db.Where("code = ?", r.ID).First(&meeting).Error; err != nil {
-			log.Printf("[Room %s] Meeting not found by code, skipping DB save: %v", r.ID, err)
-			return
+	if strings.HasPrefix(roomID, "meeting-") {
+		meetingIDStr := strings.TrimPrefix(roomID, "meeting-")
+		if err := db.Where("id = ?", meetingIDStr).First(&meeting).Error; err != nil {
+			return nil, err
 		}
+		return &meeting, nil
 	}
 
-	// Convert Redis transcripts to VoiceRecord models
-	voiceRecords := make([]model.VoiceRecord, 0, len(transcripts))
-	for _, t := range transcripts {
-		// Only save final transcripts to avoid duplicates
-		if !t.IsFinal {
-			continue
-		}
+	if err := db.Where("code = ?", roomID).First(&meeting).Error; err != nil {
+		return nil, err
+	}
+	return &meeting, nil
+}
 
-		record := model.VoiceRecord{
-			MeetingID:   meeting.ID,
-			SpeakerName: t.SpeakerName,
-			Original:    t.Original,
-			CreatedAt:   t.Timestamp,
-		}
+// workspaceRegion returns the pinned AWS data region of the room's workspace ("" = default)
+func (r *Room) workspaceRegion() string {
+	if r.hub.db == nil {
+		return ""
+	}
 
-		if t.SourceLang != "" {
-			record.SourceLang = &t.SourceLang
-		}
-		if t.Translated != "" {
-			record.Translated = &t.Translated
-		}
-		if t.TargetLang != "" {
-			record.TargetLang = &t.TargetLang
-		}
+	meeting, err := r.findMeeting()
+	if err != nil {
+		return ""
+	}
 
-		voiceRecords = append(voiceRecords, record)
+	var workspace model.Workspace
+	if err := r.hub.db.Select("id", "data_region").First(&workspace, meeting.WorkspaceID).Error; err != nil {
+		return ""
+	}
+	if workspace.DataRegion == nil {
+		return ""
 	}
+	return *workspace.DataRegion
+}
 
-	if len(voiceRecords) == 0 {
-		log.Printf("[Room %s] No final transcripts to save", r.ID)
-		return
+// workspaceTerminologies returns the synced glossary terminologies of the room's workspace
+func (r *Room) workspaceTerminologies() map[string]string {
+	if r.hub.db == nil {
+		return nil
 	}
 
-	// Bulk insert to database
-	if err := r.hub.db.Create(&voiceRecords).Error; err != nil {
-		log.Printf("[Room %s] Failed to save transcripts to database: %v", r.ID, err)
-		return
+	meeting, err := r.findMeeting()
+	if err != nil {
+		return nil
+	}
+
+	var synced []model.GlossaryTerminology
+	if err := r.hub.db.
+		Where("workspace_id = ? AND term_count > 0 AND synced_at IS NOT NULL AND last_error IS NULL", meeting.WorkspaceID).
+		Find(&synced).Error; err != nil {
+		log.Printf("[Room %s] Failed to load glossary terminologies: %v", r.ID, err)
+		return nil
+	}
+
+	names := make(map[string]string, len(synced))
+	for _, t := range synced {
+		names[t.SourceLang+":"+t.TargetLang] = t.TerminologyName
+	}
+	return names
+}
+
+// workspaceRedaction returns the profanity/PII redaction setting of the room's workspace (nil = off)
+func (r *Room) workspaceRedaction() *awsai.RedactionConfig {
+	if r.hub.db == nil {
+		return nil
+	}
+
+	meeting, err := r.findMeeting()
+	if err != nil {
+		return nil
+	}
+
+	var setting model.RedactionSetting
+	if err := r.hub.db.Where("workspace_id = ?", meeting.WorkspaceID).Limit(1).Find(&setting).Error; err != nil {
+		log.Printf("[Room %s] Failed to load redaction settings: %v", r.ID, err)
+		return nil
+	}
+	if setting.WorkspaceID == 0 || setting.Mode == model.RedactionModeOff.String() {
+		return nil
 	}
 
-	log.Printf("[Room %s] Saved %d transcripts to database (meeting_id: %d)", r.ID, len(voiceRecords), meeting.ID)
+	cfg := &awsai.RedactionConfig{
+		Mode:     awsai.RedactionMode(setting.Mode),
+		DenyList: splitDenyList(setting.DenyList),
+		PII:      setting.RedactPII,
+	}
+	if r.hub.cfg != nil {
+		cfg.VocabularyFilters = r.hub.cfg.AI.TranscribeVocabularyFilters
+	}
+	return cfg
 }
 
 // =============================================================================
@@ -471,6 +603,7 @@ func (r *Room) saveTranscriptsToDatabase() {
 func (r *Room) runBroadcaster() {
 	log.Printf("[Room %s] Broadcaster started", r.ID)
 	defer log.Printf("[Room %s] Broadcaster stopped", r.ID)
+	defer errorreport.Recover(r.reportContext("room.broadcaster"))
 
 	for {
 		select {
@@ -513,7 +646,7 @@ func (r *Room) broadcastMessage(msg *BroadcastMessage) {
 			}
 		} else if msg.Type == "audio" {
 			// Audio messages go only to matching targetLang (and not the speaker)
-			shouldSend = msg.TargetLang == listener.TargetLang
+			shouldSend = msg.TargetLang == listener.TargetLang && listener.acceptsAudience(msg.Audience)
 		}
 
 		if shouldSend {
@@ -522,33 +655,41 @@ func (r *Room) broadcastMessage(msg *BroadcastMessage) {
 	}
 }
 
+// sendToListener encodes a message for the listener's protocol and queues it
 func (r *Room) sendToListener(listener *Listener, msg *BroadcastMessage) {
-	listener.writeMu.Lock()
-	defer listener.writeMu.Unlock()
-
-	var err error
-	if msg.AudioData != nil && len(msg.AudioData) > 0 {
+	var frame listenerFrame
+	if msg.Type == "audio" && listener.Protocol == listenerProtocolFramed {
+		// Typed frame with speaker/language header (see room_frames.go)
+		data, err := encodeListenerFrame(msg)
+		if err != nil {
+			log.Printf("[Room %s] Failed to encode frame: %v", r.ID, err)
+			return
+		}
+		frame = listenerFrame{messageType: websocket.BinaryMessage, data: data, audio: true}
+	} else if msg.Type == "audio" && listener.Protocol == listenerProtocolTTS {
+		// Framed chunk (see room_tts_stream.go)
+		frame = listenerFrame{messageType: websocket.BinaryMessage, data: encodeTTSFrame(msg), audio: true}
+	} else if msg.AudioData != nil && len(msg.AudioData) > 0 {
 		// Send binary audio data
-		err = listener.Conn.WriteMessage(websocket.BinaryMessage, msg.AudioData)
+		frame = listenerFrame{messageType: websocket.BinaryMessage, data: msg.AudioData, audio: true}
 	} else {
 		// Send JSON message
-		jsonData, jsonErr := json.Marshal(msg)
-		if jsonErr != nil {
-			log.Printf("[Room %s] Failed to marshal message: %v", r.ID, jsonErr)
+		jsonData, err := json.Marshal(msg)
+		if err != nil {
+			log.Printf("[Room %s] Failed to marshal message: %v", r.ID, err)
 			return
 		}
-		err = listener.Conn.WriteMessage(websocket.TextMessage, jsonData)
+		frame = listenerFrame{messageType: websocket.TextMessage, data: jsonData}
 	}
 
-	if err != nil {
-		log.Printf("[Room %s] Failed to send to listener %s: %v", r.ID, listener.ID, err)
-	}
+	r.enqueue(listener, frame)
 }
 
 // runAudioProcessor processes incoming audio and sends to AI server
 func (r *Room) runAudioProcessor() {
 	log.Printf("[Room %s] Audio processor started (useAWS: %v)", r.ID, r.hub.useAWS)
 	defer log.Printf("[Room %s] Audio processor stopped", r.ID)
+	defer errorreport.Recover(r.reportContext("room.audio_processor"))
 
 	// Start AI stream (AWS or gRPC)
 	if err := r.startStream(); err != nil {
@@ -569,12 +710,30 @@ func (r *Room) runAudioProcessor() {
 	}
 }
 
-// startStream starts either AWS pipeline or gRPC stream
+// startStream starts the preferred AI backend (AWS pipeline or gRPC stream).
+// With fallback enabled a start failure is not fatal: the room moves to the other backend.
 func (r *Room) startStream() error {
-	if r.hub.useAWS {
-		return r.startAWSPipeline()
+	if isProbeRoom(r.ID) {
+		return nil
 	}
-	return r.startGrpcStream()
+
+	r.reloadTranslationSettings()
+
+	backend := r.hub.preferredBackend()
+	err := r.startBackend(backend)
+
+	r.fallback.mu.Lock()
+	r.fallback.active = backend
+	r.fallback.mu.Unlock()
+
+	if err != nil {
+		r.recordBackendFailure(backend, err, true)
+		if r.hub.fallbackEnabled() {
+			r.ensureBackend()
+			return nil
+		}
+	}
+	return err
 }
 
 func (r *Room) startGrpcStream() error {
@@ -583,10 +742,10 @@ func (r *Room) startGrpcStream() error {
 		return nil
 	}
 
-	// Get target languages for this room
+	// Get target languages for this room (workspace defaults until listeners join)
 	targetLangs := r.GetTargetLanguages()
 	if len(targetLangs) == 0 {
-		targetLangs = []string{"en"} // Default
+		targetLangs = r.languagePolicy().defaultTargets()
 	}
 
 	// Build participants from listeners
@@ -621,12 +780,16 @@ func (r *Room) startGrpcStream() error {
 		return err
 	}
 
+	// Translation runs on the AI server, so transcripts are redacted when they come back
+	redactor := awsai.NewRedactor(r.workspaceRedaction())
+
 	r.mu.Lock()
 	r.grpcStream = stream
+	r.redactor = redactor
 	r.mu.Unlock()
 
 	// Start receiving responses
-	go r.receiveGrpcResponses()
+	r.workers.Go("grpc_responses", r.receiveGrpcResponses)
 
 	return nil
 }
@@ -638,15 +801,24 @@ func (r *Room) startAWSPipeline() error {
 		return nil
 	}
 
-	// Get target languages for this room
+	// Get target languages for this room (workspace defaults until listeners join)
 	targetLangs := r.GetTargetLanguages()
 	if len(targetLangs) == 0 {
-		targetLangs = []string{"en"} // Default
+		targetLangs = r.languagePolicy().defaultTargets()
 	}
 
+	settings := r.translationSettings()
 	pipelineCfg := &awsai.PipelineConfig{
 		TargetLanguages: targetLangs,
 		SampleRate:      16000,
+		Region:          r.workspaceRegion(),
+		Terminologies:   r.workspaceTerminologies(),
+		Redaction:       r.workspaceRedaction(),
+		Settings:        &settings,
+
+		VADAggressiveness: r.vadAggressiveness(),
+		SpeakerResolver:   r.resolveDiarizedSpeaker,
+		Usage:             r.usageMeter(),
 	}
 
 	pipeline, err := awsai.NewPipeline(r.ctx, r.hub.cfg, pipelineCfg)
@@ -657,10 +829,11 @@ func (r *Room) startAWSPipeline() error {
 
 	r.mu.Lock()
 	r.awsPipeline = pipeline
+	r.redactor = nil // the pipeline redacts before Translate
 	r.mu.Unlock()
 
 	// Start receiving responses from AWS pipeline
-	go r.receiveAWSResponses()
+	r.workers.Go("aws_responses", r.receiveAWSResponses)
 
 	log.Printf("[Room %s] AWS pipeline started with targets: %v", r.ID, targetLangs)
 	return nil
@@ -668,6 +841,8 @@ func (r *Room) startAWSPipeline() error {
 
 // receiveAWSResponses handles responses from AWS pipeline
 func (r *Room) receiveAWSResponses() {
+	defer errorreport.Recover(r.reportContext("room.aws_responses"))
+
 	r.mu.RLock()
 	pipeline := r.awsPipeline
 	r.mu.RUnlock()
@@ -707,6 +882,8 @@ func (r *Room) receiveAWSResponses() {
 }
 
 func (r *Room) receiveGrpcResponses() {
+	defer errorreport.Recover(r.reportContext("room.grpc_responses"))
+
 	r.mu.RLock()
 	stream := r.grpcStream
 	r.mu.RUnlock()
@@ -723,8 +900,10 @@ func (r *Room) receiveGrpcResponses() {
 		case transcript, ok := <-stream.TranscriptChan:
 			if !ok {
 				log.Printf("[Room %s] TranscriptChan closed", r.ID)
+				r.grpcStreamLost(stream, errBackendStreamLost)
 				return
 			}
+			r.recordBackendSuccess(backendGRPC)
 			r.handleTranscript(transcript)
 
 		case audio, ok := <-stream.AudioChan:
@@ -740,13 +919,56 @@ func (r *Room) receiveGrpcResponses() {
 			}
 			if err != nil {
 				log.Printf("[Room %s] gRPC error: %v", r.ID, err)
+				r.grpcStreamLost(stream, err)
 				return
 			}
 		}
 	}
 }
 
+// grpcStreamLost reports a gRPC stream that ended on its own (not stopped by a backend switch)
+func (r *Room) grpcStreamLost(stream *ai.ChatStream, err error) {
+	r.mu.RLock()
+	current := r.grpcStream == stream
+	r.mu.RUnlock()
+
+	if current && r.ctx.Err() == nil {
+		r.recordBackendFailure(backendGRPC, err, true)
+	}
+}
+
 func (r *Room) handleTranscript(t *ai.TranscriptMessage) {
+	r.mu.RLock()
+	redactor := r.redactor
+	settings := r.translation
+	r.mu.RUnlock()
+
+	// Drop translations into languages the room no longer allows
+	if len(settings.AllowedTargets) > 0 {
+		allowed := t.Translations[:0]
+		for _, trans := range t.Translations {
+			if settings.AllowsTarget(trans.TargetLanguage) {
+				allowed = append(allowed, trans)
+			}
+		}
+		t.Translations = allowed
+	}
+
+	if redactor != nil {
+		original, ok := redactor.Apply(t.OriginalText)
+		if !ok {
+			return
+		}
+		t.OriginalText = original
+		for _, trans := range t.Translations {
+			translated, ok := redactor.Apply(trans.TranslatedText)
+			if !ok {
+				return
+			}
+			trans.TranslatedText = translated
+		}
+	}
+
 	speakerID := ""
 	speakerName := ""
 	if t.Speaker != nil {
@@ -773,28 +995,19 @@ func (r *Room) handleTranscript(t *ai.TranscriptMessage) {
 			})
 		}
 
-		// Save translated transcript to Redis (only once per translation)
-		if t.IsFinal && r.hub.redisClient != nil {
+		// Save translated transcript (only once per translation)
+		if t.IsFinal {
 			for _, trans := range t.Translations {
-				go func(targetLang, translatedText string) {
-					ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
-					defer cancel()
-
-					transcript := &cache.RoomTranscript{
-						RoomID:      r.ID,
-						SpeakerID:   speakerID,
-						SpeakerName: speakerName,
-						Original:    t.OriginalText,
-						Translated:  translatedText,
-						SourceLang:  t.OriginalLanguage,
-						TargetLang:  targetLang,
-						IsFinal:     t.IsFinal,
-					}
-
-					if err := r.hub.redisClient.AddTranscript(ctx, r.ID, transcript); err != nil {
-						log.Printf("[Room %s] Failed to save translated transcript to Redis: %v", r.ID, err)
-					}
-				}(trans.TargetLanguage, trans.TranslatedText)
+				r.saveTranscript(&cache.RoomTranscript{
+					RoomID:      r.ID,
+					SpeakerID:   speakerID,
+					SpeakerName: speakerName,
+					Original:    t.OriginalText,
+					Translated:  trans.TranslatedText,
+					SourceLang:  t.OriginalLanguage,
+					TargetLang:  trans.TargetLanguage,
+					IsFinal:     t.IsFinal,
+				})
 			}
 		}
 	} else {
@@ -810,43 +1023,83 @@ func (r *Room) handleTranscript(t *ai.TranscriptMessage) {
 			},
 		})
 
-		// Save original to Redis
-		if t.IsFinal && r.hub.redisClient != nil {
-			go func() {
-				ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
-				defer cancel()
+		// Save original
+		if t.IsFinal {
+			r.saveTranscript(&cache.RoomTranscript{
+				RoomID:      r.ID,
+				SpeakerID:   speakerID,
+				SpeakerName: speakerName,
+				Original:    t.OriginalText,
+				SourceLang:  t.OriginalLanguage,
+				IsFinal:     t.IsFinal,
+			})
+		}
+	}
+}
 
-				transcript := &cache.RoomTranscript{
-					RoomID:      r.ID,
-					SpeakerID:   speakerID,
-					SpeakerName: speakerName,
-					Original:    t.OriginalText,
-					SourceLang:  t.OriginalLanguage,
-					IsFinal:     t.IsFinal,
-				}
+// saveTranscript stores a final transcript in Redis (live list) and queues it for Postgres
+func (r *Room) saveTranscript(transcript *cache.RoomTranscript) {
+	transcript.Timestamp = time.Now()
 
-				if err := r.hub.redisClient.AddTranscript(ctx, r.ID, transcript); err != nil {
-					log.Printf("[Room %s] Failed to save transcript to Redis: %v", r.ID, err)
-				}
-			}()
-		}
+	if r.hub.transcripts != nil {
+		r.hub.transcripts.Enqueue(r.ID, *transcript)
+	}
+
+	if r.hub.redisClient != nil {
+		r.workers.Go("transcript_cache", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+
+			if err := r.hub.redisClient.AddTranscript(ctx, r.ID, transcript); err != nil {
+				log.Printf("[Room %s] Failed to save transcript to Redis: %v", r.ID, err)
+			}
+		})
 	}
 }
 
 func (r *Room) handleAudio(audio *ai.AudioMessage) {
+	settings := r.translationSettings()
+	if !settings.TTSEnabled || !settings.AllowsTarget(audio.TargetLanguage) {
+		return
+	}
+
+	if audio.Streamed {
+		r.handleStreamedAudio(audio)
+		return
+	}
+
 	r.Broadcast(&BroadcastMessage{
-		Type:       "audio",
-		SpeakerID:  audio.SpeakerParticipantID,
-		TargetLang: audio.TargetLanguage,
-		AudioData:  audio.AudioData,
+		Type:         "audio",
+		SpeakerID:    audio.SpeakerParticipantID,
+		TargetLang:   audio.TargetLanguage,
+		AudioData:    audio.AudioData,
+		TranscriptID: audio.TranscriptID,
 	})
 }
 
 func (r *Room) processAudio(msg *AudioMessage) {
-	if r.hub.useAWS {
-		r.processAudioAWS(msg)
-	} else {
+	if isProbeRoom(r.ID) {
+		r.processAudioProbe(msg)
+		return
+	}
+
+	// Exclude speakers who haven't consented while recording is enabled
+	if !r.isSpeakerConsented(msg.SpeakerID, msg.SourceLang) {
+		return
+	}
+
+	if r.ensureBackend() != backendAWS {
 		r.processAudioGRPC(msg)
+		return
+	}
+
+	// Only speech reaches Transcribe; the gRPC server does its own silence handling
+	for _, frame := range r.gateAudio(msg) {
+		r.processAudioAWS(&AudioMessage{
+			SpeakerID:  msg.SpeakerID,
+			SourceLang: msg.SourceLang,
+			AudioData:  frame,
+		})
 	}
 }
 
@@ -874,7 +1127,10 @@ func (r *Room) processAudioAWS(msg *AudioMessage) {
 
 	if err := pipeline.ProcessAudio(msg.SpeakerID, msg.SourceLang, speakerName, msg.AudioData); err != nil {
 		log.Printf("[Room %s] ❌ AWS pipeline error: %v", r.ID, err)
+		r.recordBackendFailure(backendAWS, err, false)
+		return
 	}
+	r.recordBackendSuccess(backendAWS)
 }
 
 // processAudioGRPC sends audio to Python gRPC server
@@ -914,6 +1170,7 @@ func (r *Room) processAudioGRPC(msg *AudioMessage) {
 	case stream.SendChan <- audioChunk:
 		// Audio sent successfully
 	default:
+		roomDroppedTotal.Inc("room_grpc_send")
 		log.Printf("[Room %s] Send channel full, audio dropped from %s", r.ID, msg.SpeakerID)
 	}
 }
@@ -922,6 +1179,23 @@ func (r *Room) processAudioGRPC(msg *AudioMessage) {
 // Cleanup
 // =============================================================================
 
+// countIdleRoomsWithWorkers counts rooms whose listeners are all gone but whose workers still run
+func (h *RoomHub) countIdleRoomsWithWorkers() float64 {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	idle := 0
+	for _, room := range h.rooms {
+		room.mu.RLock()
+		noListeners := len(room.Listeners) == 0
+		room.mu.RUnlock()
+		if noListeners && len(room.workers.Running()) > 0 {
+			idle++
+		}
+	}
+	return float64(idle)
+}
+
 // CleanupInactiveRooms removes rooms with no activity
 func (h *RoomHub) CleanupInactiveRooms(maxAge time.Duration) {
 	h.mu.Lock()
@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"go.uber.org/goleak"
+)
+
+// newTestRoomHub returns a hub without Redis, database or AI backends.
+// Probe rooms skip the AI stream, so only the room's own goroutines run.
+func newTestRoomHub() *RoomHub {
+	return &RoomHub{
+		rooms:      make(map[string]*Room),
+		instanceID: "test",
+	}
+}
+
+// waitForWorkers polls until at most want goroutines of the given kind ("" = all kinds) are running
+func waitForWorkers(t *testing.T, room *Room, kind string, want int) {
+	t.Helper()
+
+	count := func() int {
+		running := room.workers.Running()
+		if kind != "" {
+			return running[kind]
+		}
+		total := 0
+		for _, n := range running {
+			total += n
+		}
+		return total
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for count() > want {
+		if time.Now().After(deadline) {
+			t.Fatalf("room goroutines still running: %v", room.workers.Running())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestRemoveListenerStopsWriter(t *testing.T) {
+	defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+
+	hub := newTestRoomHub()
+	room := hub.GetOrCreateRoom(probeRoomPrefix + "remove-listener")
+
+	first := room.AddListener("listener-1", "en", listenerProtocolRaw, nil)
+	room.AddListener("listener-2", "ko", listenerProtocolRaw, nil)
+	if got := room.workers.Running()["listener_writer"]; got != 2 {
+		t.Fatalf("listener_writer running = %d, want 2", got)
+	}
+
+	room.RemoveListener(first)
+	waitForWorkers(t, room, "listener_writer", 1)
+
+	hub.RemoveRoom(room.ID)
+}
+
+func TestRoomShutdownStopsWorkers(t *testing.T) {
+	defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+
+	hub := newTestRoomHub()
+	room := hub.GetOrCreateRoom(probeRoomPrefix + "shutdown")
+	room.AddListener("listener-1", "en", listenerProtocolRaw, nil)
+	// A reconnect replaces the listener; the old writer must not outlive the room
+	room.AddListener("listener-1", "ko", listenerProtocolRaw, nil)
+
+	hub.RemoveRoom(room.ID)
+	waitForWorkers(t, room, "", 0)
+}
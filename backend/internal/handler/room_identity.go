@@ -0,0 +1,199 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+
+	"realtime-backend/internal/auth"
+	"realtime-backend/internal/model"
+)
+
+// speakerIdentityTTL bounds how long a verified (or rejected) speaker is cached per room
+const speakerIdentityTTL = time.Minute
+
+// roomIdentity /ws/room 연결 사용자 검증 결과 (Locals "roomIdentity")
+type roomIdentity struct {
+	UserID      int64
+	Nickname    string
+	WorkspaceID *int64
+}
+
+// RoomIdentityHandler /ws/room 연결 사용자 인증 및 CONNECT_MEDIA 권한 검증
+type RoomIdentityHandler struct {
+	db         *gorm.DB
+	jwtManager *auth.JWTManager
+	required   bool
+}
+
+// NewRoomIdentityHandler RoomIdentityHandler 생성 (required=false면 검증 생략, 기존 클라이언트 호환용)
+func NewRoomIdentityHandler(db *gorm.DB, jwtManager *auth.JWTManager, required bool) *RoomIdentityHandler {
+	return &RoomIdentityHandler{db: db, jwtManager: jwtManager, required: required}
+}
+
+// VerifyRoomIdentity /ws/room 업그레이드 미들웨어 (RequireJoinToken 다음에 위치)
+// 입장 토큰 또는 access_token 쿠키로 사용자를 확인하고, listenerId가 본인인지와
+// 미팅 워크스페이스의 CONNECT_MEDIA 권한을 검사
+func (h *RoomIdentityHandler) VerifyRoomIdentity(c *fiber.Ctx) error {
+	roomID := c.Query("roomId")
+	if !h.required || roomID == "" || isProbeRoom(roomID) {
+		return c.Next()
+	}
+
+	userID, nickname, ok := h.identify(c)
+	if !ok {
+		return RejectWebSocket(c, WSCloseUnauthorized, "authentication required")
+	}
+
+	if listenerID := strings.TrimSpace(c.Query("listenerId")); listenerID != "" && listenerID != strconv.FormatInt(userID, 10) {
+		return RejectWebSocket(c, WSCloseForbidden, "listenerId does not match the signed-in user")
+	}
+
+	meeting, err := lookupRoomMeeting(h.db, roomID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return RejectWebSocket(c, WSCloseNotFound, "meeting not found")
+		}
+		return RejectWebSocket(c, WSCloseInternalError, "failed to get meeting")
+	}
+
+	identity := &roomIdentity{UserID: userID, Nickname: nickname}
+	if meeting != nil {
+		if meeting.Status == "ENDED" {
+			return RejectWebSocket(c, WSCloseForbidden, "meeting has ended")
+		}
+		if meeting.WorkspaceID != nil {
+			hasPermission, err := auth.CheckChannelPermission(h.db, *meeting.WorkspaceID, meeting.ID, userID, "CONNECT_MEDIA")
+			if err != nil {
+				return RejectWebSocket(c, WSCloseInternalError, "permission check failed")
+			}
+			if !hasPermission {
+				return RejectWebSocket(c, WSCloseForbidden, "permission denied: CONNECT_MEDIA")
+			}
+			if satisfied, err := WorkspaceTwoFactorSatisfied(h.db, *meeting.WorkspaceID, userID); err != nil {
+				return RejectWebSocket(c, WSCloseInternalError, "permission check failed")
+			} else if !satisfied {
+				return RejectWebSocket(c, WSCloseForbidden, "this workspace requires two-factor authentication")
+			}
+			identity.WorkspaceID = meeting.WorkspaceID
+		}
+	}
+
+	c.Locals("roomIdentity", identity)
+	return c.Next()
+}
+
+// identify 입장 토큰 클레임(RequireJoinToken이 설정) 또는 티켓/Authorization 헤더/쿠키(OptionalWSAuth가 설정)에서 사용자 확인
+func (h *RoomIdentityHandler) identify(c *fiber.Ctx) (userID int64, nickname string, ok bool) {
+	if claims, ok := c.Locals("joinClaims").(*auth.JoinClaims); ok {
+		return claims.UserID, claims.Nickname, true
+	}
+	if claims, ok := c.Locals("claims").(*auth.Claims); ok {
+		return claims.UserID, claims.Nickname, true
+	}
+	return 0, "", false
+}
+
+// lookupRoomMeeting Room ID("meeting-{id}" 또는 미팅 코드)로 미팅 조회
+// 미팅 코드와 일치하는 미팅이 없으면 임시 Room으로 보고 nil, nil 반환
+func lookupRoomMeeting(db *gorm.DB, roomID string) (*model.Meeting, error) {
+	var meeting model.Meeting
+	query := db.Select("id, workspace_id, host_id, status")
+	if strings.HasPrefix(roomID, "meeting-") {
+		query = query.Where("id = ?", strings.TrimPrefix(roomID, "meeting-"))
+	} else {
+		query = query.Where("code = ?", roomID)
+	}
+
+	if err := query.First(&meeting).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) && !strings.HasPrefix(roomID, "meeting-") {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &meeting, nil
+}
+
+// =============================================================================
+// Speaker verification - speaker IDs in audio frames must be real room members
+// =============================================================================
+
+// speakerIdentity is a speaker ID checked against the users table and workspace membership
+type speakerIdentity struct {
+	allowed    bool
+	nickname   string
+	profileImg string
+	checkedAt  time.Time
+}
+
+// roomSpeakerIdentities caches speaker verification results for a room
+type roomSpeakerIdentities struct {
+	mu      sync.Mutex
+	entries map[string]*speakerIdentity
+}
+
+// verifySpeaker reports whether audio tagged with speakerID may enter the room and returns the
+// nickname/profile stored for that user. Client-supplied names are not trusted once verification is on.
+// Returns nil identity (and true) when verification is disabled, so callers keep the legacy behaviour.
+func (r *Room) verifySpeaker(speakerID string) (*speakerIdentity, bool) {
+	if r.hub.db == nil || r.hub.cfg == nil || !r.hub.cfg.Auth.RequireRoomIdentity || isProbeRoom(r.ID) {
+		return nil, true
+	}
+
+	cache := &r.identities
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	if entry, ok := cache.entries[speakerID]; ok && time.Since(entry.checkedAt) < speakerIdentityTTL {
+		return entry, entry.allowed
+	}
+
+	entry := r.loadSpeakerIdentity(speakerID)
+	if cache.entries == nil {
+		cache.entries = make(map[string]*speakerIdentity)
+	}
+	cache.entries[speakerID] = entry
+	if !entry.allowed {
+		log.Printf("[Room %s] 🚫 Rejected unverified speaker ID %q", r.ID, speakerID)
+	}
+	return entry, entry.allowed
+}
+
+// loadSpeakerIdentity checks that the speaker is a user who may send media in the room's workspace
+func (r *Room) loadSpeakerIdentity(speakerID string) *speakerIdentity {
+	entry := &speakerIdentity{checkedAt: time.Now()}
+
+	userID, err := strconv.ParseInt(speakerID, 10, 64)
+	if err != nil {
+		return entry
+	}
+
+	var user model.User
+	if err := r.hub.db.Select("id, nickname, profile_img").First(&user, userID).Error; err != nil {
+		return entry
+	}
+
+	meeting, err := lookupRoomMeeting(r.hub.db, r.ID)
+	if err != nil {
+		return entry
+	}
+	if meeting != nil && meeting.WorkspaceID != nil {
+		hasPermission, err := auth.CheckChannelPermission(r.hub.db, *meeting.WorkspaceID, meeting.ID, userID, "CONNECT_MEDIA")
+		if err != nil || !hasPermission {
+			return entry
+		}
+	}
+
+	entry.allowed = true
+	entry.nickname = user.Nickname
+	if user.ProfileImg != nil {
+		entry.profileImg = *user.ProfileImg
+	}
+	return entry
+}
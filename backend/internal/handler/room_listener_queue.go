@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"github.com/gofiber/contrib/websocket"
+
+	"realtime-backend/internal/metrics"
+)
+
+const (
+	// listenerQueueSize is how many outgoing frames a listener may have pending
+	listenerQueueSize = 256
+	// listenerSaturationLimit disconnects a listener whose queue stays full this long
+	listenerSaturationLimit = 5 * time.Second
+	// listenerWriteTimeout fails a single write to a stalled connection
+	listenerWriteTimeout = 5 * time.Second
+)
+
+// roomListenerEvictionsTotal counts listeners disconnected for not keeping up
+var roomListenerEvictionsTotal = metrics.NewCounterVec("eum_room_listener_evictions_total",
+	"Room listeners disconnected because their send queue stayed full", "reason")
+
+// listenerFrame is one encoded outgoing WebSocket message
+type listenerFrame struct {
+	messageType int
+	data        []byte
+	audio       bool // May be dropped in favour of newer frames when the queue is full
+}
+
+// listenerQueue decouples a listener's writes from the room broadcaster, so one slow client
+// only delays and drops its own frames. When full, the oldest audio frame is dropped first.
+type listenerQueue struct {
+	mu             sync.Mutex
+	frames         []listenerFrame
+	saturatedSince time.Time // Zero while the queue has room
+	closed         bool
+	wake           chan struct{}
+	done           chan struct{}
+}
+
+func newListenerQueue() *listenerQueue {
+	return &listenerQueue{
+		frames: make([]listenerFrame, 0, 16),
+		wake:   make(chan struct{}, 1),
+		done:   make(chan struct{}),
+	}
+}
+
+// push queues a frame and reports whether the listener has been saturated for too long
+func (q *listenerQueue) push(frame listenerFrame) (evict bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return false
+	}
+
+	if len(q.frames) >= listenerQueueSize {
+		drop := 0
+		for i, f := range q.frames {
+			if f.audio {
+				drop = i
+				break
+			}
+		}
+		if q.frames[drop].audio {
+			roomDroppedTotal.Inc("listener_audio")
+		} else {
+			roomDroppedTotal.Inc("listener")
+		}
+		q.frames = append(q.frames[:drop], q.frames[drop+1:]...)
+
+		now := time.Now()
+		if q.saturatedSince.IsZero() {
+			q.saturatedSince = now
+		}
+		evict = now.Sub(q.saturatedSince) > listenerSaturationLimit
+	} else {
+		q.saturatedSince = time.Time{}
+	}
+
+	q.frames = append(q.frames, frame)
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+	return evict
+}
+
+// take removes and returns all pending frames
+func (q *listenerQueue) take() []listenerFrame {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	frames := q.frames
+	q.frames = make([]listenerFrame, 0, 16)
+	return frames
+}
+
+// close stops the writer; pending frames are discarded
+func (q *listenerQueue) close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if !q.closed {
+		q.closed = true
+		close(q.done)
+	}
+}
+
+// sendText queues a text message to the listener (e.g. a control reply from the read loop)
+func (l *Listener) sendText(data []byte) {
+	l.queue.push(listenerFrame{messageType: websocket.TextMessage, data: data})
+}
+
+// enqueue queues a frame and disconnects the listener if it stopped keeping up
+func (r *Room) enqueue(listener *Listener, frame listenerFrame) {
+	if !listener.queue.push(frame) {
+		return
+	}
+
+	listener.queue.close()
+	roomListenerEvictionsTotal.Inc("saturated")
+	log.Printf("[Room %s] Disconnecting slow listener %s (queue full for %s)",
+		r.ID, listener.ID, listenerSaturationLimit)
+	// The read loop notices the closed connection and removes the listener
+	go closeWS(listener.Conn, WSCloseSlowClient, "client too slow")
+}
+
+// runListenerWriter is the only goroutine writing data frames to the listener's connection
+func (r *Room) runListenerWriter(listener *Listener) {
+	q := listener.queue
+	for {
+		select {
+		case <-r.ctx.Done():
+			return
+		case <-q.done:
+			return
+		case <-q.wake:
+		}
+
+		for _, frame := range q.take() {
+			listener.Conn.SetWriteDeadline(time.Now().Add(listenerWriteTimeout))
+			if err := listener.Conn.WriteMessage(frame.messageType, frame.data); err != nil {
+				log.Printf("[Room %s] Failed to send to listener %s: %v", r.ID, listener.ID, err)
+				q.close()
+				listener.Conn.Close()
+				return
+			}
+		}
+	}
+}
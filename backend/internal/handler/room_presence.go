@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"log"
+	"time"
+
+	"realtime-backend/internal/presence"
+)
+
+// inCallActivityTTL bounds how long a user stays "in a call" if the leave is
+// never observed (instance crash, token issued but never used). Normal leaves
+// clear the activity right away.
+const inCallActivityTTL = 4 * time.Hour
+
+// SetPresenceManager lets the hub mark verified listeners as IN_MEETING.
+func (h *RoomHub) SetPresenceManager(pm *presence.Manager) {
+	h.presence = pm
+}
+
+// markInCall sets IN_MEETING for a verified listener. Anonymous listeners
+// (no roomIdentity) have no user to attach the activity to.
+func (h *RoomHub) markInCall(roomID string, identity *roomIdentity) {
+	if h.presence == nil || identity == nil {
+		return
+	}
+	if err := h.presence.SetActivity(identity.UserID, presence.ActivityInMeeting, roomID, inCallActivityTTL); err != nil {
+		log.Printf("⚠️ [Room %s] Failed to mark user %d in call: %v", roomID, identity.UserID, err)
+	}
+}
+
+// clearInCall drops the activity set for this room. Activity set from another
+// room (the user already moved on) is left alone.
+func (h *RoomHub) clearInCall(roomID string, identity *roomIdentity) {
+	if h.presence == nil || identity == nil {
+		return
+	}
+	if err := h.presence.ClearActivity(identity.UserID, roomID); err != nil {
+		log.Printf("⚠️ [Room %s] Failed to clear in-call activity for user %d: %v", roomID, identity.UserID, err)
+	}
+}
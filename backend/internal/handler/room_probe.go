@@ -0,0 +1,30 @@
+package handler
+
+import (
+	"fmt"
+	"strings"
+)
+
+// probeRoomPrefix marks rooms opened by the synthetic room-pipeline probe (see probe.go).
+// They go through the regular listener/broadcast path, but never reach Transcribe,
+// Translate or the AI server: audio is answered with a mock transcript instead.
+const probeRoomPrefix = "probe-"
+
+func isProbeRoom(roomID string) bool {
+	return strings.HasPrefix(roomID, probeRoomPrefix)
+}
+
+// processAudioProbe answers each audio chunk with a synthetic final transcript.
+// Nothing is saved, so probes leave no trace in Redis or voice_records.
+func (r *Room) processAudioProbe(msg *AudioMessage) {
+	r.Broadcast(&BroadcastMessage{
+		Type:      "transcript",
+		SpeakerID: msg.SpeakerID,
+		Data: TranscriptData{
+			ParticipantID: msg.SpeakerID,
+			Original:      fmt.Sprintf("probe: received %d bytes of audio", len(msg.AudioData)),
+			IsFinal:       true,
+			Language:      msg.SourceLang,
+		},
+	})
+}
@@ -0,0 +1,218 @@
+package handler
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// resumeReplayWindow is how much recent audio per speaker is kept for replay after a resume
+	resumeReplayWindow = 3 * time.Second
+	// resumeReplayBytes bounds the replay buffer (16kHz 16-bit mono PCM)
+	resumeReplayBytes = int(resumeReplayWindow/time.Second) * 16000 * 2
+)
+
+// audioTail is the most recent audio of one speaker, oldest frame first
+type audioTail struct {
+	frames [][]byte
+	size   int
+}
+
+func (t *audioTail) push(frame []byte) {
+	t.frames = append(t.frames, append([]byte(nil), frame...))
+	t.size += len(frame)
+	for t.size > resumeReplayBytes && len(t.frames) > 1 {
+		t.size -= len(t.frames[0])
+		t.frames = t.frames[1:]
+	}
+}
+
+// resumeSession is one /ws/room connection's speaker state, kept for a short window after the
+// connection drops so a reconnect with its token reattaches to the same Transcribe streams.
+// Sessions live in memory, so resuming needs the reconnect to reach the same instance.
+type resumeSession struct {
+	token      string
+	listenerID string
+	speakers   map[string]string     // speaker ID → source language fed by this connection
+	tails      map[string]*audioTail // speaker ID → recent audio for replay
+	connected  bool
+	expiry     *time.Timer // set while suspended
+}
+
+// roomResume tracks resumable connections of a room
+type roomResume struct {
+	mu       sync.Mutex
+	sessions map[string]*resumeSession // token → session
+	feeders  map[string]string         // speaker ID → token of the session that fed it last
+}
+
+// openResumeSession reattaches to a suspended session when token belongs to this listener,
+// otherwise starts a new session. Reports whether an existing session was resumed.
+func (r *Room) openResumeSession(token, listenerID string) (*resumeSession, bool) {
+	rs := &r.resume
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	if rs.sessions == nil {
+		rs.sessions = make(map[string]*resumeSession)
+		rs.feeders = make(map[string]string)
+	}
+
+	if sess, ok := rs.sessions[token]; ok && token != "" && sess.listenerID == listenerID && !sess.connected {
+		if sess.expiry != nil {
+			sess.expiry.Stop()
+			sess.expiry = nil
+		}
+		sess.connected = true
+		return sess, true
+	}
+
+	sess := &resumeSession{
+		token:      uuid.New().String(),
+		listenerID: listenerID,
+		speakers:   make(map[string]string),
+		tails:      make(map[string]*audioTail),
+		connected:  true,
+	}
+	rs.sessions[sess.token] = sess
+	return sess, false
+}
+
+// noteSpeakerAudio records that the session fed audio for a speaker
+func (r *Room) noteSpeakerAudio(sess *resumeSession, speakerID, sourceLang string, audioData []byte) {
+	rs := &r.resume
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	sess.speakers[speakerID] = sourceLang
+	rs.feeders[speakerID] = sess.token
+
+	tail := sess.tails[speakerID]
+	if tail == nil {
+		tail = &audioTail{}
+		sess.tails[speakerID] = tail
+	}
+	tail.push(audioData)
+}
+
+// forgetSpeakerResume drops a speaker that left explicitly (speaker_leave)
+func (r *Room) forgetSpeakerResume(sess *resumeSession, speakerID string) {
+	rs := &r.resume
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	delete(sess.speakers, speakerID)
+	delete(sess.tails, speakerID)
+	if rs.feeders[speakerID] == sess.token {
+		delete(rs.feeders, speakerID)
+	}
+}
+
+// suspendResumeSession keeps the session's speakers and their streams for window after the
+// connection dropped. Without a window the session is simply forgotten.
+func (r *Room) suspendResumeSession(sess *resumeSession, window time.Duration) {
+	rs := &r.resume
+	rs.mu.Lock()
+	sess.connected = false
+	if window <= 0 {
+		delete(rs.sessions, sess.token)
+		rs.mu.Unlock()
+		return
+	}
+	speakers := make(map[string]string, len(sess.speakers))
+	for speakerID, lang := range sess.speakers {
+		speakers[speakerID] = lang
+	}
+	token := sess.token
+	sess.expiry = time.AfterFunc(window, func() { r.expireResumeSession(token) })
+	rs.mu.Unlock()
+
+	r.mu.RLock()
+	pipeline := r.awsPipeline
+	r.mu.RUnlock()
+	if pipeline != nil {
+		until := time.Now().Add(window)
+		for speakerID, lang := range speakers {
+			pipeline.HoldSpeakerStream(speakerID, lang, until)
+		}
+	}
+	log.Printf("[Room %s] Listener %s suspended with %d speakers (resumable for %s)",
+		r.ID, sess.listenerID, len(speakers), window)
+}
+
+// expireResumeSession removes speakers that only the expired session was feeding
+func (r *Room) expireResumeSession(token string) {
+	rs := &r.resume
+	rs.mu.Lock()
+	sess, ok := rs.sessions[token]
+	if !ok || sess.connected {
+		rs.mu.Unlock()
+		return
+	}
+	delete(rs.sessions, token)
+
+	var orphaned []string
+	for speakerID := range sess.speakers {
+		if rs.feeders[speakerID] == token {
+			delete(rs.feeders, speakerID)
+			orphaned = append(orphaned, speakerID)
+		}
+	}
+	rs.mu.Unlock()
+
+	// A shut down room may have been replaced by a new one with the same ID
+	if r.ctx.Err() != nil {
+		return
+	}
+	for _, speakerID := range orphaned {
+		r.RemoveSpeaker(speakerID)
+	}
+	if len(orphaned) > 0 {
+		log.Printf("[Room %s] Resume window of listener %s expired, removed %d speakers",
+			r.ID, sess.listenerID, len(orphaned))
+	}
+}
+
+// replayResumedAudio releases the stream holds of a resumed session. Speakers whose stream was
+// closed anyway get their recent audio replayed, so an utterance cut by the drop is not lost.
+func (r *Room) replayResumedAudio(sess *resumeSession) {
+	type replay struct {
+		speakerID, lang string
+		frames          [][]byte
+	}
+
+	rs := &r.resume
+	rs.mu.Lock()
+	replays := make([]replay, 0, len(sess.speakers))
+	for speakerID, lang := range sess.speakers {
+		var frames [][]byte
+		if tail := sess.tails[speakerID]; tail != nil {
+			frames = tail.frames
+		}
+		replays = append(replays, replay{speakerID: speakerID, lang: lang, frames: frames})
+	}
+	rs.mu.Unlock()
+
+	r.mu.RLock()
+	pipeline := r.awsPipeline
+	r.mu.RUnlock()
+
+	replayed := 0
+	for _, rp := range replays {
+		if pipeline != nil {
+			pipeline.HoldSpeakerStream(rp.speakerID, rp.lang, time.Time{})
+			if pipeline.HasSpeakerStream(rp.speakerID, rp.lang) {
+				continue
+			}
+		}
+		for _, frame := range rp.frames {
+			r.SendAudio(rp.speakerID, rp.lang, frame)
+		}
+		replayed++
+	}
+	log.Printf("[Room %s] Listener %s resumed (%d speakers, %d replayed)",
+		r.ID, sess.listenerID, len(replays), replayed)
+}
@@ -0,0 +1,206 @@
+package handler
+
+import (
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+
+	"realtime-backend/internal/cache"
+	"realtime-backend/internal/errorreport"
+	"realtime-backend/internal/model"
+)
+
+const (
+	transcriptBatchSize     = 50
+	transcriptFlushInterval = 2 * time.Second
+	transcriptQueueSize     = 1000
+)
+
+// pendingTranscript is a final transcript waiting to be written to voice_records
+type pendingTranscript struct {
+	roomID     string
+	transcript cache.RoomTranscript
+}
+
+// transcriptWriter persists final transcripts to Postgres asynchronously, in batches.
+// Redis keeps serving the live transcript list; this makes meetings survive Redis eviction.
+type transcriptWriter struct {
+	db    *gorm.DB
+	queue chan pendingTranscript
+	flush chan chan struct{}
+	done  chan struct{}
+	once  sync.Once
+
+	meetingMu  sync.Mutex
+	meetingIDs map[string]int64 // room ID -> meeting ID (0 = no meeting behind the room)
+}
+
+func newTranscriptWriter(db *gorm.DB) *transcriptWriter {
+	w := &transcriptWriter{
+		db:         db,
+		queue:      make(chan pendingTranscript, transcriptQueueSize),
+		flush:      make(chan chan struct{}),
+		done:       make(chan struct{}),
+		meetingIDs: make(map[string]int64),
+	}
+	go w.run()
+	return w
+}
+
+// Enqueue schedules a final transcript for persistence (non-blocking)
+func (w *transcriptWriter) Enqueue(roomID string, t cache.RoomTranscript) {
+	if !t.IsFinal {
+		return
+	}
+	if t.Timestamp.IsZero() {
+		t.Timestamp = time.Now()
+	}
+
+	select {
+	case w.queue <- pendingTranscript{roomID: roomID, transcript: t}:
+	default:
+		roomDroppedTotal.Inc("transcript_writer")
+		log.Printf("[TranscriptWriter] Queue full, dropping transcript for room %s", roomID)
+	}
+}
+
+// Flush writes everything queued so far and waits until it is stored
+func (w *transcriptWriter) Flush() {
+	ack := make(chan struct{})
+	select {
+	case w.flush <- ack:
+		<-ack
+	case <-w.done:
+	}
+}
+
+// Close flushes pending transcripts and stops the writer
+func (w *transcriptWriter) Close() {
+	w.once.Do(func() {
+		w.Flush()
+		close(w.done)
+	})
+}
+
+// ForgetRoom drops the cached meeting lookup of a closed room
+func (w *transcriptWriter) ForgetRoom(roomID string) {
+	w.meetingMu.Lock()
+	delete(w.meetingIDs, roomID)
+	w.meetingMu.Unlock()
+}
+
+func (w *transcriptWriter) run() {
+	defer errorreport.Recover(errorreport.Context{Component: "room.transcript_writer"})
+
+	ticker := time.NewTicker(transcriptFlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]pendingTranscript, 0, transcriptBatchSize)
+	drain := func() {
+		for {
+			select {
+			case p := <-w.queue:
+				batch = append(batch, p)
+			default:
+				return
+			}
+		}
+	}
+
+	for {
+		select {
+		case <-w.done:
+			return
+		case p := <-w.queue:
+			batch = append(batch, p)
+			if len(batch) >= transcriptBatchSize {
+				w.write(batch)
+				batch = batch[:0]
+			}
+		case <-ticker.C:
+			if len(batch) > 0 {
+				w.write(batch)
+				batch = batch[:0]
+			}
+		case ack := <-w.flush:
+			drain()
+			if len(batch) > 0 {
+				w.write(batch)
+				batch = batch[:0]
+			}
+			close(ack)
+		}
+	}
+}
+
+func (w *transcriptWriter) write(batch []pendingTranscript) {
+	records := make([]model.VoiceRecord, 0, len(batch))
+	for _, p := range batch {
+		meetingID := w.meetingID(p.roomID)
+		if meetingID == 0 {
+			continue
+		}
+
+		t := p.transcript
+		record := model.VoiceRecord{
+			MeetingID:   &meetingID,
+			SpeakerName: t.SpeakerName,
+			Original:    t.Original,
+			CreatedAt:   t.Timestamp,
+		}
+		if t.SourceLang != "" {
+			sourceLang := t.SourceLang
+			record.SourceLang = &sourceLang
+		}
+		if t.Translated != "" {
+			translated := t.Translated
+			record.Translated = &translated
+		}
+		if t.TargetLang != "" {
+			targetLang := t.TargetLang
+			record.TargetLang = &targetLang
+		}
+		records = append(records, record)
+	}
+
+	if len(records) == 0 {
+		return
+	}
+
+	if err := w.db.CreateInBatches(&records, transcriptBatchSize).Error; err != nil {
+		log.Printf("[TranscriptWriter] Failed to save %d transcripts: %v", len(records), err)
+		return
+	}
+	log.Printf("[TranscriptWriter] Saved %d transcripts to database", len(records))
+}
+
+// meetingID resolves the meeting behind a room ID ("meeting-{id}" or a meeting code)
+func (w *transcriptWriter) meetingID(roomID string) int64 {
+	w.meetingMu.Lock()
+	defer w.meetingMu.Unlock()
+
+	if id, ok := w.meetingIDs[roomID]; ok {
+		return id
+	}
+
+	var meeting model.Meeting
+	var err error
+	if strings.HasPrefix(roomID, "meeting-") {
+		err = w.db.Select("id").Where("id = ?", strings.TrimPrefix(roomID, "meeting-")).First(&meeting).Error
+	} else {
+		err = w.db.Select("id").Where("code = ?", roomID).First(&meeting).Error
+	}
+	if err == gorm.ErrRecordNotFound {
+		log.Printf("[TranscriptWriter] Meeting not found for room %s, transcripts not persisted", roomID)
+	} else if err != nil {
+		// Transient DB error: not cached, so later transcripts retry the lookup
+		log.Printf("[TranscriptWriter] Failed to resolve meeting for room %s: %v", roomID, err)
+		return 0
+	}
+
+	w.meetingIDs[roomID] = meeting.ID
+	return meeting.ID
+}
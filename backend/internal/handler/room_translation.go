@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"log"
+	"time"
+
+	awsai "realtime-backend/internal/aws"
+	"realtime-backend/internal/model"
+)
+
+// roomEventTranslationSettings tells peers to reload a room's translation settings from the database
+const roomEventTranslationSettings = "translation_settings"
+
+// translationSettingsFromModel converts a stored row into pipeline settings
+func translationSettingsFromModel(s *model.RoomTranslationSetting) awsai.TranslationSettings {
+	settings := awsai.TranslationSettings{
+		TTSEnabled:      s.TTSEnabled,
+		PartialInterval: time.Duration(s.PartialIntervalMs) * time.Millisecond,
+		MinConfidence:   float32(s.MinConfidence),
+	}
+	if s.AllowedTargetLangs != "" {
+		settings.AllowedTargets = splitLangList(s.AllowedTargetLangs)
+	}
+	return settings
+}
+
+// loadTranslationSettings reads the room's stored settings (defaults when none are stored)
+func (r *Room) loadTranslationSettings() awsai.TranslationSettings {
+	if r.hub.db == nil {
+		return awsai.DefaultTranslationSettings()
+	}
+
+	var setting model.RoomTranslationSetting
+	if err := r.hub.db.Where("room_id = ?", r.ID).Limit(1).Find(&setting).Error; err != nil {
+		log.Printf("[Room %s] Failed to load translation settings: %v", r.ID, err)
+		return awsai.DefaultTranslationSettings()
+	}
+	if setting.RoomID == "" {
+		return awsai.DefaultTranslationSettings()
+	}
+	return translationSettingsFromModel(&setting)
+}
+
+// reloadTranslationSettings refreshes the settings from the database and applies them
+func (r *Room) reloadTranslationSettings() {
+	r.applyTranslationSettings(r.loadTranslationSettings())
+}
+
+// applyTranslationSettings swaps the room's settings and pushes them into a running AWS pipeline.
+// The gRPC backend has no per-room knobs, so TTS and allowed targets are enforced at broadcast.
+func (r *Room) applyTranslationSettings(settings awsai.TranslationSettings) {
+	r.mu.Lock()
+	r.translation = settings
+	pipeline := r.awsPipeline
+	r.mu.Unlock()
+
+	if pipeline != nil {
+		pipeline.ApplySettings(settings)
+	}
+}
+
+// translationSettings returns the room's current translation settings
+func (r *Room) translationSettings() awsai.TranslationSettings {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.translation
+}
+
+// languagePolicy returns the default/allowed target languages of the room's workspace.
+// Rooms without a workspace (or when the lookup fails) allow every language.
+func (r *Room) languagePolicy() workspaceLanguagePolicy {
+	if r.hub.db == nil {
+		return workspaceLanguagePolicy{}
+	}
+
+	meeting, err := lookupRoomMeeting(r.hub.db, r.ID)
+	if err != nil || meeting == nil || meeting.WorkspaceID == nil {
+		return workspaceLanguagePolicy{}
+	}
+
+	setting, err := loadWorkspaceLanguageSetting(r.hub.db, *meeting.WorkspaceID)
+	if err != nil {
+		log.Printf("[Room %s] Failed to load workspace language settings: %v", r.ID, err)
+		return workspaceLanguagePolicy{}
+	}
+	return languagePolicyFromModel(setting)
+}
+
+// ApplyTranslationSettings applies new settings to the room on this instance (if open)
+// and asks other instances hosting the room to reload them
+func (h *RoomHub) ApplyTranslationSettings(roomID string, settings awsai.TranslationSettings) {
+	h.mu.RLock()
+	room, exists := h.rooms[roomID]
+	h.mu.RUnlock()
+
+	if exists {
+		room.applyTranslationSettings(settings)
+		log.Printf("[Room %s] Applied translation settings (tts: %v, partialInterval: %s, minConfidence: %.2f, allowed: %v)",
+			roomID, settings.TTSEnabled, settings.PartialInterval, settings.MinConfidence, settings.AllowedTargets)
+	}
+	h.publishRoomEvent(roomID, &roomEvent{Kind: roomEventTranslationSettings})
+}
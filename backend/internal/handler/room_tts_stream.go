@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"encoding/binary"
+	"sync"
+	"time"
+
+	"realtime-backend/internal/ai"
+)
+
+// Audio audiences: streaming listeners (?audioStream=1 or ?protocol=2) get every TTS chunk as a
+// framed binary message; legacy listeners keep receiving one raw MP3 blob per utterance.
+const (
+	audienceStreaming = "streaming"
+	audienceLegacy    = "legacy"
+)
+
+const (
+	// ttsFrameVersion is the first byte of every framed audio message
+	ttsFrameVersion = 1
+	// ttsFrameFlagLast marks the last chunk of a transcript's audio
+	ttsFrameFlagLast = 1 << 0
+	// ttsAssemblyTTL drops half-assembled audio whose last chunk never arrived
+	ttsAssemblyTTL = 30 * time.Second
+	// ttsAssemblyMaxBytes bounds one assembled utterance
+	ttsAssemblyMaxBytes = 4 << 20
+)
+
+// ttsAssemblyEntry is the audio received so far for one transcript and language
+type ttsAssemblyEntry struct {
+	data      []byte
+	updatedAt time.Time
+}
+
+// roomTTSAssembly joins streamed TTS chunks back into whole blobs for legacy listeners
+type roomTTSAssembly struct {
+	mu      sync.Mutex
+	entries map[string]*ttsAssemblyEntry // transcript ID + "|" + language → audio so far
+}
+
+// handleStreamedAudio forwards one TTS chunk to streaming listeners right away and, once the
+// last chunk arrives, the assembled utterance to legacy listeners
+func (r *Room) handleStreamedAudio(audio *ai.AudioMessage) {
+	if len(audio.AudioData) > 0 || audio.Last {
+		r.Broadcast(&BroadcastMessage{
+			Type:         "audio",
+			SpeakerID:    audio.SpeakerParticipantID,
+			TargetLang:   audio.TargetLanguage,
+			AudioData:    audio.AudioData,
+			TranscriptID: audio.TranscriptID,
+			Sequence:     audio.Sequence,
+			Last:         audio.Last,
+			Audience:     audienceStreaming,
+		})
+	}
+
+	assembled := r.assembleTTS(audio)
+	if len(assembled) == 0 {
+		return
+	}
+	r.Broadcast(&BroadcastMessage{
+		Type:         "audio",
+		SpeakerID:    audio.SpeakerParticipantID,
+		TargetLang:   audio.TargetLanguage,
+		AudioData:    assembled,
+		TranscriptID: audio.TranscriptID,
+		Last:         true,
+		Audience:     audienceLegacy,
+	})
+}
+
+// assembleTTS appends a chunk and returns the whole audio when the chunk is the last one
+func (r *Room) assembleTTS(audio *ai.AudioMessage) []byte {
+	a := &r.ttsAssembly
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	now := time.Now()
+	if a.entries == nil {
+		a.entries = make(map[string]*ttsAssemblyEntry)
+	}
+	for key, entry := range a.entries {
+		if now.Sub(entry.updatedAt) > ttsAssemblyTTL {
+			delete(a.entries, key)
+		}
+	}
+
+	key := audio.TranscriptID + "|" + audio.TargetLanguage
+	entry := a.entries[key]
+	if entry == nil {
+		entry = &ttsAssemblyEntry{}
+		a.entries[key] = entry
+	}
+	if len(entry.data)+len(audio.AudioData) <= ttsAssemblyMaxBytes {
+		entry.data = append(entry.data, audio.AudioData...)
+	}
+	entry.updatedAt = now
+
+	if !audio.Last {
+		return nil
+	}
+	delete(a.entries, key)
+	return entry.data
+}
+
+// acceptsAudience reports whether an audio message is meant for this listener
+func (l *Listener) acceptsAudience(audience string) bool {
+	switch audience {
+	case audienceStreaming:
+		return l.streamsAudio()
+	case audienceLegacy:
+		return !l.streamsAudio()
+	default:
+		return true
+	}
+}
+
+// encodeTTSFrame wraps audio for streaming listeners:
+//
+//	[version:1][flags:1][sequence:4, big endian][transcript ID length:1][transcript ID][MP3 bytes]
+//
+// Whole blobs (gRPC backend, cache hits) go out as sequence 0 with the last flag set.
+func encodeTTSFrame(msg *BroadcastMessage) []byte {
+	transcriptID := msg.TranscriptID
+	if len(transcriptID) > 255 {
+		transcriptID = transcriptID[:255]
+	}
+
+	var flags byte
+	if msg.Last || msg.Audience == "" {
+		flags |= ttsFrameFlagLast
+	}
+
+	frame := make([]byte, 0, 7+len(transcriptID)+len(msg.AudioData))
+	frame = append(frame, ttsFrameVersion, flags)
+	frame = binary.BigEndian.AppendUint32(frame, msg.Sequence)
+	frame = append(frame, byte(len(transcriptID)))
+	frame = append(frame, transcriptID...)
+	return append(frame, msg.AudioData...)
+}
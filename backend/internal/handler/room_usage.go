@@ -0,0 +1,285 @@
+package handler
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	awsai "realtime-backend/internal/aws"
+	"realtime-backend/internal/errorreport"
+	"realtime-backend/internal/model"
+)
+
+const (
+	// aiUsageFlushInterval is how often metered usage is added to the daily aggregates
+	aiUsageFlushInterval = 30 * time.Second
+	// aiUsageCapRefresh is how long a workspace's cap and month-to-date usage are cached.
+	// Limits changed on another instance apply here within this interval.
+	aiUsageCapRefresh = time.Minute
+	aiUsageDateLayout = "2006-01-02"
+)
+
+// aiUsageKey is one row of ai_usage_daily
+type aiUsageKey struct {
+	workspaceID int64
+	roomID      string
+	day         string // UTC date
+}
+
+// aiUsageAmounts holds usage per kind
+type aiUsageAmounts struct {
+	transcribeSeconds float64
+	translateChars    float64
+	ttsChars          float64
+}
+
+func (a *aiUsageAmounts) add(kind awsai.UsageKind, amount float64) {
+	switch kind {
+	case awsai.UsageTranscribeSeconds:
+		a.transcribeSeconds += amount
+	case awsai.UsageTranslateChars:
+		a.translateChars += amount
+	case awsai.UsageTTSChars:
+		a.ttsChars += amount
+	}
+}
+
+func (a *aiUsageAmounts) get(kind awsai.UsageKind) float64 {
+	switch kind {
+	case awsai.UsageTranscribeSeconds:
+		return a.transcribeSeconds
+	case awsai.UsageTranslateChars:
+		return a.translateChars
+	case awsai.UsageTTSChars:
+		return a.ttsChars
+	}
+	return 0
+}
+
+// aiUsageCap is a workspace's cached monthly limit and usage
+type aiUsageCap struct {
+	limit     model.WorkspaceAIUsageLimit
+	month     string         // "2006-01" the usage below belongs to
+	stored    aiUsageAmounts // month-to-date usage in the database when loaded
+	sinceLoad aiUsageAmounts // usage not in stored (pending at load time + recorded since)
+	loadedAt  time.Time
+}
+
+func (c *aiUsageCap) limitFor(kind awsai.UsageKind) int64 {
+	switch kind {
+	case awsai.UsageTranscribeSeconds:
+		return c.limit.MonthlyTranscribeSeconds
+	case awsai.UsageTranslateChars:
+		return c.limit.MonthlyTranslateChars
+	case awsai.UsageTTSChars:
+		return c.limit.MonthlyTTSChars
+	}
+	return 0
+}
+
+// aiUsageTracker meters AWS AI usage per room and workspace. Usage is summed in memory and
+// upserted into daily aggregates periodically; caps are checked against cached monthly totals.
+type aiUsageTracker struct {
+	db      *gorm.DB
+	mu      sync.Mutex
+	pending map[aiUsageKey]*aiUsageAmounts
+	caps    map[int64]*aiUsageCap
+	done    chan struct{}
+	stopped chan struct{}
+	once    sync.Once
+}
+
+func newAIUsageTracker(db *gorm.DB) *aiUsageTracker {
+	t := &aiUsageTracker{
+		db:      db,
+		pending: make(map[aiUsageKey]*aiUsageAmounts),
+		caps:    make(map[int64]*aiUsageCap),
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	go t.run()
+	return t
+}
+
+// Record adds usage (no database write)
+func (t *aiUsageTracker) Record(workspaceID int64, roomID string, kind awsai.UsageKind, amount float64) {
+	now := time.Now().UTC()
+	key := aiUsageKey{workspaceID: workspaceID, roomID: roomID, day: now.Format(aiUsageDateLayout)}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	amounts, ok := t.pending[key]
+	if !ok {
+		amounts = &aiUsageAmounts{}
+		t.pending[key] = amounts
+	}
+	amounts.add(kind, amount)
+
+	if c := t.caps[workspaceID]; c != nil && c.month == now.Format("2006-01") {
+		c.sinceLoad.add(kind, amount)
+	}
+}
+
+// Allowed reports whether the workspace is still under its monthly cap for kind
+func (t *aiUsageTracker) Allowed(workspaceID int64, kind awsai.UsageKind) bool {
+	if workspaceID == 0 {
+		return true
+	}
+	month := time.Now().UTC().Format("2006-01")
+
+	t.mu.Lock()
+	c := t.caps[workspaceID]
+	t.mu.Unlock()
+	if c == nil || c.month != month || time.Since(c.loadedAt) > aiUsageCapRefresh {
+		c = t.loadCap(workspaceID, month)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	limit := c.limitFor(kind)
+	return limit <= 0 || c.stored.get(kind)+c.sinceLoad.get(kind) < float64(limit)
+}
+
+// loadCap reads the workspace's limits and, if any is set, its month-to-date usage
+func (t *aiUsageTracker) loadCap(workspaceID int64, month string) *aiUsageCap {
+	c := &aiUsageCap{month: month, loadedAt: time.Now()}
+	t.db.Where("workspace_id = ?", workspaceID).Limit(1).Find(&c.limit)
+
+	if c.limit.MonthlyTranscribeSeconds > 0 || c.limit.MonthlyTranslateChars > 0 || c.limit.MonthlyTTSChars > 0 {
+		var sums struct {
+			TranscribeSeconds float64
+			TranslateChars    float64
+			TTSChars          float64
+		}
+		monthStart, _ := time.Parse("2006-01", month)
+		err := t.db.Model(&model.AIUsageDaily{}).
+			Select("COALESCE(SUM(transcribe_seconds), 0) AS transcribe_seconds, "+
+				"COALESCE(SUM(translate_chars), 0) AS translate_chars, COALESCE(SUM(tts_chars), 0) AS tts_chars").
+			Where("workspace_id = ? AND usage_date >= ?", workspaceID, monthStart).
+			Scan(&sums).Error
+		if err != nil {
+			log.Printf("[AIUsage] Failed to load usage of workspace %d: %v", workspaceID, err)
+		}
+		c.stored = aiUsageAmounts{
+			transcribeSeconds: sums.TranscribeSeconds,
+			translateChars:    sums.TranslateChars,
+			ttsChars:          sums.TTSChars,
+		}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	// Usage not yet flushed is not in stored
+	for key, amounts := range t.pending {
+		if key.workspaceID == workspaceID && key.day[:7] == month {
+			c.sinceLoad.transcribeSeconds += amounts.transcribeSeconds
+			c.sinceLoad.translateChars += amounts.translateChars
+			c.sinceLoad.ttsChars += amounts.ttsChars
+		}
+	}
+	t.caps[workspaceID] = c
+	return c
+}
+
+// Invalidate drops the workspace's cached cap so new limits apply on the next check
+func (t *aiUsageTracker) Invalidate(workspaceID int64) {
+	t.mu.Lock()
+	delete(t.caps, workspaceID)
+	t.mu.Unlock()
+}
+
+// Close saves pending usage and stops the tracker
+func (t *aiUsageTracker) Close() {
+	t.once.Do(func() {
+		close(t.done)
+		<-t.stopped
+	})
+}
+
+func (t *aiUsageTracker) run() {
+	defer close(t.stopped)
+	defer errorreport.Recover(errorreport.Context{Component: "room.ai_usage_tracker"})
+
+	ticker := time.NewTicker(aiUsageFlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-t.done:
+			t.Flush()
+			return
+		case <-ticker.C:
+			t.Flush()
+		}
+	}
+}
+
+// Flush adds pending usage to the daily aggregates
+func (t *aiUsageTracker) Flush() {
+	t.mu.Lock()
+	if len(t.pending) == 0 {
+		t.mu.Unlock()
+		return
+	}
+	pending := t.pending
+	t.pending = make(map[aiUsageKey]*aiUsageAmounts)
+	t.mu.Unlock()
+
+	rows := make([]model.AIUsageDaily, 0, len(pending))
+	for key, amounts := range pending {
+		day, _ := time.Parse(aiUsageDateLayout, key.day)
+		rows = append(rows, model.AIUsageDaily{
+			WorkspaceID:       key.workspaceID,
+			RoomID:            key.roomID,
+			UsageDate:         day,
+			TranscribeSeconds: amounts.transcribeSeconds,
+			TranslateChars:    int64(amounts.translateChars),
+			TTSChars:          int64(amounts.ttsChars),
+		})
+	}
+
+	err := t.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "workspace_id"}, {Name: "room_id"}, {Name: "usage_date"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"transcribe_seconds": gorm.Expr("ai_usage_daily.transcribe_seconds + excluded.transcribe_seconds"),
+			"translate_chars":    gorm.Expr("ai_usage_daily.translate_chars + excluded.translate_chars"),
+			"tts_chars":          gorm.Expr("ai_usage_daily.tts_chars + excluded.tts_chars"),
+			"updated_at":         gorm.Expr("excluded.updated_at"),
+		}),
+	}).CreateInBatches(&rows, 200).Error
+	if err != nil {
+		log.Printf("[AIUsage] Failed to save usage for %d rooms: %v", len(rows), err)
+	}
+}
+
+// roomUsageMeter is the AWS pipeline's UsageMeter for one room
+type roomUsageMeter struct {
+	tracker     *aiUsageTracker
+	workspaceID int64
+	roomID      string
+}
+
+func (m *roomUsageMeter) RecordUsage(kind awsai.UsageKind, amount float64) {
+	m.tracker.Record(m.workspaceID, m.roomID, kind, amount)
+}
+
+func (m *roomUsageMeter) UsageAllowed(kind awsai.UsageKind) bool {
+	return m.tracker.Allowed(m.workspaceID, kind)
+}
+
+// usageMeter meters the room's AWS pipeline against its workspace. The gRPC backend
+// does its own translation, so its usage is not AWS spend and is not metered here.
+func (r *Room) usageMeter() awsai.UsageMeter {
+	if r.hub.usage == nil {
+		return nil
+	}
+
+	var workspaceID int64
+	if meeting, err := lookupRoomMeeting(r.hub.db, r.ID); err == nil && meeting != nil && meeting.WorkspaceID != nil {
+		workspaceID = *meeting.WorkspaceID
+	}
+	return &roomUsageMeter{tracker: r.hub.usage, workspaceID: workspaceID, roomID: r.ID}
+}
@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"strings"
+	"sync"
+
+	awsai "realtime-backend/internal/aws"
+	"realtime-backend/internal/metrics"
+)
+
+var vadFramesTotal = metrics.NewCounterVec("eum_vad_frames_total",
+	"Speaker audio frames seen by the VAD gate before Transcribe", "result")
+
+// roomVAD holds one voice activity gate per speaker stream (speakerID:sourceLang)
+type roomVAD struct {
+	mu    sync.Mutex
+	gates map[string]*awsai.VAD
+}
+
+// vadAggressiveness returns the configured VAD level (0 = off)
+func (r *Room) vadAggressiveness() int {
+	if r.hub.cfg == nil {
+		return awsai.VADOff
+	}
+	return awsai.NormalizeVADAggressiveness(r.hub.cfg.AI.VADAggressiveness)
+}
+
+// gateAudio runs a speaker frame through the speaker's VAD and returns the frames to stream.
+// Silent frames are held back (a short pre-roll is replayed when speech starts), so muted or
+// quiet speakers stop costing Transcribe time and their streams idle out in the pipeline.
+func (r *Room) gateAudio(msg *AudioMessage) [][]byte {
+	level := r.vadAggressiveness()
+	if level == awsai.VADOff {
+		return [][]byte{msg.AudioData}
+	}
+
+	key := msg.SpeakerID + ":" + msg.SourceLang
+
+	r.vad.mu.Lock()
+	if r.vad.gates == nil {
+		r.vad.gates = make(map[string]*awsai.VAD)
+	}
+	gate, exists := r.vad.gates[key]
+	if !exists {
+		gate = awsai.NewVAD(level, 16000)
+		r.vad.gates[key] = gate
+	}
+	frames := gate.Process(msg.AudioData)
+	r.vad.mu.Unlock()
+
+	if len(frames) == 0 {
+		vadFramesTotal.Inc("suppressed")
+	} else {
+		vadFramesTotal.Add(float64(len(frames)), "forwarded")
+	}
+	return frames
+}
+
+// forgetSpeakerVAD drops the VAD state of a speaker that left the room
+func (r *Room) forgetSpeakerVAD(speakerID string) {
+	r.vad.mu.Lock()
+	defer r.vad.mu.Unlock()
+
+	for key := range r.vad.gates {
+		if strings.HasPrefix(key, speakerID+":") {
+			delete(r.vad.gates, key)
+		}
+	}
+}
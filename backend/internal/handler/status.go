@@ -0,0 +1,452 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+
+	"realtime-backend/internal/cache"
+	"realtime-backend/internal/model"
+	"realtime-backend/internal/storage"
+)
+
+// 상태 페이지 컴포넌트 상태 (심각한 순서가 뒤)
+const (
+	componentOperational   = "operational"
+	componentMaintenance   = "maintenance"
+	componentDegraded      = "degraded"
+	componentOutage        = "outage"
+	componentNotConfigured = "not_configured"
+)
+
+// statusComponents 공개 상태 페이지에 표시하는 컴포넌트 (표시 순서)
+var statusComponents = []struct{ name, label string }{
+	{"api", "API"},
+	{"realtime", "실시간 회의/채팅"},
+	{"translation", "실시간 번역"},
+	{"storage", "파일 저장소"},
+}
+
+const (
+	statusCacheTTL         = 30 * time.Second // 점검 결과 재사용 시간 (공개 엔드포인트 부하 제한)
+	statusCheckTimeout     = 2 * time.Second
+	statusResolvedLookback = 7 * 24 * time.Hour // 최근 해결된 장애 표시 기간
+	statusIncidentLimit    = 50
+)
+
+// StatusHandler 공개 상태 페이지 API + 장애 공지 관리
+type StatusHandler struct {
+	db        *gorm.DB
+	roomHub   *RoomHub
+	redis     *cache.RedisClient
+	s3        *storage.S3Registry
+	aiAddress string
+
+	mu       sync.Mutex
+	cached   *StatusResponse
+	cachedAt time.Time
+}
+
+// NewStatusHandler StatusHandler 생성 (roomHub, redis, s3는 nil 가능)
+func NewStatusHandler(db *gorm.DB, roomHub *RoomHub, redis *cache.RedisClient, s3 *storage.S3Registry, aiAddress string) *StatusHandler {
+	return &StatusHandler{db: db, roomHub: roomHub, redis: redis, s3: s3, aiAddress: aiAddress}
+}
+
+// ComponentStatus 컴포넌트 상태
+type ComponentStatus struct {
+	Name        string  `json:"name"`
+	Label       string  `json:"label"`
+	Status      string  `json:"status"`
+	IncidentIDs []int64 `json:"incident_ids,omitempty"` // 상태에 반영된 진행 중 장애
+}
+
+// StatusIncidentResponse 장애 공지 응답
+type StatusIncidentResponse struct {
+	ID         int64    `json:"id"`
+	Title      string   `json:"title"`
+	Message    string   `json:"message"`
+	Severity   string   `json:"severity"`
+	Components []string `json:"components"`
+	ResolvedAt *string  `json:"resolved_at,omitempty"`
+	CreatedAt  string   `json:"created_at"`
+	UpdatedAt  string   `json:"updated_at"`
+}
+
+// StatusResponse 공개 상태 페이지 응답
+type StatusResponse struct {
+	Status          string                   `json:"status"`
+	Components      []ComponentStatus        `json:"components"`
+	Incidents       []StatusIncidentResponse `json:"incidents"`        // 진행 중
+	RecentIncidents []StatusIncidentResponse `json:"recent_incidents"` // 최근 7일 내 해결
+	UpdatedAt       string                   `json:"updated_at"`
+}
+
+// StatusIncidentRequest 장애 공지 등록/수정 요청 (수정 시 보낸 필드만 반영)
+type StatusIncidentRequest struct {
+	Title      *string   `json:"title"`
+	Message    *string   `json:"message"`
+	Severity   *string   `json:"severity"`
+	Components *[]string `json:"components"`
+	Resolved   *bool     `json:"resolved"`
+}
+
+// GetStatus 컴포넌트 상태와 장애 공지 요약 (인증 불필요, 결과는 30초간 캐시)
+func (h *StatusHandler) GetStatus(c *fiber.Ctx) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.cached == nil || time.Since(h.cachedAt) >= statusCacheTTL {
+		h.cached = h.buildStatus(c.Context())
+		h.cachedAt = time.Now()
+	}
+
+	c.Set(fiber.HeaderCacheControl, fmt.Sprintf("public, max-age=%d", int(statusCacheTTL.Seconds())))
+	return c.JSON(h.cached)
+}
+
+// GetIncidents 장애 공지 목록 (플랫폼 관리자 전용, 최신순)
+func (h *StatusHandler) GetIncidents(c *fiber.Ctx) error {
+	if _, ok := requirePlatformAdmin(c, h.db); !ok {
+		return nil
+	}
+
+	var incidents []model.StatusIncident
+	h.db.Order("created_at DESC").Limit(statusIncidentLimit).Find(&incidents)
+
+	responses := make([]StatusIncidentResponse, len(incidents))
+	for i := range incidents {
+		responses[i] = toStatusIncidentResponse(&incidents[i])
+	}
+	return c.JSON(fiber.Map{
+		"incidents": responses,
+		"total":     len(responses),
+	})
+}
+
+// CreateIncident 장애 공지 등록 (플랫폼 관리자 전용)
+func (h *StatusHandler) CreateIncident(c *fiber.Ctx) error {
+	admin, ok := requirePlatformAdmin(c, h.db)
+	if !ok {
+		return nil
+	}
+
+	var req StatusIncidentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+	if req.Title == nil || req.Severity == nil || req.Components == nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "title, severity and components are required",
+		})
+	}
+
+	incident := model.StatusIncident{CreatedBy: admin.ID}
+	if err := applyStatusIncidentRequest(&incident, &req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	if err := h.db.Create(&incident).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to create incident",
+		})
+	}
+	h.invalidate()
+
+	return c.Status(fiber.StatusCreated).JSON(toStatusIncidentResponse(&incident))
+}
+
+// UpdateIncident 장애 공지 수정/해결 처리 (플랫폼 관리자 전용)
+func (h *StatusHandler) UpdateIncident(c *fiber.Ctx) error {
+	if _, ok := requirePlatformAdmin(c, h.db); !ok {
+		return nil
+	}
+
+	incidentID, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid incident id",
+		})
+	}
+
+	var req StatusIncidentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+
+	var incident model.StatusIncident
+	if err := h.db.First(&incident, incidentID).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "incident not found",
+		})
+	}
+
+	if err := applyStatusIncidentRequest(&incident, &req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	if err := h.db.Save(&incident).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to update incident",
+		})
+	}
+	h.invalidate()
+
+	return c.JSON(toStatusIncidentResponse(&incident))
+}
+
+// invalidate 장애 공지 변경을 상태 페이지에 바로 반영
+func (h *StatusHandler) invalidate() {
+	h.mu.Lock()
+	h.cached = nil
+	h.mu.Unlock()
+}
+
+// buildStatus 컴포넌트 점검 + 진행 중 장애 반영
+func (h *StatusHandler) buildStatus(ctx context.Context) *StatusResponse {
+	ctx, cancel := context.WithTimeout(ctx, statusCheckTimeout)
+	defer cancel()
+
+	checks := map[string]string{
+		"api":         h.checkAPI(ctx),
+		"realtime":    h.checkRealtime(ctx),
+		"translation": h.checkTranslation(),
+		"storage":     h.checkStorage(ctx),
+	}
+
+	var active, resolved []model.StatusIncident
+	h.db.Where("resolved_at IS NULL").Order("created_at DESC").Find(&active)
+	h.db.Where("resolved_at >= ?", time.Now().Add(-statusResolvedLookback)).
+		Order("resolved_at DESC").Limit(statusIncidentLimit).Find(&resolved)
+
+	resp := &StatusResponse{
+		Status:          componentOperational,
+		Components:      make([]ComponentStatus, 0, len(statusComponents)),
+		Incidents:       make([]StatusIncidentResponse, 0, len(active)),
+		RecentIncidents: make([]StatusIncidentResponse, 0, len(resolved)),
+		UpdatedAt:       time.Now().Format("2006-01-02T15:04:05Z07:00"),
+	}
+
+	for _, comp := range statusComponents {
+		status := ComponentStatus{Name: comp.name, Label: comp.label, Status: checks[comp.name]}
+		for i := range active {
+			if !slices.Contains(splitStatusComponents(active[i].Components), comp.name) {
+				continue
+			}
+			status.IncidentIDs = append(status.IncidentIDs, active[i].ID)
+			status.Status = worseComponentStatus(status.Status, incidentComponentStatus(active[i].Severity))
+		}
+		if status.Status != componentNotConfigured {
+			resp.Status = worseComponentStatus(resp.Status, status.Status)
+		}
+		resp.Components = append(resp.Components, status)
+	}
+
+	for i := range active {
+		resp.Incidents = append(resp.Incidents, toStatusIncidentResponse(&active[i]))
+	}
+	for i := range resolved {
+		resp.RecentIncidents = append(resp.RecentIncidents, toStatusIncidentResponse(&resolved[i]))
+	}
+	return resp
+}
+
+// checkAPI DB 연결 확인 (DB 없이는 API 대부분이 동작하지 않음)
+func (h *StatusHandler) checkAPI(ctx context.Context) string {
+	sqlDB, err := h.db.DB()
+	if err != nil || sqlDB.PingContext(ctx) != nil {
+		return componentOutage
+	}
+	return componentOperational
+}
+
+// checkRealtime 회의 Room 허브와 인스턴스 간 공유용 Redis 확인
+func (h *StatusHandler) checkRealtime(ctx context.Context) string {
+	if h.roomHub == nil {
+		return componentOutage
+	}
+	if h.redis != nil && h.redis.Health(ctx) != nil {
+		return componentDegraded // 단일 인스턴스 내 회의는 계속 동작
+	}
+	return componentOperational
+}
+
+// checkTranslation 음성 인식/번역 백엔드 확인 (회로 차단기가 열린 Room이 있으면 저하)
+func (h *StatusHandler) checkTranslation() string {
+	if h.roomHub == nil {
+		return componentNotConfigured
+	}
+
+	preferred := h.roomHub.preferredBackend()
+	if !h.roomHub.backendAvailable(preferred) {
+		if h.roomHub.fallbackEnabled() && h.roomHub.backendAvailable(otherBackend(preferred)) {
+			return componentDegraded
+		}
+		return componentOutage
+	}
+	if preferred == backendGRPC && h.aiAddress != "" {
+		conn, err := net.DialTimeout("tcp", h.aiAddress, statusCheckTimeout)
+		if err != nil {
+			return componentOutage
+		}
+		conn.Close()
+	}
+
+	for _, room := range h.roomHub.BackendStatuses() {
+		if room.Active == "" {
+			continue
+		}
+		if room.Breakers[room.Active].State == breakerOpen {
+			return componentDegraded
+		}
+	}
+	return componentOperational
+}
+
+// checkStorage 리전별 S3 버킷 접근 확인 (일부 리전만 실패하면 저하)
+func (h *StatusHandler) checkStorage(ctx context.Context) string {
+	if h.s3 == nil {
+		return componentNotConfigured
+	}
+
+	regions := h.s3.Regions()
+	failed := 0
+	for _, region := range regions {
+		svc, err := h.s3.ForRegion(region)
+		if err != nil || svc.Ping(ctx) != nil {
+			failed++
+		}
+	}
+	switch {
+	case failed == 0:
+		return componentOperational
+	case failed < len(regions):
+		return componentDegraded
+	default:
+		return componentOutage
+	}
+}
+
+// applyStatusIncidentRequest 요청 값 검증 후 반영
+func applyStatusIncidentRequest(incident *model.StatusIncident, req *StatusIncidentRequest) error {
+	if req.Title != nil {
+		title := strings.TrimSpace(*req.Title)
+		if title == "" || len(title) > 200 {
+			return fmt.Errorf("title must be 1 to 200 characters")
+		}
+		incident.Title = title
+	}
+	if req.Message != nil {
+		incident.Message = strings.TrimSpace(*req.Message)
+	}
+	if req.Severity != nil {
+		severity := model.IncidentSeverity(strings.ToUpper(*req.Severity))
+		if incidentComponentStatus(severity.String()) == "" {
+			return fmt.Errorf("severity must be one of MINOR, MAJOR, MAINTENANCE")
+		}
+		incident.Severity = severity.String()
+	}
+	if req.Components != nil {
+		components := make([]string, 0, len(*req.Components))
+		for _, name := range *req.Components {
+			if !isStatusComponent(name) {
+				return fmt.Errorf("unknown component: %s", name)
+			}
+			if !slices.Contains(components, name) {
+				components = append(components, name)
+			}
+		}
+		if len(components) == 0 {
+			return fmt.Errorf("at least one component is required")
+		}
+		incident.Components = strings.Join(components, ",")
+	}
+	if req.Resolved != nil {
+		if *req.Resolved && incident.ResolvedAt == nil {
+			now := time.Now()
+			incident.ResolvedAt = &now
+		} else if !*req.Resolved {
+			incident.ResolvedAt = nil
+		}
+	}
+	return nil
+}
+
+// incidentComponentStatus 장애 심각도에 해당하는 컴포넌트 상태 (알 수 없는 값이면 빈 문자열)
+func incidentComponentStatus(severity string) string {
+	switch model.IncidentSeverity(severity) {
+	case model.IncidentMinor:
+		return componentDegraded
+	case model.IncidentMajor:
+		return componentOutage
+	case model.IncidentMaintenance:
+		return componentMaintenance
+	}
+	return ""
+}
+
+func componentStatusRank(status string) int {
+	switch status {
+	case componentMaintenance:
+		return 1
+	case componentDegraded:
+		return 2
+	case componentOutage:
+		return 3
+	}
+	return 0
+}
+
+func worseComponentStatus(a, b string) string {
+	if componentStatusRank(b) > componentStatusRank(a) {
+		return b
+	}
+	return a
+}
+
+func isStatusComponent(name string) bool {
+	for _, comp := range statusComponents {
+		if comp.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func splitStatusComponents(list string) []string {
+	if list == "" {
+		return []string{}
+	}
+	return strings.Split(list, ",")
+}
+
+func toStatusIncidentResponse(i *model.StatusIncident) StatusIncidentResponse {
+	resp := StatusIncidentResponse{
+		ID:         i.ID,
+		Title:      i.Title,
+		Message:    i.Message,
+		Severity:   i.Severity,
+		Components: splitStatusComponents(i.Components),
+		CreatedAt:  i.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:  i.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	if i.ResolvedAt != nil {
+		t := i.ResolvedAt.Format("2006-01-02T15:04:05Z07:00")
+		resp.ResolvedAt = &t
+	}
+	return resp
+}
@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"gorm.io/gorm/clause"
+
+	"realtime-backend/internal/errorreport"
+	"realtime-backend/internal/model"
+)
+
+const (
+	statusExpirySweepInterval = time.Minute
+	statusExpirySweepBatch    = 500
+
+	// maxStatusClearAfter 상태 메시지 자동 삭제 시간 상한
+	maxStatusClearAfter = 30 * 24 * time.Hour
+)
+
+// parseStatusExpiry 상태 메시지 만료 시각 (expires_at: RFC3339, clear_after: "30m", "4h" 같은 기간)
+// 둘 다 비어 있으면 nil (만료 없음)
+func parseStatusExpiry(expiresAt, clearAfter string, now time.Time) (*time.Time, error) {
+	expiresAt = strings.TrimSpace(expiresAt)
+	clearAfter = strings.TrimSpace(clearAfter)
+
+	var t time.Time
+	switch {
+	case expiresAt != "":
+		parsed, err := time.Parse(time.RFC3339, expiresAt)
+		if err != nil {
+			return nil, fmt.Errorf("expires_at must be RFC3339")
+		}
+		t = parsed
+	case clearAfter != "":
+		d, err := time.ParseDuration(clearAfter)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("clear_after must be a positive duration (e.g. 30m, 4h)")
+		}
+		t = now.Add(d)
+	default:
+		return nil, nil
+	}
+
+	if !t.After(now) {
+		return nil, fmt.Errorf("expiry must be in the future")
+	}
+	if t.After(now.Add(maxStatusClearAfter)) {
+		return nil, fmt.Errorf("expiry must be within 30 days")
+	}
+	return &t, nil
+}
+
+// StartStatusExpiry 만료된 커스텀 상태 메시지 정리 시작 (DB와 Redis에서 삭제 후 발행)
+func (h *UserHandler) StartStatusExpiry() {
+	if h.statusDone != nil {
+		return
+	}
+	h.statusDone = make(chan struct{})
+	go h.runStatusExpiry(h.statusDone)
+}
+
+// Close 상태 메시지 만료 정리 중단
+func (h *UserHandler) Close() {
+	if h.statusDone != nil {
+		close(h.statusDone)
+		h.statusDone = nil
+	}
+}
+
+func (h *UserHandler) runStatusExpiry(done <-chan struct{}) {
+	defer errorreport.Recover(errorreport.Context{Component: "users.status_expiry"})
+
+	ticker := time.NewTicker(statusExpirySweepInterval)
+	defer ticker.Stop()
+
+	for {
+		h.clearExpiredStatuses(time.Now())
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// clearExpiredStatuses 만료 시각이 지난 상태 메시지 삭제
+// 여러 인스턴스가 동시에 돌아도 조건부 UPDATE로 지운 행만 돌려받으므로 한 번만 발행
+func (h *UserHandler) clearExpiredStatuses(now time.Time) {
+	for {
+		var ids []int64
+		if err := h.db.Model(&model.User{}).
+			Where("custom_status_expires_at <= ?", now).
+			Order("id").
+			Limit(statusExpirySweepBatch).
+			Pluck("id", &ids).Error; err != nil {
+			log.Printf("⚠️ 만료된 상태 메시지 조회 실패: %v", err)
+			return
+		}
+		if len(ids) == 0 {
+			return
+		}
+
+		var cleared []model.User
+		if err := h.db.Model(&cleared).
+			Clauses(clause.Returning{Columns: []clause.Column{{Name: "id"}}}).
+			Where("id IN ? AND custom_status_expires_at <= ?", ids, now).
+			Updates(map[string]interface{}{
+				"custom_status_text":       nil,
+				"custom_status_emoji":      nil,
+				"custom_status_expires_at": nil,
+			}).Error; err != nil {
+			log.Printf("⚠️ 만료된 상태 메시지 삭제 실패: %v", err)
+			return
+		}
+
+		if h.presenceManager != nil {
+			for _, u := range cleared {
+				if err := h.presenceManager.ClearStatusMessage(u.ID); err != nil {
+					log.Printf("⚠️ 상태 메시지 presence 갱신 실패: user=%d, err=%v", u.ID, err)
+				}
+			}
+		}
+		if len(ids) < statusExpirySweepBatch {
+			return
+		}
+	}
+}
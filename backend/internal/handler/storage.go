@@ -1,24 +1,37 @@
 package handler
 
 import (
+	"fmt"
 	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"gorm.io/gorm"
 
 	"realtime-backend/internal/auth"
+	appconfig "realtime-backend/internal/config"
 	"realtime-backend/internal/model"
 	"realtime-backend/internal/storage"
 )
 
 type StorageHandler struct {
-	db *gorm.DB
-	s3 *storage.S3Service
+	db       *gorm.DB
+	s3       *storage.S3Registry
+	zip      zipLimits
+	accesses *fileAccessTracker // 최근 파일용 열람 기록 (일괄 저장)
+
+	uploadMaxSize  int64         // 멀티파트 업로드 최대 파일 크기 (0 = 제한 없음)
+	defaultQuota   int64         // 워크스페이스 기본 저장 용량 (0 = 제한 없음)
+	trashRetention time.Duration // 휴지통 보관 기간
+	trashDone      chan struct{} // 닫으면 휴지통 정리 중단
+
+	transcribeCfg *appconfig.Config // 업로드 파일 전사용 AWS 설정 (nil = 비활성)
+	textractCfg   *appconfig.Config // PDF/이미지 OCR용 AWS 설정 (nil = 텍스트/오피스 문서만 추출)
 }
 
 // NewStorageHandler StorageHandler 생성
-func NewStorageHandler(db *gorm.DB, s3 *storage.S3Service) *StorageHandler {
-	return &StorageHandler{db: db, s3: s3}
+func NewStorageHandler(db *gorm.DB, s3 *storage.S3Registry) *StorageHandler {
+	return &StorageHandler{db: db, s3: s3, accesses: newFileAccessTracker(db), trashRetention: defaultTrashRetention}
 }
 
 // FileResponse 파일/폴더 응답
@@ -33,10 +46,14 @@ type FileResponse struct {
 	FileSize         *int64         `json:"file_size,omitempty"`
 	MimeType         *string        `json:"mime_type,omitempty"`
 	S3Key            *string        `json:"s3_key,omitempty"`
+	ContentHash      *string        `json:"content_hash,omitempty"`
+	ThumbnailURL     *string        `json:"thumbnail_url,omitempty"` // 이미지 썸네일 (생성 전이면 없음)
+	PreviewURL       *string        `json:"preview_url,omitempty"`
 	RelatedMeetingID *int64         `json:"related_meeting_id,omitempty"`
 	CreatedAt        string         `json:"created_at"`
 	Uploader         *UserResponse  `json:"uploader,omitempty"`
 	Children         []FileResponse `json:"children,omitempty"`
+	Restricted       bool           `json:"restricted,omitempty"` // 접근 제한 폴더
 }
 
 // CreateFolderRequest 폴더 생성 요청
@@ -50,6 +67,27 @@ type GetPresignedURLRequest struct {
 	FileName       string `json:"file_name"`
 	ContentType    string `json:"content_type"`
 	ParentFolderID *int64 `json:"parent_folder_id,omitempty"`
+	ContentHash    string `json:"content_hash,omitempty"` // SHA-256 hex (주면 같은 내용의 기존 파일을 함께 반환)
+	FileSize       int64  `json:"file_size,omitempty"`    // 주면 업로드 전에 저장 용량 확인
+}
+
+// ConfirmUploadRequest 업로드 완료 확인 요청
+// duplicate_action이 link이고 같은 내용의 파일이 있으면 key 없이도 기존 객체를 공유하는 항목 생성
+type ConfirmUploadRequest struct {
+	Name            string `json:"name"`
+	Key             string `json:"key"`
+	FileSize        int64  `json:"file_size"`
+	MimeType        string `json:"mime_type"`
+	ParentFolderID  *int64 `json:"parent_folder_id,omitempty"`
+	ContentHash     string `json:"content_hash,omitempty"`     // SHA-256 hex (없으면 S3 체크섬 사용)
+	DuplicateAction string `json:"duplicate_action,omitempty"` // keep(기본), link
+}
+
+// ConfirmUploadResponse 업로드 완료 응답 (같은 내용의 기존 파일 정보 포함)
+type ConfirmUploadResponse struct {
+	FileResponse
+	Duplicates   []FileResponse `json:"duplicates,omitempty"`     // keep: 같은 내용의 기존 파일
+	LinkedFileID *int64         `json:"linked_file_id,omitempty"` // link: 객체를 공유하는 원본 파일
 }
 
 // GetPresignedURL 파일 업로드용 Presigned URL 생성
@@ -87,20 +125,52 @@ func (h *StorageHandler) GetPresignedURL(c *fiber.Ctx) error {
 			"error": "file_name and content_type are required",
 		})
 	}
+	contentHash, ok := normalizeContentHash(req.ContentHash)
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "content_hash must be a hex SHA-256 digest",
+		})
+	}
+
+	// 제한된 폴더에는 허용된 멤버만 업로드
+	access := h.requireFolderAccess(c, int64(workspaceID), claims.UserID)
+	if access == nil {
+		return nil
+	}
+	if !access.parentAllowed(req.ParentFolderID) {
+		return folderForbidden(c)
+	}
+	if req.FileSize > 0 && !h.requireStorageQuota(c, int64(workspaceID), req.FileSize) {
+		return nil
+	}
+
+	s3Service, err := h.s3ForWorkspace(int64(workspaceID))
+	if err != nil {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+			"error": "storage is not available in workspace data region",
+		})
+	}
 
 	// Presigned URL 생성
-	presigned, err := h.s3.GenerateUploadURL(int64(workspaceID), req.FileName, req.ContentType)
+	presigned, err := s3Service.GenerateUploadURL(int64(workspaceID), req.FileName, req.ContentType)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "failed to generate presigned URL",
 		})
 	}
 
+	// 같은 내용의 파일이 있으면 업로드 대신 연결할 수 있도록 함께 반환
+	duplicates := make([]FileResponse, 0)
+	for _, f := range h.findDuplicateFiles(int64(workspaceID), contentHash, access) {
+		duplicates = append(duplicates, h.toFileResponse(&f))
+	}
+
 	return c.JSON(fiber.Map{
 		"upload_url":       presigned.URL,
 		"key":              presigned.Key,
 		"expires_at":       presigned.ExpiresAt,
 		"parent_folder_id": req.ParentFolderID,
+		"duplicates":       duplicates,
 	})
 }
 
@@ -121,21 +191,29 @@ func (h *StorageHandler) ConfirmUpload(c *fiber.Ctx) error {
 		})
 	}
 
-	var req struct {
-		Name           string `json:"name"`
-		Key            string `json:"key"`
-		FileSize       int64  `json:"file_size"`
-		MimeType       string `json:"mime_type"`
-		ParentFolderID *int64 `json:"parent_folder_id,omitempty"`
-	}
-
+	var req ConfirmUploadRequest
 	if err := c.BodyParser(&req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 			"error": "invalid request body",
 		})
 	}
 
-	if req.Name == "" || req.Key == "" {
+	if req.DuplicateAction == "" {
+		req.DuplicateAction = duplicateActionKeep
+	}
+	if req.DuplicateAction != duplicateActionKeep && req.DuplicateAction != duplicateActionLink {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "duplicate_action must be keep or link",
+		})
+	}
+	contentHash, ok := normalizeContentHash(req.ContentHash)
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "content_hash must be a hex SHA-256 digest",
+		})
+	}
+
+	if req.Name == "" || (req.Key == "" && (req.DuplicateAction != duplicateActionLink || contentHash == "")) {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 			"error": "name and key are required",
 		})
@@ -154,8 +232,26 @@ func (h *StorageHandler) ConfirmUpload(c *fiber.Ctx) error {
 		}
 	}
 
-	// S3 URL 생성
-	fileURL := h.s3.GetPublicURL(req.Key)
+	access := h.requireFolderAccess(c, int64(workspaceID), claims.UserID)
+	if access == nil {
+		return nil
+	}
+	if !access.parentAllowed(req.ParentFolderID) {
+		return folderForbidden(c)
+	}
+
+	// S3 URL 생성 (워크스페이스 데이터 리전 버킷 기준)
+	s3Service, err := h.s3ForWorkspace(int64(workspaceID))
+	if err != nil {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+			"error": "storage is not available in workspace data region",
+		})
+	}
+	// 클라이언트가 해시를 주지 않았으면 업로드 시 기록된 S3 체크섬 사용
+	if contentHash == "" && req.Key != "" {
+		contentHash, _ = s3Service.ObjectSHA256(c.Context(), req.Key)
+	}
+	duplicates := h.findDuplicateFiles(int64(workspaceID), contentHash, access)
 
 	file := model.WorkspaceFile{
 		WorkspaceID:    int64(workspaceID),
@@ -163,10 +259,38 @@ func (h *StorageHandler) ConfirmUpload(c *fiber.Ctx) error {
 		ParentFolderID: req.ParentFolderID,
 		Name:           req.Name,
 		Type:           "FILE",
-		FileURL:        &fileURL,
 		FileSize:       &req.FileSize,
 		MimeType:       &req.MimeType,
-		S3Key:          &req.Key,
+	}
+	if contentHash != "" {
+		file.ContentHash = &contentHash
+	}
+
+	var linked *model.WorkspaceFile
+	if req.DuplicateAction == duplicateActionLink && len(duplicates) > 0 {
+		// 기존 파일의 S3 객체를 공유 (새로 올린 사본은 확인 후 삭제)
+		linked = &duplicates[0]
+		file.FileURL = linked.FileURL
+		file.FileSize = linked.FileSize
+		file.MimeType = linked.MimeType
+		file.S3Key = linked.S3Key
+		file.ThumbnailURL = linked.ThumbnailURL
+		file.PreviewURL = linked.PreviewURL
+	} else if req.Key == "" {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "no existing file with the same content",
+		})
+	} else {
+		// 용량을 넘으면 이미 올라간 객체는 지우고 거부
+		if !h.requireStorageQuota(c, int64(workspaceID), req.FileSize) {
+			for _, key := range h.unreferencedS3Keys([]string{req.Key}) {
+				s3Service.DeleteFile(key)
+			}
+			return nil
+		}
+		fileURL := s3Service.GetPublicURL(req.Key)
+		file.FileURL = &fileURL
+		file.S3Key = &req.Key
 	}
 
 	if err := h.db.Create(&file).Error; err != nil {
@@ -175,9 +299,34 @@ func (h *StorageHandler) ConfirmUpload(c *fiber.Ctx) error {
 		})
 	}
 
+	resp := ConfirmUploadResponse{}
+	if linked != nil {
+		if req.Key != "" && req.Key != *linked.S3Key {
+			for _, key := range h.unreferencedS3Keys([]string{req.Key}) {
+				s3Service.DeleteFile(key)
+			}
+		}
+		resp.LinkedFileID = &linked.ID
+	} else {
+		h.addStorageUsage(int64(workspaceID), req.FileSize)
+		for i := range duplicates {
+			resp.Duplicates = append(resp.Duplicates, h.toFileResponse(&duplicates[i]))
+		}
+	}
+
 	h.db.Preload("Uploader").First(&file, file.ID)
+	h.recordFileActivity(&file, &claims.UserID, model.FileActionUpload, "")
+	h.notifyFolderWatchers(h.folderWatchTargets(file.WorkspaceID, file.ParentFolderID, claims.UserID, &file),
+		folderEvent{kind: folderEventFileAdded, actorID: claims.UserID, file: file})
+	if linked != nil {
+		h.copyFileText(linked.ID, &file)
+	} else {
+		h.queueTextExtraction(&file)
+		h.queueThumbnails(&file)
+	}
 
-	return c.Status(fiber.StatusCreated).JSON(h.toFileResponse(&file))
+	resp.FileResponse = h.toFileResponse(&file)
+	return c.Status(fiber.StatusCreated).JSON(resp)
 }
 
 // GetWorkspaceFiles 워크스페이스 파일 목록
@@ -200,6 +349,14 @@ func (h *StorageHandler) GetWorkspaceFiles(c *fiber.Ctx) error {
 	// 부모 폴더 ID (없으면 루트)
 	parentFolderID := c.QueryInt("parent_folder_id", 0)
 
+	access := h.requireFolderAccess(c, int64(workspaceID), claims.UserID)
+	if access == nil {
+		return nil
+	}
+	if parentFolderID > 0 && !access.folderAllowed(int64(parentFolderID)) {
+		return folderForbidden(c)
+	}
+
 	var files []model.WorkspaceFile
 	query := h.db.Where("workspace_id = ?", workspaceID)
 
@@ -220,9 +377,15 @@ func (h *StorageHandler) GetWorkspaceFiles(c *fiber.Ctx) error {
 		})
 	}
 
-	responses := make([]FileResponse, len(files))
-	for i, f := range files {
-		responses[i] = h.toFileResponse(&f)
+	// 접근할 수 없는 하위 폴더는 목록에서 제외
+	responses := make([]FileResponse, 0, len(files))
+	for _, f := range files {
+		if !access.fileAllowed(&f) {
+			continue
+		}
+		resp := h.toFileResponse(&f)
+		resp.Restricted = f.Type == "FOLDER" && access.restricted(f.ID)
+		responses = append(responses, resp)
 	}
 
 	// 현재 경로 정보
@@ -284,6 +447,14 @@ func (h *StorageHandler) CreateFolder(c *fiber.Ctx) error {
 		}
 	}
 
+	access := h.requireFolderAccess(c, int64(workspaceID), claims.UserID)
+	if access == nil {
+		return nil
+	}
+	if !access.parentAllowed(req.ParentFolderID) {
+		return folderForbidden(c)
+	}
+
 	// 같은 위치에 같은 이름의 폴더가 있는지 확인
 	var existing model.WorkspaceFile
 	query := h.db.Where("workspace_id = ? AND name = ? AND type = ?", workspaceID, req.Name, "FOLDER")
@@ -313,6 +484,9 @@ func (h *StorageHandler) CreateFolder(c *fiber.Ctx) error {
 	}
 
 	h.db.Preload("Uploader").First(&folder, folder.ID)
+	h.recordFileActivity(&folder, &claims.UserID, model.FileActionCreateFolder, "")
+	h.notifyFolderWatchers(h.folderWatchTargets(folder.WorkspaceID, folder.ParentFolderID, claims.UserID, &folder),
+		folderEvent{kind: folderEventFileAdded, actorID: claims.UserID, file: folder})
 
 	return c.Status(fiber.StatusCreated).JSON(h.toFileResponse(&folder))
 }
@@ -356,6 +530,14 @@ func (h *StorageHandler) UploadFile(c *fiber.Ctx) error {
 
 	req.Name = sanitizeString(req.Name)
 
+	access := h.requireFolderAccess(c, int64(workspaceID), claims.UserID)
+	if access == nil {
+		return nil
+	}
+	if !access.parentAllowed(req.ParentFolderID) {
+		return folderForbidden(c)
+	}
+
 	file := model.WorkspaceFile{
 		WorkspaceID:    int64(workspaceID),
 		UploaderID:     &claims.UserID,
@@ -374,11 +556,14 @@ func (h *StorageHandler) UploadFile(c *fiber.Ctx) error {
 	}
 
 	h.db.Preload("Uploader").First(&file, file.ID)
+	h.recordFileActivity(&file, &claims.UserID, model.FileActionUpload, "")
+	h.notifyFolderWatchers(h.folderWatchTargets(file.WorkspaceID, file.ParentFolderID, claims.UserID, &file),
+		folderEvent{kind: folderEventFileAdded, actorID: claims.UserID, file: file})
 
 	return c.Status(fiber.StatusCreated).JSON(h.toFileResponse(&file))
 }
 
-// DeleteFile 파일/폴더 삭제
+// DeleteFile 파일/폴더를 휴지통으로 이동 (보관 기간이 지나거나 관리자가 비우면 영구 삭제)
 func (h *StorageHandler) DeleteFile(c *fiber.Ctx) error {
 	claims := c.Locals("claims").(*auth.Claims)
 	workspaceID, err := c.ParamsInt("workspaceId")
@@ -419,38 +604,36 @@ func (h *StorageHandler) DeleteFile(c *fiber.Ctx) error {
 		})
 	}
 
-	// 트랜잭션으로 DB 삭제 (S3 삭제는 별도 처리)
-	var s3KeysToDelete []string
-
-	err = h.db.Transaction(func(tx *gorm.DB) error {
-		// 폴더인 경우 하위 항목도 삭제
-		if file.Type == "FOLDER" {
-			h.deleteRecursiveWithTx(tx, file.ID, &s3KeysToDelete)
-		}
-
-		// S3 키 수집
-		if file.S3Key != nil && *file.S3Key != "" {
-			s3KeysToDelete = append(s3KeysToDelete, *file.S3Key)
-		}
+	// 폴더는 하위의 제한된 폴더까지 모두 접근 가능해야 삭제 가능
+	access := h.requireFolderAccess(c, int64(workspaceID), claims.UserID)
+	if access == nil {
+		return nil
+	}
+	if (file.Type == "FOLDER" && !access.subtreeAllowed(file.ID)) || !access.fileAllowed(&file) {
+		return folderForbidden(c)
+	}
 
-		return tx.Delete(&file).Error
-	})
+	// 구독자는 삭제 전에 조회 (폴더 자체를 구독한 사용자 포함)
+	watchFrom := file.ParentFolderID
+	if file.Type == "FOLDER" {
+		watchFrom = &file.ID
+	}
+	watchTargets := h.folderWatchTargets(int64(workspaceID), watchFrom, claims.UserID, &file)
 
-	if err != nil {
+	if err := h.db.Transaction(func(tx *gorm.DB) error {
+		return moveToTrashWithTx(tx, &file, claims.UserID)
+	}); err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "failed to delete file",
 		})
 	}
 
-	// DB 삭제 성공 후 S3 파일 삭제 (실패해도 무시)
-	if h.s3 != nil {
-		for _, key := range s3KeysToDelete {
-			h.s3.DeleteFile(key)
-		}
-	}
+	h.recordFileActivity(&file, &claims.UserID, model.FileActionDelete, "")
+	h.notifyFolderWatchers(watchTargets, folderEvent{kind: folderEventFileDeleted, actorID: claims.UserID, file: file})
 
 	return c.JSON(fiber.Map{
-		"message": "file deleted",
+		"message":  "file moved to trash",
+		"purge_at": time.Now().Add(h.trashRetention).Format("2006-01-02T15:04:05Z07:00"),
 	})
 }
 
@@ -485,6 +668,14 @@ func (h *StorageHandler) RenameFile(c *fiber.Ctx) error {
 		})
 	}
 
+	access := h.requireFolderAccess(c, int64(workspaceID), claims.UserID)
+	if access == nil {
+		return nil
+	}
+	if !access.fileAllowed(&file) {
+		return folderForbidden(c)
+	}
+
 	var req struct {
 		Name string `json:"name"`
 	}
@@ -500,6 +691,7 @@ func (h *StorageHandler) RenameFile(c *fiber.Ctx) error {
 		})
 	}
 
+	oldName := file.Name
 	file.Name = sanitizeString(req.Name)
 	if err := h.db.Save(&file).Error; err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
@@ -507,7 +699,98 @@ func (h *StorageHandler) RenameFile(c *fiber.Ctx) error {
 		})
 	}
 	h.db.Preload("Uploader").First(&file, file.ID)
+	if file.Name != oldName {
+		h.recordFileActivity(&file, &claims.UserID, model.FileActionRename, oldName)
+		h.notifyFolderWatchers(h.folderWatchTargets(file.WorkspaceID, file.ParentFolderID, claims.UserID, &file),
+			folderEvent{kind: folderEventFileRenamed, actorID: claims.UserID, file: file, oldName: oldName})
+	}
+
+	return c.JSON(h.toFileResponse(&file))
+}
+
+// MoveFile 파일/폴더를 다른 폴더로 이동 (parent_folder_id가 null이면 루트로)
+func (h *StorageHandler) MoveFile(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	workspaceID, err := c.ParamsInt("workspaceId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid workspace id",
+		})
+	}
+	fileID, err := c.ParamsInt("fileId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid file id",
+		})
+	}
+
+	// 멤버 확인
+	if !h.isWorkspaceMember(int64(workspaceID), claims.UserID) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "you are not a member of this workspace",
+		})
+	}
+
+	var file model.WorkspaceFile
+	err = h.db.Where("id = ? AND workspace_id = ?", fileID, workspaceID).First(&file).Error
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "file not found",
+		})
+	}
+
+	var req struct {
+		ParentFolderID *int64 `json:"parent_folder_id"`
+	}
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+
+	tree := h.loadFolderTree(int64(workspaceID))
+	if req.ParentFolderID != nil {
+		if _, ok := tree.parents[*req.ParentFolderID]; !ok {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "parent folder not found",
+			})
+		}
+		// 폴더를 자기 자신이나 하위 폴더 안으로 옮기면 순환이 생김
+		if file.Type == "FOLDER" && (*req.ParentFolderID == file.ID || tree.isDescendant(*req.ParentFolderID, file.ID)) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "cannot move a folder into itself",
+			})
+		}
+	}
+
+	// 옮기는 항목 전체와 대상 폴더 모두 접근 가능해야 함
+	access := h.requireFolderAccess(c, int64(workspaceID), claims.UserID)
+	if access == nil {
+		return nil
+	}
+	if (file.Type == "FOLDER" && !access.subtreeAllowed(file.ID)) || !access.fileAllowed(&file) || !access.parentAllowed(req.ParentFolderID) {
+		return folderForbidden(c)
+	}
+
+	folderName := func(id *int64) string {
+		if id == nil {
+			return "/"
+		}
+		return tree.names[*id]
+	}
+	from := folderName(file.ParentFolderID)
+	if (file.ParentFolderID == nil) != (req.ParentFolderID == nil) ||
+		(file.ParentFolderID != nil && *file.ParentFolderID != *req.ParentFolderID) {
+		if err := h.db.Model(&file).Update("parent_folder_id", req.ParentFolderID).Error; err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "failed to move file",
+			})
+		}
+		file.ParentFolderID = req.ParentFolderID
+		h.recordFileActivity(&file, &claims.UserID, model.FileActionMove, fmt.Sprintf("%s → %s", from, folderName(req.ParentFolderID)))
+	}
 
+	h.db.Preload("Uploader").First(&file, file.ID)
 	return c.JSON(h.toFileResponse(&file))
 }
 
@@ -548,6 +831,17 @@ func (h *StorageHandler) GetDownloadURL(c *fiber.Ctx) error {
 		})
 	}
 
+	access := h.requireFolderAccess(c, int64(workspaceID), claims.UserID)
+	if access == nil {
+		return nil
+	}
+	if !access.fileAllowed(&file) {
+		return folderForbidden(c)
+	}
+
+	h.recordAccess(claims.UserID, &file)
+	h.recordFileActivity(&file, &claims.UserID, model.FileActionDownload, "")
+
 	if file.S3Key == nil || *file.S3Key == "" {
 		// S3 키가 없으면 기존 URL 반환
 		if file.FileURL != nil {
@@ -560,8 +854,15 @@ func (h *StorageHandler) GetDownloadURL(c *fiber.Ctx) error {
 		})
 	}
 
+	s3Service, err := h.s3ForWorkspace(int64(workspaceID))
+	if err != nil {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+			"error": "storage is not available in workspace data region",
+		})
+	}
+
 	// Presigned URL 생성
-	url, err := h.s3.GetFileURL(*file.S3Key)
+	url, err := s3Service.GetFileURL(*file.S3Key)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "failed to generate download URL",
@@ -582,21 +883,71 @@ func (h *StorageHandler) isWorkspaceMember(workspaceID, userID int64) bool {
 	return count > 0
 }
 
-func (h *StorageHandler) deleteRecursiveWithTx(tx *gorm.DB, folderID int64, s3Keys *[]string) {
-	var children []model.WorkspaceFile
-	tx.Where("parent_folder_id = ?", folderID).Find(&children)
+// s3ForWorkspace 워크스페이스 데이터 리전에 맞는 S3 서비스 조회
+func (h *StorageHandler) s3ForWorkspace(workspaceID int64) (*storage.S3Service, error) {
+	if h.s3 == nil {
+		return nil, fmt.Errorf("S3 service is not configured")
+	}
+
+	var region string
+	h.db.Model(&model.Workspace{}).
+		Where("id = ?", workspaceID).
+		Select("COALESCE(data_region, '')").
+		Scan(&region)
+	return h.s3.ForRegion(region)
+}
+
+// fileDeleteBatch 영구 삭제 시 한 번에 지우는 항목 수
+const fileDeleteBatch = 500
+
+// collectSubtreeIDsWithTx 재귀 CTE로 하위 항목 ID를 한 번에 조회 (깊은 항목부터, 순환 참조는 경로로 차단)
+// includeTrashed면 따로 휴지통에 옮긴 항목까지 포함, 조회 실패는 호출한 트랜잭션이 롤백하도록 반환
+func collectSubtreeIDsWithTx(tx *gorm.DB, folderID int64, includeTrashed bool) ([]int64, error) {
+	live := " AND f.deleted_at IS NULL"
+	if includeTrashed {
+		live = ""
+	}
+
+	var ids []int64
+	err := tx.Raw(`
+	WITH RECURSIVE subtree AS (
+		SELECT f.id, 1 AS depth, ARRAY[f.parent_folder_id, f.id] AS path
+		FROM workspace_files f WHERE f.parent_folder_id = ?`+live+`
+		UNION ALL
+		SELECT f.id, s.depth + 1, s.path || f.id
+		FROM workspace_files f JOIN subtree s ON f.parent_folder_id = s.id
+		WHERE NOT f.id = ANY(s.path)`+live+`
+	)
+	SELECT id FROM subtree GROUP BY id ORDER BY MAX(depth) DESC`, folderID).Scan(&ids).Error
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// deleteFilesWithTx 항목과 관련 기록을 묶음 단위로 영구 삭제 (ids는 하위 항목이 먼저 오도록 정렬, S3 키와 크기는 수집만)
+func (h *StorageHandler) deleteFilesWithTx(tx *gorm.DB, ids []int64, s3Objects map[string]int64) error {
+	for start := 0; start < len(ids); start += fileDeleteBatch {
+		batch := ids[start:min(start+fileDeleteBatch, len(ids))]
 
-	for _, child := range children {
-		// S3 키 수집 (삭제는 트랜잭션 완료 후)
-		if child.S3Key != nil && *child.S3Key != "" {
-			*s3Keys = append(*s3Keys, *child.S3Key)
+		var files []model.WorkspaceFile
+		tx.Unscoped().Select("id", "s3_key", "file_size").Where("id IN ?", batch).Find(&files)
+		for i := range files {
+			collectS3Object(s3Objects, &files[i])
 		}
 
-		if child.Type == "FOLDER" {
-			h.deleteRecursiveWithTx(tx, child.ID, s3Keys)
+		deleteFolderPermissionsWithTx(tx, batch)
+		deleteFolderWatchesWithTx(tx, batch)
+		deleteFileActivityWithTx(tx, batch)
+		deleteFileTranscriptsWithTx(tx, batch)
+		deleteFileTextWithTx(tx, batch)
+		deleteMeetingMinutesWithTx(tx, batch)
+		deleteFileShareLinksWithTx(tx, batch)
+		if err := tx.Unscoped().Where("id IN ?", batch).Delete(&model.WorkspaceFile{}).Error; err != nil {
+			return err
 		}
-		tx.Delete(&child)
 	}
+	return nil
 }
 
 func (h *StorageHandler) getBreadcrumbs(folderID int64) []FileResponse {
@@ -631,6 +982,9 @@ func (h *StorageHandler) toFileResponse(f *model.WorkspaceFile) FileResponse {
 		FileSize:         f.FileSize,
 		MimeType:         f.MimeType,
 		S3Key:            f.S3Key,
+		ContentHash:      f.ContentHash,
+		ThumbnailURL:     f.ThumbnailURL,
+		PreviewURL:       f.PreviewURL,
 		RelatedMeetingID: f.RelatedMeetingID,
 		CreatedAt:        f.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 	}
@@ -0,0 +1,304 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"realtime-backend/internal/auth"
+	"realtime-backend/internal/storage"
+)
+
+const (
+	maxSignedPartsPerRequest = 100
+	multipartRequestTimeout  = 30 * time.Second
+)
+
+// InitiateMultipartUploadRequest 멀티파트 업로드 시작 요청
+type InitiateMultipartUploadRequest struct {
+	FileName       string `json:"file_name"`
+	ContentType    string `json:"content_type"`
+	FileSize       int64  `json:"file_size"`
+	PartSize       int64  `json:"part_size"` // 0이면 서버가 결정
+	ParentFolderID *int64 `json:"parent_folder_id"`
+}
+
+// MultipartUploadRequest 진행 중인 멀티파트 업로드 지정
+type MultipartUploadRequest struct {
+	Key         string  `json:"key"`
+	UploadID    string  `json:"upload_id"`
+	PartNumbers []int32 `json:"part_numbers"` // 파트 URL 서명 시에만 사용
+}
+
+// SetUploadLimit 멀티파트 업로드 최대 파일 크기 설정
+func (h *StorageHandler) SetUploadLimit(maxSize int64) {
+	h.uploadMaxSize = maxSize
+}
+
+// InitiateMultipartUpload 대용량 파일 멀티파트 업로드 시작
+// 파트를 모두 올리고 complete를 호출한 뒤 기존 /files/confirm으로 파일 항목 생성
+func (h *StorageHandler) InitiateMultipartUpload(c *fiber.Ctx) error {
+	if h.s3 == nil {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+			"error": "S3 service is not configured",
+		})
+	}
+
+	claims := c.Locals("claims").(*auth.Claims)
+	workspaceID, err := c.ParamsInt("workspaceId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid workspace id",
+		})
+	}
+
+	if !h.isWorkspaceMember(int64(workspaceID), claims.UserID) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "you are not a member of this workspace",
+		})
+	}
+
+	var req InitiateMultipartUploadRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+
+	if req.FileName == "" || req.ContentType == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "file_name and content_type are required",
+		})
+	}
+	if h.uploadMaxSize > 0 && req.FileSize > h.uploadMaxSize {
+		return c.Status(fiber.StatusRequestEntityTooLarge).JSON(fiber.Map{
+			"error": fmt.Sprintf("file exceeds the %d byte upload limit", h.uploadMaxSize),
+		})
+	}
+	partSize, partCount, err := storage.MultipartPartSize(req.FileSize, req.PartSize)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	// 제한된 폴더에는 허용된 멤버만 업로드
+	access := h.requireFolderAccess(c, int64(workspaceID), claims.UserID)
+	if access == nil {
+		return nil
+	}
+	if !access.parentAllowed(req.ParentFolderID) {
+		return folderForbidden(c)
+	}
+	if !h.requireStorageQuota(c, int64(workspaceID), req.FileSize) {
+		return nil
+	}
+
+	s3Service, err := h.s3ForWorkspace(int64(workspaceID))
+	if err != nil {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+			"error": "storage is not available in workspace data region",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Context(), multipartRequestTimeout)
+	defer cancel()
+
+	key, uploadID, err := s3Service.CreateMultipartUpload(ctx, int64(workspaceID), req.FileName, req.ContentType)
+	if err != nil {
+		log.Printf("❌ failed to create multipart upload: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to start multipart upload",
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(storage.MultipartUpload{
+		Key:       key,
+		UploadID:  uploadID,
+		PartSize:  partSize,
+		PartCount: partCount,
+	})
+}
+
+// SignMultipartParts 파트 업로드용 Presigned URL 발급 (한 번에 최대 100개)
+func (h *StorageHandler) SignMultipartParts(c *fiber.Ctx) error {
+	req, s3Service, ok := h.requireMultipartUpload(c)
+	if !ok {
+		return nil
+	}
+
+	if len(req.PartNumbers) == 0 || len(req.PartNumbers) > maxSignedPartsPerRequest {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": fmt.Sprintf("part_numbers must contain 1 to %d entries", maxSignedPartsPerRequest),
+		})
+	}
+	for _, n := range req.PartNumbers {
+		if n < 1 || n > storage.MultipartMaxParts {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": fmt.Sprintf("part numbers must be between 1 and %d", storage.MultipartMaxParts),
+			})
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(c.Context(), multipartRequestTimeout)
+	defer cancel()
+
+	parts, expiresAt, err := s3Service.PresignUploadParts(ctx, req.Key, req.UploadID, req.PartNumbers)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to generate presigned URLs",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"parts":      parts,
+		"expires_at": expiresAt,
+	})
+}
+
+// CompleteMultipartUpload 업로드된 파트를 검증하고 객체 완성
+// 클라이언트가 보낸 ETag 대신 S3의 파트 목록을 기준으로 크기 규칙을 확인
+func (h *StorageHandler) CompleteMultipartUpload(c *fiber.Ctx) error {
+	req, s3Service, ok := h.requireMultipartUpload(c)
+	if !ok {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(c.Context(), multipartRequestTimeout)
+	defer cancel()
+
+	parts, err := s3Service.ListUploadedParts(ctx, req.Key, req.UploadID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "multipart upload not found",
+		})
+	}
+
+	size, err := h.validateUploadedParts(parts)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	if err := s3Service.CompleteMultipartUpload(ctx, req.Key, req.UploadID, parts); err != nil {
+		log.Printf("❌ failed to complete multipart upload: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to complete multipart upload",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"key":       req.Key,
+		"file_size": size,
+	})
+}
+
+// AbortMultipartUpload 멀티파트 업로드 취소
+func (h *StorageHandler) AbortMultipartUpload(c *fiber.Ctx) error {
+	req, s3Service, ok := h.requireMultipartUpload(c)
+	if !ok {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(c.Context(), multipartRequestTimeout)
+	defer cancel()
+
+	if err := s3Service.AbortMultipartUpload(ctx, req.Key, req.UploadID); err != nil {
+		log.Printf("❌ failed to abort multipart upload: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to abort multipart upload",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "multipart upload aborted",
+	})
+}
+
+// requireMultipartUpload 요청 본문 파싱 + 멤버/키 소속 확인 (실패 시 응답을 쓰고 false)
+func (h *StorageHandler) requireMultipartUpload(c *fiber.Ctx) (*MultipartUploadRequest, *storage.S3Service, bool) {
+	if h.s3 == nil {
+		c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+			"error": "S3 service is not configured",
+		})
+		return nil, nil, false
+	}
+
+	claims := c.Locals("claims").(*auth.Claims)
+	workspaceID, err := c.ParamsInt("workspaceId")
+	if err != nil {
+		c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid workspace id",
+		})
+		return nil, nil, false
+	}
+
+	if !h.isWorkspaceMember(int64(workspaceID), claims.UserID) {
+		c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "you are not a member of this workspace",
+		})
+		return nil, nil, false
+	}
+
+	var req MultipartUploadRequest
+	if err := c.BodyParser(&req); err != nil {
+		c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+		return nil, nil, false
+	}
+	if req.Key == "" || req.UploadID == "" {
+		c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "key and upload_id are required",
+		})
+		return nil, nil, false
+	}
+	if !storage.IsWorkspaceObjectKey(int64(workspaceID), req.Key) {
+		c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "key does not belong to this workspace",
+		})
+		return nil, nil, false
+	}
+
+	s3Service, err := h.s3ForWorkspace(int64(workspaceID))
+	if err != nil {
+		c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+			"error": "storage is not available in workspace data region",
+		})
+		return nil, nil, false
+	}
+
+	return &req, s3Service, true
+}
+
+// validateUploadedParts 파트 번호가 1부터 연속이고, 마지막을 제외한 파트가 같은 크기(최소 5MB)인지 확인 후 총 크기 반환
+func (h *StorageHandler) validateUploadedParts(parts []storage.UploadedPart) (int64, error) {
+	if len(parts) == 0 {
+		return 0, fmt.Errorf("no parts have been uploaded")
+	}
+
+	partSize := parts[0].Size
+	var total int64
+	for i, p := range parts {
+		if p.PartNumber != int32(i+1) {
+			return 0, fmt.Errorf("part %d is missing", i+1)
+		}
+		last := i == len(parts)-1
+		if !last && (p.Size != partSize || p.Size < storage.MultipartMinPartSize) {
+			return 0, fmt.Errorf("part %d must be %d bytes", p.PartNumber, partSize)
+		}
+		if last && p.Size > partSize {
+			return 0, fmt.Errorf("last part is larger than the part size")
+		}
+		total += p.Size
+	}
+
+	if h.uploadMaxSize > 0 && total > h.uploadMaxSize {
+		return 0, fmt.Errorf("file exceeds the %d byte upload limit", h.uploadMaxSize)
+	}
+	return total, nil
+}
@@ -0,0 +1,297 @@
+package handler
+
+import (
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"realtime-backend/internal/auth"
+	"realtime-backend/internal/model"
+)
+
+const storageBreakdownLimit = 20
+
+// StorageUsageResponse 저장 용량 사용 현황
+type StorageUsageResponse struct {
+	QuotaBytes int64                  `json:"quota_bytes"` // 0 = 제한 없음
+	UsedBytes  int64                  `json:"used_bytes"`
+	TrashBytes int64                  `json:"trash_bytes"`
+	ByFolder   []StorageUsageByFolder `json:"by_folder"`
+	ByUploader []StorageUsageByUser   `json:"by_uploader"`
+	ByMimeType []StorageUsageByType   `json:"by_mime_type"`
+	IsCustom   bool                   `json:"is_custom"` // 워크스페이스별 한도가 설정됨
+	UpdatedAt  *string                `json:"updated_at,omitempty"`
+}
+
+// StorageUsageByFolder 폴더별 사용량 (바로 아래 파일 기준, FolderID nil = 루트)
+type StorageUsageByFolder struct {
+	FolderID *int64 `json:"folder_id"`
+	Name     string `json:"name"`
+	Bytes    int64  `json:"bytes"`
+	Files    int64  `json:"files"`
+}
+
+// StorageUsageByUser 업로더별 사용량
+type StorageUsageByUser struct {
+	UserID   *int64 `json:"user_id"`
+	Nickname string `json:"nickname"`
+	Bytes    int64  `json:"bytes"`
+	Files    int64  `json:"files"`
+}
+
+// StorageUsageByType MIME 타입별 사용량
+type StorageUsageByType struct {
+	MimeType string `json:"mime_type"`
+	Bytes    int64  `json:"bytes"`
+	Files    int64  `json:"files"`
+}
+
+// UpdateStorageQuotaRequest 저장 용량 한도 변경 요청 (null이면 서버 기본값으로)
+type UpdateStorageQuotaRequest struct {
+	QuotaBytes *int64 `json:"quota_bytes"`
+}
+
+// SetDefaultQuota 워크스페이스 기본 저장 용량 설정 (0 = 제한 없음)
+func (h *StorageHandler) SetDefaultQuota(quotaBytes int64) {
+	h.defaultQuota = quotaBytes
+}
+
+// GetStorageUsage 저장 용량 한도와 폴더/업로더/형식별 사용량
+// 분류별 합계는 파일 항목 기준이라 중복 연결한 파일은 각각 더해짐
+func (h *StorageHandler) GetStorageUsage(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	workspaceID, err := c.ParamsInt("workspaceId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid workspace id",
+		})
+	}
+
+	if !h.isWorkspaceMember(int64(workspaceID), claims.UserID) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "you are not a member of this workspace",
+		})
+	}
+
+	quota, err := h.storageQuota(int64(workspaceID))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get storage usage",
+		})
+	}
+
+	resp := h.toStorageUsageResponse(&quota)
+	h.db.Unscoped().Model(&model.WorkspaceFile{}).
+		Where("workspace_id = ? AND type = ? AND deleted_at IS NOT NULL", workspaceID, "FILE").
+		Select("COALESCE(SUM(file_size), 0)").
+		Scan(&resp.TrashBytes)
+	resp.ByFolder = h.usageByFolder(int64(workspaceID))
+	resp.ByUploader = h.usageByUploader(int64(workspaceID))
+	resp.ByMimeType = h.usageByMimeType(int64(workspaceID))
+
+	return c.JSON(resp)
+}
+
+// UpdateStorageQuota 워크스페이스 저장 용량 한도 변경 (ADMIN 권한 필요)
+func (h *StorageHandler) UpdateStorageQuota(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	workspaceID, err := c.ParamsInt("workspaceId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid workspace id",
+		})
+	}
+
+	hasPermission, err := auth.CheckPermission(h.db, int64(workspaceID), claims.UserID, "ADMIN")
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to check permission",
+		})
+	}
+	if !hasPermission {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "you do not have permission to manage storage quota",
+		})
+	}
+
+	var req UpdateStorageQuotaRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+	if req.QuotaBytes != nil && *req.QuotaBytes < 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "quota_bytes must not be negative",
+		})
+	}
+
+	quota, err := h.storageQuota(int64(workspaceID))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get storage usage",
+		})
+	}
+	quota.QuotaBytes = req.QuotaBytes
+	quota.UpdatedBy = &claims.UserID
+	if err := h.db.Select("quota_bytes", "updated_by", "updated_at").Save(&quota).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to update storage quota",
+		})
+	}
+	recordAuditEvent(h.db, int64(workspaceID), &claims.UserID, model.AuditSettingsUpdated, model.AuditTargetWorkspace, int64(workspaceID), map[string]interface{}{
+		"setting":     "storage_quota",
+		"quota_bytes": req.QuotaBytes,
+	})
+
+	return c.JSON(h.toStorageUsageResponse(&quota))
+}
+
+// requireStorageQuota 업로드할 크기가 남은 용량 안인지 확인 (초과 시 413 응답을 쓰고 false)
+func (h *StorageHandler) requireStorageQuota(c *fiber.Ctx, workspaceID, size int64) bool {
+	quota, err := h.storageQuota(workspaceID)
+	if err != nil {
+		c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to check storage quota",
+		})
+		return false
+	}
+
+	limit := h.quotaLimit(&quota)
+	if limit > 0 && quota.UsedBytes+size > limit {
+		c.Status(fiber.StatusRequestEntityTooLarge).JSON(fiber.Map{
+			"error":       fmt.Sprintf("storage quota exceeded: %d of %d bytes used, upload needs %d bytes", quota.UsedBytes, limit, size),
+			"quota_bytes": limit,
+			"used_bytes":  quota.UsedBytes,
+		})
+		return false
+	}
+	return true
+}
+
+// storageQuota 사용량 행 조회 (처음이면 기존 S3 객체 크기로 초기화)
+func (h *StorageHandler) storageQuota(workspaceID int64) (model.WorkspaceStorageQuota, error) {
+	var quota model.WorkspaceStorageQuota
+	err := h.db.Where("workspace_id = ?", workspaceID).Limit(1).Find(&quota).Error
+	if err != nil || quota.WorkspaceID != 0 {
+		return quota, err
+	}
+
+	quota = model.WorkspaceStorageQuota{WorkspaceID: workspaceID, UsedBytes: h.storedObjectBytes(workspaceID)}
+	if err := h.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&quota).Error; err != nil {
+		return quota, err
+	}
+	return quota, h.db.Where("workspace_id = ?", workspaceID).First(&quota).Error
+}
+
+// storedObjectBytes S3 객체별 크기 합계 (휴지통 포함, 같은 키는 한 번만)
+func (h *StorageHandler) storedObjectBytes(workspaceID int64) int64 {
+	var total int64
+	h.db.Raw(`SELECT COALESCE(SUM(size), 0) FROM (
+		SELECT MAX(file_size) AS size FROM workspace_files
+		WHERE workspace_id = ? AND s3_key IS NOT NULL AND s3_key <> ''
+		GROUP BY s3_key
+	) objects`, workspaceID).Scan(&total)
+	return total
+}
+
+// addStorageUsage DB 반영 후 사용량 증감 (delta < 0이면 해제, 0 아래로 내려가지 않음)
+// 사용량 행이 아직 없으면 현재 파일 목록으로 초기화하므로 delta를 따로 더하지 않음
+func (h *StorageHandler) addStorageUsage(workspaceID, delta int64) {
+	if delta == 0 {
+		return
+	}
+	var count int64
+	h.db.Model(&model.WorkspaceStorageQuota{}).Where("workspace_id = ?", workspaceID).Count(&count)
+	if count == 0 {
+		h.storageQuota(workspaceID)
+		return
+	}
+	h.db.Model(&model.WorkspaceStorageQuota{}).
+		Where("workspace_id = ?", workspaceID).
+		Update("used_bytes", gorm.Expr("GREATEST(used_bytes + ?, 0)", delta))
+}
+
+// quotaLimit 적용되는 한도 (워크스페이스 설정이 없으면 서버 기본값)
+func (h *StorageHandler) quotaLimit(quota *model.WorkspaceStorageQuota) int64 {
+	if quota.QuotaBytes != nil {
+		return *quota.QuotaBytes
+	}
+	return h.defaultQuota
+}
+
+func (h *StorageHandler) usageByFolder(workspaceID int64) []StorageUsageByFolder {
+	rows := make([]StorageUsageByFolder, 0)
+	h.db.Model(&model.WorkspaceFile{}).
+		Select("parent_folder_id AS folder_id, COALESCE(SUM(file_size), 0) AS bytes, COUNT(*) AS files").
+		Where("workspace_id = ? AND type = ?", workspaceID, "FILE").
+		Group("parent_folder_id").
+		Order("bytes DESC").
+		Limit(storageBreakdownLimit).
+		Scan(&rows)
+
+	folderIDs := make([]int64, 0, len(rows))
+	for _, row := range rows {
+		if row.FolderID != nil {
+			folderIDs = append(folderIDs, *row.FolderID)
+		}
+	}
+	names := make(map[int64]string, len(folderIDs))
+	if len(folderIDs) > 0 {
+		var folders []model.WorkspaceFile
+		h.db.Select("id", "name").Where("id IN ?", folderIDs).Find(&folders)
+		for _, f := range folders {
+			names[f.ID] = f.Name
+		}
+	}
+	for i := range rows {
+		if rows[i].FolderID != nil {
+			rows[i].Name = names[*rows[i].FolderID]
+		}
+	}
+	return rows
+}
+
+func (h *StorageHandler) usageByUploader(workspaceID int64) []StorageUsageByUser {
+	rows := make([]StorageUsageByUser, 0)
+	h.db.Model(&model.WorkspaceFile{}).
+		Select("workspace_files.uploader_id AS user_id, COALESCE(users.nickname, '') AS nickname, "+
+			"COALESCE(SUM(workspace_files.file_size), 0) AS bytes, COUNT(*) AS files").
+		Joins("LEFT JOIN users ON users.id = workspace_files.uploader_id").
+		Where("workspace_files.workspace_id = ? AND workspace_files.type = ?", workspaceID, "FILE").
+		Group("workspace_files.uploader_id, users.nickname").
+		Order("bytes DESC").
+		Limit(storageBreakdownLimit).
+		Scan(&rows)
+	return rows
+}
+
+func (h *StorageHandler) usageByMimeType(workspaceID int64) []StorageUsageByType {
+	rows := make([]StorageUsageByType, 0)
+	h.db.Model(&model.WorkspaceFile{}).
+		Select("COALESCE(mime_type, '') AS mime_type, COALESCE(SUM(file_size), 0) AS bytes, COUNT(*) AS files").
+		Where("workspace_id = ? AND type = ?", workspaceID, "FILE").
+		Group("COALESCE(mime_type, '')").
+		Order("bytes DESC").
+		Limit(storageBreakdownLimit).
+		Scan(&rows)
+	return rows
+}
+
+func (h *StorageHandler) toStorageUsageResponse(quota *model.WorkspaceStorageQuota) StorageUsageResponse {
+	resp := StorageUsageResponse{
+		QuotaBytes: h.quotaLimit(quota),
+		UsedBytes:  quota.UsedBytes,
+		ByFolder:   []StorageUsageByFolder{},
+		ByUploader: []StorageUsageByUser{},
+		ByMimeType: []StorageUsageByType{},
+		IsCustom:   quota.QuotaBytes != nil,
+	}
+	if !quota.UpdatedAt.IsZero() {
+		t := quota.UpdatedAt.Format("2006-01-02T15:04:05Z07:00")
+		resp.UpdatedAt = &t
+	}
+	return resp
+}
@@ -0,0 +1,294 @@
+package handler
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+
+	"realtime-backend/internal/auth"
+	"realtime-backend/internal/model"
+)
+
+const (
+	fileSearchMinQuery      = 2
+	fileSearchDefaultLimit  = 20
+	fileSearchMaxLimit      = 50
+	fileSearchCandidates    = 200 // 폴더 권한 필터링 전에 조회하는 후보 수
+	fileSearchSnippetRadius = 80  // 일치 위치 앞뒤로 보여 줄 글자 수
+)
+
+// FileSearchResult 파일 검색 결과 (본문이 일치하면 일치 부분 발췌 포함)
+type FileSearchResult struct {
+	FileResponse
+	MatchedContent bool            `json:"matched_content"`
+	Snippet        *string         `json:"snippet,omitempty"`
+	Path           []FilePathEntry `json:"path"` // 루트부터 상위 폴더까지 (루트에 있으면 빈 배열)
+}
+
+// FilePathEntry 검색 결과 경로의 폴더
+type FilePathEntry struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+// fileSearchFilter 검색 조건 (빈 값은 조건 없음)
+type fileSearchFilter struct {
+	query      string
+	mimeType   string // "image/png" 또는 "image/*"
+	fileType   string // FILE, FOLDER
+	uploaderID int64
+	folderIDs  []int64 // folder_id와 그 하위 폴더
+	from       *time.Time
+	to         *time.Time
+}
+
+// SearchFiles 이름/추출한 문서 본문, 형식, 업로더, 폴더 범위, 업로드 날짜로 파일 검색
+// 접근할 수 없는 폴더의 파일은 제외하고, 결과마다 위치를 찾을 수 있도록 폴더 경로 포함
+func (h *StorageHandler) SearchFiles(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	workspaceID, err := c.ParamsInt("workspaceId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid workspace id",
+		})
+	}
+
+	if !h.isWorkspaceMember(int64(workspaceID), claims.UserID) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "you are not a member of this workspace",
+		})
+	}
+
+	limit := c.QueryInt("limit", fileSearchDefaultLimit)
+	if limit <= 0 || limit > fileSearchMaxLimit {
+		limit = fileSearchDefaultLimit
+	}
+
+	access := h.requireFolderAccess(c, int64(workspaceID), claims.UserID)
+	if access == nil {
+		return nil
+	}
+
+	tree := h.loadFolderTree(int64(workspaceID))
+	filter, errMsg := parseFileSearchFilter(c, tree)
+	if errMsg != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": errMsg,
+		})
+	}
+	if len(filter.folderIDs) > 0 && !access.folderAllowed(filter.folderIDs[0]) {
+		return folderForbidden(c)
+	}
+
+	var files []model.WorkspaceFile
+	err = h.fileSearchQuery(int64(workspaceID), filter).
+		Preload("Uploader").
+		Order("created_at DESC").
+		Limit(fileSearchCandidates).
+		Find(&files).Error
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to search files",
+		})
+	}
+
+	results := make([]FileSearchResult, 0, limit)
+	ids := make([]int64, 0, limit)
+	for i := range files {
+		if !access.fileAllowed(&files[i]) {
+			continue
+		}
+		results = append(results, FileSearchResult{FileResponse: h.toFileResponse(&files[i])})
+		ids = append(ids, files[i].ID)
+		if len(results) == limit {
+			break
+		}
+	}
+
+	if filter.query != "" {
+		h.attachSearchSnippets(results, ids, filter.query)
+	}
+	tree.attachPaths(results)
+
+	return c.JSON(fiber.Map{
+		"files": results,
+		"total": len(results),
+	})
+}
+
+// parseFileSearchFilter 쿼리 파라미터 검증 (q, mime_type, type, uploader_id, folder_id, from, to 중 하나 이상 필요)
+func parseFileSearchFilter(c *fiber.Ctx, tree *folderTree) (*fileSearchFilter, string) {
+	filter := &fileSearchFilter{
+		query:      strings.TrimSpace(c.Query("q")),
+		mimeType:   strings.ToLower(strings.TrimSpace(c.Query("mime_type"))),
+		fileType:   strings.ToUpper(c.Query("type")),
+		uploaderID: int64(c.QueryInt("uploader_id", 0)),
+	}
+
+	if filter.query != "" && len([]rune(filter.query)) < fileSearchMinQuery {
+		return nil, "search query must be at least 2 characters"
+	}
+	if filter.fileType != "" && filter.fileType != "FILE" && filter.fileType != "FOLDER" {
+		return nil, "type must be FILE or FOLDER"
+	}
+
+	if folderID := int64(c.QueryInt("folder_id", 0)); folderID > 0 {
+		if _, ok := tree.parents[folderID]; !ok {
+			return nil, "folder not found"
+		}
+		filter.folderIDs = append(filter.folderIDs, folderID)
+		for id := range tree.parents {
+			if tree.isDescendant(id, folderID) {
+				filter.folderIDs = append(filter.folderIDs, id)
+			}
+		}
+	}
+
+	var err error
+	if filter.from, err = parseSearchDate(c.Query("from"), false); err != nil {
+		return nil, "from must be a date (YYYY-MM-DD) or RFC3339 time"
+	}
+	if filter.to, err = parseSearchDate(c.Query("to"), true); err != nil {
+		return nil, "to must be a date (YYYY-MM-DD) or RFC3339 time"
+	}
+
+	if filter.query == "" && filter.mimeType == "" && filter.fileType == "" && filter.uploaderID == 0 &&
+		len(filter.folderIDs) == 0 && filter.from == nil && filter.to == nil {
+		return nil, "at least one search condition is required"
+	}
+	return filter, ""
+}
+
+// parseSearchDate 날짜만 주면 하루의 시작 (endOfDay면 다음 날 시작 직전까지 포함되도록 다음 날 0시)
+func parseSearchDate(value string, endOfDay bool) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+	t, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	if err != nil {
+		return nil, err
+	}
+	if endOfDay {
+		t = t.AddDate(0, 0, 1)
+	}
+	return &t, nil
+}
+
+// fileSearchQuery 검색 조건을 적용한 쿼리 (휴지통 항목은 자동 제외)
+func (h *StorageHandler) fileSearchQuery(workspaceID int64, filter *fileSearchFilter) *gorm.DB {
+	query := h.db.Where("workspace_id = ?", workspaceID)
+
+	if filter.query != "" {
+		pattern := "%" + filter.query + "%"
+		query = query.Where("name ILIKE ? OR id IN (?)", pattern,
+			h.db.Model(&model.FileText{}).Select("file_id").
+				Where("workspace_id = ? AND status = ? AND content ILIKE ?", workspaceID, model.FileTextCompleted.String(), pattern))
+	}
+	if prefix, ok := strings.CutSuffix(filter.mimeType, "/*"); ok {
+		query = query.Where("mime_type LIKE ?", prefix+"/%")
+	} else if filter.mimeType != "" {
+		query = query.Where("mime_type = ?", filter.mimeType)
+	}
+	if filter.fileType != "" {
+		query = query.Where("type = ?", filter.fileType)
+	}
+	if filter.uploaderID > 0 {
+		query = query.Where("uploader_id = ?", filter.uploaderID)
+	}
+	if len(filter.folderIDs) > 0 {
+		query = query.Where("parent_folder_id IN ?", filter.folderIDs)
+	}
+	if filter.from != nil {
+		query = query.Where("created_at >= ?", *filter.from)
+	}
+	if filter.to != nil {
+		query = query.Where("created_at < ?", *filter.to)
+	}
+	return query
+}
+
+// attachSearchSnippets 본문 일치 부분 발췌 (본문 전체를 읽지 않도록 DB에서 잘라 옴)
+func (h *StorageHandler) attachSearchSnippets(results []FileSearchResult, ids []int64, query string) {
+	if len(ids) == 0 {
+		return
+	}
+
+	var snippets []struct {
+		FileID  int64
+		Snippet string
+	}
+	h.db.Model(&model.FileText{}).
+		Select("file_id, substring(content from greatest(strpos(lower(content), lower(?)) - ?, 1) for ?) AS snippet",
+			query, fileSearchSnippetRadius, 2*fileSearchSnippetRadius+len([]rune(query))).
+		Where("file_id IN ? AND status = ? AND strpos(lower(content), lower(?)) > 0",
+			ids, model.FileTextCompleted.String(), query).
+		Scan(&snippets)
+
+	byFile := make(map[int64]string, len(snippets))
+	for _, s := range snippets {
+		byFile[s.FileID] = strings.Join(strings.Fields(s.Snippet), " ")
+	}
+	for i := range results {
+		if snippet, ok := byFile[results[i].ID]; ok {
+			results[i].MatchedContent = true
+			results[i].Snippet = &snippet
+		}
+	}
+}
+
+// folderTree 워크스페이스 폴더 구조 (검색 범위와 결과 경로 계산용, 휴지통 제외)
+type folderTree struct {
+	parents map[int64]*int64
+	names   map[int64]string
+}
+
+// loadFolderTree 폴더 id → 상위 폴더/이름 (폴더 행만 한 번에 조회)
+func (h *StorageHandler) loadFolderTree(workspaceID int64) *folderTree {
+	var folders []model.WorkspaceFile
+	h.db.Select("id", "parent_folder_id", "name").
+		Where("workspace_id = ? AND type = ?", workspaceID, "FOLDER").
+		Find(&folders)
+
+	tree := &folderTree{
+		parents: make(map[int64]*int64, len(folders)),
+		names:   make(map[int64]string, len(folders)),
+	}
+	for _, f := range folders {
+		tree.parents[f.ID] = f.ParentFolderID
+		tree.names[f.ID] = f.Name
+	}
+	return tree
+}
+
+// isDescendant folderID가 ancestorID의 하위 폴더인지
+func (t *folderTree) isDescendant(folderID, ancestorID int64) bool {
+	visited := make(map[int64]bool)
+	for parent := t.parents[folderID]; parent != nil && !visited[*parent]; parent = t.parents[*parent] {
+		if *parent == ancestorID {
+			return true
+		}
+		visited[*parent] = true
+	}
+	return false
+}
+
+// attachPaths 결과마다 루트부터 상위 폴더까지의 경로
+func (t *folderTree) attachPaths(results []FileSearchResult) {
+	for i := range results {
+		var path []FilePathEntry
+		visited := make(map[int64]bool)
+		for parent := results[i].ParentFolderID; parent != nil && !visited[*parent]; parent = t.parents[*parent] {
+			visited[*parent] = true
+			path = append([]FilePathEntry{{ID: *parent, Name: t.names[*parent]}}, path...)
+		}
+		if path == nil {
+			path = []FilePathEntry{}
+		}
+		results[i].Path = path
+	}
+}
@@ -0,0 +1,544 @@
+package handler
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+
+	"realtime-backend/internal/auth"
+	"realtime-backend/internal/model"
+)
+
+const (
+	shareLinkDefaultDays   = 7
+	shareLinkMaxDays       = 30
+	shareLinkMinPassword   = 4
+	shareLinkMaxFolderList = 500 // 폴더 링크에서 보여 줄 최대 파일 수
+	shareMaxRecipients     = 50  // 멤버에게 공유할 때 한 번에 보낼 수 있는 최대 인원
+	shareMaxMessage        = 200
+)
+
+// CreateShareLinkRequest 공유 링크 생성 요청
+type CreateShareLinkRequest struct {
+	ExpiresInDays int    `json:"expires_in_days"` // 0이면 7일, 최대 30일
+	Password      string `json:"password,omitempty"`
+}
+
+// ShareWithMembersRequest 워크스페이스 멤버에게 파일/폴더 공유 (알림 전송)
+type ShareWithMembersRequest struct {
+	UserIDs []int64 `json:"user_ids"`
+	Message string  `json:"message,omitempty"` // 알림에 덧붙일 메시지
+}
+
+// ShareLinkResponse 공유 링크 응답 (관리용)
+type ShareLinkResponse struct {
+	ID            int64         `json:"id"`
+	FileID        int64         `json:"file_id"`
+	Token         string        `json:"token"`
+	HasPassword   bool          `json:"has_password"`
+	ExpiresAt     string        `json:"expires_at"`
+	DownloadCount int           `json:"download_count"`
+	CreatedBy     int64         `json:"created_by"`
+	Creator       *UserResponse `json:"creator,omitempty"`
+	CreatedAt     string        `json:"created_at"`
+}
+
+// SharedFileResponse 링크로 공유된 파일/폴더 정보 (비로그인 사용자용, 내부 식별자 최소화)
+type SharedFileResponse struct {
+	Name             string  `json:"name"`
+	Type             string  `json:"type"` // FILE, FOLDER
+	FileSize         *int64  `json:"file_size,omitempty"`
+	MimeType         *string `json:"mime_type,omitempty"`
+	ExpiresAt        string  `json:"expires_at"`
+	RequiresPassword bool    `json:"requires_password"`
+}
+
+// SharedFolderEntry 폴더 링크에 포함된 파일
+type SharedFolderEntry struct {
+	ID       int64   `json:"id"`
+	Path     string  `json:"path"` // 공유 폴더 기준 상대 경로
+	FileSize *int64  `json:"file_size,omitempty"`
+	MimeType *string `json:"mime_type,omitempty"`
+}
+
+// ShareDownloadRequest 공유 링크 다운로드 요청 (폴더 링크는 file_id가 없으면 파일 목록 반환)
+type ShareDownloadRequest struct {
+	Password string `json:"password,omitempty"`
+	FileID   int64  `json:"file_id,omitempty"`
+}
+
+// CreateShareLink 파일/폴더 공유 링크 생성 (접근할 수 있는 멤버 누구나)
+func (h *StorageHandler) CreateShareLink(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	file, ok := h.requireSharableFile(c, claims.UserID)
+	if !ok {
+		return nil
+	}
+
+	var req CreateShareLinkRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "invalid request body",
+			})
+		}
+	}
+
+	days := req.ExpiresInDays
+	if days == 0 {
+		days = shareLinkDefaultDays
+	}
+	if days < 0 || days > shareLinkMaxDays {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": fmt.Sprintf("expires_in_days must be between 1 and %d", shareLinkMaxDays),
+		})
+	}
+
+	var passwordHash *string
+	if req.Password != "" {
+		if len([]rune(req.Password)) < shareLinkMinPassword {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": fmt.Sprintf("password must be at least %d characters", shareLinkMinPassword),
+			})
+		}
+		hash, err := auth.HashPassword(req.Password)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "failed to create share link",
+			})
+		}
+		passwordHash = &hash
+	}
+
+	token, err := newShareToken()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to create share link",
+		})
+	}
+
+	link := model.FileShareLink{
+		WorkspaceID:  file.WorkspaceID,
+		FileID:       file.ID,
+		Token:        token,
+		PasswordHash: passwordHash,
+		ExpiresAt:    time.Now().AddDate(0, 0, days),
+		CreatedBy:    claims.UserID,
+	}
+	if err := h.db.Create(&link).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to create share link",
+		})
+	}
+
+	log.Printf("🔗 공유 링크 생성: file=%d, link=%d, user=%d", file.ID, link.ID, claims.UserID)
+
+	return c.Status(fiber.StatusCreated).JSON(toShareLinkResponse(&link))
+}
+
+// ShareWithMembers 파일/폴더를 멤버에게 공유 (FILE_SHARED 알림, 해당 폴더에 접근할 수 없는 멤버는 제외)
+func (h *StorageHandler) ShareWithMembers(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	file, ok := h.requireSharableFile(c, claims.UserID)
+	if !ok {
+		return nil
+	}
+
+	var req ShareWithMembersRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+	if len(req.UserIDs) == 0 || len(req.UserIDs) > shareMaxRecipients {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": fmt.Sprintf("user_ids must contain between 1 and %d users", shareMaxRecipients),
+		})
+	}
+	message := strings.TrimSpace(req.Message)
+	if len([]rune(message)) > shareMaxMessage {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": fmt.Sprintf("message must be at most %d characters", shareMaxMessage),
+		})
+	}
+
+	var members []int64
+	h.db.Model(&model.WorkspaceMember{}).
+		Where("workspace_id = ? AND user_id IN ? AND user_id <> ? AND status = ?", file.WorkspaceID, req.UserIDs, claims.UserID, model.MemberStatusActive.String()).
+		Pluck("user_id", &members)
+
+	sharedWith := make([]int64, 0, len(members))
+	for _, userID := range members {
+		access, err := h.loadFolderAccess(file.WorkspaceID, userID)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "failed to share file",
+			})
+		}
+		if !access.fileAllowed(file) || (file.Type == "FOLDER" && !access.folderAllowed(file.ID)) {
+			continue
+		}
+		sharedWith = append(sharedWith, userID)
+	}
+	if len(sharedWith) == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "none of the users can access this file",
+		})
+	}
+
+	var sharer model.User
+	h.db.Select("id", "nickname").First(&sharer, claims.UserID)
+	kind := "파일"
+	if file.Type == "FOLDER" {
+		kind = "폴더"
+	}
+	content := fmt.Sprintf("%s님이 '%s' %s을(를) 공유했습니다.", sharer.Nickname, file.Name, kind)
+	if message != "" {
+		content += " " + message
+	}
+	relatedType := "FILE"
+	if err := createActionNotifications(h.db, sharedWith, &claims.UserID, model.NotificationTypeFileShared.String(), content, &relatedType, &file.ID, fileNotificationData(file)); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to share file",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"shared_with": sharedWith,
+	})
+}
+
+// GetShareLinks 파일의 유효한 공유 링크 목록
+func (h *StorageHandler) GetShareLinks(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	file, ok := h.requireSharableFile(c, claims.UserID)
+	if !ok {
+		return nil
+	}
+
+	var links []model.FileShareLink
+	h.db.Preload("Creator").
+		Where("file_id = ? AND revoked_at IS NULL AND expires_at > ?", file.ID, time.Now()).
+		Order("created_at DESC").
+		Find(&links)
+
+	responses := make([]ShareLinkResponse, len(links))
+	for i := range links {
+		responses[i] = toShareLinkResponse(&links[i])
+	}
+
+	return c.JSON(fiber.Map{
+		"links": responses,
+		"total": len(responses),
+	})
+}
+
+// RevokeShareLink 공유 링크 취소 (만든 사람 또는 ADMIN)
+func (h *StorageHandler) RevokeShareLink(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	workspaceID, err := c.ParamsInt("workspaceId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid workspace id",
+		})
+	}
+	linkID, err := c.ParamsInt("linkId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid link id",
+		})
+	}
+
+	if !h.isWorkspaceMember(int64(workspaceID), claims.UserID) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "you are not a member of this workspace",
+		})
+	}
+
+	var link model.FileShareLink
+	if err := h.db.Where("id = ? AND workspace_id = ? AND revoked_at IS NULL", linkID, workspaceID).First(&link).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "share link not found",
+		})
+	}
+
+	if link.CreatedBy != claims.UserID {
+		hasPermission, err := auth.CheckPermission(h.db, int64(workspaceID), claims.UserID, "ADMIN")
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "failed to check permission",
+			})
+		}
+		if !hasPermission {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": "only the link creator or workspace admins can revoke this link",
+			})
+		}
+	}
+
+	now := time.Now()
+	h.db.Model(&link).Updates(map[string]interface{}{
+		"revoked_at": now,
+		"revoked_by": claims.UserID,
+	})
+
+	return c.JSON(fiber.Map{
+		"message": "share link revoked",
+	})
+}
+
+// GetSharedFile 공유 링크 대상 정보 (인증 불필요)
+func (h *StorageHandler) GetSharedFile(c *fiber.Ctx) error {
+	link, ok := h.requireShareLink(c)
+	if !ok {
+		return nil
+	}
+
+	return c.JSON(SharedFileResponse{
+		Name:             link.File.Name,
+		Type:             link.File.Type,
+		FileSize:         link.File.FileSize,
+		MimeType:         link.File.MimeType,
+		ExpiresAt:        link.ExpiresAt.Format("2006-01-02T15:04:05Z07:00"),
+		RequiresPassword: link.PasswordHash != nil,
+	})
+}
+
+// DownloadSharedFile 공유 링크를 Presigned 다운로드 URL로 변환 (인증 불필요)
+// 폴더 링크는 file_id 없이 호출하면 포함된 파일 목록을, file_id를 주면 해당 파일 URL을 반환
+func (h *StorageHandler) DownloadSharedFile(c *fiber.Ctx) error {
+	link, ok := h.requireShareLink(c)
+	if !ok {
+		return nil
+	}
+
+	var req ShareDownloadRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "invalid request body",
+			})
+		}
+	}
+
+	if link.PasswordHash != nil {
+		matched, err := auth.CheckPassword(*link.PasswordHash, req.Password)
+		if err != nil || !matched {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"error": "incorrect password",
+			})
+		}
+	}
+
+	// 링크를 만든 멤버가 지금도 볼 수 있는 범위만 공개
+	access, err := h.loadFolderAccess(link.WorkspaceID, link.CreatedBy)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to check folder permissions",
+		})
+	}
+
+	file := &link.File
+	if file.Type == "FOLDER" {
+		if !access.folderAllowed(file.ID) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "share link not found",
+			})
+		}
+		if req.FileID == 0 {
+			return c.JSON(fiber.Map{
+				"files": h.collectSharedFolderEntries(file, access),
+			})
+		}
+
+		file = h.findSharedFolderFile(link, req.FileID)
+		if file == nil || !access.fileAllowed(file) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "file not found",
+			})
+		}
+	} else if !access.fileAllowed(file) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "share link not found",
+		})
+	}
+
+	var url string
+	if file.S3Key != nil && *file.S3Key != "" {
+		s3Service, err := h.s3ForWorkspace(link.WorkspaceID)
+		if err != nil {
+			return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+				"error": "storage is not available in workspace data region",
+			})
+		}
+		url, err = s3Service.GetFileURL(*file.S3Key)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "failed to generate download URL",
+			})
+		}
+	} else if file.FileURL != nil {
+		url = *file.FileURL
+	} else {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "file URL not found",
+		})
+	}
+
+	h.db.Model(&model.FileShareLink{}).
+		Where("id = ?", link.ID).
+		UpdateColumn("download_count", gorm.Expr("download_count + 1"))
+	h.recordFileActivity(file, nil, model.FileActionDownload, fmt.Sprintf("share link %d", link.ID))
+
+	return c.JSON(fiber.Map{
+		"url":  url,
+		"name": file.Name,
+	})
+}
+
+// requireSharableFile 경로의 파일 조회 + 멤버/폴더 권한 확인 (실패 시 응답을 쓰고 false)
+func (h *StorageHandler) requireSharableFile(c *fiber.Ctx, userID int64) (*model.WorkspaceFile, bool) {
+	workspaceID, err := c.ParamsInt("workspaceId")
+	if err != nil {
+		c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid workspace id",
+		})
+		return nil, false
+	}
+	fileID, err := c.ParamsInt("fileId")
+	if err != nil {
+		c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid file id",
+		})
+		return nil, false
+	}
+
+	if !h.isWorkspaceMember(int64(workspaceID), userID) {
+		c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "you are not a member of this workspace",
+		})
+		return nil, false
+	}
+
+	var file model.WorkspaceFile
+	if err := h.db.Where("id = ? AND workspace_id = ?", fileID, workspaceID).First(&file).Error; err != nil {
+		c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "file not found",
+		})
+		return nil, false
+	}
+
+	access := h.requireFolderAccess(c, int64(workspaceID), userID)
+	if access == nil {
+		return nil, false
+	}
+	if !access.fileAllowed(&file) || (file.Type == "FOLDER" && !access.folderAllowed(file.ID)) {
+		folderForbidden(c)
+		return nil, false
+	}
+	return &file, true
+}
+
+// requireShareLink 토큰으로 유효한 링크 조회 (만료/취소/휴지통 이동/만든 사람 탈퇴 시 404)
+func (h *StorageHandler) requireShareLink(c *fiber.Ctx) (*model.FileShareLink, bool) {
+	var link model.FileShareLink
+	err := h.db.Joins("File").
+		Where("file_share_links.token = ? AND file_share_links.revoked_at IS NULL AND file_share_links.expires_at > ?",
+			c.Params("token"), time.Now()).
+		First(&link).Error
+	if err != nil || link.File.ID == 0 || !h.isWorkspaceMember(link.WorkspaceID, link.CreatedBy) {
+		c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "share link not found",
+		})
+		return nil, false
+	}
+	return &link, true
+}
+
+// collectSharedFolderEntries 공유 폴더 하위 파일 목록 (상대 경로 포함, 최대 500개)
+func (h *StorageHandler) collectSharedFolderEntries(folder *model.WorkspaceFile, access *folderAccess) []SharedFolderEntry {
+	tree := h.loadFolderTree(folder.WorkspaceID)
+	folderIDs := []int64{folder.ID}
+	for id := range tree.parents {
+		if tree.isDescendant(id, folder.ID) && access.folderAllowed(id) {
+			folderIDs = append(folderIDs, id)
+		}
+	}
+
+	var files []model.WorkspaceFile
+	h.db.Where("parent_folder_id IN ? AND type = ?", folderIDs, "FILE").
+		Order("name ASC").
+		Limit(shareLinkMaxFolderList).
+		Find(&files)
+
+	entries := make([]SharedFolderEntry, 0, len(files))
+	for _, f := range files {
+		path := f.Name
+		for parent := f.ParentFolderID; parent != nil && *parent != folder.ID; parent = tree.parents[*parent] {
+			path = tree.names[*parent] + "/" + path
+		}
+		entries = append(entries, SharedFolderEntry{
+			ID:       f.ID,
+			Path:     path,
+			FileSize: f.FileSize,
+			MimeType: f.MimeType,
+		})
+	}
+	return entries
+}
+
+// findSharedFolderFile 공유 폴더 하위의 파일 조회 (범위 밖이면 nil)
+func (h *StorageHandler) findSharedFolderFile(link *model.FileShareLink, fileID int64) *model.WorkspaceFile {
+	var file model.WorkspaceFile
+	if err := h.db.Where("id = ? AND workspace_id = ? AND type = ?", fileID, link.WorkspaceID, "FILE").First(&file).Error; err != nil {
+		return nil
+	}
+	if file.ParentFolderID == nil {
+		return nil
+	}
+	if *file.ParentFolderID != link.FileID && !h.loadFolderTree(link.WorkspaceID).isDescendant(*file.ParentFolderID, link.FileID) {
+		return nil
+	}
+	return &file
+}
+
+// deleteFileShareLinksWithTx 삭제되는 파일의 공유 링크 정리
+func deleteFileShareLinksWithTx(tx *gorm.DB, fileIDs []int64) {
+	tx.Where("file_id IN ?", fileIDs).Delete(&model.FileShareLink{})
+}
+
+func newShareToken() (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+func toShareLinkResponse(link *model.FileShareLink) ShareLinkResponse {
+	resp := ShareLinkResponse{
+		ID:            link.ID,
+		FileID:        link.FileID,
+		Token:         link.Token,
+		HasPassword:   link.PasswordHash != nil,
+		ExpiresAt:     link.ExpiresAt.Format("2006-01-02T15:04:05Z07:00"),
+		DownloadCount: link.DownloadCount,
+		CreatedBy:     link.CreatedBy,
+		CreatedAt:     link.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	if link.Creator.ID != 0 {
+		resp.Creator = &UserResponse{
+			ID:         link.Creator.ID,
+			Email:      link.Creator.Email,
+			Nickname:   link.Creator.Nickname,
+			ProfileImg: link.Creator.ProfileImg,
+		}
+	}
+	return resp
+}
@@ -0,0 +1,428 @@
+package handler
+
+import (
+	"log"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+
+	"realtime-backend/internal/auth"
+	"realtime-backend/internal/errorreport"
+	"realtime-backend/internal/model"
+	"realtime-backend/internal/storage"
+)
+
+const (
+	defaultTrashRetention = 30 * 24 * time.Hour
+	trashSweepInterval    = time.Hour
+	trashSweepBatch       = 100
+)
+
+// TrashItemResponse 휴지통 항목 (함께 옮긴 하위 항목은 ItemCount로만 표시)
+type TrashItemResponse struct {
+	FileResponse
+	DeletedAt string `json:"deleted_at"`
+	DeletedBy *int64 `json:"deleted_by,omitempty"`
+	PurgeAt   string `json:"purge_at"`
+	ItemCount int64  `json:"item_count"` // 폴더면 하위 항목 포함
+}
+
+// SetTrashRetention 휴지통 보관 기간 설정 및 만료 항목 정리 시작
+func (h *StorageHandler) SetTrashRetention(retention time.Duration) {
+	if retention > 0 {
+		h.trashRetention = retention
+	}
+	if h.trashDone == nil {
+		h.trashDone = make(chan struct{})
+		go h.runTrashSweeper(h.trashDone)
+	}
+}
+
+// GetTrash 휴지통 목록 (최근에 지운 순)
+func (h *StorageHandler) GetTrash(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	workspaceID, err := c.ParamsInt("workspaceId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid workspace id",
+		})
+	}
+
+	if !h.isWorkspaceMember(int64(workspaceID), claims.UserID) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "you are not a member of this workspace",
+		})
+	}
+
+	access := h.requireFolderAccess(c, int64(workspaceID), claims.UserID)
+	if access == nil {
+		return nil
+	}
+
+	var files []model.WorkspaceFile
+	err = h.db.Unscoped().
+		Where("workspace_id = ? AND deleted_at IS NOT NULL AND trash_root_id = id", workspaceID).
+		Preload("Uploader").
+		Order("deleted_at DESC").
+		Find(&files).Error
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get trash",
+		})
+	}
+
+	rootIDs := make([]int64, 0, len(files))
+	for _, f := range files {
+		rootIDs = append(rootIDs, f.ID)
+	}
+	counts := h.trashItemCounts(rootIDs)
+
+	items := make([]TrashItemResponse, 0, len(files))
+	for i := range files {
+		if !access.fileAllowed(&files[i]) {
+			continue
+		}
+		items = append(items, h.toTrashItemResponse(&files[i], counts[files[i].ID]))
+	}
+
+	return c.JSON(fiber.Map{
+		"items":          items,
+		"total":          len(items),
+		"retention_days": int(h.trashRetention.Hours() / 24),
+	})
+}
+
+// RestoreFile 휴지통 항목 복원 (원래 폴더가 없어졌으면 루트로)
+func (h *StorageHandler) RestoreFile(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	file, ok := h.requireTrashItem(c, claims.UserID)
+	if !ok {
+		return nil
+	}
+
+	var workspace model.Workspace
+	h.db.First(&workspace, file.WorkspaceID)
+
+	deletedByUser := file.DeletedBy != nil && *file.DeletedBy == claims.UserID
+	uploadedByUser := file.UploaderID != nil && *file.UploaderID == claims.UserID
+	if !deletedByUser && !uploadedByUser && workspace.OwnerID != claims.UserID {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "you don't have permission to restore this file",
+		})
+	}
+
+	access := h.requireFolderAccess(c, file.WorkspaceID, claims.UserID)
+	if access == nil {
+		return nil
+	}
+	if !access.fileAllowed(file) {
+		return folderForbidden(c)
+	}
+
+	// 원래 상위 폴더도 휴지통에 있거나 삭제됐으면 루트로 복원
+	if file.ParentFolderID != nil {
+		var count int64
+		h.db.Model(&model.WorkspaceFile{}).Where("id = ?", *file.ParentFolderID).Count(&count)
+		if count == 0 {
+			file.ParentFolderID = nil
+		}
+	}
+
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Model(&model.WorkspaceFile{}).
+			Where("id = ?", file.ID).
+			Update("parent_folder_id", file.ParentFolderID).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Model(&model.WorkspaceFile{}).
+			Where("trash_root_id = ?", file.ID).
+			Updates(map[string]interface{}{"deleted_at": nil, "deleted_by": nil, "trash_root_id": nil}).Error
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to restore file",
+		})
+	}
+
+	h.db.Preload("Uploader").First(file, file.ID)
+	h.recordFileActivity(file, &claims.UserID, model.FileActionRestore, "")
+	return c.JSON(h.toFileResponse(file))
+}
+
+// PurgeTrashItem 휴지통 항목 영구 삭제 (ADMIN 권한 필요)
+func (h *StorageHandler) PurgeTrashItem(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	file, ok := h.requireTrashItem(c, claims.UserID)
+	if !ok {
+		return nil
+	}
+	if !h.requireTrashAdmin(c, file.WorkspaceID, claims.UserID) {
+		return nil
+	}
+
+	if err := h.purgeTrashItems(file.WorkspaceID, []model.WorkspaceFile{*file}, &claims.UserID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to delete file",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "file permanently deleted",
+	})
+}
+
+// EmptyTrash 휴지통 비우기 (ADMIN 권한 필요)
+func (h *StorageHandler) EmptyTrash(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	workspaceID, err := c.ParamsInt("workspaceId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid workspace id",
+		})
+	}
+	if !h.requireTrashAdmin(c, int64(workspaceID), claims.UserID) {
+		return nil
+	}
+
+	var roots []model.WorkspaceFile
+	h.db.Unscoped().
+		Where("workspace_id = ? AND deleted_at IS NOT NULL AND trash_root_id = id", workspaceID).
+		Find(&roots)
+
+	if err := h.purgeTrashItems(int64(workspaceID), roots, &claims.UserID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to empty trash",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "trash emptied",
+		"deleted": len(roots),
+	})
+}
+
+// requireTrashItem 워크스페이스 멤버 확인 + 휴지통 최상위 항목 조회 (실패 시 응답을 쓰고 false)
+func (h *StorageHandler) requireTrashItem(c *fiber.Ctx, userID int64) (*model.WorkspaceFile, bool) {
+	workspaceID, err := c.ParamsInt("workspaceId")
+	if err != nil {
+		c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid workspace id",
+		})
+		return nil, false
+	}
+	fileID, err := c.ParamsInt("fileId")
+	if err != nil {
+		c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid file id",
+		})
+		return nil, false
+	}
+
+	if !h.isWorkspaceMember(int64(workspaceID), userID) {
+		c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "you are not a member of this workspace",
+		})
+		return nil, false
+	}
+
+	var file model.WorkspaceFile
+	err = h.db.Unscoped().
+		Where("id = ? AND workspace_id = ? AND deleted_at IS NOT NULL AND trash_root_id = id", fileID, workspaceID).
+		First(&file).Error
+	if err != nil {
+		c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "file not found in trash",
+		})
+		return nil, false
+	}
+	return &file, true
+}
+
+// requireTrashAdmin 영구 삭제 권한 확인 (실패 시 응답을 쓰고 false)
+func (h *StorageHandler) requireTrashAdmin(c *fiber.Ctx, workspaceID, userID int64) bool {
+	hasPermission, err := auth.CheckPermission(h.db, workspaceID, userID, "ADMIN")
+	if err != nil {
+		c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to check permission",
+		})
+		return false
+	}
+	if !hasPermission {
+		c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "only workspace admins can permanently delete files",
+		})
+		return false
+	}
+	return true
+}
+
+// moveToTrashWithTx 항목과 하위 항목을 휴지통으로 이동 (TrashRootID로 묶어 함께 복원)
+// 이미 따로 휴지통에 옮긴 하위 항목은 자신의 묶음을 유지
+func moveToTrashWithTx(tx *gorm.DB, file *model.WorkspaceFile, userID int64) error {
+	ids := []int64{file.ID}
+	if file.Type == "FOLDER" {
+		children, err := collectSubtreeIDsWithTx(tx, file.ID, false)
+		if err != nil {
+			return err
+		}
+		ids = append(ids, children...)
+	}
+
+	return tx.Model(&model.WorkspaceFile{}).
+		Where("id IN ?", ids).
+		Updates(map[string]interface{}{
+			"deleted_at":    time.Now(),
+			"deleted_by":    userID,
+			"trash_root_id": file.ID,
+		}).Error
+}
+
+// purgeFileWithTx 항목과 하위 항목(따로 휴지통에 옮긴 항목 포함), 관련 기록을 영구 삭제
+func (h *StorageHandler) purgeFileWithTx(tx *gorm.DB, file *model.WorkspaceFile, s3Objects map[string]int64) error {
+	var ids []int64
+	if file.Type == "FOLDER" {
+		var err error
+		if ids, err = collectSubtreeIDsWithTx(tx, file.ID, true); err != nil {
+			return err
+		}
+	}
+	return h.deleteFilesWithTx(tx, append(ids, file.ID), s3Objects)
+}
+
+// purgeTrashItems 휴지통 항목 영구 삭제 후 더 이상 참조되지 않는 S3 객체 삭제 및 사용량 반환 (actorID가 nil이면 보관 기간 만료)
+func (h *StorageHandler) purgeTrashItems(workspaceID int64, roots []model.WorkspaceFile, actorID *int64) error {
+	if len(roots) == 0 {
+		return nil
+	}
+
+	s3Objects := make(map[string]int64)
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		for i := range roots {
+			if err := h.purgeFileWithTx(tx, &roots[i], s3Objects); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for i := range roots {
+		h.recordFileActivity(&roots[i], actorID, model.FileActionPurge, "")
+	}
+
+	keys := make([]string, 0, len(s3Objects))
+	for key := range s3Objects {
+		keys = append(keys, key)
+	}
+	unreferenced := h.unreferencedS3Keys(keys)
+
+	var freed int64
+	for _, key := range unreferenced {
+		freed += s3Objects[key]
+	}
+	h.addStorageUsage(workspaceID, -freed)
+
+	// DB 삭제 성공 후 S3 파일 삭제 (실패해도 무시, 다른 항목이 공유하는 객체는 유지)
+	if s3Service, err := h.s3ForWorkspace(workspaceID); err == nil {
+		if err := s3Service.DeleteFiles(append(unreferenced, storage.ThumbnailKeys(unreferenced)...)); err != nil {
+			log.Printf("⚠️ S3 객체 삭제 실패: workspace=%d, err=%v", workspaceID, err)
+		}
+	}
+	return nil
+}
+
+// collectS3Object 영구 삭제할 항목의 S3 키와 크기 기록
+func collectS3Object(s3Objects map[string]int64, f *model.WorkspaceFile) {
+	if f.S3Key == nil || *f.S3Key == "" {
+		return
+	}
+	var size int64
+	if f.FileSize != nil {
+		size = *f.FileSize
+	}
+	if size >= s3Objects[*f.S3Key] {
+		s3Objects[*f.S3Key] = size
+	}
+}
+
+// runTrashSweeper 보관 기간이 지난 휴지통 항목을 주기적으로 영구 삭제
+func (h *StorageHandler) runTrashSweeper(done <-chan struct{}) {
+	defer errorreport.Recover(errorreport.Context{Component: "storage.trash_sweeper"})
+
+	ticker := time.NewTicker(trashSweepInterval)
+	defer ticker.Stop()
+
+	for {
+		h.sweepTrash()
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (h *StorageHandler) sweepTrash() {
+	cutoff := time.Now().Add(-h.trashRetention)
+	for {
+		var expired []model.WorkspaceFile
+		h.db.Unscoped().
+			Where("deleted_at < ? AND trash_root_id = id", cutoff).
+			Order("deleted_at ASC").
+			Limit(trashSweepBatch).
+			Find(&expired)
+		if len(expired) == 0 {
+			return
+		}
+
+		byWorkspace := make(map[int64][]model.WorkspaceFile)
+		for _, f := range expired {
+			byWorkspace[f.WorkspaceID] = append(byWorkspace[f.WorkspaceID], f)
+		}
+		for workspaceID, roots := range byWorkspace {
+			if err := h.purgeTrashItems(workspaceID, roots, nil); err != nil {
+				log.Printf("⚠️ 휴지통 정리 실패 (workspace %d): %v", workspaceID, err)
+				return
+			}
+		}
+		log.Printf("🗑️ 보관 기간이 지난 휴지통 항목 %d개 영구 삭제", len(expired))
+
+		if len(expired) < trashSweepBatch {
+			return
+		}
+	}
+}
+
+// trashItemCounts 휴지통 묶음별 항목 수
+func (h *StorageHandler) trashItemCounts(rootIDs []int64) map[int64]int64 {
+	counts := make(map[int64]int64, len(rootIDs))
+	if len(rootIDs) == 0 {
+		return counts
+	}
+
+	var rows []struct {
+		TrashRootID int64
+		Count       int64
+	}
+	h.db.Unscoped().Model(&model.WorkspaceFile{}).
+		Select("trash_root_id, COUNT(*) AS count").
+		Where("trash_root_id IN ?", rootIDs).
+		Group("trash_root_id").
+		Scan(&rows)
+	for _, row := range rows {
+		counts[row.TrashRootID] = row.Count
+	}
+	return counts
+}
+
+func (h *StorageHandler) toTrashItemResponse(f *model.WorkspaceFile, count int64) TrashItemResponse {
+	return TrashItemResponse{
+		FileResponse: h.toFileResponse(f),
+		DeletedAt:    f.DeletedAt.Time.Format("2006-01-02T15:04:05Z07:00"),
+		DeletedBy:    f.DeletedBy,
+		PurgeAt:      f.DeletedAt.Time.Add(h.trashRetention).Format("2006-01-02T15:04:05Z07:00"),
+		ItemCount:    count,
+	}
+}
@@ -0,0 +1,366 @@
+package handler
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"realtime-backend/internal/auth"
+	"realtime-backend/internal/model"
+	"realtime-backend/internal/storage"
+)
+
+const (
+	zipJobTimeout          = time.Hour
+	zipJobProgressInterval = 2 * time.Second // 진행률 DB 갱신 최소 간격
+)
+
+// zipLimits 폴더 ZIP 다운로드 제한
+type zipLimits struct {
+	maxSize     int64
+	streamLimit int64
+	concurrency int
+}
+
+// FileZipJobResponse 폴더 ZIP 작업 응답
+type FileZipJobResponse struct {
+	ID          int64   `json:"id"`
+	WorkspaceID int64   `json:"workspace_id"`
+	FolderID    int64   `json:"folder_id"`
+	Status      string  `json:"status"`
+	TotalFiles  int     `json:"total_files"`
+	DoneFiles   int     `json:"done_files"`
+	TotalBytes  int64   `json:"total_bytes"`
+	DoneBytes   int64   `json:"done_bytes"`
+	DownloadURL *string `json:"download_url,omitempty"` // COMPLETED일 때만
+	Error       *string `json:"error,omitempty"`
+	CreatedAt   string  `json:"created_at"`
+	CompletedAt *string `json:"completed_at,omitempty"`
+}
+
+// SetZipLimits 폴더 ZIP 최대 크기, 즉시 스트리밍 한도, S3 동시 다운로드 수 설정
+func (h *StorageHandler) SetZipLimits(maxSize, streamLimit int64, concurrency int) {
+	h.zip = zipLimits{maxSize: maxSize, streamLimit: streamLimit, concurrency: concurrency}
+}
+
+// DownloadFolderZip 폴더 내용을 ZIP으로 다운로드
+// 작은 폴더는 바로 스트리밍하고, 큰 폴더(또는 ?async=true)는 백그라운드 작업으로 만든 뒤 알림으로 안내
+func (h *StorageHandler) DownloadFolderZip(c *fiber.Ctx) error {
+	if h.s3 == nil {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+			"error": "S3 service is not configured",
+		})
+	}
+
+	claims := c.Locals("claims").(*auth.Claims)
+	workspaceID, err := c.ParamsInt("workspaceId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid workspace id",
+		})
+	}
+	folderID, err := c.ParamsInt("folderId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid folder id",
+		})
+	}
+
+	// 멤버 확인
+	if !h.isWorkspaceMember(int64(workspaceID), claims.UserID) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "you are not a member of this workspace",
+		})
+	}
+
+	var folder model.WorkspaceFile
+	if err := h.db.Where("id = ? AND workspace_id = ? AND type = ?", folderID, workspaceID, "FOLDER").First(&folder).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "folder not found",
+		})
+	}
+
+	access := h.requireFolderAccess(c, int64(workspaceID), claims.UserID)
+	if access == nil {
+		return nil
+	}
+	if !access.folderAllowed(folder.ID) {
+		return folderForbidden(c)
+	}
+
+	h.recordFileActivity(&folder, &claims.UserID, model.FileActionDownload, "zip")
+
+	folderName := sanitizeZipPath(folder.Name)
+	entries, totalBytes := h.collectZipEntries(folder.ID, folderName+"/", map[int64]bool{}, access)
+	if h.zip.maxSize > 0 && totalBytes > h.zip.maxSize {
+		return c.Status(fiber.StatusRequestEntityTooLarge).JSON(fiber.Map{
+			"error":       "folder is too large to download as zip",
+			"total_bytes": totalBytes,
+			"max_bytes":   h.zip.maxSize,
+		})
+	}
+
+	s3Service, err := h.s3ForWorkspace(int64(workspaceID))
+	if err != nil {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+			"error": "storage is not available in workspace data region",
+		})
+	}
+
+	if c.QueryBool("async") || (h.zip.streamLimit > 0 && totalBytes > h.zip.streamLimit) {
+		job := model.FileZipJob{
+			WorkspaceID: int64(workspaceID),
+			FolderID:    folder.ID,
+			RequestedBy: claims.UserID,
+			Status:      model.FileZipJobPending.String(),
+			TotalFiles:  countZipFiles(entries),
+			TotalBytes:  totalBytes,
+		}
+		if err := h.db.Create(&job).Error; err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "failed to create zip job",
+			})
+		}
+
+		go h.runZipJob(job, folder.Name, entries, s3Service)
+
+		return c.Status(fiber.StatusAccepted).JSON(h.toZipJobResponse(&job, nil))
+	}
+
+	concurrency := h.zip.concurrency
+	c.Set(fiber.HeaderContentType, "application/zip")
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename*=UTF-8''%s", url.PathEscape(folderName+".zip")))
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		if err := s3Service.WriteZip(context.Background(), w, entries, concurrency, nil); err != nil {
+			log.Printf("⚠️ 폴더 ZIP 스트리밍 중단: folder=%d, err=%v", folder.ID, err)
+			return
+		}
+		w.Flush()
+	})
+	return nil
+}
+
+// GetZipJob 폴더 ZIP 작업 진행 상황 조회 (요청자 본인만)
+func (h *StorageHandler) GetZipJob(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	workspaceID, err := c.ParamsInt("workspaceId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid workspace id",
+		})
+	}
+	jobID, err := c.ParamsInt("jobId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid job id",
+		})
+	}
+
+	var job model.FileZipJob
+	if err := h.db.Where("id = ? AND workspace_id = ? AND requested_by = ?", jobID, workspaceID, claims.UserID).First(&job).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "zip job not found",
+		})
+	}
+
+	var downloadURL *string
+	if job.Status == model.FileZipJobCompleted.String() && job.S3Key != nil {
+		s3Service, err := h.s3ForWorkspace(job.WorkspaceID)
+		if err != nil {
+			return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+				"error": "storage is not available in workspace data region",
+			})
+		}
+		u, err := s3Service.GetFileURL(*job.S3Key)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "failed to generate download URL",
+			})
+		}
+		downloadURL = &u
+	}
+
+	return c.JSON(h.toZipJobResponse(&job, downloadURL))
+}
+
+// runZipJob 임시 파일에 ZIP을 만든 뒤 S3에 올리고 요청자에게 알림
+func (h *StorageHandler) runZipJob(job model.FileZipJob, folderName string, entries []storage.ZipEntry, s3Service *storage.S3Service) {
+	relatedType := "FILE_ZIP_JOB"
+	fail := func(err error) {
+		log.Printf("❌ 폴더 ZIP 작업 실패: job=%d, err=%v", job.ID, err)
+		msg := err.Error()
+		now := time.Now()
+		h.db.Model(&model.FileZipJob{}).Where("id = ?", job.ID).Updates(map[string]interface{}{
+			"status":       model.FileZipJobFailed.String(),
+			"error":        msg,
+			"completed_at": now,
+		})
+		content := fmt.Sprintf("'%s' 폴더 압축에 실패했습니다.", folderName)
+		CreateNotification(h.db, job.RequestedBy, nil, model.NotificationTypeFileExport.String(), content, &relatedType, &job.ID)
+	}
+
+	h.db.Model(&model.FileZipJob{}).Where("id = ?", job.ID).Update("status", model.FileZipJobRunning.String())
+
+	tmp, err := os.CreateTemp("", "eum-zip-*.zip")
+	if err != nil {
+		fail(err)
+		return
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), zipJobTimeout)
+	defer cancel()
+
+	var lastUpdate time.Time
+	progress := func(doneFiles int, doneBytes int64) {
+		if time.Since(lastUpdate) < zipJobProgressInterval {
+			return
+		}
+		lastUpdate = time.Now()
+		h.db.Model(&model.FileZipJob{}).Where("id = ?", job.ID).Updates(map[string]interface{}{
+			"done_files": countZipFiles(entries[:doneFiles]),
+			"done_bytes": doneBytes,
+		})
+	}
+	if err := s3Service.WriteZip(ctx, tmp, entries, h.zip.concurrency, progress); err != nil {
+		fail(err)
+		return
+	}
+
+	info, err := tmp.Stat()
+	if err != nil {
+		fail(err)
+		return
+	}
+	if _, err := tmp.Seek(0, 0); err != nil {
+		fail(err)
+		return
+	}
+
+	key := storage.WorkspaceObjectKey(job.WorkspaceID, sanitizeZipPath(folderName)+".zip")
+	if err := s3Service.PutObject(key, "application/zip", tmp, info.Size()); err != nil {
+		fail(err)
+		return
+	}
+
+	now := time.Now()
+	h.db.Model(&model.FileZipJob{}).Where("id = ?", job.ID).Updates(map[string]interface{}{
+		"status":       model.FileZipJobCompleted.String(),
+		"s3_key":       key,
+		"done_files":   job.TotalFiles,
+		"done_bytes":   job.TotalBytes,
+		"completed_at": now,
+	})
+	log.Printf("✅ 폴더 ZIP 작업 완료: job=%d, files=%d, size=%d bytes", job.ID, job.TotalFiles, info.Size())
+
+	content := fmt.Sprintf("'%s' 폴더 압축 파일이 준비되었습니다.", folderName)
+	CreateNotification(h.db, job.RequestedBy, nil, model.NotificationTypeFileExport.String(), content, &relatedType, &job.ID)
+}
+
+// collectZipEntries 폴더 하위 항목을 ZIP 항목으로 수집 (S3 키가 없는 레거시 파일과 접근할 수 없는 폴더는 제외)
+func (h *StorageHandler) collectZipEntries(folderID int64, prefix string, visited map[int64]bool, access *folderAccess) ([]storage.ZipEntry, int64) {
+	if visited[folderID] {
+		return nil, 0
+	}
+	visited[folderID] = true
+
+	var children []model.WorkspaceFile
+	h.db.Where("parent_folder_id = ?", folderID).Order("type DESC, name ASC").Find(&children)
+
+	var entries []storage.ZipEntry
+	var total int64
+	used := make(map[string]int)
+	for _, child := range children {
+		name := uniqueZipName(sanitizeZipPath(child.Name), used)
+
+		if child.Type == "FOLDER" {
+			if !access.folderAllowed(child.ID) {
+				continue
+			}
+			sub, size := h.collectZipEntries(child.ID, prefix+name+"/", visited, access)
+			if len(sub) == 0 {
+				sub = []storage.ZipEntry{{Path: prefix + name + "/", ModTime: child.CreatedAt}}
+			}
+			entries = append(entries, sub...)
+			total += size
+			continue
+		}
+
+		if child.S3Key == nil || *child.S3Key == "" {
+			continue
+		}
+		entry := storage.ZipEntry{Path: prefix + name, Key: *child.S3Key, ModTime: child.CreatedAt}
+		if child.FileSize != nil {
+			entry.Size = *child.FileSize
+		}
+		entries = append(entries, entry)
+		total += entry.Size
+	}
+	return entries, total
+}
+
+func (h *StorageHandler) toZipJobResponse(job *model.FileZipJob, downloadURL *string) FileZipJobResponse {
+	resp := FileZipJobResponse{
+		ID:          job.ID,
+		WorkspaceID: job.WorkspaceID,
+		FolderID:    job.FolderID,
+		Status:      job.Status,
+		TotalFiles:  job.TotalFiles,
+		DoneFiles:   job.DoneFiles,
+		TotalBytes:  job.TotalBytes,
+		DoneBytes:   job.DoneBytes,
+		DownloadURL: downloadURL,
+		Error:       job.Error,
+		CreatedAt:   job.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	if job.CompletedAt != nil {
+		t := job.CompletedAt.Format("2006-01-02T15:04:05Z07:00")
+		resp.CompletedAt = &t
+	}
+	return resp
+}
+
+// countZipFiles 빈 폴더 항목을 제외한 파일 수
+func countZipFiles(entries []storage.ZipEntry) int {
+	n := 0
+	for _, e := range entries {
+		if e.Key != "" {
+			n++
+		}
+	}
+	return n
+}
+
+// sanitizeZipPath ZIP 경로에 쓸 수 없는 문자 제거 (경로 탈출 방지)
+func sanitizeZipPath(name string) string {
+	name = strings.NewReplacer("/", "_", "\\", "_", "\x00", "").Replace(name)
+	name = strings.TrimSpace(name)
+	if name == "" || name == "." || name == ".." {
+		return "untitled"
+	}
+	return name
+}
+
+// uniqueZipName 같은 폴더 안에서 이름이 겹치면 "name (n).ext" 형태로 변경
+func uniqueZipName(name string, used map[string]int) string {
+	n := used[name]
+	used[name] = n + 1
+	if n == 0 {
+		return name
+	}
+
+	ext := ""
+	if idx := strings.LastIndex(name, "."); idx > 0 {
+		ext = name[idx:]
+		name = name[:idx]
+	}
+	return fmt.Sprintf("%s (%d)%s", name, n, ext)
+}
@@ -0,0 +1,237 @@
+package handler
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+
+	"realtime-backend/internal/auth"
+	awsai "realtime-backend/internal/aws"
+	"realtime-backend/internal/language"
+	"realtime-backend/internal/model"
+)
+
+const (
+	maxPartialIntervalMs  = 5000
+	maxAllowedTargetLangs = 20
+)
+
+// TranslationSettingsHandler 음성 Room 번역 설정 관리
+// 변경 사항은 진행 중인 Room의 파이프라인에 즉시 적용됨 (다른 인스턴스는 Redis 이벤트로 다시 읽음)
+type TranslationSettingsHandler struct {
+	db      *gorm.DB
+	roomHub *RoomHub
+}
+
+// NewTranslationSettingsHandler TranslationSettingsHandler 생성
+func NewTranslationSettingsHandler(db *gorm.DB, roomHub *RoomHub) *TranslationSettingsHandler {
+	return &TranslationSettingsHandler{db: db, roomHub: roomHub}
+}
+
+// UpdateTranslationSettingsRequest 번역 설정 변경 요청 (보낸 항목만 변경)
+type UpdateTranslationSettingsRequest struct {
+	TTSEnabled         *bool     `json:"tts_enabled"`
+	PartialIntervalMs  *int      `json:"partial_interval_ms"`  // 0 ~ 5000, 0 = 제한 없음
+	MinConfidence      *float64  `json:"min_confidence"`       // 0 ~ 1
+	AllowedTargetLangs *[]string `json:"allowed_target_langs"` // 빈 배열 = 전체 허용
+}
+
+// TranslationSettingsResponse 번역 설정 응답
+type TranslationSettingsResponse struct {
+	RoomID             string   `json:"room_id"`
+	TTSEnabled         bool     `json:"tts_enabled"`
+	PartialIntervalMs  int      `json:"partial_interval_ms"`
+	MinConfidence      float64  `json:"min_confidence"`
+	AllowedTargetLangs []string `json:"allowed_target_langs"`
+	UpdatedBy          *int64   `json:"updated_by,omitempty"`
+	UpdatedAt          *string  `json:"updated_at,omitempty"`
+}
+
+// GetTranslationSettings 번역 설정 조회 (설정이 없으면 기본값)
+func (h *TranslationSettingsHandler) GetTranslationSettings(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	roomID := c.Params("roomId")
+
+	meeting, ok := h.findRoomMeeting(c, roomID)
+	if !ok {
+		return nil
+	}
+	if meeting != nil && meeting.WorkspaceID != nil && !h.isWorkspaceMember(*meeting.WorkspaceID, claims.UserID) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "you are not a member of this workspace",
+		})
+	}
+
+	setting, err := h.loadSetting(roomID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get translation settings",
+		})
+	}
+
+	return c.JSON(toTranslationSettingsResponse(setting))
+}
+
+// UpdateTranslationSettings 번역 설정 변경 (미팅 호스트 또는 워크스페이스 ADMIN)
+func (h *TranslationSettingsHandler) UpdateTranslationSettings(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	roomID := c.Params("roomId")
+
+	meeting, ok := h.findRoomMeeting(c, roomID)
+	if !ok {
+		return nil
+	}
+	if meeting != nil && meeting.HostID != claims.UserID {
+		allowed := false
+		if meeting.WorkspaceID != nil {
+			hasPermission, err := auth.CheckPermission(h.db, *meeting.WorkspaceID, claims.UserID, "ADMIN")
+			if err != nil {
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error": "failed to check permission",
+				})
+			}
+			allowed = hasPermission
+		}
+		if !allowed {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": "only the host or a workspace admin can change translation settings",
+			})
+		}
+	}
+
+	var req UpdateTranslationSettingsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+
+	setting, err := h.loadSetting(roomID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get translation settings",
+		})
+	}
+
+	if req.TTSEnabled != nil {
+		setting.TTSEnabled = *req.TTSEnabled
+	}
+	if req.PartialIntervalMs != nil {
+		if *req.PartialIntervalMs < 0 || *req.PartialIntervalMs > maxPartialIntervalMs {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "partial_interval_ms must be between 0 and 5000",
+			})
+		}
+		setting.PartialIntervalMs = *req.PartialIntervalMs
+	}
+	if req.MinConfidence != nil {
+		if *req.MinConfidence < 0 || *req.MinConfidence > 1 {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "min_confidence must be between 0 and 1",
+			})
+		}
+		setting.MinConfidence = *req.MinConfidence
+	}
+	if req.AllowedTargetLangs != nil {
+		langs, msg := normalizeAllowedTargetLangs(*req.AllowedTargetLangs)
+		if msg != "" {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": msg,
+			})
+		}
+		setting.AllowedTargetLangs = strings.Join(langs, ",")
+	}
+	setting.UpdatedBy = &claims.UserID
+
+	if err := h.db.Save(setting).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to update translation settings",
+		})
+	}
+
+	if h.roomHub != nil {
+		h.roomHub.ApplyTranslationSettings(roomID, translationSettingsFromModel(setting))
+	}
+
+	return c.JSON(toTranslationSettingsResponse(setting))
+}
+
+// findRoomMeeting Room ID("meeting-{id}" 또는 미팅 코드)로 미팅 조회 (실패 시 응답 작성)
+// 미팅과 연결되지 않은 임시 Room이면 nil 반환
+func (h *TranslationSettingsHandler) findRoomMeeting(c *fiber.Ctx, roomID string) (meeting *model.Meeting, ok bool) {
+	if roomID == "" || len(roomID) > maxJoinRoomIDLength {
+		c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid room id"})
+		return nil, false
+	}
+
+	meeting, err := lookupRoomMeeting(h.db, roomID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "meeting not found"})
+			return nil, false
+		}
+		c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get meeting"})
+		return nil, false
+	}
+	return meeting, true
+}
+
+// loadSetting 저장된 설정 조회 (없으면 기본값)
+func (h *TranslationSettingsHandler) loadSetting(roomID string) (*model.RoomTranslationSetting, error) {
+	setting := &model.RoomTranslationSetting{
+		RoomID:        roomID,
+		TTSEnabled:    true,
+		MinConfidence: awsai.MinConfidenceThreshold,
+	}
+	if err := h.db.Where("room_id = ?", roomID).Limit(1).Find(setting).Error; err != nil {
+		return nil, err
+	}
+	return setting, nil
+}
+
+func (h *TranslationSettingsHandler) isWorkspaceMember(workspaceID, userID int64) bool {
+	var count int64
+	h.db.Model(&model.WorkspaceMember{}).
+		Where("workspace_id = ? AND user_id = ? AND status = ?", workspaceID, userID, model.MemberStatusActive.String()).
+		Count(&count)
+	return count > 0
+}
+
+// normalizeAllowedTargetLangs 허용 번역 언어 정규화 및 검증 (에러 메시지 반환, 정상이면 "")
+func normalizeAllowedTargetLangs(list []string) ([]string, string) {
+	seen := make(map[string]bool, len(list))
+	langs := make([]string, 0, len(list))
+	for _, code := range list {
+		l, ok := language.Get(code)
+		if !ok || !l.Translate {
+			return nil, "unsupported language: " + strings.TrimSpace(code)
+		}
+		if seen[l.Code] {
+			continue
+		}
+		seen[l.Code] = true
+		langs = append(langs, l.Code)
+	}
+	if len(langs) > maxAllowedTargetLangs {
+		return nil, "too many allowed target languages (max 20)"
+	}
+	return langs, ""
+}
+
+func toTranslationSettingsResponse(s *model.RoomTranslationSetting) TranslationSettingsResponse {
+	resp := TranslationSettingsResponse{
+		RoomID:             s.RoomID,
+		TTSEnabled:         s.TTSEnabled,
+		PartialIntervalMs:  s.PartialIntervalMs,
+		MinConfidence:      s.MinConfidence,
+		AllowedTargetLangs: splitLangList(s.AllowedTargetLangs),
+		UpdatedBy:          s.UpdatedBy,
+	}
+	if !s.UpdatedAt.IsZero() {
+		t := s.UpdatedAt.Format("2006-01-02T15:04:05Z07:00")
+		resp.UpdatedAt = &t
+	}
+	return resp
+}
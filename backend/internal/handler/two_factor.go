@@ -0,0 +1,453 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"realtime-backend/internal/auth"
+	"realtime-backend/internal/model"
+)
+
+const (
+	// twoFactorMaxFailures 로그인 2단계 인증 연속 실패 한도 (넘으면 잠금)
+	twoFactorMaxFailures = 5
+	// twoFactorLockout 잠금 시간 - 대기 토큰 유효 시간보다 길어 잠금 전에 받은 대기 토큰은 모두 만료됨
+	twoFactorLockout = 15 * time.Minute
+)
+
+// TwoFactorCodeRequest 인증 앱 코드 또는 백업 코드
+type TwoFactorCodeRequest struct {
+	Code string `json:"code"`
+}
+
+// TwoFactorVerifyRequest 로그인 2단계 인증 요청
+type TwoFactorVerifyRequest struct {
+	TwoFactorToken string `json:"two_factor_token"`
+	Code           string `json:"code"`
+}
+
+// twoFactorEnabled 사용자가 2단계 인증 등록을 마쳤는지
+func twoFactorEnabled(db *gorm.DB, userID int64) (bool, error) {
+	var count int64
+	err := db.Model(&model.UserTwoFactor{}).
+		Where("user_id = ? AND enabled_at IS NOT NULL", userID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// checkSecondFactor 인증 앱 코드 또는 사용하지 않은 백업 코드 확인 후 사용 처리
+// 조건부 갱신으로 같은 코드를 동시에 보내도 한 번만 통과
+func checkSecondFactor(db *gorm.DB, tf *model.UserTwoFactor, code string) (bool, error) {
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return false, nil
+	}
+
+	if step, ok := auth.ValidateTOTP(tf.Secret, code, tf.LastUsedStep, time.Now()); ok {
+		result := db.Model(&model.UserTwoFactor{}).
+			Where("user_id = ? AND last_used_step < ?", tf.UserID, step).
+			Update("last_used_step", step)
+		if result.Error != nil {
+			return false, result.Error
+		}
+		return result.RowsAffected > 0, nil
+	}
+
+	result := db.Model(&model.TwoFactorBackupCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", tf.UserID, auth.HashBackupCode(code)).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// reserveTwoFactorAttempt 코드 확인 전에 실패 횟수를 먼저 올려 동시 요청으로도 한도를 넘지 못하게 함
+// 한도에 닿는 시도에서 잠금을 걸고(성공하면 resetTwoFactorFailures가 풂) 이번 시도 번호 반환, 이미 잠겨 있으면 0
+func reserveTwoFactorAttempt(db *gorm.DB, userID int64) (int, error) {
+	now := time.Now()
+
+	// 잠금 시간이 지났으면 실패 횟수 초기화
+	if err := db.Model(&model.UserTwoFactor{}).
+		Where("user_id = ? AND locked_until <= ?", userID, now).
+		Updates(map[string]any{"failed_count": 0, "locked_until": nil}).Error; err != nil {
+		return 0, err
+	}
+
+	var tf model.UserTwoFactor
+	result := db.Model(&tf).
+		Clauses(clause.Returning{Columns: []clause.Column{{Name: "failed_count"}}}).
+		Where("user_id = ? AND locked_until IS NULL", userID).
+		Updates(map[string]any{
+			"failed_count": gorm.Expr("failed_count + 1"),
+			"locked_until": gorm.Expr("CASE WHEN failed_count + 1 >= ? THEN ?::timestamptz END", twoFactorMaxFailures, now.Add(twoFactorLockout)),
+		})
+	if result.Error != nil || result.RowsAffected == 0 {
+		return 0, result.Error
+	}
+	return tf.FailedCount, nil
+}
+
+// resetTwoFactorFailures 2단계 인증 성공 시 실패 횟수와 잠금 초기화
+func resetTwoFactorFailures(db *gorm.DB, userID int64) error {
+	return db.Model(&model.UserTwoFactor{}).
+		Where("user_id = ?", userID).
+		Updates(map[string]any{"failed_count": 0, "locked_until": nil}).Error
+}
+
+// rejectTwoFactorLocked 실패 한도 초과로 잠긴 사용자 거부 (대기 토큰도 더 이상 쓸 수 없음)
+func rejectTwoFactorLocked(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(twoFactorLockout.Seconds())))
+	return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+		"error": "too many failed two-factor attempts, sign in again later",
+		"code":  "TWO_FACTOR_LOCKED",
+	})
+}
+
+// replaceBackupCodes 기존 백업 코드를 모두 지우고 새로 발급 (원문은 이 응답에서만 보여 줌)
+func replaceBackupCodes(tx *gorm.DB, userID int64) ([]string, error) {
+	codes, hashes, err := auth.GenerateBackupCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Where("user_id = ?", userID).Delete(&model.TwoFactorBackupCode{}).Error; err != nil {
+		return nil, err
+	}
+	rows := make([]model.TwoFactorBackupCode, len(hashes))
+	for i, hash := range hashes {
+		rows[i] = model.TwoFactorBackupCode{UserID: userID, CodeHash: hash}
+	}
+	if err := tx.Create(&rows).Error; err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// requireSecondFactor 로그인 세션 대신 2단계 인증 대기 토큰 응답
+func (h *AuthHandler) requireSecondFactor(c *fiber.Ctx, user *model.User) error {
+	token, err := h.jwtManager.GenerateTwoFactorToken(user.ID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to generate token"})
+	}
+	return c.JSON(fiber.Map{
+		"two_factor_required": true,
+		"two_factor_token":    token,
+		"expires_in":          int64(auth.TwoFactorTokenExpiry.Seconds()),
+	})
+}
+
+// loadTwoFactor 로그인한 사용자의 2단계 인증 정보 (없으면 nil)
+func (h *AuthHandler) loadTwoFactor(userID int64) (*model.UserTwoFactor, error) {
+	var tf model.UserTwoFactor
+	if err := h.db.First(&tf, "user_id = ?", userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &tf, nil
+}
+
+// VerifyTwoFactor 대기 토큰과 인증 앱/백업 코드로 로그인 완료
+func (h *AuthHandler) VerifyTwoFactor(c *fiber.Ctx) error {
+	var req TwoFactorVerifyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid request body"})
+	}
+	if req.TwoFactorToken == "" || req.Code == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "two_factor_token and code are required"})
+	}
+
+	userID, err := h.jwtManager.ValidateTwoFactorToken(req.TwoFactorToken)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "invalid or expired two-factor token"})
+	}
+
+	tf, err := h.loadTwoFactor(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to load two-factor settings"})
+	}
+	if tf == nil || !tf.IsEnabled() {
+		// 대기 토큰 발급 후 2단계 인증이 해제됨 - 처음부터 다시 로그인
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "invalid or expired two-factor token"})
+	}
+
+	attempt, err := reserveTwoFactorAttempt(h.db, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to verify code"})
+	}
+	if attempt == 0 {
+		return rejectTwoFactorLocked(c)
+	}
+
+	ok, err := checkSecondFactor(h.db, tf, req.Code)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to verify code"})
+	}
+	if !ok {
+		log.Printf("⚠️ 2단계 인증 실패: user=%d ip=%s attempt=%d", userID, c.IP(), attempt)
+		if attempt >= twoFactorMaxFailures {
+			log.Printf("🔒 2단계 인증 잠금: user=%d until=%s", userID, time.Now().Add(twoFactorLockout).Format(time.RFC3339))
+			return rejectTwoFactorLocked(c)
+		}
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "invalid two-factor code"})
+	}
+	if err := resetTwoFactorFailures(h.db, userID); err != nil {
+		log.Printf("⚠️ 2단계 인증 실패 횟수 초기화 실패: user=%d, err=%v", userID, err)
+	}
+
+	var user model.User
+	if err := h.db.First(&user, userID).Error; err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "user not found"})
+	}
+	if user.SuspendedAt != nil {
+		return rejectSuspended(c)
+	}
+	return h.startSession(c, &user)
+}
+
+// GetTwoFactorStatus 내 2단계 인증 상태
+func (h *AuthHandler) GetTwoFactorStatus(c *fiber.Ctx) error {
+	claims, err := auth.GetClaimsFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "authentication required"})
+	}
+
+	tf, err := h.loadTwoFactor(claims.UserID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to load two-factor settings"})
+	}
+	if tf == nil || !tf.IsEnabled() {
+		return c.JSON(fiber.Map{"enabled": false})
+	}
+
+	var remaining int64
+	h.db.Model(&model.TwoFactorBackupCode{}).Where("user_id = ? AND used_at IS NULL", claims.UserID).Count(&remaining)
+	return c.JSON(fiber.Map{
+		"enabled":                true,
+		"enabled_at":             tf.EnabledAt.Format(time.RFC3339),
+		"backup_codes_remaining": remaining,
+	})
+}
+
+// SetupTwoFactor 2단계 인증 등록 시작 - 비밀 키와 QR 코드용 URI 발급 (코드 확인 전까지는 적용 안 됨)
+func (h *AuthHandler) SetupTwoFactor(c *fiber.Ctx) error {
+	claims, err := auth.GetClaimsFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "authentication required"})
+	}
+
+	tf, err := h.loadTwoFactor(claims.UserID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to load two-factor settings"})
+	}
+	if tf != nil && tf.IsEnabled() {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "two-factor authentication is already enabled"})
+	}
+
+	var user model.User
+	if err := h.db.First(&user, claims.UserID).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "user not found"})
+	}
+
+	secret, err := auth.GenerateTOTPSecret()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to generate secret"})
+	}
+	// 등록 중인 키가 있으면 교체 (이미 켜진 경우는 위에서 거부)
+	pending := model.UserTwoFactor{UserID: claims.UserID, Secret: secret}
+	if err := h.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{"secret": secret, "last_used_step": 0, "updated_at": time.Now()}),
+		Where:     clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "user_two_factors.enabled_at IS NULL"}}},
+	}).Create(&pending).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to start two-factor setup"})
+	}
+
+	return c.JSON(fiber.Map{
+		"secret":           secret,
+		"provisioning_uri": auth.TOTPProvisioningURI(secret, user.Email),
+	})
+}
+
+// EnableTwoFactor 인증 앱 코드 확인 후 2단계 인증 적용, 백업 코드 발급
+func (h *AuthHandler) EnableTwoFactor(c *fiber.Ctx) error {
+	claims, err := auth.GetClaimsFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "authentication required"})
+	}
+	var req TwoFactorCodeRequest
+	if err := c.BodyParser(&req); err != nil || req.Code == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "code is required"})
+	}
+
+	tf, err := h.loadTwoFactor(claims.UserID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to load two-factor settings"})
+	}
+	if tf == nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "two-factor setup has not been started"})
+	}
+	if tf.IsEnabled() {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "two-factor authentication is already enabled"})
+	}
+
+	step, ok := auth.ValidateTOTP(tf.Secret, req.Code, tf.LastUsedStep, time.Now())
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid code"})
+	}
+
+	var codes []string
+	err = h.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.UserTwoFactor{}).
+			Where("user_id = ? AND secret = ? AND enabled_at IS NULL", claims.UserID, tf.Secret).
+			Updates(map[string]interface{}{"enabled_at": time.Now(), "last_used_step": step})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		codes, err = replaceBackupCodes(tx, claims.UserID)
+		return err
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// 그 사이에 등록을 다시 시작했거나 다른 요청이 먼저 적용함
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "two-factor setup changed, please try again"})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to enable two-factor authentication"})
+	}
+
+	log.Printf("🔐 2단계 인증 활성화: user=%d", claims.UserID)
+	return c.JSON(fiber.Map{
+		"message":      "two-factor authentication enabled",
+		"backup_codes": codes,
+	})
+}
+
+// DisableTwoFactor 인증 앱/백업 코드 확인 후 2단계 인증 해제
+func (h *AuthHandler) DisableTwoFactor(c *fiber.Ctx) error {
+	claims, err := auth.GetClaimsFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "authentication required"})
+	}
+	var req TwoFactorCodeRequest
+	if err := c.BodyParser(&req); err != nil || req.Code == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "code is required"})
+	}
+
+	tf, err := h.loadTwoFactor(claims.UserID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to load two-factor settings"})
+	}
+	if tf == nil || !tf.IsEnabled() {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "two-factor authentication is not enabled"})
+	}
+
+	ok, err := checkSecondFactor(h.db, tf, req.Code)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to verify code"})
+	}
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid code"})
+	}
+
+	err = h.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", claims.UserID).Delete(&model.TwoFactorBackupCode{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("user_id = ?", claims.UserID).Delete(&model.UserTwoFactor{}).Error
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to disable two-factor authentication"})
+	}
+
+	log.Printf("🔓 2단계 인증 해제: user=%d", claims.UserID)
+	return c.JSON(fiber.Map{"message": "two-factor authentication disabled"})
+}
+
+// RegenerateBackupCodes 인증 앱 코드 확인 후 백업 코드 새로 발급 (이전 코드는 모두 무효)
+func (h *AuthHandler) RegenerateBackupCodes(c *fiber.Ctx) error {
+	claims, err := auth.GetClaimsFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "authentication required"})
+	}
+	var req TwoFactorCodeRequest
+	if err := c.BodyParser(&req); err != nil || req.Code == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "code is required"})
+	}
+
+	tf, err := h.loadTwoFactor(claims.UserID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to load two-factor settings"})
+	}
+	if tf == nil || !tf.IsEnabled() {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "two-factor authentication is not enabled"})
+	}
+
+	ok, err := checkSecondFactor(h.db, tf, req.Code)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to verify code"})
+	}
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid code"})
+	}
+
+	var codes []string
+	err = h.db.Transaction(func(tx *gorm.DB) error {
+		codes, err = replaceBackupCodes(tx, claims.UserID)
+		return err
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to generate backup codes"})
+	}
+
+	return c.JSON(fiber.Map{"backup_codes": codes})
+}
+
+// WorkspaceTwoFactorSatisfied 워크스페이스가 2단계 인증을 요구하면 사용자가 등록을 마쳤는지 확인
+// 요구하지 않거나 워크스페이스가 없으면 true (없는 워크스페이스는 호출한 쪽에서 처리)
+func WorkspaceTwoFactorSatisfied(db *gorm.DB, workspaceID, userID int64) (bool, error) {
+	var workspace model.Workspace
+	if err := db.Select("id", "require_two_factor").First(&workspace, workspaceID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return true, nil
+		}
+		return false, err
+	}
+	if !workspace.RequireTwoFactor {
+		return true, nil
+	}
+	return twoFactorEnabled(db, userID)
+}
+
+// RequireTwoFactor 2단계 인증을 요구하는 워크스페이스는 2단계 인증을 켠 사용자만 접근
+func RequireTwoFactor(db *gorm.DB) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims, ok := c.Locals("claims").(*auth.Claims)
+		workspaceID, err := c.ParamsInt("workspaceId")
+		if !ok || err != nil {
+			return c.Next()
+		}
+
+		satisfied, err := WorkspaceTwoFactorSatisfied(db, int64(workspaceID), claims.UserID)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to check permission"})
+		}
+		if !satisfied {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": "this workspace requires two-factor authentication",
+				"code":  "TWO_FACTOR_REQUIRED",
+			})
+		}
+		return c.Next()
+	}
+}
@@ -13,12 +13,16 @@ import (
 	"realtime-backend/internal/auth"
 	"realtime-backend/internal/model"
 	"realtime-backend/internal/presence"
+	"realtime-backend/internal/storage"
 )
 
 // UserHandler 유저 핸들러
 type UserHandler struct {
 	db              *gorm.DB
 	presenceManager *presence.Manager
+	statusDone      chan struct{} // 상태 메시지 만료 정리 중단 (status_expiry.go)
+	s3              *storage.S3Registry
+	avatarMaxSize   int64
 }
 
 // NewUserHandler UserHandler 생성
@@ -34,7 +38,8 @@ type UpdateUserStatusRequest struct {
 	Status            string  `json:"status"` // ONLINE, IDLE, DND, OFFLINE
 	CustomStatusText  *string `json:"custom_status_text"`
 	CustomStatusEmoji *string `json:"custom_status_emoji"`
-	ExpiresAt         *string `json:"expires_at"` // ISO8601 string or duration
+	ExpiresAt         *string `json:"expires_at"`  // RFC3339 만료 시각
+	ClearAfter        *string `json:"clear_after"` // 또는 기간 ("30m", "4h")
 }
 
 // UpdateUserStatus 유저 상태 업데이트
@@ -66,11 +71,20 @@ func (h *UserHandler) UpdateUserStatus(c *fiber.Ctx) error {
 		updates["custom_status_emoji"] = *req.CustomStatusEmoji
 	}
 
-	// 만료 시간 (심플하게 처리)
+	// 만료 시간 (메시지를 바꾸면서 지정하지 않으면 이전 만료 시간도 지움)
+	var expiresAt, clearAfter string
 	if req.ExpiresAt != nil {
-		// 파싱 로직 필요하지만 일단 생략하거나 RFC3339 가정
-		t, _ := time.Parse(time.RFC3339, *req.ExpiresAt)
-		updates["custom_status_expires_at"] = t
+		expiresAt = *req.ExpiresAt
+	}
+	if req.ClearAfter != nil {
+		clearAfter = *req.ClearAfter
+	}
+	expiry, err := parseStatusExpiry(expiresAt, clearAfter, time.Now())
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	if expiry != nil || req.CustomStatusText != nil || req.CustomStatusEmoji != nil {
+		updates["custom_status_expires_at"] = expiry
 	}
 
 	if len(updates) > 0 {
@@ -91,11 +105,15 @@ func (h *UserHandler) UpdateUserStatus(c *fiber.Ctx) error {
 			currentEmoji = cached.StatusMessageEmoji
 		}
 
-		if err := h.presenceManager.SetPresence(claims.UserID, presence.PresenceStatus(req.Status), "server-1", currentMsg, currentEmoji); err != nil {
+		if err := h.presenceManager.SetPresence(claims.UserID, presence.PresenceStatus(req.Status), h.presenceManager.ServerID(), currentMsg, currentEmoji); err != nil {
 			return c.Status(500).JSON(fiber.Map{"error": "Failed to update presence"})
 		}
 	}
 
+	if req.Status != "" && !strings.EqualFold(req.Status, string(presence.StatusDND)) {
+		go flushHeldNotifications(h.db, claims.UserID)
+	}
+
 	// 커스텀 상태 메시지도 Redis에 넣으려면 SetPresence를 확장하거나 별도 키 사용 필요
 	// 현재 SetPresence는 Status와 Heartbeat만 관리. StatusMessage 필드가 PresenceData에 있으니 활용 가능.
 	// 하지만 SetPresence 함수는 StatusMessage를 인자로 받지 않으므로 수정 필요.
@@ -284,8 +302,10 @@ func (h *UserHandler) UpdateUser(c *fiber.Ctx) error {
 	}
 
 	user.Nickname = nickname
+	oldAvatarKey := user.AvatarKey
 	if profileImgPath != nil {
 		user.ProfileImg = profileImgPath
+		user.AvatarKey = nil
 	}
 
 	if err := h.db.Save(&user).Error; err != nil {
@@ -293,6 +313,9 @@ func (h *UserHandler) UpdateUser(c *fiber.Ctx) error {
 			"error": "failed to update user",
 		})
 	}
+	if profileImgPath != nil {
+		h.deleteAvatar(oldAvatarKey)
+	}
 
 	// 응답
 	return c.JSON(UserResponse{
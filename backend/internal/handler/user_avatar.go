@@ -0,0 +1,174 @@
+package handler
+
+import (
+	"errors"
+	"log"
+
+	"github.com/gofiber/fiber/v2"
+
+	"realtime-backend/internal/auth"
+	"realtime-backend/internal/model"
+	"realtime-backend/internal/storage"
+)
+
+// AvatarUploadURLRequest 프로필 이미지 업로드 URL 요청
+type AvatarUploadURLRequest struct {
+	ContentType string `json:"content_type"`
+	FileSize    int64  `json:"file_size"`
+}
+
+// CompleteAvatarRequest 업로드한 원본으로 프로필 이미지 적용 요청
+type CompleteAvatarRequest struct {
+	Key string `json:"key"`
+}
+
+// SetStorage 프로필 이미지를 올릴 S3 레지스트리와 원본 최대 크기 설정
+// 사용자는 워크스페이스에 묶이지 않으므로 항상 기본 리전 버킷(CDN) 사용
+func (h *UserHandler) SetStorage(s3 *storage.S3Registry, avatarMaxSize int64) {
+	h.s3 = s3
+	h.avatarMaxSize = avatarMaxSize
+}
+
+// avatarStorage 기본 리전 S3 서비스 (설정되지 않았으면 nil)
+func (h *UserHandler) avatarStorage() *storage.S3Service {
+	if h.s3 == nil {
+		return nil
+	}
+	s3Service, err := h.s3.ForRegion("")
+	if err != nil {
+		return nil
+	}
+	return s3Service
+}
+
+// CreateAvatarUploadURL 프로필 이미지 원본 업로드용 Presigned URL 발급
+// 클라이언트가 S3에 직접 올린 뒤 CompleteAvatarUpload 로 적용
+func (h *UserHandler) CreateAvatarUploadURL(c *fiber.Ctx) error {
+	claims, err := auth.GetClaimsFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "authentication required"})
+	}
+	s3Service := h.avatarStorage()
+	if s3Service == nil {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"error": "file storage is not configured"})
+	}
+
+	var req AvatarUploadURLRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid request body"})
+	}
+	if !storage.IsThumbnailSource(req.ContentType) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "profile image must be a JPEG, PNG or GIF image"})
+	}
+	if req.FileSize <= 0 || req.FileSize > h.avatarMaxSize {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid file size",
+			"limit": h.avatarMaxSize,
+		})
+	}
+
+	presigned, err := s3Service.GenerateAvatarUploadURL(claims.UserID, req.ContentType, req.FileSize)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to generate upload URL"})
+	}
+	return c.JSON(presigned)
+}
+
+// CompleteAvatarUpload 업로드한 원본을 표준 크기로 줄여 프로필 이미지로 적용하고 이전 이미지 정리
+func (h *UserHandler) CompleteAvatarUpload(c *fiber.Ctx) error {
+	claims, err := auth.GetClaimsFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "authentication required"})
+	}
+	s3Service := h.avatarStorage()
+	if s3Service == nil {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"error": "file storage is not configured"})
+	}
+
+	var req CompleteAvatarRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid request body"})
+	}
+	if !storage.IsAvatarUploadKey(claims.UserID, req.Key) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid upload key"})
+	}
+
+	var user model.User
+	if err := h.db.First(&user, claims.UserID).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "user not found"})
+	}
+
+	prefix, err := s3Service.CreateAvatar(c.Context(), claims.UserID, req.Key, h.avatarMaxSize)
+	// 원본은 성공/실패와 관계없이 삭제 (크기별 JPEG만 보관)
+	if delErr := s3Service.DeleteFile(req.Key); delErr != nil {
+		log.Printf("⚠️ 프로필 이미지 원본 삭제 실패: key=%s, err=%v", req.Key, delErr)
+	}
+	if err != nil {
+		if errors.Is(err, storage.ErrImageTooLarge) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "image is too large"})
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid image or upload not found"})
+	}
+
+	oldKey := user.AvatarKey
+	profileImg := s3Service.GetPublicURL(storage.AvatarKey(prefix, storage.AvatarSizes[len(storage.AvatarSizes)-1]))
+	if err := h.db.Model(&user).Updates(map[string]interface{}{
+		"profile_img": profileImg,
+		"avatar_key":  prefix,
+	}).Error; err != nil {
+		s3Service.DeleteFiles(storage.AvatarKeys(prefix))
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to update user"})
+	}
+	h.deleteAvatar(oldKey)
+
+	return c.JSON(UserResponse{
+		ID:         user.ID,
+		Email:      user.Email,
+		Nickname:   user.Nickname,
+		ProfileImg: &profileImg,
+		Provider:   user.Provider,
+	})
+}
+
+// DeleteAvatar 프로필 이미지 제거
+func (h *UserHandler) DeleteAvatar(c *fiber.Ctx) error {
+	claims, err := auth.GetClaimsFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "authentication required"})
+	}
+
+	var user model.User
+	if err := h.db.First(&user, claims.UserID).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "user not found"})
+	}
+
+	oldKey := user.AvatarKey
+	if err := h.db.Model(&user).Updates(map[string]interface{}{
+		"profile_img": nil,
+		"avatar_key":  nil,
+	}).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to update user"})
+	}
+	h.deleteAvatar(oldKey)
+
+	return c.JSON(UserResponse{
+		ID:       user.ID,
+		Email:    user.Email,
+		Nickname: user.Nickname,
+		Provider: user.Provider,
+	})
+}
+
+// deleteAvatar 교체/제거된 프로필 이미지의 크기별 객체 삭제 (실패해도 요청은 성공으로 처리)
+func (h *UserHandler) deleteAvatar(prefix *string) {
+	if prefix == nil || *prefix == "" {
+		return
+	}
+	s3Service := h.avatarStorage()
+	if s3Service == nil {
+		return
+	}
+	if err := s3Service.DeleteFiles(storage.AvatarKeys(*prefix)); err != nil {
+		log.Printf("⚠️ 이전 프로필 이미지 삭제 실패: prefix=%s, err=%v", *prefix, err)
+	}
+}
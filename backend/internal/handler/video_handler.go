@@ -4,10 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log"
 	"time"
 
 	internalAuth "realtime-backend/internal/auth"
 	"realtime-backend/internal/config"
+	"realtime-backend/internal/presence"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/livekit/protocol/auth"
@@ -17,14 +19,20 @@ import (
 )
 
 type VideoHandler struct {
-	cfg *config.Config
-	db  *gorm.DB
+	cfg      *config.Config
+	db       *gorm.DB
+	presence *presence.Manager
 }
 
 func NewVideoHandler(cfg *config.Config, db *gorm.DB) *VideoHandler {
 	return &VideoHandler{cfg: cfg, db: db}
 }
 
+// SetPresenceManager marks users as IN_MEETING when they get a token.
+func (h *VideoHandler) SetPresenceManager(pm *presence.Manager) {
+	h.presence = pm
+}
+
 type TokenRequest struct {
 	RoomName        string `json:"roomName"`
 	ParticipantName string `json:"participantName"`
@@ -68,20 +76,21 @@ func (h *VideoHandler) GenerateToken(c *fiber.Ctx) error {
 	if len(req.RoomName) > 8 && req.RoomName[:8] == "meeting-" {
 		idStr := req.RoomName[8:]
 		var meeting struct {
+			ID          int64
 			Status      string
 			WorkspaceID int64
 		}
 		// model.Meeting 대신 가벼운 구조체 사용 또는 GORM 활용
-		if err := h.db.Table("meetings").Select("status, workspace_id").Where("id = ?", idStr).Scan(&meeting).Error; err == nil {
+		if err := h.db.Table("meetings").Select("id, status, workspace_id").Where("id = ?", idStr).Scan(&meeting).Error; err == nil {
 			if meeting.Status == "ENDED" {
 				return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
 					"error": "이미 종료된 통화방입니다.",
 				})
 			}
 
-			// 권한 확인 (CONNECT_VOICE)
+			// 권한 확인 (CONNECT_MEDIA, 게스트는 초대받은 회의만)
 			if userID, ok := c.Locals("userId").(int64); ok {
-				hasPermission, err := internalAuth.CheckPermission(h.db, meeting.WorkspaceID, userID, "CONNECT_VOICE")
+				hasPermission, err := internalAuth.CheckChannelPermission(h.db, meeting.WorkspaceID, meeting.ID, userID, "CONNECT_MEDIA")
 				if err != nil {
 					return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to check permission"})
 				}
@@ -139,6 +148,14 @@ func (h *VideoHandler) GenerateToken(c *fiber.Ctx) error {
 		})
 	}
 
+	// Show "in a call" to workspace members; cleared when the client reports
+	// leaving (change_activity) or after inCallActivityTTL.
+	if userID, ok := c.Locals("userId").(int64); ok && h.presence != nil {
+		if err := h.presence.SetActivity(userID, presence.ActivityInMeeting, req.RoomName, inCallActivityTTL); err != nil {
+			log.Printf("⚠️ Failed to mark user %d in call: %v", userID, err)
+		}
+	}
+
 	return c.JSON(TokenResponse{Token: token})
 }
 
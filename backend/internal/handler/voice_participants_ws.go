@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"realtime-backend/internal/config"
+	"realtime-backend/internal/errorreport"
 
 	"github.com/gofiber/contrib/websocket"
 	"github.com/livekit/protocol/livekit"
@@ -81,7 +82,7 @@ func (h *VoiceParticipantsWSHandler) HandleWebSocket(c *websocket.Conn) {
 	// 패닉 복구 - 어떤 상황에서도 서버가 죽지 않도록
 	defer func() {
 		if r := recover(); r != nil {
-			log.Printf("음성 참가자 WebSocket 패닉 복구: %v", r)
+			errorreport.CapturePanic(r, wsReportContext(c, "ws.voice_participants"))
 		}
 	}()
 
@@ -89,13 +90,13 @@ func (h *VoiceParticipantsWSHandler) HandleWebSocket(c *websocket.Conn) {
 	workspaceID, ok := c.Locals("workspaceId").(int64)
 	if !ok {
 		log.Printf("음성 참가자 WebSocket: workspaceId 타입 오류")
-		c.Close()
+		closeWS(c, WSCloseInternalError, "invalid session")
 		return
 	}
 	userID, ok := c.Locals("userId").(int64)
 	if !ok {
 		log.Printf("음성 참가자 WebSocket: userId 타입 오류")
-		c.Close()
+		closeWS(c, WSCloseInternalError, "invalid session")
 		return
 	}
 
@@ -162,7 +163,7 @@ func (h *VoiceParticipantsWSHandler) sendInitialParticipants(c *websocket.Conn,
 	// 패닉 복구
 	defer func() {
 		if r := recover(); r != nil {
-			log.Printf("초기 참가자 전송 패닉 복구: %v", r)
+			errorreport.CapturePanic(r, wsReportContext(c, "ws.voice_participants.initial"))
 		}
 	}()
 
@@ -325,7 +326,10 @@ func (h *VoiceParticipantsWSHandler) broadcastToWorkspace(workspaceID int64, msg
 	// 패닉 복구
 	defer func() {
 		if r := recover(); r != nil {
-			log.Printf("브로드캐스트 패닉 복구: %v", r)
+			errorreport.CapturePanic(r, errorreport.Context{
+				Component:   "ws.voice_participants.broadcast",
+				WorkspaceID: workspaceID,
+			})
 		}
 	}()
 
@@ -21,7 +21,8 @@ func NewVoiceRecordHandler(db *gorm.DB) *VoiceRecordHandler {
 // VoiceRecordResponse 음성 기록 응답
 type VoiceRecordResponse struct {
 	ID          int64         `json:"id"`
-	MeetingID   int64         `json:"meeting_id"`
+	MeetingID   *int64        `json:"meeting_id,omitempty"`
+	FileID      *int64        `json:"related_file_id,omitempty"` // 업로드 파일 전사 결과
 	SpeakerID   *int64        `json:"speaker_id,omitempty"`
 	SpeakerName string        `json:"speaker_name"`
 	Original    string        `json:"original"`
@@ -98,7 +99,7 @@ func (h *VoiceRecordHandler) GetVoiceRecords(c *fiber.Ctx) error {
 	// 응답 변환
 	responses := make([]VoiceRecordResponse, len(records))
 	for i, record := range records {
-		responses[i] = h.toVoiceRecordResponse(&record)
+		responses[i] = toVoiceRecordResponse(&record)
 	}
 
 	// 전체 개수 조회
@@ -174,8 +175,9 @@ func (h *VoiceRecordHandler) CreateVoiceRecord(c *fiber.Ctx) error {
 	}
 
 	// 음성 기록 생성
+	recordMeetingID := int64(meetingID)
 	record := model.VoiceRecord{
-		MeetingID:   int64(meetingID),
+		MeetingID:   &recordMeetingID,
 		SpeakerID:   &claims.UserID,
 		SpeakerName: req.SpeakerName,
 		Original:    req.Original,
@@ -192,7 +194,7 @@ func (h *VoiceRecordHandler) CreateVoiceRecord(c *fiber.Ctx) error {
 	// Speaker 정보 로드
 	h.db.Preload("Speaker").First(&record, record.ID)
 
-	return c.Status(fiber.StatusCreated).JSON(h.toVoiceRecordResponse(&record))
+	return c.Status(fiber.StatusCreated).JSON(toVoiceRecordResponse(&record))
 }
 
 // CreateVoiceRecordBulk 음성 기록 일괄 생성
@@ -248,6 +250,7 @@ func (h *VoiceRecordHandler) CreateVoiceRecordBulk(c *fiber.Ctx) error {
 
 	// 음성 기록 생성
 	records := make([]model.VoiceRecord, len(req.Records))
+	recordMeetingID := int64(meetingID)
 	for i, r := range req.Records {
 		original := sanitizeString(r.Original)
 		if len(original) > 5000 {
@@ -264,7 +267,7 @@ func (h *VoiceRecordHandler) CreateVoiceRecordBulk(c *fiber.Ctx) error {
 		}
 
 		records[i] = model.VoiceRecord{
-			MeetingID:   int64(meetingID),
+			MeetingID:   &recordMeetingID,
 			SpeakerName: speakerName,
 			Original:    original,
 			Translated:  r.Translated,
@@ -339,10 +342,11 @@ func (h *VoiceRecordHandler) isWorkspaceMember(workspaceID, userID int64) bool {
 	return count > 0
 }
 
-func (h *VoiceRecordHandler) toVoiceRecordResponse(record *model.VoiceRecord) VoiceRecordResponse {
+func toVoiceRecordResponse(record *model.VoiceRecord) VoiceRecordResponse {
 	resp := VoiceRecordResponse{
 		ID:          record.ID,
 		MeetingID:   record.MeetingID,
+		FileID:      record.RelatedFileID,
 		SpeakerID:   record.SpeakerID,
 		SpeakerName: record.SpeakerName,
 		Original:    record.Original,
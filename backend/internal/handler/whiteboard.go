@@ -7,6 +7,7 @@ import (
 	"realtime-backend/internal/model"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
@@ -15,22 +16,43 @@ import (
 
 type WhiteboardHandler struct {
 	db *gorm.DB
+
+	// Live boards for /ws/whiteboard (only while at least one client is connected)
+	boards            map[whiteboardScope]*whiteboardBoard
+	mu                sync.Mutex
+	messagesPerSecond int           // per-connection message limit (0 = unlimited)
+	flushInterval     time.Duration // how often queued board events are written to the DB
+
+	storage *StorageHandler // S3 for image objects (nil = images disabled)
+
+	trashRetention time.Duration // how long content replaced by a checkpoint restore is kept
+	trashDone      chan struct{} // stops the trash sweeper
 }
 
 func NewWhiteboardHandler(db *gorm.DB) *WhiteboardHandler {
-	return &WhiteboardHandler{db: db}
+	return &WhiteboardHandler{
+		db:             db,
+		boards:         make(map[whiteboardScope]*whiteboardBoard),
+		flushInterval:  time.Second,
+		trashRetention: whiteboardDefaultTrashRetention,
+	}
 }
 
 type WhiteboardRequest struct {
-	Room   string `json:"room"`
-	Stroke any    `json:"stroke,omitempty"` // Can be single object or array
-	Type   string `json:"type,omitempty"`   // add, clear, undo, redo, snapshot
+	Room     string            `json:"room"`
+	Board    int64             `json:"board,omitempty"`    // named board ID (omit for the meeting's default board)
+	Stroke   any               `json:"stroke,omitempty"`   // Can be single object or array
+	Object   json.RawMessage   `json:"object,omitempty"`   // shape/text/image for add and update (see WhiteboardObject)
+	ObjectID string            `json:"objectId,omitempty"` // object to delete
+	Type     string            `json:"type,omitempty"`     // add, update, delete, clear, undo, redo (WebSocket only: cursor)
+	Cursor   *WhiteboardCursor `json:"cursor,omitempty"`   // WebSocket cursor/selection update
 }
 
-// GetWhiteboard returns the history of strokes for the meeting
+// GetWhiteboard returns the history of strokes for the meeting (or the named board given by ?board=)
 func (h *WhiteboardHandler) GetWhiteboard(c *fiber.Ctx) error {
 	roomName := c.Query("room")
-	if roomName == "" {
+	boardID := int64(c.QueryInt("board"))
+	if roomName == "" && boardID == 0 {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Room name is required"})
 	}
 
@@ -39,23 +61,48 @@ func (h *WhiteboardHandler) GetWhiteboard(c *fiber.Ctx) error {
 		userID = val.(int64)
 	}
 
-	meetingID, err := h.getMeetingID(roomName, userID)
+	scope, err := h.resolveScope(roomName, boardID, userID)
+	if err != nil {
+		return whiteboardScopeError(c, err)
+	}
+
+	// Events from WebSocket clients may still be queued; write them first so the history is complete
+	h.flushLiveBoard(scope)
+
+	history, canUndo, canRedo, err := loadWhiteboardHistory(h.db, scope)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	access, err := h.whiteboardAccess(scope, userID)
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Meeting not found"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "permission check failed"})
 	}
 
+	return c.JSON(fiber.Map{
+		"success":   true,
+		"history":   history,
+		"canUndo":   canUndo,
+		"canRedo":   canRedo,
+		"viewOnly":  h.whiteboardViewOnly(scope),
+		"canDraw":   access.CanDraw,
+		"presenter": access.Presenter,
+	})
+}
+
+// loadWhiteboardHistory returns snapshot chunks followed by active strokes, plus the undo/redo state
+func loadWhiteboardHistory(db *gorm.DB, scope whiteboardScope) ([]any, bool, bool, error) {
 	// 1. Fetch Snapshots (Chunked data)
 	var snapshots []model.WhiteboardSnapshot
-	if err := h.db.Where("meeting_id = ?", meetingID).Order("id ASC").Find(&snapshots).Error; err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to fetch snapshots"})
+	if err := scope.where(db).Order("id ASC").Find(&snapshots).Error; err != nil {
+		return nil, false, false, errors.New("Failed to fetch snapshots")
 	}
 
 	// 2. Fetch Active Strokes (Non-deleted, Recent)
 	var strokes []model.WhiteboardStroke
-	if err := h.db.Where("meeting_id = ? AND is_deleted = ?", meetingID, false).
+	if err := scope.where(db).Where("is_deleted = ?", false).
 		Order("id ASC").
 		Find(&strokes).Error; err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to fetch strokes"})
+		return nil, false, false, errors.New("Failed to fetch strokes")
 	}
 
 	// 3. Merge data
@@ -82,46 +129,49 @@ func (h *WhiteboardHandler) GetWhiteboard(c *fiber.Ctx) error {
 	// Undo/Redo is only for available strokes in 'whiteboard_strokes'
 	// Users cannot undo archived snapshot content easily.
 	var deletedCount int64
-	h.db.Model(&model.WhiteboardStroke{}).Where("meeting_id = ? AND is_deleted = ?", meetingID, true).Count(&deletedCount)
+	scope.where(db.Model(&model.WhiteboardStroke{})).Where("is_deleted = ?", true).Count(&deletedCount)
 
-	return c.JSON(fiber.Map{
-		"success": true,
-		"history": history,
-		"canUndo": len(strokes) > 0,
-		"canRedo": deletedCount > 0,
-	})
+	return history, len(strokes) > 0, deletedCount > 0, nil
+}
+
+// countWhiteboardStrokes returns how many strokes can be undone (active) and redone (deleted)
+func countWhiteboardStrokes(db *gorm.DB, scope whiteboardScope) (undoCount, redoCount int64) {
+	scope.where(db.Model(&model.WhiteboardStroke{})).Where("is_deleted = ?", false).Count(&undoCount)
+	scope.where(db.Model(&model.WhiteboardStroke{})).Where("is_deleted = ?", true).Count(&redoCount)
+	return undoCount, redoCount
 }
 
 // Helper to chunk strokes into a snapshot
-func (h *WhiteboardHandler) snapshotStrokes(meetingID int64) {
+// Returns how many active strokes were archived (they can no longer be undone)
+func (h *WhiteboardHandler) snapshotStrokes(scope whiteboardScope) int {
 	const triggerCount = 1100
 	const keepRecentCount = 100
 
 	var count int64
-	// Count only active strokes
-	h.db.Model(&model.WhiteboardStroke{}).Where("meeting_id = ? AND is_deleted = ?", meetingID, false).Count(&count)
+	// Count only active freehand strokes (shapes/text/images stay as rows so they can be edited)
+	scope.where(h.db.Model(&model.WhiteboardStroke{})).Where("is_deleted = ? AND object_type = ?", false, model.WhiteboardObjectStroke).Count(&count)
 
 	if count >= triggerCount {
-		log.Printf("[Snapshot] Triggered for meeting %d. Count: %d", meetingID, count)
+		log.Printf("[Snapshot] Triggered for %s. Count: %d", scope, count)
 
 		// 1. Select oldest (Total - 100) strokes
 		limit := int(count) - keepRecentCount
 		if limit <= 0 {
-			return
+			return 0
 		}
 
 		var strokes []model.WhiteboardStroke
 		// Order by ID ASC (oldest first)
-		if err := h.db.Where("meeting_id = ? AND is_deleted = ?", meetingID, false).
+		if err := scope.where(h.db).Where("is_deleted = ? AND object_type = ?", false, model.WhiteboardObjectStroke).
 			Order("id ASC").
 			Limit(limit).
 			Find(&strokes).Error; err != nil {
 			log.Printf("[Snapshot] Failed to select strokes: %v", err)
-			return
+			return 0
 		}
 
 		if len(strokes) == 0 {
-			return
+			return 0
 		}
 
 		// 2. Serialize stroke data
@@ -133,40 +183,43 @@ func (h *WhiteboardHandler) snapshotStrokes(meetingID int64) {
 		jsonData, err := json.Marshal(aggregatedData)
 		if err != nil {
 			log.Printf("[Snapshot] Failed to marshal aggregated data: %v", err)
-			return
+			return 0
 		}
 
 		// 3. Create Snapshot
 		snapshot := model.WhiteboardSnapshot{
-			MeetingID: meetingID,
-			Data:      string(jsonData),
-			StartID:   strokes[0].ID,
-			EndID:     strokes[len(strokes)-1].ID,
+			MeetingID:    scope.meetingRef(),
+			WhiteboardID: scope.boardRef(),
+			Data:         string(jsonData),
+			StartID:      strokes[0].ID,
+			EndID:        strokes[len(strokes)-1].ID,
 		}
 
 		tx := h.db.Begin()
 		if err := tx.Create(&snapshot).Error; err != nil {
 			tx.Rollback()
 			log.Printf("[Snapshot] Failed to create snapshot: %v", err)
-			return
+			return 0
 		}
 
 		// 4. Hard Delete processed strokes to keep table small as per user request ("Select lag")
 		// Soft Delete would keep rows and slow down indexes/Selects over time.
 		// Since data is safely in snapshot, we remove individual rows.
-		if err := tx.Where("meeting_id = ? AND id <= ? AND is_deleted = ?", meetingID, snapshot.EndID, false).
+		if err := scope.where(tx).Where("id <= ? AND is_deleted = ? AND object_type = ?", snapshot.EndID, false, model.WhiteboardObjectStroke).
 			Delete(&model.WhiteboardStroke{}).Error; err != nil {
 			tx.Rollback()
 			log.Printf("[Snapshot] Failed to delete strokes: %v", err)
-			return
+			return 0
 		}
 
 		tx.Commit()
 		log.Printf("[Snapshot] Successfully created snapshot %d (Strokes %d-%d merged and deleted)", snapshot.ID, snapshot.StartID, snapshot.EndID)
+		return len(strokes)
 	}
+	return 0
 }
 
-// HandleWhiteboard handles add, undo, redo, clear actions
+// HandleWhiteboard handles add, update, delete, undo, redo, clear actions
 func (h *WhiteboardHandler) HandleWhiteboard(c *fiber.Ctx) error {
 	userID := int64(0)
 	if val := c.Locals("userID"); val != nil {
@@ -182,81 +235,78 @@ func (h *WhiteboardHandler) HandleWhiteboard(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
 	}
 
-	if req.Room == "" {
+	if req.Room == "" && req.Board == 0 {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Room name is required"})
 	}
 
-	meetingID, err := h.getMeetingID(req.Room, userID)
+	scope, err := h.resolveScope(req.Room, req.Board, userID)
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Meeting not found"})
+		return whiteboardScopeError(c, err)
 	}
 
-	switch req.Type {
-	case "clear":
-		// Hard Delete everything for this meeting
-		log.Printf("[Whiteboard] User %d requesting CLEAR in meeting %d", userID, meetingID)
-		if err := h.db.Where("meeting_id = ?", meetingID).Delete(&model.WhiteboardStroke{}).Error; err != nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to clear strokes"})
-		}
-		if err := h.db.Where("meeting_id = ?", meetingID).Delete(&model.WhiteboardSnapshot{}).Error; err != nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to clear snapshots"})
-		}
+	op, err := newWhiteboardOp(&req, userID, scope.WorkspaceID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
 
-	case "undo":
-		// Undo only affects 'WhiteboardStroke' (Active). We cannot easily undo a snapshot stroke.
-		var lastStroke model.WhiteboardStroke
-		err := h.db.Where("meeting_id = ? AND is_deleted = ?", meetingID, false).Order("id DESC").First(&lastStroke).Error
-		if err == nil {
-			now := time.Now()
-			// Mark as deleted
-			h.db.Model(&lastStroke).Updates(map[string]interface{}{
-				"is_deleted": true,
-				"deleted_at": now,
+	// Every change needs DRAW_WHITEBOARD, and only the presenter may draw while the board is view-only
+	access, err := h.whiteboardAccess(scope, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "permission check failed"})
+	}
+
+	// If the board is open over WebSocket, route the event through its hub so connected clients see it
+	if op.Type != "" {
+		canUndo, canRedo, live, err := h.submitToLiveBoard(scope, op, access)
+		if err != nil {
+			return whiteboardOpError(c, err)
+		}
+		if live {
+			return c.JSON(fiber.Map{
+				"success": true,
+				"canUndo": canUndo,
+				"canRedo": canRedo,
 			})
 		}
+		if err := access.check(h.whiteboardViewOnly(scope)); err != nil {
+			return whiteboardOpError(c, err)
+		}
+	}
 
-	case "redo":
-		// Redo finds the most recently deleted stroke
-		var lastDeletedStroke model.WhiteboardStroke
-		err := h.db.Where("meeting_id = ? AND is_deleted = ?", meetingID, true).Order("deleted_at DESC").First(&lastDeletedStroke).Error
-		if err == nil {
-			h.db.Model(&lastDeletedStroke).Updates(map[string]interface{}{
-				"is_deleted": false,
-				"deleted_at": gorm.Expr("NULL"),
-			})
+	switch op.Type {
+	case "clear":
+		log.Printf("[Whiteboard] User %d requesting CLEAR in %s", userID, scope)
+		if err := clearWhiteboard(h.db, scope); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
 		}
 
-	default: // "add"
-		if req.Stroke != nil {
-			// Clear Redo stack first
-			h.db.Where("meeting_id = ? AND is_deleted = ?", meetingID, true).Delete(&model.WhiteboardStroke{})
+	case "undo":
+		undoWhiteboardStroke(h.db, scope, op.At)
 
-			strokeBytes, err := json.Marshal(req.Stroke)
-			if err != nil {
-				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid stroke data"})
-			}
+	case "redo":
+		redoWhiteboardStroke(h.db, scope)
 
-			newStroke := model.WhiteboardStroke{
-				MeetingID:  meetingID,
-				UserID:     userID,
-				StrokeData: string(strokeBytes),
-				IsDeleted:  false,
-			}
+	case "update", "delete":
+		if err := h.writeObjectChange(scope, op); err != nil {
+			return whiteboardOpError(c, err)
+		}
 
-			if err := h.db.Create(&newStroke).Error; err != nil {
-				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to save stroke"})
+	case "add":
+		if op.ObjectID != "" {
+			if _, exists := findWhiteboardObject(h.db, scope, op.ObjectID); exists {
+				return whiteboardOpError(c, errWhiteboardObjectExists)
 			}
-
-			// Check for Snapshot Trigger in background
-			go h.snapshotStrokes(meetingID)
 		}
+		if err := addWhiteboardStrokes(h.db, scope, []model.WhiteboardStroke{op.row(scope)}); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to save stroke"})
+		}
+
+		// Check for Snapshot Trigger in background
+		go h.snapshotStrokes(scope)
 	}
 
 	// Calculate Undo/Redo state (Only for active non-snapshotted strokes)
-	var undoCount int64
-	h.db.Model(&model.WhiteboardStroke{}).Where("meeting_id = ? AND is_deleted = ?", meetingID, false).Count(&undoCount)
-	var redoCount int64
-	h.db.Model(&model.WhiteboardStroke{}).Where("meeting_id = ? AND is_deleted = ?", meetingID, true).Count(&redoCount)
+	undoCount, redoCount := countWhiteboardStrokes(h.db, scope)
 	// Note: 'undoCount' here is only recent strokes. If usage is high, users can't undo beyond snapshot.
 	// This fits the requirement "Keep recent 100 for Undo".
 
@@ -267,6 +317,51 @@ func (h *WhiteboardHandler) HandleWhiteboard(c *fiber.Ctx) error {
 	})
 }
 
+// clearWhiteboard hard deletes every stroke and snapshot of the board
+func clearWhiteboard(db *gorm.DB, scope whiteboardScope) error {
+	if err := scope.where(db).Delete(&model.WhiteboardStroke{}).Error; err != nil {
+		return errors.New("Failed to clear strokes")
+	}
+	if err := scope.where(db).Delete(&model.WhiteboardSnapshot{}).Error; err != nil {
+		return errors.New("Failed to clear snapshots")
+	}
+	return nil
+}
+
+// undoWhiteboardStroke marks the latest active stroke as deleted
+// Undo only affects 'WhiteboardStroke' (Active). We cannot easily undo a snapshot stroke.
+func undoWhiteboardStroke(db *gorm.DB, scope whiteboardScope, at time.Time) {
+	var lastStroke model.WhiteboardStroke
+	err := scope.where(db).Where("is_deleted = ?", false).Order("id DESC").First(&lastStroke).Error
+	if err == nil {
+		// Mark as deleted
+		db.Model(&lastStroke).Updates(map[string]interface{}{
+			"is_deleted": true,
+			"deleted_at": at,
+		})
+	}
+}
+
+// redoWhiteboardStroke restores the most recently deleted stroke
+// Batched undos can share a timestamp; the lower ID was undone later, so it is restored first
+func redoWhiteboardStroke(db *gorm.DB, scope whiteboardScope) {
+	var lastDeletedStroke model.WhiteboardStroke
+	err := scope.where(db).Where("is_deleted = ?", true).Order("deleted_at DESC, id ASC").First(&lastDeletedStroke).Error
+	if err == nil {
+		db.Model(&lastDeletedStroke).Updates(map[string]interface{}{
+			"is_deleted": false,
+			"deleted_at": gorm.Expr("NULL"),
+		})
+	}
+}
+
+// addWhiteboardStrokes clears the redo stack and inserts new strokes in order
+func addWhiteboardStrokes(db *gorm.DB, scope whiteboardScope, strokes []model.WhiteboardStroke) error {
+	// Clear Redo stack first
+	scope.where(db).Where("is_deleted = ?", true).Delete(&model.WhiteboardStroke{})
+	return db.CreateInBatches(strokes, 100).Error
+}
+
 // Helper to get meeting ID from room name
 func (h *WhiteboardHandler) getMeetingID(roomName string, userID int64) (int64, error) {
 	// 1. Check for standard "meeting-{id}" format
@@ -298,6 +393,10 @@ func (h *WhiteboardHandler) getMeetingID(roomName string, userID int64) (int64,
 			// Extract ID coming after "workspace"
 			// Format: workspace-{id}-...
 			if wid, err := strconv.ParseInt(parts[1], 10, 64); err == nil {
+				// Only members may open a channel of the workspace; otherwise anyone could create meetings in it
+				if !h.isWorkspaceMember(wid, userID) {
+					return 0, errWhiteboardForbidden
+				}
 				// Create a new persistent meeting for this channel
 				newMeeting := model.Meeting{
 					WorkspaceID: &wid,
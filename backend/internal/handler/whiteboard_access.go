@@ -0,0 +1,177 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"realtime-backend/internal/auth"
+	"realtime-backend/internal/model"
+)
+
+var (
+	errWhiteboardDrawDenied = errors.New("permission denied: DRAW_WHITEBOARD")
+	errWhiteboardViewOnly   = errors.New("whiteboard is in view-only mode")
+)
+
+// whiteboardAccess is what a user may do on a board; it is checked once per request or connection
+type whiteboardAccess struct {
+	CanDraw   bool // DRAW_WHITEBOARD (meeting channel overrides apply)
+	Presenter bool // may toggle view-only mode and keeps drawing while it is on
+}
+
+// check returns an error if the user may not change the board in its current mode
+func (a whiteboardAccess) check(viewOnly bool) error {
+	if !a.CanDraw {
+		return errWhiteboardDrawDenied
+	}
+	if viewOnly && !a.Presenter {
+		return errWhiteboardViewOnly
+	}
+	return nil
+}
+
+// WhiteboardViewOnlyRequest turns view-only mode on or off for a board
+type WhiteboardViewOnlyRequest struct {
+	Room     string `json:"room"`
+	Board    int64  `json:"board,omitempty"`
+	ViewOnly bool   `json:"view_only"`
+}
+
+// canViewMeeting reports whether the user may see a meeting's boards:
+// workspace members for workspace meetings, otherwise the host and participants
+func (h *WhiteboardHandler) canViewMeeting(meeting *model.Meeting, userID int64) bool {
+	if meeting.WorkspaceID != nil {
+		return h.isWorkspaceMember(*meeting.WorkspaceID, userID)
+	}
+	if meeting.HostID == userID {
+		return true
+	}
+	var count int64
+	h.db.Model(&model.Participant{}).
+		Where("meeting_id = ? AND user_id = ?", meeting.ID, userID).
+		Count(&count)
+	return count > 0
+}
+
+// whiteboardAccess loads the user's drawing permission and presenter role for a board
+func (h *WhiteboardHandler) whiteboardAccess(scope whiteboardScope, userID int64) (whiteboardAccess, error) {
+	var access whiteboardAccess
+	var err error
+	switch {
+	case scope.MeetingID != 0 && scope.WorkspaceID != 0:
+		access.CanDraw, err = auth.CheckChannelPermission(h.db, scope.WorkspaceID, scope.MeetingID, userID, model.PermissionDrawWhiteboard)
+	case scope.WorkspaceID != 0:
+		access.CanDraw, err = auth.CheckPermission(h.db, scope.WorkspaceID, userID, model.PermissionDrawWhiteboard)
+	default:
+		// Meetings outside a workspace have no roles; every participant may draw
+		access.CanDraw = true
+	}
+	if err != nil {
+		return access, err
+	}
+
+	access.Presenter, err = h.isWhiteboardPresenter(scope, userID)
+	return access, err
+}
+
+// isWhiteboardPresenter reports whether the user controls view-only mode:
+// the meeting host or a HOST/PRESENTER participant, the creator of a workspace board,
+// or a workspace member with MANAGE_CHANNELS
+func (h *WhiteboardHandler) isWhiteboardPresenter(scope whiteboardScope, userID int64) (bool, error) {
+	if scope.MeetingID != 0 {
+		var meeting model.Meeting
+		if err := h.db.Select("id", "host_id").First(&meeting, scope.MeetingID).Error; err != nil {
+			return false, err
+		}
+		if meeting.HostID == userID {
+			return true, nil
+		}
+		var count int64
+		h.db.Model(&model.Participant{}).
+			Where("meeting_id = ? AND user_id = ? AND role IN ?", scope.MeetingID, userID, []string{"HOST", "PRESENTER"}).
+			Count(&count)
+		if count > 0 {
+			return true, nil
+		}
+	} else if scope.BoardID != 0 {
+		var createdBy *int64
+		h.db.Model(&model.Whiteboard{}).Where("id = ?", scope.BoardID).Select("created_by").Scan(&createdBy)
+		if createdBy != nil && *createdBy == userID {
+			return true, nil
+		}
+	}
+
+	if scope.WorkspaceID == 0 {
+		return false, nil
+	}
+	return auth.CheckPermission(h.db, scope.WorkspaceID, userID, model.PermissionManageChannels)
+}
+
+// whiteboardViewOnly reads the stored view-only flag (named boards: whiteboards, default boards: meetings)
+func (h *WhiteboardHandler) whiteboardViewOnly(scope whiteboardScope) bool {
+	var viewOnly bool
+	if scope.BoardID != 0 {
+		h.db.Model(&model.Whiteboard{}).Where("id = ?", scope.BoardID).Select("view_only").Scan(&viewOnly)
+	} else {
+		h.db.Model(&model.Meeting{}).Where("id = ?", scope.MeetingID).Select("whiteboard_view_only").Scan(&viewOnly)
+	}
+	return viewOnly
+}
+
+// requireWhiteboardDraw checks that the user may currently change the board.
+// Writes the error response and returns false on failure.
+func (h *WhiteboardHandler) requireWhiteboardDraw(c *fiber.Ctx, scope whiteboardScope, userID int64) (whiteboardAccess, bool) {
+	access, err := h.whiteboardAccess(scope, userID)
+	if err != nil {
+		c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "permission check failed"})
+		return access, false
+	}
+	if err := access.check(h.whiteboardViewOnly(scope)); err != nil {
+		whiteboardOpError(c, err)
+		return access, false
+	}
+	return access, true
+}
+
+// SetViewOnly turns view-only mode on or off; only the presenter may change it.
+// Connected clients receive a "mode" event.
+func (h *WhiteboardHandler) SetViewOnly(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+
+	var req WhiteboardViewOnlyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid request body"})
+	}
+	if req.Room == "" && req.Board == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Room name is required"})
+	}
+
+	scope, err := h.resolveScope(req.Room, req.Board, claims.UserID)
+	if err != nil {
+		return whiteboardScopeError(c, err)
+	}
+
+	presenter, err := h.isWhiteboardPresenter(scope, claims.UserID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "permission check failed"})
+	}
+	if !presenter {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "only the presenter can change view-only mode"})
+	}
+
+	if scope.BoardID != 0 {
+		err = h.db.Model(&model.Whiteboard{}).Where("id = ?", scope.BoardID).Update("view_only", req.ViewOnly).Error
+	} else {
+		err = h.db.Model(&model.Meeting{}).Where("id = ?", scope.MeetingID).Update("whiteboard_view_only", req.ViewOnly).Error
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to update view-only mode"})
+	}
+
+	h.setLiveViewOnly(scope, claims.UserID, req.ViewOnly)
+	return c.JSON(fiber.Map{
+		"success":  true,
+		"viewOnly": req.ViewOnly,
+	})
+}
@@ -0,0 +1,372 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+
+	"realtime-backend/internal/auth"
+	"realtime-backend/internal/model"
+)
+
+const whiteboardNameMaxLength = 100
+
+var (
+	errWhiteboardMeetingNotFound = errors.New("meeting not found")
+	errWhiteboardNotFound        = errors.New("whiteboard not found")
+	errWhiteboardForbidden       = errors.New("whiteboard access denied")
+)
+
+// whiteboardScope identifies one stroke history: a meeting's default board (BoardID 0)
+// or a named board (BoardID set; MeetingID is 0 for workspace boards)
+type whiteboardScope struct {
+	MeetingID   int64
+	BoardID     int64
+	WorkspaceID int64 // owner of uploaded images; 0 if the meeting has no workspace
+}
+
+// where narrows a stroke/snapshot query to this board
+func (s whiteboardScope) where(db *gorm.DB) *gorm.DB {
+	if s.BoardID != 0 {
+		return db.Where("whiteboard_id = ?", s.BoardID)
+	}
+	return db.Where("meeting_id = ? AND whiteboard_id IS NULL", s.MeetingID)
+}
+
+func (s whiteboardScope) meetingRef() *int64 {
+	if s.MeetingID == 0 {
+		return nil
+	}
+	id := s.MeetingID
+	return &id
+}
+
+func (s whiteboardScope) boardRef() *int64 {
+	if s.BoardID == 0 {
+		return nil
+	}
+	id := s.BoardID
+	return &id
+}
+
+// newStroke builds a freehand stroke row belonging to this board
+func (s whiteboardScope) newStroke(userID int64, data []byte) model.WhiteboardStroke {
+	return model.WhiteboardStroke{
+		MeetingID:    s.meetingRef(),
+		WhiteboardID: s.boardRef(),
+		UserID:       userID,
+		ObjectType:   model.WhiteboardObjectStroke,
+		StrokeData:   string(data),
+		IsDeleted:    false,
+	}
+}
+
+// newObject builds a shape/text/image row belonging to this board
+func (s whiteboardScope) newObject(userID int64, objectType, objectID string, data []byte) model.WhiteboardStroke {
+	row := s.newStroke(userID, data)
+	row.ObjectType = objectType
+	row.ObjectID = &objectID
+	return row
+}
+
+func (s whiteboardScope) String() string {
+	if s.BoardID != 0 {
+		return fmt.Sprintf("board %d", s.BoardID)
+	}
+	return fmt.Sprintf("meeting %d", s.MeetingID)
+}
+
+// WhiteboardBoardRequest creates or renames a named board
+type WhiteboardBoardRequest struct {
+	Room string `json:"room,omitempty"` // meeting boards only (POST /api/whiteboard/boards)
+	Name string `json:"name"`
+}
+
+// WhiteboardBoardResponse is one entry of the board picker
+type WhiteboardBoardResponse struct {
+	ID          int64     `json:"id"`
+	Name        string    `json:"name"`
+	MeetingID   *int64    `json:"meeting_id,omitempty"`
+	WorkspaceID int64     `json:"workspace_id"`
+	CreatedBy   *int64    `json:"created_by,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// resolveScope picks the stroke history for a request: ?board= wins, otherwise the room's default board.
+// The caller must be able to see the meeting (see canViewMeeting); a named board must belong to the
+// caller's workspace and, if a room is also given, to that meeting.
+func (h *WhiteboardHandler) resolveScope(roomName string, boardID, userID int64) (whiteboardScope, error) {
+	if boardID == 0 {
+		meeting, err := h.findWhiteboardMeeting(roomName, userID)
+		if err != nil {
+			return whiteboardScope{}, err
+		}
+		scope := whiteboardScope{MeetingID: meeting.ID}
+		if meeting.WorkspaceID != nil {
+			scope.WorkspaceID = *meeting.WorkspaceID
+		}
+		return scope, nil
+	}
+
+	var board model.Whiteboard
+	if err := h.db.First(&board, boardID).Error; err != nil {
+		return whiteboardScope{}, errWhiteboardNotFound
+	}
+	if !h.isWorkspaceMember(board.WorkspaceID, userID) {
+		return whiteboardScope{}, errWhiteboardForbidden
+	}
+
+	scope := whiteboardScope{BoardID: board.ID, WorkspaceID: board.WorkspaceID}
+	if board.MeetingID != nil {
+		scope.MeetingID = *board.MeetingID
+	}
+	if roomName != "" {
+		meetingID, err := h.getMeetingID(roomName, userID)
+		if err != nil || meetingID != scope.MeetingID {
+			return whiteboardScope{}, errWhiteboardNotFound
+		}
+	}
+	return scope, nil
+}
+
+// whiteboardScopeError maps resolveScope errors to HTTP responses
+func whiteboardScopeError(c *fiber.Ctx, err error) error {
+	if errors.Is(err, errWhiteboardForbidden) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "you do not have access to this whiteboard"})
+	}
+	if errors.Is(err, errWhiteboardMeetingNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Meeting not found"})
+	}
+	return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Whiteboard not found"})
+}
+
+// ListMeetingBoards lists the named boards of a meeting (?room=)
+// The meeting's default board is always available and is not listed.
+func (h *WhiteboardHandler) ListMeetingBoards(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+
+	meeting, ok := h.requireMeetingForBoards(c, c.Query("room"), claims.UserID)
+	if !ok {
+		return nil
+	}
+
+	var boards []model.Whiteboard
+	if err := h.db.Where("meeting_id = ?", meeting.ID).Order("id ASC").Find(&boards).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get whiteboards"})
+	}
+	return c.JSON(fiber.Map{"boards": toWhiteboardBoardResponses(boards)})
+}
+
+// CreateMeetingBoard adds a named board to a meeting
+func (h *WhiteboardHandler) CreateMeetingBoard(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+
+	var req WhiteboardBoardRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid request body"})
+	}
+	name, msg := normalizeWhiteboardName(req.Name)
+	if msg != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": msg})
+	}
+
+	meeting, ok := h.requireMeetingForBoards(c, req.Room, claims.UserID)
+	if !ok {
+		return nil
+	}
+	if meeting.WorkspaceID == nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "meeting does not belong to a workspace"})
+	}
+	if _, ok := h.requireWhiteboardDraw(c, whiteboardScope{MeetingID: meeting.ID, WorkspaceID: *meeting.WorkspaceID}, claims.UserID); !ok {
+		return nil
+	}
+
+	meetingID := meeting.ID
+	board := model.Whiteboard{
+		MeetingID:   &meetingID,
+		WorkspaceID: *meeting.WorkspaceID,
+		Name:        name,
+		CreatedBy:   &claims.UserID,
+	}
+	if err := h.db.Create(&board).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to create whiteboard"})
+	}
+	return c.Status(fiber.StatusCreated).JSON(toWhiteboardBoardResponse(&board))
+}
+
+// ListWorkspaceBoards lists the workspace-level boards (not attached to a meeting)
+func (h *WhiteboardHandler) ListWorkspaceBoards(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	workspaceID, err := c.ParamsInt("workspaceId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid workspace id"})
+	}
+	if !h.isWorkspaceMember(int64(workspaceID), claims.UserID) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "you are not a member of this workspace"})
+	}
+
+	var boards []model.Whiteboard
+	if err := h.db.Where("workspace_id = ? AND meeting_id IS NULL", workspaceID).
+		Order("updated_at DESC").
+		Find(&boards).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get whiteboards"})
+	}
+	return c.JSON(fiber.Map{"boards": toWhiteboardBoardResponses(boards)})
+}
+
+// CreateWorkspaceBoard adds a workspace-level board
+func (h *WhiteboardHandler) CreateWorkspaceBoard(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	workspaceID, err := c.ParamsInt("workspaceId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid workspace id"})
+	}
+	if !h.isWorkspaceMember(int64(workspaceID), claims.UserID) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "you are not a member of this workspace"})
+	}
+	access, err := h.whiteboardAccess(whiteboardScope{WorkspaceID: int64(workspaceID)}, claims.UserID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "permission check failed"})
+	}
+	if !access.CanDraw {
+		return whiteboardOpError(c, errWhiteboardDrawDenied)
+	}
+
+	var req WhiteboardBoardRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid request body"})
+	}
+	name, msg := normalizeWhiteboardName(req.Name)
+	if msg != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": msg})
+	}
+
+	board := model.Whiteboard{
+		WorkspaceID: int64(workspaceID),
+		Name:        name,
+		CreatedBy:   &claims.UserID,
+	}
+	if err := h.db.Create(&board).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to create whiteboard"})
+	}
+	return c.Status(fiber.StatusCreated).JSON(toWhiteboardBoardResponse(&board))
+}
+
+// RenameBoard renames a named board (members who may currently draw on it)
+func (h *WhiteboardHandler) RenameBoard(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	boardID, err := c.ParamsInt("boardId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid whiteboard id"})
+	}
+
+	var req WhiteboardBoardRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid request body"})
+	}
+	name, msg := normalizeWhiteboardName(req.Name)
+	if msg != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": msg})
+	}
+
+	var board model.Whiteboard
+	if err := h.db.First(&board, boardID).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Whiteboard not found"})
+	}
+	if !h.isWorkspaceMember(board.WorkspaceID, claims.UserID) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "you are not a member of this workspace"})
+	}
+	scope := whiteboardScope{BoardID: board.ID, WorkspaceID: board.WorkspaceID}
+	if board.MeetingID != nil {
+		scope.MeetingID = *board.MeetingID
+	}
+	if _, ok := h.requireWhiteboardDraw(c, scope, claims.UserID); !ok {
+		return nil
+	}
+
+	if err := h.db.Model(&board).Update("name", name).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to rename whiteboard"})
+	}
+	return c.JSON(toWhiteboardBoardResponse(&board))
+}
+
+// requireMeetingForBoards resolves a room to its meeting and checks that the caller can see it.
+// Writes the error response and returns false on failure.
+func (h *WhiteboardHandler) requireMeetingForBoards(c *fiber.Ctx, roomName string, userID int64) (*model.Meeting, bool) {
+	if roomName == "" {
+		c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Room name is required"})
+		return nil, false
+	}
+	meeting, err := h.findWhiteboardMeeting(roomName, userID)
+	if err != nil {
+		whiteboardScopeError(c, err)
+		return nil, false
+	}
+	return meeting, true
+}
+
+// findWhiteboardMeeting resolves a room to its meeting and checks that the caller can see it
+func (h *WhiteboardHandler) findWhiteboardMeeting(roomName string, userID int64) (*model.Meeting, error) {
+	meetingID, err := h.getMeetingID(roomName, userID)
+	if errors.Is(err, errWhiteboardForbidden) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, errWhiteboardMeetingNotFound
+	}
+
+	var meeting model.Meeting
+	if err := h.db.Select("id", "workspace_id", "host_id").First(&meeting, meetingID).Error; err != nil {
+		return nil, errWhiteboardMeetingNotFound
+	}
+	if !h.canViewMeeting(&meeting, userID) {
+		return nil, errWhiteboardForbidden
+	}
+	return &meeting, nil
+}
+
+func (h *WhiteboardHandler) isWorkspaceMember(workspaceID, userID int64) bool {
+	var count int64
+	h.db.Model(&model.WorkspaceMember{}).
+		Where("workspace_id = ? AND user_id = ? AND status = ?", workspaceID, userID, model.MemberStatusActive.String()).
+		Count(&count)
+	return count > 0
+}
+
+// normalizeWhiteboardName trims the name and returns an error message if it is empty or too long
+func normalizeWhiteboardName(name string) (string, string) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", "name is required"
+	}
+	if utf8.RuneCountInString(name) > whiteboardNameMaxLength {
+		return "", fmt.Sprintf("name must be at most %d characters", whiteboardNameMaxLength)
+	}
+	return name, ""
+}
+
+func toWhiteboardBoardResponse(board *model.Whiteboard) WhiteboardBoardResponse {
+	return WhiteboardBoardResponse{
+		ID:          board.ID,
+		Name:        board.Name,
+		MeetingID:   board.MeetingID,
+		WorkspaceID: board.WorkspaceID,
+		CreatedBy:   board.CreatedBy,
+		CreatedAt:   board.CreatedAt,
+		UpdatedAt:   board.UpdatedAt,
+	}
+}
+
+func toWhiteboardBoardResponses(boards []model.Whiteboard) []WhiteboardBoardResponse {
+	responses := make([]WhiteboardBoardResponse, len(boards))
+	for i := range boards {
+		responses[i] = toWhiteboardBoardResponse(&boards[i])
+	}
+	return responses
+}
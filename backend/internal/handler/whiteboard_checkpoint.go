@@ -0,0 +1,312 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+
+	"realtime-backend/internal/auth"
+	"realtime-backend/internal/errorreport"
+	"realtime-backend/internal/model"
+)
+
+const (
+	whiteboardDefaultTrashRetention = 7 * 24 * time.Hour
+	whiteboardTrashSweepInterval    = time.Hour
+	whiteboardMaxCheckpoints        = 50 // named checkpoints per board
+)
+
+// whiteboardCheckpointData is the board content stored in WhiteboardCheckpoint.Data
+type whiteboardCheckpointData struct {
+	Snapshots []json.RawMessage         `json:"snapshots"` // WhiteboardSnapshot.Data chunks, oldest first
+	Rows      []whiteboardCheckpointRow `json:"rows"`      // active strokes and objects, oldest first
+}
+
+type whiteboardCheckpointRow struct {
+	UserID     int64           `json:"userId"`
+	ObjectType string          `json:"objectType"`
+	ObjectID   *string         `json:"objectId,omitempty"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// WhiteboardCheckpointRequest names a new checkpoint (room/board select the board like POST /api/whiteboard)
+type WhiteboardCheckpointRequest struct {
+	Room  string `json:"room"`
+	Board int64  `json:"board,omitempty"`
+	Name  string `json:"name"`
+}
+
+// SetTrashRetention sets how long content replaced by a restore is kept and starts the sweeper
+func (h *WhiteboardHandler) SetTrashRetention(retention time.Duration) {
+	if retention > 0 {
+		h.trashRetention = retention
+	}
+	if h.trashDone == nil {
+		h.trashDone = make(chan struct{})
+		go h.runTrashSweeper(h.trashDone)
+	}
+}
+
+// ListCheckpoints lists a board's named checkpoints and unexpired trash (?room=, ?board=), newest first
+func (h *WhiteboardHandler) ListCheckpoints(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	roomName := c.Query("room")
+	boardID := int64(c.QueryInt("board"))
+	if roomName == "" && boardID == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Room name is required"})
+	}
+
+	scope, err := h.resolveScope(roomName, boardID, claims.UserID)
+	if err != nil {
+		return whiteboardScopeError(c, err)
+	}
+
+	var checkpoints []model.WhiteboardCheckpoint
+	if err := scope.where(h.db).
+		Where("expires_at IS NULL OR expires_at > ?", time.Now()).
+		Omit("data").
+		Order("id DESC").
+		Find(&checkpoints).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get checkpoints"})
+	}
+	return c.JSON(fiber.Map{"checkpoints": checkpoints})
+}
+
+// CreateCheckpoint saves the board's current content under a name
+func (h *WhiteboardHandler) CreateCheckpoint(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+
+	var req WhiteboardCheckpointRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid request body"})
+	}
+	if req.Room == "" && req.Board == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Room name is required"})
+	}
+	name, msg := normalizeWhiteboardName(req.Name)
+	if msg != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": msg})
+	}
+
+	scope, err := h.resolveScope(req.Room, req.Board, claims.UserID)
+	if err != nil {
+		return whiteboardScopeError(c, err)
+	}
+	if _, ok := h.requireWhiteboardDraw(c, scope, claims.UserID); !ok {
+		return nil
+	}
+
+	var count int64
+	scope.where(h.db.Model(&model.WhiteboardCheckpoint{})).
+		Where("kind = ?", model.WhiteboardCheckpointManual).
+		Count(&count)
+	if count >= whiteboardMaxCheckpoints {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": fmt.Sprintf("a board can have at most %d checkpoints", whiteboardMaxCheckpoints),
+		})
+	}
+
+	// Events from WebSocket clients may still be queued; write them first so the checkpoint is complete
+	h.flushLiveBoard(scope)
+
+	data, itemCount, err := captureWhiteboard(h.db, scope)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to read whiteboard"})
+	}
+	checkpoint := model.WhiteboardCheckpoint{
+		MeetingID:    scope.meetingRef(),
+		WhiteboardID: scope.boardRef(),
+		Kind:         model.WhiteboardCheckpointManual,
+		Name:         name,
+		Data:         string(data),
+		ItemCount:    itemCount,
+		CreatedBy:    &claims.UserID,
+	}
+	if err := h.db.Create(&checkpoint).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to create checkpoint"})
+	}
+	return c.Status(fiber.StatusCreated).JSON(checkpoint)
+}
+
+// RestoreCheckpoint reverts the board to a checkpoint in one transaction.
+// The content it replaces is kept as a TRASH checkpoint (restorable) until trashRetention passes.
+func (h *WhiteboardHandler) RestoreCheckpoint(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	checkpointID, err := c.ParamsInt("checkpointId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid checkpoint id"})
+	}
+
+	var req WhiteboardCheckpointRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid request body"})
+	}
+	if req.Room == "" && req.Board == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Room name is required"})
+	}
+
+	scope, err := h.resolveScope(req.Room, req.Board, claims.UserID)
+	if err != nil {
+		return whiteboardScopeError(c, err)
+	}
+	if _, ok := h.requireWhiteboardDraw(c, scope, claims.UserID); !ok {
+		return nil
+	}
+
+	var checkpoint model.WhiteboardCheckpoint
+	if err := scope.where(h.db).
+		Where("id = ? AND (expires_at IS NULL OR expires_at > ?)", checkpointID, time.Now()).
+		First(&checkpoint).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "checkpoint not found"})
+	}
+
+	var trash model.WhiteboardCheckpoint
+	err = h.withBoardLocked(scope, func(board *whiteboardBoard) error {
+		if err := h.db.Transaction(func(tx *gorm.DB) error {
+			data, itemCount, err := captureWhiteboard(tx, scope)
+			if err != nil {
+				return err
+			}
+			expiresAt := time.Now().Add(h.trashRetention)
+			trash = model.WhiteboardCheckpoint{
+				MeetingID:    scope.meetingRef(),
+				WhiteboardID: scope.boardRef(),
+				Kind:         model.WhiteboardCheckpointTrash,
+				Name:         fmt.Sprintf("Before restoring checkpoint #%d", checkpoint.ID),
+				Data:         string(data),
+				ItemCount:    itemCount,
+				CreatedBy:    &claims.UserID,
+				ExpiresAt:    &expiresAt,
+			}
+			if err := tx.Create(&trash).Error; err != nil {
+				return err
+			}
+			return restoreWhiteboard(tx, scope, checkpoint.Data)
+		}); err != nil {
+			return err
+		}
+
+		if board != nil {
+			h.reloadLiveBoard(board, claims.UserID)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("[Whiteboard] Failed to restore checkpoint %d for %s: %v", checkpoint.ID, scope, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to restore checkpoint"})
+	}
+	log.Printf("[Whiteboard] User %d restored checkpoint %d in %s (previous content in checkpoint %d)", claims.UserID, checkpoint.ID, scope, trash.ID)
+
+	undoCount, redoCount := countWhiteboardStrokes(h.db, scope)
+	return c.JSON(fiber.Map{
+		"success":             true,
+		"canUndo":             undoCount > 0,
+		"canRedo":             redoCount > 0,
+		"trash_checkpoint_id": trash.ID,
+	})
+}
+
+// captureWhiteboard returns the board content as checkpoint data and the number of strokes/objects in it.
+// Undone strokes (the redo stack) are not included.
+func captureWhiteboard(db *gorm.DB, scope whiteboardScope) ([]byte, int, error) {
+	var snapshots []model.WhiteboardSnapshot
+	if err := scope.where(db).Order("id ASC").Find(&snapshots).Error; err != nil {
+		return nil, 0, err
+	}
+	var rows []model.WhiteboardStroke
+	if err := scope.where(db).Where("is_deleted = ?", false).Order("id ASC").Find(&rows).Error; err != nil {
+		return nil, 0, err
+	}
+
+	data := whiteboardCheckpointData{
+		Snapshots: make([]json.RawMessage, 0, len(snapshots)),
+		Rows:      make([]whiteboardCheckpointRow, 0, len(rows)),
+	}
+	itemCount := len(rows)
+	for _, snap := range snapshots {
+		var chunk []json.RawMessage
+		if err := json.Unmarshal([]byte(snap.Data), &chunk); err != nil {
+			return nil, 0, fmt.Errorf("snapshot %d: %w", snap.ID, err)
+		}
+		data.Snapshots = append(data.Snapshots, json.RawMessage(snap.Data))
+		itemCount += len(chunk)
+	}
+	for _, row := range rows {
+		data.Rows = append(data.Rows, whiteboardCheckpointRow{
+			UserID:     row.UserID,
+			ObjectType: row.ObjectType,
+			ObjectID:   row.ObjectID,
+			Data:       json.RawMessage(row.StrokeData),
+		})
+	}
+
+	encoded, err := json.Marshal(data)
+	return encoded, itemCount, err
+}
+
+// restoreWhiteboard replaces the board content with checkpoint data (call inside a transaction)
+func restoreWhiteboard(tx *gorm.DB, scope whiteboardScope, raw string) error {
+	var data whiteboardCheckpointData
+	if err := json.Unmarshal([]byte(raw), &data); err != nil {
+		return fmt.Errorf("invalid checkpoint data: %w", err)
+	}
+
+	if err := clearWhiteboard(tx, scope); err != nil {
+		return err
+	}
+	for _, chunk := range data.Snapshots {
+		snapshot := model.WhiteboardSnapshot{
+			MeetingID:    scope.meetingRef(),
+			WhiteboardID: scope.boardRef(),
+			Data:         string(chunk),
+		}
+		if err := tx.Create(&snapshot).Error; err != nil {
+			return err
+		}
+	}
+
+	if len(data.Rows) == 0 {
+		return nil
+	}
+	rows := make([]model.WhiteboardStroke, 0, len(data.Rows))
+	for _, r := range data.Rows {
+		row := scope.newStroke(r.UserID, r.Data)
+		row.ObjectType = r.ObjectType
+		row.ObjectID = r.ObjectID
+		rows = append(rows, row)
+	}
+	return tx.CreateInBatches(rows, 100).Error
+}
+
+func (h *WhiteboardHandler) runTrashSweeper(done <-chan struct{}) {
+	defer errorreport.Recover(errorreport.Context{Component: "whiteboard.trash_sweeper"})
+
+	ticker := time.NewTicker(whiteboardTrashSweepInterval)
+	defer ticker.Stop()
+
+	for {
+		h.sweepTrash()
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// sweepTrash permanently deletes expired TRASH checkpoints
+func (h *WhiteboardHandler) sweepTrash() {
+	result := h.db.Where("kind = ? AND expires_at < ?", model.WhiteboardCheckpointTrash, time.Now()).
+		Delete(&model.WhiteboardCheckpoint{})
+	if result.Error != nil {
+		log.Printf("[Whiteboard] Failed to purge trash checkpoints: %v", result.Error)
+		return
+	}
+	if result.RowsAffected > 0 {
+		log.Printf("[Whiteboard] Purged %d expired trash checkpoints", result.RowsAffected)
+	}
+}
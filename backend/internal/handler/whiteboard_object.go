@@ -0,0 +1,418 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+
+	"realtime-backend/internal/auth"
+	"realtime-backend/internal/model"
+	"realtime-backend/internal/storage"
+)
+
+const (
+	whiteboardMaxStrokeBytes  = 64 * 1024        // largest stroke/object payload
+	whiteboardMaxDrawEvents   = 20000            // segments in one freehand stroke
+	whiteboardMaxCoordinate   = 1e6              // canvas coordinates are kept within ±1e6
+	whiteboardMaxObjectSize   = 1e5              // width/height of shapes, text boxes and images
+	whiteboardMaxLineWidth    = 500              // pen/border width
+	whiteboardMaxTextLength   = 5000             // characters in a text object
+	whiteboardMinFontSize     = 4                // px
+	whiteboardMaxFontSize     = 512              // px
+	whiteboardImageMaxBytes   = 10 * 1024 * 1024 // uploaded image size limit
+	whiteboardMaxColor        = 0xFFFFFF         // colors are 0xRRGGBB numbers, like DrawEvent.color
+	whiteboardObjectIDPattern = `^[A-Za-z0-9_-]{1,64}$`
+)
+
+var (
+	whiteboardObjectIDRegexp = regexp.MustCompile(whiteboardObjectIDPattern)
+
+	errWhiteboardObjectExists   = errors.New("object already exists")
+	errWhiteboardObjectNotFound = errors.New("object not found")
+)
+
+// WhiteboardObject is a shape, text or image on the whiteboard.
+// The client picks the ID and uses it for later update/delete events.
+type WhiteboardObject struct {
+	ID          string  `json:"id"`
+	Type        string  `json:"type"` // rect, ellipse, arrow, text, image
+	X           float64 `json:"x"`
+	Y           float64 `json:"y"`
+	Width       float64 `json:"width,omitempty"`  // rect, ellipse, image; optional wrap width for text
+	Height      float64 `json:"height,omitempty"` // rect, ellipse, image
+	X2          float64 `json:"x2,omitempty"`     // arrow end point
+	Y2          float64 `json:"y2,omitempty"`
+	Rotation    float64 `json:"rotation,omitempty"` // degrees
+	Color       int64   `json:"color"`              // border/line/text color
+	FillColor   *int64  `json:"fillColor,omitempty"`
+	StrokeWidth float64 `json:"strokeWidth,omitempty"`
+	Text        string  `json:"text,omitempty"`
+	FontSize    float64 `json:"fontSize,omitempty"`
+	ImageKey    string  `json:"imageKey,omitempty"` // from POST /api/whiteboard/images/upload-url
+}
+
+// whiteboardDrawEvent is one segment of a freehand stroke (frontend DrawEvent); other fields are kept as sent
+type whiteboardDrawEvent struct {
+	Type  string   `json:"type"`
+	X     *float64 `json:"x"`
+	Y     *float64 `json:"y"`
+	PrevX *float64 `json:"prevX"`
+	PrevY *float64 `json:"prevY"`
+	Color *int64   `json:"color"`
+	Width *float64 `json:"width"`
+}
+
+// WhiteboardImageUploadRequest requests a presigned URL for an image object
+type WhiteboardImageUploadRequest struct {
+	Room        string `json:"room"`
+	Board       int64  `json:"board,omitempty"`
+	ContentType string `json:"content_type"`
+	FileSize    int64  `json:"file_size"`
+}
+
+// newWhiteboardOp validates a client event (HTTP body or WebSocket message) and turns it into a board op.
+// An add without a stroke or object returns an op with an empty Type (nothing to do).
+func newWhiteboardOp(req *WhiteboardRequest, userID, workspaceID int64) (whiteboardOp, error) {
+	op := whiteboardOp{Type: req.Type, UserID: userID, At: time.Now()}
+
+	switch req.Type {
+	case "undo", "redo", "clear":
+		return op, nil
+
+	case "", "add":
+		op.Type = "add"
+		if len(req.Object) > 0 {
+			obj, data, err := validateWhiteboardObject(req.Object, workspaceID)
+			if err != nil {
+				return op, err
+			}
+			op.ObjectType, op.ObjectID, op.Data = obj.Type, obj.ID, data
+			return op, nil
+		}
+		if req.Stroke == nil {
+			op.Type = ""
+			return op, nil
+		}
+		data, err := json.Marshal(req.Stroke)
+		if err != nil {
+			return op, errors.New("Invalid stroke data")
+		}
+		if err := validateWhiteboardStroke(data); err != nil {
+			return op, err
+		}
+		op.ObjectType, op.Data = model.WhiteboardObjectStroke, data
+		return op, nil
+
+	case "update":
+		if len(req.Object) == 0 {
+			return op, errors.New("object is required")
+		}
+		obj, data, err := validateWhiteboardObject(req.Object, workspaceID)
+		if err != nil {
+			return op, err
+		}
+		op.ObjectType, op.ObjectID, op.Data = obj.Type, obj.ID, data
+		return op, nil
+
+	case "delete":
+		if !whiteboardObjectIDRegexp.MatchString(req.ObjectID) {
+			return op, errors.New("invalid objectId")
+		}
+		op.ObjectID = req.ObjectID
+		return op, nil
+	}
+	return op, errors.New("unknown event type")
+}
+
+// validateWhiteboardStroke checks a freehand stroke: one draw event or an array of them
+func validateWhiteboardStroke(data []byte) error {
+	if len(data) > whiteboardMaxStrokeBytes {
+		return errors.New("stroke is too large")
+	}
+
+	var events []whiteboardDrawEvent
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(data, &events); err != nil {
+			return errors.New("Invalid stroke data")
+		}
+	} else {
+		var event whiteboardDrawEvent
+		if err := json.Unmarshal(data, &event); err != nil {
+			return errors.New("Invalid stroke data")
+		}
+		events = []whiteboardDrawEvent{event}
+	}
+
+	if len(events) == 0 || len(events) > whiteboardMaxDrawEvents {
+		return errors.New("Invalid stroke data")
+	}
+	for _, e := range events {
+		if e.Type != "" && e.Type != "draw" {
+			return errors.New("Invalid stroke data")
+		}
+		for _, v := range []*float64{e.X, e.Y, e.PrevX, e.PrevY} {
+			if v == nil || !inCoordinateRange(*v) {
+				return errors.New("Invalid stroke data")
+			}
+		}
+		if e.Color == nil || !isWhiteboardColor(*e.Color) {
+			return errors.New("Invalid stroke color")
+		}
+		if e.Width == nil || *e.Width <= 0 || *e.Width > whiteboardMaxLineWidth {
+			return errors.New("Invalid stroke width")
+		}
+	}
+	return nil
+}
+
+// validateWhiteboardObject decodes an object strictly, checks the fields its type needs and
+// returns the canonical JSON that is stored and broadcast
+func validateWhiteboardObject(raw json.RawMessage, workspaceID int64) (*WhiteboardObject, []byte, error) {
+	if len(raw) > whiteboardMaxStrokeBytes {
+		return nil, nil, errors.New("object is too large")
+	}
+
+	var obj WhiteboardObject
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&obj); err != nil {
+		return nil, nil, errors.New("invalid object")
+	}
+
+	if !whiteboardObjectIDRegexp.MatchString(obj.ID) {
+		return nil, nil, errors.New("invalid object id")
+	}
+	if !inCoordinateRange(obj.X) || !inCoordinateRange(obj.Y) {
+		return nil, nil, errors.New("object position is out of range")
+	}
+	if obj.Rotation < -360 || obj.Rotation > 360 {
+		return nil, nil, errors.New("rotation must be between -360 and 360")
+	}
+	if !isWhiteboardColor(obj.Color) || (obj.FillColor != nil && !isWhiteboardColor(*obj.FillColor)) {
+		return nil, nil, errors.New("invalid color")
+	}
+	if obj.StrokeWidth < 0 || obj.StrokeWidth > whiteboardMaxLineWidth {
+		return nil, nil, errors.New("invalid strokeWidth")
+	}
+
+	hasSize := obj.Width > 0 && obj.Width <= whiteboardMaxObjectSize && obj.Height > 0 && obj.Height <= whiteboardMaxObjectSize
+	switch obj.Type {
+	case model.WhiteboardObjectRect, model.WhiteboardObjectEllipse:
+		if !hasSize {
+			return nil, nil, errors.New("width and height are required")
+		}
+		if obj.Text != "" || obj.ImageKey != "" || obj.X2 != 0 || obj.Y2 != 0 {
+			return nil, nil, fmt.Errorf("unexpected fields for %s", obj.Type)
+		}
+
+	case model.WhiteboardObjectArrow:
+		if !inCoordinateRange(obj.X2) || !inCoordinateRange(obj.Y2) || (obj.X2 == obj.X && obj.Y2 == obj.Y) {
+			return nil, nil, errors.New("arrow needs a distinct end point")
+		}
+		if obj.StrokeWidth == 0 {
+			return nil, nil, errors.New("strokeWidth is required")
+		}
+		if obj.Width != 0 || obj.Height != 0 || obj.Text != "" || obj.ImageKey != "" || obj.FillColor != nil {
+			return nil, nil, errors.New("unexpected fields for arrow")
+		}
+
+	case model.WhiteboardObjectText:
+		if obj.Text == "" || utf8.RuneCountInString(obj.Text) > whiteboardMaxTextLength {
+			return nil, nil, fmt.Errorf("text must be 1-%d characters", whiteboardMaxTextLength)
+		}
+		if obj.FontSize < whiteboardMinFontSize || obj.FontSize > whiteboardMaxFontSize {
+			return nil, nil, fmt.Errorf("fontSize must be between %d and %d", whiteboardMinFontSize, whiteboardMaxFontSize)
+		}
+		if obj.Width < 0 || obj.Width > whiteboardMaxObjectSize || obj.Height < 0 || obj.Height > whiteboardMaxObjectSize {
+			return nil, nil, errors.New("invalid text box size")
+		}
+		if obj.ImageKey != "" || obj.X2 != 0 || obj.Y2 != 0 {
+			return nil, nil, errors.New("unexpected fields for text")
+		}
+
+	case model.WhiteboardObjectImage:
+		if !hasSize {
+			return nil, nil, errors.New("width and height are required")
+		}
+		// The key must come from this workspace's upload URL, so one board cannot show another workspace's files
+		if keyWorkspace, ok := storage.WhiteboardImageWorkspace(obj.ImageKey); !ok || workspaceID == 0 || keyWorkspace != workspaceID {
+			return nil, nil, errors.New("invalid imageKey")
+		}
+		if obj.Text != "" || obj.X2 != 0 || obj.Y2 != 0 {
+			return nil, nil, errors.New("unexpected fields for image")
+		}
+
+	default:
+		return nil, nil, errors.New("unknown object type")
+	}
+
+	data, err := json.Marshal(&obj)
+	if err != nil {
+		return nil, nil, errors.New("invalid object")
+	}
+	return &obj, data, nil
+}
+
+func inCoordinateRange(v float64) bool {
+	return v >= -whiteboardMaxCoordinate && v <= whiteboardMaxCoordinate
+}
+
+func isWhiteboardColor(c int64) bool {
+	return c >= 0 && c <= whiteboardMaxColor
+}
+
+// row builds the stroke-table row for an add op
+func (op whiteboardOp) row(scope whiteboardScope) model.WhiteboardStroke {
+	if op.ObjectID != "" {
+		return scope.newObject(op.UserID, op.ObjectType, op.ObjectID, op.Data)
+	}
+	return scope.newStroke(op.UserID, op.Data)
+}
+
+// whiteboardOpError maps rejected board events (permission, view-only, object conflicts) to HTTP responses
+func whiteboardOpError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, errWhiteboardDrawDenied), errors.Is(err, errWhiteboardViewOnly):
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, errWhiteboardObjectExists):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, errWhiteboardObjectNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to update object"})
+}
+
+// findWhiteboardObject returns the type of a visible object on the board and whether it exists
+func findWhiteboardObject(db *gorm.DB, scope whiteboardScope, objectID string) (string, bool) {
+	var row model.WhiteboardStroke
+	if err := scope.where(db).Select("id", "object_type").
+		Where("object_id = ? AND is_deleted = ?", objectID, false).
+		First(&row).Error; err != nil {
+		return "", false
+	}
+	return row.ObjectType, true
+}
+
+// loadWhiteboardObjects returns the IDs and types of the board's visible objects
+func loadWhiteboardObjects(db *gorm.DB, scope whiteboardScope) map[string]string {
+	var rows []model.WhiteboardStroke
+	scope.where(db).Select("object_id", "object_type").
+		Where("object_id IS NOT NULL AND is_deleted = ?", false).
+		Find(&rows)
+
+	objects := make(map[string]string, len(rows))
+	for _, row := range rows {
+		objects[*row.ObjectID] = row.ObjectType
+	}
+	return objects
+}
+
+// writeObjectChange applies an update/delete op directly to the DB (board not open over WebSocket)
+func (h *WhiteboardHandler) writeObjectChange(scope whiteboardScope, op whiteboardOp) error {
+	objectType, ok := findWhiteboardObject(h.db, scope, op.ObjectID)
+	if !ok || (op.Type == "update" && objectType != op.ObjectType) {
+		return errWhiteboardObjectNotFound
+	}
+	if op.Type == "update" {
+		return updateWhiteboardObject(h.db, scope, op.ObjectID, op.Data)
+	}
+	return deleteWhiteboardObject(h.db, scope, op.ObjectID)
+}
+
+// updateWhiteboardObject replaces the data of a visible object
+func updateWhiteboardObject(db *gorm.DB, scope whiteboardScope, objectID string, data []byte) error {
+	return scope.where(db.Model(&model.WhiteboardStroke{})).
+		Where("object_id = ? AND is_deleted = ?", objectID, false).
+		Update("stroke_data", string(data)).Error
+}
+
+// deleteWhiteboardObject hard deletes an object; unlike undo it cannot be redone
+func deleteWhiteboardObject(db *gorm.DB, scope whiteboardScope, objectID string) error {
+	return scope.where(db).Where("object_id = ? AND is_deleted = ?", objectID, false).
+		Delete(&model.WhiteboardStroke{}).Error
+}
+
+// SetStorage sets the storage handler used for image objects (workspace data region bucket)
+func (h *WhiteboardHandler) SetStorage(storage *StorageHandler) {
+	h.storage = storage
+}
+
+// CreateImageUploadURL issues a presigned URL for uploading an image object to the board's workspace
+func (h *WhiteboardHandler) CreateImageUploadURL(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	if h.storage == nil {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"error": "file storage is not configured"})
+	}
+
+	var req WhiteboardImageUploadRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid request body"})
+	}
+	if req.Room == "" && req.Board == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Room name is required"})
+	}
+	if !storage.IsWhiteboardImageType(req.ContentType) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "image must be a PNG, JPEG, GIF or WebP file"})
+	}
+	if req.FileSize <= 0 || req.FileSize > whiteboardImageMaxBytes {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid file size",
+			"limit": whiteboardImageMaxBytes,
+		})
+	}
+
+	scope, err := h.resolveScope(req.Room, req.Board, claims.UserID)
+	if err != nil {
+		return whiteboardScopeError(c, err)
+	}
+	if scope.WorkspaceID == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "meeting does not belong to a workspace"})
+	}
+	if _, ok := h.requireWhiteboardDraw(c, scope, claims.UserID); !ok {
+		return nil
+	}
+
+	s3Service, err := h.storage.s3ForWorkspace(scope.WorkspaceID)
+	if err != nil {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"error": "file storage is not configured"})
+	}
+	presigned, err := s3Service.GenerateWhiteboardImageUploadURL(scope.WorkspaceID, req.ContentType, req.FileSize)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to generate upload URL"})
+	}
+	return c.JSON(presigned)
+}
+
+// GetImage redirects to a short-lived download URL for an image object (?key=)
+func (h *WhiteboardHandler) GetImage(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	if h.storage == nil {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"error": "file storage is not configured"})
+	}
+
+	key := c.Query("key")
+	workspaceID, ok := storage.WhiteboardImageWorkspace(key)
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid key"})
+	}
+	if !h.isWorkspaceMember(workspaceID, claims.UserID) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "you are not a member of this workspace"})
+	}
+
+	s3Service, err := h.storage.s3ForWorkspace(workspaceID)
+	if err != nil {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"error": "file storage is not configured"})
+	}
+	url, err := s3Service.GetFileURL(key)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to generate download URL"})
+	}
+	return c.Redirect(url, fiber.StatusFound)
+}
@@ -0,0 +1,154 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+const (
+	whiteboardCursorInterval          = 33 * time.Millisecond // cursor relays are coalesced to ~30 per second per client
+	whiteboardCursorMessagesPerSecond = 120                   // cursor messages above this close the connection
+	whiteboardMaxSelection            = 200                   // object IDs in one selection highlight
+)
+
+// whiteboardCursorColors is the palette collaborators' cursors are drawn in
+var whiteboardCursorColors = []string{
+	"#EF4444", "#F59E0B", "#10B981", "#3B82F6", "#8B5CF6",
+	"#EC4899", "#14B8A6", "#F97316", "#6366F1", "#84CC16",
+}
+
+// WhiteboardCursor is a collaborator's pointer and selection. It is relayed live and never stored.
+type WhiteboardCursor struct {
+	X         float64  `json:"x"`
+	Y         float64  `json:"y"`
+	Hidden    bool     `json:"hidden,omitempty"`    // pointer left the canvas
+	Selection []string `json:"selection,omitempty"` // IDs of the objects the user has selected
+}
+
+// WhiteboardPresence is one connected collaborator (one entry per connection, so two tabs show two cursors)
+type WhiteboardPresence struct {
+	ClientID string            `json:"clientId"`
+	UserID   int64             `json:"userId"`
+	Nickname string            `json:"nickname"`
+	Color    string            `json:"color"`
+	Cursor   *WhiteboardCursor `json:"cursor,omitempty"`
+}
+
+// whiteboardCursorColor gives each user the same color on every board
+func whiteboardCursorColor(userID int64) string {
+	i := userID % int64(len(whiteboardCursorColors))
+	if i < 0 {
+		i = -i
+	}
+	return whiteboardCursorColors[i]
+}
+
+// validate checks the cursor position and selected object IDs
+func (c *WhiteboardCursor) validate() error {
+	if !inCoordinateRange(c.X) || !inCoordinateRange(c.Y) {
+		return errors.New("cursor position is out of range")
+	}
+	if len(c.Selection) > whiteboardMaxSelection {
+		return errors.New("selection is too large")
+	}
+	for _, id := range c.Selection {
+		if !whiteboardObjectIDRegexp.MatchString(id) {
+			return errors.New("invalid object id in selection")
+		}
+	}
+	return nil
+}
+
+// presence describes the client to other collaborators (caller holds board.mu)
+func (c *whiteboardClient) presence() WhiteboardPresence {
+	return WhiteboardPresence{
+		ClientID: c.id,
+		UserID:   c.userID,
+		Nickname: c.nickname,
+		Color:    whiteboardCursorColor(c.userID),
+		Cursor:   c.cursor,
+	}
+}
+
+// presenceList returns every connected collaborator (caller holds b.mu)
+func (b *whiteboardBoard) presenceList() []WhiteboardPresence {
+	list := make([]WhiteboardPresence, 0, len(b.clients))
+	for client := range b.clients {
+		list = append(list, client.presence())
+	}
+	return list
+}
+
+// broadcastPresence tells the other clients that a collaborator joined or left (caller holds b.mu).
+// These use enqueue, not offer: a lost leave event would leave a stale cursor behind.
+func (b *whiteboardBoard) broadcastPresence(eventType string, client *whiteboardClient) {
+	p := client.presence()
+	msg, _ := json.Marshal(WhiteboardEvent{
+		Type:     eventType,
+		Presence: []WhiteboardPresence{p},
+		CanUndo:  b.undoCount > 0,
+		CanRedo:  b.redoCount > 0,
+	})
+	for other := range b.clients {
+		if other != client {
+			other.enqueue(msg)
+		}
+	}
+}
+
+// moveCursor stores the client's cursor and relays it to the other clients.
+// Updates arriving faster than whiteboardCursorInterval are coalesced so the latest position is always sent.
+func (b *whiteboardBoard) moveCursor(client *whiteboardClient, cursor *WhiteboardCursor) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	client.cursor = cursor
+	if client.cursorPending {
+		return
+	}
+	if wait := whiteboardCursorInterval - time.Since(client.cursorSentAt); wait > 0 {
+		client.cursorPending = true
+		time.AfterFunc(wait, func() { b.flushCursor(client) })
+		return
+	}
+	b.relayCursor(client)
+}
+
+// flushCursor sends a coalesced cursor update unless the client has left
+func (b *whiteboardBoard) flushCursor(client *whiteboardClient) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	client.cursorPending = false
+	if _, ok := b.clients[client]; ok {
+		b.relayCursor(client)
+	}
+}
+
+// relayCursor sends the client's current cursor to the others (caller holds b.mu)
+func (b *whiteboardBoard) relayCursor(client *whiteboardClient) {
+	client.cursorSentAt = time.Now()
+	msg, _ := json.Marshal(WhiteboardEvent{
+		Type:     "cursor",
+		ClientID: client.id,
+		UserID:   client.userID,
+		Cursor:   client.cursor,
+		CanUndo:  b.undoCount > 0,
+		CanRedo:  b.redoCount > 0,
+	})
+	for other := range b.clients {
+		if other != client {
+			other.offer(msg)
+		}
+	}
+}
+
+// offer queues an ephemeral event, dropping it if the client's queue is full.
+// Unlike enqueue it never closes the client: a missed cursor update is replaced by the next one.
+func (c *whiteboardClient) offer(msg []byte) {
+	select {
+	case c.send <- msg:
+	default:
+	}
+}
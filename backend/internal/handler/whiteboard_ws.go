@@ -0,0 +1,665 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/gofiber/contrib/websocket"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"realtime-backend/internal/auth"
+	"realtime-backend/internal/errorreport"
+	"realtime-backend/internal/model"
+)
+
+const (
+	whiteboardFlushBatch = 50  // flush early once this many events are queued
+	whiteboardSendBuffer = 256 // per-client outgoing queue; a full queue closes the client (4011)
+)
+
+// WhiteboardEvent is sent to /ws/whiteboard clients
+type WhiteboardEvent struct {
+	Type      string               `json:"type"` // init, add, update, delete, undo, redo, clear, restore, mode, cursor, join, leave, state, error
+	UserID    int64                `json:"userId,omitempty"`
+	ClientID  string               `json:"clientId,omitempty"` // cursor: the connection that moved
+	Cursor    *WhiteboardCursor    `json:"cursor,omitempty"`
+	Presence  []WhiteboardPresence `json:"presence,omitempty"` // init: other collaborators; join/leave: the one that changed
+	Stroke    json.RawMessage      `json:"stroke,omitempty"`
+	Object    json.RawMessage      `json:"object,omitempty"`
+	ObjectID  string               `json:"objectId,omitempty"`
+	History   []any                `json:"history,omitempty"`
+	CanUndo   bool                 `json:"canUndo"`
+	CanRedo   bool                 `json:"canRedo"`
+	ViewOnly  *bool                `json:"viewOnly,omitempty"`  // init and mode
+	CanDraw   *bool                `json:"canDraw,omitempty"`   // init: this client's DRAW_WHITEBOARD
+	Presenter *bool                `json:"presenter,omitempty"` // init: this client may draw in view-only mode
+	Message   string               `json:"message,omitempty"`
+}
+
+// whiteboardOp is a validated board event waiting to be written to the stroke tables
+type whiteboardOp struct {
+	Type       string // add, update, delete, undo, redo, clear
+	UserID     int64
+	ObjectType string          // add/update: stroke or a WhiteboardObject type
+	ObjectID   string          // empty for freehand strokes
+	Data       json.RawMessage // stroke or canonical object JSON
+	At         time.Time
+}
+
+// whiteboardBoard is the hub for one whiteboard (a meeting's default board or a named board).
+// Events are applied and broadcast immediately, then persisted in batches by runBoard.
+type whiteboardBoard struct {
+	scope whiteboardScope
+	refs  int // connected clients, guarded by WhiteboardHandler.mu
+
+	mu        sync.Mutex
+	clients   map[*whiteboardClient]struct{}
+	pending   []whiteboardOp
+	undoCount int               // active strokes (persisted + queued) that undo can remove
+	redoCount int               // undone strokes that redo can restore
+	objects   map[string]string // visible object ID -> type, for update/delete checks
+	viewOnly  bool              // only presenters may change the board
+
+	flushMu  sync.Mutex // serialises writes so queued events reach the DB in order
+	flushNow chan struct{}
+	done     chan struct{}
+	stopped  chan struct{}
+}
+
+// whiteboardClient is one /ws/whiteboard connection
+type whiteboardClient struct {
+	id        string // presence ID, unique per connection
+	userID    int64
+	nickname  string
+	access    whiteboardAccess // checked at connect; role changes apply on reconnect
+	conn      *websocket.Conn
+	send      chan []byte
+	closeOnce sync.Once
+
+	// Guarded by the board's mu
+	cursor        *WhiteboardCursor
+	cursorSentAt  time.Time
+	cursorPending bool
+}
+
+// SetRealtimeOptions configures the per-connection message limit and the batch persistence interval
+func (h *WhiteboardHandler) SetRealtimeOptions(messagesPerSecond int, flushInterval time.Duration) {
+	h.messagesPerSecond = messagesPerSecond
+	if flushInterval > 0 {
+		h.flushInterval = flushInterval
+	}
+}
+
+// ResolveBoard resolves the :room param (meeting default board) or ?board= (named board) before the WebSocket upgrade
+func (h *WhiteboardHandler) ResolveBoard(c *fiber.Ctx) error {
+	claims, ok := c.Locals("claims").(*auth.Claims)
+	if !ok {
+		// Already rejected by RequireWSAuth
+		return c.Next()
+	}
+
+	roomName := c.Params("room")
+	boardID := int64(c.QueryInt("board"))
+	if roomName == "" && boardID == 0 {
+		return RejectWebSocket(c, WSCloseInvalidRequest, "room or board is required")
+	}
+
+	scope, err := h.resolveScope(roomName, boardID, claims.UserID)
+	switch {
+	case errors.Is(err, errWhiteboardForbidden):
+		return RejectWebSocket(c, WSCloseForbidden, "whiteboard access denied")
+	case err != nil:
+		return RejectWebSocket(c, WSCloseNotFound, "whiteboard not found")
+	}
+	if scope.WorkspaceID != 0 {
+		satisfied, err := WorkspaceTwoFactorSatisfied(h.db, scope.WorkspaceID, claims.UserID)
+		if err != nil {
+			return RejectWebSocket(c, WSCloseInternalError, "permission check failed")
+		}
+		if !satisfied {
+			return RejectWebSocket(c, WSCloseForbidden, "this workspace requires two-factor authentication")
+		}
+	}
+	access, err := h.whiteboardAccess(scope, claims.UserID)
+	if err != nil {
+		return RejectWebSocket(c, WSCloseInternalError, "permission check failed")
+	}
+
+	c.Locals("whiteboardScope", scope)
+	c.Locals("whiteboardAccess", access)
+	c.Locals("userId", claims.UserID)
+	c.Locals("nickname", claims.Nickname)
+	return c.Next()
+}
+
+// HandleWebSocket joins the board hub, sends the current history and relays add/undo/redo/clear events
+func (h *WhiteboardHandler) HandleWebSocket(c *websocket.Conn) {
+	defer func() {
+		if r := recover(); r != nil {
+			errorreport.CapturePanic(r, wsReportContext(c, "ws.whiteboard"))
+		}
+	}()
+
+	scope, ok1 := c.Locals("whiteboardScope").(whiteboardScope)
+	userID, ok2 := c.Locals("userId").(int64)
+	access, ok3 := c.Locals("whiteboardAccess").(whiteboardAccess)
+	if !ok1 || !ok2 || !ok3 {
+		closeWS(c, WSCloseInternalError, "invalid session")
+		return
+	}
+
+	nickname, _ := c.Locals("nickname").(string)
+	client := &whiteboardClient{
+		id:       uuid.NewString(),
+		userID:   userID,
+		nickname: nickname,
+		access:   access,
+		conn:     c,
+		send:     make(chan []byte, whiteboardSendBuffer),
+	}
+	go client.writeLoop()
+
+	board := h.joinBoard(scope)
+	defer h.leaveBoard(board)
+
+	if err := h.attachClient(board, client); err != nil {
+		log.Printf("[Whiteboard] Failed to load history for %s: %v", scope, err)
+		close(client.send)
+		closeWS(c, WSCloseInternalError, "failed to load whiteboard")
+		return
+	}
+	defer board.detachClient(client)
+
+	log.Printf("[Whiteboard] Client connected: %s, user=%d", scope, userID)
+	defer log.Printf("[Whiteboard] Client disconnected: %s, user=%d", scope, userID)
+
+	limiter := newWSRateLimiter(h.messagesPerSecond)
+	// Cursors are sent far more often than board events, so they have their own, higher limit
+	cursorLimiter := newWSRateLimiter(whiteboardCursorMessagesPerSecond)
+	for {
+		_, msgBytes, err := c.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		var req WhiteboardRequest
+		if err := json.Unmarshal(msgBytes, &req); err != nil {
+			if !limiter.allow() {
+				log.Printf("[Whiteboard] Closing client over rate limit: %s, user=%d", scope, userID)
+				client.close(WSCloseRateLimited, "too many messages")
+				return
+			}
+			client.sendEvent(WhiteboardEvent{Type: "error", Message: "invalid message"})
+			continue
+		}
+
+		if req.Type == "cursor" {
+			if !cursorLimiter.allow() {
+				log.Printf("[Whiteboard] Closing client over cursor rate limit: %s, user=%d", scope, userID)
+				client.close(WSCloseRateLimited, "too many messages")
+				return
+			}
+			if req.Cursor == nil {
+				continue
+			}
+			if err := req.Cursor.validate(); err != nil {
+				client.sendEvent(WhiteboardEvent{Type: "error", Message: err.Error()})
+				continue
+			}
+			board.moveCursor(client, req.Cursor)
+			continue
+		}
+
+		if !limiter.allow() {
+			log.Printf("[Whiteboard] Closing client over rate limit: %s, user=%d", scope, userID)
+			client.close(WSCloseRateLimited, "too many messages")
+			return
+		}
+
+		op, err := newWhiteboardOp(&req, userID, scope.WorkspaceID)
+		if err != nil {
+			client.sendEvent(WhiteboardEvent{Type: "error", Message: err.Error()})
+			continue
+		}
+		if op.Type == "" {
+			continue
+		}
+
+		canUndo, canRedo, err := board.apply(op, client, client.access)
+		if err != nil {
+			client.sendEvent(WhiteboardEvent{Type: "error", Message: err.Error(), ObjectID: op.ObjectID, CanUndo: canUndo, CanRedo: canRedo})
+			continue
+		}
+		// The sender already drew the change locally; it only needs the new undo/redo state
+		client.sendEvent(WhiteboardEvent{Type: "state", CanUndo: canUndo, CanRedo: canRedo})
+	}
+}
+
+// submitToLiveBoard applies an HTTP whiteboard event through the hub if the board is open over WebSocket
+func (h *WhiteboardHandler) submitToLiveBoard(scope whiteboardScope, op whiteboardOp, access whiteboardAccess) (canUndo, canRedo, live bool, err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	board, ok := h.boards[scope]
+	if !ok {
+		return false, false, false, nil
+	}
+	canUndo, canRedo, err = board.apply(op, nil, access)
+	return canUndo, canRedo, true, err
+}
+
+// setLiveViewOnly updates the mode of an open board and tells its clients.
+// The flag is already stored, so a board opened after this reads the new value.
+func (h *WhiteboardHandler) setLiveViewOnly(scope whiteboardScope, userID int64, viewOnly bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	board, ok := h.boards[scope]
+	if !ok {
+		return
+	}
+
+	board.mu.Lock()
+	defer board.mu.Unlock()
+
+	board.viewOnly = viewOnly
+	msg, _ := json.Marshal(WhiteboardEvent{
+		Type:     "mode",
+		UserID:   userID,
+		ViewOnly: &viewOnly,
+		CanUndo:  board.undoCount > 0,
+		CanRedo:  board.redoCount > 0,
+	})
+	for client := range board.clients {
+		client.enqueue(msg)
+	}
+}
+
+// flushLiveBoard writes any queued events of an open board so HTTP reads see them
+func (h *WhiteboardHandler) flushLiveBoard(scope whiteboardScope) {
+	h.mu.Lock()
+	board, ok := h.boards[scope]
+	h.mu.Unlock()
+	if ok {
+		h.flushBoard(board)
+	}
+}
+
+// Close writes queued events of every open board (called on shutdown)
+func (h *WhiteboardHandler) Close() {
+	h.mu.Lock()
+	boards := make([]*whiteboardBoard, 0, len(h.boards))
+	for _, board := range h.boards {
+		boards = append(boards, board)
+	}
+	h.mu.Unlock()
+
+	for _, board := range boards {
+		h.flushBoard(board)
+	}
+
+	if h.trashDone != nil {
+		close(h.trashDone)
+	}
+}
+
+// withBoardLocked runs fn while no event can reach the board: an open board is flushed and kept
+// locked (board is nil otherwise), and h.mu stops a client from opening it in the meantime
+func (h *WhiteboardHandler) withBoardLocked(scope whiteboardScope, fn func(board *whiteboardBoard) error) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	board, ok := h.boards[scope]
+	if !ok {
+		return fn(nil)
+	}
+
+	board.flushMu.Lock()
+	defer board.flushMu.Unlock()
+	board.mu.Lock()
+	defer board.mu.Unlock()
+
+	ops := board.pending
+	board.pending = nil
+	h.persistOps(board, ops)
+	return fn(board)
+}
+
+// reloadLiveBoard resets an open board from the DB after its content was replaced and sends
+// every client the new history (caller holds board.mu via withBoardLocked)
+func (h *WhiteboardHandler) reloadLiveBoard(board *whiteboardBoard, userID int64) {
+	undoCount, redoCount := countWhiteboardStrokes(h.db, board.scope)
+	board.undoCount = int(undoCount)
+	board.redoCount = int(redoCount)
+	board.objects = loadWhiteboardObjects(h.db, board.scope)
+
+	history, _, _, err := loadWhiteboardHistory(h.db, board.scope)
+	if err != nil {
+		// Clients reload the whole board on reconnect
+		log.Printf("[Whiteboard] Failed to reload %s: %v", board.scope, err)
+		for client := range board.clients {
+			go client.close(WSCloseInternalError, "failed to reload whiteboard")
+		}
+		return
+	}
+	msg, _ := json.Marshal(WhiteboardEvent{
+		Type:    "restore",
+		UserID:  userID,
+		History: history,
+		CanUndo: board.undoCount > 0,
+		CanRedo: board.redoCount > 0,
+	})
+	for client := range board.clients {
+		client.enqueue(msg)
+	}
+}
+
+// joinBoard returns the hub for a board, creating it (and its flush loop) for the first client
+func (h *WhiteboardHandler) joinBoard(scope whiteboardScope) *whiteboardBoard {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	board, ok := h.boards[scope]
+	if !ok {
+		undoCount, redoCount := countWhiteboardStrokes(h.db, scope)
+		board = &whiteboardBoard{
+			scope:     scope,
+			clients:   make(map[*whiteboardClient]struct{}),
+			undoCount: int(undoCount),
+			redoCount: int(redoCount),
+			objects:   loadWhiteboardObjects(h.db, scope),
+			viewOnly:  h.whiteboardViewOnly(scope),
+			flushNow:  make(chan struct{}, 1),
+			done:      make(chan struct{}),
+			stopped:   make(chan struct{}),
+		}
+		h.boards[scope] = board
+		go h.runBoard(board)
+	}
+	board.refs++
+	return board
+}
+
+// leaveBoard drops a client reference; the last client stops the flush loop and writes what is left.
+// The final flush happens under h.mu so a client reopening the board always loads the complete history.
+func (h *WhiteboardHandler) leaveBoard(board *whiteboardBoard) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	board.refs--
+	if board.refs > 0 {
+		return
+	}
+	delete(h.boards, board.scope)
+	close(board.done)
+	<-board.stopped
+	h.flushBoard(board)
+}
+
+// runBoard persists queued events every flushInterval, or sooner once a batch fills up
+func (h *WhiteboardHandler) runBoard(board *whiteboardBoard) {
+	defer close(board.stopped)
+
+	ticker := time.NewTicker(h.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			h.flushBoard(board)
+		case <-board.flushNow:
+			h.flushBoard(board)
+		case <-board.done:
+			return
+		}
+	}
+}
+
+// attachClient writes queued events, sends the full history and registers the client.
+// Holding board.mu throughout means the client sees every later event exactly once.
+func (h *WhiteboardHandler) attachClient(board *whiteboardBoard, client *whiteboardClient) error {
+	board.flushMu.Lock()
+	defer board.flushMu.Unlock()
+	board.mu.Lock()
+	defer board.mu.Unlock()
+
+	ops := board.pending
+	board.pending = nil
+	h.persistOps(board, ops)
+
+	history, _, _, err := loadWhiteboardHistory(h.db, board.scope)
+	if err != nil {
+		return err
+	}
+	if history == nil {
+		history = []any{}
+	}
+
+	presence := board.presenceList()
+	board.clients[client] = struct{}{}
+	board.broadcastPresence("join", client)
+
+	viewOnly := board.viewOnly
+	client.sendEvent(WhiteboardEvent{
+		Type:      "init",
+		History:   history,
+		Presence:  presence,
+		CanUndo:   board.undoCount > 0,
+		CanRedo:   board.redoCount > 0,
+		ViewOnly:  &viewOnly,
+		CanDraw:   &client.access.CanDraw,
+		Presenter: &client.access.Presenter,
+	})
+	return nil
+}
+
+// flushBoard writes the board's queued events to the stroke tables
+func (h *WhiteboardHandler) flushBoard(board *whiteboardBoard) {
+	board.flushMu.Lock()
+	defer board.flushMu.Unlock()
+
+	board.mu.Lock()
+	ops := board.pending
+	board.pending = nil
+	board.mu.Unlock()
+
+	h.persistOps(board, ops)
+}
+
+// persistOps writes events in order, inserting consecutive adds in one batch.
+// Callers hold board.flushMu; board.mu may be held too, so snapshot accounting must not lock it.
+func (h *WhiteboardHandler) persistOps(board *whiteboardBoard, ops []whiteboardOp) {
+	if len(ops) == 0 {
+		return
+	}
+
+	var adds []model.WhiteboardStroke
+	added := false
+	writeAdds := func() {
+		if len(adds) == 0 {
+			return
+		}
+		if err := addWhiteboardStrokes(h.db, board.scope, adds); err != nil {
+			log.Printf("[Whiteboard] Failed to save %d strokes for %s: %v", len(adds), board.scope, err)
+		}
+		adds = nil
+		added = true
+	}
+
+	for _, op := range ops {
+		switch op.Type {
+		case "add":
+			adds = append(adds, op.row(board.scope))
+		case "update":
+			writeAdds()
+			if err := updateWhiteboardObject(h.db, board.scope, op.ObjectID, op.Data); err != nil {
+				log.Printf("[Whiteboard] Failed to update object %s for %s: %v", op.ObjectID, board.scope, err)
+			}
+		case "delete":
+			writeAdds()
+			if err := deleteWhiteboardObject(h.db, board.scope, op.ObjectID); err != nil {
+				log.Printf("[Whiteboard] Failed to delete object %s for %s: %v", op.ObjectID, board.scope, err)
+			}
+		case "undo":
+			writeAdds()
+			undoWhiteboardStroke(h.db, board.scope, op.At)
+		case "redo":
+			writeAdds()
+			redoWhiteboardStroke(h.db, board.scope)
+		case "clear":
+			adds = nil
+			if err := clearWhiteboard(h.db, board.scope); err != nil {
+				log.Printf("[Whiteboard] %v for %s", err, board.scope)
+			}
+		}
+	}
+	writeAdds()
+
+	if added {
+		if archived := h.snapshotStrokes(board.scope); archived > 0 {
+			go board.archived(archived)
+		}
+	}
+}
+
+// archived removes strokes merged into a snapshot from the undo count (they can no longer be undone)
+func (b *whiteboardBoard) archived(n int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.undoCount -= n
+	if b.undoCount < 0 {
+		b.undoCount = 0
+	}
+}
+
+// apply updates the undo/redo state, queues the event and broadcasts it to every client except the sender.
+// Undo/redo with nothing to act on is dropped; adding an existing object ID or changing a missing object fails,
+// as does any change the user may not make in the board's current mode. Returns the resulting undo/redo availability.
+func (b *whiteboardBoard) apply(op whiteboardOp, sender *whiteboardClient, access whiteboardAccess) (canUndo, canRedo bool, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err := access.check(b.viewOnly); err != nil {
+		return b.undoCount > 0, b.redoCount > 0, err
+	}
+
+	switch op.Type {
+	case "add":
+		if op.ObjectID != "" {
+			if _, exists := b.objects[op.ObjectID]; exists {
+				return b.undoCount > 0, b.redoCount > 0, errWhiteboardObjectExists
+			}
+			b.objects[op.ObjectID] = op.ObjectType
+		}
+		b.undoCount++
+		b.redoCount = 0
+	case "update":
+		if objectType, exists := b.objects[op.ObjectID]; !exists || objectType != op.ObjectType {
+			return b.undoCount > 0, b.redoCount > 0, errWhiteboardObjectNotFound
+		}
+	case "delete":
+		if _, exists := b.objects[op.ObjectID]; !exists {
+			return b.undoCount > 0, b.redoCount > 0, errWhiteboardObjectNotFound
+		}
+		delete(b.objects, op.ObjectID)
+		if b.undoCount > 0 {
+			b.undoCount--
+		}
+	case "undo":
+		if b.undoCount == 0 {
+			return false, b.redoCount > 0, nil
+		}
+		b.undoCount--
+		b.redoCount++
+	case "redo":
+		if b.redoCount == 0 {
+			return b.undoCount > 0, false, nil
+		}
+		b.redoCount--
+		b.undoCount++
+	case "clear":
+		b.undoCount = 0
+		b.redoCount = 0
+		b.objects = make(map[string]string)
+	default:
+		return b.undoCount > 0, b.redoCount > 0, nil
+	}
+
+	b.pending = append(b.pending, op)
+	if len(b.pending) >= whiteboardFlushBatch {
+		select {
+		case b.flushNow <- struct{}{}:
+		default:
+		}
+	}
+
+	canUndo, canRedo = b.undoCount > 0, b.redoCount > 0
+	event := WhiteboardEvent{
+		Type:     op.Type,
+		UserID:   op.UserID,
+		ObjectID: op.ObjectID,
+		CanUndo:  canUndo,
+		CanRedo:  canRedo,
+	}
+	switch {
+	case op.ObjectID != "" && op.Data != nil:
+		event.Object = op.Data
+	case op.Data != nil:
+		event.Stroke = op.Data
+	}
+	msg, _ := json.Marshal(event)
+	for client := range b.clients {
+		if client != sender {
+			client.enqueue(msg)
+		}
+	}
+	return canUndo, canRedo, nil
+}
+
+// detachClient unregisters the client and stops its writer
+func (b *whiteboardBoard) detachClient(client *whiteboardClient) {
+	b.mu.Lock()
+	delete(b.clients, client)
+	b.broadcastPresence("leave", client)
+	b.mu.Unlock()
+	close(client.send)
+}
+
+// sendEvent queues an event for this client only
+func (c *whiteboardClient) sendEvent(event WhiteboardEvent) {
+	msg, _ := json.Marshal(event)
+	c.enqueue(msg)
+}
+
+// enqueue never blocks the board; a client that cannot keep up is closed and reloads history on reconnect
+func (c *whiteboardClient) enqueue(msg []byte) {
+	select {
+	case c.send <- msg:
+	default:
+		go c.close(WSCloseSlowClient, "client is too slow")
+	}
+}
+
+// close sends a close frame once; the read loop then exits and the client leaves the board
+func (c *whiteboardClient) close(code int, reason string) {
+	c.closeOnce.Do(func() {
+		closeWS(c.conn, code, reason)
+	})
+}
+
+// writeLoop is the only writer of data frames for this connection
+func (c *whiteboardClient) writeLoop() {
+	for msg := range c.send {
+		if err := c.conn.WriteMessage(websocket.TextMessage, msg); err != nil {
+			// Drain so senders never block; the read loop notices the broken connection
+			for range c.send {
+			}
+			return
+		}
+	}
+}
@@ -1,19 +1,27 @@
 package handler
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
 	"gorm.io/gorm"
 
 	"realtime-backend/internal/auth"
+	"realtime-backend/internal/language"
 	"realtime-backend/internal/model"
+	"realtime-backend/internal/presence"
+	"realtime-backend/internal/storage"
 )
 
 // WorkspaceHandler 워크스페이스 핸들러
 type WorkspaceHandler struct {
-	db *gorm.DB
+	db          *gorm.DB
+	dataRegions []string            // 데이터 레지던시로 선택 가능한 AWS 리전
+	s3          *storage.S3Registry // 아이콘 업로드 (nil이면 비활성화)
+	presence    *presence.Manager   // 멤버 목록 접속 상태 (nil이면 모두 OFFLINE)
 }
 
 // NewWorkspaceHandler WorkspaceHandler 생성
@@ -29,13 +37,20 @@ type CreateWorkspaceRequest struct {
 
 // WorkspaceResponse 워크스페이스 응답
 type WorkspaceResponse struct {
-	ID          int64                     `json:"id"`
-	Name        string                    `json:"name"`
-	OwnerID     int64                     `json:"owner_id"`
-	CreatedAt   string                    `json:"created_at"`
-	Owner       *UserResponse             `json:"owner,omitempty"`
-	Members     []WorkspaceMemberResponse `json:"members,omitempty"`
-	CategoryIDs []int64                   `json:"category_ids,omitempty"`
+	ID               int64                     `json:"id"`
+	Name             string                    `json:"name"`
+	OwnerID          int64                     `json:"owner_id"`
+	DataRegion       *string                   `json:"data_region,omitempty"`
+	Description      *string                   `json:"description,omitempty"`
+	IconURL          *string                   `json:"icon_url,omitempty"`
+	DefaultLanguage  *string                   `json:"default_language,omitempty"`
+	Settings         json.RawMessage           `json:"settings,omitempty"`
+	Discoverable     bool                      `json:"discoverable"`
+	RequireTwoFactor bool                      `json:"require_two_factor"`
+	CreatedAt        string                    `json:"created_at"`
+	Owner            *UserResponse             `json:"owner,omitempty"`
+	Members          []WorkspaceMemberResponse `json:"members,omitempty"`
+	CategoryIDs      []int64                   `json:"category_ids,omitempty"`
 }
 
 // WorkspaceMemberResponse 워크스페이스 멤버 응답
@@ -102,38 +117,25 @@ func (h *WorkspaceHandler) CreateWorkspace(c *fiber.Ctx) error {
 			return err
 		}
 
-		// 소유자를 멤버로 추가 (ACTIVE 상태)
+		// 기본 역할(Owner/Admin/Member) 및 권한 생성
+		roleIDs, err := seedDefaultRolesWithTx(tx, workspace.ID)
+		if err != nil {
+			return err
+		}
+		ownerRoleID := roleIDs[model.RoleNameOwner]
+		memberRoleID := roleIDs[model.RoleNameMember]
+
+		// 소유자를 멤버로 추가 (ACTIVE 상태, Owner 역할)
 		ownerMember := model.WorkspaceMember{
 			WorkspaceID: workspace.ID,
 			UserID:      claims.UserID,
+			RoleID:      &ownerRoleID,
 			Status:      model.MemberStatusActive.String(),
 		}
 		if err := tx.Create(&ownerMember).Error; err != nil {
 			return err
 		}
 
-		// 기본 역할(Member) 생성
-		defaultRole := model.Role{
-			WorkspaceID: workspace.ID,
-			Name:        "Member",
-			Color:       valPtr("#A3A3A3"), // Neutral gray
-			IsDefault:   true,
-		}
-		if err := tx.Create(&defaultRole).Error; err != nil {
-			return err
-		}
-
-		// 기본 권한 부여 (메시지 전송, 음성 접속)
-		defaultPermissions := []string{"SEND_MESSAGES", "CONNECT_MEDIA"}
-		for _, code := range defaultPermissions {
-			if err := tx.Create(&model.RolePermission{
-				RoleID:         defaultRole.ID,
-				PermissionCode: code,
-			}).Error; err != nil {
-				return err
-			}
-		}
-
 		// 초대할 멤버들 추가 (PENDING 상태)
 		for _, memberID := range req.MemberIDs {
 			// 본인은 이미 추가됨
@@ -147,10 +149,11 @@ func (h *WorkspaceHandler) CreateWorkspace(c *fiber.Ctx) error {
 				continue // 존재하지 않는 사용자는 무시
 			}
 
-			// PENDING 상태로 멤버 생성
+			// PENDING 상태로 멤버 생성 (수락하면 Member 역할로 참여)
 			member := model.WorkspaceMember{
 				WorkspaceID: workspace.ID,
 				UserID:      memberID,
+				RoleID:      &memberRoleID,
 				Status:      model.MemberStatusPending.String(),
 			}
 			if err := tx.Create(&member).Error; err != nil {
@@ -325,17 +328,15 @@ func (h *WorkspaceHandler) GetWorkspace(c *fiber.Ctx) error {
 		if err := h.db.Where("workspace_id = ? AND is_default = ?", workspace.ID, true).First(&defaultRole).Error; err != nil {
 			if err == gorm.ErrRecordNotFound {
 				// 기본 역할 생성
-				defaultColor := "#A3A3A3"
 				defaultRole = model.Role{
 					WorkspaceID: int64(workspaceID),
-					Name:        "Member",
-					Color:       &defaultColor,
+					Name:        model.RoleNameMember,
+					Color:       valPtr("#A3A3A3"),
 					IsDefault:   true,
 				}
 				// 기본 권한 설정
-				defaultRole.Permissions = []model.RolePermission{
-					{PermissionCode: "SEND_MESSAGES"},
-					{PermissionCode: "CONNECT_MEDIA"},
+				for _, code := range model.MemberRolePermissions {
+					defaultRole.Permissions = append(defaultRole.Permissions, model.RolePermission{PermissionCode: code})
 				}
 				if err := h.db.Create(&defaultRole).Error; err != nil {
 					log.Printf("warning: failed to create default role for workspace %d: %v", workspace.ID, err)
@@ -350,6 +351,7 @@ func (h *WorkspaceHandler) GetWorkspace(c *fiber.Ctx) error {
 			h.db.Model(&model.WorkspaceMember{}).
 				Where("workspace_id = ? AND role_id IS NULL", workspace.ID).
 				Update("role_id", defaultRole.ID)
+			auth.InvalidateWorkspacePermissions(workspace.ID)
 		}
 	}()
 
@@ -426,6 +428,7 @@ func (h *WorkspaceHandler) AddMembers(c *fiber.Ctx) error {
 	// 트랜잭션으로 초대장 생성
 	var invitedMemberIDs []int64
 	err = h.db.Transaction(func(tx *gorm.DB) error {
+		defaultRoleID := defaultRoleIDWithTx(tx, workspace.ID)
 		for _, memberID := range req.MemberIDs {
 			// 이미 멤버인 경우 건너뛰기
 			if existingMembers[memberID] {
@@ -442,6 +445,7 @@ func (h *WorkspaceHandler) AddMembers(c *fiber.Ctx) error {
 			member := model.WorkspaceMember{
 				WorkspaceID: workspace.ID,
 				UserID:      memberID,
+				RoleID:      defaultRoleID,
 				Status:      model.MemberStatusPending.String(),
 			}
 			if err := tx.Create(&member).Error; err != nil {
@@ -461,7 +465,9 @@ func (h *WorkspaceHandler) AddMembers(c *fiber.Ctx) error {
 
 	// 트랜잭션 완료 후 알림 생성 (알림 실패가 멤버 추가에 영향 X)
 	for _, memberID := range invitedMemberIDs {
+		auth.InvalidateMemberPermissions(workspace.ID, memberID)
 		CreateWorkspaceInviteNotification(h.db, claims.UserID, memberID, workspace.ID, workspace.Name, inviter.Nickname)
+		recordAuditEvent(h.db, workspace.ID, &claims.UserID, model.AuditMemberInvited, model.AuditTargetMember, memberID, nil)
 	}
 
 	return c.JSON(fiber.Map{
@@ -503,12 +509,16 @@ func (h *WorkspaceHandler) LeaveWorkspace(c *fiber.Ctx) error {
 		})
 	}
 
-	// 멤버십 삭제
-	if err := h.db.Delete(&member).Error; err != nil {
+	// 멤버십 및 개인 설정 삭제
+	if err := h.db.Transaction(func(tx *gorm.DB) error {
+		return removeMemberWithTx(tx, &member)
+	}); err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "failed to leave workspace",
 		})
 	}
+	auth.InvalidateMemberPermissions(workspace.ID, claims.UserID)
+	recordAuditEvent(h.db, workspace.ID, &claims.UserID, model.AuditMemberLeft, model.AuditTargetMember, claims.UserID, nil)
 
 	return c.JSON(fiber.Map{
 		"message": "successfully left workspace",
@@ -518,10 +528,19 @@ func (h *WorkspaceHandler) LeaveWorkspace(c *fiber.Ctx) error {
 // 헬퍼 함수: 워크스페이스 응답 변환
 func (h *WorkspaceHandler) toWorkspaceResponse(ws *model.Workspace) WorkspaceResponse {
 	resp := WorkspaceResponse{
-		ID:        ws.ID,
-		Name:      ws.Name,
-		OwnerID:   ws.OwnerID,
-		CreatedAt: ws.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		ID:               ws.ID,
+		Name:             ws.Name,
+		OwnerID:          ws.OwnerID,
+		DataRegion:       ws.DataRegion,
+		Description:      ws.Description,
+		IconURL:          ws.IconURL,
+		DefaultLanguage:  ws.DefaultLanguage,
+		Discoverable:     ws.Discoverable,
+		RequireTwoFactor: ws.RequireTwoFactor,
+		CreatedAt:        ws.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	if ws.Settings != "" {
+		resp.Settings = json.RawMessage(ws.Settings)
 	}
 
 	// Owner
@@ -537,44 +556,54 @@ func (h *WorkspaceHandler) toWorkspaceResponse(ws *model.Workspace) WorkspaceRes
 	// Members
 	if len(ws.Members) > 0 {
 		resp.Members = make([]WorkspaceMemberResponse, len(ws.Members))
-		for i, m := range ws.Members {
-			resp.Members[i] = WorkspaceMemberResponse{
-				ID:       m.ID,
-				UserID:   m.UserID,
-				RoleID:   m.RoleID,
-				Status:   m.Status,
-				JoinedAt: m.JoinedAt.Format("2006-01-02T15:04:05Z07:00"),
-			}
-			if m.User.ID != 0 {
-				resp.Members[i].User = &UserResponse{
-					ID:         m.User.ID,
-					Email:      m.User.Email,
-					Nickname:   m.User.Nickname,
-					ProfileImg: m.User.ProfileImg,
-				}
-			}
-			if m.Role != nil && m.Role.ID != 0 {
-				perms := make([]string, len(m.Role.Permissions))
-				for j, p := range m.Role.Permissions {
-					perms[j] = p.PermissionCode
-				}
-				resp.Members[i].Role = &RoleResponse{
-					ID:          m.Role.ID,
-					Name:        m.Role.Name,
-					Color:       m.Role.Color,
-					IsDefault:   m.Role.IsDefault,
-					Permissions: perms,
-				}
-			}
+		for i := range ws.Members {
+			resp.Members[i] = toWorkspaceMemberResponse(&ws.Members[i])
 		}
 	}
 
 	return resp
 }
 
-// UpdateWorkspaceRequest 워크스페이스 수정 요청
+// 헬퍼 함수: 멤버 응답 변환 (User, Role.Permissions가 preload되어 있으면 포함)
+func toWorkspaceMemberResponse(m *model.WorkspaceMember) WorkspaceMemberResponse {
+	resp := WorkspaceMemberResponse{
+		ID:       m.ID,
+		UserID:   m.UserID,
+		RoleID:   m.RoleID,
+		Status:   m.Status,
+		JoinedAt: m.JoinedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	if m.User.ID != 0 {
+		resp.User = &UserResponse{
+			ID:         m.User.ID,
+			Email:      m.User.Email,
+			Nickname:   m.User.Nickname,
+			ProfileImg: m.User.ProfileImg,
+		}
+	}
+	if m.Role != nil && m.Role.ID != 0 {
+		perms := make([]string, len(m.Role.Permissions))
+		for j, p := range m.Role.Permissions {
+			perms[j] = p.PermissionCode
+		}
+		resp.Role = &RoleResponse{
+			ID:          m.Role.ID,
+			Name:        m.Role.Name,
+			Color:       m.Role.Color,
+			IsDefault:   m.Role.IsDefault,
+			Permissions: perms,
+		}
+	}
+	return resp
+}
+
+// UpdateWorkspaceRequest 워크스페이스 수정 요청 (보낸 항목만 변경, 빈 문자열은 설명/기본 언어 해제)
 type UpdateWorkspaceRequest struct {
-	Name string `json:"name"`
+	Name             string  `json:"name"`
+	Description      *string `json:"description"`
+	DefaultLanguage  *string `json:"default_language"`
+	Discoverable     *bool   `json:"discoverable"`
+	RequireTwoFactor *bool   `json:"require_two_factor"`
 }
 
 // UpdateWorkspace 워크스페이스 수정
@@ -590,7 +619,7 @@ func (h *WorkspaceHandler) UpdateWorkspace(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid request body"})
 	}
 
-	if req.Name == "" {
+	if req.Name == "" && req.Description == nil && req.DefaultLanguage == nil && req.Discoverable == nil && req.RequireTwoFactor == nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "workspace name is required"})
 	}
 
@@ -608,11 +637,71 @@ func (h *WorkspaceHandler) UpdateWorkspace(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "you do not have permission to update workspace"})
 	}
 
-	workspace.Name = sanitizeString(req.Name)
+	if req.Name != "" {
+		workspace.Name = sanitizeString(req.Name)
+	}
+	if req.Description != nil {
+		description := strings.TrimSpace(sanitizeString(*req.Description))
+		if len([]rune(description)) > workspaceDescriptionMaxLen {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("description must be at most %d characters", workspaceDescriptionMaxLen)})
+		}
+		workspace.Description = nil
+		if description != "" {
+			workspace.Description = &description
+		}
+	}
+	if req.DefaultLanguage != nil {
+		workspace.DefaultLanguage = nil
+		if *req.DefaultLanguage != "" {
+			lang := language.Normalize(*req.DefaultLanguage)
+			if lang == "" {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "unsupported language: " + *req.DefaultLanguage})
+			}
+			workspace.DefaultLanguage = &lang
+		}
+	}
+	if req.Discoverable != nil {
+		workspace.Discoverable = *req.Discoverable
+	}
+	if req.RequireTwoFactor != nil {
+		// 켜는 관리자 본인이 먼저 막히지 않도록 본인의 2단계 인증부터 확인
+		if *req.RequireTwoFactor && !workspace.RequireTwoFactor {
+			enabled, err := twoFactorEnabled(h.db, claims.UserID)
+			if err != nil {
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to check two-factor authentication"})
+			}
+			if !enabled {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error": "enable two-factor authentication on your account before requiring it",
+					"code":  "TWO_FACTOR_REQUIRED",
+				})
+			}
+		}
+		workspace.RequireTwoFactor = *req.RequireTwoFactor
+	}
+
 	if err := h.db.Save(&workspace).Error; err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to update workspace"})
 	}
 
+	changed := map[string]interface{}{}
+	if req.Name != "" {
+		changed["name"] = workspace.Name
+	}
+	if req.Description != nil {
+		changed["description"] = workspace.Description
+	}
+	if req.DefaultLanguage != nil {
+		changed["default_language"] = workspace.DefaultLanguage
+	}
+	if req.Discoverable != nil {
+		changed["discoverable"] = workspace.Discoverable
+	}
+	if req.RequireTwoFactor != nil {
+		changed["require_two_factor"] = workspace.RequireTwoFactor
+	}
+	recordAuditEvent(h.db, workspace.ID, &claims.UserID, model.AuditWorkspaceUpdated, model.AuditTargetWorkspace, workspace.ID, changed)
+
 	return c.JSON(h.toWorkspaceResponse(&workspace))
 }
 
@@ -657,6 +746,7 @@ func (h *WorkspaceHandler) DeleteWorkspace(c *fiber.Ctx) error {
 	if err := h.db.Delete(&workspace).Error; err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to delete workspace: " + err.Error()})
 	}
+	auth.InvalidateWorkspacePermissions(workspace.ID)
 
 	return c.SendStatus(fiber.StatusNoContent)
 }
@@ -712,6 +802,7 @@ func (h *WorkspaceHandler) UpdateMemberRole(c *fiber.Ctx) error {
 	}
 
 	// 역할 존재 확인 및 할당
+	previousRoleID := member.RoleID
 	if req.RoleID != 0 {
 		var role model.Role
 		if err := h.db.Where("id = ? AND workspace_id = ?", req.RoleID, workspaceID).First(&role).Error; err != nil {
@@ -730,6 +821,9 @@ func (h *WorkspaceHandler) UpdateMemberRole(c *fiber.Ctx) error {
 			"error": "failed to update member role",
 		})
 	}
+	auth.InvalidateMemberPermissions(workspace.ID, member.UserID)
+	recordAuditEvent(h.db, workspace.ID, &claims.UserID, model.AuditMemberRoleChanged, model.AuditTargetMember, member.UserID,
+		memberRoleAuditDiff(h.db, workspace.ID, previousRoleID, member.RoleID))
 
 	return c.JSON(fiber.Map{
 		"message": "member role updated",
@@ -757,6 +851,11 @@ func (h *WorkspaceHandler) KickMember(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "you do not have permission to kick members"})
 	}
 
+	// 자기 자신은 나가기로 처리
+	if int64(userID) == claims.UserID {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "use leave to remove yourself"})
+	}
+
 	// 추방 대상 멤버 조회
 	var member model.WorkspaceMember
 	if err := h.db.Where("workspace_id = ? AND user_id = ?", workspaceID, userID).First(&member).Error; err != nil {
@@ -765,15 +864,60 @@ func (h *WorkspaceHandler) KickMember(c *fiber.Ctx) error {
 
 	// 소유자는 추방 불가능
 	var workspace model.Workspace
-	h.db.First(&workspace, workspaceID)
+	if err := h.db.First(&workspace, workspaceID).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "workspace not found"})
+	}
 	if workspace.OwnerID == int64(userID) {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "cannot kick the owner"})
 	}
 
-	// 멤버 추방
-	if err := h.db.Delete(&member).Error; err != nil {
+	// 멤버 추방 (역할, 폴더 권한, 카테고리 매핑 함께 정리)
+	if err := h.db.Transaction(func(tx *gorm.DB) error {
+		if member.Status == model.MemberStatusPending.String() {
+			if err := expireInviteNotificationsWithTx(tx, member.WorkspaceID, member.UserID); err != nil {
+				return err
+			}
+		}
+		return removeMemberWithTx(tx, &member)
+	}); err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to kick member"})
 	}
+	auth.InvalidateMemberPermissions(workspace.ID, member.UserID)
+	recordAuditEvent(h.db, workspace.ID, &claims.UserID, model.AuditMemberRemoved, model.AuditTargetMember, member.UserID, map[string]interface{}{
+		"status": member.Status,
+	})
+
+	// 초대를 수락하기 전이면 알림 없이 초대만 취소된 것
+	if member.Status == model.MemberStatusActive.String() {
+		content := fmt.Sprintf("%s 워크스페이스에서 내보내졌습니다.", workspace.Name)
+		relatedType := "WORKSPACE"
+		workspaceID64 := int64(workspaceID)
+		if err := CreateNotification(h.db, member.UserID, &claims.UserID, model.NotificationTypeWorkspaceRemoved.String(), content, &relatedType, &workspaceID64); err != nil {
+			log.Printf("⚠️ 추방 알림 생성 실패: workspace=%d, user=%d, err=%v", workspaceID, userID, err)
+		}
+	}
 
 	return c.JSON(fiber.Map{"message": "member kicked successfully"})
 }
+
+// removeMemberWithTx 멤버십과 그 멤버에게만 의미 있는 워크스페이스 데이터 삭제
+// (멤버 지정 폴더 권한, 폴더 구독, 사용자 카테고리에 넣어 둔 워크스페이스 매핑, 사용자 대상 채널 오버라이드)
+func removeMemberWithTx(tx *gorm.DB, member *model.WorkspaceMember) error {
+	if err := tx.Where("workspace_id = ? AND user_id = ?", member.WorkspaceID, member.UserID).
+		Delete(&model.FolderPermission{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("workspace_id = ? AND user_id = ?", member.WorkspaceID, member.UserID).
+		Delete(&model.FolderWatch{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("workspace_id = ? AND user_id = ?", member.WorkspaceID, member.UserID).
+		Delete(&model.WorkspaceCategoryMapping{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("workspace_id = ? AND target_type = ? AND target_id = ?", member.WorkspaceID, model.OverrideTargetUser, member.UserID).
+		Delete(&model.ChannelPermissionOverride{}).Error; err != nil {
+		return err
+	}
+	return tx.Delete(member).Error
+}
@@ -0,0 +1,516 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+
+	"realtime-backend/internal/auth"
+	"realtime-backend/internal/model"
+)
+
+const inviteLinkMaxHours = 30 * 24 // 초대 링크 최대 유효 기간
+
+var errInviteLinkUnavailable = errors.New("invite link is expired, revoked or used up")
+
+// CreateInviteLinkRequest 초대 링크 생성 요청 (모두 선택)
+type CreateInviteLinkRequest struct {
+	RoleID         *int64 `json:"role_id,omitempty"`          // 없으면 기본 역할
+	MaxUses        *int   `json:"max_uses,omitempty"`         // 없으면 무제한
+	ExpiresInHours int    `json:"expires_in_hours,omitempty"` // 0이면 만료 없음
+}
+
+// InviteLinkResponse 초대 링크 응답
+type InviteLinkResponse struct {
+	ID          int64         `json:"id"`
+	WorkspaceID int64         `json:"workspace_id"`
+	Token       string        `json:"token"`
+	RoleID      *int64        `json:"role_id,omitempty"`
+	MaxUses     *int          `json:"max_uses,omitempty"`
+	UseCount    int           `json:"use_count"`
+	ExpiresAt   *string       `json:"expires_at,omitempty"`
+	CreatedBy   int64         `json:"created_by"`
+	Creator     *UserResponse `json:"creator,omitempty"`
+	CreatedAt   string        `json:"created_at"`
+}
+
+// InvitePreviewResponse 수락 전 보여 줄 초대 정보
+type InvitePreviewResponse struct {
+	WorkspaceID   int64  `json:"workspace_id"`
+	WorkspaceName string `json:"workspace_name"`
+	MemberCount   int64  `json:"member_count"`
+	IsMember      bool   `json:"is_member"`
+}
+
+// CreateInviteLink 초대 링크 생성 (MANAGE_MEMBERS 권한 필요)
+func (h *WorkspaceHandler) CreateInviteLink(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	workspaceID, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid workspace id",
+		})
+	}
+
+	if !h.requireManageMembers(c, int64(workspaceID), claims.UserID) {
+		return nil
+	}
+
+	var req CreateInviteLinkRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "invalid request body",
+			})
+		}
+	}
+
+	if req.MaxUses != nil && *req.MaxUses <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "max_uses must be positive",
+		})
+	}
+	if req.ExpiresInHours < 0 || req.ExpiresInHours > inviteLinkMaxHours {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": fmt.Sprintf("expires_in_hours must be between 0 and %d", inviteLinkMaxHours),
+		})
+	}
+	if req.RoleID != nil {
+		var count int64
+		h.db.Model(&model.Role{}).Where("id = ? AND workspace_id = ?", *req.RoleID, workspaceID).Count(&count)
+		if count == 0 {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "role not found in this workspace",
+			})
+		}
+	}
+
+	token, err := newShareToken()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to create invite link",
+		})
+	}
+
+	link := model.WorkspaceInviteLink{
+		WorkspaceID: int64(workspaceID),
+		Token:       token,
+		RoleID:      req.RoleID,
+		MaxUses:     req.MaxUses,
+		CreatedBy:   claims.UserID,
+	}
+	if req.ExpiresInHours > 0 {
+		expiresAt := time.Now().Add(time.Duration(req.ExpiresInHours) * time.Hour)
+		link.ExpiresAt = &expiresAt
+	}
+	if err := h.db.Create(&link).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to create invite link",
+		})
+	}
+
+	log.Printf("🔗 초대 링크 생성: workspace=%d, link=%d, user=%d", workspaceID, link.ID, claims.UserID)
+	recordAuditEvent(h.db, link.WorkspaceID, &claims.UserID, model.AuditInviteLinkCreated, model.AuditTargetInviteLink, link.ID, map[string]interface{}{
+		"role_id":    link.RoleID,
+		"max_uses":   link.MaxUses,
+		"expires_at": link.ExpiresAt,
+	})
+
+	return c.Status(fiber.StatusCreated).JSON(toInviteLinkResponse(&link))
+}
+
+// GetInviteLinks 아직 쓸 수 있는 초대 링크 목록 (MANAGE_MEMBERS 권한 필요)
+func (h *WorkspaceHandler) GetInviteLinks(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	workspaceID, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid workspace id",
+		})
+	}
+
+	if !h.requireManageMembers(c, int64(workspaceID), claims.UserID) {
+		return nil
+	}
+
+	var links []model.WorkspaceInviteLink
+	err = usableInviteLinks(h.db.Where("workspace_id = ?", workspaceID)).
+		Preload("Creator").
+		Order("created_at DESC").
+		Find(&links).Error
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get invite links",
+		})
+	}
+
+	responses := make([]InviteLinkResponse, len(links))
+	for i := range links {
+		responses[i] = toInviteLinkResponse(&links[i])
+	}
+
+	return c.JSON(fiber.Map{
+		"links": responses,
+		"total": len(responses),
+	})
+}
+
+// RevokeInviteLink 초대 링크 취소 (MANAGE_MEMBERS 권한 필요)
+func (h *WorkspaceHandler) RevokeInviteLink(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	workspaceID, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid workspace id",
+		})
+	}
+	linkID, err := c.ParamsInt("linkId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid link id",
+		})
+	}
+
+	if !h.requireManageMembers(c, int64(workspaceID), claims.UserID) {
+		return nil
+	}
+
+	result := h.db.Model(&model.WorkspaceInviteLink{}).
+		Where("id = ? AND workspace_id = ? AND revoked_at IS NULL", linkID, workspaceID).
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to revoke invite link",
+		})
+	}
+	if result.RowsAffected == 0 {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "invite link not found",
+		})
+	}
+	recordAuditEvent(h.db, int64(workspaceID), &claims.UserID, model.AuditInviteLinkRevoked, model.AuditTargetInviteLink, int64(linkID), nil)
+
+	return c.JSON(fiber.Map{
+		"message": "invite link revoked",
+	})
+}
+
+// GetInvite 초대 링크로 들어갈 워크스페이스 정보
+func (h *WorkspaceHandler) GetInvite(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+
+	var link model.WorkspaceInviteLink
+	err := usableInviteLinks(h.db.Where("token = ?", c.Params("token"))).
+		Preload("Workspace").
+		First(&link).Error
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "invite link not found or expired",
+		})
+	}
+
+	var memberCount, mine int64
+	h.db.Model(&model.WorkspaceMember{}).
+		Where("workspace_id = ? AND status = ?", link.WorkspaceID, model.MemberStatusActive.String()).
+		Count(&memberCount)
+	h.db.Model(&model.WorkspaceMember{}).
+		Where("workspace_id = ? AND user_id = ? AND status = ?", link.WorkspaceID, claims.UserID, model.MemberStatusActive.String()).
+		Count(&mine)
+
+	return c.JSON(InvitePreviewResponse{
+		WorkspaceID:   link.WorkspaceID,
+		WorkspaceName: link.Workspace.Name,
+		MemberCount:   memberCount,
+		IsMember:      mine > 0,
+	})
+}
+
+// AcceptInvite 초대 링크 수락 → ACTIVE 멤버로 가입 (대기 중인 초대가 있으면 그 초대를 활성화)
+// 이미 멤버면 사용 횟수를 쓰지 않고 그대로 성공
+func (h *WorkspaceHandler) AcceptInvite(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+
+	var link model.WorkspaceInviteLink
+	if err := h.db.Where("token = ?", c.Params("token")).First(&link).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "invite link not found",
+		})
+	}
+
+	joined := false
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		var member model.WorkspaceMember
+		err := tx.Where("workspace_id = ? AND user_id = ?", link.WorkspaceID, claims.UserID).First(&member).Error
+		if err == nil && member.Status == model.MemberStatusActive.String() {
+			return nil
+		}
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		// 만료/취소/횟수 검사와 사용 횟수 증가를 한 번에 (동시에 수락해도 max_uses를 넘지 않음)
+		result := usableInviteLinks(tx.Model(&model.WorkspaceInviteLink{}).Where("id = ?", link.ID)).
+			UpdateColumn("use_count", gorm.Expr("use_count + 1"))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errInviteLinkUnavailable
+		}
+
+		roleID := inviteRoleWithTx(tx, &link)
+		if member.ID != 0 {
+			err = tx.Model(&member).Updates(map[string]interface{}{
+				"status":  model.MemberStatusActive.String(),
+				"role_id": roleID,
+			}).Error
+		} else {
+			err = tx.Create(&model.WorkspaceMember{
+				WorkspaceID: link.WorkspaceID,
+				UserID:      claims.UserID,
+				RoleID:      roleID,
+				Status:      model.MemberStatusActive.String(),
+			}).Error
+		}
+		joined = err == nil
+		return err
+	})
+	if errors.Is(err, errInviteLinkUnavailable) {
+		return c.Status(fiber.StatusGone).JSON(fiber.Map{
+			"error": errInviteLinkUnavailable.Error(),
+		})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to accept invite",
+		})
+	}
+
+	if joined {
+		auth.InvalidateMemberPermissions(link.WorkspaceID, claims.UserID)
+		log.Printf("👋 초대 링크로 가입: workspace=%d, link=%d, user=%d", link.WorkspaceID, link.ID, claims.UserID)
+		recordAuditEvent(h.db, link.WorkspaceID, &claims.UserID, model.AuditMemberJoined, model.AuditTargetMember, claims.UserID, map[string]interface{}{
+			"link_id": link.ID,
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message":      "invite accepted",
+		"workspace_id": link.WorkspaceID,
+	})
+}
+
+// PendingInvitationResponse 아직 수락하지 않은 초대
+type PendingInvitationResponse struct {
+	MemberID  int64         `json:"member_id"`
+	UserID    int64         `json:"user_id"`
+	User      *UserResponse `json:"user,omitempty"`
+	InvitedBy *UserResponse `json:"invited_by,omitempty"`
+	InvitedAt string        `json:"invited_at"`
+	ExpiresAt *string       `json:"expires_at,omitempty"` // 초대 유효 기간 (없으면 만료 없음)
+}
+
+// GetPendingInvitations 수락 대기 중인 초대 목록 (MANAGE_MEMBERS 권한 필요)
+func (h *WorkspaceHandler) GetPendingInvitations(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	workspaceID, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid workspace id",
+		})
+	}
+
+	if !h.requireManageMembers(c, int64(workspaceID), claims.UserID) {
+		return nil
+	}
+
+	var members []model.WorkspaceMember
+	err = h.db.Where("workspace_id = ? AND status = ?", workspaceID, model.MemberStatusPending.String()).
+		Preload("User").
+		Order("joined_at DESC").
+		Find(&members).Error
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get invitations",
+		})
+	}
+
+	// 초대한 사람은 초대 알림의 발신자
+	userIDs := make([]int64, len(members))
+	for i, m := range members {
+		userIDs[i] = m.UserID
+	}
+	var notifications []model.Notification
+	if len(userIDs) > 0 {
+		h.db.Where("type = ? AND related_id = ? AND receiver_id IN ? AND expired_at IS NULL",
+			model.NotificationTypeWorkspaceInvite.String(), workspaceID, userIDs).
+			Preload("Sender").
+			Order("created_at ASC").
+			Find(&notifications)
+	}
+	inviters := make(map[int64]*model.User, len(notifications))
+	expiresAt := make(map[int64]*time.Time, len(notifications))
+	for i := range notifications {
+		if notifications[i].Sender != nil {
+			inviters[notifications[i].ReceiverID] = notifications[i].Sender
+		}
+		expiresAt[notifications[i].ReceiverID] = notifications[i].ExpiresAt
+	}
+
+	responses := make([]PendingInvitationResponse, len(members))
+	for i, m := range members {
+		responses[i] = PendingInvitationResponse{
+			MemberID:  m.ID,
+			UserID:    m.UserID,
+			InvitedAt: m.JoinedAt.Format("2006-01-02T15:04:05Z07:00"),
+		}
+		if m.User.ID != 0 {
+			responses[i].User = &UserResponse{
+				ID:         m.User.ID,
+				Email:      m.User.Email,
+				Nickname:   m.User.Nickname,
+				ProfileImg: m.User.ProfileImg,
+			}
+		}
+		if t := expiresAt[m.UserID]; t != nil {
+			formatted := t.Format("2006-01-02T15:04:05Z07:00")
+			responses[i].ExpiresAt = &formatted
+		}
+		if inviter, ok := inviters[m.UserID]; ok {
+			responses[i].InvitedBy = &UserResponse{
+				ID:         inviter.ID,
+				Email:      inviter.Email,
+				Nickname:   inviter.Nickname,
+				ProfileImg: inviter.ProfileImg,
+			}
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"invitations": responses,
+		"total":       len(responses),
+	})
+}
+
+// RevokeInvitation 대기 중인 초대 취소 (MANAGE_MEMBERS 권한 필요, 초대 알림은 만료 처리)
+func (h *WorkspaceHandler) RevokeInvitation(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	workspaceID, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid workspace id",
+		})
+	}
+	userID, err := c.ParamsInt("userId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid user id",
+		})
+	}
+
+	if !h.requireManageMembers(c, int64(workspaceID), claims.UserID) {
+		return nil
+	}
+
+	found := false
+	err = h.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("workspace_id = ? AND user_id = ? AND status = ?", workspaceID, userID, model.MemberStatusPending.String()).
+			Delete(&model.WorkspaceMember{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		found = true
+		return expireInviteNotificationsWithTx(tx, int64(workspaceID), int64(userID))
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to revoke invitation",
+		})
+	}
+	auth.InvalidateMemberPermissions(int64(workspaceID), int64(userID))
+	if !found {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "pending invitation not found",
+		})
+	}
+	recordAuditEvent(h.db, int64(workspaceID), &claims.UserID, model.AuditInvitationRevoked, model.AuditTargetMember, int64(userID), nil)
+
+	return c.JSON(fiber.Map{
+		"message": "invitation revoked",
+	})
+}
+
+// expireInviteNotificationsWithTx 초대받은 사용자의 워크스페이스 초대 알림을 만료 처리
+func expireInviteNotificationsWithTx(tx *gorm.DB, workspaceID, userID int64) error {
+	return tx.Model(&model.Notification{}).
+		Where("receiver_id = ? AND type = ? AND related_id = ? AND expired_at IS NULL",
+			userID, model.NotificationTypeWorkspaceInvite.String(), workspaceID).
+		Updates(map[string]interface{}{"expired_at": time.Now(), "expired_reason": model.InviteExpiredRevoked}).Error
+}
+
+// requireManageMembers MANAGE_MEMBERS 권한 확인 (없으면 응답을 쓰고 false)
+func (h *WorkspaceHandler) requireManageMembers(c *fiber.Ctx, workspaceID, userID int64) bool {
+	hasPermission, err := auth.CheckPermission(h.db, workspaceID, userID, "MANAGE_MEMBERS")
+	if err != nil {
+		c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to check permission",
+		})
+		return false
+	}
+	if !hasPermission {
+		c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "you do not have permission to manage members",
+		})
+		return false
+	}
+	return true
+}
+
+// usableInviteLinks 취소되지 않았고 만료 전이며 사용 횟수가 남은 링크만
+func usableInviteLinks(query *gorm.DB) *gorm.DB {
+	return query.Where("revoked_at IS NULL AND (expires_at IS NULL OR expires_at > ?) AND (max_uses IS NULL OR use_count < max_uses)", time.Now())
+}
+
+// inviteRoleWithTx 링크에 지정된 역할 (역할이 지워졌으면 기본 역할)
+func inviteRoleWithTx(tx *gorm.DB, link *model.WorkspaceInviteLink) *int64 {
+	var role model.Role
+	if link.RoleID != nil {
+		if err := tx.Where("id = ? AND workspace_id = ?", *link.RoleID, link.WorkspaceID).First(&role).Error; err == nil {
+			return &role.ID
+		}
+	}
+	return defaultRoleIDWithTx(tx, link.WorkspaceID)
+}
+
+func toInviteLinkResponse(link *model.WorkspaceInviteLink) InviteLinkResponse {
+	resp := InviteLinkResponse{
+		ID:          link.ID,
+		WorkspaceID: link.WorkspaceID,
+		Token:       link.Token,
+		RoleID:      link.RoleID,
+		MaxUses:     link.MaxUses,
+		UseCount:    link.UseCount,
+		CreatedBy:   link.CreatedBy,
+		CreatedAt:   link.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	if link.ExpiresAt != nil {
+		expiresAt := link.ExpiresAt.Format("2006-01-02T15:04:05Z07:00")
+		resp.ExpiresAt = &expiresAt
+	}
+	if link.Creator.ID != 0 {
+		resp.Creator = &UserResponse{
+			ID:         link.Creator.ID,
+			Email:      link.Creator.Email,
+			Nickname:   link.Creator.Nickname,
+			ProfileImg: link.Creator.ProfileImg,
+		}
+	}
+	return resp
+}
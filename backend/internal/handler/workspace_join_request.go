@@ -0,0 +1,419 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+
+	"realtime-backend/internal/auth"
+	"realtime-backend/internal/model"
+)
+
+const (
+	joinRequestMessageMaxLen = 500
+	discoverDefaultLimit     = 20
+	discoverMaxLimit         = 50
+)
+
+var errJoinRequestReviewed = errors.New("join request has already been reviewed")
+
+// DiscoverableWorkspaceResponse 가입 요청할 수 있는 공개 워크스페이스 (멤버 목록 등 내부 정보 제외)
+type DiscoverableWorkspaceResponse struct {
+	ID             int64   `json:"id"`
+	Name           string  `json:"name"`
+	Description    *string `json:"description,omitempty"`
+	IconURL        *string `json:"icon_url,omitempty"`
+	MemberCount    int64   `json:"member_count"`
+	RequestPending bool    `json:"request_pending"` // 내가 보낸 가입 요청이 대기 중
+}
+
+// JoinRequestResponse 가입 요청 응답
+type JoinRequestResponse struct {
+	ID          int64         `json:"id"`
+	WorkspaceID int64         `json:"workspace_id"`
+	UserID      int64         `json:"user_id"`
+	User        *UserResponse `json:"user,omitempty"`
+	Message     *string       `json:"message,omitempty"`
+	Status      string        `json:"status"`
+	ReviewedBy  *int64        `json:"reviewed_by,omitempty"`
+	ReviewedAt  *string       `json:"reviewed_at,omitempty"`
+	CreatedAt   string        `json:"created_at"`
+}
+
+// DiscoverWorkspaces 가입 요청할 수 있는 공개 워크스페이스 검색 (이미 속한 워크스페이스 제외)
+func (h *WorkspaceHandler) DiscoverWorkspaces(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+
+	limit := c.QueryInt("limit", discoverDefaultLimit)
+	if limit <= 0 || limit > discoverMaxLimit {
+		limit = discoverDefaultLimit
+	}
+	offset := c.QueryInt("offset", 0)
+	if offset < 0 {
+		offset = 0
+	}
+
+	query := h.db.Model(&model.Workspace{}).
+		Where("discoverable = ?", true).
+		Where("NOT EXISTS (SELECT 1 FROM workspace_members wm WHERE wm.workspace_id = workspaces.id AND wm.user_id = ? AND wm.status = ?)",
+			claims.UserID, model.MemberStatusActive.String())
+	if search := strings.TrimSpace(c.Query("search")); search != "" {
+		pattern := "%" + search + "%"
+		query = query.Where("workspaces.name ILIKE ? OR workspaces.description ILIKE ?", pattern, pattern)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to count workspaces",
+		})
+	}
+
+	var workspaces []model.Workspace
+	if err := query.Order("workspaces.created_at DESC").Limit(limit).Offset(offset).Find(&workspaces).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get workspaces",
+		})
+	}
+
+	workspaceIDs := make([]int64, len(workspaces))
+	for i, ws := range workspaces {
+		workspaceIDs[i] = ws.ID
+	}
+
+	memberCounts := make(map[int64]int64, len(workspaces))
+	pending := make(map[int64]bool)
+	if len(workspaceIDs) > 0 {
+		var counts []struct {
+			WorkspaceID int64
+			Count       int64
+		}
+		h.db.Model(&model.WorkspaceMember{}).
+			Select("workspace_id, COUNT(*) AS count").
+			Where("workspace_id IN ? AND status = ?", workspaceIDs, model.MemberStatusActive.String()).
+			Group("workspace_id").
+			Scan(&counts)
+		for _, row := range counts {
+			memberCounts[row.WorkspaceID] = row.Count
+		}
+
+		var pendingIDs []int64
+		h.db.Model(&model.WorkspaceJoinRequest{}).
+			Where("workspace_id IN ? AND user_id = ? AND status = ?", workspaceIDs, claims.UserID, model.JoinRequestStatusPending.String()).
+			Pluck("workspace_id", &pendingIDs)
+		for _, id := range pendingIDs {
+			pending[id] = true
+		}
+	}
+
+	responses := make([]DiscoverableWorkspaceResponse, len(workspaces))
+	for i, ws := range workspaces {
+		responses[i] = DiscoverableWorkspaceResponse{
+			ID:             ws.ID,
+			Name:           ws.Name,
+			Description:    ws.Description,
+			IconURL:        ws.IconURL,
+			MemberCount:    memberCounts[ws.ID],
+			RequestPending: pending[ws.ID],
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"workspaces": responses,
+		"total":      total,
+		"has_more":   int64(offset+len(workspaces)) < total,
+	})
+}
+
+// CreateJoinRequest 공개 워크스페이스 가입 요청 (멤버 관리 권한이 있는 사람들에게 알림)
+func (h *WorkspaceHandler) CreateJoinRequest(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	workspaceID, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid workspace id",
+		})
+	}
+
+	var req struct {
+		Message string `json:"message"`
+	}
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "invalid request body",
+			})
+		}
+	}
+	message := strings.TrimSpace(sanitizeString(req.Message))
+	if len([]rune(message)) > joinRequestMessageMaxLen {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": fmt.Sprintf("message must be at most %d characters", joinRequestMessageMaxLen),
+		})
+	}
+
+	// 공개되지 않은 워크스페이스는 존재 여부도 알리지 않음
+	var workspace model.Workspace
+	if err := h.db.Where("id = ? AND discoverable = ?", workspaceID, true).First(&workspace).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "workspace not found",
+		})
+	}
+
+	var member model.WorkspaceMember
+	if err := h.db.Where("workspace_id = ? AND user_id = ?", workspaceID, claims.UserID).First(&member).Error; err == nil {
+		if member.Status == model.MemberStatusActive.String() {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": "you are already a member of this workspace",
+			})
+		}
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "you already have a pending invitation to this workspace",
+		})
+	}
+
+	var pendingCount int64
+	h.db.Model(&model.WorkspaceJoinRequest{}).
+		Where("workspace_id = ? AND user_id = ? AND status = ?", workspaceID, claims.UserID, model.JoinRequestStatusPending.String()).
+		Count(&pendingCount)
+	if pendingCount > 0 {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "you already requested to join this workspace",
+		})
+	}
+
+	joinRequest := model.WorkspaceJoinRequest{
+		WorkspaceID: workspace.ID,
+		UserID:      claims.UserID,
+		Status:      model.JoinRequestStatusPending.String(),
+	}
+	if message != "" {
+		joinRequest.Message = &message
+	}
+	if err := h.db.Create(&joinRequest).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to create join request",
+		})
+	}
+
+	var requester model.User
+	h.db.First(&requester, claims.UserID)
+	content := fmt.Sprintf("%s님이 %s 워크스페이스에 가입을 요청했습니다.", requester.Nickname, workspace.Name)
+	relatedType := "WORKSPACE"
+	for _, managerID := range workspaceMemberManagerIDs(h.db, workspace.ID, workspace.OwnerID) {
+		if err := CreateNotification(h.db, managerID, &claims.UserID, model.NotificationTypeJoinRequest.String(), content, &relatedType, &workspace.ID); err != nil {
+			log.Printf("⚠️ 가입 요청 알림 생성 실패: workspace=%d, receiver=%d, err=%v", workspace.ID, managerID, err)
+		}
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(toJoinRequestResponse(&joinRequest))
+}
+
+// GetJoinRequests 대기 중인 가입 요청 목록 (MANAGE_MEMBERS 권한 필요, 오래된 순)
+func (h *WorkspaceHandler) GetJoinRequests(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	workspaceID, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid workspace id",
+		})
+	}
+
+	if !h.requireManageMembers(c, int64(workspaceID), claims.UserID) {
+		return nil
+	}
+
+	var requests []model.WorkspaceJoinRequest
+	err = h.db.Where("workspace_id = ? AND status = ?", workspaceID, model.JoinRequestStatusPending.String()).
+		Preload("User").
+		Order("created_at ASC").
+		Find(&requests).Error
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get join requests",
+		})
+	}
+
+	responses := make([]JoinRequestResponse, len(requests))
+	for i := range requests {
+		responses[i] = toJoinRequestResponse(&requests[i])
+	}
+
+	return c.JSON(fiber.Map{
+		"requests": responses,
+		"total":    len(responses),
+	})
+}
+
+// ApproveJoinRequest 가입 요청 승인 → 기본 역할의 ACTIVE 멤버로 추가
+func (h *WorkspaceHandler) ApproveJoinRequest(c *fiber.Ctx) error {
+	return h.reviewJoinRequest(c, true)
+}
+
+// DenyJoinRequest 가입 요청 거절
+func (h *WorkspaceHandler) DenyJoinRequest(c *fiber.Ctx) error {
+	return h.reviewJoinRequest(c, false)
+}
+
+func (h *WorkspaceHandler) reviewJoinRequest(c *fiber.Ctx, approve bool) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	workspaceID, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid workspace id",
+		})
+	}
+	requestID, err := c.ParamsInt("requestId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request id",
+		})
+	}
+
+	if !h.requireManageMembers(c, int64(workspaceID), claims.UserID) {
+		return nil
+	}
+
+	var workspace model.Workspace
+	if err := h.db.First(&workspace, workspaceID).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "workspace not found",
+		})
+	}
+
+	var joinRequest model.WorkspaceJoinRequest
+	if err := h.db.Where("id = ? AND workspace_id = ?", requestID, workspaceID).First(&joinRequest).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "join request not found",
+		})
+	}
+
+	status := model.JoinRequestStatusDenied
+	if approve {
+		status = model.JoinRequestStatusApproved
+	}
+	now := time.Now()
+
+	err = h.db.Transaction(func(tx *gorm.DB) error {
+		// 다른 관리자가 먼저 처리했으면 중단
+		result := tx.Model(&model.WorkspaceJoinRequest{}).
+			Where("id = ? AND status = ?", joinRequest.ID, model.JoinRequestStatusPending.String()).
+			Updates(map[string]interface{}{
+				"status":      status.String(),
+				"reviewed_by": claims.UserID,
+				"reviewed_at": now,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errJoinRequestReviewed
+		}
+		if !approve {
+			return nil
+		}
+
+		// 요청 후 초대를 받았거나 초대 링크로 이미 들어왔을 수 있음
+		var member model.WorkspaceMember
+		err := tx.Where("workspace_id = ? AND user_id = ?", workspace.ID, joinRequest.UserID).First(&member).Error
+		if err == nil {
+			if member.Status == model.MemberStatusActive.String() {
+				return nil
+			}
+			if err := expireInviteNotificationsWithTx(tx, workspace.ID, joinRequest.UserID); err != nil {
+				return err
+			}
+			return tx.Model(&member).Update("status", model.MemberStatusActive.String()).Error
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		return tx.Create(&model.WorkspaceMember{
+			WorkspaceID: workspace.ID,
+			UserID:      joinRequest.UserID,
+			RoleID:      defaultRoleIDWithTx(tx, workspace.ID),
+			Status:      model.MemberStatusActive.String(),
+		}).Error
+	})
+	if errors.Is(err, errJoinRequestReviewed) {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": errJoinRequestReviewed.Error(),
+		})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to review join request",
+		})
+	}
+
+	joinRequest.Status = status.String()
+	joinRequest.ReviewedBy = &claims.UserID
+	joinRequest.ReviewedAt = &now
+
+	content := fmt.Sprintf("%s 워크스페이스 가입 요청이 거절되었습니다.", workspace.Name)
+	if approve {
+		auth.InvalidateMemberPermissions(workspace.ID, joinRequest.UserID)
+		content = fmt.Sprintf("%s 워크스페이스 가입 요청이 승인되었습니다.", workspace.Name)
+		recordAuditEvent(h.db, workspace.ID, &claims.UserID, model.AuditMemberJoined, model.AuditTargetMember, joinRequest.UserID, map[string]interface{}{
+			"join_request_id": joinRequest.ID,
+		})
+	} else {
+		recordAuditEvent(h.db, workspace.ID, &claims.UserID, model.AuditJoinRequestDenied, model.AuditTargetMember, joinRequest.UserID, map[string]interface{}{
+			"join_request_id": joinRequest.ID,
+		})
+	}
+	relatedType := "WORKSPACE"
+	if err := CreateNotification(h.db, joinRequest.UserID, &claims.UserID, model.NotificationTypeJoinResult.String(), content, &relatedType, &workspace.ID); err != nil {
+		log.Printf("⚠️ 가입 요청 결과 알림 생성 실패: workspace=%d, user=%d, err=%v", workspace.ID, joinRequest.UserID, err)
+	}
+
+	return c.JSON(toJoinRequestResponse(&joinRequest))
+}
+
+// workspaceMemberManagerIDs 소유자와 ADMIN/MANAGE_MEMBERS 권한을 가진 ACTIVE 멤버
+func workspaceMemberManagerIDs(db *gorm.DB, workspaceID, ownerID int64) []int64 {
+	var userIDs []int64
+	db.Model(&model.WorkspaceMember{}).
+		Distinct("workspace_members.user_id").
+		Joins("JOIN role_permissions ON role_permissions.role_id = workspace_members.role_id").
+		Where("workspace_members.workspace_id = ? AND workspace_members.status = ?", workspaceID, model.MemberStatusActive.String()).
+		Where("role_permissions.permission_code IN ?", []string{"ADMIN", "MANAGE_MEMBERS"}).
+		Pluck("workspace_members.user_id", &userIDs)
+
+	if !slices.Contains(userIDs, ownerID) {
+		userIDs = append(userIDs, ownerID)
+	}
+	return userIDs
+}
+
+func toJoinRequestResponse(r *model.WorkspaceJoinRequest) JoinRequestResponse {
+	resp := JoinRequestResponse{
+		ID:          r.ID,
+		WorkspaceID: r.WorkspaceID,
+		UserID:      r.UserID,
+		Message:     r.Message,
+		Status:      r.Status,
+		ReviewedBy:  r.ReviewedBy,
+		CreatedAt:   r.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	if r.ReviewedAt != nil {
+		reviewedAt := r.ReviewedAt.Format("2006-01-02T15:04:05Z07:00")
+		resp.ReviewedAt = &reviewedAt
+	}
+	if r.User.ID != 0 {
+		resp.User = &UserResponse{
+			ID:         r.User.ID,
+			Email:      r.User.Email,
+			Nickname:   r.User.Nickname,
+			ProfileImg: r.User.ProfileImg,
+		}
+	}
+	return resp
+}
@@ -0,0 +1,211 @@
+package handler
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+
+	"realtime-backend/internal/auth"
+	"realtime-backend/internal/language"
+	"realtime-backend/internal/model"
+)
+
+// UpdateWorkspaceLanguagesRequest 워크스페이스 번역 언어 설정 변경 요청
+type UpdateWorkspaceLanguagesRequest struct {
+	DefaultTargetLangs []string `json:"default_target_langs"`
+	AllowedTargetLangs []string `json:"allowed_target_langs"` // 빈 배열 = 전체 허용
+}
+
+// WorkspaceLanguagesResponse 워크스페이스 번역 언어 설정 응답
+type WorkspaceLanguagesResponse struct {
+	WorkspaceID        int64               `json:"workspace_id"`
+	DefaultTargetLangs []string            `json:"default_target_langs"`
+	AllowedTargetLangs []string            `json:"allowed_target_langs"`
+	AvailableTargets   []language.Language `json:"available_targets"` // 리스너가 선택할 수 있는 번역 언어
+	UpdatedBy          *int64              `json:"updated_by,omitempty"`
+	UpdatedAt          *string             `json:"updated_at,omitempty"`
+}
+
+// GetWorkspaceLanguages 워크스페이스 기본/허용 번역 언어 조회 (멤버)
+func (h *LanguageHandler) GetWorkspaceLanguages(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	workspaceID, err := c.ParamsInt("workspaceId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid workspace id",
+		})
+	}
+
+	var count int64
+	h.db.Model(&model.WorkspaceMember{}).
+		Where("workspace_id = ? AND user_id = ? AND status = ?", workspaceID, claims.UserID, model.MemberStatusActive.String()).
+		Count(&count)
+	if count == 0 {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "you are not a member of this workspace",
+		})
+	}
+
+	setting, err := loadWorkspaceLanguageSetting(h.db, int64(workspaceID))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get language settings",
+		})
+	}
+
+	return c.JSON(toWorkspaceLanguagesResponse(setting))
+}
+
+// UpdateWorkspaceLanguages 워크스페이스 기본/허용 번역 언어 변경 (ADMIN)
+// 새로 시작하는 Room과 새로 입장하는 리스너부터 적용됨
+func (h *LanguageHandler) UpdateWorkspaceLanguages(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	workspaceID, err := c.ParamsInt("workspaceId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid workspace id",
+		})
+	}
+
+	hasPermission, err := auth.CheckPermission(h.db, int64(workspaceID), claims.UserID, "ADMIN")
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to check permission",
+		})
+	}
+	if !hasPermission {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "you do not have permission to manage language settings",
+		})
+	}
+
+	var req UpdateWorkspaceLanguagesRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+
+	allowed, msg := normalizeAllowedTargetLangs(req.AllowedTargetLangs)
+	if msg != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": msg,
+		})
+	}
+	defaults, msg := normalizeAllowedTargetLangs(req.DefaultTargetLangs)
+	if msg != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": msg,
+		})
+	}
+
+	policy := workspaceLanguagePolicy{allowed: allowed}
+	for _, lang := range defaults {
+		if !policy.allows(lang) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "default target language is not in the allowed list: " + lang,
+			})
+		}
+	}
+
+	setting := model.WorkspaceLanguageSetting{
+		WorkspaceID:        int64(workspaceID),
+		DefaultTargetLangs: strings.Join(defaults, ","),
+		AllowedTargetLangs: strings.Join(allowed, ","),
+		UpdatedBy:          &claims.UserID,
+	}
+	if err := h.db.Save(&setting).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to update language settings",
+		})
+	}
+	recordAuditEvent(h.db, setting.WorkspaceID, &claims.UserID, model.AuditSettingsUpdated, model.AuditTargetWorkspace, setting.WorkspaceID, map[string]interface{}{
+		"setting":              "languages",
+		"default_target_langs": defaults,
+		"allowed_target_langs": allowed,
+	})
+
+	return c.JSON(toWorkspaceLanguagesResponse(&setting))
+}
+
+// workspaceLanguagePolicy 워크스페이스 번역 언어 정책 (Room 파이프라인 기본값 및 리스너 언어 검증)
+type workspaceLanguagePolicy struct {
+	defaults []string
+	allowed  []string // 비어 있으면 전체 허용
+}
+
+// allows 리스너가 선택할 수 있는 번역 언어인지 확인
+func (p workspaceLanguagePolicy) allows(lang string) bool {
+	if len(p.allowed) == 0 {
+		return true
+	}
+	for _, a := range p.allowed {
+		if a == lang {
+			return true
+		}
+	}
+	return false
+}
+
+// defaultTargets 리스너가 없을 때 사용할 번역 대상 (설정이 없으면 기본 언어 또는 첫 허용 언어)
+func (p workspaceLanguagePolicy) defaultTargets() []string {
+	if len(p.defaults) > 0 {
+		return append([]string(nil), p.defaults...)
+	}
+	if p.allows(language.DefaultTarget) {
+		return []string{language.DefaultTarget}
+	}
+	return []string{p.allowed[0]}
+}
+
+// availableTargets 선택 가능한 번역 언어 목록
+func (p workspaceLanguagePolicy) availableTargets() []language.Language {
+	langs := make([]language.Language, 0)
+	for _, l := range language.All() {
+		if l.Translate && p.allows(l.Code) {
+			langs = append(langs, l)
+		}
+	}
+	return langs
+}
+
+func languagePolicyFromModel(s *model.WorkspaceLanguageSetting) workspaceLanguagePolicy {
+	return workspaceLanguagePolicy{
+		defaults: splitLangList(s.DefaultTargetLangs),
+		allowed:  splitLangList(s.AllowedTargetLangs),
+	}
+}
+
+// loadWorkspaceLanguageSetting 저장된 설정 조회 (없으면 빈 설정 = 전체 허용)
+func loadWorkspaceLanguageSetting(db *gorm.DB, workspaceID int64) (*model.WorkspaceLanguageSetting, error) {
+	setting := &model.WorkspaceLanguageSetting{WorkspaceID: workspaceID}
+	if err := db.Where("workspace_id = ?", workspaceID).Limit(1).Find(setting).Error; err != nil {
+		return nil, err
+	}
+	return setting, nil
+}
+
+// splitLangList 쉼표로 구분된 언어 목록을 슬라이스로 변환
+func splitLangList(list string) []string {
+	if list == "" {
+		return []string{}
+	}
+	return strings.Split(list, ",")
+}
+
+func toWorkspaceLanguagesResponse(s *model.WorkspaceLanguageSetting) WorkspaceLanguagesResponse {
+	policy := languagePolicyFromModel(s)
+	resp := WorkspaceLanguagesResponse{
+		WorkspaceID:        s.WorkspaceID,
+		DefaultTargetLangs: policy.defaultTargets(),
+		AllowedTargetLangs: policy.allowed,
+		AvailableTargets:   policy.availableTargets(),
+		UpdatedBy:          s.UpdatedBy,
+	}
+	if !s.UpdatedAt.IsZero() {
+		t := s.UpdatedAt.Format("2006-01-02T15:04:05Z07:00")
+		resp.UpdatedAt = &t
+	}
+	return resp
+}
@@ -0,0 +1,209 @@
+package handler
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"realtime-backend/internal/auth"
+	"realtime-backend/internal/model"
+	"realtime-backend/internal/presence"
+)
+
+const (
+	memberListDefaultLimit = 50
+	memberListMaxLimit     = 100
+)
+
+// MemberListItem 멤버 목록 항목 (Redis presence 상태 포함, 접속 기록이 없으면 OFFLINE)
+type MemberListItem struct {
+	WorkspaceMemberResponse
+	Presence           presence.PresenceStatus `json:"presence"`
+	StatusMessage      *string                 `json:"status_message,omitempty"`
+	StatusMessageEmoji *string                 `json:"status_message_emoji,omitempty"`
+}
+
+// SetPresenceManager 멤버 목록에 접속 상태를 합칠 presence 관리자 설정
+func (h *WorkspaceHandler) SetPresenceManager(pm *presence.Manager) {
+	h.presence = pm
+}
+
+// GetMembers 워크스페이스 멤버 목록 (가입 순, cursor 페이지네이션)
+// search는 닉네임/이메일, status는 ACTIVE(기본)/PENDING/ALL, role_id는 역할로 필터
+func (h *WorkspaceHandler) GetMembers(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	workspaceID, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid workspace id",
+		})
+	}
+
+	var count int64
+	h.db.Model(&model.WorkspaceMember{}).
+		Where("workspace_id = ? AND user_id = ? AND status = ?", workspaceID, claims.UserID, model.MemberStatusActive.String()).
+		Count(&count)
+	if count == 0 {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "you are not a member of this workspace",
+		})
+	}
+
+	limit := c.QueryInt("limit", memberListDefaultLimit)
+	if limit <= 0 || limit > memberListMaxLimit {
+		limit = memberListDefaultLimit
+	}
+
+	query := h.db.Model(&model.WorkspaceMember{}).
+		Where("workspace_members.workspace_id = ?", workspaceID)
+
+	switch status := strings.ToUpper(c.Query("status", model.MemberStatusActive.String())); status {
+	case "ALL":
+	case model.MemberStatusActive.String(), model.MemberStatusPending.String():
+		query = query.Where("workspace_members.status = ?", status)
+	default:
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "status must be ACTIVE, PENDING or ALL",
+		})
+	}
+
+	if roleID := c.QueryInt("role_id", 0); roleID > 0 {
+		query = query.Where("workspace_members.role_id = ?", roleID)
+	}
+
+	if search := strings.TrimSpace(c.Query("search")); search != "" {
+		pattern := "%" + search + "%"
+		query = query.Joins("JOIN users ON users.id = workspace_members.user_id").
+			Where("users.nickname ILIKE ? OR users.email ILIKE ?", pattern, pattern)
+	}
+
+	if cursor := c.Query("cursor"); cursor != "" {
+		afterID, err := strconv.ParseInt(cursor, 10, 64)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "invalid cursor",
+			})
+		}
+		query = query.Where("workspace_members.id > ?", afterID)
+	}
+
+	// 다음 페이지가 있는지 알기 위해 하나 더 조회
+	var members []model.WorkspaceMember
+	err = query.Preload("User").
+		Preload("Role").
+		Preload("Role.Permissions").
+		Order("workspace_members.id ASC").
+		Limit(limit + 1).
+		Find(&members).Error
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get members",
+		})
+	}
+
+	var nextCursor *string
+	if len(members) > limit {
+		members = members[:limit]
+		next := strconv.FormatInt(members[limit-1].ID, 10)
+		nextCursor = &next
+	}
+
+	userIDs := make([]int64, len(members))
+	for i, m := range members {
+		userIDs[i] = m.UserID
+	}
+	presenceMap := map[int64]*presence.PresenceData{}
+	if h.presence != nil {
+		if loaded, err := h.presence.GetMultiPresence(userIDs); err == nil {
+			presenceMap = loaded
+		}
+	}
+
+	items := make([]MemberListItem, len(members))
+	for i := range members {
+		items[i] = MemberListItem{
+			WorkspaceMemberResponse: toWorkspaceMemberResponse(&members[i]),
+			Presence:                presence.StatusOffline,
+		}
+		if p, ok := presenceMap[members[i].UserID]; ok {
+			items[i].Presence = p.Status
+			items[i].StatusMessage = p.StatusMessage
+			items[i].StatusMessageEmoji = p.StatusMessageEmoji
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"members":     items,
+		"next_cursor": nextCursor,
+		"has_more":    nextCursor != nil,
+	})
+}
+
+// PresenceRosterItem 멤버 한 명의 접속 상태
+type PresenceRosterItem struct {
+	UserID             int64                   `json:"user_id"`
+	Status             presence.PresenceStatus `json:"status"`
+	StatusMessage      *string                 `json:"status_message,omitempty"`
+	StatusMessageEmoji *string                 `json:"status_message_emoji,omitempty"`
+	LastHeartbeat      *int64                  `json:"last_heartbeat,omitempty"` // unix 초, 오프라인이면 없음
+}
+
+// GetPresenceRoster 워크스페이스 ACTIVE 멤버 전체의 접속 상태 (Redis MGET 한 번)
+// 멤버 사이드바가 처음 열릴 때 사용자별로 구독하지 않고 한 번에 채우는 용도
+func (h *WorkspaceHandler) GetPresenceRoster(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	workspaceID, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid workspace id",
+		})
+	}
+
+	var userIDs []int64
+	if err := h.db.Model(&model.WorkspaceMember{}).
+		Where("workspace_id = ? AND status = ?", workspaceID, model.MemberStatusActive.String()).
+		Order("user_id").
+		Pluck("user_id", &userIDs).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get presence",
+		})
+	}
+	if !slices.Contains(userIDs, claims.UserID) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "you are not a member of this workspace",
+		})
+	}
+
+	presenceMap := map[int64]*presence.PresenceData{}
+	if h.presence != nil {
+		loaded, err := h.presence.GetMultiPresence(userIDs)
+		if err != nil {
+			return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+				"error": "presence is temporarily unavailable",
+			})
+		}
+		presenceMap = loaded
+	}
+
+	items := make([]PresenceRosterItem, len(userIDs))
+	online := 0
+	for i, userID := range userIDs {
+		items[i] = PresenceRosterItem{UserID: userID, Status: presence.StatusOffline}
+		if p, ok := presenceMap[userID]; ok {
+			items[i].Status = p.Status
+			items[i].StatusMessage = p.StatusMessage
+			items[i].StatusMessageEmoji = p.StatusMessageEmoji
+			lastHeartbeat := p.LastHeartbeat
+			items[i].LastHeartbeat = &lastHeartbeat
+			online++
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"members":      items,
+		"online_count": online,
+		"total":        len(items),
+	})
+}
@@ -0,0 +1,271 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log"
+
+	"github.com/gofiber/fiber/v2"
+
+	"realtime-backend/internal/auth"
+	"realtime-backend/internal/model"
+	"realtime-backend/internal/storage"
+)
+
+const (
+	workspaceDescriptionMaxLen = 500
+	workspaceIconMaxBytes      = 2 << 20 // 원본 이미지 최대 크기
+	workspaceIconSize          = 256     // 정사각형 기준 긴 변 px
+	workspaceSettingsMaxBytes  = 16 << 10
+)
+
+// SetStorage 워크스페이스 아이콘을 올릴 S3 레지스트리 설정
+func (h *WorkspaceHandler) SetStorage(s3 *storage.S3Registry) {
+	h.s3 = s3
+}
+
+// UploadWorkspaceIcon 워크스페이스 아이콘 업로드 (ADMIN)
+// 원본 대신 256px JPEG로 다시 인코딩해 저장 (SVG 등 스크립트가 들어갈 수 있는 형식은 받지 않음)
+func (h *WorkspaceHandler) UploadWorkspaceIcon(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	workspace, ok := h.requireWorkspaceAdmin(c, claims.UserID)
+	if !ok {
+		return nil
+	}
+
+	if h.s3 == nil {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+			"error": "file storage is not configured",
+		})
+	}
+
+	fileHeader, err := c.FormFile("icon")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "icon file is required",
+		})
+	}
+	if fileHeader.Size > workspaceIconMaxBytes {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "icon image too large (max 2MB)",
+		})
+	}
+	if !storage.IsThumbnailSource(fileHeader.Header.Get("Content-Type")) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "icon must be a JPEG, PNG or GIF image",
+		})
+	}
+
+	src, err := fileHeader.Open()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to read icon",
+		})
+	}
+	defer src.Close()
+
+	data, err := io.ReadAll(io.LimitReader(src, workspaceIconMaxBytes+1))
+	if err != nil || len(data) > workspaceIconMaxBytes {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "failed to read icon",
+		})
+	}
+
+	rendered, err := storage.RenderThumbnails(data, workspaceIconSize)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid image",
+		})
+	}
+	icon := rendered[workspaceIconSize]
+
+	s3Service, err := h.s3.ForRegion(h.effectiveRegion(workspace.DataRegion))
+	if err != nil {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+			"error": "storage is not available in workspace data region",
+		})
+	}
+
+	key := storage.WorkspaceObjectKey(workspace.ID, "icon.jpg")
+	if err := s3Service.PutObject(key, "image/jpeg", bytes.NewReader(icon), int64(len(icon))); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to upload icon",
+		})
+	}
+
+	oldKey := workspace.IconKey
+	iconURL := s3Service.GetPublicURL(key)
+	workspace.IconURL = &iconURL
+	workspace.IconKey = &key
+	if err := h.db.Model(workspace).Updates(map[string]interface{}{
+		"icon_url": iconURL,
+		"icon_key": key,
+	}).Error; err != nil {
+		s3Service.DeleteFile(key)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to update workspace",
+		})
+	}
+	h.deleteWorkspaceIcon(s3Service, oldKey)
+	recordAuditEvent(h.db, workspace.ID, &claims.UserID, model.AuditWorkspaceUpdated, model.AuditTargetWorkspace, workspace.ID, map[string]interface{}{
+		"field": "icon",
+	})
+
+	return c.JSON(h.toWorkspaceResponse(workspace))
+}
+
+// DeleteWorkspaceIcon 워크스페이스 아이콘 제거 (ADMIN)
+func (h *WorkspaceHandler) DeleteWorkspaceIcon(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	workspace, ok := h.requireWorkspaceAdmin(c, claims.UserID)
+	if !ok {
+		return nil
+	}
+
+	oldKey := workspace.IconKey
+	if err := h.db.Model(workspace).Updates(map[string]interface{}{
+		"icon_url": nil,
+		"icon_key": nil,
+	}).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to update workspace",
+		})
+	}
+	workspace.IconURL = nil
+	workspace.IconKey = nil
+	recordAuditEvent(h.db, workspace.ID, &claims.UserID, model.AuditWorkspaceUpdated, model.AuditTargetWorkspace, workspace.ID, map[string]interface{}{
+		"field":   "icon",
+		"removed": true,
+	})
+
+	if h.s3 != nil {
+		if s3Service, err := h.s3.ForRegion(h.effectiveRegion(workspace.DataRegion)); err == nil {
+			h.deleteWorkspaceIcon(s3Service, oldKey)
+		}
+	}
+
+	return c.JSON(h.toWorkspaceResponse(workspace))
+}
+
+// GetWorkspaceSettings 워크스페이스 설정 조회 (멤버)
+func (h *WorkspaceHandler) GetWorkspaceSettings(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	workspaceID, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid workspace id",
+		})
+	}
+
+	var count int64
+	h.db.Model(&model.WorkspaceMember{}).
+		Where("workspace_id = ? AND user_id = ? AND status = ?", workspaceID, claims.UserID, model.MemberStatusActive.String()).
+		Count(&count)
+	if count == 0 {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "you are not a member of this workspace",
+		})
+	}
+
+	var workspace model.Workspace
+	if err := h.db.Select("id", "settings").First(&workspace, workspaceID).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "workspace not found",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"workspace_id": workspace.ID,
+		"settings":     json.RawMessage(workspace.Settings),
+	})
+}
+
+// UpdateWorkspaceSettings 워크스페이스 설정 전체 교체 (ADMIN, JSON 객체만 허용)
+func (h *WorkspaceHandler) UpdateWorkspaceSettings(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	workspace, ok := h.requireWorkspaceAdmin(c, claims.UserID)
+	if !ok {
+		return nil
+	}
+
+	var req struct {
+		Settings json.RawMessage `json:"settings"`
+	}
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+
+	var settings map[string]interface{}
+	if err := json.Unmarshal(req.Settings, &settings); err != nil || settings == nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "settings must be a JSON object",
+		})
+	}
+	compact, _ := json.Marshal(settings)
+	if len(compact) > workspaceSettingsMaxBytes {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "settings are too large (max 16KB)",
+		})
+	}
+
+	if err := h.db.Model(workspace).Update("settings", string(compact)).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to update settings",
+		})
+	}
+	recordAuditEvent(h.db, workspace.ID, &claims.UserID, model.AuditSettingsUpdated, model.AuditTargetWorkspace, workspace.ID, map[string]interface{}{
+		"setting": "settings",
+	})
+
+	return c.JSON(fiber.Map{
+		"workspace_id": workspace.ID,
+		"settings":     json.RawMessage(compact),
+	})
+}
+
+// requireWorkspaceAdmin 워크스페이스 조회 + ADMIN 권한 확인 (실패하면 응답을 쓰고 false)
+func (h *WorkspaceHandler) requireWorkspaceAdmin(c *fiber.Ctx, userID int64) (*model.Workspace, bool) {
+	workspaceID, err := c.ParamsInt("id")
+	if err != nil {
+		c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid workspace id",
+		})
+		return nil, false
+	}
+
+	var workspace model.Workspace
+	if err := h.db.First(&workspace, workspaceID).Error; err != nil {
+		c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "workspace not found",
+		})
+		return nil, false
+	}
+
+	hasPermission, err := auth.CheckPermission(h.db, workspace.ID, userID, "ADMIN")
+	if err != nil {
+		c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to check permission",
+		})
+		return nil, false
+	}
+	if !hasPermission {
+		c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "you do not have permission to update workspace",
+		})
+		return nil, false
+	}
+	return &workspace, true
+}
+
+// deleteWorkspaceIcon 교체/제거된 아이콘 객체 삭제 (실패해도 요청은 성공으로 처리)
+func (h *WorkspaceHandler) deleteWorkspaceIcon(s3Service *storage.S3Service, key *string) {
+	if key == nil || *key == "" {
+		return
+	}
+	if err := s3Service.DeleteFile(*key); err != nil {
+		log.Printf("⚠️ 이전 워크스페이스 아이콘 삭제 실패: key=%s, err=%v", *key, err)
+	}
+}
@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"realtime-backend/internal/auth"
+	"realtime-backend/internal/model"
+)
+
+// UpdateDataRegionRequest 데이터 리전 변경 요청 (빈 값이면 기본 리전으로 해제)
+type UpdateDataRegionRequest struct {
+	Region string `json:"region"`
+}
+
+// SetDataRegions 선택 가능한 데이터 리전 설정 (첫 번째가 기본 리전)
+func (h *WorkspaceHandler) SetDataRegions(regions []string) {
+	h.dataRegions = regions
+}
+
+// GetDataRegion 워크스페이스 데이터 리전 조회
+func (h *WorkspaceHandler) GetDataRegion(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	workspaceID, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid workspace id"})
+	}
+
+	var count int64
+	h.db.Model(&model.WorkspaceMember{}).
+		Where("workspace_id = ? AND user_id = ? AND status = ?", workspaceID, claims.UserID, model.MemberStatusActive.String()).
+		Count(&count)
+	if count == 0 {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "you are not a member of this workspace"})
+	}
+
+	var workspace model.Workspace
+	if err := h.db.First(&workspace, workspaceID).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "workspace not found"})
+	}
+
+	return c.JSON(fiber.Map{
+		"workspace_id":      workspace.ID,
+		"data_region":       workspace.DataRegion,
+		"effective_region":  h.effectiveRegion(workspace.DataRegion),
+		"available_regions": h.dataRegions,
+	})
+}
+
+// UpdateDataRegion 워크스페이스 데이터 리전 고정 (ADMIN)
+// 이미 S3에 저장된 파일이 있으면 다른 리전으로 옮길 수 없으므로 거부
+func (h *WorkspaceHandler) UpdateDataRegion(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	workspaceID, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid workspace id"})
+	}
+
+	var req UpdateDataRegionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid request body"})
+	}
+
+	if req.Region != "" && !h.isDataRegionAvailable(req.Region) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":             "unsupported data region",
+			"available_regions": h.dataRegions,
+		})
+	}
+
+	var workspace model.Workspace
+	if err := h.db.First(&workspace, workspaceID).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "workspace not found"})
+	}
+
+	// 권한 확인 (ADMIN)
+	hasPermission, err := auth.CheckPermission(h.db, int64(workspaceID), claims.UserID, "ADMIN")
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to check permission"})
+	}
+	if !hasPermission {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "you do not have permission to update workspace"})
+	}
+
+	if h.effectiveRegion(workspace.DataRegion) != h.effectiveRegion(&req.Region) {
+		var storedFiles int64
+		h.db.Model(&model.WorkspaceFile{}).
+			Where("workspace_id = ? AND s3_key IS NOT NULL AND s3_key <> ''", workspaceID).
+			Count(&storedFiles)
+		if storedFiles > 0 {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": "workspace already has files stored in another region",
+			})
+		}
+	}
+
+	var region *string
+	if req.Region != "" {
+		region = &req.Region
+	}
+	if err := h.db.Model(&workspace).Update("data_region", region).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to update data region"})
+	}
+	workspace.DataRegion = region
+	recordAuditEvent(h.db, workspace.ID, &claims.UserID, model.AuditSettingsUpdated, model.AuditTargetWorkspace, workspace.ID, map[string]interface{}{
+		"setting":     "data_region",
+		"data_region": region,
+	})
+
+	return c.JSON(h.toWorkspaceResponse(&workspace))
+}
+
+func (h *WorkspaceHandler) isDataRegionAvailable(region string) bool {
+	for _, r := range h.dataRegions {
+		if r == region {
+			return true
+		}
+	}
+	return false
+}
+
+// effectiveRegion 실제 적용되는 리전 (미지정이면 기본 리전)
+func (h *WorkspaceHandler) effectiveRegion(region *string) string {
+	if region != nil && *region != "" {
+		return *region
+	}
+	if len(h.dataRegions) > 0 {
+		return h.dataRegions[0]
+	}
+	return ""
+}
@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gofiber/contrib/websocket"
+	"github.com/gofiber/fiber/v2"
+
+	"realtime-backend/internal/auth"
+)
+
+// CreateWSTicket 일회용 WebSocket 티켓 발급 (모든 /ws 엔드포인트에서 ?ticket= 으로 한 번만 사용)
+func (h *JoinTokenHandler) CreateWSTicket(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*auth.Claims)
+	if claims.PersonalToken != nil && !claims.PersonalToken.HasScope(auth.ScopeWrite) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "personal access token requires the write scope for WebSocket access",
+			"code":  "INSUFFICIENT_SCOPE",
+		})
+	}
+
+	ticket, expiresAt, err := h.jwtManager.GenerateWSTicket(claims)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to generate ticket"})
+	}
+	return c.JSON(fiber.Map{
+		"ticket":     ticket,
+		"expires_at": expiresAt.Format(time.RFC3339),
+	})
+}
+
+// RequireWSAuth WebSocket 업그레이드 인증 미들웨어 (티켓, Authorization 헤더, 쿠키 순)
+// 확인한 사용자는 HTTP 라우트와 같이 c.Locals("claims")에 저장
+func (h *JoinTokenHandler) RequireWSAuth(c *fiber.Ctx) error {
+	return h.wsAuth(c, true)
+}
+
+// OptionalWSAuth 인증 정보가 없으면 그대로 진행하는 WebSocket 인증 미들웨어 (/ws/audio, /ws/room)
+// 인증 정보를 보냈는데 유효하지 않으면 거부
+func (h *JoinTokenHandler) OptionalWSAuth(c *fiber.Ctx) error {
+	return h.wsAuth(c, false)
+}
+
+func (h *JoinTokenHandler) wsAuth(c *fiber.Ctx, required bool) error {
+	if !websocket.IsWebSocketUpgrade(c) {
+		return fiber.ErrUpgradeRequired
+	}
+
+	claims, err := h.jwtManager.AuthenticateWebSocket(c, h.store)
+	switch {
+	case errors.Is(err, auth.ErrMissingToken):
+		if !required {
+			return c.Next()
+		}
+		// WebSocket은 JSON 응답 대신 종료 코드로 거부
+		return RejectWebSocket(c, WSCloseUnauthorized, "missing access token")
+	case errors.Is(err, auth.ErrJoinTokenReused):
+		return RejectWebSocket(c, WSCloseUnauthorized, "ticket already used")
+	case errors.Is(err, auth.ErrExpiredToken):
+		return RejectWebSocket(c, WSCloseUnauthorized, "token expired")
+	case errors.Is(err, auth.ErrRevokedToken):
+		return RejectWebSocket(c, WSCloseUnauthorized, "session has been revoked")
+	case errors.Is(err, auth.ErrInsufficientScope):
+		return RejectWebSocket(c, WSCloseForbidden, "personal access token requires the write scope")
+	case err != nil:
+		return RejectWebSocket(c, WSCloseUnauthorized, "invalid or expired access token")
+	}
+
+	if err := h.jwtManager.GuardWebSocketImpersonation(c, claims); err != nil {
+		return RejectWebSocket(c, WSCloseForbidden, "impersonation session is read-only")
+	}
+
+	c.Locals("claims", claims)
+	return c.Next()
+}
@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"github.com/gofiber/contrib/websocket"
+	"github.com/gofiber/fiber/v2"
+)
+
+// WebSocket 종료 코드 (모든 /ws 엔드포인트 공통)
+//
+// 클라이언트 재연결 기준:
+//   - 1000, 4000, 4001, 4003, 4004: 재연결하지 않음 (4001은 토큰 갱신 후 재시도 가능)
+//   - 4008, 4010, 4011: 잠시 후 재시도 (백오프)
+//   - 1011, 1012: 즉시 또는 짧은 백오프 후 재연결
+const (
+	WSCloseNormal         = websocket.CloseNormalClosure     // 1000 정상 종료
+	WSCloseInternalError  = websocket.CloseInternalServerErr // 1011 서버 내부 오류
+	WSCloseServiceRestart = websocket.CloseServiceRestart    // 1012 서버 재시작/배포
+	WSCloseInvalidRequest = 4000                             // 파라미터 누락/형식 오류
+	WSCloseUnauthorized   = 4001                             // 토큰 없음/만료
+	WSCloseForbidden      = 4003                             // 멤버 아님/권한 없음
+	WSCloseNotFound       = 4004                             // 채팅방 등 대상 없음
+	WSCloseRateLimited    = 4008                             // 메시지 전송 속도 초과
+	WSCloseRoomFull       = 4010                             // Room 최대 인원 초과
+	WSCloseSlowClient     = 4011                             // 수신이 밀려 전송 대기열이 계속 가득 참
+)
+
+const wsCloseWriteTimeout = time.Second
+
+// wsConnections 현재 열린 WebSocket 연결 (서버 종료 시 1012로 닫기 위함)
+var wsConnections = struct {
+	sync.Mutex
+	conns map[*websocket.Conn]struct{}
+}{conns: make(map[*websocket.Conn]struct{})}
+
+// wsRejection 업그레이드 전 미들웨어에서 거부한 사유 (업그레이드 후 종료 프레임으로 전달)
+type wsRejection struct {
+	code   int
+	reason string
+}
+
+// RejectWebSocket 업그레이드 미들웨어에서 연결 거부
+// HTTP 상태 코드는 브라우저에서 읽을 수 없으므로 업그레이드 후 종료 코드/사유를 보내고 닫음
+// 앞선 미들웨어가 이미 거부했다면 처음 사유를 유지
+func RejectWebSocket(c *fiber.Ctx, code int, reason string) error {
+	if _, rejected := c.Locals("wsRejection").(wsRejection); !rejected {
+		c.Locals("wsRejection", wsRejection{code: code, reason: reason})
+	}
+	return c.Next()
+}
+
+// WithCloseCodes WebSocket 핸들러 래퍼
+// 미들웨어 거부 사유를 종료 프레임으로 전달하고, 서버 종료 시 닫을 수 있도록 연결을 등록
+func WithCloseCodes(handler func(*websocket.Conn)) func(*websocket.Conn) {
+	return func(c *websocket.Conn) {
+		if rejection, ok := c.Locals("wsRejection").(wsRejection); ok {
+			closeWS(c, rejection.code, rejection.reason)
+			return
+		}
+
+		wsConnections.Lock()
+		wsConnections.conns[c] = struct{}{}
+		wsConnections.Unlock()
+		defer func() {
+			wsConnections.Lock()
+			delete(wsConnections.conns, c)
+			wsConnections.Unlock()
+		}()
+
+		handler(c)
+	}
+}
+
+// CloseAllWebSockets 열린 모든 WebSocket 연결을 종료 코드와 함께 닫음 (Graceful Shutdown용)
+func CloseAllWebSockets(code int, reason string) {
+	wsConnections.Lock()
+	conns := make([]*websocket.Conn, 0, len(wsConnections.conns))
+	for c := range wsConnections.conns {
+		conns = append(conns, c)
+	}
+	wsConnections.Unlock()
+
+	for _, c := range conns {
+		closeWS(c, code, reason)
+	}
+	if len(conns) > 0 {
+		log.Printf("🔌 Closed %d WebSocket connections (code=%d, reason=%s)", len(conns), code, reason)
+	}
+}
+
+// closeWS 종료 프레임 전송 후 연결 닫기 (WriteControl은 다른 쓰기와 동시에 호출 가능)
+func closeWS(c *websocket.Conn, code int, reason string) {
+	msg := websocket.FormatCloseMessage(code, reason)
+	if err := c.WriteControl(websocket.CloseMessage, msg, time.Now().Add(wsCloseWriteTimeout)); err != nil {
+		log.Printf("⚠️ WebSocket close frame failed (code=%d): %v", code, err)
+	}
+	c.Close()
+}
+
+// wsRateLimiter 연결 단위 메시지 속도 제한 (토큰 버킷, 초당 limit개, 최대 limit*2개까지 몰아서 허용)
+type wsRateLimiter struct {
+	limit  float64
+	tokens float64
+	last   time.Time
+}
+
+// newWSRateLimiter limit <= 0 이면 제한 없음 (nil 반환)
+func newWSRateLimiter(limit int) *wsRateLimiter {
+	if limit <= 0 {
+		return nil
+	}
+	return &wsRateLimiter{limit: float64(limit), tokens: float64(limit) * 2, last: time.Now()}
+}
+
+// allow 메시지 한 개 허용 여부 (한 연결의 읽기 루프에서만 호출)
+func (l *wsRateLimiter) allow() bool {
+	if l == nil {
+		return true
+	}
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * l.limit
+	if max := l.limit * 2; l.tokens > max {
+		l.tokens = max
+	}
+	l.last = now
+	if l.tokens < 1 {
+		return false
+	}
+	l.tokens--
+	return true
+}
@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"fmt"
+
+	"github.com/gofiber/contrib/websocket"
+
+	"realtime-backend/internal/errorreport"
+)
+
+// wsReportContext WebSocket 연결 정보(사용자/워크스페이스/방)로 오류 리포트 컨텍스트 구성
+// WS 핸들러 고루틴은 Fiber recover 미들웨어 밖에서 돌기 때문에 각 핸들러가 직접 보고해야 함
+func wsReportContext(c *websocket.Conn, component string) errorreport.Context {
+	ctx := errorreport.Context{Component: component, Tags: map[string]string{}}
+	if c == nil {
+		return ctx
+	}
+
+	if userID, ok := c.Locals("userId").(int64); ok {
+		ctx.UserID = userID
+	}
+	if workspaceID, ok := c.Locals("workspaceId").(int64); ok {
+		ctx.WorkspaceID = workspaceID
+	}
+	if roomID := c.Locals("roomId"); roomID != nil {
+		ctx.Tags["room_id"] = fmt.Sprint(roomID)
+	}
+	return ctx
+}
+
+// reportContext Room 고루틴(브로드캐스터, 오디오 처리, AI 응답 수신)용 오류 리포트 컨텍스트
+func (r *Room) reportContext(component string) errorreport.Context {
+	return errorreport.Context{
+		Component: component,
+		Tags:      map[string]string{"room_id": r.ID},
+	}
+}
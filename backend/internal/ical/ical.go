@@ -0,0 +1,393 @@
+// Package ical 일정 동기화용 iCalendar(RFC 5545) 읽기/쓰기 (VEVENT만 다룸)
+package ical
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+const (
+	utcLayout   = "20060102T150405Z"
+	localLayout = "20060102T150405"
+	dateLayout  = "20060102"
+
+	// maxLineOctets 접기 전 한 줄 최대 길이 (CRLF 제외)
+	maxLineOctets = 75
+)
+
+// Event VEVENT 하나
+// 반복 일정의 특정 회차를 수정한 경우 같은 UID에 RecurrenceID가 있는 Event로 표현
+type Event struct {
+	UID          string
+	Summary      string
+	Description  string
+	Start        time.Time
+	End          time.Time
+	AllDay       bool
+	TZID         string      // IANA 타임존 (있으면 현지 시각으로 출력해 반복 일정이 서머타임을 따라감)
+	RRule        string      // "FREQ=..." (RRULE: 접두사 없음)
+	ExDates      []time.Time // 취소된 회차
+	RecurrenceID *time.Time  // 수정된 회차의 원래 시작 시각
+	Cancelled    bool        // STATUS:CANCELLED
+	Created      time.Time
+
+	duration time.Duration // DTEND 대신 DURATION으로 온 경우 (DTSTART보다 먼저 올 수 있음)
+}
+
+// Encode VCALENDAR 출력 (name은 구독 시 캘린더 앱에 표시되는 이름)
+func Encode(w io.Writer, name string, events []Event) error {
+	bw := bufio.NewWriter(w)
+	line := func(s string) {
+		writeFolded(bw, s)
+	}
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//EUM//Calendar//KO")
+	line("CALSCALE:GREGORIAN")
+	line("METHOD:PUBLISH")
+	if name != "" {
+		line("X-WR-CALNAME:" + escapeText(name))
+	}
+
+	now := time.Now().UTC().Format(utcLayout)
+	for _, e := range events {
+		line("BEGIN:VEVENT")
+		line("UID:" + escapeText(e.UID))
+		line("DTSTAMP:" + now)
+		if e.AllDay {
+			line("DTSTART;VALUE=DATE:" + e.Start.Format(dateLayout))
+			line("DTEND;VALUE=DATE:" + allDayEnd(e.Start, e.End).Format(dateLayout))
+		} else {
+			line("DTSTART" + e.formatTime(e.Start))
+			line("DTEND" + e.formatTime(e.End))
+		}
+		if e.RecurrenceID != nil {
+			if e.AllDay {
+				line("RECURRENCE-ID;VALUE=DATE:" + e.RecurrenceID.Format(dateLayout))
+			} else {
+				line("RECURRENCE-ID" + e.formatTime(*e.RecurrenceID))
+			}
+		}
+		line("SUMMARY:" + escapeText(e.Summary))
+		if e.Description != "" {
+			line("DESCRIPTION:" + escapeText(e.Description))
+		}
+		if e.RRule != "" {
+			line("RRULE:" + e.RRule)
+		}
+		for _, ex := range e.ExDates {
+			if e.AllDay {
+				line("EXDATE;VALUE=DATE:" + ex.Format(dateLayout))
+			} else {
+				line("EXDATE" + e.formatTime(ex))
+			}
+		}
+		if e.Cancelled {
+			line("STATUS:CANCELLED")
+		}
+		if !e.Created.IsZero() {
+			line("CREATED:" + e.Created.UTC().Format(utcLayout))
+		}
+		line("END:VEVENT")
+	}
+	line("END:VCALENDAR")
+	return bw.Flush()
+}
+
+// formatTime 시각 속성 값 (";TZID=...:현지 시각" 또는 ":UTC 시각")
+func (e *Event) formatTime(t time.Time) string {
+	if e.TZID != "" && e.TZID != "UTC" {
+		if loc, err := time.LoadLocation(e.TZID); err == nil {
+			return ";TZID=" + e.TZID + ":" + t.In(loc).Format(localLayout)
+		}
+	}
+	return ":" + t.UTC().Format(utcLayout)
+}
+
+// allDayEnd 종일 일정의 DTEND (End는 마지막 날짜, DTEND는 그다음 날짜로 제외 표기)
+func allDayEnd(start, end time.Time) time.Time {
+	if end.Before(start) {
+		end = start
+	}
+	return time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, end.Location()).AddDate(0, 0, 1)
+}
+
+// writeFolded 75옥텟마다 줄 접기 (UTF-8 문자 중간에서 자르지 않음)
+func writeFolded(w *bufio.Writer, s string) {
+	limit := maxLineOctets
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !isRuneStart(s[cut]) {
+			cut--
+		}
+		w.WriteString(s[:cut])
+		w.WriteString("\r\n ")
+		s = s[cut:]
+		limit = maxLineOctets - 1 // 이어지는 줄은 앞 공백 포함
+	}
+	w.WriteString(s)
+	w.WriteString("\r\n")
+}
+
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
+
+var textEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+func escapeText(s string) string {
+	return textEscaper.Replace(s)
+}
+
+var textUnescaper = strings.NewReplacer(`\\`, `\`, `\;`, ";", `\,`, ",", `\n`, "\n", `\N`, "\n")
+
+func unescapeText(s string) string {
+	return textUnescaper.Replace(s)
+}
+
+// property 한 줄 (NAME;PARAM=VALUE:value)
+type property struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// Parse VCALENDAR에서 VEVENT 목록 읽기 (VTIMEZONE은 읽지 않고 TZID를 IANA 이름으로 해석)
+func Parse(r io.Reader) ([]Event, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []Event
+	var current *Event
+	depth := 0 // VEVENT 안의 하위 컴포넌트 (VALARM 등)
+	for _, raw := range lines {
+		if raw == "" {
+			continue
+		}
+		p, err := parseProperty(raw)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case p.name == "BEGIN" && strings.EqualFold(p.value, "VEVENT"):
+			current = &Event{}
+			depth = 0
+			continue
+		case p.name == "END" && strings.EqualFold(p.value, "VEVENT"):
+			if current == nil {
+				return nil, errors.New("unexpected END:VEVENT")
+			}
+			if current.End.IsZero() && current.duration != 0 {
+				current.End = current.Start.Add(current.duration)
+			}
+			if current.End.IsZero() {
+				current.End = current.Start
+				if current.AllDay {
+					current.End = current.Start.AddDate(0, 0, 1)
+				}
+			}
+			events = append(events, *current)
+			current = nil
+			continue
+		}
+		if current == nil {
+			continue
+		}
+		if p.name == "BEGIN" {
+			depth++
+			continue
+		}
+		if p.name == "END" {
+			depth--
+			continue
+		}
+		if depth > 0 {
+			continue
+		}
+
+		if err := applyProperty(current, p); err != nil {
+			return nil, fmt.Errorf("%s: %w", p.name, err)
+		}
+	}
+	if current != nil {
+		return nil, errors.New("missing END:VEVENT")
+	}
+	return events, nil
+}
+
+func applyProperty(e *Event, p property) error {
+	switch p.name {
+	case "UID":
+		e.UID = unescapeText(p.value)
+	case "SUMMARY":
+		e.Summary = unescapeText(p.value)
+	case "DESCRIPTION":
+		e.Description = unescapeText(p.value)
+	case "DTSTART":
+		t, allDay, err := parseTime(p)
+		if err != nil {
+			return err
+		}
+		e.Start, e.AllDay = t, allDay
+		if !allDay && t.Location() != time.UTC {
+			e.TZID = t.Location().String()
+		}
+	case "DTEND":
+		t, _, err := parseTime(p)
+		if err != nil {
+			return err
+		}
+		e.End = t
+	case "DURATION":
+		d, err := parseDuration(p.value)
+		if err != nil {
+			return err
+		}
+		e.duration = d
+	case "RRULE":
+		e.RRule = p.value
+	case "EXDATE":
+		for _, v := range strings.Split(p.value, ",") {
+			t, _, err := parseTime(property{name: p.name, params: p.params, value: v})
+			if err != nil {
+				return err
+			}
+			e.ExDates = append(e.ExDates, t)
+		}
+	case "RECURRENCE-ID":
+		t, _, err := parseTime(p)
+		if err != nil {
+			return err
+		}
+		e.RecurrenceID = &t
+	case "STATUS":
+		e.Cancelled = strings.EqualFold(p.value, "CANCELLED")
+	case "CREATED":
+		if t, _, err := parseTime(p); err == nil {
+			e.Created = t
+		}
+	}
+	return nil
+}
+
+// unfold 줄 접기 해제 (공백/탭으로 시작하는 줄은 앞 줄에 이어 붙임)
+func unfold(r io.Reader) ([]string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	var lines []string
+	for scanner.Scan() {
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if len(text) > 0 && (text[0] == ' ' || text[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1] += text[1:]
+			continue
+		}
+		lines = append(lines, text)
+	}
+	return lines, scanner.Err()
+}
+
+func parseProperty(line string) (property, error) {
+	// 값에 ':'가 있을 수 있으므로 따옴표 밖의 첫 ':'에서 자름
+	inQuote := false
+	colon := -1
+	for i := 0; i < len(line); i++ {
+		if line[i] == '"' {
+			inQuote = !inQuote
+		} else if line[i] == ':' && !inQuote {
+			colon = i
+			break
+		}
+	}
+	if colon < 0 {
+		return property{}, fmt.Errorf("invalid line %q", line)
+	}
+
+	head, value := line[:colon], line[colon+1:]
+	parts := strings.Split(head, ";")
+	p := property{name: strings.ToUpper(parts[0]), params: make(map[string]string), value: value}
+	for _, param := range parts[1:] {
+		if k, v, ok := strings.Cut(param, "="); ok {
+			p.params[strings.ToUpper(k)] = strings.Trim(v, `"`)
+		}
+	}
+	return p, nil
+}
+
+// parseTime DATE / UTC / TZID 지정 / 유동(floating, UTC로 취급) 시각 파싱
+func parseTime(p property) (time.Time, bool, error) {
+	value := strings.TrimSpace(p.value)
+	if p.params["VALUE"] == "DATE" || (len(value) == len(dateLayout) && !strings.Contains(value, "T")) {
+		t, err := time.Parse(dateLayout, value)
+		return t, true, err
+	}
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse(utcLayout, value)
+		return t, false, err
+	}
+	loc := time.UTC
+	if tzid := p.params["TZID"]; tzid != "" {
+		if l, err := time.LoadLocation(tzid); err == nil {
+			loc = l
+		}
+	}
+	t, err := time.ParseInLocation(localLayout, value, loc)
+	return t, false, err
+}
+
+// parseDuration RFC 5545 DURATION (예: PT1H30M, P1D)
+func parseDuration(s string) (time.Duration, error) {
+	orig := s
+	sign := time.Duration(1)
+	if strings.HasPrefix(s, "-") {
+		sign, s = -1, s[1:]
+	}
+	s = strings.TrimPrefix(s, "+")
+	if !strings.HasPrefix(s, "P") {
+		return 0, fmt.Errorf("invalid duration %q", orig)
+	}
+	s = s[1:]
+
+	var total time.Duration
+	inTime := false
+	num := 0
+	hasNum := false
+	for _, ch := range s {
+		switch {
+		case ch >= '0' && ch <= '9':
+			num = num*10 + int(ch-'0')
+			hasNum = true
+			continue
+		case ch == 'T':
+			inTime = true
+			continue
+		}
+		if !hasNum {
+			return 0, fmt.Errorf("invalid duration %q", orig)
+		}
+		n := time.Duration(num)
+		switch {
+		case ch == 'W':
+			total += n * 7 * 24 * time.Hour
+		case ch == 'D':
+			total += n * 24 * time.Hour
+		case ch == 'H' && inTime:
+			total += n * time.Hour
+		case ch == 'M' && inTime:
+			total += n * time.Minute
+		case ch == 'S' && inTime:
+			total += n * time.Second
+		default:
+			return 0, fmt.Errorf("invalid duration %q", orig)
+		}
+		num, hasNum = 0, false
+	}
+	return sign * total, nil
+}
@@ -0,0 +1,167 @@
+package language
+
+import (
+	"sort"
+	"strings"
+)
+
+// Language 지원 언어 정보 및 AWS 서비스별 코드/가능 여부
+type Language struct {
+	Code       string `json:"code"`        // 앱에서 사용하는 언어 코드 (예: "ko", "zh-TW")
+	Name       string `json:"name"`        // 영어 이름
+	NativeName string `json:"native_name"` // 해당 언어로 쓴 이름
+
+	TranscribeCode string `json:"-"` // Amazon Transcribe Streaming 로케일 (예: "ko-KR")
+	TranslateCode  string `json:"-"` // Amazon Translate 언어 코드
+	PollyVoice     string `json:"-"` // Amazon Polly 기본 음성 ("" = TTS 미지원)
+	PollyEngine    string `json:"-"` // "neural" 또는 "standard"
+
+	STT       bool `json:"stt"`       // 음성 인식 가능
+	Translate bool `json:"translate"` // 번역 가능
+	TTS       bool `json:"tts"`       // 음성 합성 가능
+}
+
+// DefaultSource 발화 언어 기본값
+const DefaultSource = "ko"
+
+// DefaultTarget 번역 대상 언어 기본값
+const DefaultTarget = "en"
+
+// registry 지원 언어 목록 (Transcribe Streaming / Translate / Polly 지원 범위 기준)
+var registry = []Language{
+	lang("ko", "Korean", "한국어", "ko-KR", "ko", "Seoyeon", "neural"),
+	lang("en", "English", "English", "en-US", "en", "Joanna", "neural"),
+	lang("ja", "Japanese", "日本語", "ja-JP", "ja", "Mizuki", "standard"),
+	lang("zh", "Chinese (Simplified)", "简体中文", "zh-CN", "zh", "Zhiyu", "neural"),
+	lang("zh-TW", "Chinese (Traditional)", "繁體中文", "zh-TW", "zh-TW", "", ""),
+	lang("es", "Spanish", "Español", "es-US", "es", "Lupe", "neural"),
+	lang("fr", "French", "Français", "fr-FR", "fr", "Lea", "neural"),
+	lang("de", "German", "Deutsch", "de-DE", "de", "Vicki", "neural"),
+	lang("it", "Italian", "Italiano", "it-IT", "it", "Bianca", "neural"),
+	lang("pt", "Portuguese", "Português", "pt-BR", "pt", "Camila", "neural"),
+	lang("ru", "Russian", "Русский", "ru-RU", "ru", "Tatyana", "standard"),
+	lang("ar", "Arabic", "العربية", "ar-SA", "ar", "Zeina", "standard"),
+	lang("hi", "Hindi", "हिन्दी", "hi-IN", "hi", "Kajal", "neural"),
+	lang("th", "Thai", "ไทย", "th-TH", "th", "", ""),
+	lang("vi", "Vietnamese", "Tiếng Việt", "vi-VN", "vi", "", ""),
+	lang("id", "Indonesian", "Bahasa Indonesia", "id-ID", "id", "", ""),
+	lang("ms", "Malay", "Bahasa Melayu", "ms-MY", "ms", "", ""),
+	lang("tr", "Turkish", "Türkçe", "tr-TR", "tr", "Burcu", "neural"),
+	lang("nl", "Dutch", "Nederlands", "nl-NL", "nl", "Laura", "neural"),
+	lang("pl", "Polish", "Polski", "pl-PL", "pl", "Ola", "neural"),
+	lang("sv", "Swedish", "Svenska", "sv-SE", "sv", "Elin", "neural"),
+	lang("da", "Danish", "Dansk", "da-DK", "da", "Sofie", "neural"),
+	lang("fi", "Finnish", "Suomi", "fi-FI", "fi", "Suvi", "neural"),
+	lang("no", "Norwegian", "Norsk", "no-NO", "no", "Ida", "neural"),
+	lang("cs", "Czech", "Čeština", "cs-CZ", "cs", "Jitka", "neural"),
+	lang("ro", "Romanian", "Română", "ro-RO", "ro", "Carmen", "standard"),
+	lang("uk", "Ukrainian", "Українська", "uk-UA", "uk", "", ""),
+	lang("el", "Greek", "Ελληνικά", "el-GR", "el", "", ""),
+	lang("he", "Hebrew", "עברית", "he-IL", "he", "", ""),
+}
+
+// aliases 클라이언트가 보내는 로케일/변형 코드 → 앱 언어 코드
+var aliases = map[string]string{
+	"zh-cn":   "zh",
+	"zh-hans": "zh",
+	"zh-tw":   "zh-TW",
+	"zh-hant": "zh-TW",
+	"zh-hk":   "zh-TW",
+	"nb":      "no",
+	"iw":      "he",
+	"in":      "id",
+}
+
+var byCode = func() map[string]Language {
+	m := make(map[string]Language, len(registry))
+	for _, l := range registry {
+		m[strings.ToLower(l.Code)] = l
+	}
+	return m
+}()
+
+func lang(code, name, nativeName, transcribeCode, translateCode, pollyVoice, pollyEngine string) Language {
+	return Language{
+		Code:           code,
+		Name:           name,
+		NativeName:     nativeName,
+		TranscribeCode: transcribeCode,
+		TranslateCode:  translateCode,
+		PollyVoice:     pollyVoice,
+		PollyEngine:    pollyEngine,
+		STT:            transcribeCode != "",
+		Translate:      translateCode != "",
+		TTS:            pollyVoice != "",
+	}
+}
+
+// Normalize 언어 코드를 앱 언어 코드로 정규화 (지원하지 않으면 "")
+// "ko-KR", "EN_us", "zh-Hant" 같은 로케일 형식도 허용
+func Normalize(code string) string {
+	code = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "_", "-"))
+	if code == "" {
+		return ""
+	}
+	if alias, ok := aliases[code]; ok {
+		return alias
+	}
+	if l, ok := byCode[code]; ok {
+		return l.Code
+	}
+
+	// 지역 코드를 떼고 기본 언어로 재시도 (예: "en-GB" → "en")
+	if i := strings.IndexByte(code, '-'); i > 0 {
+		base := code[:i]
+		if alias, ok := aliases[base]; ok {
+			return alias
+		}
+		if l, ok := byCode[base]; ok {
+			return l.Code
+		}
+	}
+	return ""
+}
+
+// Get 언어 정보 조회 (코드는 Normalize 규칙으로 해석)
+func Get(code string) (Language, bool) {
+	normalized := Normalize(code)
+	if normalized == "" {
+		return Language{}, false
+	}
+	l, ok := byCode[strings.ToLower(normalized)]
+	return l, ok
+}
+
+// IsSupported 지원 언어 여부
+func IsSupported(code string) bool {
+	_, ok := Get(code)
+	return ok
+}
+
+// SupportsSTT 음성 인식 가능한 언어인지 확인
+func SupportsSTT(code string) bool {
+	l, ok := Get(code)
+	return ok && l.STT
+}
+
+// SupportsTTS 음성 합성 가능한 언어인지 확인
+func SupportsTTS(code string) bool {
+	l, ok := Get(code)
+	return ok && l.TTS
+}
+
+// All 지원 언어 전체 목록 (코드 순)
+func All() []Language {
+	list := make([]Language, len(registry))
+	copy(list, registry)
+	sort.Slice(list, func(i, j int) bool { return list[i].Code < list[j].Code })
+	return list
+}
+
+// NormalizeOr 정규화 실패 시 fallback 반환
+func NormalizeOr(code, fallback string) string {
+	if normalized := Normalize(code); normalized != "" {
+		return normalized
+	}
+	return fallback
+}
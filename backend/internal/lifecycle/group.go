@@ -0,0 +1,133 @@
+package lifecycle
+
+import (
+	"log"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"realtime-backend/internal/metrics"
+)
+
+const closePollInterval = 100 * time.Millisecond
+
+var (
+	startedTotal = metrics.NewCounterVec("eum_goroutines_started_total",
+		"Tracked goroutines started, by owner component and kind", "component", "kind")
+	runningGauge = metrics.NewGaugeVec("eum_goroutines_running",
+		"Tracked goroutines currently running, by owner component and kind", "component", "kind")
+	leaksTotal = metrics.NewCounterVec("eum_goroutine_leaks_total",
+		"Tracked goroutines still running after their owner was closed", "component", "kind")
+	groupsGauge = metrics.NewGaugeVec("eum_goroutine_groups",
+		"Open goroutine groups (rooms, pipelines) by component", "component")
+)
+
+func init() {
+	metrics.NewGaugeFunc("eum_goroutines", "Total goroutines in the process", func() float64 {
+		return float64(runtime.NumGoroutine())
+	})
+}
+
+// Group 소유자(Room, Pipeline 등) 단위 고루틴 레지스트리
+// 소유자가 닫힌 뒤에도 끝나지 않는 고루틴을 누수로 집계해 /metrics에 노출
+type Group struct {
+	component string
+	id        string
+
+	mu      sync.Mutex
+	running map[string]int
+	total   int
+	closed  bool
+}
+
+// NewGroup 고루틴 그룹 생성 (component: 지표 라벨, id: 로그용 식별자)
+func NewGroup(component, id string) *Group {
+	groupsGauge.Inc(component)
+	return &Group{
+		component: component,
+		id:        id,
+		running:   make(map[string]int),
+	}
+}
+
+// Go 추적되는 고루틴 실행 (kind: 고루틴 종류, 예: "broadcaster")
+func (g *Group) Go(kind string, fn func()) {
+	g.mu.Lock()
+	g.running[kind]++
+	g.total++
+	g.mu.Unlock()
+
+	startedTotal.Inc(g.component, kind)
+	runningGauge.Inc(g.component, kind)
+
+	go func() {
+		defer func() {
+			g.mu.Lock()
+			g.running[kind]--
+			if g.running[kind] == 0 {
+				delete(g.running, kind)
+			}
+			g.total--
+			g.mu.Unlock()
+
+			runningGauge.Dec(g.component, kind)
+		}()
+		fn()
+	}()
+}
+
+func (g *Group) active() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.total
+}
+
+// Running 현재 실행 중인 고루틴 수 (종류별)
+func (g *Group) Running() map[string]int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	snapshot := make(map[string]int, len(g.running))
+	for kind, n := range g.running {
+		snapshot[kind] = n
+	}
+	return snapshot
+}
+
+// Close 소유자 종료 후 호출. timeout 안에 끝나지 않은 고루틴을 누수로 집계하고 반환
+// 블로킹되므로 보통 별도 고루틴에서 호출 (go group.Close(...))
+func (g *Group) Close(timeout time.Duration) map[string]int {
+	g.mu.Lock()
+	if g.closed {
+		g.mu.Unlock()
+		return nil
+	}
+	g.closed = true
+	g.mu.Unlock()
+	defer groupsGauge.Dec(g.component)
+
+	// 종료 직후 잠깐 남은 고루틴(정리 중인 Redis 호출 등)은 누수가 아니므로 timeout까지 대기
+	ticker := time.NewTicker(closePollInterval)
+	defer ticker.Stop()
+	deadline := time.Now().Add(timeout)
+	for g.active() > 0 && time.Now().Before(deadline) {
+		<-ticker.C
+	}
+
+	leaked := g.Running()
+	if len(leaked) == 0 {
+		return nil
+	}
+
+	kinds := make([]string, 0, len(leaked))
+	for kind, n := range leaked {
+		leaksTotal.Add(float64(n), g.component, kind)
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	log.Printf("⚠️ [Lifecycle] %s %s: goroutines still running %v after close: %s",
+		g.component, g.id, timeout, strings.Join(kinds, ", "))
+	return leaked
+}
@@ -0,0 +1,73 @@
+package lifecycle
+
+import (
+	"testing"
+	"time"
+
+	"go.uber.org/goleak"
+)
+
+func TestGroupCloseWaitsForWorkers(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	g := NewGroup("test", "exit")
+	stop := make(chan struct{})
+	g.Go("worker", func() { <-stop })
+	g.Go("worker", func() { <-stop })
+	if got := g.Running()["worker"]; got != 2 {
+		t.Fatalf("Running()[worker] = %d, want 2", got)
+	}
+
+	close(stop)
+	if leaked := g.Close(time.Second); leaked != nil {
+		t.Fatalf("Close() leaked = %v, want nil", leaked)
+	}
+	if running := g.Running(); len(running) != 0 {
+		t.Fatalf("Running() after Close = %v, want empty", running)
+	}
+}
+
+func TestGroupCloseReportsLeaks(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	g := NewGroup("test", "leak")
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	g.Go("stuck", func() {
+		defer close(done)
+		<-stop
+	})
+	g.Go("quick", func() {})
+
+	leaked := g.Close(200 * time.Millisecond)
+	if len(leaked) != 1 || leaked["stuck"] != 1 {
+		t.Fatalf("Close() leaked = %v, want map[stuck:1]", leaked)
+	}
+
+	// 누수로 집계된 고루틴은 goleak 검사 전에 정리
+	close(stop)
+	<-done
+}
+
+func TestGroupCloseIsIdempotent(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	g := NewGroup("test", "twice")
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	g.Go("stuck", func() {
+		defer close(done)
+		<-stop
+	})
+
+	if leaked := g.Close(100 * time.Millisecond); leaked == nil {
+		t.Fatalf("first Close() leaked = nil, want the stuck worker")
+	}
+	// 두 번째 호출은 누수를 다시 집계하지 않음
+	if leaked := g.Close(100 * time.Millisecond); leaked != nil {
+		t.Fatalf("second Close() leaked = %v, want nil", leaked)
+	}
+
+	close(stop)
+	<-done
+}
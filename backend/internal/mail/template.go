@@ -0,0 +1,94 @@
+package mail
+
+import (
+	"bytes"
+	"embed"
+	htmltemplate "html/template"
+	texttemplate "text/template"
+)
+
+// 메일 템플릿 이름
+const (
+	TemplateWorkspaceInvite = "workspace_invite"
+	TemplateMeetingInvite   = "meeting_invite"
+	TemplateDigest          = "digest"
+	TemplateVerifyEmail     = "verify_email"
+	TemplatePasswordReset   = "password_reset"
+)
+
+//go:embed templates/*.html templates/*.txt
+var templateFS embed.FS
+
+// buttonLink 본문 버튼 템플릿 인자
+type buttonLink struct {
+	URL   string
+	Label string
+}
+
+func link(url, label string) buttonLink {
+	return buttonLink{URL: url, Label: label}
+}
+
+var (
+	htmlTemplates = htmltemplate.Must(htmltemplate.New("mail").
+			Funcs(htmltemplate.FuncMap{"link": link}).
+			ParseFS(templateFS, "templates/*.html"))
+	textTemplates = texttemplate.Must(texttemplate.New("mail").
+			Funcs(texttemplate.FuncMap{"link": link}).
+			ParseFS(templateFS, "templates/*.txt"))
+)
+
+// InviteData 워크스페이스/회의 초대 메일 내용
+type InviteData struct {
+	RecipientName  string
+	InviterName    string
+	WorkspaceName  string
+	MeetingTitle   string // 회의 초대만
+	ActionURL      string
+	UnsubscribeURL string
+}
+
+// AccountData 이메일 인증/비밀번호 재설정 메일 내용
+type AccountData struct {
+	RecipientName  string
+	ActionURL      string
+	ValidFor       string // 링크 유효 기간 ("24시간")
+	UnsubscribeURL string // 계정 메일은 수신 거부 없음 (푸터 템플릿 공용)
+}
+
+// DigestData 일일 요약 메일 내용
+type DigestData struct {
+	RecipientName  string
+	Date           string // 수신자 타임존 기준 날짜
+	Mentions       []DigestMention
+	DirectMessages []DigestDirectMessage
+	ActionURL      string
+	UnsubscribeURL string
+}
+
+// DigestMention 읽지 않은 멘션 하나
+type DigestMention struct {
+	SenderName string
+	Content    string
+	At         string
+}
+
+// DigestDirectMessage 읽지 않은 메시지가 있는 DM 방 하나
+type DigestDirectMessage struct {
+	SenderName    string
+	WorkspaceName string
+	Count         int64
+	Preview       string // 가장 최근 메시지
+}
+
+// Render 템플릿으로 HTML/텍스트 본문 생성
+func Render(name string, data any) (html, text string, err error) {
+	var h, t bytes.Buffer
+	if err := htmlTemplates.ExecuteTemplate(&h, name, data); err != nil {
+		return "", "", err
+	}
+	if err := textTemplates.ExecuteTemplate(&t, name, data); err != nil {
+		return "", "", err
+	}
+	return h.String(), t.String(), nil
+}
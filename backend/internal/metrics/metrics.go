@@ -0,0 +1,281 @@
+package metrics
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// collector /metrics 출력 대상
+type collector interface {
+	write(b *strings.Builder)
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   []collector
+)
+
+func register(c collector) {
+	registryMu.Lock()
+	registry = append(registry, c)
+	registryMu.Unlock()
+}
+
+// Render 등록된 모든 지표를 Prometheus 텍스트 포맷으로 출력
+func Render() string {
+	registryMu.RLock()
+	collectors := make([]collector, len(registry))
+	copy(collectors, registry)
+	registryMu.RUnlock()
+
+	var b strings.Builder
+	for _, c := range collectors {
+		c.write(&b)
+	}
+	return b.String()
+}
+
+// vec 라벨 조합별 값 저장소 (Counter/Gauge 공용)
+type vec struct {
+	name   string
+	help   string
+	kind   string
+	labels []string
+	mu     sync.Mutex
+	values map[string]float64
+	keys   map[string][]string
+}
+
+func newVec(name, help, kind string, labels []string) *vec {
+	v := &vec{
+		name:   name,
+		help:   help,
+		kind:   kind,
+		labels: labels,
+		values: make(map[string]float64),
+		keys:   make(map[string][]string),
+	}
+	register(v)
+	return v
+}
+
+func (v *vec) add(delta float64, labelValues []string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.values[v.keyLocked(labelValues)] += delta
+}
+
+func (v *vec) set(value float64, labelValues []string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.values[v.keyLocked(labelValues)] = value
+}
+
+// keyLocked 라벨 값 조합의 내부 키 (라벨 개수가 다르면 코드 오류이므로 panic)
+func (v *vec) keyLocked(labelValues []string) string {
+	if len(labelValues) != len(v.labels) {
+		panic(fmt.Sprintf("metrics: %s expects %d label values, got %d", v.name, len(v.labels), len(labelValues)))
+	}
+	key := strings.Join(labelValues, "\xff")
+	if _, ok := v.keys[key]; !ok {
+		v.keys[key] = append([]string(nil), labelValues...)
+	}
+	return key
+}
+
+func (v *vec) write(b *strings.Builder) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	writeHeader(b, v.name, v.help, v.kind)
+	keys := make([]string, 0, len(v.values))
+	for k := range v.values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		writeSample(b, v.name, v.labels, v.keys[k], v.values[k])
+	}
+}
+
+// CounterVec 라벨별 누적 카운터
+type CounterVec struct{ v *vec }
+
+// NewCounterVec 카운터 생성 및 등록
+func NewCounterVec(name, help string, labels ...string) *CounterVec {
+	return &CounterVec{v: newVec(name, help, "counter", labels)}
+}
+
+// Inc 1 증가
+func (c *CounterVec) Inc(labelValues ...string) {
+	c.v.add(1, labelValues)
+}
+
+// Add delta만큼 증가 (음수 불가)
+func (c *CounterVec) Add(delta float64, labelValues ...string) {
+	if delta < 0 {
+		return
+	}
+	c.v.add(delta, labelValues)
+}
+
+// GaugeVec 라벨별 현재 값
+type GaugeVec struct{ v *vec }
+
+// NewGaugeVec 게이지 생성 및 등록
+func NewGaugeVec(name, help string, labels ...string) *GaugeVec {
+	return &GaugeVec{v: newVec(name, help, "gauge", labels)}
+}
+
+// Inc 1 증가
+func (g *GaugeVec) Inc(labelValues ...string) {
+	g.v.add(1, labelValues)
+}
+
+// Dec 1 감소
+func (g *GaugeVec) Dec(labelValues ...string) {
+	g.v.add(-1, labelValues)
+}
+
+// Set 값 설정
+func (g *GaugeVec) Set(value float64, labelValues ...string) {
+	g.v.set(value, labelValues)
+}
+
+// DefaultLatencyBuckets 외부 API 호출 지연 시간용 기본 버킷 (초)
+var DefaultLatencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// HistogramVec 라벨별 관측값 분포 (누적 버킷 + 합계 + 개수)
+type HistogramVec struct {
+	name    string
+	help    string
+	labels  []string
+	buckets []float64
+	mu      sync.Mutex
+	series  map[string]*histogramSeries
+}
+
+type histogramSeries struct {
+	labelValues []string
+	counts      []uint64 // 버킷별 개수 (누적 아님, 출력 시 누적)
+	sum         float64
+	count       uint64
+}
+
+// NewHistogramVec 히스토그램 생성 및 등록 (buckets는 오름차순 상한값, +Inf는 자동 추가)
+func NewHistogramVec(name, help string, buckets []float64, labels ...string) *HistogramVec {
+	bs := append([]float64(nil), buckets...)
+	sort.Float64s(bs)
+	h := &HistogramVec{
+		name:    name,
+		help:    help,
+		labels:  labels,
+		buckets: bs,
+		series:  make(map[string]*histogramSeries),
+	}
+	register(h)
+	return h
+}
+
+// Observe 관측값 기록
+func (h *HistogramVec) Observe(value float64, labelValues ...string) {
+	if len(labelValues) != len(h.labels) {
+		panic(fmt.Sprintf("metrics: %s expects %d label values, got %d", h.name, len(h.labels), len(labelValues)))
+	}
+	key := strings.Join(labelValues, "\xff")
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	s, ok := h.series[key]
+	if !ok {
+		s = &histogramSeries{
+			labelValues: append([]string(nil), labelValues...),
+			counts:      make([]uint64, len(h.buckets)),
+		}
+		h.series[key] = s
+	}
+	for i, upper := range h.buckets {
+		if value <= upper {
+			s.counts[i]++
+			break
+		}
+	}
+	s.sum += value
+	s.count++
+}
+
+// ObserveDuration start부터 현재까지 경과 시간(초) 기록
+func (h *HistogramVec) ObserveDuration(start time.Time, labelValues ...string) {
+	h.Observe(time.Since(start).Seconds(), labelValues...)
+}
+
+func (h *HistogramVec) write(b *strings.Builder) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	writeHeader(b, h.name, h.help, "histogram")
+	keys := make([]string, 0, len(h.series))
+	for k := range h.series {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	bucketLabels := append(append([]string(nil), h.labels...), "le")
+	for _, k := range keys {
+		s := h.series[k]
+		bucketValues := append(append([]string(nil), s.labelValues...), "")
+		var cumulative uint64
+		for i, upper := range h.buckets {
+			cumulative += s.counts[i]
+			bucketValues[len(bucketValues)-1] = strconv.FormatFloat(upper, 'g', -1, 64)
+			writeSample(b, h.name+"_bucket", bucketLabels, bucketValues, float64(cumulative))
+		}
+		bucketValues[len(bucketValues)-1] = "+Inf"
+		writeSample(b, h.name+"_bucket", bucketLabels, bucketValues, float64(s.count))
+		writeSample(b, h.name+"_sum", h.labels, s.labelValues, s.sum)
+		writeSample(b, h.name+"_count", h.labels, s.labelValues, float64(s.count))
+	}
+}
+
+// gaugeFunc 출력 시점에 값을 계산하는 게이지
+type gaugeFunc struct {
+	name string
+	help string
+	fn   func() float64
+}
+
+// NewGaugeFunc 출력할 때마다 fn으로 값을 구하는 게이지 등록
+func NewGaugeFunc(name, help string, fn func() float64) {
+	register(&gaugeFunc{name: name, help: help, fn: fn})
+}
+
+func (g *gaugeFunc) write(b *strings.Builder) {
+	writeHeader(b, g.name, g.help, "gauge")
+	writeSample(b, g.name, nil, nil, g.fn())
+}
+
+func writeHeader(b *strings.Builder, name, help, kind string) {
+	fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+func writeSample(b *strings.Builder, name string, labels, values []string, value float64) {
+	b.WriteString(name)
+	if len(labels) > 0 {
+		b.WriteByte('{')
+		for i, l := range labels {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			fmt.Fprintf(b, "%s=%q", l, values[i])
+		}
+		b.WriteByte('}')
+	}
+	b.WriteByte(' ')
+	b.WriteString(strconv.FormatFloat(value, 'g', -1, 64))
+	b.WriteByte('\n')
+}
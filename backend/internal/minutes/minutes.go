@@ -0,0 +1,99 @@
+// Package minutes 회의 음성 기록을 회의록 문서(Markdown/PDF)로 변환
+package minutes
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// 회의록 형식
+const (
+	FormatMarkdown = "markdown"
+	FormatPDF      = "pdf"
+)
+
+// Entry 발언 하나
+type Entry struct {
+	Time       time.Time
+	Speaker    string
+	SourceLang string
+	Original   string
+	Translated string // 번역이 없으면 빈 문자열
+	TargetLang string
+}
+
+// Document 회의록 내용
+type Document struct {
+	Title        string
+	StartedAt    time.Time
+	EndedAt      time.Time
+	Participants []string
+	Entries      []Entry
+}
+
+// Valid 지원하는 형식인지 확인
+func Valid(format string) bool {
+	return format == FormatMarkdown || format == FormatPDF
+}
+
+// Render 형식에 맞게 문서 생성 (파일 확장자, MIME 타입 포함)
+func Render(doc *Document, format string) (data []byte, ext, mimeType string) {
+	if format == FormatPDF {
+		return renderPDF(doc), ".pdf", "application/pdf"
+	}
+	return []byte(RenderMarkdown(doc)), ".md", "text/markdown; charset=utf-8"
+}
+
+// RenderMarkdown Markdown 회의록 (검색 색인에도 그대로 사용)
+func RenderMarkdown(doc *Document) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s 회의록\n\n", doc.Title)
+	fmt.Fprintf(&b, "- 일시: %s\n", doc.period())
+	if len(doc.Participants) > 0 {
+		fmt.Fprintf(&b, "- 참석자: %s\n", strings.Join(doc.Participants, ", "))
+	}
+	fmt.Fprintf(&b, "- 발언 수: %d\n\n", len(doc.Entries))
+
+	b.WriteString("## 대화 기록\n\n")
+	if len(doc.Entries) == 0 {
+		b.WriteString("_기록된 발언이 없습니다._\n")
+	}
+	for _, e := range doc.Entries {
+		fmt.Fprintf(&b, "**[%s] %s**", e.Time.Local().Format("15:04:05"), e.speaker())
+		if e.SourceLang != "" {
+			fmt.Fprintf(&b, " (%s)", e.SourceLang)
+		}
+		fmt.Fprintf(&b, ": %s\n", oneLine(e.Original))
+		if e.Translated != "" {
+			fmt.Fprintf(&b, "> %s: %s\n", e.TargetLang, oneLine(e.Translated))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// period 회의 시간 표시 ("2006-01-02 15:04 ~ 16:10")
+func (d *Document) period() string {
+	start := d.StartedAt.Local()
+	end := d.EndedAt.Local()
+	if end.IsZero() || !end.After(start) {
+		return start.Format("2006-01-02 15:04")
+	}
+	if start.Format("2006-01-02") == end.Format("2006-01-02") {
+		return start.Format("2006-01-02 15:04") + " ~ " + end.Format("15:04")
+	}
+	return start.Format("2006-01-02 15:04") + " ~ " + end.Format("2006-01-02 15:04")
+}
+
+func (e *Entry) speaker() string {
+	if e.Speaker == "" {
+		return "알 수 없음"
+	}
+	return e.Speaker
+}
+
+// oneLine 발언 안의 줄바꿈 제거 (Markdown 목록이 깨지지 않도록)
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
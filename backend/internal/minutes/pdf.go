@@ -0,0 +1,162 @@
+package minutes
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode/utf16"
+)
+
+// PDF 레이아웃 (A4, pt 단위)
+const (
+	pdfPageWidth  = 595.0
+	pdfPageHeight = 842.0
+	pdfMargin     = 50.0
+	pdfBodySize   = 10.5
+	pdfLineGap    = 1.45 // 글자 크기 대비 줄 간격
+)
+
+// pdfFontName 임베딩 없이 뷰어가 제공하는 Adobe-Korea1 기본 글꼴
+// (폰트 파일을 서버에 두지 않기 위해 PDF 표준 CJK 글꼴을 참조)
+const pdfFontName = "HYSMyeongJo-Medium"
+
+// pdfLine 한 줄 (크기와 들여쓰기)
+type pdfLine struct {
+	text   string
+	size   float64
+	indent float64
+}
+
+// renderPDF 회의록 PDF 생성 (텍스트만, 페이지 자동 분할)
+func renderPDF(doc *Document) []byte {
+	var lines []pdfLine
+	add := func(text string, size, indent float64) {
+		lines = append(lines, wrapPDFText(text, size, indent)...)
+	}
+
+	add(doc.Title+" 회의록", 18, 0)
+	lines = append(lines, pdfLine{size: pdfBodySize})
+	add("일시: "+doc.period(), pdfBodySize, 0)
+	if len(doc.Participants) > 0 {
+		add("참석자: "+strings.Join(doc.Participants, ", "), pdfBodySize, 0)
+	}
+	add(fmt.Sprintf("발언 수: %d", len(doc.Entries)), pdfBodySize, 0)
+	lines = append(lines, pdfLine{size: pdfBodySize})
+	add("대화 기록", 13, 0)
+	lines = append(lines, pdfLine{size: pdfBodySize / 2})
+
+	if len(doc.Entries) == 0 {
+		add("기록된 발언이 없습니다.", pdfBodySize, 0)
+	}
+	for _, e := range doc.Entries {
+		head := fmt.Sprintf("[%s] %s", e.Time.Local().Format("15:04:05"), e.speaker())
+		if e.SourceLang != "" {
+			head += " (" + e.SourceLang + ")"
+		}
+		add(head+": "+oneLine(e.Original), pdfBodySize, 0)
+		if e.Translated != "" {
+			add(e.TargetLang+": "+oneLine(e.Translated), pdfBodySize-1, 16)
+		}
+		lines = append(lines, pdfLine{size: pdfBodySize / 2})
+	}
+
+	return writePDF(paginatePDF(lines))
+}
+
+// wrapPDFText 페이지 폭에 맞춰 줄바꿈 (한글 등 전각 1em, 그 외 0.5em으로 추정)
+func wrapPDFText(text string, size, indent float64) []pdfLine {
+	maxWidth := pdfPageWidth - 2*pdfMargin - indent
+	var lines []pdfLine
+	var current []rune
+	width := 0.0
+	for _, r := range text {
+		if r > 0xFFFF || r < 0x20 {
+			continue // UCS-2로 표현할 수 없는 문자(이모지 등)와 제어 문자 제외
+		}
+		w := size
+		if r < 0x80 {
+			w = size / 2
+		}
+		if width+w > maxWidth && len(current) > 0 {
+			lines = append(lines, pdfLine{text: string(current), size: size, indent: indent})
+			current, width = current[:0], 0
+		}
+		current = append(current, r)
+		width += w
+	}
+	return append(lines, pdfLine{text: string(current), size: size, indent: indent})
+}
+
+// paginatePDF 줄을 페이지별 콘텐츠 스트림으로 배치
+func paginatePDF(lines []pdfLine) []string {
+	var pages []string
+	var content strings.Builder
+	y := pdfPageHeight - pdfMargin
+	for _, line := range lines {
+		height := line.size * pdfLineGap
+		if y-height < pdfMargin && content.Len() > 0 {
+			pages = append(pages, content.String())
+			content.Reset()
+			y = pdfPageHeight - pdfMargin
+		}
+		y -= height
+		if line.text == "" {
+			continue
+		}
+		fmt.Fprintf(&content, "BT /F1 %.1f Tf %.1f %.1f Td <%s> Tj ET\n",
+			line.size, pdfMargin+line.indent, y, pdfHexString(line.text))
+	}
+	if content.Len() > 0 || len(pages) == 0 {
+		pages = append(pages, content.String())
+	}
+	return pages
+}
+
+// pdfHexString UniKS-UCS2-H 인코딩용 UTF-16BE hex 문자열
+func pdfHexString(s string) string {
+	var b strings.Builder
+	for _, u := range utf16.Encode([]rune(s)) {
+		fmt.Fprintf(&b, "%04X", u)
+	}
+	return b.String()
+}
+
+// writePDF 페이지 콘텐츠로 PDF 파일 구성
+func writePDF(pages []string) []byte {
+	// 1: Catalog, 2: Pages, 3: Type0 글꼴, 4: CID 글꼴, 5: 글꼴 정보, 6~: 페이지/콘텐츠 쌍
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"", // Pages는 페이지 객체 번호가 정해진 뒤 채움
+		fmt.Sprintf("<< /Type /Font /Subtype /Type0 /BaseFont /%s /Encoding /UniKS-UCS2-H /DescendantFonts [4 0 R] >>", pdfFontName),
+		fmt.Sprintf("<< /Type /Font /Subtype /CIDFontType0 /BaseFont /%s /CIDSystemInfo << /Registry (Adobe) /Ordering (Korea1) /Supplement 1 >> /FontDescriptor 5 0 R /DW 1000 /W [1 95 500] >>", pdfFontName),
+		fmt.Sprintf("<< /Type /FontDescriptor /FontName /%s /Flags 6 /FontBBox [0 -148 1001 880] /ItalicAngle 0 /Ascent 880 /Descent -120 /CapHeight 880 /StemV 93 >>", pdfFontName),
+	}
+
+	kids := make([]string, 0, len(pages))
+	for _, content := range pages {
+		pageNum := len(objects) + 1
+		kids = append(kids, fmt.Sprintf("%d 0 R", pageNum))
+		objects = append(objects,
+			fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>",
+				pdfPageWidth, pdfPageHeight, pageNum+1),
+			fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", len(content), content),
+		)
+	}
+	objects[1] = fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages))
+
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return buf.Bytes()
+}
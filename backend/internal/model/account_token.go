@@ -0,0 +1,27 @@
+package model
+
+import (
+	"time"
+)
+
+// 계정 토큰 용도
+const (
+	AccountTokenVerifyEmail   = "VERIFY_EMAIL"
+	AccountTokenResetPassword = "RESET_PASSWORD"
+)
+
+// AccountToken 메일로 보내는 일회용 토큰 (이메일 인증, 비밀번호 재설정)
+// 원문은 메일에만 있고 DB에는 SHA-256 해시만 저장
+type AccountToken struct {
+	ID        int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID    int64      `gorm:"not null;index" json:"user_id"`
+	Purpose   string     `gorm:"type:varchar(20);not null" json:"purpose"`
+	TokenHash string     `gorm:"type:varchar(64);not null;uniqueIndex" json:"-"`
+	ExpiresAt time.Time  `gorm:"not null" json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	CreatedAt time.Time  `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (AccountToken) TableName() string {
+	return "account_tokens"
+}
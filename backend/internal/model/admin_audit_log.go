@@ -0,0 +1,45 @@
+package model
+
+import (
+	"time"
+)
+
+// AdminAction 플랫폼 관리자 작업 종류
+type AdminAction string
+
+const (
+	AdminUserViewed      AdminAction = "USER_VIEWED"      // 사용자 상세 조회
+	AdminUserSuspended   AdminAction = "USER_SUSPENDED"   // 계정 정지 (모든 세션 로그아웃 포함)
+	AdminUserUnsuspended AdminAction = "USER_UNSUSPENDED" // 계정 정지 해제
+	AdminUserLoggedOut   AdminAction = "USER_LOGGED_OUT"  // 모든 세션 강제 로그아웃
+	AdminWorkspaceViewed AdminAction = "WORKSPACE_VIEWED" // 워크스페이스 상세 조회
+)
+
+func (a AdminAction) String() string {
+	return string(a)
+}
+
+// 관리자 작업 대상 종류
+const (
+	AdminTargetUser      = "USER"
+	AdminTargetWorkspace = "WORKSPACE"
+)
+
+// AdminAuditLog 플랫폼 관리자 작업 기록 (워크스페이스 감사 기록과 별도, 관리자만 조회)
+type AdminAuditLog struct {
+	ID         int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	AdminID    int64     `gorm:"not null;index" json:"admin_id"`
+	Action     string    `gorm:"type:varchar(50);not null;index" json:"action"`
+	TargetType string    `gorm:"type:varchar(30);not null" json:"target_type"` // USER, WORKSPACE
+	TargetID   int64     `gorm:"not null;index" json:"target_id"`
+	Metadata   string    `gorm:"type:jsonb;not null;default:'{}'" json:"-"`
+	IPAddress  string    `gorm:"type:varchar(64)" json:"ip_address"`
+	CreatedAt  time.Time `gorm:"autoCreateTime;index" json:"created_at"`
+
+	// Relations
+	Admin *User `gorm:"foreignKey:AdminID" json:"admin,omitempty"`
+}
+
+func (AdminAuditLog) TableName() string {
+	return "admin_audit_logs"
+}
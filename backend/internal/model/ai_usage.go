@@ -0,0 +1,35 @@
+package model
+
+import (
+	"time"
+)
+
+// AIUsageDaily 워크스페이스/Room별 일일 AI 사용량 (Transcribe 초, Translate/Polly 문자 수)
+type AIUsageDaily struct {
+	WorkspaceID       int64     `gorm:"primaryKey" json:"workspace_id"` // 워크스페이스가 없는 Room은 0
+	RoomID            string    `gorm:"primaryKey;type:varchar(100)" json:"room_id"`
+	UsageDate         time.Time `gorm:"primaryKey;type:date" json:"usage_date"` // UTC 기준 날짜
+	TranscribeSeconds float64   `gorm:"not null;default:0" json:"transcribe_seconds"`
+	TranslateChars    int64     `gorm:"not null;default:0" json:"translate_chars"`
+	TTSChars          int64     `gorm:"not null;default:0" json:"tts_chars"`
+	UpdatedAt         time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (AIUsageDaily) TableName() string {
+	return "ai_usage_daily"
+}
+
+// WorkspaceAIUsageLimit 워크스페이스 월간 AI 사용량 한도 (0 = 제한 없음)
+// 한도에 도달하면 해당 기능(전사/번역/TTS)만 다음 달(UTC)까지 중단됨
+type WorkspaceAIUsageLimit struct {
+	WorkspaceID              int64     `gorm:"primaryKey" json:"workspace_id"`
+	MonthlyTranscribeSeconds int64     `gorm:"not null;default:0" json:"monthly_transcribe_seconds"`
+	MonthlyTranslateChars    int64     `gorm:"not null;default:0" json:"monthly_translate_chars"`
+	MonthlyTTSChars          int64     `gorm:"not null;default:0" json:"monthly_tts_chars"`
+	UpdatedBy                *int64    `json:"updated_by,omitempty"`
+	UpdatedAt                time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (WorkspaceAIUsageLimit) TableName() string {
+	return "workspace_ai_usage_limits"
+}
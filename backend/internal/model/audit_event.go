@@ -0,0 +1,62 @@
+package model
+
+import (
+	"time"
+)
+
+// AuditAction 워크스페이스 관리 작업 종류
+type AuditAction string
+
+const (
+	AuditMemberInvited           AuditAction = "MEMBER_INVITED"
+	AuditMemberJoined            AuditAction = "MEMBER_JOINED" // 초대 링크로 가입
+	AuditMemberRemoved           AuditAction = "MEMBER_REMOVED"
+	AuditMemberLeft              AuditAction = "MEMBER_LEFT"
+	AuditInvitationRevoked       AuditAction = "INVITATION_REVOKED"
+	AuditJoinRequestDenied       AuditAction = "JOIN_REQUEST_DENIED"
+	AuditMemberRoleChanged       AuditAction = "MEMBER_ROLE_CHANGED"
+	AuditRoleCreated             AuditAction = "ROLE_CREATED"
+	AuditRoleUpdated             AuditAction = "ROLE_UPDATED"
+	AuditRoleDeleted             AuditAction = "ROLE_DELETED"
+	AuditRoomDeleted             AuditAction = "ROOM_DELETED"
+	AuditChannelOverridesUpdated AuditAction = "CHANNEL_OVERRIDES_UPDATED"
+	AuditWorkspaceUpdated        AuditAction = "WORKSPACE_UPDATED" // 이름, 설명, 기본 언어, 아이콘
+	AuditSettingsUpdated         AuditAction = "SETTINGS_UPDATED"  // 설정 JSON, 번역 언어, 마스킹, 데이터 리전, 저장 용량
+	AuditInviteLinkCreated       AuditAction = "INVITE_LINK_CREATED"
+	AuditInviteLinkRevoked       AuditAction = "INVITE_LINK_REVOKED"
+	AuditEventUpdated            AuditAction = "EVENT_UPDATED" // MANAGE_EVENTS 권한으로 다른 멤버의 일정 수정
+	AuditEventDeleted            AuditAction = "EVENT_DELETED"
+)
+
+func (a AuditAction) String() string {
+	return string(a)
+}
+
+// 감사 기록 대상 종류
+const (
+	AuditTargetMember     = "MEMBER"
+	AuditTargetRole       = "ROLE"
+	AuditTargetRoom       = "ROOM"
+	AuditTargetWorkspace  = "WORKSPACE"
+	AuditTargetInviteLink = "INVITE_LINK"
+	AuditTargetEvent      = "CALENDAR_EVENT"
+)
+
+// AuditEvent 워크스페이스 관리 작업 기록 (누가, 무엇을, 어떤 대상에)
+type AuditEvent struct {
+	ID          int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	WorkspaceID int64     `gorm:"not null;index:idx_audit_events_feed,priority:1" json:"workspace_id"`
+	ActorID     *int64    `gorm:"index" json:"actor_id,omitempty"` // 시스템 작업이면 NULL
+	Action      string    `gorm:"type:varchar(50);not null" json:"action"`
+	TargetType  string    `gorm:"type:varchar(30);not null" json:"target_type"` // MEMBER, ROLE, ROOM, WORKSPACE, INVITE_LINK
+	TargetID    *int64    `json:"target_id,omitempty"`
+	Metadata    string    `gorm:"type:jsonb;not null;default:'{}'" json:"-"`
+	CreatedAt   time.Time `gorm:"autoCreateTime;index:idx_audit_events_feed,priority:2" json:"created_at"`
+
+	// Relations
+	Actor *User `gorm:"foreignKey:ActorID" json:"actor,omitempty"`
+}
+
+func (AuditEvent) TableName() string {
+	return "audit_events"
+}
@@ -0,0 +1,41 @@
+package model
+
+import (
+	"time"
+)
+
+// 로그인 세션 종료 사유
+const (
+	SessionRevokedLogout        = "LOGOUT"
+	SessionRevokedByUser        = "REVOKED"       // 세션 목록에서 로그아웃
+	SessionRevokedRefreshReuse  = "REFRESH_REUSE" // 이미 교체된 리프레시 토큰이 다시 사용됨 (탈취 의심)
+	SessionRevokedPasswordReset = "PASSWORD_RESET"
+	SessionRevokedByAdmin       = "ADMIN"          // 플랫폼 관리자의 강제 로그아웃/계정 정지
+	SessionRevokedAccountLinked = "ACCOUNT_LINKED" // 미인증 이메일 계정을 Google 로그인으로 인증 (선점 가입자의 비밀번호 폐기)
+)
+
+// AuthSession 로그인 세션 (기기별 리프레시 토큰)
+// 리프레시 토큰은 갱신할 때마다 교체하고 현재/직전 토큰의 해시만 저장
+type AuthSession struct {
+	ID                int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID            int64      `gorm:"not null;index" json:"user_id"`
+	RefreshTokenHash  string     `gorm:"type:varchar(64);not null" json:"-"`
+	PreviousTokenHash *string    `gorm:"type:varchar(64)" json:"-"` // 동시에 갱신한 다른 탭의 요청을 탈취로 오인하지 않도록
+	RotatedAt         *time.Time `json:"-"`
+	Device            string     `gorm:"type:varchar(255)" json:"device"` // User-Agent
+	IPAddress         string     `gorm:"type:varchar(64)" json:"ip_address"`
+	CreatedAt         time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	LastSeenAt        time.Time  `gorm:"not null" json:"last_seen_at"`
+	ExpiresAt         time.Time  `gorm:"not null" json:"expires_at"`
+	RevokedAt         *time.Time `gorm:"index" json:"revoked_at,omitempty"`
+	RevokedReason     *string    `gorm:"type:varchar(20)" json:"revoked_reason,omitempty"`
+}
+
+func (AuthSession) TableName() string {
+	return "auth_sessions"
+}
+
+// IsActive 취소되지 않았고 만료 전인지
+func (s *AuthSession) IsActive() bool {
+	return s.RevokedAt == nil && time.Now().Before(s.ExpiresAt)
+}
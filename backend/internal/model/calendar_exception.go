@@ -0,0 +1,25 @@
+package model
+
+import (
+	"time"
+)
+
+// CalendarEventException 반복 일정의 특정 발생 회차 수정/취소 기록
+// OccurrenceStart는 규칙으로 계산된 원래 시작 시각 (수정 후에도 회차 식별용으로 유지)
+type CalendarEventException struct {
+	ID              int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	EventID         int64      `gorm:"not null;uniqueIndex:idx_event_exception_occurrence,priority:1" json:"event_id"`
+	OccurrenceStart time.Time  `gorm:"not null;uniqueIndex:idx_event_exception_occurrence,priority:2" json:"occurrence_start"`
+	IsCancelled     bool       `gorm:"not null;default:false" json:"is_cancelled"`
+	Title           *string    `gorm:"type:varchar(255)" json:"title,omitempty"`
+	Description     *string    `gorm:"type:text" json:"description,omitempty"`
+	StartAt         *time.Time `json:"start_at,omitempty"`
+	EndAt           *time.Time `json:"end_at,omitempty"`
+	UpdatedBy       *int64     `json:"updated_by,omitempty"`
+	CreatedAt       time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt       time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (CalendarEventException) TableName() string {
+	return "calendar_event_exceptions"
+}
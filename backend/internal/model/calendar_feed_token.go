@@ -0,0 +1,18 @@
+package model
+
+import (
+	"time"
+)
+
+// CalendarFeedToken 사용자별 워크스페이스 캘린더 구독 토큰 (재발급하면 TokenHash가 바뀌어 이전 URL은 무효)
+type CalendarFeedToken struct {
+	WorkspaceID int64     `gorm:"primaryKey" json:"workspace_id"`
+	UserID      int64     `gorm:"primaryKey" json:"user_id"`
+	TokenHash   string    `gorm:"size:64;uniqueIndex" json:"-"` // 토큰 SHA-256 (원문은 발급 응답에서만 보여 줌)
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (CalendarFeedToken) TableName() string {
+	return "calendar_feed_tokens"
+}
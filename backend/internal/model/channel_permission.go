@@ -0,0 +1,35 @@
+package model
+
+import (
+	"time"
+)
+
+// 채널 권한 오버라이드 대상/효과
+const (
+	OverrideTargetRole = "ROLE"
+	OverrideTargetUser = "USER"
+
+	OverrideEffectAllow = "ALLOW"
+	OverrideEffectDeny  = "DENY"
+)
+
+// ChannelPermissionCodes 채팅방/회의 단위로 덮어쓸 수 있는 권한
+var ChannelPermissionCodes = []string{PermissionSendMessages, PermissionConnectMedia, PermissionDrawWhiteboard}
+
+// ChannelPermissionOverride 채팅방/회의별 권한 오버라이드 (역할 또는 사용자 단위로 허용/거부)
+// 사용자 오버라이드가 역할 오버라이드보다 우선
+type ChannelPermissionOverride struct {
+	ID             int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	WorkspaceID    int64     `gorm:"not null;index" json:"workspace_id"`
+	MeetingID      int64     `gorm:"not null;uniqueIndex:idx_channel_override_target,priority:1" json:"meeting_id"` // 채팅방도 meetings 행
+	TargetType     string    `gorm:"type:varchar(10);not null;uniqueIndex:idx_channel_override_target,priority:2" json:"target_type"`
+	TargetID       int64     `gorm:"not null;uniqueIndex:idx_channel_override_target,priority:3" json:"target_id"` // 역할 ID 또는 사용자 ID
+	PermissionCode string    `gorm:"type:varchar(50);not null;uniqueIndex:idx_channel_override_target,priority:4" json:"permission_code"`
+	Effect         string    `gorm:"type:varchar(10);not null" json:"effect"` // ALLOW, DENY
+	UpdatedBy      *int64    `json:"updated_by,omitempty"`
+	CreatedAt      time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (ChannelPermissionOverride) TableName() string {
+	return "channel_permission_overrides"
+}
@@ -0,0 +1,27 @@
+package model
+
+import (
+	"time"
+)
+
+// RecordingConsent 녹음/자막 저장 동의 기록 (미팅 참가자별)
+type RecordingConsent struct {
+	ID          int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	MeetingID   int64      `gorm:"not null;uniqueIndex:idx_consent_meeting_user" json:"meeting_id"`
+	UserID      int64      `gorm:"not null;uniqueIndex:idx_consent_meeting_user" json:"user_id"`
+	Status      string     `gorm:"type:varchar(20);not null" json:"status"` // GRANTED, REVOKED
+	ConsentedAt *time.Time `json:"consented_at,omitempty"`
+	RevokedAt   *time.Time `json:"revoked_at,omitempty"`
+	IPAddress   *string    `gorm:"type:varchar(64)" json:"ip_address,omitempty"`
+	UserAgent   *string    `gorm:"type:varchar(255)" json:"user_agent,omitempty"`
+	CreatedAt   time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+
+	// Relations
+	Meeting Meeting `gorm:"foreignKey:MeetingID" json:"meeting,omitempty"`
+	User    User    `gorm:"foreignKey:UserID" json:"user,omitempty"`
+}
+
+func (RecordingConsent) TableName() string {
+	return "recording_consents"
+}
@@ -12,9 +12,21 @@ const (
 type NotificationType string
 
 const (
-	NotificationTypeWorkspaceInvite NotificationType = "WORKSPACE_INVITE"
-	NotificationTypeMeetingAlert    NotificationType = "MEETING_ALERT"
-	NotificationTypeCommentMention  NotificationType = "COMMENT_MENTION"
+	NotificationTypeWorkspaceInvite  NotificationType = "WORKSPACE_INVITE"
+	NotificationTypeMeetingAlert     NotificationType = "MEETING_ALERT"
+	NotificationTypeCommentMention   NotificationType = "COMMENT_MENTION"
+	NotificationTypeFileExport       NotificationType = "FILE_EXPORT"            // 폴더 ZIP 작업 완료/실패
+	NotificationTypeFileActivity     NotificationType = "FILE_ACTIVITY"          // 구독한 폴더의 파일 추가/이름 변경/삭제
+	NotificationTypeFileTranscript   NotificationType = "FILE_TRANSCRIPT"        // 업로드 파일 전사 완료/실패
+	NotificationTypeWorkspaceRemoved NotificationType = "WORKSPACE_REMOVED"      // 관리자가 워크스페이스에서 내보냄
+	NotificationTypeJoinRequest      NotificationType = "WORKSPACE_JOIN_REQUEST" // 공개 워크스페이스 가입 요청 (관리자에게)
+	NotificationTypeJoinResult       NotificationType = "WORKSPACE_JOIN_RESULT"  // 가입 요청 승인/거절 (요청자에게)
+	NotificationTypeRoleMention      NotificationType = "ROLE_MENTION"           // 채팅 메시지에서 내 역할이 멘션됨
+	NotificationTypeEventReminder    NotificationType = "EVENT_REMINDER"         // 일정 시작 전 알림
+	NotificationTypeEventRSVP        NotificationType = "EVENT_RSVP"             // 참석자가 참석/불참 응답 (일정 생성자에게)
+	NotificationTypeEventComment     NotificationType = "EVENT_COMMENT"          // 일정에 새 댓글
+	NotificationTypeMeetingStarted   NotificationType = "MEETING_STARTED"        // 참여 중인 회의를 호스트가 시작함
+	NotificationTypeFileShared       NotificationType = "FILE_SHARED"            // 멤버가 나에게 파일/폴더를 공유함
 )
 
 // String 메서드
@@ -26,6 +38,19 @@ func (n NotificationType) String() string {
 	return string(n)
 }
 
+// JoinRequestStatus 워크스페이스 가입 요청 상태
+type JoinRequestStatus string
+
+const (
+	JoinRequestStatusPending  JoinRequestStatus = "PENDING"
+	JoinRequestStatusApproved JoinRequestStatus = "APPROVED"
+	JoinRequestStatusDenied   JoinRequestStatus = "DENIED"
+)
+
+func (s JoinRequestStatus) String() string {
+	return string(s)
+}
+
 // MeetingType 미팅/채팅방 타입
 type MeetingType string
 
@@ -38,3 +63,42 @@ const (
 func (m MeetingType) String() string {
 	return string(m)
 }
+
+// ConsentStatus 녹음 동의 상태
+type ConsentStatus string
+
+const (
+	ConsentStatusGranted ConsentStatus = "GRANTED"
+	ConsentStatusRevoked ConsentStatus = "REVOKED"
+)
+
+func (c ConsentStatus) String() string {
+	return string(c)
+}
+
+// ImpersonationAction 대리 접속 감사 기록 유형
+type ImpersonationAction string
+
+const (
+	ImpersonationActionStart   ImpersonationAction = "START"
+	ImpersonationActionRequest ImpersonationAction = "REQUEST"
+	ImpersonationActionBlocked ImpersonationAction = "BLOCKED"
+	ImpersonationActionEnd     ImpersonationAction = "END"
+)
+
+func (a ImpersonationAction) String() string {
+	return string(a)
+}
+
+// RedactionMode 필터링된 내용이 포함된 발화 처리 방식
+type RedactionMode string
+
+const (
+	RedactionModeOff  RedactionMode = "OFF"
+	RedactionModeMask RedactionMode = "MASK" // 해당 부분만 *** 처리
+	RedactionModeDrop RedactionMode = "DROP" // 발화 전체 제외
+)
+
+func (m RedactionMode) String() string {
+	return string(m)
+}
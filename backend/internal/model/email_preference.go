@@ -0,0 +1,28 @@
+package model
+
+import (
+	"time"
+)
+
+// 메일 수신 거부 목록
+const (
+	EmailListInvites = "invites" // 워크스페이스/회의 초대
+	EmailListDigest  = "digest"  // 일일 요약
+)
+
+// EmailPreference 사용자별 메일 알림 설정 (행이 없으면 모두 받음)
+// 수신 거부 토큰은 로그인 없이 메일의 링크로 수신 거부할 때 사용
+type EmailPreference struct {
+	UserID           int64      `gorm:"primaryKey" json:"user_id"`
+	InvitesOptOut    bool       `gorm:"not null;default:false" json:"-"`
+	DigestOptOut     bool       `gorm:"not null;default:false" json:"-"`
+	DigestHour       *int       `json:"digest_hour,omitempty"`                       // 발송 시각 (0-23), nil이면 서버 기본값
+	TimeZone         *string    `gorm:"type:varchar(64)" json:"time_zone,omitempty"` // 발송 시각 기준 타임존, nil이면 서버 기본값
+	UnsubscribeToken string     `gorm:"type:varchar(64);not null;uniqueIndex" json:"-"`
+	LastDigestAt     *time.Time `json:"last_digest_at,omitempty"`
+	UpdatedAt        time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (EmailPreference) TableName() string {
+	return "email_preferences"
+}
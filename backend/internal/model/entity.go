@@ -2,6 +2,8 @@ package model
 
 import (
 	"time"
+
+	"gorm.io/gorm"
 )
 
 // User 사용자
@@ -10,14 +12,22 @@ type User struct {
 	Email      string  `gorm:"type:varchar(255);uniqueIndex;not null" json:"email"`
 	Nickname   string  `gorm:"type:varchar(100);not null" json:"nickname"`
 	ProfileImg *string `gorm:"type:text" json:"profile_img,omitempty"`
+	AvatarKey  *string `gorm:"type:varchar(255)" json:"-"` // 업로드한 프로필 이미지 S3 prefix (교체/삭제 시 크기별 객체 정리)
 	Provider   *string `gorm:"type:varchar(50)" json:"provider,omitempty"`
 	ProviderID *string `gorm:"type:varchar(255)" json:"provider_id,omitempty"`
 
+	// 이메일/비밀번호 계정 (Google 계정은 비어 있음)
+	PasswordHash    *string    `gorm:"type:varchar(255)" json:"-"`
+	EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty"`
+
 	// Presence & Status
 	DefaultStatus         string     `gorm:"type:varchar(20);default:'ONLINE'" json:"default_status"`
 	CustomStatusText      *string    `gorm:"type:varchar(100)" json:"custom_status_text,omitempty"`
 	CustomStatusEmoji     *string    `gorm:"type:varchar(10)" json:"custom_status_emoji,omitempty"`
 	CustomStatusExpiresAt *time.Time `json:"custom_status_expires_at,omitempty"`
+	IsPlatformAdmin       bool       `gorm:"default:false" json:"is_platform_admin"` // 플랫폼 운영자 (지원용 대리 접속 등)
+	SuspendedAt           *time.Time `json:"suspended_at,omitempty"`                 // 관리자가 정지한 계정 (로그인/토큰 갱신 거부)
+	SuspendedReason       *string    `gorm:"type:text" json:"-"`
 	CreatedAt             time.Time  `gorm:"autoCreateTime" json:"created_at"`
 
 	// Relations
@@ -31,10 +41,18 @@ func (User) TableName() string {
 
 // Workspace 워크스페이스
 type Workspace struct {
-	ID        int64     `gorm:"primaryKey;autoIncrement" json:"id"`
-	Name      string    `gorm:"type:varchar(100);not null" json:"name"`
-	OwnerID   int64     `gorm:"not null" json:"owner_id"`
-	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+	ID               int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	Name             string    `gorm:"type:varchar(100);not null" json:"name"`
+	OwnerID          int64     `gorm:"not null" json:"owner_id"`
+	DataRegion       *string   `gorm:"type:varchar(30)" json:"data_region,omitempty"` // AWS 리전 고정 (NULL이면 기본 리전)
+	Description      *string   `gorm:"type:text" json:"description,omitempty"`
+	IconURL          *string   `gorm:"type:text" json:"icon_url,omitempty"`
+	IconKey          *string   `gorm:"type:varchar(500)" json:"-"`                         // 아이콘 S3 객체 키 (교체/삭제 시 이전 객체 정리)
+	DefaultLanguage  *string   `gorm:"type:varchar(10)" json:"default_language,omitempty"` // 워크스페이스 기본 언어 코드
+	Settings         string    `gorm:"type:jsonb;not null;default:'{}'" json:"-"`          // 클라이언트가 정의하는 설정 (JSON 객체)
+	Discoverable     bool      `gorm:"not null;default:false" json:"discoverable"`         // 멤버가 아니어도 검색해서 가입 요청 가능
+	RequireTwoFactor bool      `gorm:"not null;default:false" json:"require_two_factor"`   // 2단계 인증을 켠 멤버만 접근 가능
+	CreatedAt        time.Time `gorm:"autoCreateTime" json:"created_at"`
 
 	// Relations
 	Owner          User              `gorm:"foreignKey:OwnerID" json:"owner,omitempty"`
@@ -56,6 +74,7 @@ type Role struct {
 	Name        string  `gorm:"type:varchar(50);not null" json:"name"`
 	Color       *string `gorm:"type:varchar(20)" json:"color,omitempty"`
 	IsDefault   bool    `gorm:"default:false" json:"is_default"`
+	IsGuest     bool    `gorm:"not null;default:false" json:"is_guest"` // 회의 전용 게스트 역할 (채널 오버라이드로 허용된 회의 접속만 가능)
 
 	// Relations
 	Workspace   Workspace        `gorm:"foreignKey:WorkspaceID" json:"workspace,omitempty"`
@@ -100,16 +119,20 @@ func (WorkspaceMember) TableName() string {
 
 // Meeting 회의
 type Meeting struct {
-	ID          int64      `gorm:"primaryKey;autoIncrement" json:"id"`
-	WorkspaceID *int64     `json:"workspace_id,omitempty"`
-	HostID      int64      `gorm:"not null" json:"host_id"`
-	Title       string     `gorm:"type:varchar(200);not null" json:"title"`
-	Code        string     `gorm:"type:varchar(100);uniqueIndex;not null" json:"code"`
-	Type        string     `gorm:"type:varchar(20);not null" json:"type"` // VIDEO, VOICE_ONLY
-	Status      string     `gorm:"type:varchar(20);default:'SCHEDULED'" json:"status"`
-	StartedAt   *time.Time `json:"started_at,omitempty"`
-	EndedAt     *time.Time `json:"ended_at,omitempty"`
-	CreatedAt   time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	ID                 int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	WorkspaceID        *int64     `json:"workspace_id,omitempty"`
+	HostID             int64      `gorm:"not null" json:"host_id"`
+	Title              string     `gorm:"type:varchar(200);not null" json:"title"`
+	Code               string     `gorm:"type:varchar(100);uniqueIndex;not null" json:"code"`
+	Type               string     `gorm:"type:varchar(20);not null" json:"type"` // VIDEO, VOICE_ONLY
+	Status             string     `gorm:"type:varchar(20);default:'SCHEDULED'" json:"status"`
+	RecordingEnabled   bool       `gorm:"default:false" json:"recording_enabled"`             // 녹음/자막 저장 (참가자 동의 필요)
+	WhiteboardViewOnly bool       `gorm:"not null;default:false" json:"whiteboard_view_only"` // 기본 화이트보드 보기 전용 (발표자만 그리기)
+	ScheduledStartAt   *time.Time `json:"scheduled_start_at,omitempty"`                       // 캘린더 일정으로 만든 회의의 예정 시각
+	ScheduledEndAt     *time.Time `json:"scheduled_end_at,omitempty"`
+	StartedAt          *time.Time `json:"started_at,omitempty"`
+	EndedAt            *time.Time `json:"ended_at,omitempty"`
+	CreatedAt          time.Time  `gorm:"autoCreateTime" json:"created_at"`
 
 	// Relations
 	Workspace         *Workspace         `gorm:"foreignKey:WorkspaceID" json:"workspace,omitempty"`
@@ -144,13 +167,18 @@ func (Participant) TableName() string {
 	return "participants"
 }
 
-// Whiteboard 화이트보드
+// Whiteboard 화이트보드 (이름 있는 보드, 미팅 또는 워크스페이스에 속함)
+// 획 기록은 whiteboard_strokes/whiteboard_snapshots 의 whiteboard_id 로 보드마다 따로 관리
 type Whiteboard struct {
 	ID          int64     `gorm:"primaryKey;autoIncrement" json:"id"`
-	MeetingID   *int64    `json:"meeting_id,omitempty"`
-	WorkspaceID int64     `gorm:"not null" json:"workspace_id"`
-	Data        *string   `gorm:"type:jsonb" json:"data,omitempty"` // JSONB
+	MeetingID   *int64    `gorm:"index" json:"meeting_id,omitempty"` // nil = 워크스페이스 보드
+	WorkspaceID int64     `gorm:"not null;index" json:"workspace_id"`
+	Name        string    `gorm:"size:100;not null;default:'Whiteboard'" json:"name"`
+	CreatedBy   *int64    `json:"created_by,omitempty"`
+	ViewOnly    bool      `gorm:"not null;default:false" json:"view_only"` // 보기 전용 (발표자만 그리기)
+	Data        *string   `gorm:"type:jsonb" json:"data,omitempty"`        // JSONB
 	RedoData    *string   `gorm:"type:jsonb" json:"redo_data,omitempty"`
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt   time.Time `gorm:"autoUpdateTime" json:"updated_at"`
 
 	// Relations
@@ -183,7 +211,8 @@ func (ChatLog) TableName() string {
 // VoiceRecord 음성 기록 (STT 결과)
 type VoiceRecord struct {
 	ID            int64     `gorm:"primaryKey;autoIncrement" json:"id"`
-	MeetingID     int64     `gorm:"not null;index" json:"meeting_id"`
+	MeetingID     *int64    `gorm:"index" json:"meeting_id,omitempty"`      // 업로드 파일 전사 기록은 NULL일 수 있음
+	RelatedFileID *int64    `gorm:"index" json:"related_file_id,omitempty"` // 업로드된 오디오/비디오 파일 전사 결과
 	SpeakerID     *int64    `json:"speaker_id,omitempty"`
 	SpeakerName   string    `gorm:"type:varchar(100)" json:"speaker_name"`
 	Original      string    `gorm:"type:text;not null" json:"original"`            // STT 원본 텍스트
@@ -193,8 +222,8 @@ type VoiceRecord struct {
 	CreatedAt     time.Time `gorm:"autoCreateTime;index" json:"created_at"`
 
 	// Relations
-	Meeting Meeting `gorm:"foreignKey:MeetingID" json:"meeting,omitempty"`
-	Speaker *User   `gorm:"foreignKey:SpeakerID" json:"speaker,omitempty"`
+	Meeting *Meeting `gorm:"foreignKey:MeetingID" json:"meeting,omitempty"`
+	Speaker *User    `gorm:"foreignKey:SpeakerID" json:"speaker,omitempty"`
 }
 
 func (VoiceRecord) TableName() string {
@@ -210,16 +239,28 @@ type CalendarEvent struct {
 	Description     *string   `gorm:"type:text" json:"description,omitempty"`
 	StartAt         time.Time `gorm:"not null" json:"start_at"`
 	EndAt           time.Time `gorm:"not null" json:"end_at"`
-	IsAllDay        bool      `gorm:"default:false" json:"is_all_day"`
+	IsAllDay        bool      `gorm:"default:false" json:"is_all_day"`                                 // 종일 일정은 날짜의 UTC 0시로 저장
+	TimeZone        string    `gorm:"type:varchar(64);not null;default:'Asia/Seoul'" json:"time_zone"` // IANA 타임존 (반복 계산 기준)
 	LinkedMeetingID *int64    `json:"linked_meeting_id,omitempty"`
 	Color           *string   `gorm:"type:varchar(20)" json:"color,omitempty"`
 	CreatedAt       time.Time `gorm:"autoCreateTime" json:"created_at"`
 
+	// 반복 일정 (RRULE, 없으면 단일 일정)
+	RecurrenceRule  *string    `gorm:"type:varchar(500)" json:"recurrence_rule,omitempty"`
+	RecurrenceUntil *time.Time `json:"recurrence_until,omitempty"` // 마지막 발생 시작 시각 (끝이 없으면 NULL, 기간 조회용)
+
+	// ICS로 가져온 일정의 원래 UID (다시 가져올 때 중복 방지, 내보낼 때 그대로 사용)
+	ExternalUID *string `gorm:"type:varchar(255);index" json:"external_uid,omitempty"`
+
+	// 기본 알림 (시작 몇 분 전, JSON 배열) - 참석자별 설정이 없으면 사용
+	ReminderMinutes string `gorm:"type:jsonb;not null;default:'[]'" json:"-"`
+
 	// Relations
-	Workspace     Workspace       `gorm:"foreignKey:WorkspaceID" json:"workspace,omitempty"`
-	Creator       *User           `gorm:"foreignKey:CreatorID" json:"creator,omitempty"`
-	LinkedMeeting *Meeting        `gorm:"foreignKey:LinkedMeetingID" json:"linked_meeting,omitempty"`
-	Attendees     []EventAttendee `gorm:"foreignKey:EventID" json:"attendees,omitempty"`
+	Workspace     Workspace         `gorm:"foreignKey:WorkspaceID" json:"workspace,omitempty"`
+	Creator       *User             `gorm:"foreignKey:CreatorID" json:"creator,omitempty"`
+	LinkedMeeting *Meeting          `gorm:"foreignKey:LinkedMeetingID" json:"linked_meeting,omitempty"`
+	Attendees     []EventAttendee   `gorm:"foreignKey:EventID" json:"attendees,omitempty"`
+	Attachments   []EventAttachment `gorm:"foreignKey:EventID" json:"attachments,omitempty"`
 }
 
 func (CalendarEvent) TableName() string {
@@ -228,10 +269,11 @@ func (CalendarEvent) TableName() string {
 
 // EventAttendee 일정 참여자
 type EventAttendee struct {
-	EventID   int64     `gorm:"primaryKey" json:"event_id"`
-	UserID    int64     `gorm:"primaryKey" json:"user_id"`
-	Status    string    `gorm:"type:varchar(20);default:'PENDING'" json:"status"` // PENDING, ACCEPTED, DECLINED
-	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+	EventID     int64     `gorm:"primaryKey" json:"event_id"`
+	UserID      int64     `gorm:"primaryKey" json:"user_id"`
+	Status      string    `gorm:"type:varchar(20);default:'PENDING'" json:"status"` // PENDING, ACCEPTED, DECLINED
+	IsOrganizer bool      `gorm:"not null;default:false" json:"is_organizer"`       // 공동 주최자 (일정 수정/삭제 가능)
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
 
 	// Relations
 	Event CalendarEvent `gorm:"foreignKey:EventID" json:"event,omitempty"`
@@ -243,19 +285,26 @@ func (EventAttendee) TableName() string {
 }
 
 // WorkspaceFile 워크스페이스 파일/폴더
+
 type WorkspaceFile struct {
-	ID               int64     `gorm:"primaryKey;autoIncrement" json:"id"`
-	WorkspaceID      int64     `gorm:"not null" json:"workspace_id"`
-	UploaderID       *int64    `json:"uploader_id,omitempty"`
-	ParentFolderID   *int64    `json:"parent_folder_id,omitempty"`
-	Name             string    `gorm:"type:varchar(255);not null" json:"name"`
-	Type             string    `gorm:"type:varchar(20);not null" json:"type"` // FILE, FOLDER
-	FileURL          *string   `gorm:"type:text" json:"file_url,omitempty"`
-	FileSize         *int64    `json:"file_size,omitempty"`
-	MimeType         *string   `gorm:"type:varchar(100)" json:"mime_type,omitempty"`
-	S3Key            *string   `gorm:"type:varchar(500)" json:"s3_key,omitempty"` // AWS S3 객체 키
-	RelatedMeetingID *int64    `json:"related_meeting_id,omitempty"`
-	CreatedAt        time.Time `gorm:"autoCreateTime" json:"created_at"`
+	ID               int64          `gorm:"primaryKey;autoIncrement" json:"id"`
+	WorkspaceID      int64          `gorm:"not null;index:idx_workspace_files_scope,priority:1;index:idx_workspace_files_created,priority:1" json:"workspace_id"`
+	UploaderID       *int64         `gorm:"index" json:"uploader_id,omitempty"`
+	ParentFolderID   *int64         `gorm:"index:idx_workspace_files_scope,priority:2" json:"parent_folder_id,omitempty"`
+	Name             string         `gorm:"type:varchar(255);not null" json:"name"`
+	Type             string         `gorm:"type:varchar(20);not null" json:"type"` // FILE, FOLDER
+	FileURL          *string        `gorm:"type:text" json:"file_url,omitempty"`
+	FileSize         *int64         `json:"file_size,omitempty"`
+	MimeType         *string        `gorm:"type:varchar(100);index" json:"mime_type,omitempty"`
+	S3Key            *string        `gorm:"type:varchar(500)" json:"s3_key,omitempty"`            // AWS S3 객체 키 (같은 내용으로 연결한 파일끼리 공유)
+	ContentHash      *string        `gorm:"type:varchar(64);index" json:"content_hash,omitempty"` // 본문 SHA-256 (hex, 중복 업로드 감지용)
+	ThumbnailURL     *string        `gorm:"type:text" json:"thumbnail_url,omitempty"`             // 목록 그리드용 축소 이미지 (업로드 후 비동기 생성)
+	PreviewURL       *string        `gorm:"type:text" json:"preview_url,omitempty"`
+	RelatedMeetingID *int64         `json:"related_meeting_id,omitempty"`
+	CreatedAt        time.Time      `gorm:"autoCreateTime;index:idx_workspace_files_created,priority:2" json:"created_at"`
+	DeletedAt        gorm.DeletedAt `gorm:"index" json:"-"` // 휴지통으로 옮긴 시각 (일반 조회에서 자동 제외)
+	DeletedBy        *int64         `json:"-"`
+	TrashRootID      *int64         `gorm:"index" json:"-"` // 함께 휴지통으로 옮긴 최상위 항목 (복원 단위)
 
 	// Relations
 	Workspace      Workspace       `gorm:"foreignKey:WorkspaceID" json:"workspace,omitempty"`
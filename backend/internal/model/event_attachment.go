@@ -0,0 +1,30 @@
+package model
+
+import (
+	"time"
+)
+
+// 일정 첨부 종류
+const (
+	EventAttachmentFile = "FILE" // 워크스페이스 파일 (회의록, 발표 자료 등)
+	EventAttachmentLink = "LINK" // 외부 문서 링크 (회의 안건 문서 등)
+)
+
+// EventAttachment 일정에 첨부한 준비 자료 (초대와 함께 참석자에게 보임)
+type EventAttachment struct {
+	ID        int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	EventID   int64     `gorm:"not null;index" json:"event_id"`
+	Type      string    `gorm:"type:varchar(10);not null" json:"type"` // FILE, LINK
+	FileID    *int64    `gorm:"index" json:"file_id,omitempty"`
+	URL       *string   `gorm:"type:varchar(2048)" json:"url,omitempty"`
+	Title     string    `gorm:"type:varchar(255);not null" json:"title"`
+	AddedBy   *int64    `json:"added_by,omitempty"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+
+	// Relations
+	File *WorkspaceFile `gorm:"foreignKey:FileID" json:"file,omitempty"`
+}
+
+func (EventAttachment) TableName() string {
+	return "event_attachments"
+}
@@ -0,0 +1,22 @@
+package model
+
+import (
+	"time"
+)
+
+// EventComment 일정 댓글 (준비물, 장소 변경 등 일정 관련 대화)
+type EventComment struct {
+	ID        int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	EventID   int64     `gorm:"not null;index" json:"event_id"`
+	UserID    int64     `gorm:"not null" json:"user_id"`
+	Content   string    `gorm:"type:text;not null" json:"content"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+
+	// Relations
+	User User `gorm:"foreignKey:UserID" json:"user,omitempty"`
+}
+
+func (EventComment) TableName() string {
+	return "event_comments"
+}
@@ -0,0 +1,30 @@
+package model
+
+import (
+	"time"
+)
+
+// EventReminderPreference 참석자별 알림 설정 (행이 있으면 일정 기본 알림 대신 사용, "[]"이면 알림 끔)
+type EventReminderPreference struct {
+	EventID       int64     `gorm:"primaryKey" json:"event_id"`
+	UserID        int64     `gorm:"primaryKey" json:"user_id"`
+	MinutesBefore string    `gorm:"type:jsonb;not null;default:'[]'" json:"-"` // 시작 몇 분 전에 알릴지 (JSON 배열)
+	UpdatedAt     time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (EventReminderPreference) TableName() string {
+	return "event_reminder_preferences"
+}
+
+// EventReminderDelivery 발송한 일정 알림 (여러 인스턴스/재시작 시 중복 발송 방지)
+type EventReminderDelivery struct {
+	EventID       int64     `gorm:"primaryKey" json:"event_id"`
+	UserID        int64     `gorm:"primaryKey" json:"user_id"`
+	StartAt       time.Time `gorm:"primaryKey;index" json:"start_at"` // 알린 회차의 시작 시각
+	MinutesBefore int       `gorm:"primaryKey" json:"minutes_before"`
+	SentAt        time.Time `gorm:"autoCreateTime" json:"sent_at"`
+}
+
+func (EventReminderDelivery) TableName() string {
+	return "event_reminder_deliveries"
+}
@@ -0,0 +1,83 @@
+package model
+
+import (
+	"time"
+)
+
+// FileAccess 사용자별 파일 열람 기록 (다운로드/미리보기, 일정 간격으로 묶어서 저장)
+type FileAccess struct {
+	UserID         int64     `gorm:"primaryKey" json:"user_id"`
+	FileID         int64     `gorm:"primaryKey" json:"file_id"`
+	WorkspaceID    int64     `gorm:"not null;index:idx_file_access_recent,priority:1" json:"workspace_id"`
+	AccessCount    int64     `gorm:"not null;default:0" json:"access_count"`
+	LastAccessedAt time.Time `gorm:"not null;index:idx_file_access_recent,priority:2" json:"last_accessed_at"`
+}
+
+func (FileAccess) TableName() string {
+	return "file_accesses"
+}
+
+// FileStar 사용자별 즐겨찾기 파일
+type FileStar struct {
+	UserID      int64     `gorm:"primaryKey" json:"user_id"`
+	FileID      int64     `gorm:"primaryKey" json:"file_id"`
+	WorkspaceID int64     `gorm:"not null;index" json:"workspace_id"`
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (FileStar) TableName() string {
+	return "file_stars"
+}
+
+// FileActivityAction 파일 활동 종류
+type FileActivityAction string
+
+const (
+	FileActionUpload       FileActivityAction = "UPLOAD"
+	FileActionCreateFolder FileActivityAction = "CREATE_FOLDER"
+	FileActionRename       FileActivityAction = "RENAME"
+	FileActionMove         FileActivityAction = "MOVE"
+	FileActionDelete       FileActivityAction = "DELETE"  // 휴지통으로 이동
+	FileActionRestore      FileActivityAction = "RESTORE" // 휴지통에서 복원
+	FileActionPurge        FileActivityAction = "PURGE"   // 영구 삭제
+	FileActionDownload     FileActivityAction = "DOWNLOAD"
+)
+
+func (a FileActivityAction) String() string {
+	return string(a)
+}
+
+// FileActivity 파일 활동 감사 기록 (추가만 하고 수정/삭제하지 않음, 파일이 영구 삭제돼도 유지)
+// 파일 이름과 위치는 기록 당시 값
+type FileActivity struct {
+	ID          int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	WorkspaceID int64     `gorm:"not null;index:idx_file_activities_feed,priority:1" json:"workspace_id"`
+	FileID      int64     `gorm:"not null;index" json:"file_id"`
+	FolderID    *int64    `json:"folder_id,omitempty"` // 당시 상위 폴더
+	ActorID     *int64    `json:"actor_id,omitempty"`  // NULL = 시스템 (보관 기간 만료, 공유 링크 다운로드 등)
+	Action      string    `gorm:"type:varchar(20);not null" json:"action"`
+	FileName    string    `gorm:"type:varchar(255);not null" json:"file_name"`
+	FileType    string    `gorm:"type:varchar(20);not null" json:"file_type"` // FILE, FOLDER
+	Detail      *string   `gorm:"type:text" json:"detail,omitempty"`          // 이전 이름, 이동 전 폴더 등
+	CreatedAt   time.Time `gorm:"autoCreateTime;index:idx_file_activities_feed,priority:2" json:"created_at"`
+
+	// Relations
+	Actor *User `gorm:"foreignKey:ActorID" json:"actor,omitempty"`
+}
+
+func (FileActivity) TableName() string {
+	return "file_activities"
+}
+
+// FileActivitySetting 파일 활동을 SYSTEM 메시지로 올릴 채팅방 설정
+type FileActivitySetting struct {
+	WorkspaceID      int64     `gorm:"primaryKey" json:"workspace_id"`
+	ChatRoomID       *int64    `json:"chat_room_id,omitempty"` // NULL = 채팅방에 올리지 않음
+	IncludeDownloads bool      `gorm:"not null;default:false" json:"include_downloads"`
+	UpdatedBy        *int64    `json:"updated_by,omitempty"`
+	UpdatedAt        time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (FileActivitySetting) TableName() string {
+	return "file_activity_settings"
+}
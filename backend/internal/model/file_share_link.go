@@ -0,0 +1,29 @@
+package model
+
+import (
+	"time"
+)
+
+// FileShareLink 워크스페이스 밖으로 공유하는 파일/폴더 링크
+// 만료, 취소되었거나 대상이 휴지통으로 옮겨지면 더 이상 열리지 않음
+type FileShareLink struct {
+	ID            int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	WorkspaceID   int64      `gorm:"not null;index" json:"workspace_id"`
+	FileID        int64      `gorm:"not null;index" json:"file_id"`
+	Token         string     `gorm:"type:varchar(64);not null;uniqueIndex" json:"token"`
+	PasswordHash  *string    `gorm:"type:varchar(255)" json:"-"`
+	ExpiresAt     time.Time  `gorm:"not null" json:"expires_at"`
+	DownloadCount int        `gorm:"not null;default:0" json:"download_count"`
+	CreatedBy     int64      `gorm:"not null" json:"created_by"`
+	CreatedAt     time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	RevokedAt     *time.Time `json:"revoked_at,omitempty"`
+	RevokedBy     *int64     `json:"revoked_by,omitempty"`
+
+	// Relations
+	File    WorkspaceFile `gorm:"foreignKey:FileID" json:"file,omitempty"`
+	Creator User          `gorm:"foreignKey:CreatedBy" json:"creator,omitempty"`
+}
+
+func (FileShareLink) TableName() string {
+	return "file_share_links"
+}
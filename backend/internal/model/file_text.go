@@ -0,0 +1,39 @@
+package model
+
+import (
+	"time"
+)
+
+// FileTextStatus 문서 본문 추출 상태
+type FileTextStatus string
+
+const (
+	FileTextPending   FileTextStatus = "PENDING" // 추출 중 (OCR 작업 완료 대기 포함)
+	FileTextCompleted FileTextStatus = "COMPLETED"
+	FileTextFailed    FileTextStatus = "FAILED"
+)
+
+func (s FileTextStatus) String() string {
+	return string(s)
+}
+
+// FileText 업로드 문서에서 추출한 본문 (파일 검색과 AI 어시스턴트의 문서 참조용)
+// 텍스트/오피스 문서는 서버에서 파싱하고, PDF/이미지는 Amazon Textract로 OCR
+type FileText struct {
+	FileID      int64      `gorm:"primaryKey;autoIncrement:false" json:"file_id"`
+	WorkspaceID int64      `gorm:"not null;index" json:"workspace_id"`
+	Status      string     `gorm:"type:varchar(20);not null;default:'PENDING'" json:"status"`
+	Method      string     `gorm:"type:varchar(20);not null" json:"method"` // PARSER, TEXTRACT
+	JobID       *string    `gorm:"type:varchar(100)" json:"-"`              // Textract 작업 ID
+	Region      string     `gorm:"type:varchar(30)" json:"-"`               // 버킷(= Textract) 리전
+	Content     string     `gorm:"type:text;not null;default:''" json:"-"`
+	Pages       int        `gorm:"not null;default:0" json:"pages"` // OCR한 페이지 수
+	Error       *string    `gorm:"type:text" json:"error,omitempty"`
+	CreatedAt   time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+func (FileText) TableName() string {
+	return "file_texts"
+}
@@ -0,0 +1,39 @@
+package model
+
+import (
+	"time"
+)
+
+// FileTranscriptionStatus 업로드 파일 전사 작업 상태
+type FileTranscriptionStatus string
+
+const (
+	FileTranscriptionRunning   FileTranscriptionStatus = "RUNNING"
+	FileTranscriptionCompleted FileTranscriptionStatus = "COMPLETED"
+	FileTranscriptionFailed    FileTranscriptionStatus = "FAILED"
+)
+
+func (s FileTranscriptionStatus) String() string {
+	return string(s)
+}
+
+// FileTranscriptionJob 업로드된 오디오/비디오 파일의 Amazon Transcribe 배치 작업
+// 완료되면 결과를 voice_records(related_file_id)로 저장하고 업로더에게 알림
+type FileTranscriptionJob struct {
+	ID           int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	WorkspaceID  int64      `gorm:"not null;index" json:"workspace_id"`
+	FileID       int64      `gorm:"not null;index" json:"file_id"`
+	RequestedBy  int64      `gorm:"not null" json:"requested_by"`
+	JobName      string     `gorm:"type:varchar(200);not null;uniqueIndex" json:"-"` // Transcribe 작업 이름
+	Region       string     `gorm:"type:varchar(30);not null" json:"-"`              // 버킷(= Transcribe) 리전
+	Status       string     `gorm:"type:varchar(20);not null;default:'RUNNING'" json:"status"`
+	LanguageCode *string    `gorm:"type:varchar(10)" json:"language_code,omitempty"` // 지정/감지된 언어 (ko, en ...)
+	RecordCount  int        `gorm:"not null;default:0" json:"record_count"`
+	Error        *string    `gorm:"type:text" json:"error,omitempty"`
+	CreatedAt    time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	CompletedAt  *time.Time `json:"completed_at,omitempty"`
+}
+
+func (FileTranscriptionJob) TableName() string {
+	return "file_transcription_jobs"
+}
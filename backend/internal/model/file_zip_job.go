@@ -0,0 +1,40 @@
+package model
+
+import (
+	"time"
+)
+
+// FileZipJobStatus 폴더 ZIP 작업 상태
+type FileZipJobStatus string
+
+const (
+	FileZipJobPending   FileZipJobStatus = "PENDING"
+	FileZipJobRunning   FileZipJobStatus = "RUNNING"
+	FileZipJobCompleted FileZipJobStatus = "COMPLETED"
+	FileZipJobFailed    FileZipJobStatus = "FAILED"
+)
+
+func (s FileZipJobStatus) String() string {
+	return string(s)
+}
+
+// FileZipJob 대용량 폴더 ZIP 백그라운드 작업 (완료 시 S3에 업로드 후 알림)
+type FileZipJob struct {
+	ID          int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	WorkspaceID int64      `gorm:"not null;index" json:"workspace_id"`
+	FolderID    int64      `gorm:"not null" json:"folder_id"`
+	RequestedBy int64      `gorm:"not null" json:"requested_by"`
+	Status      string     `gorm:"type:varchar(20);not null;default:'PENDING'" json:"status"`
+	TotalFiles  int        `gorm:"not null;default:0" json:"total_files"`
+	DoneFiles   int        `gorm:"not null;default:0" json:"done_files"`
+	TotalBytes  int64      `gorm:"not null;default:0" json:"total_bytes"`
+	DoneBytes   int64      `gorm:"not null;default:0" json:"done_bytes"`
+	S3Key       *string    `gorm:"type:varchar(500)" json:"-"` // 완성된 ZIP 객체 키
+	Error       *string    `gorm:"type:text" json:"error,omitempty"`
+	CreatedAt   time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+func (FileZipJob) TableName() string {
+	return "file_zip_jobs"
+}
@@ -0,0 +1,21 @@
+package model
+
+import (
+	"time"
+)
+
+// FolderPermission 폴더 접근 제한 규칙 (역할 또는 특정 멤버 허용)
+// 규칙이 하나도 없는 폴더는 모든 멤버에게 열려 있고, 제한은 하위 항목 전체에 적용됨
+type FolderPermission struct {
+	ID          int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	WorkspaceID int64     `gorm:"not null;index" json:"workspace_id"`
+	FolderID    int64     `gorm:"not null;index" json:"folder_id"`
+	RoleID      *int64    `json:"role_id,omitempty"` // 이 역할의 멤버 허용
+	UserID      *int64    `json:"user_id,omitempty"` // 이 멤버 허용
+	CreatedBy   int64     `gorm:"not null" json:"created_by"`
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (FolderPermission) TableName() string {
+	return "folder_permissions"
+}
@@ -0,0 +1,22 @@
+package model
+
+import (
+	"time"
+)
+
+// FolderWatch 폴더 구독 (하위 파일 추가/이름 변경/삭제 시 알림)
+// WebhookURL을 지정하면 같은 이벤트를 외부 URL로도 전송 (WebhookSecret으로 HMAC 서명)
+type FolderWatch struct {
+	ID            int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	WorkspaceID   int64     `gorm:"not null;index" json:"workspace_id"`
+	FolderID      int64     `gorm:"not null;uniqueIndex:idx_folder_watch_user" json:"folder_id"`
+	UserID        int64     `gorm:"not null;uniqueIndex:idx_folder_watch_user" json:"user_id"`
+	WebhookURL    *string   `gorm:"type:varchar(2048)" json:"webhook_url,omitempty"`
+	WebhookSecret *string   `gorm:"type:varchar(64)" json:"-"`
+	CreatedAt     time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt     time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (FolderWatch) TableName() string {
+	return "folder_watches"
+}
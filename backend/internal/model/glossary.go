@@ -0,0 +1,42 @@
+package model
+
+import (
+	"time"
+)
+
+// GlossaryTerm 워크스페이스 용어집 항목 (언어 쌍별 선호 번역)
+type GlossaryTerm struct {
+	ID          int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	WorkspaceID int64     `gorm:"not null;uniqueIndex:idx_glossary_term" json:"workspace_id"`
+	SourceLang  string    `gorm:"type:varchar(10);not null;uniqueIndex:idx_glossary_term" json:"source_lang"`
+	TargetLang  string    `gorm:"type:varchar(10);not null;uniqueIndex:idx_glossary_term" json:"target_lang"`
+	SourceTerm  string    `gorm:"type:varchar(200);not null;uniqueIndex:idx_glossary_term" json:"source_term"`
+	TargetTerm  string    `gorm:"type:varchar(200);not null" json:"target_term"`
+	CreatedBy   *int64    `json:"created_by,omitempty"`
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+
+	// Relations
+	Creator *User `gorm:"foreignKey:CreatedBy" json:"creator,omitempty"`
+}
+
+func (GlossaryTerm) TableName() string {
+	return "glossary_terms"
+}
+
+// GlossaryTerminology 언어 쌍별 Amazon Translate 사용자 지정 용어 동기화 상태
+type GlossaryTerminology struct {
+	ID              int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	WorkspaceID     int64      `gorm:"not null;uniqueIndex:idx_glossary_pair" json:"workspace_id"`
+	SourceLang      string     `gorm:"type:varchar(10);not null;uniqueIndex:idx_glossary_pair" json:"source_lang"`
+	TargetLang      string     `gorm:"type:varchar(10);not null;uniqueIndex:idx_glossary_pair" json:"target_lang"`
+	TerminologyName string     `gorm:"type:varchar(100);not null" json:"terminology_name"`
+	TermCount       int        `gorm:"default:0" json:"term_count"`
+	SyncedAt        *time.Time `json:"synced_at,omitempty"`
+	LastError       *string    `gorm:"type:text" json:"last_error,omitempty"`
+	UpdatedAt       time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (GlossaryTerminology) TableName() string {
+	return "glossary_terminologies"
+}
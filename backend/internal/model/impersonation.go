@@ -0,0 +1,48 @@
+package model
+
+import (
+	"time"
+)
+
+// ImpersonationSession 관리자 대리 접속 세션 (지원용)
+type ImpersonationSession struct {
+	ID           int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	AdminID      int64      `gorm:"not null;index" json:"admin_id"`
+	TargetUserID int64      `gorm:"not null;index" json:"target_user_id"`
+	Reason       string     `gorm:"type:text;not null" json:"reason"`
+	ReadOnly     bool       `gorm:"default:true" json:"read_only"`
+	ExpiresAt    time.Time  `gorm:"not null" json:"expires_at"`
+	EndedAt      *time.Time `json:"ended_at,omitempty"`
+	IPAddress    *string    `gorm:"type:varchar(64)" json:"ip_address,omitempty"`
+	CreatedAt    time.Time  `gorm:"autoCreateTime" json:"created_at"`
+
+	// Relations
+	Admin      User `gorm:"foreignKey:AdminID" json:"admin,omitempty"`
+	TargetUser User `gorm:"foreignKey:TargetUserID" json:"target_user,omitempty"`
+}
+
+func (ImpersonationSession) TableName() string {
+	return "impersonation_sessions"
+}
+
+// IsActive 종료되지 않았고 만료 전인 세션인지
+func (s *ImpersonationSession) IsActive() bool {
+	return s.EndedAt == nil && time.Now().Before(s.ExpiresAt)
+}
+
+// ImpersonationAuditLog 대리 접속 감사 기록
+type ImpersonationAuditLog struct {
+	ID           int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	SessionID    int64     `gorm:"not null;index" json:"session_id"`
+	AdminID      int64     `gorm:"not null" json:"admin_id"`
+	TargetUserID int64     `gorm:"not null" json:"target_user_id"`
+	Action       string    `gorm:"type:varchar(30);not null" json:"action"` // START, REQUEST, BLOCKED, END
+	Method       *string   `gorm:"type:varchar(10)" json:"method,omitempty"`
+	Path         *string   `gorm:"type:varchar(500)" json:"path,omitempty"`
+	StatusCode   *int      `json:"status_code,omitempty"`
+	CreatedAt    time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (ImpersonationAuditLog) TableName() string {
+	return "impersonation_audit_logs"
+}
@@ -0,0 +1,22 @@
+package model
+
+import (
+	"time"
+)
+
+// MeetingMinutes 회의 음성 기록으로 만든 회의록 파일
+// 방이 닫힐 때 자동으로 만들거나 요청 시 생성 (파일은 workspace_files에 저장)
+type MeetingMinutes struct {
+	ID           int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	MeetingID    int64      `gorm:"not null;index" json:"meeting_id"`
+	FileID       int64      `gorm:"not null;index" json:"file_id"`
+	Format       string     `gorm:"type:varchar(20);not null" json:"format"` // markdown, pdf
+	RecordCount  int        `gorm:"not null;default:0" json:"record_count"`
+	LastRecordAt *time.Time `json:"last_record_at,omitempty"` // 포함한 마지막 발언 시각 (자동 생성은 이후 발언이 있을 때만)
+	CreatedBy    *int64     `json:"created_by,omitempty"`     // nil = 방 종료 시 자동 생성
+	CreatedAt    time.Time  `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (MeetingMinutes) TableName() string {
+	return "meeting_minutes"
+}
@@ -4,17 +4,36 @@ import (
 	"time"
 )
 
+// 초대 알림 만료 사유
+const (
+	InviteExpiredRevoked = "REVOKED" // 관리자가 초대 취소 또는 멤버 추방
+	InviteExpiredTTL     = "EXPIRED" // 유효 기간이 지남
+)
+
 // Notification 알림
 type Notification struct {
-	ID          int64     `gorm:"primaryKey;autoIncrement" json:"id"`
-	ReceiverID  int64     `gorm:"not null" json:"receiver_id"`
-	SenderID    *int64    `json:"sender_id,omitempty"`                   // 시스템 알림이면 NULL
-	Type        string    `gorm:"type:varchar(50);not null" json:"type"` // WORKSPACE_INVITE, MEETING_ALERT, COMMENT_MENTION
-	Content     string    `gorm:"type:text;not null" json:"content"`
-	IsRead      bool      `gorm:"default:false" json:"is_read"`
-	RelatedType *string   `gorm:"type:varchar(50)" json:"related_type,omitempty"` // WORKSPACE, MEETING
-	RelatedID   *int64    `json:"related_id,omitempty"`
-	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
+	ID          int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	ReceiverID  int64      `gorm:"not null;index:idx_notifications_receiver,priority:1;index:idx_notifications_group,priority:1" json:"receiver_id"`
+	SenderID    *int64     `json:"sender_id,omitempty"`                   // 시스템 알림이면 NULL
+	Type        string     `gorm:"type:varchar(50);not null" json:"type"` // WORKSPACE_INVITE, MEETING_ALERT, COMMENT_MENTION
+	Content     string     `gorm:"type:text;not null" json:"content"`
+	IsRead      bool       `gorm:"default:false;index:idx_notifications_receiver,priority:2" json:"is_read"`
+	RelatedType *string    `gorm:"type:varchar(50)" json:"related_type,omitempty"` // WORKSPACE, MEETING
+	RelatedID   *int64     `json:"related_id,omitempty"`
+	CreatedAt   time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	ExpiredAt   *time.Time `json:"expired_at,omitempty"` // 초대 취소 등으로 더 이상 수락/거절할 수 없게 된 시각
+
+	// 초대 알림 유효 기간: expires_at이 지나면 수락할 수 없고 정리 작업이 만료 처리
+	ExpiresAt     *time.Time `json:"expires_at,omitempty"`
+	ExpiredReason *string    `gorm:"type:varchar(20)" json:"expired_reason,omitempty"` // REVOKED, EXPIRED
+
+	// 묶음 알림: 같은 group_key의 읽지 않은 알림이 있으면 새로 만들지 않고 개수만 올림
+	GroupKey   *string   `gorm:"type:varchar(150);index:idx_notifications_group,priority:2" json:"group_key,omitempty"`
+	GroupCount int       `gorm:"not null;default:1" json:"group_count"`
+	UpdatedAt  time.Time `gorm:"autoUpdateTime" json:"updated_at"` // 마지막으로 묶인 시각
+
+	// 실행 가능한 알림의 구조화 정보 (딥 링크, 주 동작) JSON
+	Data *string `gorm:"type:jsonb" json:"data,omitempty"`
 
 	// Relations
 	Receiver User  `gorm:"foreignKey:ReceiverID" json:"receiver,omitempty"`
@@ -0,0 +1,34 @@
+package model
+
+import (
+	"time"
+)
+
+// NotificationSettings 사용자별 알림 방해 금지 설정 (행이 없으면 방해 금지 시간 없음)
+// 방해 금지 중에 온 알림은 저장만 하고, HeldSince부터 쌓인 알림은 다시 활성화될 때 요약으로 전달
+type NotificationSettings struct {
+	UserID            int64      `gorm:"primaryKey" json:"user_id"`
+	QuietHoursEnabled bool       `gorm:"not null;default:false" json:"quiet_hours_enabled"`
+	QuietStart        int        `gorm:"not null" json:"quiet_start"`       // 시작 (자정부터 분, 0-1439)
+	QuietEnd          int        `gorm:"not null" json:"quiet_end"`         // 끝 (시작보다 작으면 자정을 넘김)
+	TimeZone          string     `gorm:"type:varchar(64)" json:"time_zone"` // 빈 값이면 서버 기본 타임존
+	HeldSince         *time.Time `gorm:"index" json:"held_since,omitempty"` // 전달을 보류한 첫 알림 시각 (요약 후 nil)
+	UpdatedAt         time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (NotificationSettings) TableName() string {
+	return "notification_settings"
+}
+
+// InQuietHours t가 방해 금지 시간 안인지 (시작과 끝이 같으면 항상 아님)
+func (s *NotificationSettings) InQuietHours(t time.Time, loc *time.Location) bool {
+	if !s.QuietHoursEnabled || s.QuietStart == s.QuietEnd {
+		return false
+	}
+	local := t.In(loc)
+	minute := local.Hour()*60 + local.Minute()
+	if s.QuietStart < s.QuietEnd {
+		return minute >= s.QuietStart && minute < s.QuietEnd
+	}
+	return minute >= s.QuietStart || minute < s.QuietEnd
+}
@@ -0,0 +1,131 @@
+package model
+
+import "slices"
+
+// 권한 카테고리 (역할 편집 UI 그룹)
+const (
+	PermissionCategoryGeneral   = "GENERAL"
+	PermissionCategoryMembers   = "MEMBERS"
+	PermissionCategoryChannels  = "CHANNELS"
+	PermissionCategoryResources = "RESOURCES"
+)
+
+// PermissionCategoryInfo 권한 카테고리 정보
+type PermissionCategoryInfo struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+}
+
+// PermissionInfo 권한 코드 설명
+type PermissionInfo struct {
+	Code          string `json:"code"`
+	Name          string `json:"name"`
+	Description   string `json:"description"`
+	Category      string `json:"category"`
+	ChannelScoped bool   `json:"channel_scoped"` // 채팅방/회의 단위 오버라이드 가능 여부
+}
+
+// PermissionCategories 카테고리 표시 순서
+var PermissionCategories = []PermissionCategoryInfo{
+	{Code: PermissionCategoryGeneral, Name: "일반"},
+	{Code: PermissionCategoryMembers, Name: "멤버"},
+	{Code: PermissionCategoryChannels, Name: "채널"},
+	{Code: PermissionCategoryResources, Name: "리소스"},
+}
+
+// permissionCatalog 서버가 인식하는 전체 권한 (표시 순서대로)
+var permissionCatalog = []PermissionInfo{
+	{
+		Code:        PermissionAdmin,
+		Name:        "관리자",
+		Description: "워크스페이스의 모든 권한을 가지며 채널 오버라이드의 영향을 받지 않습니다.",
+		Category:    PermissionCategoryGeneral,
+	},
+	{
+		Code:        PermissionManageMembers,
+		Name:        "멤버 관리",
+		Description: "멤버 초대, 내보내기, 가입 요청 승인을 할 수 있습니다.",
+		Category:    PermissionCategoryMembers,
+	},
+	{
+		Code:        PermissionManageRoles,
+		Name:        "역할 관리",
+		Description: "역할을 만들고 수정하며 채널별 권한을 설정할 수 있습니다.",
+		Category:    PermissionCategoryMembers,
+	},
+	{
+		Code:        PermissionManageChannels,
+		Name:        "채널 관리",
+		Description: "채팅방을 만들고 이름 변경, 삭제를 할 수 있습니다.",
+		Category:    PermissionCategoryChannels,
+	},
+	{
+		Code:        PermissionSendMessages,
+		Name:        "메시지 보내기",
+		Description: "채팅방에 메시지를 보낼 수 있습니다.",
+		Category:    PermissionCategoryChannels,
+	},
+	{
+		Code:        PermissionMentionRoles,
+		Name:        "역할 멘션",
+		Description: "메시지에서 역할을 멘션해 그 역할의 모든 멤버에게 알림을 보낼 수 있습니다.",
+		Category:    PermissionCategoryChannels,
+	},
+	{
+		Code:        PermissionConnectMedia,
+		Name:        "음성/화상 연결",
+		Description: "회의에 음성과 화상으로 참여할 수 있습니다.",
+		Category:    PermissionCategoryChannels,
+	},
+	{
+		Code:        PermissionDrawWhiteboard,
+		Name:        "화이트보드 그리기",
+		Description: "회의와 워크스페이스 화이트보드에 그리고 도형, 텍스트, 이미지를 편집할 수 있습니다. 없으면 보기만 가능합니다.",
+		Category:    PermissionCategoryChannels,
+	},
+	{
+		Code:        PermissionManageFiles,
+		Name:        "파일 관리",
+		Description: "다른 멤버의 파일과 폴더를 관리하고 폴더 권한을 설정할 수 있습니다.",
+		Category:    PermissionCategoryResources,
+	},
+	{
+		Code:        PermissionManageGlossary,
+		Name:        "용어집 관리",
+		Description: "번역 용어집 항목을 추가, 수정, 삭제할 수 있습니다.",
+		Category:    PermissionCategoryResources,
+	},
+	{
+		Code:        PermissionManageEvents,
+		Name:        "일정 관리",
+		Description: "다른 멤버가 만든 캘린더 일정을 수정, 삭제하고 공동 주최자를 지정할 수 있습니다.",
+		Category:    PermissionCategoryResources,
+	},
+}
+
+var permissionsByCode = func() map[string]PermissionInfo {
+	m := make(map[string]PermissionInfo, len(permissionCatalog))
+	for i := range permissionCatalog {
+		p := &permissionCatalog[i]
+		p.ChannelScoped = slices.Contains(ChannelPermissionCodes, p.Code)
+		m[p.Code] = *p
+	}
+	return m
+}()
+
+// AllPermissions 전체 권한 목록 (표시 순서)
+func AllPermissions() []PermissionInfo {
+	return slices.Clone(permissionCatalog)
+}
+
+// LookupPermission 권한 코드 정보 조회
+func LookupPermission(code string) (PermissionInfo, bool) {
+	p, ok := permissionsByCode[code]
+	return p, ok
+}
+
+// IsKnownPermission 등록된 권한 코드인지 여부
+func IsKnownPermission(code string) bool {
+	_, ok := permissionsByCode[code]
+	return ok
+}
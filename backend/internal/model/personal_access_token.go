@@ -0,0 +1,37 @@
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+// PersonalAccessToken 스크립트/봇용 개인 액세스 토큰 (원문은 발급 시 한 번만 보여 주고 해시만 저장)
+type PersonalAccessToken struct {
+	ID          int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID      int64      `gorm:"not null;index" json:"user_id"`
+	Name        string     `gorm:"type:varchar(100);not null" json:"name"`
+	TokenPrefix string     `gorm:"type:varchar(16);not null" json:"token_prefix"` // 목록에서 구분용 앞부분
+	TokenHash   string     `gorm:"type:varchar(64);not null;uniqueIndex" json:"-"`
+	Scopes      string     `gorm:"type:varchar(100);not null" json:"-"` // 쉼표로 구분 (read,write)
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`                // nil이면 만료 없음
+	LastUsedAt  *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt   *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt   time.Time  `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (PersonalAccessToken) TableName() string {
+	return "personal_access_tokens"
+}
+
+// ScopeList 권한 범위 목록
+func (t *PersonalAccessToken) ScopeList() []string {
+	if t.Scopes == "" {
+		return nil
+	}
+	return strings.Split(t.Scopes, ",")
+}
+
+// IsActive 취소되지 않았고 만료 전인지
+func (t *PersonalAccessToken) IsActive() bool {
+	return t.RevokedAt == nil && (t.ExpiresAt == nil || time.Now().Before(*t.ExpiresAt))
+}
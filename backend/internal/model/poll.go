@@ -0,0 +1,44 @@
+package model
+
+import (
+	"time"
+)
+
+// Poll 미팅/채팅방 투표
+// 집계의 원본은 PollVote 이며 Redis 는 실시간 집계 캐시로만 사용
+type Poll struct {
+	ID          int64      `gorm:"primaryKey;autoIncrement" json:"-"`
+	PublicID    string     `gorm:"size:40;uniqueIndex;not null" json:"id"` // API 에서 쓰는 "poll-{랜덤 hex}" 식별자
+	MeetingID   int64      `gorm:"not null;index" json:"meeting_id"`
+	Question    string     `gorm:"size:300;not null" json:"question"`
+	Options     string     `gorm:"type:jsonb;not null" json:"-"` // 선택지 문자열 배열
+	IsAnonymous bool       `gorm:"default:false" json:"is_anonymous"`
+	CreatedBy   int64      `gorm:"not null" json:"created_by"`
+	ExpiresAt   *time.Time `gorm:"index" json:"expires_at,omitempty"` // nil 이면 직접 마감할 때까지 진행
+	ClosedAt    *time.Time `json:"closed_at,omitempty"`
+	CreatedAt   time.Time  `gorm:"autoCreateTime" json:"created_at"`
+
+	// Relations
+	Meeting *Meeting `gorm:"foreignKey:MeetingID" json:"meeting,omitempty"`
+	Creator *User    `gorm:"foreignKey:CreatedBy" json:"creator,omitempty"`
+}
+
+func (Poll) TableName() string {
+	return "polls"
+}
+
+// PollVote 투표 참여 기록 (사용자당 한 표, 복합 기본키로 중복 투표 방지)
+type PollVote struct {
+	PollID      int64     `gorm:"primaryKey" json:"poll_id"`
+	UserID      int64     `gorm:"primaryKey" json:"user_id"`
+	OptionIndex int       `gorm:"not null" json:"option_index"`
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
+
+	// Relations
+	Poll *Poll `gorm:"foreignKey:PollID;constraint:OnDelete:CASCADE" json:"-"`
+	User *User `gorm:"foreignKey:UserID" json:"user,omitempty"`
+}
+
+func (PollVote) TableName() string {
+	return "poll_votes"
+}
@@ -0,0 +1,24 @@
+package model
+
+import (
+	"time"
+)
+
+// PushDevice 푸시 알림을 받을 기기 (앱 FCM 토큰 또는 브라우저 Web Push 구독)
+// 같은 토큰을 다른 사용자가 등록하면 그 사용자에게 옮김 (공용 브라우저 재로그인)
+type PushDevice struct {
+	ID           int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID       int64      `gorm:"not null;index" json:"user_id"`
+	Platform     string     `gorm:"type:varchar(10);not null" json:"platform"` // FCM, WEBPUSH
+	Token        string     `gorm:"type:text;not null;uniqueIndex" json:"-"`   // FCM 등록 토큰 또는 Web Push 엔드포인트
+	P256dh       *string    `gorm:"type:varchar(255)" json:"-"`
+	Auth         *string    `gorm:"type:varchar(255)" json:"-"`
+	UserAgent    *string    `gorm:"type:varchar(255)" json:"user_agent,omitempty"`
+	FailureCount int        `gorm:"not null;default:0" json:"-"` // 연속 전송 실패 (일정 횟수 넘으면 삭제)
+	LastUsedAt   *time.Time `json:"last_used_at,omitempty"`
+	CreatedAt    time.Time  `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (PushDevice) TableName() string {
+	return "push_devices"
+}
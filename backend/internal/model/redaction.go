@@ -0,0 +1,19 @@
+package model
+
+import (
+	"time"
+)
+
+// RedactionSetting 워크스페이스 음성 파이프라인 비속어/개인정보 가림 설정
+type RedactionSetting struct {
+	WorkspaceID int64     `gorm:"primaryKey" json:"workspace_id"`
+	Mode        string    `gorm:"type:varchar(10);not null;default:'OFF'" json:"mode"` // OFF, MASK, DROP
+	RedactPII   bool      `gorm:"default:false" json:"redact_pii"`
+	DenyList    string    `gorm:"type:text;not null;default:''" json:"-"` // 줄바꿈으로 구분한 금칙어 목록
+	UpdatedBy   *int64    `json:"updated_by,omitempty"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (RedactionSetting) TableName() string {
+	return "workspace_redaction_settings"
+}
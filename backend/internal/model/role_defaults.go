@@ -0,0 +1,76 @@
+package model
+
+// 권한 코드 (role_permissions.permission_code)
+const (
+	PermissionAdmin          = "ADMIN" // 모든 권한 (소유자와 동일하게 취급)
+	PermissionManageMembers  = "MANAGE_MEMBERS"
+	PermissionManageRoles    = "MANAGE_ROLES"
+	PermissionManageChannels = "MANAGE_CHANNELS"
+	PermissionManageFiles    = "MANAGE_FILES"
+	PermissionManageGlossary = "MANAGE_GLOSSARY"
+	PermissionSendMessages   = "SEND_MESSAGES"
+	PermissionConnectMedia   = "CONNECT_MEDIA"
+	PermissionMentionRoles   = "MENTION_ROLES"   // 메시지에서 역할 멘션으로 해당 역할 멤버 전체에게 알림
+	PermissionManageEvents   = "MANAGE_EVENTS"   // 다른 멤버가 만든 일정 수정/삭제
+	PermissionDrawWhiteboard = "DRAW_WHITEBOARD" // 화이트보드 그리기/편집 (없으면 보기만 가능)
+)
+
+// DefaultRoleTemplate 워크스페이스 생성 시 만드는 기본 역할
+type DefaultRoleTemplate struct {
+	Name        string
+	Color       string
+	IsDefault   bool // 초대/가입한 멤버에게 자동으로 주는 역할
+	IsGuest     bool
+	Permissions []string
+}
+
+// 기본 역할 이름
+const (
+	RoleNameOwner  = "Owner"
+	RoleNameAdmin  = "Admin"
+	RoleNameMember = "Member"
+	RoleNameGuest  = "Guest"
+)
+
+// GuestChannelPermissionCodes 게스트가 회의별 오버라이드로 받을 수 있는 권한 (채팅/파일 권한은 허용하지 않음)
+var GuestChannelPermissionCodes = []string{PermissionConnectMedia, PermissionDrawWhiteboard}
+
+// MemberRolePermissions 기본 Member 역할 권한 (메시지 전송, 음성/화상 접속, 화이트보드 그리기)
+var MemberRolePermissions = []string{PermissionSendMessages, PermissionConnectMedia, PermissionDrawWhiteboard}
+
+// DefaultRoleTemplates Owner/Admin/Member/Guest 기본 역할과 권한
+// Admin은 워크스페이스 삭제 등 ADMIN 전용 작업을 제외한 관리 권한만 가짐
+var DefaultRoleTemplates = []DefaultRoleTemplate{
+	{
+		Name:        RoleNameOwner,
+		Color:       "#F59E0B",
+		Permissions: []string{PermissionAdmin},
+	},
+	{
+		Name:  RoleNameAdmin,
+		Color: "#3B82F6",
+		Permissions: []string{
+			PermissionManageMembers,
+			PermissionManageRoles,
+			PermissionManageChannels,
+			PermissionManageFiles,
+			PermissionManageGlossary,
+			PermissionManageEvents,
+			PermissionSendMessages,
+			PermissionMentionRoles,
+			PermissionConnectMedia,
+			PermissionDrawWhiteboard,
+		},
+	},
+	{
+		Name:        RoleNameMember,
+		Color:       "#A3A3A3",
+		IsDefault:   true,
+		Permissions: MemberRolePermissions,
+	},
+	{
+		Name:    RoleNameGuest,
+		Color:   "#10B981",
+		IsGuest: true,
+	},
+}
@@ -0,0 +1,20 @@
+package model
+
+import (
+	"time"
+)
+
+// RoomTranslationSetting 음성 Room 번역 설정 (TTS, 부분 자막 간격, 최소 신뢰도, 허용 번역 언어)
+type RoomTranslationSetting struct {
+	RoomID             string    `gorm:"type:varchar(128);primaryKey" json:"room_id"` // "meeting-{id}" 또는 미팅 코드
+	TTSEnabled         bool      `gorm:"not null;default:true" json:"tts_enabled"`
+	PartialIntervalMs  int       `gorm:"not null;default:0" json:"partial_interval_ms"` // 0 = 제한 없음
+	MinConfidence      float64   `gorm:"not null;default:0.5" json:"min_confidence"`
+	AllowedTargetLangs string    `gorm:"type:text;not null;default:''" json:"-"` // 쉼표로 구분한 언어 코드 ("" = 전체 허용)
+	UpdatedBy          *int64    `json:"updated_by,omitempty"`
+	UpdatedAt          time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (RoomTranslationSetting) TableName() string {
+	return "room_translation_settings"
+}
@@ -0,0 +1,35 @@
+package model
+
+import (
+	"time"
+)
+
+// IncidentSeverity 장애 심각도 (공개 상태 페이지의 컴포넌트 상태에 반영)
+type IncidentSeverity string
+
+const (
+	IncidentMinor       IncidentSeverity = "MINOR"       // 일부 기능 저하
+	IncidentMajor       IncidentSeverity = "MAJOR"       // 주요 기능 장애
+	IncidentMaintenance IncidentSeverity = "MAINTENANCE" // 예정된 점검
+)
+
+func (s IncidentSeverity) String() string {
+	return string(s)
+}
+
+// StatusIncident 상태 페이지 장애 공지 (플랫폼 관리자가 등록, 해결 시 ResolvedAt 기록)
+type StatusIncident struct {
+	ID         int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	Title      string     `gorm:"type:varchar(200);not null" json:"title"`
+	Message    string     `gorm:"type:text;not null;default:''" json:"message"`
+	Severity   string     `gorm:"type:varchar(20);not null" json:"severity"`
+	Components string     `gorm:"type:varchar(255);not null;default:''" json:"components"` // 쉼표 구분 (api,realtime,translation,storage)
+	ResolvedAt *time.Time `gorm:"index" json:"resolved_at,omitempty"`
+	CreatedBy  int64      `gorm:"not null" json:"created_by"`
+	CreatedAt  time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt  time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (StatusIncident) TableName() string {
+	return "status_incidents"
+}
@@ -0,0 +1,19 @@
+package model
+
+import (
+	"time"
+)
+
+// WorkspaceStorageQuota 워크스페이스 저장 용량 한도와 사용량
+// UsedBytes는 S3 객체 기준 (중복 연결한 파일은 한 번만, 휴지통 항목은 영구 삭제 전까지 포함)
+type WorkspaceStorageQuota struct {
+	WorkspaceID int64     `gorm:"primaryKey" json:"workspace_id"`
+	QuotaBytes  *int64    `json:"quota_bytes,omitempty"` // nil = 서버 기본값, 0 = 제한 없음
+	UsedBytes   int64     `gorm:"not null;default:0" json:"used_bytes"`
+	UpdatedBy   *int64    `json:"updated_by,omitempty"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (WorkspaceStorageQuota) TableName() string {
+	return "workspace_storage_quotas"
+}
@@ -0,0 +1,37 @@
+package model
+
+import "time"
+
+// UserTwoFactor 사용자 TOTP 2단계 인증 (EnabledAt이 nil이면 등록 중 - 인증 앱 코드 확인 전)
+type UserTwoFactor struct {
+	UserID       int64      `gorm:"primaryKey" json:"user_id"`
+	Secret       string     `gorm:"type:varchar(64);not null" json:"-"`
+	EnabledAt    *time.Time `json:"enabled_at,omitempty"`
+	LastUsedStep int64      `gorm:"not null;default:0" json:"-"` // 마지막으로 쓴 TOTP 시간 구간 (같은 코드 재사용 방지)
+	FailedCount  int        `gorm:"not null;default:0" json:"-"` // 로그인 2단계 인증 연속 실패 횟수
+	LockedUntil  *time.Time `json:"-"`                           // 실패 한도 도달 시 로그인 2단계 인증 잠금 해제 시각
+	CreatedAt    time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt    time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (UserTwoFactor) TableName() string {
+	return "user_two_factors"
+}
+
+// IsEnabled 등록이 끝나 로그인에 적용되는지
+func (t *UserTwoFactor) IsEnabled() bool {
+	return t.EnabledAt != nil
+}
+
+// TwoFactorBackupCode 인증 앱을 쓸 수 없을 때의 일회용 백업 코드 (해시만 저장)
+type TwoFactorBackupCode struct {
+	ID        int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID    int64      `gorm:"not null;index" json:"user_id"`
+	CodeHash  string     `gorm:"type:varchar(64);not null" json:"-"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	CreatedAt time.Time  `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (TwoFactorBackupCode) TableName() string {
+	return "two_factor_backup_codes"
+}
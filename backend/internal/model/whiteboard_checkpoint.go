@@ -0,0 +1,34 @@
+package model
+
+import (
+	"time"
+)
+
+// 화이트보드 체크포인트 종류
+const (
+	WhiteboardCheckpointManual = "MANUAL" // 사용자가 이름을 붙여 저장
+	WhiteboardCheckpointTrash  = "TRASH"  // 복원으로 지워진 보드 내용 (ExpiresAt 이후 영구 삭제)
+)
+
+// WhiteboardCheckpoint 화이트보드 체크포인트 (저장 시점의 보드 내용 전체)
+// Data 는 스냅샷 묶음과 남아 있는 획/객체 행 (실행 취소된 항목은 제외)
+type WhiteboardCheckpoint struct {
+	ID           int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	MeetingID    *int64     `gorm:"index" json:"meeting_id,omitempty"`
+	WhiteboardID *int64     `gorm:"index" json:"whiteboard_id,omitempty"`
+	Kind         string     `gorm:"size:10;not null;default:'MANUAL'" json:"kind"`
+	Name         string     `gorm:"size:100;not null" json:"name"`
+	Data         string     `gorm:"type:jsonb;not null" json:"-"`
+	ItemCount    int        `gorm:"not null;default:0" json:"item_count"` // 획/객체 수 (스냅샷에 묶인 획 포함)
+	CreatedBy    *int64     `json:"created_by,omitempty"`
+	ExpiresAt    *time.Time `gorm:"index" json:"expires_at,omitempty"` // TRASH 만
+	CreatedAt    time.Time  `gorm:"autoCreateTime" json:"created_at"`
+
+	// Relations
+	Meeting    *Meeting    `gorm:"foreignKey:MeetingID" json:"meeting,omitempty"`
+	Whiteboard *Whiteboard `gorm:"foreignKey:WhiteboardID" json:"whiteboard,omitempty"`
+}
+
+func (WhiteboardCheckpoint) TableName() string {
+	return "whiteboard_checkpoints"
+}
@@ -4,20 +4,36 @@ import (
 	"time"
 )
 
-// WhiteboardStroke 화이트보드 획 데이터
+// 화이트보드 객체 종류 (stroke 외에는 object_id 로 수정/삭제 가능)
+const (
+	WhiteboardObjectStroke  = "stroke"
+	WhiteboardObjectRect    = "rect"
+	WhiteboardObjectEllipse = "ellipse"
+	WhiteboardObjectArrow   = "arrow"
+	WhiteboardObjectText    = "text"
+	WhiteboardObjectImage   = "image"
+)
+
+// WhiteboardStroke 화이트보드 획/객체 데이터
+// WhiteboardID 가 nil 이면 미팅 기본 보드, 있으면 이름 있는 보드(워크스페이스 보드는 MeetingID 없음)
+// 스냅샷으로 묶이는 것은 stroke 뿐이고, 도형/텍스트/이미지는 수정·삭제를 위해 행으로 남음
 type WhiteboardStroke struct {
-	ID         int64      `gorm:"primaryKey;autoIncrement" json:"id"`
-	MeetingID  int64      `gorm:"not null;index:idx_meeting_created" json:"meeting_id"`
-	UserID     int64      `gorm:"not null" json:"user_id"`
-	StrokeData string     `gorm:"type:jsonb;not null" json:"stroke_data"` // JSON array of points
-	Layer      int        `gorm:"default:0" json:"layer"`
-	IsDeleted  bool       `gorm:"default:false;index" json:"is_deleted"`
-	DeletedAt  *time.Time `json:"deleted_at,omitempty"`
-	CreatedAt  time.Time  `gorm:"autoCreateTime;index:idx_meeting_created" json:"created_at"`
+	ID           int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	MeetingID    *int64     `gorm:"index:idx_meeting_created" json:"meeting_id,omitempty"`
+	WhiteboardID *int64     `gorm:"index" json:"whiteboard_id,omitempty"`
+	UserID       int64      `gorm:"not null" json:"user_id"`
+	ObjectType   string     `gorm:"size:20;not null;default:'stroke'" json:"object_type"`
+	ObjectID     *string    `gorm:"size:64;index" json:"object_id,omitempty"` // 클라이언트가 만든 ID (stroke 는 없음)
+	StrokeData   string     `gorm:"type:jsonb;not null" json:"stroke_data"`   // JSON array of points, or the object
+	Layer        int        `gorm:"default:0" json:"layer"`
+	IsDeleted    bool       `gorm:"default:false;index" json:"is_deleted"`
+	DeletedAt    *time.Time `json:"deleted_at,omitempty"`
+	CreatedAt    time.Time  `gorm:"autoCreateTime;index:idx_meeting_created" json:"created_at"`
 
 	// Relations
-	Meeting Meeting `gorm:"foreignKey:MeetingID" json:"meeting,omitempty"`
-	User    User    `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	Meeting    *Meeting    `gorm:"foreignKey:MeetingID" json:"meeting,omitempty"`
+	Whiteboard *Whiteboard `gorm:"foreignKey:WhiteboardID" json:"whiteboard,omitempty"`
+	User       User        `gorm:"foreignKey:UserID" json:"user,omitempty"`
 }
 
 func (WhiteboardStroke) TableName() string {
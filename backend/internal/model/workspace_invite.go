@@ -0,0 +1,29 @@
+package model
+
+import (
+	"time"
+)
+
+// WorkspaceInviteLink 워크스페이스 초대 링크 (링크를 연 로그인 사용자는 바로 멤버가 됨)
+// 만료, 취소되었거나 사용 횟수를 다 쓰면 더 이상 수락할 수 없음
+type WorkspaceInviteLink struct {
+	ID          int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	WorkspaceID int64      `gorm:"not null;index" json:"workspace_id"`
+	Token       string     `gorm:"type:varchar(64);not null;uniqueIndex" json:"token"`
+	RoleID      *int64     `json:"role_id,omitempty"`  // NULL이면 워크스페이스 기본 역할
+	MaxUses     *int       `json:"max_uses,omitempty"` // NULL이면 무제한
+	UseCount    int        `gorm:"not null;default:0" json:"use_count"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"` // NULL이면 만료 없음
+	CreatedBy   int64      `gorm:"not null" json:"created_by"`
+	CreatedAt   time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	RevokedAt   *time.Time `json:"revoked_at,omitempty"`
+
+	// Relations
+	Workspace Workspace `gorm:"foreignKey:WorkspaceID" json:"workspace,omitempty"`
+	Role      *Role     `gorm:"foreignKey:RoleID" json:"role,omitempty"`
+	Creator   User      `gorm:"foreignKey:CreatedBy" json:"creator,omitempty"`
+}
+
+func (WorkspaceInviteLink) TableName() string {
+	return "workspace_invite_links"
+}
@@ -0,0 +1,27 @@
+package model
+
+import (
+	"time"
+)
+
+// WorkspaceJoinRequest 공개(discoverable) 워크스페이스 가입 요청
+// 관리자가 승인하면 ACTIVE 멤버가 되고, 거절해도 기록은 남김
+type WorkspaceJoinRequest struct {
+	ID          int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	WorkspaceID int64      `gorm:"not null;index:idx_join_requests_scope,priority:1" json:"workspace_id"`
+	UserID      int64      `gorm:"not null;index" json:"user_id"`
+	Message     *string    `gorm:"type:varchar(500)" json:"message,omitempty"`
+	Status      string     `gorm:"type:varchar(20);not null;default:'PENDING';index:idx_join_requests_scope,priority:2" json:"status"`
+	ReviewedBy  *int64     `json:"reviewed_by,omitempty"`
+	ReviewedAt  *time.Time `json:"reviewed_at,omitempty"`
+	CreatedAt   time.Time  `gorm:"autoCreateTime" json:"created_at"`
+
+	// Relations
+	Workspace Workspace `gorm:"foreignKey:WorkspaceID" json:"workspace,omitempty"`
+	User      User      `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	Reviewer  *User     `gorm:"foreignKey:ReviewedBy" json:"reviewer,omitempty"`
+}
+
+func (WorkspaceJoinRequest) TableName() string {
+	return "workspace_join_requests"
+}
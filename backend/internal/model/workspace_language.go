@@ -0,0 +1,18 @@
+package model
+
+import (
+	"time"
+)
+
+// WorkspaceLanguageSetting 워크스페이스 음성 번역 기본/허용 대상 언어 설정
+type WorkspaceLanguageSetting struct {
+	WorkspaceID        int64     `gorm:"primaryKey" json:"workspace_id"`
+	DefaultTargetLangs string    `gorm:"type:text;not null;default:''" json:"-"` // 쉼표로 구분 (리스너가 없을 때 파이프라인 기본 대상)
+	AllowedTargetLangs string    `gorm:"type:text;not null;default:''" json:"-"` // 쉼표로 구분 ("" = 전체 허용)
+	UpdatedBy          *int64    `json:"updated_by,omitempty"`
+	UpdatedAt          time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (WorkspaceLanguageSetting) TableName() string {
+	return "workspace_language_settings"
+}
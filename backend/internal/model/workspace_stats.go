@@ -0,0 +1,26 @@
+package model
+
+import (
+	"time"
+)
+
+// WorkspaceDailyStats 워크스페이스 일별 집계 (UTC 기준, 주기적인 롤업 작업이 채움)
+// 대시보드는 원본 테이블 대신 이 테이블만 조회
+type WorkspaceDailyStats struct {
+	WorkspaceID       int64     `gorm:"primaryKey" json:"workspace_id"`
+	StatDate          time.Time `gorm:"primaryKey;type:date" json:"stat_date"`
+	MessageCount      int64     `gorm:"not null;default:0" json:"message_count"`   // SYSTEM 메시지 제외
+	MeetingCount      int64     `gorm:"not null;default:0" json:"meeting_count"`   // 그날 끝난 회의
+	MeetingMinutes    float64   `gorm:"not null;default:0" json:"meeting_minutes"` // 그날 끝난 회의의 진행 시간 합
+	ActiveMembers     int64     `gorm:"not null;default:0" json:"active_members"`  // 메시지를 보냈거나 회의에 들어온 멤버 수
+	StorageAddedBytes int64     `gorm:"not null;default:0" json:"storage_added_bytes"`
+	StorageUsedBytes  *int64    `json:"storage_used_bytes,omitempty"` // 그날 마지막 롤업 시점 사용량 (지난 날짜는 다시 계산하지 않음)
+	TranscribeSeconds float64   `gorm:"not null;default:0" json:"transcribe_seconds"`
+	TranslateChars    int64     `gorm:"not null;default:0" json:"translate_chars"`
+	TTSChars          int64     `gorm:"not null;default:0" json:"tts_chars"`
+	RolledUpAt        time.Time `gorm:"not null" json:"rolled_up_at"`
+}
+
+func (WorkspaceDailyStats) TableName() string {
+	return "workspace_daily_stats"
+}
@@ -0,0 +1,96 @@
+// Package netguard 사용자가 지정한 URL(웹훅, Web Push 엔드포인트)로 보내는 요청이 내부망에 닿지 않도록 막음
+package netguard
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// ErrBlockedAddress 공인 주소가 아닌 곳으로 연결하려 함
+var ErrBlockedAddress = errors.New("destination is not a public address")
+
+// blockedNets IsPrivate/IsLoopback 등 표준 판별로 걸러지지 않는 특수 용도 대역
+var blockedNets = mustParseCIDRs(
+	"0.0.0.0/8",       // 현재 네트워크
+	"100.64.0.0/10",   // CGNAT (일부 클라우드 메타데이터 포함)
+	"192.0.0.0/24",    // IETF 프로토콜 할당
+	"192.0.2.0/24",    // 문서용
+	"198.18.0.0/15",   // 벤치마크
+	"198.51.100.0/24", // 문서용
+	"203.0.113.0/24",  // 문서용
+	"240.0.0.0/4",     // 예약 (브로드캐스트 포함)
+	"64:ff9b::/96",    // NAT64 - IPv4 내부 주소로 변환될 수 있음
+	"64:ff9b:1::/48",  // 로컬 NAT64
+	"100::/64",        // 폐기용
+	"2001:db8::/32",   // 문서용
+)
+
+// IsPublicIP 인터넷에서 라우팅되는 유니캐스트 주소인지 (사설, 루프백, 링크 로컬, 멀티캐스트, 미지정 주소는 거부)
+func IsPublicIP(ip net.IP) bool {
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4 // IPv4-mapped IPv6 (::ffff:10.0.0.1) 도 IPv4 규칙으로 판별
+	}
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return false
+	}
+	for _, n := range blockedNets {
+		if n.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// NewHTTPClient 공인 주소로만 연결하는 HTTP 클라이언트 (리다이렉트는 따라가지 않음)
+// DNS 조회 결과를 연결 직전에 확인하므로 공개 호스트명이 내부 주소로 풀리거나 DNS 리바인딩을 시도해도 거부됨
+func NewHTTPClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   timeout,
+		KeepAlive: 30 * time.Second,
+		Control:   guardDial,
+	}
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			Proxy:                 nil, // 프록시를 거치면 실제 목적지 주소를 확인할 수 없음
+			DialContext:           dialer.DialContext,
+			ForceAttemptHTTP2:     true,
+			MaxIdleConns:          100,
+			IdleConnTimeout:       90 * time.Second,
+			TLSHandshakeTimeout:   timeout,
+			ExpectContinueTimeout: time.Second,
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// guardDial 소켓 연결 직전 (DNS 조회가 끝난 실제 IP) 주소 확인
+func guardDial(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return ErrBlockedAddress
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !IsPublicIP(ip) {
+		return ErrBlockedAddress
+	}
+	return nil
+}
+
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	nets := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, n, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		nets = append(nets, n)
+	}
+	return nets
+}
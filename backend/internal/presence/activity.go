@@ -0,0 +1,168 @@
+package presence
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Activity 접속 상태와 별개로 표시하는 현재 활동 (사이드바의 "통화 중" 등)
+type Activity string
+
+const (
+	ActivityInMeeting     Activity = "IN_MEETING"
+	ActivityScreenSharing Activity = "SCREEN_SHARING"
+)
+
+// activitiesKey 활동 만료 시각 (sorted set, score = unix 초) - 만료를 발행하기 위해 사용
+const activitiesKey = "presence:activities"
+
+// ValidActivity 클라이언트가 보낼 수 있는 활동인지
+func ValidActivity(a Activity) bool {
+	return a == ActivityInMeeting || a == ActivityScreenSharing
+}
+
+// activityState 활동 키에 저장하는 값 (Room은 어느 회의에서 설정했는지 - 다른 회의를 나갈 때 지우지 않도록)
+type activityState struct {
+	Activity Activity `json:"activity"`
+	Room     string   `json:"room"`
+	Since    int64    `json:"since"`
+}
+
+func (m *Manager) getActivityKey(userID int64) string {
+	return fmt.Sprintf("presence:activity:%d", userID)
+}
+
+// clearActivityScript 같은 회의에서 설정한 활동만 삭제 (room이 빈 문자열이면 무조건)
+var clearActivityScript = redis.NewScript(`
+local raw = redis.call('GET', KEYS[1])
+if not raw then
+  return 0
+end
+if ARGV[1] ~= '' and cjson.decode(raw)['room'] ~= ARGV[1] then
+  return 0
+end
+redis.call('DEL', KEYS[1])
+redis.call('ZREM', KEYS[2], ARGV[2])
+return 1
+`)
+
+// SetActivity 활동 설정 후 발행 (ttl이 지나면 자동으로 사라짐)
+// 같은 회의에서 이미 같은 활동이면 시작 시각은 유지
+func (m *Manager) SetActivity(userID int64, activity Activity, room string, ttl time.Duration) error {
+	now := time.Now()
+	state := activityState{Activity: activity, Room: room, Since: now.Unix()}
+	if current, err := m.activity(userID); err == nil && current != nil && current.Activity == activity && current.Room == room {
+		state.Since = current.Since
+	}
+	jsonData, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	_, err = m.client.TxPipelined(m.ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(m.ctx, m.getActivityKey(userID), jsonData, ttl)
+		pipe.ZAdd(m.ctx, activitiesKey, redis.Z{Score: float64(now.Add(ttl).Unix()), Member: userID})
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return m.publishCurrent(userID)
+}
+
+// ClearActivity room에서 설정한 활동 삭제 후 발행 (room이 비어 있으면 어떤 활동이든 삭제)
+func (m *Manager) ClearActivity(userID int64, room string) error {
+	cleared, err := clearActivityScript.Run(m.ctx, m.client,
+		[]string{m.getActivityKey(userID), activitiesKey},
+		room, userID,
+	).Int()
+	if err != nil || cleared == 0 {
+		return err
+	}
+	return m.publishCurrent(userID)
+}
+
+// activity 현재 활동 (없으면 nil)
+func (m *Manager) activity(userID int64) (*activityState, error) {
+	val, err := m.client.Get(m.ctx, m.getActivityKey(userID)).Result()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var state activityState
+	if err := json.Unmarshal([]byte(val), &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// withActivities 상태에 활동 합치기 (MGET 한 번)
+func (m *Manager) withActivities(presences map[int64]*PresenceData) {
+	if len(presences) == 0 {
+		return
+	}
+	userIDs := make([]int64, 0, len(presences))
+	keys := make([]string, 0, len(presences))
+	for userID, data := range presences {
+		data.Activity = "" // 저장된 값이 아니라 활동 키 기준
+		data.ActivitySince = 0
+		userIDs = append(userIDs, userID)
+		keys = append(keys, m.getActivityKey(userID))
+	}
+	results, err := m.client.MGet(m.ctx, keys...).Result()
+	if err != nil {
+		return
+	}
+	for i, result := range results {
+		strVal, ok := result.(string)
+		if !ok {
+			continue
+		}
+		var state activityState
+		if err := json.Unmarshal([]byte(strVal), &state); err == nil {
+			presences[userIDs[i]].Activity = state.Activity
+			presences[userIDs[i]].ActivitySince = state.Since
+		}
+	}
+}
+
+// publishCurrent 현재 상태(활동 포함)를 다시 발행 (오프라인이면 발행하지 않음)
+func (m *Manager) publishCurrent(userID int64) error {
+	data, err := m.GetPresence(userID)
+	if err != nil || data == nil {
+		return err
+	}
+	return m.PublishPresence(*data)
+}
+
+// expireActivities TTL로 사라진 활동을 발행 (사이드바가 "통화 중"을 내리도록)
+func (m *Manager) expireActivities(now time.Time) error {
+	members, err := m.client.ZRangeByScore(m.ctx, activitiesKey, &redis.ZRangeBy{
+		Min:   "-inf",
+		Max:   strconv.FormatInt(now.Unix(), 10),
+		Count: sweepBatch,
+	}).Result()
+	if err != nil {
+		return err
+	}
+	for _, member := range members {
+		m.client.ZRem(m.ctx, activitiesKey, member)
+		userID, err := strconv.ParseInt(member, 10, 64)
+		if err != nil {
+			continue
+		}
+		if state, err := m.activity(userID); err == nil && state == nil {
+			if err := m.publishCurrent(userID); err != nil && !errors.Is(err, ErrOffline) {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -3,7 +3,9 @@ package presence
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -19,6 +21,15 @@ const (
 	StatusOffline PresenceStatus = "OFFLINE"
 )
 
+// ErrOffline 상태 키가 없음 (만료됐거나 접속 종료됨)
+var ErrOffline = errors.New("presence: user is offline")
+
+// heartbeatsKey 접속 중인 사용자의 마지막 heartbeat 시각 (sorted set, score = unix 초)
+const heartbeatsKey = "presence:heartbeats"
+
+// defaultKeyTTL 만료 검사를 켜지 않았을 때 상태 키 TTL (Heartbeat는 30초마다)
+const defaultKeyTTL = 60 * time.Second
+
 // PresenceData Redis에 저장될 상태 데이터
 type PresenceData struct {
 	UserID             int64          `json:"user_id"`
@@ -26,17 +37,28 @@ type PresenceData struct {
 	StatusMessage      *string        `json:"status_message,omitempty"`       // 캐싱된 상태 메시지 텍스트
 	StatusMessageEmoji *string        `json:"status_message_emoji,omitempty"` // 캐싱된 상태 메시지 이모지
 	LastHeartbeat      int64          `json:"last_heartbeat"`
-	ServerID           string         `json:"server_id"` // 멀티 서버 확장 대비
+	ServerID           string         `json:"server_id"`           // 멀티 서버 확장 대비
+	AutoIdle           bool           `json:"auto_idle,omitempty"` // heartbeat가 끊겨 서버가 IDLE로 바꾼 상태 (heartbeat가 오면 ONLINE 복귀)
+	Devices            []string       `json:"devices,omitempty"`   // 접속 중인 기기 종류 (web, desktop, mobile)
+	Activity           Activity       `json:"activity,omitempty"`  // 통화 중 등 (활동 키에서 채움)
+	ActivitySince      int64          `json:"activity_since,omitempty"`
 }
 
 // Manager Presence 관리자
 type Manager struct {
-	client *redis.Client
-	ctx    context.Context
+	client   *redis.Client
+	ctx      context.Context
+	serverID string        // 이 인스턴스 (세션 소유자, 죽은 인스턴스의 세션 정리에 사용)
+	keyTTL   time.Duration // 상태 키 TTL (만료 검사가 돌지 않아도 결국 사라지도록)
+
+	idleAfter    time.Duration
+	offlineAfter time.Duration
+	done         chan struct{}
+	closeOnce    sync.Once
 }
 
-// NewManager 생성자
-func NewManager(addr string, password string, db int) *Manager {
+// NewManager 생성자 (serverID는 인스턴스마다 달라야 함)
+func NewManager(addr string, password string, db int, serverID string) *Manager {
 	rdb := redis.NewClient(&redis.Options{
 		Addr:     addr,
 		Password: password,
@@ -44,11 +66,18 @@ func NewManager(addr string, password string, db int) *Manager {
 	})
 
 	return &Manager{
-		client: rdb,
-		ctx:    context.Background(),
+		client:   rdb,
+		ctx:      context.Background(),
+		serverID: serverID,
+		keyTTL:   defaultKeyTTL,
 	}
 }
 
+// ServerID 이 인스턴스 식별자 (PresenceData.ServerID에 기록)
+func (m *Manager) ServerID() string {
+	return m.serverID
+}
+
 // Key 생성 유틸
 func (m *Manager) getUserKey(userID int64) string {
 	return fmt.Sprintf("presence:user:%d", userID)
@@ -64,33 +93,105 @@ func (m *Manager) SetPresence(userID int64, status PresenceStatus, serverID stri
 		StatusMessage:      message,
 		StatusMessageEmoji: emoji,
 	}
+	if devices, err := m.Devices(userID); err == nil {
+		data.Devices = devices
+	}
+
+	return m.store(m.client, &data)
+}
 
+// store 상태 저장 + heartbeat 시각 기록
+func (m *Manager) store(c redis.Cmdable, data *PresenceData) error {
 	jsonData, err := json.Marshal(data)
 	if err != nil {
 		return err
 	}
 
-	// 60초 TTL (Heartbeat는 30초마다)
-	return m.client.Set(m.ctx, m.getUserKey(userID), jsonData, 60*time.Second).Err()
+	_, err = c.TxPipelined(m.ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(m.ctx, m.getUserKey(data.UserID), jsonData, m.keyTTL)
+		pipe.ZAdd(m.ctx, heartbeatsKey, redis.Z{Score: float64(data.LastHeartbeat), Member: data.UserID})
+		return nil
+	})
+	return err
 }
 
-// UpdateHeartbeat 생존 신고 (TTL 연장)
+// UpdateHeartbeat 생존 신고 (heartbeat 시각/TTL 갱신, 자동 IDLE이었으면 ONLINE으로 되돌리고 발행)
+// 상태 키가 없으면 ErrOffline (호출자가 다시 SetPresence)
 func (m *Manager) UpdateHeartbeat(userID int64) error {
-	// 키가 존재할 때만 Expiration 갱신 (XX 옵션과 유사한 효과를 내기 위해 Expire 사용)
-	// 하지만 단순히 값 덮어쓰기가 아니라 TTL만 늘려야 하므로 Expire 사용
-	result, err := m.client.Expire(m.ctx, m.getUserKey(userID), 60*time.Second).Result()
+	key := m.getUserKey(userID)
+	var resumed *PresenceData
+	err := m.client.Watch(m.ctx, func(tx *redis.Tx) error {
+		data, err := m.load(tx, key)
+		if err != nil {
+			return err
+		}
+		data.LastHeartbeat = time.Now().Unix()
+		if data.AutoIdle {
+			data.Status = StatusOnline
+			data.AutoIdle = false
+			resumed = data
+		}
+		return m.store(tx, data)
+	}, key)
 	if err != nil {
 		return err
 	}
-	if !result {
-		return fmt.Errorf("user %d not found (offline)", userID)
+	if resumed != nil {
+		return m.PublishPresence(*resumed)
 	}
 	return nil
 }
 
+// ClearStatusMessage 커스텀 상태 메시지만 지우고 발행 (오프라인이면 아무것도 하지 않음)
+func (m *Manager) ClearStatusMessage(userID int64) error {
+	key := m.getUserKey(userID)
+	var cleared *PresenceData
+	err := m.client.Watch(m.ctx, func(tx *redis.Tx) error {
+		data, err := m.load(tx, key)
+		if err != nil {
+			return err
+		}
+		if data.StatusMessage == nil && data.StatusMessageEmoji == nil {
+			return nil
+		}
+		data.StatusMessage = nil
+		data.StatusMessageEmoji = nil
+		cleared = data
+		return m.store(tx, data)
+	}, key)
+	if errors.Is(err, ErrOffline) {
+		return nil
+	}
+	if err != nil || cleared == nil {
+		return err
+	}
+	return m.PublishPresence(*cleared)
+}
+
+// load 상태 조회 (키가 없으면 ErrOffline)
+func (m *Manager) load(c redis.Cmdable, key string) (*PresenceData, error) {
+	val, err := c.Get(m.ctx, key).Result()
+	if err == redis.Nil {
+		return nil, ErrOffline
+	}
+	if err != nil {
+		return nil, err
+	}
+	var data PresenceData
+	if err := json.Unmarshal([]byte(val), &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
 // RemovePresence 상태 삭제 (Disconnect)
 func (m *Manager) RemovePresence(userID int64) error {
-	return m.client.Del(m.ctx, m.getUserKey(userID)).Err()
+	_, err := m.client.TxPipelined(m.ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(m.ctx, m.getUserKey(userID))
+		pipe.ZRem(m.ctx, heartbeatsKey, userID)
+		return nil
+	})
+	return err
 }
 
 // GetPresence 상태 조회
@@ -107,6 +208,7 @@ func (m *Manager) GetPresence(userID int64) (*PresenceData, error) {
 	if err := json.Unmarshal([]byte(val), &data); err != nil {
 		return nil, err
 	}
+	m.withActivities(map[int64]*PresenceData{userID: &data})
 	return &data, nil
 }
 
@@ -143,12 +245,19 @@ func (m *Manager) GetMultiPresence(userIDs []int64) (map[int64]*PresenceData, er
 			presenceMap[userIDs[i]] = &data
 		}
 	}
+	m.withActivities(presenceMap)
 
 	return presenceMap, nil
 }
 
-// PublishPresence 상태 변경 이벤트 발행
+// PublishPresence 상태 변경 이벤트 발행 (온라인 상태면 현재 활동을 채워서)
 func (m *Manager) PublishPresence(data PresenceData) error {
+	if data.Status != StatusOffline && data.Activity == "" {
+		if state, err := m.activity(data.UserID); err == nil && state != nil {
+			data.Activity = state.Activity
+			data.ActivitySince = state.Since
+		}
+	}
 	jsonData, err := json.Marshal(data)
 	if err != nil {
 		return err
@@ -0,0 +1,105 @@
+package presence
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// serversKey presence 세션을 가진 적이 있는 인스턴스 목록 (set)
+const serversKey = "presence:servers"
+
+// minServerAliveTTL 인스턴스 생존 키 최소 TTL (검사 주기가 짧아도 잠깐의 지연으로 죽은 것으로 보지 않도록)
+const minServerAliveTTL = 30 * time.Second
+
+// ServerStatus 인스턴스별 presence 세션 현황 (진단용)
+type ServerStatus struct {
+	ID       string `json:"id"`
+	Alive    bool   `json:"alive"`
+	Sessions int64  `json:"sessions"`
+	Self     bool   `json:"self"`
+}
+
+func serverAliveKey(serverID string) string {
+	return fmt.Sprintf("presence:server:%s", serverID)
+}
+
+// serverSessionsKey 인스턴스가 가진 연결 세션 (set, serverSessionEntry)
+func serverSessionsKey(serverID string) string {
+	return fmt.Sprintf("presence:server_sessions:%s", serverID)
+}
+
+func (m *Manager) serverAliveTTL(interval time.Duration) time.Duration {
+	return max(3*interval, minServerAliveTTL)
+}
+
+// markAlive 이 인스턴스 생존 신고 (검사 주기마다)
+func (m *Manager) markAlive(interval time.Duration) error {
+	pipe := m.client.TxPipeline()
+	pipe.SAdd(m.ctx, serversKey, m.serverID)
+	pipe.Set(m.ctx, serverAliveKey(m.serverID), time.Now().Unix(), m.serverAliveTTL(interval))
+	_, err := pipe.Exec(m.ctx)
+	return err
+}
+
+// Servers 인스턴스별 세션 현황
+func (m *Manager) Servers() ([]ServerStatus, error) {
+	ids, err := m.client.SMembers(m.ctx, serversKey).Result()
+	if err != nil {
+		return nil, err
+	}
+	statuses := make([]ServerStatus, 0, len(ids))
+	for _, id := range ids {
+		alive, err := m.client.Exists(m.ctx, serverAliveKey(id)).Result()
+		if err != nil {
+			return nil, err
+		}
+		sessions, err := m.client.SCard(m.ctx, serverSessionsKey(id)).Result()
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, ServerStatus{ID: id, Alive: alive == 1, Sessions: sessions, Self: id == m.serverID})
+	}
+	return statuses, nil
+}
+
+// cleanupDeadServers 생존 신고가 끊긴 인스턴스의 세션 삭제 (그 인스턴스에만 연결돼 있던 사용자는 OFFLINE 발행)
+func (m *Manager) cleanupDeadServers() error {
+	statuses, err := m.Servers()
+	if err != nil {
+		return err
+	}
+	for _, server := range statuses {
+		if server.Alive || server.Self {
+			continue
+		}
+		entries, err := m.client.SMembers(m.ctx, serverSessionsKey(server.ID)).Result()
+		if err != nil {
+			return err
+		}
+		offline := 0
+		for _, entry := range entries {
+			userPart, member, ok := strings.Cut(entry, "|")
+			userID, err := strconv.ParseInt(userPart, 10, 64)
+			if !ok || err != nil {
+				continue
+			}
+			last, err := m.dropSession(server.ID, userID, member)
+			if err != nil {
+				return err
+			}
+			if last {
+				offline++
+				m.PublishPresence(PresenceData{UserID: userID, Status: StatusOffline, ServerID: server.ID})
+			} else if data, err := m.RefreshDevices(userID); err == nil && data != nil {
+				m.PublishPresence(*data)
+			}
+		}
+		m.client.Del(m.ctx, serverSessionsKey(server.ID))
+		m.client.SRem(m.ctx, serversKey, server.ID)
+		log.Printf("🧹 presence: removed %d sessions of dead instance %s (%d users offline)", len(entries), server.ID, offline)
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package presence
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// 접속 기기 종류
+const (
+	DeviceWeb     = "web"
+	DeviceDesktop = "desktop"
+	DeviceMobile  = "mobile"
+)
+
+// NormalizeDevice 클라이언트가 보낸 기기 종류 (모르는 값은 web)
+func NormalizeDevice(device string) string {
+	switch d := strings.ToLower(strings.TrimSpace(device)); d {
+	case DeviceDesktop, DeviceMobile:
+		return d
+	default:
+		return DeviceWeb
+	}
+}
+
+// endSessionScript 세션 삭제 후 남은 세션이 없으면 상태 키까지 한 번에 삭제 (1 = 마지막 세션이었음)
+// 그 사이 다른 기기가 접속해도 상태를 지우지 않도록 스크립트로 처리
+var endSessionScript = redis.NewScript(`
+redis.call('ZREM', KEYS[1], ARGV[1])
+redis.call('SREM', KEYS[4], ARGV[4])
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', ARGV[2])
+if redis.call('ZCARD', KEYS[1]) == 0 then
+  redis.call('DEL', KEYS[1], KEYS[2])
+  redis.call('ZREM', KEYS[3], ARGV[3])
+  return 1
+end
+return 0
+`)
+
+// getSessionsKey 사용자의 연결별 세션 (sorted set, member = "세션ID:기기", score = 마지막 활동 unix 초)
+func (m *Manager) getSessionsKey(userID int64) string {
+	return fmt.Sprintf("presence:sessions:%d", userID)
+}
+
+func sessionMember(sessionID, device string) string {
+	return sessionID + ":" + device
+}
+
+// serverSessionEntry 인스턴스별 세션 목록 항목 ("사용자ID|세션 member")
+func serverSessionEntry(userID int64, member string) string {
+	return strconv.FormatInt(userID, 10) + "|" + member
+}
+
+// staleCutoff 이보다 오래 활동이 없는 세션은 끊긴 것으로 봄 (연결을 가진 서버가 죽은 경우)
+func (m *Manager) staleCutoff(now time.Time) string {
+	return strconv.FormatInt(now.Add(-m.keyTTL).Unix(), 10)
+}
+
+// TouchSession 연결 세션 등록/갱신 (연결 직후와 heartbeat마다)
+func (m *Manager) TouchSession(userID int64, sessionID, device string) error {
+	key := m.getSessionsKey(userID)
+	now := time.Now()
+	member := sessionMember(sessionID, device)
+	_, err := m.client.TxPipelined(m.ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZAdd(m.ctx, key, redis.Z{Score: float64(now.Unix()), Member: member})
+		pipe.ZRemRangeByScore(m.ctx, key, "-inf", m.staleCutoff(now))
+		pipe.Expire(m.ctx, key, m.keyTTL)
+		pipe.SAdd(m.ctx, serverSessionsKey(m.serverID), serverSessionEntry(userID, member))
+		return nil
+	})
+	return err
+}
+
+// EndSession 연결 세션 종료, 사용자의 마지막 세션이었으면 상태도 삭제하고 true
+func (m *Manager) EndSession(userID int64, sessionID, device string) (bool, error) {
+	return m.dropSession(m.serverID, userID, sessionMember(sessionID, device))
+}
+
+// dropSession serverID 인스턴스가 가진 세션 하나 삭제 (죽은 인스턴스 정리에도 사용)
+func (m *Manager) dropSession(serverID string, userID int64, member string) (bool, error) {
+	last, err := endSessionScript.Run(m.ctx, m.client,
+		[]string{m.getSessionsKey(userID), m.getUserKey(userID), heartbeatsKey, serverSessionsKey(serverID)},
+		member, m.staleCutoff(time.Now()), userID, serverSessionEntry(userID, member),
+	).Int()
+	if err != nil {
+		return false, err
+	}
+	return last == 1, nil
+}
+
+// Devices 접속 중인 기기 종류 (중복 제거, 정렬)
+func (m *Manager) Devices(userID int64) ([]string, error) {
+	members, err := m.client.ZRangeByScore(m.ctx, m.getSessionsKey(userID), &redis.ZRangeBy{
+		Min: m.staleCutoff(time.Now()),
+		Max: "+inf",
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+	devices := make([]string, 0, len(members))
+	for _, member := range members {
+		if i := strings.LastIndexByte(member, ':'); i >= 0 {
+			if device := member[i+1:]; !slices.Contains(devices, device) {
+				devices = append(devices, device)
+			}
+		}
+	}
+	slices.Sort(devices)
+	return devices, nil
+}
+
+// RefreshDevices 세션 변화를 상태의 기기 목록에 반영 (상태가 없으면 nil)
+func (m *Manager) RefreshDevices(userID int64) (*PresenceData, error) {
+	devices, err := m.Devices(userID)
+	if err != nil {
+		return nil, err
+	}
+	key := m.getUserKey(userID)
+	var updated *PresenceData
+	err = m.client.Watch(m.ctx, func(tx *redis.Tx) error {
+		data, err := m.load(tx, key)
+		if err != nil {
+			return err
+		}
+		data.Devices = devices
+		jsonData, err := json.Marshal(data)
+		if err != nil {
+			return err
+		}
+		_, err = tx.TxPipelined(m.ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(m.ctx, key, jsonData, redis.KeepTTL)
+			return nil
+		})
+		updated = data
+		return err
+	}, key)
+	if errors.Is(err, ErrOffline) {
+		return nil, nil
+	}
+	return updated, err
+}
@@ -0,0 +1,168 @@
+package presence
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// sweepLockKey 여러 인스턴스 중 한 곳만 만료 검사를 하도록 잡는 락
+const sweepLockKey = "presence:sweep_lock"
+
+// sweepBatch 한 번에 검사하는 최대 사용자 수
+const sweepBatch = 500
+
+// StartSweeper 인스턴스 생존 신고와 만료 검사 시작
+// heartbeat가 끊긴 사용자는 ONLINE → IDLE → OFFLINE으로 내리고 전환을 발행
+// idleAfter 동안 heartbeat가 없으면 IDLE(직접 고른 DND/IDLE은 그대로), offlineAfter가 지나면 OFFLINE
+// 생존 신고가 끊긴 인스턴스의 세션은 다른 인스턴스가 정리
+func (m *Manager) StartSweeper(idleAfter, offlineAfter, interval time.Duration) {
+	if interval <= 0 || m.done != nil {
+		return
+	}
+	if idleAfter > 0 && offlineAfter > idleAfter {
+		m.idleAfter = idleAfter
+		m.offlineAfter = offlineAfter
+		// 검사가 한두 번 밀려도 키가 먼저 사라지지 않도록 여유를 둠 (모든 서버가 죽어도 결국 만료)
+		m.keyTTL = offlineAfter + 2*interval
+	}
+	if err := m.markAlive(interval); err != nil {
+		log.Printf("⚠️ presence 인스턴스 등록 실패: %v", err)
+	}
+	m.done = make(chan struct{})
+	go m.runSweeper(interval)
+}
+
+// Close 만료 검사 중단, 생존 키를 지워 다른 인스턴스가 남은 세션을 바로 정리하도록
+func (m *Manager) Close() {
+	m.closeOnce.Do(func() {
+		if m.done != nil {
+			close(m.done)
+			m.client.Del(m.ctx, serverAliveKey(m.serverID))
+		}
+	})
+}
+
+func (m *Manager) runSweeper(interval time.Duration) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("⚠️ presence sweeper panic: %v", r)
+		}
+	}()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.done:
+			return
+		case <-ticker.C:
+		}
+
+		if err := m.markAlive(interval); err != nil {
+			log.Printf("⚠️ presence 생존 신고 실패: %v", err)
+		}
+
+		ok, err := m.client.SetNX(m.ctx, sweepLockKey, m.serverID, interval).Result()
+		if err != nil || !ok {
+			continue
+		}
+		if err := m.cleanupDeadServers(); err != nil {
+			log.Printf("⚠️ 종료된 인스턴스 세션 정리 실패: %v", err)
+		}
+		if err := m.expireActivities(time.Now()); err != nil {
+			log.Printf("⚠️ presence 활동 만료 발행 실패: %v", err)
+		}
+		if m.idleAfter <= 0 {
+			continue
+		}
+		if err := m.sweep(time.Now()); err != nil {
+			log.Printf("⚠️ presence 만료 검사 실패: %v", err)
+		}
+	}
+}
+
+// sweep idleAfter 이상 heartbeat가 없는 사용자 상태 전환
+func (m *Manager) sweep(now time.Time) error {
+	cutoff := now.Add(-m.idleAfter).Unix()
+	members, err := m.client.ZRangeByScore(m.ctx, heartbeatsKey, &redis.ZRangeBy{
+		Min:   "-inf",
+		Max:   strconv.FormatInt(cutoff, 10),
+		Count: sweepBatch,
+	}).Result()
+	if err != nil {
+		return err
+	}
+
+	for _, member := range members {
+		userID, err := strconv.ParseInt(member, 10, 64)
+		if err != nil {
+			m.client.ZRem(m.ctx, heartbeatsKey, member)
+			continue
+		}
+		transition, err := m.expire(userID, now)
+		if err != nil {
+			if !errors.Is(err, redis.TxFailedErr) {
+				log.Printf("⚠️ presence 전환 실패: user=%d, err=%v", userID, err)
+			}
+			continue
+		}
+		if transition != nil {
+			m.PublishPresence(*transition)
+		}
+	}
+	return nil
+}
+
+// expire 사용자 한 명의 상태 전환 (그 사이 heartbeat가 오면 트랜잭션이 실패해 건너뜀)
+func (m *Manager) expire(userID int64, now time.Time) (*PresenceData, error) {
+	key := m.getUserKey(userID)
+	var transition *PresenceData
+	err := m.client.Watch(m.ctx, func(tx *redis.Tx) error {
+		data, err := m.load(tx, key)
+		if errors.Is(err, ErrOffline) {
+			// 키가 먼저 만료됨 (연결을 가진 서버가 정리하지 못함)
+			transition = &PresenceData{UserID: userID, Status: StatusOffline}
+			return tx.ZRem(m.ctx, heartbeatsKey, userID).Err()
+		}
+		if err != nil {
+			return err
+		}
+
+		silence := now.Sub(time.Unix(data.LastHeartbeat, 0))
+		switch {
+		case silence >= m.offlineAfter:
+			transition = &PresenceData{UserID: userID, Status: StatusOffline, ServerID: data.ServerID}
+			_, err := tx.TxPipelined(m.ctx, func(pipe redis.Pipeliner) error {
+				pipe.Del(m.ctx, key)
+				pipe.ZRem(m.ctx, heartbeatsKey, userID)
+				return nil
+			})
+			return err
+		case silence >= m.idleAfter && strings.EqualFold(string(data.Status), string(StatusOnline)):
+			data.Status = StatusIdle
+			data.AutoIdle = true
+			jsonData, err := json.Marshal(data)
+			if err != nil {
+				return err
+			}
+			_, err = tx.TxPipelined(m.ctx, func(pipe redis.Pipeliner) error {
+				pipe.Set(m.ctx, key, jsonData, redis.KeepTTL)
+				return nil
+			})
+			transition = data
+			return err
+		}
+		return nil
+	}, key)
+	if err != nil {
+		return nil, err
+	}
+	return transition, nil
+}
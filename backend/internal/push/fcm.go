@@ -0,0 +1,84 @@
+package push
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"google.golang.org/api/fcm/v1"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
+)
+
+// FCMSender Firebase Cloud Messaging HTTP v1 API로 앱에 전송
+type FCMSender struct {
+	service *fcm.Service
+	parent  string // projects/{projectID}
+}
+
+// NewFCMSender 서비스 계정 JSON 파일로 전송기 생성
+func NewFCMSender(ctx context.Context, projectID, credentialsFile string) (*FCMSender, error) {
+	if projectID == "" {
+		return nil, errors.New("FCM project id is required")
+	}
+	service, err := fcm.NewService(ctx, option.WithAuthCredentialsFile(option.ServiceAccount, credentialsFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create FCM client: %w", err)
+	}
+	return &FCMSender{service: service, parent: "projects/" + projectID}, nil
+}
+
+// Send 등록 토큰 하나로 알림 전송
+func (s *FCMSender) Send(ctx context.Context, sub Subscription, msg Message) error {
+	data := make(map[string]string, len(msg.Data)+1)
+	for k, v := range msg.Data {
+		data[k] = v
+	}
+	if msg.Tag != "" {
+		data["tag"] = msg.Tag
+	}
+
+	message := &fcm.Message{
+		Token:        sub.Token,
+		Notification: &fcm.Notification{Title: msg.Title, Body: msg.Body},
+		Data:         data,
+	}
+	if msg.Tag != "" {
+		message.Android = &fcm.AndroidConfig{
+			CollapseKey:  msg.Tag,
+			Notification: &fcm.AndroidNotification{Tag: msg.Tag},
+		}
+		message.Apns = &fcm.ApnsConfig{Headers: map[string]string{"apns-collapse-id": msg.Tag}}
+	}
+
+	_, err := s.service.Projects.Messages.Send(s.parent, &fcm.SendMessageRequest{Message: message}).Context(ctx).Do()
+	if err == nil {
+		return nil
+	}
+
+	var apiErr *googleapi.Error
+	if !errors.As(err, &apiErr) {
+		return &TemporaryError{Err: err}
+	}
+	switch {
+	case apiErr.Code == http.StatusNotFound, isUnregistered(apiErr):
+		return ErrSubscriptionGone
+	case apiErr.Code == http.StatusTooManyRequests || apiErr.Code >= 500:
+		return &TemporaryError{Err: err}
+	default:
+		return err
+	}
+}
+
+// isUnregistered 앱 삭제 등으로 토큰이 무효화된 경우 (FCM 오류 상세의 UNREGISTERED)
+func isUnregistered(err *googleapi.Error) bool {
+	for _, detail := range err.Details {
+		if m, ok := detail.(map[string]interface{}); ok {
+			if code, _ := m["errorCode"].(string); code == "UNREGISTERED" {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,56 @@
+package push
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// 기기 종류
+const (
+	PlatformFCM     = "FCM"     // Android/iOS 앱 (Firebase Cloud Messaging)
+	PlatformWebPush = "WEBPUSH" // 브라우저 (Web Push + VAPID)
+)
+
+// ErrSubscriptionGone 기기 토큰이 만료/해지됨 (다시 보내도 실패하므로 삭제)
+var ErrSubscriptionGone = errors.New("push subscription is no longer valid")
+
+// Subscription 푸시를 받을 기기 하나
+type Subscription struct {
+	Platform string
+	Token    string // FCM 등록 토큰 또는 Web Push 엔드포인트 URL
+	P256dh   string // Web Push 수신자 공개키 (base64url)
+	Auth     string // Web Push 인증 시크릿 (base64url)
+}
+
+// Message 푸시 알림 내용
+type Message struct {
+	Title string            `json:"title"`
+	Body  string            `json:"body"`
+	Tag   string            `json:"tag,omitempty"` // 같은 태그의 알림은 기기에서 하나로 교체
+	Data  map[string]string `json:"data,omitempty"`
+}
+
+// Sender 플랫폼별 전송기
+type Sender interface {
+	Send(ctx context.Context, sub Subscription, msg Message) error
+}
+
+// TemporaryError 잠시 후 다시 보내면 성공할 수 있는 오류 (429, 5xx, 네트워크)
+type TemporaryError struct {
+	Err error
+}
+
+func (e *TemporaryError) Error() string {
+	return fmt.Sprintf("temporary push failure: %v", e.Err)
+}
+
+func (e *TemporaryError) Unwrap() error {
+	return e.Err
+}
+
+// IsTemporary 재시도할 만한 오류인지
+func IsTemporary(err error) bool {
+	var temp *TemporaryError
+	return errors.As(err, &temp)
+}
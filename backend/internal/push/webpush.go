@@ -0,0 +1,244 @@
+package push
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"realtime-backend/internal/netguard"
+)
+
+// Web Push 설정
+const (
+	webPushTTL         = 24 * time.Hour // 브라우저가 꺼져 있을 때 푸시 서비스가 보관하는 시간
+	webPushRecordSize  = 4096
+	webPushMaxPayload  = 3000 // 암호화 오버헤드를 빼고 푸시 서비스가 받는 크기 이하로
+	vapidTokenLifetime = 12 * time.Hour
+)
+
+// webPushServiceHosts 브라우저 푸시 서비스 도메인 (Chrome/Edge 구버전, Firefox, Safari, Edge)
+// 구독 엔드포인트는 서버가 직접 POST 하므로 이 도메인 밖의 주소는 받지 않음
+var webPushServiceHosts = []string{
+	"fcm.googleapis.com",
+	"android.googleapis.com",
+	"updates.push.services.mozilla.com",
+	"push.apple.com",
+	"notify.windows.com",
+}
+
+// ValidWebPushEndpoint 알려진 푸시 서비스의 https 엔드포인트인지
+func ValidWebPushEndpoint(raw string) bool {
+	u, err := url.Parse(raw)
+	if err != nil || u.Scheme != "https" || u.User != nil || (u.Port() != "" && u.Port() != "443") {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	for _, service := range webPushServiceHosts {
+		if host == service || strings.HasSuffix(host, "."+service) {
+			return true
+		}
+	}
+	return false
+}
+
+// WebPushSender VAPID로 서명하고 RFC 8291(aes128gcm)로 암호화해 브라우저 푸시 서비스에 전송
+type WebPushSender struct {
+	privateKey *ecdsa.PrivateKey
+	publicKey  string // base64url (브라우저 구독 시 applicationServerKey)
+	subject    string // mailto: 또는 https: 연락처
+	client     *http.Client
+}
+
+// NewWebPushSender VAPID 개인키(base64url, 32바이트)로 전송기 생성
+func NewWebPushSender(privateKey, subject string) (*WebPushSender, error) {
+	raw, err := decodeBase64URL(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid VAPID private key: %w", err)
+	}
+	key, err := ecdsa.ParseRawPrivateKey(elliptic.P256(), raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid VAPID private key: %w", err)
+	}
+	pub, err := key.PublicKey.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	if subject == "" {
+		return nil, fmt.Errorf("VAPID subject is required")
+	}
+
+	return &WebPushSender{
+		privateKey: key,
+		publicKey:  base64.RawURLEncoding.EncodeToString(pub),
+		subject:    subject,
+		client:     netguard.NewHTTPClient(10 * time.Second),
+	}, nil
+}
+
+// PublicKey 브라우저 PushManager.subscribe에 넘길 VAPID 공개키
+func (s *WebPushSender) PublicKey() string {
+	return s.publicKey
+}
+
+// Send 구독 엔드포인트로 암호화한 알림 전송
+func (s *WebPushSender) Send(ctx context.Context, sub Subscription, msg Message) error {
+	// 등록 시 확인하기 전에 저장된 구독도 푸시 서비스가 아니면 보내지 않고 삭제
+	endpoint, err := url.Parse(sub.Token)
+	if err != nil || !ValidWebPushEndpoint(sub.Token) {
+		return ErrSubscriptionGone
+	}
+
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	if len(payload) > webPushMaxPayload {
+		return fmt.Errorf("push payload too large (%d bytes)", len(payload))
+	}
+	body, err := encryptWebPush(payload, sub.P256dh, sub.Auth)
+	if err != nil {
+		// 구독 키가 잘못되면 다시 보내도 실패
+		return fmt.Errorf("%w: %v", ErrSubscriptionGone, err)
+	}
+
+	token, err := s.vapidToken(endpoint.Scheme + "://" + endpoint.Host)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.Token, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("TTL", fmt.Sprintf("%d", int(webPushTTL.Seconds())))
+	req.Header.Set("Urgency", "high")
+	if msg.Tag != "" {
+		req.Header.Set("Topic", topicHeader(msg.Tag))
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("vapid t=%s, k=%s", token, s.publicKey))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return &TemporaryError{Err: err}
+	}
+	defer resp.Body.Close()
+	detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return nil
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		return ErrSubscriptionGone
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return &TemporaryError{Err: fmt.Errorf("push service returned %d: %s", resp.StatusCode, strings.TrimSpace(string(detail)))}
+	default:
+		return fmt.Errorf("push service returned %d: %s", resp.StatusCode, strings.TrimSpace(string(detail)))
+	}
+}
+
+// vapidToken 푸시 서비스 origin용 VAPID JWT (RFC 8292)
+func (s *WebPushSender) vapidToken(audience string) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+		"aud": audience,
+		"exp": time.Now().Add(vapidTokenLifetime).Unix(),
+		"sub": s.subject,
+	})
+	return token.SignedString(s.privateKey)
+}
+
+// encryptWebPush RFC 8291 aes128gcm 암호화 (레코드 하나)
+// 본문: salt(16) | 레코드 크기(4) | 키 길이(1) | 서버 공개키(65) | 암호문
+func encryptWebPush(plaintext []byte, p256dh, authSecret string) ([]byte, error) {
+	uaPublicRaw, err := decodeBase64URL(p256dh)
+	if err != nil {
+		return nil, fmt.Errorf("invalid p256dh: %w", err)
+	}
+	auth, err := decodeBase64URL(authSecret)
+	if err != nil || len(auth) == 0 {
+		return nil, fmt.Errorf("invalid auth secret")
+	}
+
+	curve := ecdh.P256()
+	uaPublic, err := curve.NewPublicKey(uaPublicRaw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid p256dh: %w", err)
+	}
+	asPrivate, err := curve.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	asPublicRaw := asPrivate.PublicKey().Bytes()
+	sharedSecret, err := asPrivate.ECDH(uaPublic)
+	if err != nil {
+		return nil, err
+	}
+
+	keyInfo := "WebPush: info\x00" + string(uaPublicRaw) + string(asPublicRaw)
+	ikm, err := hkdf.Key(sha256.New, sharedSecret, auth, keyInfo, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	cek, err := hkdf.Key(sha256.New, ikm, salt, "Content-Encoding: aes128gcm\x00", 16)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := hkdf.Key(sha256.New, ikm, salt, "Content-Encoding: nonce\x00", 12)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	// 마지막 레코드 구분자 0x02
+	record := append(append([]byte{}, plaintext...), 0x02)
+	ciphertext := gcm.Seal(nil, nonce, record, nil)
+
+	header := make([]byte, 0, 16+4+1+len(asPublicRaw))
+	header = append(header, salt...)
+	header = binary.BigEndian.AppendUint32(header, webPushRecordSize)
+	header = append(header, byte(len(asPublicRaw)))
+	header = append(header, asPublicRaw...)
+	return append(header, ciphertext...), nil
+}
+
+// topicHeader Topic 헤더는 base64url 문자 32자 이하만 허용
+func topicHeader(tag string) string {
+	sum := sha256.Sum256([]byte(tag))
+	return base64.RawURLEncoding.EncodeToString(sum[:])[:32]
+}
+
+// decodeBase64URL 패딩 유무와 관계없이 base64url 디코딩 (브라우저마다 다름)
+func decodeBase64URL(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+}
@@ -0,0 +1,301 @@
+package recurrence
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequency 반복 주기
+type Frequency string
+
+const (
+	Daily   Frequency = "DAILY"
+	Weekly  Frequency = "WEEKLY"
+	Monthly Frequency = "MONTHLY"
+	Yearly  Frequency = "YEARLY"
+)
+
+// maxIterations 규칙 하나를 펼칠 때 검사하는 최대 주기 수 (잘못된 규칙으로 무한 반복 방지)
+const maxIterations = 100000
+
+// untilLayout RRULE UNTIL 값 형식 (UTC)
+const untilLayout = "20060102T150405Z"
+
+var weekdayCodes = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// Rule RFC 5545 RRULE 중 일정 반복에 필요한 부분
+// (FREQ, INTERVAL, COUNT, UNTIL, 주간 BYDAY, 월간 BYMONTHDAY)
+type Rule struct {
+	Freq       Frequency
+	Interval   int
+	Count      int        // 0 = 제한 없음
+	Until      *time.Time // 마지막 발생 시각 (포함)
+	ByDay      []time.Weekday
+	ByMonthDay []int // 음수는 말일 기준 (-1 = 마지막 날)
+}
+
+// Parse RRULE 문자열 파싱 (앞의 "RRULE:"은 있어도 됨)
+func Parse(s string) (*Rule, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "RRULE:")
+	if s == "" {
+		return nil, errors.New("empty recurrence rule")
+	}
+
+	r := &Rule{Interval: 1}
+	for _, part := range strings.Split(s, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid rule part %q", part)
+		}
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			r.Freq = Frequency(strings.ToUpper(value))
+		case "INTERVAL":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 || n > 999 {
+				return nil, fmt.Errorf("invalid INTERVAL %q", value)
+			}
+			r.Interval = n
+		case "COUNT":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 || n > 9999 {
+				return nil, fmt.Errorf("invalid COUNT %q", value)
+			}
+			r.Count = n
+		case "UNTIL":
+			t, err := parseUntil(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid UNTIL %q", value)
+			}
+			r.Until = &t
+		case "BYDAY":
+			for _, code := range strings.Split(strings.ToUpper(value), ",") {
+				day, ok := weekdayCodes[code]
+				if !ok {
+					return nil, fmt.Errorf("unsupported BYDAY %q", code)
+				}
+				if !slices.Contains(r.ByDay, day) {
+					r.ByDay = append(r.ByDay, day)
+				}
+			}
+		case "BYMONTHDAY":
+			for _, v := range strings.Split(value, ",") {
+				n, err := strconv.Atoi(v)
+				if err != nil || n == 0 || n < -31 || n > 31 {
+					return nil, fmt.Errorf("invalid BYMONTHDAY %q", v)
+				}
+				if !slices.Contains(r.ByMonthDay, n) {
+					r.ByMonthDay = append(r.ByMonthDay, n)
+				}
+			}
+		case "WKST":
+			if value != "MO" {
+				return nil, errors.New("only WKST=MO is supported")
+			}
+		default:
+			return nil, fmt.Errorf("unsupported rule part %q", key)
+		}
+	}
+
+	switch r.Freq {
+	case Daily, Yearly:
+		if len(r.ByDay) > 0 || len(r.ByMonthDay) > 0 {
+			return nil, fmt.Errorf("BYDAY/BYMONTHDAY are not supported with FREQ=%s", r.Freq)
+		}
+	case Weekly:
+		if len(r.ByMonthDay) > 0 {
+			return nil, errors.New("BYMONTHDAY is not supported with FREQ=WEEKLY")
+		}
+	case Monthly:
+		if len(r.ByDay) > 0 {
+			return nil, errors.New("BYDAY is not supported with FREQ=MONTHLY")
+		}
+	default:
+		return nil, errors.New("FREQ must be DAILY, WEEKLY, MONTHLY or YEARLY")
+	}
+	if r.Count > 0 && r.Until != nil {
+		return nil, errors.New("COUNT and UNTIL cannot be used together")
+	}
+
+	slices.Sort(r.ByDay)
+	slices.Sort(r.ByMonthDay)
+	return r, nil
+}
+
+func parseUntil(value string) (time.Time, error) {
+	if t, err := time.Parse(untilLayout, value); err == nil {
+		return t, nil
+	}
+	// 날짜만 있으면 그날 끝까지 포함
+	t, err := time.Parse("20060102", value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.Add(24*time.Hour - time.Second), nil
+}
+
+// String 정규화된 RRULE 문자열 ("RRULE:" 접두사 없음)
+func (r *Rule) String() string {
+	parts := []string{"FREQ=" + string(r.Freq)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if r.Until != nil {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format(untilLayout))
+	}
+	if len(r.ByDay) > 0 {
+		codes := make([]string, len(r.ByDay))
+		for i, day := range r.ByDay {
+			codes[i] = strings.ToUpper(day.String()[:2])
+		}
+		parts = append(parts, "BYDAY="+strings.Join(codes, ","))
+	}
+	if len(r.ByMonthDay) > 0 {
+		days := make([]string, len(r.ByMonthDay))
+		for i, day := range r.ByMonthDay {
+			days[i] = strconv.Itoa(day)
+		}
+		parts = append(parts, "BYMONTHDAY="+strings.Join(days, ","))
+	}
+	return strings.Join(parts, ";")
+}
+
+// Between dtstart에서 시작하는 반복 중 [from, to] 사이에 시작하는 발생 시각 (최대 limit개)
+// 시각 계산은 dtstart의 타임존 기준 (주간/월간 반복의 요일·날짜가 타임존에 따라 달라짐)
+func (r *Rule) Between(dtstart, from, to time.Time, limit int) []time.Time {
+	var occurrences []time.Time
+	r.each(dtstart, func(t time.Time) bool {
+		if t.After(to) {
+			return false
+		}
+		if !t.Before(from) {
+			occurrences = append(occurrences, t)
+		}
+		return len(occurrences) < limit
+	})
+	return occurrences
+}
+
+// Includes t가 이 반복의 발생 시각인지 여부
+func (r *Rule) Includes(dtstart, t time.Time) bool {
+	found := false
+	r.each(dtstart, func(occurrence time.Time) bool {
+		if occurrence.Equal(t) {
+			found = true
+		}
+		return occurrence.Before(t)
+	})
+	return found
+}
+
+// LastOccurrence 마지막 발생 시각 (COUNT/UNTIL이 없으면 끝이 없으므로 nil)
+func (r *Rule) LastOccurrence(dtstart time.Time) *time.Time {
+	if r.Count == 0 && r.Until == nil {
+		return nil
+	}
+	var last *time.Time
+	r.each(dtstart, func(t time.Time) bool {
+		last = &t
+		return true
+	})
+	return last
+}
+
+// each 발생 시각을 순서대로 전달 (fn이 false를 반환하거나 COUNT/UNTIL에 닿으면 중단)
+func (r *Rule) each(dtstart time.Time, fn func(time.Time) bool) {
+	emitted := 0
+	for period := 0; period < maxIterations; period++ {
+		for _, t := range r.candidates(dtstart, period) {
+			if t.Before(dtstart) {
+				continue
+			}
+			if r.Until != nil && t.After(*r.Until) {
+				return
+			}
+			if !fn(t) {
+				return
+			}
+			emitted++
+			if r.Count > 0 && emitted >= r.Count {
+				return
+			}
+		}
+	}
+}
+
+// candidates period번째 주기에 속하는 발생 후보 (시간순)
+func (r *Rule) candidates(dtstart time.Time, period int) []time.Time {
+	y, m, d := dtstart.Date()
+	hh, mm, ss := dtstart.Clock()
+	loc := dtstart.Location()
+	at := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, hh, mm, ss, dtstart.Nanosecond(), loc)
+	}
+	step := period * r.Interval
+
+	switch r.Freq {
+	case Daily:
+		return []time.Time{at(y, m, d+step)}
+
+	case Weekly:
+		days := r.ByDay
+		if len(days) == 0 {
+			return []time.Time{at(y, m, d+7*step)}
+		}
+		// 주는 월요일 시작 (WKST=MO)
+		weekStart := d - (int(dtstart.Weekday())+6)%7 + 7*step
+		out := make([]time.Time, 0, len(days))
+		for _, day := range days {
+			out = append(out, at(y, m, weekStart+(int(day)+6)%7))
+		}
+		slices.SortFunc(out, func(a, b time.Time) int { return a.Compare(b) })
+		return out
+
+	case Monthly:
+		first := at(y, m+time.Month(step), 1)
+		year, month := first.Year(), first.Month()
+		lastDay := at(year, month+1, 0).Day()
+		monthDays := r.ByMonthDay
+		if len(monthDays) == 0 {
+			monthDays = []int{d}
+		}
+		var out []time.Time
+		for _, md := range monthDays {
+			day := md
+			if md < 0 {
+				day = lastDay + md + 1
+			}
+			// 없는 날짜(예: 2월 30일)는 건너뜀
+			if day < 1 || day > lastDay {
+				continue
+			}
+			out = append(out, at(year, month, day))
+		}
+		slices.SortFunc(out, func(a, b time.Time) int { return a.Compare(b) })
+		return slices.CompactFunc(out, func(a, b time.Time) bool { return a.Equal(b) })
+
+	case Yearly:
+		t := at(y+step, m, d)
+		// 2월 29일 반복은 윤년에만
+		if t.Day() != d {
+			return nil
+		}
+		return []time.Time{t}
+	}
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"log"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
@@ -18,7 +19,9 @@ import (
 	"realtime-backend/internal/auth"
 	"realtime-backend/internal/cache"
 	"realtime-backend/internal/config"
+	"realtime-backend/internal/errorreport"
 	"realtime-backend/internal/handler"
+	"realtime-backend/internal/language"
 	"realtime-backend/internal/middleware"
 	"realtime-backend/internal/model"
 	"realtime-backend/internal/presence"
@@ -38,6 +41,8 @@ type Server struct {
 	categoryHandler            *handler.CategoryHandler
 	notificationHandler        *handler.NotificationHandler
 	notificationWSHandler      *handler.NotificationWSHandler
+	pushHandler                *handler.PushHandler
+	mailHandler                *handler.MailHandler
 	chatHandler                *handler.ChatHandler
 	chatWSHandler              *handler.ChatWSHandler
 	meetingHandler             *handler.MeetingHandler
@@ -49,10 +54,25 @@ type Server struct {
 	voiceRecordHandler         *handler.VoiceRecordHandler
 	voiceParticipantsWSHandler *handler.VoiceParticipantsWSHandler
 	healthHandler              *handler.HealthHandler
+	impersonationHandler       *handler.ImpersonationHandler
+	adminHandler               *handler.AdminHandler
+	glossaryHandler            *handler.GlossaryHandler
+	probeHandler               *handler.ProbeHandler
+	diagnosticsHandler         *handler.DiagnosticsHandler
+	languageHandler            *handler.LanguageHandler
+	joinTokenHandler           *handler.JoinTokenHandler
+	translationSettingsHandler *handler.TranslationSettingsHandler
+	aiUsageHandler             *handler.AIUsageHandler
+	analyticsHandler           *handler.AnalyticsHandler
+	meetingMinutesHandler      *handler.MeetingMinutesHandler
+	roomIdentityHandler        *handler.RoomIdentityHandler
+	redactionHandler           *handler.RedactionHandler
+	statusHandler              *handler.StatusHandler
 	pollHandler                *handler.PollHandler
 	jwtManager                 *auth.JWTManager
 	memberService              *service.MemberService
 	workspaceMW                *middleware.WorkspaceMiddleware
+	presenceManager            *presence.Manager
 }
 
 // New 새 서버 인스턴스 생성
@@ -78,7 +98,9 @@ func New(cfg *config.Config, db *gorm.DB) *Server {
 		cfg.Redis.Addr,
 		cfg.Redis.Password,
 		cfg.Redis.DB,
+		cfg.Server.ID,
 	)
+	presenceManager.StartSweeper(cfg.Presence.IdleAfter, cfg.Presence.OfflineAfter, cfg.Presence.SweepInterval)
 
 	jwtManager := auth.NewJWTManager(
 		cfg.Auth.JWTSecret,
@@ -86,37 +108,67 @@ func New(cfg *config.Config, db *gorm.DB) *Server {
 		cfg.Auth.RefreshTokenExpiry,
 	)
 	googleAuth := auth.NewGoogleAuthenticator(cfg.Auth.GoogleClientID)
+	impersonationService := service.NewImpersonationService(db)
+	jwtManager.SetImpersonationStore(impersonationService)
+	jwtManager.SetSessionStore(service.NewSessionService(db))
+	jwtManager.SetPersonalTokenStore(service.NewPersonalTokenService(db))
+	impersonationHandler := handler.NewImpersonationHandler(db, jwtManager, impersonationService, cfg.Auth.ImpersonationMaxDuration)
+	adminHandler := handler.NewAdminHandler(db)
 	authHandler := handler.NewAuthHandler(db, jwtManager, googleAuth, cfg.Auth.SecureCookie)
 	userHandler := handler.NewUserHandler(db, presenceManager)
+	userHandler.StartStatusExpiry()
 	workspaceHandler := handler.NewWorkspaceHandler(db)
+	workspaceHandler.SetPresenceManager(presenceManager)
 	categoryHandler := handler.NewCategoryHandler(db)
 	notificationHandler := handler.NewNotificationHandler(db)
+	notificationHandler.SetInviteTTL(cfg.Server.InviteTTL)
+	notificationHandler.StartHeldSummaries()
 	notificationWSHandler := handler.NewNotificationWSHandler(db, presenceManager)
+	pushHandler := handler.NewPushHandler(db, cfg.Push)
+	mailHandler := handler.NewMailHandler(db, cfg.Mail, cfg.Server.PublicWebURL)
 	chatHandler := handler.NewChatHandler(db)
 	chatWSHandler := handler.NewChatWSHandler(db)
+	chatWSHandler.SetMessageRateLimit(cfg.WebSocket.ChatMessagesPerSecond)
 	meetingHandler := handler.NewMeetingHandler(db)
-	calendarHandler := handler.NewCalendarHandler(db)
+	calendarHandler := handler.NewCalendarHandler(db, jwtManager, cfg.Server.PublicWebURL)
+	calendarHandler.StartReminders()
 	roleHandler := handler.NewRoleHandler(db)
 	videoHandler := handler.NewVideoHandler(cfg, db)
+	videoHandler.SetPresenceManager(presenceManager)
 	whiteboardHandler := handler.NewWhiteboardHandler(db)
+	whiteboardHandler.SetRealtimeOptions(cfg.WebSocket.WhiteboardMessagesPerSecond, cfg.WebSocket.WhiteboardFlushInterval)
+	whiteboardHandler.SetTrashRetention(cfg.WebSocket.WhiteboardTrashRetention)
 	voiceRecordHandler := handler.NewVoiceRecordHandler(db)
 	voiceParticipantsWSHandler := handler.NewVoiceParticipantsWSHandler(cfg)
 
-	// S3 서비스 초기화 (선택적)
-	var s3Service *storage.S3Service
+	// S3 서비스 초기화 (선택적, 워크스페이스 데이터 리전별 버킷)
+	var s3Registry *storage.S3Registry
 	if cfg.S3.BucketName != "" && cfg.S3.AccessKeyID != "" {
 		var err error
-		s3Service, err = storage.NewS3Service(&cfg.S3)
+		s3Registry, err = storage.NewS3Registry(&cfg.S3)
 		if err != nil {
 			log.Printf("⚠️ S3 service initialization failed: %v (file upload will be disabled)", err)
 		} else {
-			log.Printf("✅ S3 service initialized (bucket: %s)", cfg.S3.BucketName)
+			log.Printf("✅ S3 service initialized (bucket: %s, regions: %v)", cfg.S3.BucketName, s3Registry.Regions())
 		}
 	} else {
 		log.Println("ℹ️ S3 service not configured (file upload will be disabled)")
 	}
-	storageHandler := handler.NewStorageHandler(db, s3Service)
+	storageHandler := handler.NewStorageHandler(db, s3Registry)
+	workspaceHandler.SetDataRegions(storage.SupportedRegions(&cfg.S3))
+	workspaceHandler.SetStorage(s3Registry)
+	userHandler.SetStorage(s3Registry, cfg.S3.AvatarMaxSize)
+	storageHandler.SetZipLimits(cfg.S3.ZipMaxSize, cfg.S3.ZipStreamLimit, cfg.S3.ZipConcurrency)
+	storageHandler.SetUploadLimit(cfg.S3.UploadMaxSize)
+	storageHandler.SetTrashRetention(cfg.S3.TrashRetention)
+	storageHandler.SetDefaultQuota(cfg.S3.WorkspaceQuota)
+	storageHandler.SetTranscription(cfg)
+	storageHandler.SetTextExtraction(cfg)
+	whiteboardHandler.SetStorage(storageHandler)
 	healthHandler := handler.NewHealthHandler(db, cfg.AI.ServerAddr)
+	languageHandler := handler.NewLanguageHandler(db)
+	glossaryHandler := handler.NewGlossaryHandler(db, cfg)
+	redactionHandler := handler.NewRedactionHandler(db)
 
 	// Service 레이어 초기화
 	memberService := service.NewMemberService(db)
@@ -124,22 +176,37 @@ func New(cfg *config.Config, db *gorm.DB) *Server {
 
 	// Audio handler 생성 및 DB 설정
 	audioHandler := handler.NewAudioHandler(cfg, db)
+	notificationWSHandler.SetRedisClient(audioHandler.GetRedisClient())
 	if roomHub := audioHandler.GetRoomHub(); roomHub != nil {
 		roomHub.SetDB(db)
+		roomHub.SetPresenceManager(presenceManager)
 	}
-
-	// Poll Handler 초기화 (Redis 재사용 또는 신규 생성)
-	var pollHandler *handler.PollHandler
+	probeHandler := handler.NewProbeHandler(cfg, audioHandler.GetRoomHub())
+	diagnosticsHandler := handler.NewDiagnosticsHandler(audioHandler.GetRoomHub(), cfg.Server.ID, presenceManager)
+	joinTokenHandler := handler.NewJoinTokenHandler(db, jwtManager, audioHandler.GetRedisClient(),
+		cfg.Auth.JoinTokenExpiry, cfg.Auth.RequireJoinToken)
+	translationSettingsHandler := handler.NewTranslationSettingsHandler(db, audioHandler.GetRoomHub())
+	aiUsageHandler := handler.NewAIUsageHandler(db, audioHandler.GetRoomHub())
+	analyticsHandler := handler.NewAnalyticsHandler(db)
+	analyticsHandler.StartRollups()
+	meetingMinutesHandler := handler.NewMeetingMinutesHandler(db, storageHandler, audioHandler.GetRoomHub())
+	roomIdentityHandler := handler.NewRoomIdentityHandler(db, jwtManager, cfg.Auth.RequireRoomIdentity)
+	statusHandler := handler.NewStatusHandler(db, audioHandler.GetRoomHub(), audioHandler.GetRedisClient(), s3Registry, cfg.AI.ServerAddr)
+
+	// Poll Handler 초기화 (투표는 DB 에 저장, Redis 는 실시간 집계 캐시로만 사용)
+	var pollRedis *cache.RedisClient
 	if cfg.Redis.Enabled && cfg.Redis.Addr != "" {
 		// 오디오 핸들러와 별도로 Redis 연결 생성 (커넥션 풀링으로 효율적)
 		redisClient, err := cache.NewRedisClient(cfg.Redis.Addr, cfg.Redis.Password)
 		if err != nil {
-			log.Printf("⚠️ PollHandler Redis connection failed: %v", err)
+			log.Printf("⚠️ PollHandler Redis connection failed, counting votes from DB: %v", err)
 		} else {
-			pollHandler = handler.NewPollHandler(redisClient)
-			log.Println("📊 PollHandler initialized with Redis")
+			pollRedis = redisClient
+			log.Println("📊 PollHandler initialized with Redis cache")
 		}
 	}
+	pollHandler := handler.NewPollHandler(db, pollRedis)
+	pollHandler.StartExpiryCloser()
 
 	return &Server{
 		app:                   app,
@@ -152,6 +219,9 @@ func New(cfg *config.Config, db *gorm.DB) *Server {
 		categoryHandler:       categoryHandler,
 		notificationHandler:   notificationHandler,
 		notificationWSHandler: notificationWSHandler,
+		presenceManager:       presenceManager,
+		pushHandler:           pushHandler,
+		mailHandler:           mailHandler,
 		chatHandler:           chatHandler,
 		chatWSHandler:         chatWSHandler,
 		meetingHandler:        meetingHandler,
@@ -163,6 +233,20 @@ func New(cfg *config.Config, db *gorm.DB) *Server {
 		voiceRecordHandler:         voiceRecordHandler,
 		voiceParticipantsWSHandler: voiceParticipantsWSHandler,
 		healthHandler:              healthHandler,
+		impersonationHandler:       impersonationHandler,
+		adminHandler:               adminHandler,
+		glossaryHandler:            glossaryHandler,
+		probeHandler:               probeHandler,
+		diagnosticsHandler:         diagnosticsHandler,
+		languageHandler:            languageHandler,
+		joinTokenHandler:           joinTokenHandler,
+		translationSettingsHandler: translationSettingsHandler,
+		aiUsageHandler:             aiUsageHandler,
+		analyticsHandler:           analyticsHandler,
+		meetingMinutesHandler:      meetingMinutesHandler,
+		roomIdentityHandler:        roomIdentityHandler,
+		redactionHandler:           redactionHandler,
+		statusHandler:              statusHandler,
 		pollHandler:                pollHandler, // Added
 		jwtManager:                 jwtManager,
 		memberService:              memberService,
@@ -174,7 +258,8 @@ func New(cfg *config.Config, db *gorm.DB) *Server {
 func (s *Server) SetupMiddleware() {
 	// 패닉 복구
 	s.app.Use(recover.New(recover.Config{
-		EnableStackTrace: true,
+		EnableStackTrace:  true,
+		StackTraceHandler: reportHTTPPanic,
 	}))
 
 	// 로깅
@@ -190,12 +275,35 @@ func (s *Server) SetupMiddleware() {
 		AllowHeaders:     "Origin, Content-Type, Accept, Authorization",
 		AllowMethods:     "GET, POST, PUT, DELETE, OPTIONS",
 		AllowCredentials: true,
+		ExposeHeaders:    "X-Impersonation, X-Impersonated-By, X-Impersonation-Read-Only, X-Impersonation-Expires-At",
 	}))
 
 	// 정적 파일 제공 (업로드된 파일)
 	s.app.Static("/uploads", "./uploads")
 }
 
+// reportHTTPPanic HTTP 핸들러 패닉을 요청 사용자/워크스페이스 정보와 함께 보고
+func reportHTTPPanic(c *fiber.Ctx, e interface{}) {
+	ctx := errorreport.Context{
+		Component: "http",
+		Tags: map[string]string{
+			"method": c.Method(),
+			"route":  c.Route().Path,
+		},
+	}
+	if claims, ok := c.Locals("claims").(*auth.Claims); ok && claims != nil {
+		ctx.UserID = claims.UserID
+	}
+	if wsID, err := c.ParamsInt("workspaceId"); err == nil {
+		ctx.WorkspaceID = int64(wsID)
+	} else if strings.HasPrefix(c.Route().Path, "/api/workspaces/:id") {
+		if wsID, err := c.ParamsInt("id"); err == nil {
+			ctx.WorkspaceID = int64(wsID)
+		}
+	}
+	errorreport.CapturePanic(e, ctx)
+}
+
 // SetupRoutes 라우트 설정
 func (s *Server) SetupRoutes() {
 	// 헬스체크 엔드포인트
@@ -203,6 +311,7 @@ func (s *Server) SetupRoutes() {
 	s.app.Get("/health", s.healthHandler.Check)           // 전체 상태 (DB + AI)
 	s.app.Get("/health/live", s.healthHandler.Liveness)   // K8s liveness probe
 	s.app.Get("/health/ready", s.healthHandler.Readiness) // K8s readiness probe
+	s.app.Get("/metrics", s.healthHandler.Metrics)        // Prometheus 지표
 
 	// Rate Limiter 설정 (인증 엔드포인트용 - Brute Force 방지)
 	authLimiter := limiter.New(limiter.Config{
@@ -218,28 +327,121 @@ func (s *Server) SetupRoutes() {
 		},
 	})
 
+	// 공개 상태 페이지 (인증 불필요, IP당 분당 30회)
+	statusLimiter := limiter.New(limiter.Config{
+		Max:        30,
+		Expiration: 1 * time.Minute,
+		KeyGenerator: func(c *fiber.Ctx) string {
+			return c.IP()
+		},
+		LimitReached: func(c *fiber.Ctx) error {
+			return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+				"error": "too many requests, please try again later",
+			})
+		},
+	})
+	s.app.Get("/status", statusLimiter, s.statusHandler.GetStatus)
+
+	// 공유 링크 (인증 불필요, 비밀번호 대입 방지를 위해 IP당 분당 20회)
+	shareLimiter := limiter.New(limiter.Config{
+		Max:        20,
+		Expiration: 1 * time.Minute,
+		KeyGenerator: func(c *fiber.Ctx) string {
+			return c.IP()
+		},
+		LimitReached: func(c *fiber.Ctx) error {
+			return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+				"error": "too many requests, please try again later",
+			})
+		},
+	})
+	shareGroup := s.app.Group("/share", shareLimiter)
+	shareGroup.Get("/:token", s.storageHandler.GetSharedFile)
+	shareGroup.Post("/:token/download", s.storageHandler.DownloadSharedFile)
+
 	// API 그룹
 	api := s.app.Group("/api")
 
+	// 지원 언어 목록 (인증 불필요, 클라이언트 언어 선택 UI용)
+	api.Get("/languages", s.languageHandler.GetLanguages)
+
+	// 권한 목록 (역할 편집 UI용, 인증 필요)
+	api.Get("/permissions", auth.AuthMiddleware(s.jwtManager), s.roleHandler.GetPermissionCatalog)
+
+	// 합성 모니터링 프로브 (외부 업타임 모니터용, X-Probe-Token 필요)
+	probe := api.Group("/probe", s.probeHandler.RequireProbeToken)
+	probe.Get("/ws-chat", s.probeHandler.ProbeWSChat)
+	probe.Get("/room-pipeline", s.probeHandler.ProbeRoomPipeline)
+
+	// 운영 진단 (프로브와 같은 토큰 사용)
+	diagnostics := api.Group("/diagnostics", s.probeHandler.RequireProbeToken)
+	diagnostics.Get("/ai-backends", s.diagnosticsHandler.AIBackends)
+	diagnostics.Get("/presence", s.diagnosticsHandler.Presence)
+
 	// ... (Existing routes) ...
 	// Poll Routes (Requires Auth)
-	if s.pollHandler != nil {
-		poll := api.Group("/polls", auth.AuthMiddleware(s.jwtManager))
-		poll.Post("", s.pollHandler.CreatePoll)
-		poll.Get("/:id", s.pollHandler.GetPoll)
-		poll.Post("/:id/vote", s.pollHandler.Vote)
-		poll.Post("/:id/close", s.pollHandler.ClosePoll)
-	}
+	poll := api.Group("/polls", auth.AuthMiddleware(s.jwtManager))
+	poll.Post("", s.pollHandler.CreatePoll)
+	poll.Get("", s.pollHandler.ListPolls)
+	poll.Get("/:id", s.pollHandler.GetPoll)
+	poll.Post("/:id/vote", s.pollHandler.Vote)
+	poll.Post("/:id/close", s.pollHandler.ClosePoll)
 
 	// Auth 라우트 그룹
 	authGroup := s.app.Group("/auth")
 	authGroup.Post("/google", authLimiter, s.authHandler.GoogleLogin)
 	authGroup.Post("/refresh", authLimiter, s.authHandler.RefreshToken)
+	authGroup.Post("/register", authLimiter, s.authHandler.Register)
+	authGroup.Post("/login", authLimiter, s.authHandler.Login)
+	authGroup.Post("/verify-email", authLimiter, s.authHandler.VerifyEmail)
+	authGroup.Post("/verify-email/resend", authLimiter, s.authHandler.ResendVerification)
+	authGroup.Post("/password/forgot", authLimiter, s.authHandler.ForgotPassword)
+	authGroup.Post("/password/reset", authLimiter, s.authHandler.ResetPassword)
+	authGroup.Post("/2fa/verify", authLimiter, s.authHandler.VerifyTwoFactor)
 	authGroup.Post("/logout", auth.AuthMiddleware(s.jwtManager), s.authHandler.Logout) // 인증된 사용자만
 	authGroup.Get("/me", auth.AuthMiddleware(s.jwtManager), s.authHandler.GetMe)
+	authGroup.Get("/sessions", auth.AuthMiddleware(s.jwtManager), auth.RejectPersonalToken(), auth.RejectImpersonation(), s.authHandler.ListSessions)
+	authGroup.Delete("/sessions", auth.AuthMiddleware(s.jwtManager), auth.RejectPersonalToken(), auth.RejectImpersonation(), s.authHandler.RevokeOtherSessions)
+	authGroup.Delete("/sessions/:id", auth.AuthMiddleware(s.jwtManager), auth.RejectPersonalToken(), auth.RejectImpersonation(), s.authHandler.RevokeSession)
+	// 개인 액세스 토큰 (토큰으로 다른 토큰을 만들지 못하도록 브라우저 세션에서만)
+	authGroup.Get("/tokens", auth.AuthMiddleware(s.jwtManager), auth.RejectPersonalToken(), auth.RejectImpersonation(), s.authHandler.ListPersonalTokens)
+	authGroup.Post("/tokens", auth.AuthMiddleware(s.jwtManager), auth.RejectPersonalToken(), auth.RejectImpersonation(), s.authHandler.CreatePersonalToken)
+	authGroup.Delete("/tokens/:id", auth.AuthMiddleware(s.jwtManager), auth.RejectPersonalToken(), auth.RejectImpersonation(), s.authHandler.RevokePersonalToken)
+	// 2단계 인증 (TOTP) 등록/해제
+	authGroup.Get("/2fa", auth.AuthMiddleware(s.jwtManager), auth.RejectPersonalToken(), auth.RejectImpersonation(), s.authHandler.GetTwoFactorStatus)
+	authGroup.Post("/2fa/setup", auth.AuthMiddleware(s.jwtManager), auth.RejectPersonalToken(), auth.RejectImpersonation(), s.authHandler.SetupTwoFactor)
+	authGroup.Post("/2fa/enable", authLimiter, auth.AuthMiddleware(s.jwtManager), auth.RejectPersonalToken(), auth.RejectImpersonation(), s.authHandler.EnableTwoFactor)
+	authGroup.Post("/2fa/disable", authLimiter, auth.AuthMiddleware(s.jwtManager), auth.RejectPersonalToken(), auth.RejectImpersonation(), s.authHandler.DisableTwoFactor)
+	authGroup.Post("/2fa/backup-codes", authLimiter, auth.AuthMiddleware(s.jwtManager), auth.RejectPersonalToken(), auth.RejectImpersonation(), s.authHandler.RegenerateBackupCodes)
 	authGroup.Put("/me", auth.AuthMiddleware(s.jwtManager), s.userHandler.UpdateUser)
+	// 프로필 이미지 (S3 Presigned 업로드 후 적용)
+	authGroup.Post("/me/avatar/upload-url", auth.AuthMiddleware(s.jwtManager), s.userHandler.CreateAvatarUploadURL)
+	authGroup.Post("/me/avatar", auth.AuthMiddleware(s.jwtManager), s.userHandler.CompleteAvatarUpload)
+	authGroup.Delete("/me/avatar", auth.AuthMiddleware(s.jwtManager), s.userHandler.DeleteAvatar)
 	authGroup.Put("/me/status", auth.AuthMiddleware(s.jwtManager), s.userHandler.UpdateUserStatus) // 상태 업데이트 엔드포인트 추가
 
+	// Admin 라우트 그룹 (플랫폼 관리자 전용, 대리 접속/개인 액세스 토큰 불가)
+	adminGroup := s.app.Group("/api/admin", auth.AuthMiddleware(s.jwtManager), auth.RejectPersonalToken(), handler.RequirePlatformAdmin(s.db))
+	adminGroup.Get("/impersonations", s.impersonationHandler.GetImpersonationSessions)
+	adminGroup.Post("/impersonations", s.impersonationHandler.StartImpersonation)
+	adminGroup.Post("/impersonations/:id/end", s.impersonationHandler.EndImpersonation)
+	adminGroup.Get("/impersonations/:id/audit", s.impersonationHandler.GetImpersonationAuditLogs)
+	adminGroup.Get("/status/incidents", s.statusHandler.GetIncidents)
+	adminGroup.Post("/status/incidents", s.statusHandler.CreateIncident)
+	adminGroup.Put("/status/incidents/:id", s.statusHandler.UpdateIncident)
+	adminGroup.Get("/users", s.adminHandler.ListUsers)
+	adminGroup.Get("/users/:id", s.adminHandler.GetUser)
+	adminGroup.Post("/users/:id/suspend", s.adminHandler.SuspendUser)
+	adminGroup.Post("/users/:id/unsuspend", s.adminHandler.UnsuspendUser)
+	adminGroup.Post("/users/:id/logout", s.adminHandler.ForceLogoutUser)
+	adminGroup.Get("/workspaces", s.adminHandler.ListWorkspaces)
+	adminGroup.Get("/workspaces/:id", s.adminHandler.GetWorkspace)
+	adminGroup.Get("/usage", s.adminHandler.GetSystemUsage)
+	adminGroup.Get("/audit-logs", s.adminHandler.GetAdminAuditLogs)
+
+	// 개인 일정 (내가 속한 모든 워크스페이스, 인증 필요)
+	api.Get("/me/events", auth.AuthMiddleware(s.jwtManager), s.calendarHandler.GetMyEvents)
+
 	// User 라우트 그룹 (인증 필요)
 	userGroup := s.app.Group("/api/users", auth.AuthMiddleware(s.jwtManager))
 	userGroup.Get("/search", s.userHandler.SearchUsers)
@@ -247,10 +449,22 @@ func (s *Server) SetupRoutes() {
 	// Notification 라우트 그룹 (인증 필요)
 	notificationGroup := s.app.Group("/api/notifications", auth.AuthMiddleware(s.jwtManager))
 	notificationGroup.Get("", s.notificationHandler.GetMyNotifications)
+	notificationGroup.Get("/unread-count", s.notificationHandler.GetUnreadCount)
+	notificationGroup.Post("/read-all", s.notificationHandler.MarkAllAsRead)
+	notificationGroup.Get("/push/config", s.pushHandler.GetPushConfig)
+	notificationGroup.Post("/push/devices", s.pushHandler.RegisterPushDevice)
+	notificationGroup.Delete("/push/devices", s.pushHandler.UnregisterPushDevice)
+	notificationGroup.Get("/email", s.mailHandler.GetEmailPreferences)
+	notificationGroup.Put("/email", s.mailHandler.UpdateEmailPreferences)
+	notificationGroup.Get("/quiet-hours", s.notificationHandler.GetQuietHours)
+	notificationGroup.Put("/quiet-hours", s.notificationHandler.UpdateQuietHours)
 	notificationGroup.Post("/:id/accept", s.notificationHandler.AcceptInvitation)
 	notificationGroup.Post("/:id/decline", s.notificationHandler.DeclineInvitation)
 	notificationGroup.Post("/:id/read", s.notificationHandler.MarkAsRead)
 
+	// 메일 수신 거부 링크 (로그인 없이 토큰으로)
+	s.app.Post("/api/mail/unsubscribe", s.mailHandler.Unsubscribe)
+
 	// Workspace Category 라우트 그룹 (인증 필요)
 	categoryGroup := s.app.Group("/api/workspace-categories", auth.AuthMiddleware(s.jwtManager))
 	categoryGroup.Get("", s.categoryHandler.GetMyCategories)
@@ -260,23 +474,64 @@ func (s *Server) SetupRoutes() {
 	categoryGroup.Post("/:categoryId/workspaces/:workspaceId", s.categoryHandler.AddWorkspaceToCategory)
 	categoryGroup.Delete("/:categoryId/workspaces/:workspaceId", s.categoryHandler.RemoveWorkspaceFromCategory)
 
+	// 캘린더 구독 피드 (캘린더 앱은 ?token= 구독 토큰으로 인증, 워크스페이스 그룹 인증보다 먼저 등록)
+	s.app.Get("/api/workspaces/:workspaceId/events.ics", s.calendarHandler.FeedAuth,
+		handler.DenyGuests(s.db), handler.RequireTwoFactor(s.db), s.calendarHandler.ExportICS)
+
 	// Workspace 라우트 그룹 (인증 필요)
 	workspaceGroup := s.app.Group("/api/workspaces", auth.AuthMiddleware(s.jwtManager))
+	// 게스트(회의 전용) 멤버는 채팅/파일 API 사용 불가
+	workspaceGroup.Use([]string{
+		"/:workspaceId/chats", "/:workspaceId/chatrooms", "/:workspaceId/dm",
+		"/:workspaceId/files", "/:workspaceId/trash", "/:workspaceId/storage", "/:workspaceId/share-links",
+	}, handler.DenyGuests(s.db))
+	// 2단계 인증을 요구하는 워크스페이스
+	workspaceGroup.Use("/:workspaceId", handler.RequireTwoFactor(s.db))
 	workspaceGroup.Post("/", s.workspaceHandler.CreateWorkspace)
 	workspaceGroup.Get("/", s.workspaceHandler.GetMyWorkspaces)
+	workspaceGroup.Get("/discover", s.workspaceHandler.DiscoverWorkspaces)
 	workspaceGroup.Get("/:id", s.workspaceHandler.GetWorkspace)
+	workspaceGroup.Get("/:id/members", s.workspaceHandler.GetMembers)
+	workspaceGroup.Get("/:id/presence", s.workspaceHandler.GetPresenceRoster)
 	workspaceGroup.Post("/:id/members", s.workspaceHandler.AddMembers)
 	workspaceGroup.Delete("/:id/leave", s.workspaceHandler.LeaveWorkspace)
 	workspaceGroup.Put("/:id/members/:userId/role", s.workspaceHandler.UpdateMemberRole)
 	workspaceGroup.Delete("/:id/members/:userId", s.workspaceHandler.KickMember)
 	workspaceGroup.Put("/:id", s.workspaceHandler.UpdateWorkspace)
 	workspaceGroup.Delete("/:id", s.workspaceHandler.DeleteWorkspace)
+	workspaceGroup.Get("/:id/region", s.workspaceHandler.GetDataRegion)
+	workspaceGroup.Put("/:id/region", s.workspaceHandler.UpdateDataRegion)
+	workspaceGroup.Post("/:id/icon", s.workspaceHandler.UploadWorkspaceIcon)
+	workspaceGroup.Delete("/:id/icon", s.workspaceHandler.DeleteWorkspaceIcon)
+	workspaceGroup.Get("/:id/settings", s.workspaceHandler.GetWorkspaceSettings)
+	workspaceGroup.Put("/:id/settings", s.workspaceHandler.UpdateWorkspaceSettings)
+	workspaceGroup.Get("/:id/audit-events", s.workspaceHandler.GetAuditEvents)
+	workspaceGroup.Get("/:id/analytics", s.analyticsHandler.GetWorkspaceAnalytics)
+
+	// 초대 링크 (워크스페이스 하위)
+	workspaceGroup.Post("/:id/invite-links", s.workspaceHandler.CreateInviteLink)
+	workspaceGroup.Get("/:id/invite-links", s.workspaceHandler.GetInviteLinks)
+	workspaceGroup.Delete("/:id/invite-links/:linkId", s.workspaceHandler.RevokeInviteLink)
+	workspaceGroup.Get("/:id/invitations", s.workspaceHandler.GetPendingInvitations)
+	workspaceGroup.Delete("/:id/invitations/:userId", s.workspaceHandler.RevokeInvitation)
+
+	// 공개 워크스페이스 가입 요청
+	workspaceGroup.Post("/:id/join-requests", s.workspaceHandler.CreateJoinRequest)
+	workspaceGroup.Get("/:id/join-requests", s.workspaceHandler.GetJoinRequests)
+	workspaceGroup.Post("/:id/join-requests/:requestId/approve", s.workspaceHandler.ApproveJoinRequest)
+	workspaceGroup.Post("/:id/join-requests/:requestId/deny", s.workspaceHandler.DenyJoinRequest)
+
+	// 초대 링크 수락 (인증 필요)
+	inviteGroup := s.app.Group("/api/invites", auth.AuthMiddleware(s.jwtManager))
+	inviteGroup.Get("/:token", s.workspaceHandler.GetInvite)
+	inviteGroup.Post("/:token/accept", s.workspaceHandler.AcceptInvite)
 
 	// Role 라우트 (워크스페이스 하위)
 	workspaceGroup.Get("/:id/roles", s.roleHandler.GetRoles)
 	workspaceGroup.Post("/:id/roles", s.roleHandler.CreateRole)
 	workspaceGroup.Put("/:id/roles/:roleId", s.roleHandler.UpdateRole)
 	workspaceGroup.Delete("/:id/roles/:roleId", s.roleHandler.DeleteRole)
+	workspaceGroup.Put("/:id/roles/:roleId/members", s.roleHandler.UpdateRoleMembers)
 
 	// Chat 라우트 (워크스페이스 하위) - 레거시
 	workspaceGroup.Get("/:workspaceId/chats", s.chatHandler.GetWorkspaceChats)
@@ -290,6 +545,8 @@ func (s *Server) SetupRoutes() {
 	workspaceGroup.Get("/:workspaceId/chatrooms/:roomId/messages", s.chatHandler.GetChatRoomMessages)
 	workspaceGroup.Post("/:workspaceId/chatrooms/:roomId/messages", s.chatHandler.SendChatRoomMessage)
 	workspaceGroup.Post("/:workspaceId/chatrooms/:roomId/read", s.chatHandler.MarkAsRead)
+	workspaceGroup.Get("/:workspaceId/chatrooms/:roomId/permissions", s.roleHandler.GetChannelOverrides)
+	workspaceGroup.Put("/:workspaceId/chatrooms/:roomId/permissions", s.roleHandler.UpdateChannelOverrides)
 
 	// Meeting 라우트 (워크스페이스 하위)
 	workspaceGroup.Get("/:workspaceId/meetings", s.meetingHandler.GetWorkspaceMeetings)
@@ -297,11 +554,47 @@ func (s *Server) SetupRoutes() {
 	workspaceGroup.Get("/:workspaceId/meetings/:meetingId", s.meetingHandler.GetMeeting)
 	workspaceGroup.Post("/:workspaceId/meetings/:meetingId/start", s.meetingHandler.StartMeeting)
 
+	// 워크스페이스 화이트보드 (미팅에 속하지 않는 이름 있는 보드)
+	workspaceGroup.Get("/:workspaceId/whiteboards", s.whiteboardHandler.ListWorkspaceBoards)
+	workspaceGroup.Post("/:workspaceId/whiteboards", s.whiteboardHandler.CreateWorkspaceBoard)
+
 	// DM 라우트
 	workspaceGroup.Post("/:workspaceId/dm", s.chatHandler.GetOrCreateDMRoom)
 	workspaceGroup.Get("/:workspaceId/dm", s.chatHandler.GetMyDMs)
 	workspaceGroup.Post("/:workspaceId/meetings/:meetingId/end", s.meetingHandler.EndMeeting)
 
+	// Recording Consent 라우트 (미팅 하위)
+	workspaceGroup.Put("/:workspaceId/meetings/:meetingId/recording", s.meetingHandler.UpdateRecordingSettings)
+	workspaceGroup.Get("/:workspaceId/meetings/:meetingId/consent", s.meetingHandler.GetMyRecordingConsent)
+	workspaceGroup.Post("/:workspaceId/meetings/:meetingId/consent", s.meetingHandler.GiveRecordingConsent)
+	workspaceGroup.Delete("/:workspaceId/meetings/:meetingId/consent", s.meetingHandler.RevokeRecordingConsent)
+	workspaceGroup.Get("/:workspaceId/meetings/:meetingId/consents", s.meetingHandler.GetRecordingConsents)
+	workspaceGroup.Get("/:workspaceId/meetings/:meetingId/permissions", s.roleHandler.GetChannelOverrides)
+	workspaceGroup.Put("/:workspaceId/meetings/:meetingId/permissions", s.roleHandler.UpdateChannelOverrides)
+	workspaceGroup.Post("/:workspaceId/meetings/:meetingId/guests", s.workspaceHandler.InviteMeetingGuest)
+
+	// 회의록 (음성 기록 → Markdown/PDF 파일)
+	workspaceGroup.Get("/:workspaceId/meetings/:meetingId/minutes", s.meetingMinutesHandler.GetMinutes)
+	workspaceGroup.Post("/:workspaceId/meetings/:meetingId/minutes", s.meetingMinutesHandler.ExportMinutes)
+
+	// Glossary 라우트 (번역 사용자 지정 용어)
+	workspaceGroup.Get("/:workspaceId/glossary", s.glossaryHandler.GetGlossary)
+	workspaceGroup.Post("/:workspaceId/glossary", s.glossaryHandler.CreateGlossaryTerm)
+	workspaceGroup.Put("/:workspaceId/glossary/:termId", s.glossaryHandler.UpdateGlossaryTerm)
+	workspaceGroup.Delete("/:workspaceId/glossary/:termId", s.glossaryHandler.DeleteGlossaryTerm)
+
+	// Redaction 라우트 (음성 파이프라인 비속어/개인정보 가림)
+	workspaceGroup.Get("/:workspaceId/redaction", s.redactionHandler.GetRedaction)
+	workspaceGroup.Put("/:workspaceId/redaction", s.redactionHandler.UpdateRedaction)
+
+	// 번역 언어 라우트 (Room 기본 번역 대상 및 리스너 허용 언어)
+	workspaceGroup.Get("/:workspaceId/languages", s.languageHandler.GetWorkspaceLanguages)
+	workspaceGroup.Put("/:workspaceId/languages", s.languageHandler.UpdateWorkspaceLanguages)
+
+	// AI 사용량 / 월간 한도 (ADMIN)
+	workspaceGroup.Get("/:workspaceId/ai-usage", s.aiUsageHandler.GetAIUsage)
+	workspaceGroup.Put("/:workspaceId/ai-usage/limits", s.aiUsageHandler.UpdateAIUsageLimits)
+
 	// Voice Record 라우트 (미팅 하위)
 	workspaceGroup.Get("/:workspaceId/meetings/:meetingId/voice-records", s.voiceRecordHandler.GetVoiceRecords)
 	workspaceGroup.Post("/:workspaceId/meetings/:meetingId/voice-records", s.voiceRecordHandler.CreateVoiceRecord)
@@ -311,9 +604,22 @@ func (s *Server) SetupRoutes() {
 	// Calendar 라우트 (워크스페이스 하위)
 	workspaceGroup.Get("/:workspaceId/events", s.calendarHandler.GetWorkspaceEvents)
 	workspaceGroup.Post("/:workspaceId/events", s.calendarHandler.CreateEvent)
+	workspaceGroup.Post("/:workspaceId/events/feed-token", s.calendarHandler.CreateFeedToken)
+	workspaceGroup.Delete("/:workspaceId/events/feed-token", s.calendarHandler.RevokeFeedToken)
+	workspaceGroup.Post("/:workspaceId/events/import", s.calendarHandler.ImportICS)
 	workspaceGroup.Put("/:workspaceId/events/:eventId", s.calendarHandler.UpdateEvent)
 	workspaceGroup.Delete("/:workspaceId/events/:eventId", s.calendarHandler.DeleteEvent)
 	workspaceGroup.Put("/:workspaceId/events/:eventId/status", s.calendarHandler.UpdateAttendeeStatus)
+	workspaceGroup.Put("/:workspaceId/events/:eventId/organizers", s.calendarHandler.UpdateEventOrganizers)
+	workspaceGroup.Post("/:workspaceId/events/:eventId/attachments", s.calendarHandler.AddEventAttachment)
+	workspaceGroup.Delete("/:workspaceId/events/:eventId/attachments/:attachmentId", s.calendarHandler.DeleteEventAttachment)
+	workspaceGroup.Put("/:workspaceId/events/:eventId/occurrences", s.calendarHandler.UpdateEventOccurrence)
+	workspaceGroup.Delete("/:workspaceId/events/:eventId/occurrences", s.calendarHandler.CancelEventOccurrence)
+	workspaceGroup.Put("/:workspaceId/events/:eventId/reminders", s.calendarHandler.UpdateMyReminders)
+	workspaceGroup.Get("/:workspaceId/events/:eventId/comments", s.calendarHandler.GetEventComments)
+	workspaceGroup.Post("/:workspaceId/events/:eventId/comments", s.calendarHandler.CreateEventComment)
+	workspaceGroup.Put("/:workspaceId/events/:eventId/comments/:commentId", s.calendarHandler.UpdateEventComment)
+	workspaceGroup.Delete("/:workspaceId/events/:eventId/comments/:commentId", s.calendarHandler.DeleteEventComment)
 
 	// Storage 라우트 (워크스페이스 하위)
 	workspaceGroup.Get("/:workspaceId/files", s.storageHandler.GetWorkspaceFiles)
@@ -321,17 +627,77 @@ func (s *Server) SetupRoutes() {
 	workspaceGroup.Post("/:workspaceId/files", s.storageHandler.UploadFile)
 	workspaceGroup.Delete("/:workspaceId/files/:fileId", s.storageHandler.DeleteFile)
 	workspaceGroup.Put("/:workspaceId/files/:fileId", s.storageHandler.RenameFile)
+	workspaceGroup.Put("/:workspaceId/files/:fileId/move", s.storageHandler.MoveFile)
+
+	// 저장 용량 한도/사용량
+	workspaceGroup.Get("/:workspaceId/storage/usage", s.storageHandler.GetStorageUsage)
+	workspaceGroup.Put("/:workspaceId/storage/quota", s.storageHandler.UpdateStorageQuota)
+
+	// 휴지통 (보관 기간이 지나면 자동 영구 삭제, 영구 삭제는 관리자만)
+	workspaceGroup.Get("/:workspaceId/trash", s.storageHandler.GetTrash)
+	workspaceGroup.Delete("/:workspaceId/trash", s.storageHandler.EmptyTrash)
+	workspaceGroup.Post("/:workspaceId/trash/:fileId/restore", s.storageHandler.RestoreFile)
+	workspaceGroup.Delete("/:workspaceId/trash/:fileId", s.storageHandler.PurgeTrashItem)
+
+	// 파일 활동 기록 (감사 로그) / 채팅방 알림 설정
+	workspaceGroup.Get("/:workspaceId/files/activity", s.storageHandler.GetFileActivities)
+	workspaceGroup.Get("/:workspaceId/files/activity/settings", s.storageHandler.GetFileActivitySettings)
+	workspaceGroup.Put("/:workspaceId/files/activity/settings", s.storageHandler.UpdateFileActivitySettings)
+
+	// 외부 공유 링크
+	workspaceGroup.Post("/:workspaceId/files/:fileId/share-links", s.storageHandler.CreateShareLink)
+	workspaceGroup.Post("/:workspaceId/files/:fileId/share", s.storageHandler.ShareWithMembers)
+	workspaceGroup.Get("/:workspaceId/files/:fileId/share-links", s.storageHandler.GetShareLinks)
+	workspaceGroup.Delete("/:workspaceId/share-links/:linkId", s.storageHandler.RevokeShareLink)
 
 	// S3 파일 업로드 라우트
 	workspaceGroup.Post("/:workspaceId/files/presign", s.storageHandler.GetPresignedURL)
 	workspaceGroup.Post("/:workspaceId/files/confirm", s.storageHandler.ConfirmUpload)
+	workspaceGroup.Post("/:workspaceId/files/multipart", s.storageHandler.InitiateMultipartUpload)
+	workspaceGroup.Post("/:workspaceId/files/multipart/parts", s.storageHandler.SignMultipartParts)
+	workspaceGroup.Post("/:workspaceId/files/multipart/complete", s.storageHandler.CompleteMultipartUpload)
+	workspaceGroup.Post("/:workspaceId/files/multipart/abort", s.storageHandler.AbortMultipartUpload)
 	workspaceGroup.Get("/:workspaceId/files/:fileId/download", s.storageHandler.GetDownloadURL)
+	workspaceGroup.Get("/:workspaceId/files/:folderId/download-zip", s.storageHandler.DownloadFolderZip)
+	workspaceGroup.Post("/:workspaceId/files/:fileId/transcribe", s.storageHandler.TranscribeFile)
+	workspaceGroup.Get("/:workspaceId/files/:fileId/transcript", s.storageHandler.GetFileTranscript)
+	workspaceGroup.Post("/:workspaceId/files/:fileId/text", s.storageHandler.ExtractFileText)
+	workspaceGroup.Get("/:workspaceId/files/:fileId/text", s.storageHandler.GetFileText)
+	workspaceGroup.Get("/:workspaceId/files/zip-jobs/:jobId", s.storageHandler.GetZipJob)
+	workspaceGroup.Get("/:workspaceId/files/:folderId/permissions", s.storageHandler.GetFolderPermissions)
+	workspaceGroup.Put("/:workspaceId/files/:folderId/permissions", s.storageHandler.UpdateFolderPermissions)
+
+	// 폴더 구독 (파일 변경 알림/웹훅)
+	workspaceGroup.Get("/:workspaceId/files/watches", s.storageHandler.GetMyFolderWatches)
+	workspaceGroup.Get("/:workspaceId/files/:folderId/watch", s.storageHandler.GetFolderWatch)
+	workspaceGroup.Put("/:workspaceId/files/:folderId/watch", s.storageHandler.WatchFolder)
+	workspaceGroup.Delete("/:workspaceId/files/:folderId/watch", s.storageHandler.UnwatchFolder)
+
+	// 파일 검색 (이름 + 추출한 문서 본문)
+	workspaceGroup.Get("/:workspaceId/files/search", s.storageHandler.SearchFiles)
+
+	// 최근 파일 / 즐겨찾기 (스토리지 홈)
+	workspaceGroup.Get("/:workspaceId/files/home", s.storageHandler.GetStorageHome)
+	workspaceGroup.Get("/:workspaceId/files/recent", s.storageHandler.GetRecentFiles)
+	workspaceGroup.Get("/:workspaceId/files/starred", s.storageHandler.GetStarredFiles)
+	workspaceGroup.Put("/:workspaceId/files/:fileId/star", s.storageHandler.StarFile)
+	workspaceGroup.Delete("/:workspaceId/files/:fileId/star", s.storageHandler.UnstarFile)
 
 	// Video Call 라우트
 	s.app.Post("/api/video/token", auth.AuthMiddleware(s.jwtManager), s.videoHandler.GenerateToken)
 	s.app.Get("/api/video/participants", auth.AuthMiddleware(s.jwtManager), s.videoHandler.GetRoomParticipants)
 	s.app.Get("/api/video/rooms/participants", auth.AuthMiddleware(s.jwtManager), s.videoHandler.GetAllRoomsParticipants)
 
+	// 음성 Room 일회용 입장 토큰 (/ws/audio, /ws/room ?token=)
+	s.app.Post("/api/room/join-token", auth.AuthMiddleware(s.jwtManager), s.joinTokenHandler.CreateJoinToken)
+
+	// 일회용 WebSocket 티켓 (쿠키를 못 쓰는 클라이언트가 모든 /ws 엔드포인트에 ?ticket= 으로 연결)
+	s.app.Post("/api/ws/ticket", auth.AuthMiddleware(s.jwtManager), s.joinTokenHandler.CreateWSTicket)
+
+	// 음성 Room 번역 설정 (TTS, 부분 자막 간격, 최소 신뢰도, 허용 언어 - 진행 중인 Room에 즉시 적용)
+	s.app.Get("/api/room/:roomId/translation-settings", auth.AuthMiddleware(s.jwtManager), s.translationSettingsHandler.GetTranslationSettings)
+	s.app.Put("/api/room/:roomId/translation-settings", auth.AuthMiddleware(s.jwtManager), s.translationSettingsHandler.UpdateTranslationSettings)
+
 	// Room Transcripts API (실시간 음성 기록 동기화)
 	s.app.Get("/api/room/:roomId/transcripts", s.handleGetRoomTranscripts)
 
@@ -339,6 +705,20 @@ func (s *Server) SetupRoutes() {
 	// Whiteboard 라우트
 	s.app.Get("/api/whiteboard", auth.AuthMiddleware(s.jwtManager), s.whiteboardHandler.GetWhiteboard)
 	s.app.Post("/api/whiteboard", auth.AuthMiddleware(s.jwtManager), s.whiteboardHandler.HandleWhiteboard)
+	// 미팅의 이름 있는 보드 (보드 선택기, ?board= 로 기록 조회/저장)
+	s.app.Get("/api/whiteboard/boards", auth.AuthMiddleware(s.jwtManager), s.whiteboardHandler.ListMeetingBoards)
+	s.app.Post("/api/whiteboard/boards", auth.AuthMiddleware(s.jwtManager), s.whiteboardHandler.CreateMeetingBoard)
+	s.app.Patch("/api/whiteboard/boards/:boardId", auth.AuthMiddleware(s.jwtManager), s.whiteboardHandler.RenameBoard)
+	// 보기 전용 모드 (발표자만 변경, 연결된 클라이언트에 mode 이벤트)
+	s.app.Put("/api/whiteboard/view-only", auth.AuthMiddleware(s.jwtManager), s.whiteboardHandler.SetViewOnly)
+	// 체크포인트 (?room=&board= 로 보드 지정, 복원 전 내용은 휴지통 체크포인트로 보관)
+	s.app.Get("/api/whiteboard/checkpoints", auth.AuthMiddleware(s.jwtManager), s.whiteboardHandler.ListCheckpoints)
+	s.app.Post("/api/whiteboard/checkpoints", auth.AuthMiddleware(s.jwtManager), s.whiteboardHandler.CreateCheckpoint)
+	s.app.Post("/api/whiteboard/checkpoints/:checkpointId/restore", auth.AuthMiddleware(s.jwtManager), s.whiteboardHandler.RestoreCheckpoint)
+
+	// 화이트보드 이미지 객체 (워크스페이스 버킷에 업로드, 멤버만 조회)
+	s.app.Post("/api/whiteboard/images/upload-url", auth.AuthMiddleware(s.jwtManager), s.whiteboardHandler.CreateImageUploadURL)
+	s.app.Get("/api/whiteboard/images", auth.AuthMiddleware(s.jwtManager), s.whiteboardHandler.GetImage)
 
 	// WebSocket 업그레이드 체크 미들웨어
 	s.app.Use("/ws", func(c *fiber.Ctx) error {
@@ -350,29 +730,20 @@ func (s *Server) SetupRoutes() {
 	})
 
 	// WebSocket 오디오 스트리밍 엔드포인트
-	s.app.Get("/ws/audio", func(c *fiber.Ctx) error {
+	s.app.Get("/ws/audio", s.joinTokenHandler.RequireJoinToken, s.joinTokenHandler.OptionalWSAuth, func(c *fiber.Ctx) error {
 		if !websocket.IsWebSocketUpgrade(c) {
 			return fiber.ErrUpgradeRequired
 		}
 
 		// 소스 언어 파라미터 추출 (발화자가 말하는 언어, 기본값: ko)
-		sourceLang := c.Query("sourceLang", "ko")
-		switch sourceLang {
-		case "ko", "en", "ja", "zh":
-			// 유효한 언어
-		default:
-			sourceLang = "ko"
+		sourceLang := language.NormalizeOr(c.Query("sourceLang"), language.DefaultSource)
+		if !language.SupportsSTT(sourceLang) {
+			sourceLang = language.DefaultSource
 		}
 		c.Locals("sourceLang", sourceLang)
 
 		// 타겟 언어 파라미터 추출 (듣고 싶은 언어, 기본값: en)
-		targetLang := c.Query("targetLang", "en")
-		switch targetLang {
-		case "ko", "en", "ja", "zh":
-			// 유효한 언어
-		default:
-			targetLang = "en"
-		}
+		targetLang := language.NormalizeOr(c.Query("targetLang"), language.DefaultTarget)
 		c.Locals("targetLang", targetLang)
 
 		// 기존 lang 파라미터도 지원 (하위 호환성)
@@ -394,14 +765,15 @@ func (s *Server) SetupRoutes() {
 		c.Locals("listenerId", listenerId)
 
 		return c.Next()
-	}, websocket.New(s.handler.HandleWebSocket, websocket.Config{
+	}, websocket.New(handler.WithCloseCodes(s.handler.HandleWebSocket), websocket.Config{
 		ReadBufferSize:  s.cfg.WebSocket.ReadBufferSize,
 		WriteBufferSize: s.cfg.WebSocket.WriteBufferSize,
 	}))
 
 	// WebSocket Room 기반 오디오 스트리밍 엔드포인트 (새로운 아키텍처)
 	// Room당 1 gRPC 스트림 공유로 연결 효율화 (N² → N)
-	s.app.Get("/ws/room", func(c *fiber.Ctx) error {
+	// 입장 토큰/티켓/Authorization 헤더/쿠키로 사용자 확인 후 CONNECT_MEDIA 권한 검증 (ROOM_IDENTITY_REQUIRED)
+	s.app.Get("/ws/room", s.joinTokenHandler.RequireJoinToken, s.joinTokenHandler.OptionalWSAuth, s.roomIdentityHandler.VerifyRoomIdentity, func(c *fiber.Ctx) error {
 		if !websocket.IsWebSocketUpgrade(c) {
 			return fiber.ErrUpgradeRequired
 		}
@@ -409,90 +781,75 @@ func (s *Server) SetupRoutes() {
 		// Room ID (필수)
 		roomId := c.Query("roomId", "")
 		if roomId == "" {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"error": "roomId is required",
-			})
+			return handler.RejectWebSocket(c, handler.WSCloseInvalidRequest, "roomId is required")
 		}
 		c.Locals("roomId", roomId)
 
 		// Listener ID (필수) - 듣는 사람의 identity
 		listenerId := c.Query("listenerId", "")
 		if listenerId == "" {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"error": "listenerId is required",
-			})
+			return handler.RejectWebSocket(c, handler.WSCloseInvalidRequest, "listenerId is required")
 		}
 		c.Locals("listenerId", listenerId)
 
-		// Target Language (선택, 기본값: en)
-		targetLang := c.Query("targetLang", "en")
-		switch targetLang {
-		case "ko", "en", "ja", "zh":
-			// 유효한 언어
-		default:
-			targetLang = "en"
-		}
+		// Target Language (선택, 기본값: en - 지정하지 않았으면 워크스페이스 기본 언어로 대체될 수 있음)
+		targetLang := language.NormalizeOr(c.Query("targetLang"), language.DefaultTarget)
 		c.Locals("targetLang", targetLang)
+		c.Locals("targetLangExplicit", c.Query("targetLang") != "")
+
+		// TTS 스트리밍 수신 여부 (기본값: 전체 MP3 한 번에)
+		c.Locals("audioStream", c.Query("audioStream") == "1")
+
+		// 바이너리 메시지 형식 (선택, 2 = 화자/언어 헤더가 붙은 프레임)
+		c.Locals("listenerProtocol", c.Query("protocol"))
+
+		// 재접속 토큰 (선택, 이전 ready 응답의 resumeToken)
+		c.Locals("resumeToken", c.Query("resumeToken"))
 
 		return c.Next()
-	}, websocket.New(s.handler.HandleRoomWebSocket, websocket.Config{
+	}, websocket.New(handler.WithCloseCodes(s.handler.HandleRoomWebSocket), websocket.Config{
 		ReadBufferSize:  s.cfg.WebSocket.ReadBufferSize,
 		WriteBufferSize: s.cfg.WebSocket.WriteBufferSize,
 	}))
 
-	// WebSocket 알림 엔드포인트
-	s.app.Get("/ws/notifications", func(c *fiber.Ctx) error {
-		if !websocket.IsWebSocketUpgrade(c) {
-			return fiber.ErrUpgradeRequired
-		}
-
-		// 쿠키에서 JWT 토큰 추출
-		accessToken := c.Cookies("access_token")
-		if accessToken == "" {
-			// WebSocket은 JSON 응답 대신 연결 거부
-			return c.SendStatus(fiber.StatusUnauthorized)
-		}
+	// WebSocket 채팅 프로브 엔드포인트 (/api/probe/ws-chat 전용, 프로브 채팅방만 사용)
+	s.app.Get("/ws/probe/chat", s.probeHandler.RequireProbeToken, s.probeHandler.PrepareChatProbe,
+		websocket.New(handler.WithCloseCodes(s.chatWSHandler.HandleWebSocket), websocket.Config{
+			ReadBufferSize:  4096,
+			WriteBufferSize: 4096,
+		}))
 
-		// JWT 검증
-		claims, err := s.jwtManager.ValidateAccessToken(accessToken)
-		if err != nil {
-			return c.SendStatus(fiber.StatusUnauthorized)
+	// WebSocket 알림 엔드포인트
+	s.app.Get("/ws/notifications", s.joinTokenHandler.RequireWSAuth, func(c *fiber.Ctx) error {
+		claims, ok := c.Locals("claims").(*auth.Claims)
+		if !ok {
+			// RequireWSAuth에서 이미 거부됨 (종료 코드는 WithCloseCodes가 전달)
+			return c.Next()
 		}
-
 		c.Locals("userId", claims.UserID)
 
 		return c.Next()
-	}, websocket.New(s.notificationWSHandler.HandleWebSocket, websocket.Config{
+	}, websocket.New(handler.WithCloseCodes(s.notificationWSHandler.HandleWebSocket), websocket.Config{
 		ReadBufferSize:  4096,
 		WriteBufferSize: 4096,
 	}))
 
 	// WebSocket 채팅 엔드포인트 (roomId 기반)
-	s.app.Get("/ws/chat/:workspaceId/:roomId", func(c *fiber.Ctx) error {
-		if !websocket.IsWebSocketUpgrade(c) {
-			return fiber.ErrUpgradeRequired
-		}
-
-		// 쿠키에서 JWT 토큰 추출
-		accessToken := c.Cookies("access_token")
-		if accessToken == "" {
-			return c.SendStatus(fiber.StatusUnauthorized)
-		}
-
-		// JWT 검증
-		claims, err := s.jwtManager.ValidateAccessToken(accessToken)
-		if err != nil {
-			return c.SendStatus(fiber.StatusUnauthorized)
+	s.app.Get("/ws/chat/:workspaceId/:roomId", s.joinTokenHandler.RequireWSAuth, func(c *fiber.Ctx) error {
+		claims, ok := c.Locals("claims").(*auth.Claims)
+		if !ok {
+			// RequireWSAuth에서 이미 거부됨 (종료 코드는 WithCloseCodes가 전달)
+			return c.Next()
 		}
 
 		workspaceID, err := c.ParamsInt("workspaceId")
 		if err != nil {
-			return c.SendStatus(fiber.StatusBadRequest)
+			return handler.RejectWebSocket(c, handler.WSCloseInvalidRequest, "invalid workspace id")
 		}
 
 		roomID, err := c.ParamsInt("roomId")
 		if err != nil {
-			return c.SendStatus(fiber.StatusBadRequest)
+			return handler.RejectWebSocket(c, handler.WSCloseInvalidRequest, "invalid room id")
 		}
 
 		// 멤버 확인 (ACTIVE 상태만)
@@ -501,7 +858,15 @@ func (s *Server) SetupRoutes() {
 			Where("workspace_id = ? AND user_id = ? AND status = ?", workspaceID, claims.UserID, "ACTIVE").
 			Count(&count)
 		if count == 0 {
-			return c.SendStatus(fiber.StatusForbidden)
+			return handler.RejectWebSocket(c, handler.WSCloseForbidden, "not a workspace member")
+		}
+		if guest, _ := auth.IsWorkspaceGuest(s.db, int64(workspaceID), claims.UserID); guest {
+			return handler.RejectWebSocket(c, handler.WSCloseForbidden, "guests cannot join chat")
+		}
+		if satisfied, err := handler.WorkspaceTwoFactorSatisfied(s.db, int64(workspaceID), claims.UserID); err != nil {
+			return handler.RejectWebSocket(c, handler.WSCloseInternalError, "permission check failed")
+		} else if !satisfied {
+			return handler.RejectWebSocket(c, handler.WSCloseForbidden, "this workspace requires two-factor authentication")
 		}
 
 		// 채팅방이 해당 워크스페이스에 속하는지 확인
@@ -510,7 +875,7 @@ func (s *Server) SetupRoutes() {
 			Where("id = ? AND workspace_id = ? AND type IN ?", roomID, workspaceID, []string{model.MeetingTypeChatRoom.String(), model.MeetingTypeDM.String()}).
 			Count(&roomCount)
 		if roomCount == 0 {
-			return c.SendStatus(fiber.StatusNotFound)
+			return handler.RejectWebSocket(c, handler.WSCloseNotFound, "chat room not found")
 		}
 
 		// 유저 정보 조회
@@ -525,32 +890,30 @@ func (s *Server) SetupRoutes() {
 		c.Locals("nickname", user.Nickname)
 
 		return c.Next()
-	}, websocket.New(s.chatWSHandler.HandleWebSocket, websocket.Config{
+	}, websocket.New(handler.WithCloseCodes(s.chatWSHandler.HandleWebSocket), websocket.Config{
 		ReadBufferSize:  4096,
 		WriteBufferSize: 4096,
 	}))
 
-	// WebSocket 음성 참가자 엔드포인트
-	s.app.Get("/ws/voice-participants/:workspaceId", func(c *fiber.Ctx) error {
-		if !websocket.IsWebSocketUpgrade(c) {
-			return fiber.ErrUpgradeRequired
-		}
-
-		// 쿠키에서 JWT 토큰 추출
-		accessToken := c.Cookies("access_token")
-		if accessToken == "" {
-			return c.SendStatus(fiber.StatusUnauthorized)
-		}
+	// WebSocket 화이트보드 엔드포인트 (보드별 허브가 add/undo/redo/clear를 브로드캐스트하고 모아서 저장)
+	// /ws/whiteboard/:room = 미팅 기본 보드, ?board= = 이름 있는 보드 (워크스페이스 보드는 room 생략)
+	s.app.Get("/ws/whiteboard/:room?", s.joinTokenHandler.RequireWSAuth, s.whiteboardHandler.ResolveBoard,
+		websocket.New(handler.WithCloseCodes(s.whiteboardHandler.HandleWebSocket), websocket.Config{
+			ReadBufferSize:  16 * 1024,
+			WriteBufferSize: 16 * 1024,
+		}))
 
-		// JWT 검증
-		claims, err := s.jwtManager.ValidateAccessToken(accessToken)
-		if err != nil {
-			return c.SendStatus(fiber.StatusUnauthorized)
+	// WebSocket 음성 참가자 엔드포인트
+	s.app.Get("/ws/voice-participants/:workspaceId", s.joinTokenHandler.RequireWSAuth, func(c *fiber.Ctx) error {
+		claims, ok := c.Locals("claims").(*auth.Claims)
+		if !ok {
+			// RequireWSAuth에서 이미 거부됨 (종료 코드는 WithCloseCodes가 전달)
+			return c.Next()
 		}
 
 		workspaceID, err := c.ParamsInt("workspaceId")
 		if err != nil {
-			return c.SendStatus(fiber.StatusBadRequest)
+			return handler.RejectWebSocket(c, handler.WSCloseInvalidRequest, "invalid workspace id")
 		}
 
 		// 멤버 확인 (ACTIVE 상태만)
@@ -559,14 +922,22 @@ func (s *Server) SetupRoutes() {
 			Where("workspace_id = ? AND user_id = ? AND status = ?", workspaceID, claims.UserID, "ACTIVE").
 			Count(&count)
 		if count == 0 {
-			return c.SendStatus(fiber.StatusForbidden)
+			return handler.RejectWebSocket(c, handler.WSCloseForbidden, "not a workspace member")
+		}
+		if guest, _ := auth.IsWorkspaceGuest(s.db, int64(workspaceID), claims.UserID); guest {
+			return handler.RejectWebSocket(c, handler.WSCloseForbidden, "guests cannot watch workspace voice channels")
+		}
+		if satisfied, err := handler.WorkspaceTwoFactorSatisfied(s.db, int64(workspaceID), claims.UserID); err != nil {
+			return handler.RejectWebSocket(c, handler.WSCloseInternalError, "permission check failed")
+		} else if !satisfied {
+			return handler.RejectWebSocket(c, handler.WSCloseForbidden, "this workspace requires two-factor authentication")
 		}
 
 		c.Locals("workspaceId", int64(workspaceID))
 		c.Locals("userId", claims.UserID)
 
 		return c.Next()
-	}, websocket.New(s.voiceParticipantsWSHandler.HandleWebSocket, websocket.Config{
+	}, websocket.New(handler.WithCloseCodes(s.voiceParticipantsWSHandler.HandleWebSocket), websocket.Config{
 		ReadBufferSize:  4096,
 		WriteBufferSize: 4096,
 	}))
@@ -581,6 +952,7 @@ func (s *Server) Start() error {
 	go func() {
 		<-quit
 		log.Println("🛑 Shutting down server...")
+		handler.CloseAllWebSockets(handler.WSCloseServiceRestart, "server restarting")
 		if err := s.app.ShutdownWithTimeout(30 * time.Second); err != nil {
 			log.Fatalf("Server shutdown error: %v", err)
 		}
@@ -589,7 +961,22 @@ func (s *Server) Start() error {
 	log.Printf("🚀 Realtime Voice AI Gateway starting on %s", s.cfg.Server.Port)
 	log.Printf("📡 WebSocket endpoint: ws://localhost%s/ws/audio", s.cfg.Server.Port)
 
-	return s.app.Listen(s.cfg.Server.Port)
+	err := s.app.Listen(s.cfg.Server.Port)
+
+	// 대기 중인 자막 DB 저장 및 AI/Redis 연결 정리
+	s.handler.Close()
+	s.storageHandler.Close()
+	s.analyticsHandler.Close()
+	s.calendarHandler.Close()
+	s.notificationHandler.Close()
+	s.pushHandler.Close()
+	s.mailHandler.Close()
+	s.userHandler.Close()
+	s.whiteboardHandler.Close()
+	s.pollHandler.Close()
+	s.presenceManager.Close()
+	errorreport.Flush(5 * time.Second)
+	return err
 }
 
 // Shutdown 서버 종료
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"log"
+	"time"
+
+	"realtime-backend/internal/auth"
+	"realtime-backend/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// ImpersonationService 관리자 대리 접속 세션 확인 및 감사 기록 (auth.ImpersonationStore 구현)
+type ImpersonationService struct {
+	db *gorm.DB
+}
+
+// NewImpersonationService ImpersonationService 생성
+func NewImpersonationService(db *gorm.DB) *ImpersonationService {
+	return &ImpersonationService{db: db}
+}
+
+// IsActive 세션이 종료되지 않았고 만료 전인지 확인
+func (s *ImpersonationService) IsActive(sessionID int64) bool {
+	var session model.ImpersonationSession
+	if err := s.db.Select("id", "expires_at", "ended_at").First(&session, sessionID).Error; err != nil {
+		return false
+	}
+	return session.IsActive()
+}
+
+// RecordAccess 대리 접속 중 요청을 감사 기록으로 남김
+func (s *ImpersonationService) RecordAccess(claim *auth.ImpersonationClaim, targetUserID int64, method, path string, status int, blocked bool) {
+	action := model.ImpersonationActionRequest
+	if blocked {
+		action = model.ImpersonationActionBlocked
+	}
+
+	log.Printf("🚨 [IMPERSONATION] session=%d admin=%d as user=%d %s %s -> %d (%s)",
+		claim.SessionID, claim.AdminID, targetUserID, method, path, status, action)
+
+	if len(path) > 500 {
+		path = path[:500]
+	}
+	s.Record(&model.ImpersonationAuditLog{
+		SessionID:    claim.SessionID,
+		AdminID:      claim.AdminID,
+		TargetUserID: targetUserID,
+		Action:       action.String(),
+		Method:       &method,
+		Path:         &path,
+		StatusCode:   &status,
+	})
+}
+
+// Record 감사 기록 저장 (실패해도 요청은 계속 처리)
+func (s *ImpersonationService) Record(entry *model.ImpersonationAuditLog) {
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+	if err := s.db.Create(entry).Error; err != nil {
+		log.Printf("🚨 [IMPERSONATION] Failed to write audit log (session=%d): %v", entry.SessionID, err)
+	}
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"realtime-backend/internal/auth"
+	"realtime-backend/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// personalTokenTouchInterval 마지막 사용 시각은 이 간격으로만 갱신 (요청마다 쓰지 않도록)
+const personalTokenTouchInterval = time.Minute
+
+// PersonalTokenService 개인 액세스 토큰 조회 (auth.PersonalTokenStore 구현)
+type PersonalTokenService struct {
+	db *gorm.DB
+}
+
+// NewPersonalTokenService PersonalTokenService 생성
+func NewPersonalTokenService(db *gorm.DB) *PersonalTokenService {
+	return &PersonalTokenService{db: db}
+}
+
+// LookupPersonalToken 해시로 유효한 토큰과 소유자 조회 (없거나 취소/만료되면 nil)
+func (s *PersonalTokenService) LookupPersonalToken(hash string) (*auth.PersonalToken, error) {
+	var token model.PersonalAccessToken
+	if err := s.db.Where("token_hash = ?", hash).First(&token).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if !token.IsActive() {
+		return nil, nil
+	}
+
+	var user model.User
+	if err := s.db.Select("id", "email", "nickname", "suspended_at").First(&user, token.UserID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if user.SuspendedAt != nil {
+		return nil, nil // 정지된 계정의 토큰은 정지 해제 전까지 거부
+	}
+
+	// 캐시에서 놓쳤을 때만 조회되므로 대략적인 마지막 사용 시각
+	if token.LastUsedAt == nil || time.Since(*token.LastUsedAt) > personalTokenTouchInterval {
+		if err := s.db.Model(&model.PersonalAccessToken{}).Where("id = ?", token.ID).
+			Update("last_used_at", time.Now()).Error; err != nil {
+			log.Printf("⚠️ 개인 액세스 토큰 사용 시각 갱신 실패: token=%d, err=%v", token.ID, err)
+		}
+	}
+
+	return &auth.PersonalToken{
+		ID:       token.ID,
+		UserID:   user.ID,
+		Email:    user.Email,
+		Nickname: user.Nickname,
+		Scopes:   token.ScopeList(),
+	}, nil
+}
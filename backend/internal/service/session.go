@@ -0,0 +1,26 @@
+package service
+
+import (
+	"realtime-backend/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// SessionService 로그인 세션 유효성 확인 (auth.SessionStore 구현)
+type SessionService struct {
+	db *gorm.DB
+}
+
+// NewSessionService SessionService 생성
+func NewSessionService(db *gorm.DB) *SessionService {
+	return &SessionService{db: db}
+}
+
+// IsSessionActive 세션이 취소되지 않았고 만료 전인지 확인
+func (s *SessionService) IsSessionActive(sessionID int64) bool {
+	var session model.AuthSession
+	if err := s.db.Select("id", "expires_at", "revoked_at").First(&session, sessionID).Error; err != nil {
+		return false
+	}
+	return session.IsActive()
+}
@@ -0,0 +1,129 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/draw"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/google/uuid"
+)
+
+// AvatarSizes 프로필 이미지 표준 크기 (정사각형 px) - 마지막 값이 기본 프로필 이미지
+var AvatarSizes = []int{64, 128, 256}
+
+// AvatarPrefix 사용자 프로필 이미지 객체 prefix: user-avatars/{userID}/
+func AvatarPrefix(userID int64) string {
+	return fmt.Sprintf("user-avatars/%d/", userID)
+}
+
+// AvatarUploadKey 클라이언트가 원본을 올릴 임시 키 (처리 후 삭제)
+func AvatarUploadKey(userID int64) string {
+	return fmt.Sprintf("%suploads/%s", AvatarPrefix(userID), uuid.New().String())
+}
+
+// IsAvatarUploadKey 해당 사용자의 임시 업로드 키인지 (다른 사용자 객체 처리 방지)
+func IsAvatarUploadKey(userID int64, key string) bool {
+	rest, ok := strings.CutPrefix(key, AvatarPrefix(userID)+"uploads/")
+	return ok && rest != "" && !strings.Contains(rest, "/")
+}
+
+// AvatarVersionPrefix 한 번 올린 프로필 이미지의 크기별 객체 prefix: user-avatars/{userID}/{version}/
+// 버전마다 키가 달라 CDN 캐시를 무효화하지 않아도 바로 바뀜
+func AvatarVersionPrefix(userID int64, version string) string {
+	return fmt.Sprintf("%s%s/", AvatarPrefix(userID), version)
+}
+
+// AvatarKey 버전 prefix 아래 크기별 객체 키
+func AvatarKey(versionPrefix string, size int) string {
+	return fmt.Sprintf("%s%d.jpg", versionPrefix, size)
+}
+
+// AvatarKeys 버전 prefix 아래 모든 크기의 객체 키
+func AvatarKeys(versionPrefix string) []string {
+	keys := make([]string, len(AvatarSizes))
+	for i, size := range AvatarSizes {
+		keys[i] = AvatarKey(versionPrefix, size)
+	}
+	return keys
+}
+
+// GenerateAvatarUploadURL 프로필 이미지 원본 업로드용 Presigned URL (크기/형식을 서명에 포함)
+func (s *S3Service) GenerateAvatarUploadURL(userID int64, contentType string, size int64) (*PresignedURL, error) {
+	return s.presignSizedUpload(AvatarUploadKey(userID), contentType, size)
+}
+
+// presignSizedUpload 크기/형식을 서명에 포함한 업로드 Presigned URL (다른 크기나 형식으로는 올릴 수 없음)
+func (s *S3Service) presignSizedUpload(key, contentType string, size int64) (*PresignedURL, error) {
+	expiresAt := time.Now().Add(s.presignExpiry)
+
+	presignResult, err := s.presignClient.PresignPutObject(context.TODO(), &s3.PutObjectInput{
+		Bucket:        aws.String(s.bucketName),
+		Key:           aws.String(key),
+		ContentType:   aws.String(contentType),
+		ContentLength: aws.Int64(size),
+	}, func(opts *s3.PresignOptions) {
+		opts.Expires = s.presignExpiry
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate presigned URL: %w", err)
+	}
+
+	return &PresignedURL{
+		URL:       presignResult.URL,
+		Key:       key,
+		ExpiresAt: expiresAt.Format(time.RFC3339),
+	}, nil
+}
+
+// CreateAvatar 임시 업로드 원본을 가운데 기준 정사각형으로 잘라 표준 크기별로 저장 (버전 prefix 반환)
+func (s *S3Service) CreateAvatar(ctx context.Context, userID int64, uploadKey string, maxBytes int64) (string, error) {
+	body, err := s.GetObject(ctx, uploadKey)
+	if err != nil {
+		return "", err
+	}
+	defer body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(body, maxBytes+1))
+	if err != nil {
+		return "", fmt.Errorf("failed to download image: %w", err)
+	}
+	if int64(len(data)) > maxBytes {
+		return "", ErrImageTooLarge
+	}
+
+	rendered, err := RenderAvatars(data)
+	if err != nil {
+		return "", err
+	}
+
+	prefix := AvatarVersionPrefix(userID, uuid.New().String())
+	for size, jpg := range rendered {
+		if err := s.PutObject(AvatarKey(prefix, size), "image/jpeg", bytes.NewReader(jpg), int64(len(jpg))); err != nil {
+			s.DeleteFiles(AvatarKeys(prefix))
+			return "", err
+		}
+	}
+	return prefix, nil
+}
+
+// RenderAvatars 가운데 기준 정사각형으로 자른 뒤 표준 크기별 JPEG로 인코딩
+func RenderAvatars(data []byte) (map[int][]byte, error) {
+	src, err := decodeImage(data)
+	if err != nil {
+		return nil, err
+	}
+
+	w, h := src.Bounds().Dx(), src.Bounds().Dy()
+	side := min(w, h)
+	square := image.NewRGBA(image.Rect(0, 0, side, side))
+	draw.Draw(square, square.Bounds(), src, image.Point{X: (w - side) / 2, Y: (h - side) / 2}, draw.Src)
+
+	return encodeSizes(square, AvatarSizes)
+}
@@ -0,0 +1,199 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// S3 멀티파트 업로드 제한
+const (
+	MultipartMinPartSize     = 5 << 20 // 마지막 파트를 제외한 최소 크기
+	MultipartMaxPartSize     = 5 << 30
+	MultipartMaxParts        = 10000
+	MultipartDefaultPartSize = 16 << 20
+)
+
+// MultipartUpload 시작한 멀티파트 업로드
+type MultipartUpload struct {
+	Key       string `json:"key"`
+	UploadID  string `json:"upload_id"`
+	PartSize  int64  `json:"part_size"`
+	PartCount int    `json:"part_count"`
+}
+
+// PresignedPart 파트 업로드용 Presigned URL
+type PresignedPart struct {
+	PartNumber int32  `json:"part_number"`
+	URL        string `json:"url"`
+}
+
+// PendingMultipartUpload 완료되지도 취소되지도 않은 멀티파트 업로드
+type PendingMultipartUpload struct {
+	Key       string
+	UploadID  string
+	Initiated time.Time
+}
+
+// UploadedPart S3에 올라간 파트
+type UploadedPart struct {
+	PartNumber int32
+	ETag       string
+	Size       int64
+}
+
+// MultipartPartSize 파일 크기에 맞는 파트 크기/개수 계산 (requested가 0이면 기본값, 파트가 너무 많으면 키움)
+func MultipartPartSize(fileSize, requested int64) (int64, int, error) {
+	if fileSize <= 0 {
+		return 0, 0, fmt.Errorf("file size must be positive")
+	}
+
+	partSize := requested
+	if partSize == 0 {
+		partSize = MultipartDefaultPartSize
+		for (fileSize+partSize-1)/partSize > MultipartMaxParts {
+			partSize *= 2
+		}
+	}
+	if partSize < MultipartMinPartSize || partSize > MultipartMaxPartSize {
+		return 0, 0, fmt.Errorf("part size must be between %d and %d bytes", MultipartMinPartSize, int64(MultipartMaxPartSize))
+	}
+
+	partCount := (fileSize + partSize - 1) / partSize
+	if partCount > MultipartMaxParts {
+		return 0, 0, fmt.Errorf("file needs more than %d parts with this part size", MultipartMaxParts)
+	}
+	return partSize, int(partCount), nil
+}
+
+// IsWorkspaceObjectKey 워크스페이스 업로드 키인지 확인 (다른 워크스페이스 객체 조작 방지)
+func IsWorkspaceObjectKey(workspaceID int64, key string) bool {
+	return strings.HasPrefix(key, WorkspacePrefix(workspaceID)) && !strings.Contains(key, "..")
+}
+
+// CreateMultipartUpload 멀티파트 업로드 시작 (키는 단일 업로드와 같은 규칙으로 생성)
+func (s *S3Service) CreateMultipartUpload(ctx context.Context, workspaceID int64, fileName, contentType string) (key, uploadID string, err error) {
+	key = WorkspaceObjectKey(workspaceID, fileName)
+	out, err := s.client.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
+		Bucket:      aws.String(s.bucketName),
+		Key:         aws.String(key),
+		ContentType: aws.String(contentType),
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create multipart upload: %w", err)
+	}
+	return key, aws.ToString(out.UploadId), nil
+}
+
+// PresignUploadParts 파트별 업로드 URL 생성
+func (s *S3Service) PresignUploadParts(ctx context.Context, key, uploadID string, partNumbers []int32) ([]PresignedPart, string, error) {
+	expiresAt := time.Now().Add(s.presignExpiry)
+	parts := make([]PresignedPart, 0, len(partNumbers))
+	for _, n := range partNumbers {
+		presigned, err := s.presignClient.PresignUploadPart(ctx, &s3.UploadPartInput{
+			Bucket:     aws.String(s.bucketName),
+			Key:        aws.String(key),
+			UploadId:   aws.String(uploadID),
+			PartNumber: aws.Int32(n),
+		}, func(opts *s3.PresignOptions) {
+			opts.Expires = s.presignExpiry
+		})
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to presign part %d: %w", n, err)
+		}
+		parts = append(parts, PresignedPart{PartNumber: n, URL: presigned.URL})
+	}
+	return parts, expiresAt.Format(time.RFC3339), nil
+}
+
+// ListUploadedParts 지금까지 올라간 파트 목록 (파트 번호순)
+func (s *S3Service) ListUploadedParts(ctx context.Context, key, uploadID string) ([]UploadedPart, error) {
+	var parts []UploadedPart
+	paginator := s3.NewListPartsPaginator(s.client, &s3.ListPartsInput{
+		Bucket:   aws.String(s.bucketName),
+		Key:      aws.String(key),
+		UploadId: aws.String(uploadID),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list parts: %w", err)
+		}
+		for _, p := range page.Parts {
+			parts = append(parts, UploadedPart{
+				PartNumber: aws.ToInt32(p.PartNumber),
+				ETag:       aws.ToString(p.ETag),
+				Size:       aws.ToInt64(p.Size),
+			})
+		}
+	}
+	sort.Slice(parts, func(i, j int) bool { return parts[i].PartNumber < parts[j].PartNumber })
+	return parts, nil
+}
+
+// CompleteMultipartUpload 파트를 합쳐 객체 완성
+func (s *S3Service) CompleteMultipartUpload(ctx context.Context, key, uploadID string, parts []UploadedPart) error {
+	completed := make([]types.CompletedPart, 0, len(parts))
+	for _, p := range parts {
+		completed = append(completed, types.CompletedPart{
+			PartNumber: aws.Int32(p.PartNumber),
+			ETag:       aws.String(p.ETag),
+		})
+	}
+	_, err := s.client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+		Bucket:          aws.String(s.bucketName),
+		Key:             aws.String(key),
+		UploadId:        aws.String(uploadID),
+		MultipartUpload: &types.CompletedMultipartUpload{Parts: completed},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to complete multipart upload: %w", err)
+	}
+	return nil
+}
+
+// AbortMultipartUpload 멀티파트 업로드 취소 (올라간 파트 삭제)
+func (s *S3Service) AbortMultipartUpload(ctx context.Context, key, uploadID string) error {
+	_, err := s.client.AbortMultipartUpload(ctx, &s3.AbortMultipartUploadInput{
+		Bucket:   aws.String(s.bucketName),
+		Key:      aws.String(key),
+		UploadId: aws.String(uploadID),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to abort multipart upload: %w", err)
+	}
+	return nil
+}
+
+// ListMultipartUploads 접두사 아래 진행 중인 멀티파트 업로드 목록 (올라간 파트는 취소 전까지 용량 차지)
+func (s *S3Service) ListMultipartUploads(ctx context.Context, prefix string) ([]PendingMultipartUpload, error) {
+	var uploads []PendingMultipartUpload
+	input := &s3.ListMultipartUploadsInput{
+		Bucket: aws.String(s.bucketName),
+		Prefix: aws.String(prefix),
+	}
+	for {
+		out, err := s.client.ListMultipartUploads(ctx, input)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list multipart uploads: %w", err)
+		}
+		for _, u := range out.Uploads {
+			uploads = append(uploads, PendingMultipartUpload{
+				Key:       aws.ToString(u.Key),
+				UploadID:  aws.ToString(u.UploadId),
+				Initiated: aws.ToTime(u.Initiated),
+			})
+		}
+		if !aws.ToBool(out.IsTruncated) {
+			return uploads, nil
+		}
+		input.KeyMarker = out.NextKeyMarker
+		input.UploadIdMarker = out.NextUploadIdMarker
+	}
+}
@@ -0,0 +1,84 @@
+package storage
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	appconfig "realtime-backend/internal/config"
+)
+
+// S3Registry 리전별 S3 서비스 관리 (워크스페이스 데이터 레지던시)
+// 기본 리전은 AWS_REGION/AWS_S3_BUCKET, 추가 리전은 AWS_S3_REGION_BUCKETS 로 설정
+type S3Registry struct {
+	cfg      appconfig.S3Config
+	mu       sync.Mutex
+	services map[string]*S3Service
+}
+
+// NewS3Registry 리전별 S3 서비스 레지스트리 생성 (기본 리전 서비스 포함)
+func NewS3Registry(cfg *appconfig.S3Config) (*S3Registry, error) {
+	defaultSvc, err := NewS3Service(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &S3Registry{
+		cfg:      *cfg,
+		services: map[string]*S3Service{cfg.Region: defaultSvc},
+	}, nil
+}
+
+// DefaultRegion 기본 리전
+func (r *S3Registry) DefaultRegion() string {
+	return r.cfg.Region
+}
+
+// Regions 사용 가능한 리전 목록
+func (r *S3Registry) Regions() []string {
+	return SupportedRegions(&r.cfg)
+}
+
+// ForRegion 리전에 해당하는 S3 서비스 반환 (빈 값이면 기본 리전)
+// 버킷이 설정되지 않은 리전은 다른 리전으로 대체하지 않고 에러 반환
+func (r *S3Registry) ForRegion(region string) (*S3Service, error) {
+	if region == "" {
+		region = r.cfg.Region
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if svc, ok := r.services[region]; ok {
+		return svc, nil
+	}
+
+	bucket, ok := r.cfg.RegionBuckets[region]
+	if !ok {
+		return nil, fmt.Errorf("no S3 bucket configured for region %s", region)
+	}
+
+	regionalCfg := r.cfg
+	regionalCfg.Region = region
+	regionalCfg.BucketName = bucket
+	regionalCfg.CDNBaseURL = "" // CDN은 기본 버킷 앞단에만 있음
+
+	svc, err := NewS3Service(&regionalCfg)
+	if err != nil {
+		return nil, err
+	}
+	r.services[region] = svc
+	return svc, nil
+}
+
+// SupportedRegions 설정상 데이터를 저장할 수 있는 리전 목록 (기본 리전 + 리전별 버킷)
+func SupportedRegions(cfg *appconfig.S3Config) []string {
+	regions := []string{cfg.Region}
+	for region := range cfg.RegionBuckets {
+		if region != cfg.Region {
+			regions = append(regions, region)
+		}
+	}
+	sort.Strings(regions[1:])
+	return regions
+}
@@ -2,6 +2,9 @@ package storage
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"path/filepath"
@@ -12,6 +15,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/google/uuid"
 
 	appconfig "realtime-backend/internal/config"
@@ -24,6 +28,8 @@ type S3Service struct {
 	bucketName    string
 	region        string
 	presignExpiry time.Duration
+	cdnBaseURL    string // 설정되면 퍼블릭 URL을 CDN 주소로 반환
+	endpoint      string // S3 호환 스토리지 주소 (경로 방식 URL)
 }
 
 // UploadResult 업로드 결과
@@ -61,7 +67,13 @@ func NewS3Service(cfg *appconfig.S3Config) (*S3Service, error) {
 		return nil, fmt.Errorf("failed to load AWS config: %w", err)
 	}
 
-	client := s3.NewFromConfig(awsCfg)
+	// S3 호환 스토리지는 가상 호스트 대신 경로 방식 주소 사용
+	client := s3.NewFromConfig(awsCfg, func(o *s3.Options) {
+		if cfg.Endpoint != "" {
+			o.BaseEndpoint = aws.String(cfg.Endpoint)
+			o.UsePathStyle = true
+		}
+	})
 	presignClient := s3.NewPresignClient(client)
 
 	return &S3Service{
@@ -70,13 +82,14 @@ func NewS3Service(cfg *appconfig.S3Config) (*S3Service, error) {
 		bucketName:    cfg.BucketName,
 		region:        cfg.Region,
 		presignExpiry: cfg.PresignExpiry,
+		cdnBaseURL:    cfg.CDNBaseURL,
+		endpoint:      cfg.Endpoint,
 	}, nil
 }
 
 // GenerateUploadURL 파일 업로드용 Presigned URL 생성
 func (s *S3Service) GenerateUploadURL(workspaceID int64, fileName, contentType string) (*PresignedURL, error) {
-	// 파일 키 생성: workspaces/{workspace_id}/{uuid}/{filename}
-	key := fmt.Sprintf("workspaces/%d/%s/%s", workspaceID, uuid.New().String(), sanitizeFileName(fileName))
+	key := WorkspaceObjectKey(workspaceID, fileName)
 
 	expiresAt := time.Now().Add(s.presignExpiry)
 
@@ -113,15 +126,81 @@ func (s *S3Service) GetFileURL(key string) (string, error) {
 	return presignResult.URL, nil
 }
 
-// GetPublicURL 퍼블릭 URL 반환 (퍼블릭 버킷용)
+// GetPublicURL 퍼블릭 URL 반환 (퍼블릭 버킷용, CDN이 설정되면 CDN 주소)
 func (s *S3Service) GetPublicURL(key string) string {
+	if s.cdnBaseURL != "" {
+		return s.cdnBaseURL + "/" + key
+	}
+	if s.endpoint != "" {
+		return fmt.Sprintf("%s/%s/%s", s.endpoint, s.bucketName, key)
+	}
 	return fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s", s.bucketName, s.region, key)
 }
 
+// ObjectURI s3:// 형식 객체 주소 (Transcribe 등 다른 AWS 서비스 입력용)
+func (s *S3Service) ObjectURI(key string) string {
+	return fmt.Sprintf("s3://%s/%s", s.bucketName, key)
+}
+
+// Region 버킷 리전
+func (s *S3Service) Region() string {
+	return s.region
+}
+
+// Bucket 버킷 이름 (Textract처럼 버킷/키를 따로 받는 서비스 입력용)
+func (s *S3Service) Bucket() string {
+	return s.bucketName
+}
+
+// Ping 버킷 접근 가능 여부 확인 (상태 페이지용)
+func (s *S3Service) Ping(ctx context.Context) error {
+	_, err := s.client.HeadBucket(ctx, &s3.HeadBucketInput{Bucket: aws.String(s.bucketName)})
+	if err != nil {
+		return fmt.Errorf("failed to head bucket %s: %w", s.bucketName, err)
+	}
+	return nil
+}
+
+// ObjectSHA256 업로드 시 S3에 기록된 전체 객체 SHA-256 체크섬 (hex)
+// 체크섬 없이 올렸거나 멀티파트 합성 체크섬이면 빈 문자열
+func (s *S3Service) ObjectSHA256(ctx context.Context, key string) (string, error) {
+	out, err := s.client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket:       aws.String(s.bucketName),
+		Key:          aws.String(key),
+		ChecksumMode: types.ChecksumModeEnabled,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to head object %s: %w", key, err)
+	}
+	if out.ChecksumSHA256 == nil || strings.Contains(*out.ChecksumSHA256, "-") {
+		return "", nil
+	}
+	sum, err := base64.StdEncoding.DecodeString(*out.ChecksumSHA256)
+	if err != nil || len(sum) != sha256.Size {
+		return "", nil
+	}
+	return hex.EncodeToString(sum), nil
+}
+
 // UploadFile 파일 직접 업로드 (서버 사이드)
 func (s *S3Service) UploadFile(workspaceID int64, fileName, contentType string, reader io.Reader, size int64) (*UploadResult, error) {
-	key := fmt.Sprintf("workspaces/%d/%s/%s", workspaceID, uuid.New().String(), sanitizeFileName(fileName))
+	key := WorkspaceObjectKey(workspaceID, fileName)
 
+	if err := s.PutObject(key, contentType, reader, size); err != nil {
+		return nil, err
+	}
+
+	return &UploadResult{
+		Key:      key,
+		URL:      s.GetPublicURL(key),
+		FileName: fileName,
+		FileSize: size,
+		MimeType: contentType,
+	}, nil
+}
+
+// PutObject 지정한 키로 객체 업로드
+func (s *S3Service) PutObject(key, contentType string, reader io.Reader, size int64) error {
 	_, err := s.client.PutObject(context.TODO(), &s3.PutObjectInput{
 		Bucket:        aws.String(s.bucketName),
 		Key:           aws.String(key),
@@ -130,16 +209,19 @@ func (s *S3Service) UploadFile(workspaceID int64, fileName, contentType string,
 		ContentLength: aws.Int64(size),
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to upload file: %w", err)
+		return fmt.Errorf("failed to upload file: %w", err)
 	}
+	return nil
+}
 
-	return &UploadResult{
-		Key:      key,
-		URL:      s.GetPublicURL(key),
-		FileName: fileName,
-		FileSize: size,
-		MimeType: contentType,
-	}, nil
+// WorkspaceObjectKey 워크스페이스 파일 키 생성: workspaces/{workspace_id}/{uuid}/{filename}
+func WorkspaceObjectKey(workspaceID int64, fileName string) string {
+	return WorkspacePrefix(workspaceID) + uuid.New().String() + "/" + sanitizeFileName(fileName)
+}
+
+// WorkspacePrefix 워크스페이스 객체가 모이는 키 접두사
+func WorkspacePrefix(workspaceID int64) string {
+	return fmt.Sprintf("workspaces/%d/", workspaceID)
 }
 
 // DeleteFile 파일 삭제
@@ -154,16 +236,63 @@ func (s *S3Service) DeleteFile(key string) error {
 	return nil
 }
 
-// DeleteFiles 여러 파일 삭제
+// deleteObjectsBatch DeleteObjects 요청 한 번에 지울 수 있는 최대 키 수
+const deleteObjectsBatch = 1000
+
+// DeleteFiles 여러 파일 삭제 (DeleteObjects로 1000개씩 묶어 요청)
 func (s *S3Service) DeleteFiles(keys []string) error {
-	for _, key := range keys {
-		if err := s.DeleteFile(key); err != nil {
-			return err
+	for start := 0; start < len(keys); start += deleteObjectsBatch {
+		batch := keys[start:min(start+deleteObjectsBatch, len(keys))]
+		objects := make([]types.ObjectIdentifier, len(batch))
+		for i, key := range batch {
+			objects[i] = types.ObjectIdentifier{Key: aws.String(key)}
+		}
+
+		out, err := s.client.DeleteObjects(context.TODO(), &s3.DeleteObjectsInput{
+			Bucket: aws.String(s.bucketName),
+			Delete: &types.Delete{Objects: objects, Quiet: aws.Bool(true)},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to delete files: %w", err)
+		}
+		if len(out.Errors) > 0 {
+			return fmt.Errorf("failed to delete %d files: %s: %s", len(out.Errors),
+				aws.ToString(out.Errors[0].Key), aws.ToString(out.Errors[0].Message))
 		}
 	}
 	return nil
 }
 
+// ObjectInfo 버킷에 있는 객체 정보
+type ObjectInfo struct {
+	Key          string
+	Size         int64
+	LastModified time.Time
+}
+
+// ListObjects 접두사 아래 모든 객체 목록 (페이지를 끝까지 조회)
+func (s *S3Service) ListObjects(ctx context.Context, prefix string) ([]ObjectInfo, error) {
+	var objects []ObjectInfo
+	paginator := s3.NewListObjectsV2Paginator(s.client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(s.bucketName),
+		Prefix: aws.String(prefix),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list objects: %w", err)
+		}
+		for _, obj := range page.Contents {
+			objects = append(objects, ObjectInfo{
+				Key:          aws.ToString(obj.Key),
+				Size:         aws.ToInt64(obj.Size),
+				LastModified: aws.ToTime(obj.LastModified),
+			})
+		}
+	}
+	return objects, nil
+}
+
 // 파일명 정리 (안전한 문자만 유지)
 func sanitizeFileName(name string) string {
 	// 경로 구분자 제거
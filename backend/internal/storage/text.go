@@ -0,0 +1,193 @@
+package storage
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// DocumentTextLimit 저장하는 본문 최대 길이 (바이트, 초과분은 잘라냄)
+	DocumentTextLimit = 1 << 20
+	// officePartLimit 오피스 문서 안 XML 한 개의 최대 압축 해제 크기 (압축 폭탄 방지)
+	officePartLimit = 64 << 20
+)
+
+// ErrUnsupportedDocument 로컬 파서로 텍스트를 추출할 수 없는 형식
+var ErrUnsupportedDocument = errors.New("unsupported document format")
+
+// DocumentKind 문서 텍스트 추출 방식
+type DocumentKind int
+
+const (
+	DocumentUnsupported DocumentKind = iota
+	DocumentPlain                    // 텍스트 파일 (txt, md, csv ...)
+	DocumentOffice                   // docx, pptx, xlsx (로컬 파싱)
+	DocumentScanned                  // pdf, 이미지 (OCR)
+)
+
+// plainTextExts 본문을 그대로 색인하는 확장자
+var plainTextExts = map[string]bool{
+	".txt": true, ".md": true, ".markdown": true, ".csv": true, ".tsv": true,
+	".json": true, ".log": true, ".xml": true, ".html": true, ".htm": true,
+}
+
+// scannedExts OCR로 추출하는 확장자 (Textract 지원 형식)
+var scannedExts = map[string]bool{
+	".pdf": true, ".png": true, ".jpg": true, ".jpeg": true, ".tif": true, ".tiff": true,
+}
+
+var (
+	slideNumberPattern = regexp.MustCompile(`(\d+)\.xml$`)
+	blankLinesPattern  = regexp.MustCompile(`\n{3,}`)
+)
+
+// DetectDocumentKind 파일 이름/MIME 타입으로 추출 방식 결정
+func DetectDocumentKind(name, mimeType string) DocumentKind {
+	ext := strings.ToLower(filepath.Ext(name))
+	switch ext {
+	case ".docx", ".pptx", ".xlsx":
+		return DocumentOffice
+	}
+	if plainTextExts[ext] {
+		return DocumentPlain
+	}
+	if scannedExts[ext] {
+		return DocumentScanned
+	}
+
+	mimeType = strings.ToLower(strings.TrimSpace(strings.SplitN(mimeType, ";", 2)[0]))
+	switch {
+	case strings.HasPrefix(mimeType, "text/"):
+		return DocumentPlain
+	case mimeType == "application/pdf", mimeType == "image/png", mimeType == "image/jpeg", mimeType == "image/tiff":
+		return DocumentScanned
+	}
+	return DocumentUnsupported
+}
+
+// ExtractDocumentText 텍스트/오피스 문서 본문 추출 (DocumentScanned는 OCR 필요)
+func ExtractDocumentText(name string, data []byte) (string, error) {
+	switch DetectDocumentKind(name, "") {
+	case DocumentPlain:
+		return CleanDocumentText(string(data)), nil
+	case DocumentOffice:
+	default:
+		return "", ErrUnsupportedDocument
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return "", fmt.Errorf("invalid office document: %w", err)
+	}
+
+	var parts []*zip.File
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".docx":
+		parts = findZipParts(zr, func(n string) bool { return n == "word/document.xml" })
+	case ".pptx":
+		parts = findZipParts(zr, func(n string) bool {
+			return strings.HasPrefix(n, "ppt/slides/slide") && strings.HasSuffix(n, ".xml")
+		})
+	case ".xlsx":
+		parts = findZipParts(zr, func(n string) bool { return n == "xl/sharedStrings.xml" })
+	}
+
+	var text strings.Builder
+	for _, part := range parts {
+		if err := readOfficeText(part, &text); err != nil {
+			return "", err
+		}
+		text.WriteString("\n\n")
+	}
+	return CleanDocumentText(text.String()), nil
+}
+
+// CleanDocumentText 색인용 본문 정리 (잘못된 UTF-8/NUL 제거, 빈 줄 축소, 길이 제한)
+func CleanDocumentText(text string) string {
+	text = strings.ToValidUTF8(text, "")
+	text = strings.ReplaceAll(text, "\x00", "")
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = blankLinesPattern.ReplaceAllString(text, "\n\n")
+	text = strings.TrimSpace(text)
+
+	if len(text) > DocumentTextLimit {
+		text = strings.ToValidUTF8(text[:DocumentTextLimit], "")
+	}
+	return text
+}
+
+// findZipParts 조건에 맞는 ZIP 항목 (슬라이드는 번호 순)
+func findZipParts(zr *zip.Reader, match func(name string) bool) []*zip.File {
+	var parts []*zip.File
+	for _, f := range zr.File {
+		if match(f.Name) {
+			parts = append(parts, f)
+		}
+	}
+	sort.Slice(parts, func(i, j int) bool {
+		return zipPartNumber(parts[i].Name) < zipPartNumber(parts[j].Name)
+	})
+	return parts
+}
+
+func zipPartNumber(name string) int {
+	m := slideNumberPattern.FindStringSubmatch(name)
+	if m == nil {
+		return 0
+	}
+	n, _ := strconv.Atoi(m[1])
+	return n
+}
+
+// readOfficeText OOXML 항목의 텍스트 노드(<w:t>, <a:t>, <t>)를 문단 단위로 기록
+func readOfficeText(part *zip.File, w *strings.Builder) error {
+	rc, err := part.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	decoder := xml.NewDecoder(io.LimitReader(rc, officePartLimit))
+	inText := false
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("invalid office document: %w", err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "t":
+				inText = true
+			case "tab":
+				w.WriteString("\t")
+			case "br":
+				w.WriteString("\n")
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "t":
+				inText = false
+			case "p", "si":
+				w.WriteString("\n")
+			}
+		case xml.CharData:
+			if inText {
+				w.Write(t)
+			}
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	_ "image/gif" // GIF 디코더 등록 (첫 프레임 사용)
+	"image/jpeg"
+	_ "image/png" // PNG 디코더 등록
+	"io"
+	"strings"
+)
+
+const (
+	// ThumbnailSize 파일 목록 그리드용 썸네일 (긴 변 기준 px)
+	ThumbnailSize = 320
+	// PreviewSize 미리보기용 이미지 (긴 변 기준 px)
+	PreviewSize = 1280
+	// ThumbnailSourceLimit 썸네일을 만드는 원본 최대 크기 (바이트)
+	ThumbnailSourceLimit = 30 << 20
+
+	thumbnailMaxPixels = 50_000_000 // 디코딩 전 해상도 검사 (압축 폭탄 방지)
+	thumbnailQuality   = 80
+)
+
+// ErrImageTooLarge 썸네일을 만들기에는 원본 해상도가 너무 큼
+var ErrImageTooLarge = errors.New("image is too large for thumbnail generation")
+
+// thumbnailMimeTypes 표준 라이브러리로 디코딩할 수 있는 이미지 형식
+var thumbnailMimeTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/jpg":  true,
+	"image/png":  true,
+	"image/gif":  true,
+}
+
+// IsThumbnailSource 썸네일을 만들 수 있는 MIME 타입인지
+func IsThumbnailSource(mimeType string) bool {
+	return thumbnailMimeTypes[mimeType]
+}
+
+// ThumbnailKey 원본 키에 대응하는 썸네일 키: thumbnails/{원본 키}/{size}.jpg
+// 같은 객체를 공유하는 파일끼리 썸네일도 공유
+func ThumbnailKey(key string, size int) string {
+	return fmt.Sprintf("thumbnails/%s/%d.jpg", key, size)
+}
+
+// ThumbnailSourceKey 썸네일 키에서 원본 키 추출 (썸네일 키가 아니면 false)
+func ThumbnailSourceKey(thumbKey string) (string, bool) {
+	rest, ok := strings.CutPrefix(thumbKey, "thumbnails/")
+	if !ok {
+		return "", false
+	}
+	slash := strings.LastIndex(rest, "/")
+	if slash <= 0 {
+		return "", false
+	}
+	return rest[:slash], true
+}
+
+// ThumbnailKeys 원본 키들에 대응하는 모든 크기의 썸네일 키
+func ThumbnailKeys(keys []string) []string {
+	thumbs := make([]string, 0, len(keys)*2)
+	for _, key := range keys {
+		thumbs = append(thumbs, ThumbnailKey(key, ThumbnailSize), ThumbnailKey(key, PreviewSize))
+	}
+	return thumbs
+}
+
+// RenderThumbnails 이미지를 크기별로 축소해 JPEG로 인코딩 (원본보다 크게 늘리지 않음)
+// 투명 배경은 흰색으로 채움
+func RenderThumbnails(data []byte, sizes ...int) (map[int][]byte, error) {
+	src, err := decodeImage(data)
+	if err != nil {
+		return nil, err
+	}
+	return encodeSizes(src, sizes)
+}
+
+// decodeImage 해상도 검사 후 디코딩해 흰 배경 RGBA로 변환
+func decodeImage(data []byte) (*image.RGBA, error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image header: %w", err)
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width*cfg.Height > thumbnailMaxPixels {
+		return nil, ErrImageTooLarge
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image: %w", err)
+	}
+
+	bounds := img.Bounds()
+	src := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(src, src.Bounds(), image.White, image.Point{}, draw.Src)
+	draw.Draw(src, src.Bounds(), img, bounds.Min, draw.Over)
+	return src, nil
+}
+
+// encodeSizes 크기별로 축소해 JPEG 인코딩
+func encodeSizes(src *image.RGBA, sizes []int) (map[int][]byte, error) {
+	rendered := make(map[int][]byte, len(sizes))
+	for _, size := range sizes {
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, scaleDown(src, size), &jpeg.Options{Quality: thumbnailQuality}); err != nil {
+			return nil, fmt.Errorf("failed to encode thumbnail: %w", err)
+		}
+		rendered[size] = buf.Bytes()
+	}
+	return rendered, nil
+}
+
+// CreateThumbnails 원본 객체로 썸네일/미리보기 이미지를 만들어 업로드 (크기 → 키 반환)
+func (s *S3Service) CreateThumbnails(ctx context.Context, key string) (map[int]string, error) {
+	body, err := s.GetObject(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(body, ThumbnailSourceLimit+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to download image: %w", err)
+	}
+	if len(data) > ThumbnailSourceLimit {
+		return nil, ErrImageTooLarge
+	}
+
+	rendered, err := RenderThumbnails(data, ThumbnailSize, PreviewSize)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make(map[int]string, len(rendered))
+	for size, jpg := range rendered {
+		thumbKey := ThumbnailKey(key, size)
+		if err := s.PutObject(thumbKey, "image/jpeg", bytes.NewReader(jpg), int64(len(jpg))); err != nil {
+			return nil, err
+		}
+		keys[size] = thumbKey
+	}
+	return keys, nil
+}
+
+// scaleDown 긴 변이 maxSize가 되도록 영역 평균으로 축소 (이미 작으면 그대로)
+func scaleDown(src *image.RGBA, maxSize int) *image.RGBA {
+	sw, sh := src.Bounds().Dx(), src.Bounds().Dy()
+	if sw <= maxSize && sh <= maxSize {
+		return src
+	}
+
+	dw, dh := maxSize, sh*maxSize/sw
+	if sh > sw {
+		dw, dh = sw*maxSize/sh, maxSize
+	}
+	dw, dh = max(dw, 1), max(dh, 1)
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		y0, y1 := y*sh/dh, max((y+1)*sh/dh, y*sh/dh+1)
+		for x := 0; x < dw; x++ {
+			x0, x1 := x*sw/dw, max((x+1)*sw/dw, x*sw/dw+1)
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				row := src.Pix[sy*src.Stride:]
+				for sx := x0; sx < x1; sx++ {
+					p := row[sx*4 : sx*4+4]
+					r += uint64(p[0])
+					g += uint64(p[1])
+					b += uint64(p[2])
+					a += uint64(p[3])
+					n++
+				}
+			}
+
+			i := dst.PixOffset(x, y)
+			dst.Pix[i] = uint8(r / n)
+			dst.Pix[i+1] = uint8(g / n)
+			dst.Pix[i+2] = uint8(b / n)
+			dst.Pix[i+3] = uint8(a / n)
+		}
+	}
+	return dst
+}
@@ -0,0 +1,56 @@
+package storage
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// whiteboardImageExtensions 화이트보드에 올릴 수 있는 이미지 형식과 확장자
+var whiteboardImageExtensions = map[string]string{
+	"image/png":  ".png",
+	"image/jpeg": ".jpg",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+// IsWhiteboardImageType 화이트보드 이미지로 허용하는 Content-Type 인지
+func IsWhiteboardImageType(contentType string) bool {
+	_, ok := whiteboardImageExtensions[contentType]
+	return ok
+}
+
+// WhiteboardImagePrefix 워크스페이스 화이트보드 이미지 객체 prefix: whiteboard-images/{workspaceID}/
+// 워크스페이스 파일(workspaces/{id}/)과 분리해 용량/목록 집계에 섞이지 않게 함
+func WhiteboardImagePrefix(workspaceID int64) string {
+	return fmt.Sprintf("whiteboard-images/%d/", workspaceID)
+}
+
+// WhiteboardImageKey 새 화이트보드 이미지 객체 키
+func WhiteboardImageKey(workspaceID int64, contentType string) string {
+	return WhiteboardImagePrefix(workspaceID) + uuid.New().String() + whiteboardImageExtensions[contentType]
+}
+
+// WhiteboardImageWorkspace 화이트보드 이미지 키의 워크스페이스 ID (형식이 다르면 false)
+func WhiteboardImageWorkspace(key string) (int64, bool) {
+	rest, ok := strings.CutPrefix(key, "whiteboard-images/")
+	if !ok {
+		return 0, false
+	}
+	idStr, name, ok := strings.Cut(rest, "/")
+	if !ok || name == "" || strings.Contains(name, "/") {
+		return 0, false
+	}
+	workspaceID, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil || workspaceID <= 0 {
+		return 0, false
+	}
+	return workspaceID, true
+}
+
+// GenerateWhiteboardImageUploadURL 화이트보드 이미지 업로드용 Presigned URL (크기/형식을 서명에 포함)
+func (s *S3Service) GenerateWhiteboardImageUploadURL(workspaceID int64, contentType string, size int64) (*PresignedURL, error) {
+	return s.presignSizedUpload(WhiteboardImageKey(workspaceID, contentType), contentType, size)
+}
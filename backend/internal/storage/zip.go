@@ -0,0 +1,161 @@
+package storage
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// zipBufferLimit 이 크기 이하 객체는 미리 메모리로 받아 두고, 큰 객체는 스트림만 열어 둠
+const zipBufferLimit = 8 << 20 // 8MB
+
+// ZipEntry ZIP에 담을 항목 (Key가 비어 있으면 빈 폴더 항목)
+type ZipEntry struct {
+	Path    string // ZIP 내부 경로 (폴더는 "/"로 끝남)
+	Key     string // S3 객체 키
+	Size    int64
+	ModTime time.Time
+}
+
+// ZipProgress ZIP 생성 진행 상황 콜백 (기록한 항목 수, 기록한 파일 바이트)
+type ZipProgress func(doneFiles int, doneBytes int64)
+
+// fetchedObject 미리 받아 둔 객체 (data 또는 body 중 하나)
+type fetchedObject struct {
+	data []byte
+	body io.ReadCloser
+	err  error
+}
+
+// GetObject 객체 본문 스트림 조회 (호출자가 Close)
+func (s *S3Service) GetObject(ctx context.Context, key string) (io.ReadCloser, error) {
+	out, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucketName),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get object %s: %w", key, err)
+	}
+	return out.Body, nil
+}
+
+// WriteZip entries를 순서대로 ZIP으로 기록
+// 최대 concurrency개 객체를 앞서 받아 오되, ZIP에는 entries 순서대로 기록
+func (s *S3Service) WriteZip(ctx context.Context, w io.Writer, entries []ZipEntry, concurrency int, progress ZipProgress) error {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// 항목별 결과 채널 (순서 보장), sem으로 동시에 받아 오는 객체 수 제한
+	results := make([]chan fetchedObject, len(entries))
+	for i := range results {
+		results[i] = make(chan fetchedObject, 1)
+	}
+	sem := make(chan struct{}, concurrency)
+	go func() {
+		for i, entry := range entries {
+			if entry.Key == "" {
+				results[i] <- fetchedObject{}
+				continue
+			}
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				results[i] <- fetchedObject{err: ctx.Err()}
+				continue
+			}
+			go func(ch chan fetchedObject, entry ZipEntry) {
+				ch <- s.fetchObject(ctx, entry)
+			}(results[i], entry)
+		}
+	}()
+
+	zw := zip.NewWriter(w)
+	var doneBytes int64
+	for i, entry := range entries {
+		obj := <-results[i]
+
+		if entry.Key == "" {
+			if _, err := zw.CreateHeader(&zip.FileHeader{Name: entry.Path, Modified: entry.ModTime}); err != nil {
+				discardResults(results[i+1:])
+				return err
+			}
+			continue
+		}
+
+		err := s.writeZipEntry(zw, entry, obj)
+		<-sem
+		if err != nil {
+			discardResults(results[i+1:])
+			return err
+		}
+
+		doneBytes += entry.Size
+		if progress != nil {
+			progress(i+1, doneBytes)
+		}
+	}
+	return zw.Close()
+}
+
+// discardResults 중단 후 남은 결과를 받아 열린 스트림을 닫음 (ctx 취소 이후 호출)
+func discardResults(pending []chan fetchedObject) {
+	go func() {
+		for _, ch := range pending {
+			if obj := <-ch; obj.body != nil {
+				obj.body.Close()
+			}
+		}
+	}()
+}
+
+func (s *S3Service) fetchObject(ctx context.Context, entry ZipEntry) fetchedObject {
+	body, err := s.GetObject(ctx, entry.Key)
+	if err != nil {
+		return fetchedObject{err: err}
+	}
+	if entry.Size > zipBufferLimit {
+		return fetchedObject{body: body}
+	}
+	defer body.Close()
+
+	var buf bytes.Buffer
+	if _, err := io.Copy(&buf, body); err != nil {
+		return fetchedObject{err: fmt.Errorf("failed to read object %s: %w", entry.Key, err)}
+	}
+	return fetchedObject{data: buf.Bytes()}
+}
+
+func (s *S3Service) writeZipEntry(zw *zip.Writer, entry ZipEntry, obj fetchedObject) error {
+	if obj.err != nil {
+		return obj.err
+	}
+
+	fw, err := zw.CreateHeader(&zip.FileHeader{
+		Name:     entry.Path,
+		Method:   zip.Deflate,
+		Modified: entry.ModTime,
+	})
+	if err != nil {
+		return err
+	}
+
+	if obj.body != nil {
+		defer obj.body.Close()
+		_, err = io.Copy(fw, obj.body)
+	} else {
+		_, err = fw.Write(obj.data)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", entry.Path, err)
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+//go:build e2e
+
+package e2e
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	wsclient "github.com/fasthttp/websocket"
+	"github.com/google/uuid"
+
+	"realtime-backend/internal/database"
+	"realtime-backend/internal/model"
+)
+
+const testPassword = "e2e-password-123"
+
+// apiClient 로그인한 사용자의 HTTP/WebSocket 클라이언트 (세션 쿠키 유지)
+type apiClient struct {
+	t           *testing.T
+	http        *http.Client
+	UserID      int64
+	Email       string
+	accessToken string
+}
+
+// newUser 회원가입 → 이메일 인증 처리 → 로그인까지 마친 클라이언트 생성
+// 인증 메일은 발송되지 않으므로 email_verified_at은 DB에 직접 기록
+func newUser(t *testing.T) *apiClient {
+	t.Helper()
+
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		t.Fatalf("cookie jar: %v", err)
+	}
+	c := &apiClient{
+		t:     t,
+		http:  &http.Client{Jar: jar, Timeout: 10 * time.Second},
+		Email: "e2e-" + uuid.NewString()[:8] + "@example.com",
+	}
+
+	var registered struct {
+		User struct {
+			ID int64 `json:"id"`
+		} `json:"user"`
+	}
+	c.mustDo(http.MethodPost, "/auth/register", map[string]string{
+		"email":    c.Email,
+		"password": testPassword,
+		"nickname": "e2e user",
+	}, http.StatusCreated, &registered)
+	c.UserID = registered.User.ID
+
+	if err := database.DB.Model(&model.User{}).
+		Where("id = ?", c.UserID).
+		Update("email_verified_at", time.Now()).Error; err != nil {
+		t.Fatalf("mark email verified: %v", err)
+	}
+
+	c.mustDo(http.MethodPost, "/auth/login", map[string]string{
+		"email":    c.Email,
+		"password": testPassword,
+	}, http.StatusOK, nil)
+
+	u, _ := url.Parse(baseURL)
+	for _, cookie := range jar.Cookies(u) {
+		if cookie.Name == "access_token" {
+			c.accessToken = cookie.Value
+		}
+	}
+	if c.accessToken == "" {
+		t.Fatalf("login did not set the access_token cookie")
+	}
+	return c
+}
+
+// do JSON 요청을 보내고 상태 코드 반환 (out이 있으면 응답 본문 디코딩)
+func (c *apiClient) do(method, path string, body, out any) int {
+	c.t.Helper()
+
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			c.t.Fatalf("%s %s: encode body: %v", method, path, err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequest(method, baseURL+path, reader)
+	if err != nil {
+		c.t.Fatalf("%s %s: %v", method, path, err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		c.t.Fatalf("%s %s: %v", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	data, _ := io.ReadAll(resp.Body)
+	if out != nil && len(data) > 0 {
+		if err := json.Unmarshal(data, out); err != nil {
+			c.t.Fatalf("%s %s: decode response %q: %v", method, path, data, err)
+		}
+	}
+	return resp.StatusCode
+}
+
+// mustDo do와 같지만 기대한 상태 코드가 아니면 테스트 실패
+func (c *apiClient) mustDo(method, path string, body any, want int, out any) {
+	c.t.Helper()
+
+	var raw json.RawMessage
+	if got := c.do(method, path, body, &raw); got != want {
+		c.t.Fatalf("%s %s: status %d, want %d (body %s)", method, path, got, want, raw)
+	}
+	if out != nil {
+		if err := json.Unmarshal(raw, out); err != nil {
+			c.t.Fatalf("%s %s: decode response %s: %v", method, path, raw, err)
+		}
+	}
+}
+
+// dial 액세스 토큰을 Authorization 헤더로 실어 WebSocket 연결
+func (c *apiClient) dial(path string, query url.Values) *wsclient.Conn {
+	c.t.Helper()
+
+	target := "ws" + strings.TrimPrefix(baseURL, "http") + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+c.accessToken)
+
+	conn, resp, err := wsclient.DefaultDialer.Dial(target, header)
+	if err != nil {
+		status := ""
+		if resp != nil {
+			status = resp.Status
+		}
+		c.t.Fatalf("dial %s: %v %s", path, err, status)
+	}
+	c.t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// readUntil match가 true를 돌려주는 텍스트 메시지를 받을 때까지 읽기
+func readUntil(t *testing.T, conn *wsclient.Conn, timeout time.Duration, match func(data []byte) bool) []byte {
+	t.Helper()
+
+	conn.SetReadDeadline(time.Now().Add(timeout))
+	for {
+		messageType, data, err := conn.ReadMessage()
+		if err != nil {
+			t.Fatalf("no matching message within %s: %v", timeout, err)
+		}
+		if messageType == wsclient.TextMessage && match(data) {
+			return data
+		}
+	}
+}
@@ -0,0 +1,162 @@
+//go:build e2e
+
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+	"google.golang.org/grpc"
+
+	"realtime-backend/pb"
+)
+
+const (
+	dbUser      = "eum"
+	dbPassword  = "eum-e2e"
+	dbName      = "eum"
+	s3Bucket    = "eum-e2e"
+	s3AccessKey = "minioadmin"
+	s3SecretKey = "minioadmin"
+	s3Region    = "us-east-1"
+)
+
+// dependencies 테스트 동안 띄우는 외부 의존성 (컨테이너 + 모의 AI 서버)
+type dependencies struct {
+	containers []testcontainers.Container
+	ai         *grpc.Server
+
+	PostgresAddr string
+	RedisAddr    string
+	S3Endpoint   string
+	AIAddr       string
+}
+
+// start Postgres/Redis/MinIO 컨테이너와 모의 AI 서버 시작
+func (d *dependencies) start(ctx context.Context) error {
+	if err := dockerHealthy(ctx); err != nil {
+		return fmt.Errorf("docker is not available: %w", err)
+	}
+
+	var err error
+
+	d.PostgresAddr, err = d.run(ctx, testcontainers.ContainerRequest{
+		Image:        "postgres:16-alpine",
+		ExposedPorts: []string{"5432/tcp"},
+		Env: map[string]string{
+			"POSTGRES_USER":     dbUser,
+			"POSTGRES_PASSWORD": dbPassword,
+			"POSTGRES_DB":       dbName,
+		},
+		// 초기화 중 한 번 재시작하므로 두 번째 준비 로그까지 대기
+		WaitingFor: wait.ForLog("database system is ready to accept connections").
+			WithOccurrence(2).
+			WithStartupTimeout(time.Minute),
+	})
+	if err != nil {
+		return fmt.Errorf("postgres: %w", err)
+	}
+
+	d.RedisAddr, err = d.run(ctx, testcontainers.ContainerRequest{
+		Image:        "redis:7-alpine",
+		ExposedPorts: []string{"6379/tcp"},
+		WaitingFor:   wait.ForListeningPort("6379/tcp"),
+	})
+	if err != nil {
+		return fmt.Errorf("redis: %w", err)
+	}
+
+	minioAddr, err := d.run(ctx, testcontainers.ContainerRequest{
+		Image:        "minio/minio:latest",
+		ExposedPorts: []string{"9000/tcp"},
+		Cmd:          []string{"server", "/data"},
+		Env: map[string]string{
+			"MINIO_ROOT_USER":     s3AccessKey,
+			"MINIO_ROOT_PASSWORD": s3SecretKey,
+		},
+		WaitingFor: wait.ForHTTP("/minio/health/live").WithPort("9000/tcp"),
+	})
+	if err != nil {
+		return fmt.Errorf("minio: %w", err)
+	}
+	d.S3Endpoint = "http://" + minioAddr
+	if _, err := newS3Client(d.S3Endpoint).CreateBucket(ctx, &s3.CreateBucketInput{Bucket: aws.String(s3Bucket)}); err != nil {
+		return fmt.Errorf("create bucket: %w", err)
+	}
+
+	d.ai, d.AIAddr, err = startMockAI()
+	if err != nil {
+		return fmt.Errorf("mock ai: %w", err)
+	}
+	return nil
+}
+
+// run 컨테이너를 띄우고 노출 포트의 host:port 반환
+func (d *dependencies) run(ctx context.Context, req testcontainers.ContainerRequest) (string, error) {
+	c, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	})
+	if c != nil {
+		d.containers = append(d.containers, c)
+	}
+	if err != nil {
+		return "", err
+	}
+	return c.Endpoint(ctx, "")
+}
+
+// Close 모의 AI 서버와 컨테이너 정리
+func (d *dependencies) Close() {
+	if d.ai != nil {
+		d.ai.Stop()
+	}
+	for _, c := range d.containers {
+		_ = testcontainers.TerminateContainer(c)
+	}
+}
+
+// dockerHealthy Docker 데몬 확인 (testcontainers는 데몬이 없으면 패닉하므로 미리 확인)
+func dockerHealthy(ctx context.Context) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+
+	provider, err := testcontainers.NewDockerProvider()
+	if err != nil {
+		return err
+	}
+	defer provider.Close()
+	return provider.Health(ctx)
+}
+
+// newS3Client MinIO 확인용 S3 클라이언트
+func newS3Client(endpoint string) *s3.Client {
+	return s3.New(s3.Options{
+		Region:       s3Region,
+		BaseEndpoint: aws.String(endpoint),
+		UsePathStyle: true,
+		Credentials:  credentials.NewStaticCredentialsProvider(s3AccessKey, s3SecretKey, ""),
+	})
+}
+
+// startMockAI 로컬 포트에서 모의 ConversationService 시작
+func startMockAI() (*grpc.Server, string, error) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, "", err
+	}
+	srv := grpc.NewServer()
+	pb.RegisterConversationServiceServer(srv, mockAIServer{})
+	go srv.Serve(lis)
+	return srv, lis.Addr().String(), nil
+}
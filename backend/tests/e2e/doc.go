@@ -0,0 +1,7 @@
+// Package e2e 실제 server.New 스택을 Postgres/Redis/MinIO 컨테이너와 모의 AI gRPC 서버에 붙여
+// 인증, 워크스페이스 생성, 채팅 WebSocket, Room 자막 브로드캐스트, 파일 업로드 흐름을 확인하는 통합 테스트
+//
+// Docker가 필요하므로 e2e 빌드 태그를 붙였을 때만 빌드/실행 (일반 go test ./... 에는 영향 없음)
+//
+//	go test -tags e2e -count=1 ./tests/e2e/...
+package e2e
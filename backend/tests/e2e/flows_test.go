@@ -0,0 +1,234 @@
+//go:build e2e
+
+package e2e
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	wsclient "github.com/fasthttp/websocket"
+	"github.com/google/uuid"
+
+	"realtime-backend/internal/database"
+	"realtime-backend/internal/model"
+)
+
+// createWorkspace 워크스페이스 생성 후 ID 반환
+func createWorkspace(t *testing.T, c *apiClient) int64 {
+	t.Helper()
+
+	var ws struct {
+		ID int64 `json:"id"`
+	}
+	c.mustDo(http.MethodPost, "/api/workspaces", map[string]string{"name": "e2e workspace"}, http.StatusCreated, &ws)
+	if ws.ID == 0 {
+		t.Fatalf("workspace response has no id")
+	}
+	return ws.ID
+}
+
+func TestAuthFlow(t *testing.T) {
+	c := newUser(t)
+
+	var me struct {
+		ID    int64  `json:"id"`
+		Email string `json:"email"`
+	}
+	c.mustDo(http.MethodGet, "/auth/me", nil, http.StatusOK, &me)
+	if me.ID != c.UserID || me.Email != c.Email {
+		t.Fatalf("/auth/me = %+v, want id=%d email=%s", me, c.UserID, c.Email)
+	}
+
+	// 리프레시 토큰 회전 후에도 새 쿠키로 인증 유지
+	c.mustDo(http.MethodPost, "/auth/refresh", nil, http.StatusOK, nil)
+	c.mustDo(http.MethodGet, "/auth/me", nil, http.StatusOK, nil)
+
+	anonymous := &apiClient{t: t, http: &http.Client{Timeout: 10 * time.Second}}
+	if got := anonymous.do(http.MethodGet, "/auth/me", nil, nil); got != http.StatusUnauthorized {
+		t.Fatalf("/auth/me without session: status %d, want 401", got)
+	}
+}
+
+func TestWorkspaceCreation(t *testing.T) {
+	owner := newUser(t)
+	workspaceID := createWorkspace(t, owner)
+
+	var ws struct {
+		ID   int64  `json:"id"`
+		Name string `json:"name"`
+	}
+	owner.mustDo(http.MethodGet, fmt.Sprintf("/api/workspaces/%d", workspaceID), nil, http.StatusOK, &ws)
+	if ws.ID != workspaceID || ws.Name != "e2e workspace" {
+		t.Fatalf("workspace = %+v, want id=%d", ws, workspaceID)
+	}
+
+	// 멤버가 아닌 사용자는 조회 불가
+	outsider := newUser(t)
+	if got := outsider.do(http.MethodGet, fmt.Sprintf("/api/workspaces/%d", workspaceID), nil, nil); got < 400 {
+		t.Fatalf("outsider could read the workspace (status %d)", got)
+	}
+}
+
+func TestChatWebSocket(t *testing.T) {
+	owner := newUser(t)
+	workspaceID := createWorkspace(t, owner)
+
+	var room struct {
+		ID int64 `json:"id"`
+	}
+	owner.mustDo(http.MethodPost, fmt.Sprintf("/api/workspaces/%d/chatrooms", workspaceID),
+		map[string]string{"title": "e2e room"}, http.StatusCreated, &room)
+
+	path := fmt.Sprintf("/ws/chat/%d/%d", workspaceID, room.ID)
+	sender := owner.dial(path, nil)
+	receiver := owner.dial(path, nil)
+
+	// 두 번째 연결이 방에 등록될 시간을 준 뒤 전송
+	time.Sleep(200 * time.Millisecond)
+	if err := sender.WriteJSON(map[string]any{
+		"type":    "message",
+		"payload": map[string]string{"message": "hello chat"},
+	}); err != nil {
+		t.Fatalf("send chat message: %v", err)
+	}
+
+	for _, conn := range []*wsclient.Conn{sender, receiver} {
+		readUntil(t, conn, 5*time.Second, func(data []byte) bool {
+			var msg struct {
+				Type    string `json:"type"`
+				Payload struct {
+					Message  string `json:"message"`
+					SenderID int64  `json:"sender_id"`
+				} `json:"payload"`
+			}
+			return json.Unmarshal(data, &msg) == nil && msg.Type == "message" &&
+				msg.Payload.Message == "hello chat" && msg.Payload.SenderID == owner.UserID
+		})
+	}
+
+	var count int64
+	database.DB.Model(&model.ChatLog{}).Where("meeting_id = ? AND message = ?", room.ID, "hello chat").Count(&count)
+	if count != 1 {
+		t.Fatalf("chat log rows = %d, want 1", count)
+	}
+}
+
+func TestRoomTranscriptBroadcast(t *testing.T) {
+	c := newUser(t)
+
+	roomID := "e2e-" + uuid.NewString()
+	speakerID := uuid.NewString() // 36 bytes, 바이너리 프레임 헤더 규격
+	conn := c.dial("/ws/room", url.Values{
+		"roomId":     {roomID},
+		"listenerId": {"e2e-listener"},
+		"targetLang": {"en"},
+	})
+
+	readUntil(t, conn, 10*time.Second, func(data []byte) bool {
+		var ready struct {
+			Status string `json:"status"`
+		}
+		return json.Unmarshal(data, &ready) == nil && ready.Status == "ready"
+	})
+
+	frame := append([]byte(speakerID+"en"), audioSample()...)
+	if err := conn.WriteMessage(wsclient.BinaryMessage, frame); err != nil {
+		t.Fatalf("send audio frame: %v", err)
+	}
+
+	readUntil(t, conn, 15*time.Second, func(data []byte) bool {
+		var msg struct {
+			Type      string `json:"type"`
+			SpeakerID string `json:"speakerId"`
+			Data      struct {
+				Original string `json:"original"`
+			} `json:"data"`
+		}
+		return json.Unmarshal(data, &msg) == nil && msg.Type == "transcript" &&
+			msg.SpeakerID == speakerID && msg.Data.Original == mockTranscript
+	})
+}
+
+func TestFilePresignAndConfirm(t *testing.T) {
+	c := newUser(t)
+	workspaceID := createWorkspace(t, c)
+	content := []byte("hello from the e2e suite")
+
+	var presigned struct {
+		UploadURL string `json:"upload_url"`
+		Key       string `json:"key"`
+	}
+	c.mustDo(http.MethodPost, fmt.Sprintf("/api/workspaces/%d/files/presign", workspaceID), map[string]any{
+		"file_name":    "hello.txt",
+		"content_type": "text/plain",
+		"file_size":    len(content),
+	}, http.StatusOK, &presigned)
+	if presigned.UploadURL == "" || presigned.Key == "" {
+		t.Fatalf("presign response missing url or key: %+v", presigned)
+	}
+
+	req, err := http.NewRequest(http.MethodPut, presigned.UploadURL, bytes.NewReader(content))
+	if err != nil {
+		t.Fatalf("build upload request: %v", err)
+	}
+	req.Header.Set("Content-Type", "text/plain")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("upload to presigned url: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("upload to presigned url: status %d", resp.StatusCode)
+	}
+
+	var file struct {
+		ID      int64  `json:"id"`
+		Name    string `json:"name"`
+		S3Key   string `json:"s3_key"`
+		FileURL string `json:"file_url"`
+	}
+	c.mustDo(http.MethodPost, fmt.Sprintf("/api/workspaces/%d/files/confirm", workspaceID), map[string]any{
+		"name":      "hello.txt",
+		"key":       presigned.Key,
+		"file_size": len(content),
+		"mime_type": "text/plain",
+	}, http.StatusCreated, &file)
+	if file.ID == 0 || file.S3Key != presigned.Key || !strings.HasPrefix(file.FileURL, deps.S3Endpoint) {
+		t.Fatalf("confirm response = %+v, want key %s under %s", file, presigned.Key, deps.S3Endpoint)
+	}
+
+	head, err := newS3Client(deps.S3Endpoint).HeadObject(context.Background(), &s3.HeadObjectInput{
+		Bucket: aws.String(s3Bucket),
+		Key:    aws.String(presigned.Key),
+	})
+	if err != nil {
+		t.Fatalf("uploaded object not found in bucket: %v", err)
+	}
+	if aws.ToInt64(head.ContentLength) != int64(len(content)) {
+		t.Fatalf("object size = %d, want %d", aws.ToInt64(head.ContentLength), len(content))
+	}
+}
+
+// audioSample 합성 오디오 (16kHz mono PCM16, 440Hz 사인파 200ms)
+func audioSample() []byte {
+	const sampleRate = 16000
+	const samples = sampleRate / 5
+
+	buf := make([]byte, samples*2)
+	for i := 0; i < samples; i++ {
+		v := int16(math.Sin(2*math.Pi*440*float64(i)/sampleRate) * 8000)
+		binary.LittleEndian.PutUint16(buf[i*2:], uint16(v))
+	}
+	return buf
+}
@@ -0,0 +1,134 @@
+//go:build e2e
+
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"realtime-backend/internal/config"
+	"realtime-backend/internal/database"
+	"realtime-backend/internal/server"
+)
+
+// baseURL 테스트 대상 서버 주소 (http://127.0.0.1:port)
+var baseURL string
+
+// deps TestMain에서 띄운 외부 의존성
+var deps = &dependencies{}
+
+func TestMain(m *testing.M) {
+	os.Exit(run(m))
+}
+
+// run 의존성과 서버를 띄우고 테스트 실행 (defer로 정리하기 위해 TestMain과 분리)
+func run(m *testing.M) int {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	defer deps.Close()
+	if err := deps.start(ctx); err != nil {
+		log.Printf("❌ e2e dependencies failed to start: %v", err)
+		return 1
+	}
+
+	port, err := freePort()
+	if err != nil {
+		log.Printf("❌ e2e could not reserve a port: %v", err)
+		return 1
+	}
+	setEnv(port)
+
+	cfg := config.Load()
+	db, err := database.ConnectDB()
+	if err != nil {
+		log.Printf("❌ e2e database connection failed: %v", err)
+		return 1
+	}
+	defer database.Close()
+
+	srv := server.New(cfg, db)
+	srv.SetupMiddleware()
+	srv.SetupRoutes()
+	go func() {
+		if err := srv.Start(); err != nil {
+			log.Printf("⚠️ e2e server stopped: %v", err)
+		}
+	}()
+	defer srv.Shutdown()
+
+	baseURL = "http://127.0.0.1:" + port
+	if err := waitForServer(ctx, baseURL+"/health/live"); err != nil {
+		log.Printf("❌ e2e server did not become live: %v", err)
+		return 1
+	}
+
+	return m.Run()
+}
+
+// setEnv 컨테이너 주소로 서버 설정 환경 변수 구성
+func setEnv(port string) {
+	pgHost, pgPort, _ := net.SplitHostPort(deps.PostgresAddr)
+
+	env := map[string]string{
+		"JWT_SECRET":             "e2e-secret-0123456789abcdef0123456789abcdef",
+		"PORT":                   ":" + port,
+		"DB_HOST":                pgHost,
+		"DB_PORT":                pgPort,
+		"DB_USER":                dbUser,
+		"DB_PASSWORD":            dbPassword,
+		"DB_NAME":                dbName,
+		"DB_SSLMODE":             "disable",
+		"REDIS_ENABLED":          "true",
+		"REDIS_ADDR":             deps.RedisAddr,
+		"AI_ENABLED":             "true",
+		"AI_USE_AWS":             "false",
+		"AI_SERVER_ADDR":         deps.AIAddr,
+		"ROOM_IDENTITY_REQUIRED": "false",
+		"AWS_REGION":             s3Region,
+		"AWS_S3_BUCKET":          s3Bucket,
+		"AWS_S3_ENDPOINT":        deps.S3Endpoint,
+		"AWS_ACCESS_KEY_ID":      s3AccessKey,
+		"AWS_SECRET_ACCESS_KEY":  s3SecretKey,
+	}
+	for k, v := range env {
+		os.Setenv(k, v)
+	}
+}
+
+// freePort 사용 가능한 로컬 포트 번호
+func freePort() (string, error) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", err
+	}
+	defer lis.Close()
+	_, port, err := net.SplitHostPort(lis.Addr().String())
+	return port, err
+}
+
+// waitForServer liveness 엔드포인트가 200을 줄 때까지 대기
+func waitForServer(ctx context.Context, url string) error {
+	for {
+		resp, err := http.Get(url)
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode == http.StatusOK {
+				return nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%s: %w", strings.TrimPrefix(url, baseURL), ctx.Err())
+		case <-time.After(200 * time.Millisecond):
+		}
+	}
+}
@@ -0,0 +1,62 @@
+//go:build e2e
+
+package e2e
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+
+	"realtime-backend/pb"
+)
+
+// mockTranscript 모의 AI 서버가 모든 오디오 청크에 돌려주는 자막
+const mockTranscript = "hello from the e2e suite"
+
+// mockAIServer Python AI 서버 대신 오디오 청크마다 확정 자막 하나를 돌려주는 gRPC 서버
+type mockAIServer struct {
+	pb.UnimplementedConversationServiceServer
+}
+
+// StreamChat 오디오 청크를 받으면 해당 발화자의 확정 자막 전송 (세션 초기화/종료는 무시)
+func (mockAIServer) StreamChat(stream pb.ConversationService_StreamChatServer) error {
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if _, ok := req.Payload.(*pb.ChatRequest_AudioChunk); !ok {
+			continue
+		}
+
+		err = stream.Send(&pb.ChatResponse{
+			SessionId: req.SessionId,
+			RoomId:    req.RoomId,
+			Payload: &pb.ChatResponse_Transcript{
+				Transcript: &pb.TranscriptResult{
+					Id:               uuid.NewString(),
+					Speaker:          &pb.SpeakerInfo{ParticipantId: req.ParticipantId},
+					OriginalText:     mockTranscript,
+					OriginalLanguage: "en",
+					IsFinal:          true,
+					TimestampMs:      uint64(time.Now().UnixMilli()),
+					Confidence:       1,
+				},
+			},
+		})
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// UpdateParticipantSettings 설정 변경은 항상 성공으로 응답
+func (mockAIServer) UpdateParticipantSettings(context.Context, *pb.ParticipantSettingsRequest) (*pb.ParticipantSettingsResponse, error) {
+	return &pb.ParticipantSettingsResponse{Success: true}, nil
+}
@@ -239,6 +239,7 @@ export default function ChatPanel({ roomId, onClose, onNewMessage, voiceRecords
                 headers: { 'Content-Type': 'application/json' },
                 credentials: 'include',
                 body: JSON.stringify({
+                    room: roomId,
                     question,
                     options,
                     duration,